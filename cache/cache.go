@@ -0,0 +1,141 @@
+// Package cache 記錄跨執行的文章爬取時間（文章 URL → 最後爬取時間），
+// 讓之後的執行在有效期限（TTL）內略過最近爬過的文章。
+// 與 checkpoint 不同：checkpoint 只用於接續單次中斷的爬取，cache 則依時間判斷是否需要重新爬取。
+package cache
+
+import (
+	"encoding/json"
+	stderrors "errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/twtrubiks/ptt-spider-go/constants"
+	"github.com/twtrubiks/ptt-spider-go/internal/fileutil"
+)
+
+// file 是快取檔的 JSON 結構
+type file struct {
+	Crawled map[string]time.Time `json:"crawled"` // 文章 URL → 最後爬取時間
+}
+
+// Cache 是持久化為 JSON 檔的文章爬取時間快取，實作 interfaces.CacheManager，可供多個 goroutine 並行使用。
+type Cache struct {
+	path string
+
+	mu      sync.Mutex
+	crawled map[string]time.Time
+	dirty   bool
+}
+
+// Open 載入快取檔，檔案不存在時回傳空快取
+func Open(path string) (*Cache, error) {
+	c := &Cache{path: path, crawled: make(map[string]time.Time)}
+
+	data, err := os.ReadFile(path)
+	if stderrors.Is(err, fs.ErrNotExist) {
+		return c, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("讀取文章快取失敗: %w", err)
+	}
+	var f file
+	if err := json.Unmarshal(data, &f); err != nil {
+		return nil, fmt.Errorf("解析文章快取失敗: %s: %w", path, err)
+	}
+	if f.Crawled != nil {
+		c.crawled = f.Crawled
+	}
+	return c, nil
+}
+
+// Path 回傳快取檔路徑
+func (c *Cache) Path() string {
+	return c.path
+}
+
+// Get 回傳文章最後一次爬取的時間，未記錄時回傳 false
+func (c *Cache) Get(articleURL string) (time.Time, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	t, ok := c.crawled[articleURL]
+	return t, ok
+}
+
+// Set 記錄文章在 crawledAt 爬取完成，覆寫先前的紀錄
+func (c *Cache) Set(articleURL string, crawledAt time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.crawled[articleURL] = crawledAt
+	c.dirty = true
+}
+
+// Delete 移除文章的紀錄，下次執行會重新爬取
+func (c *Cache) Delete(articleURL string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if _, ok := c.crawled[articleURL]; ok {
+		delete(c.crawled, articleURL)
+		c.dirty = true
+	}
+}
+
+// Clear 移除所有紀錄
+func (c *Cache) Clear() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if len(c.crawled) > 0 {
+		c.crawled = make(map[string]time.Time)
+		c.dirty = true
+	}
+}
+
+// Prune 移除 before 之前爬取的紀錄並回傳移除數，避免過期的紀錄讓快取檔無限增長。
+// 過期的文章本來就會重新爬取，移除後行為不變；移除的結果在下次 Save 時寫回。
+func (c *Cache) Prune(before time.Time) int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	removed := 0
+	for articleURL, crawledAt := range c.crawled {
+		if crawledAt.Before(before) {
+			delete(c.crawled, articleURL)
+			removed++
+		}
+	}
+	if removed > 0 {
+		c.dirty = true
+	}
+	return removed
+}
+
+// Len 回傳已記錄的文章數
+func (c *Cache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.crawled)
+}
+
+// Save 將快取寫回磁碟（暫存檔 + rename 原子寫入），沒有變更時不做任何事
+func (c *Cache) Save() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if !c.dirty {
+		return nil
+	}
+
+	data, err := json.MarshalIndent(file{Crawled: c.crawled}, "", "  ")
+	if err != nil {
+		return fmt.Errorf("序列化文章快取失敗: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(c.path), constants.DirPermission); err != nil {
+		return fmt.Errorf("建立文章快取目錄失敗: %w", err)
+	}
+	if err := fileutil.WriteFileAtomic(c.path, data); err != nil {
+		return fmt.Errorf("寫入文章快取失敗: %w", err)
+	}
+	c.dirty = false
+	return nil
+}
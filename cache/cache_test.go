@@ -0,0 +1,122 @@
+package cache
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/twtrubiks/ptt-spider-go/interfaces"
+)
+
+var _ interfaces.CacheManager = (*Cache)(nil)
+
+func TestOpen_MissingFile(t *testing.T) {
+	c, err := Open(filepath.Join(t.TempDir(), "cache.json"))
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	if c.Len() != 0 {
+		t.Errorf("檔案不存在時應為空快取，got %d", c.Len())
+	}
+}
+
+func TestCache_SaveAndOpen(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "state", "cache.json")
+	const a, b = "https://www.ptt.cc/bbs/Beauty/M.1.A.html", "https://www.ptt.cc/bbs/Beauty/M.2.A.html"
+	crawledAt := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+
+	c, err := Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c.Set(a, crawledAt)
+	c.Set(b, crawledAt)
+	c.Delete(b)
+	if err := c.Save(); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	loaded, err := Open(path)
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	if got, ok := loaded.Get(a); !ok || !got.Equal(crawledAt) {
+		t.Errorf("Get(a) = %v, %v, want %v, true", got, ok, crawledAt)
+	}
+	if _, ok := loaded.Get(b); ok {
+		t.Error("已 Delete 的文章不應存在")
+	}
+
+	loaded.Clear()
+	if err := loaded.Save(); err != nil {
+		t.Fatal(err)
+	}
+	if reopened, err := Open(path); err != nil || reopened.Len() != 0 {
+		t.Errorf("Clear 後重新開啟 Len = %d, err = %v, want 0", reopened.Len(), err)
+	}
+}
+
+func TestCache_Prune(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cache.json")
+	const old, fresh = "https://www.ptt.cc/bbs/Beauty/M.1.A.html", "https://www.ptt.cc/bbs/Beauty/M.2.A.html"
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+
+	c, err := Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c.Set(old, now.Add(-48*time.Hour))
+	c.Set(fresh, now.Add(-time.Hour))
+	if err := c.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	loaded, err := Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n := loaded.Prune(now.Add(-24 * time.Hour)); n != 1 {
+		t.Errorf("Prune() = %d, want 1", n)
+	}
+	if err := loaded.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	reopened, err := Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := reopened.Get(old); ok {
+		t.Error("過期的紀錄應在 Save 後從快取檔移除")
+	}
+	if _, ok := reopened.Get(fresh); !ok {
+		t.Error("有效期限內的紀錄應保留")
+	}
+}
+
+func TestCache_SaveWithoutChanges(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cache.json")
+	c, err := Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c.Delete("https://www.ptt.cc/bbs/Beauty/M.1.A.html")
+	c.Clear()
+	if err := c.Save(); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Errorf("沒有變更時不應寫檔, err = %v", err)
+	}
+}
+
+func TestOpen_Corrupted(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cache.json")
+	if err := os.WriteFile(path, []byte("{not json"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Open(path); err == nil {
+		t.Error("損毀的快取檔應回傳錯誤")
+	}
+}
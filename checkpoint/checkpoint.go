@@ -0,0 +1,110 @@
+// Package checkpoint 記錄已完整處理的文章（文章 URL → 完成時間），
+// 讓中斷的爬取任務重新執行時可略過已完成的文章，從中斷處繼續。
+package checkpoint
+
+import (
+	"encoding/json"
+	stderrors "errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/twtrubiks/ptt-spider-go/constants"
+	"github.com/twtrubiks/ptt-spider-go/internal/fileutil"
+)
+
+// file 是 checkpoint 檔的 JSON 結構
+type file struct {
+	Done map[string]time.Time `json:"done"` // 已完成的文章 URL → 完成時間
+}
+
+// Checkpoint 是持久化為 JSON 檔的爬取進度，可供多個 goroutine 並行使用。
+type Checkpoint struct {
+	path string
+
+	mu    sync.Mutex
+	done  map[string]time.Time
+	dirty bool
+}
+
+// New 建立空的 checkpoint，Save 時寫入 path（覆寫既有檔案）
+func New(path string) *Checkpoint {
+	return &Checkpoint{path: path, done: make(map[string]time.Time)}
+}
+
+// Load 載入 checkpoint 檔，檔案不存在時回傳空的 checkpoint
+func Load(path string) (*Checkpoint, error) {
+	cp := New(path)
+
+	data, err := os.ReadFile(path)
+	if stderrors.Is(err, fs.ErrNotExist) {
+		return cp, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("讀取 checkpoint 失敗: %w", err)
+	}
+	var f file
+	if err := json.Unmarshal(data, &f); err != nil {
+		return nil, fmt.Errorf("解析 checkpoint 失敗: %s: %w", path, err)
+	}
+	if f.Done != nil {
+		cp.done = f.Done
+	}
+	return cp, nil
+}
+
+// Path 回傳 checkpoint 檔路徑
+func (cp *Checkpoint) Path() string {
+	return cp.path
+}
+
+// IsDone 回報文章是否已完整處理過
+func (cp *Checkpoint) IsDone(articleURL string) bool {
+	cp.mu.Lock()
+	defer cp.mu.Unlock()
+	_, ok := cp.done[articleURL]
+	return ok
+}
+
+// MarkDone 將文章標記為已完整處理
+func (cp *Checkpoint) MarkDone(articleURL string) {
+	cp.mu.Lock()
+	defer cp.mu.Unlock()
+	if _, ok := cp.done[articleURL]; ok {
+		return
+	}
+	cp.done[articleURL] = time.Now()
+	cp.dirty = true
+}
+
+// Len 回傳已完成的文章數
+func (cp *Checkpoint) Len() int {
+	cp.mu.Lock()
+	defer cp.mu.Unlock()
+	return len(cp.done)
+}
+
+// Save 將 checkpoint 寫回磁碟（暫存檔 + rename 原子寫入），沒有變更時不做任何事
+func (cp *Checkpoint) Save() error {
+	cp.mu.Lock()
+	defer cp.mu.Unlock()
+	if !cp.dirty {
+		return nil
+	}
+
+	data, err := json.MarshalIndent(file{Done: cp.done}, "", "  ")
+	if err != nil {
+		return fmt.Errorf("序列化 checkpoint 失敗: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(cp.path), constants.DirPermission); err != nil {
+		return fmt.Errorf("建立 checkpoint 目錄失敗: %w", err)
+	}
+	if err := fileutil.WriteFileAtomic(cp.path, data); err != nil {
+		return fmt.Errorf("寫入 checkpoint 失敗: %w", err)
+	}
+	cp.dirty = false
+	return nil
+}
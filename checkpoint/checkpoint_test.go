@@ -0,0 +1,64 @@
+package checkpoint
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLoad_MissingFile(t *testing.T) {
+	cp, err := Load(filepath.Join(t.TempDir(), "checkpoint.json"))
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if cp.Len() != 0 {
+		t.Errorf("檔案不存在時應為空的 checkpoint，got %d", cp.Len())
+	}
+}
+
+func TestCheckpoint_SaveAndLoad(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "state", "checkpoint.json")
+	const a, b = "https://www.ptt.cc/bbs/Beauty/M.1.A.html", "https://www.ptt.cc/bbs/Beauty/M.2.A.html"
+
+	cp := New(path)
+	cp.MarkDone(a)
+	cp.MarkDone(a)
+	if err := cp.Save(); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	loaded, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if !loaded.IsDone(a) || loaded.IsDone(b) || loaded.Len() != 1 {
+		t.Errorf("載入後 IsDone(a)=%v IsDone(b)=%v Len=%d, want true false 1", loaded.IsDone(a), loaded.IsDone(b), loaded.Len())
+	}
+
+	// 原子寫入不應在目錄中留下暫存檔
+	entries, _ := os.ReadDir(filepath.Dir(path))
+	if len(entries) != 1 {
+		t.Errorf("checkpoint 目錄應只有一個檔案，got %d", len(entries))
+	}
+}
+
+func TestCheckpoint_SaveWithoutChanges(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "checkpoint.json")
+	if err := New(path).Save(); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Errorf("沒有變更時不應寫檔, err = %v", err)
+	}
+}
+
+func TestLoad_Corrupted(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "checkpoint.json")
+	if err := os.WriteFile(path, []byte("{not json"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Load(path); err == nil {
+		t.Error("毀損的 checkpoint 應回傳錯誤")
+	}
+}
@@ -0,0 +1,78 @@
+package config
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/twtrubiks/ptt-spider-go/errors"
+)
+
+// ByteSize 是以 bytes 為單位的大小設定。
+// 配置檔中可寫成整數（bytes）或帶單位的字串，如 "50MB"、"64 KB"、"1.5GB"。
+type ByteSize int64
+
+// byteUnits 是可用的大小單位（不分大小寫），KB/MB/GB 與 KiB/MiB/GiB 同樣以 1024 進位
+var byteUnits = map[string]int64{
+	"":    1,
+	"b":   1,
+	"k":   1 << 10,
+	"kb":  1 << 10,
+	"kib": 1 << 10,
+	"m":   1 << 20,
+	"mb":  1 << 20,
+	"mib": 1 << 20,
+	"g":   1 << 30,
+	"gb":  1 << 30,
+	"gib": 1 << 30,
+}
+
+// ParseByteSize 將 "50MB"、"512 KiB"、"1048576" 等寫法解析為 bytes，
+// 數值可含小數（不足 1 byte 的部分捨去），無法解析時回傳 ConfigError
+func ParseByteSize(s string) (ByteSize, error) {
+	trimmed := strings.TrimSpace(s)
+	// 數值與單位的分界：第一個不屬於數字、小數點或正負號的字元
+	split := strings.IndexFunc(trimmed, func(r rune) bool {
+		return (r < '0' || r > '9') && r != '.' && r != '-' && r != '+'
+	})
+	if split < 0 {
+		split = len(trimmed)
+	}
+	number, unit := trimmed[:split], strings.ToLower(strings.TrimSpace(trimmed[split:]))
+
+	multiplier, ok := byteUnits[unit]
+	if !ok {
+		return 0, errors.NewConfigError(fmt.Sprintf("無法解析大小 %q：未知的單位 %q（可用單位: B、KB、MB、GB）", s, trimmed[split:]), nil)
+	}
+	if n, err := strconv.ParseInt(number, 10, 64); err == nil {
+		if n > math.MaxInt64/multiplier || n < math.MinInt64/multiplier {
+			return 0, errors.NewConfigError(fmt.Sprintf("大小 %q 超出範圍", s), nil)
+		}
+		return ByteSize(n * multiplier), nil
+	}
+	f, err := strconv.ParseFloat(number, 64)
+	if err != nil {
+		return 0, errors.NewConfigError(fmt.Sprintf("無法解析大小 %q", s), err)
+	}
+	bytes := f * float64(multiplier)
+	if math.IsNaN(bytes) || bytes >= math.MaxInt64 || bytes <= math.MinInt64 {
+		return 0, errors.NewConfigError(fmt.Sprintf("大小 %q 超出範圍", s), nil)
+	}
+	return ByteSize(bytes), nil
+}
+
+// UnmarshalYAML 接受整數或帶單位的字串
+func (b *ByteSize) UnmarshalYAML(value *yaml.Node) error {
+	if value.Kind != yaml.ScalarNode {
+		return errors.NewConfigError(fmt.Sprintf("第 %d 行：大小必須是整數或帶單位的字串（如 \"50MB\"）", value.Line), nil)
+	}
+	size, err := ParseByteSize(value.Value)
+	if err != nil {
+		return fmt.Errorf("第 %d 行：%w", value.Line, err)
+	}
+	*b = size
+	return nil
+}
@@ -0,0 +1,93 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/twtrubiks/ptt-spider-go/errors"
+)
+
+func TestParseByteSize(t *testing.T) {
+	tests := []struct {
+		input string
+		want  ByteSize
+	}{
+		{"0", 0},
+		{"1048576", 1048576},
+		{"512B", 512},
+		{"64KB", 64 << 10},
+		{"64 kb", 64 << 10},
+		{"64KiB", 64 << 10},
+		{"64K", 64 << 10},
+		{"50MB", 50 << 20},
+		{" 50 MiB ", 50 << 20},
+		{"1.5MB", 3 << 19},
+		{"2GB", 2 << 30},
+		{"2gib", 2 << 30},
+		{"-1MB", -1 << 20}, // 負數交由 validateAndFix 退回預設
+	}
+	for _, tt := range tests {
+		got, err := ParseByteSize(tt.input)
+		if err != nil {
+			t.Errorf("ParseByteSize(%q) error = %v", tt.input, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ParseByteSize(%q) = %d, want %d", tt.input, got, tt.want)
+		}
+	}
+}
+
+func TestParseByteSize_Invalid(t *testing.T) {
+	for _, input := range []string{"", "MB", "50TB", "50 MBs", "1.2.3KB", "abc", "9999999999GB"} {
+		_, err := ParseByteSize(input)
+		if err == nil {
+			t.Errorf("ParseByteSize(%q) 應回傳錯誤", input)
+			continue
+		}
+		if !errors.IsConfigError(err) {
+			t.Errorf("ParseByteSize(%q) error = %v, want ConfigError", input, err)
+		}
+	}
+}
+
+func TestLoad_ByteSizeUnits(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "sizes.yaml")
+	content := `crawler:
+  maxImageBytes: "20MB"
+  writeBufferBytes: 32KB
+  headPrecheck:
+    minBytes: 4096
+`
+	if err := os.WriteFile(configPath, []byte(content), 0644); err != nil {
+		t.Fatalf("建立測試配置檔失敗: %v", err)
+	}
+
+	cfg, err := Load(configPath)
+	if err != nil {
+		t.Fatalf("Load() unexpected error = %v", err)
+	}
+	if cfg.Crawler.MaxImageBytes != 20<<20 {
+		t.Errorf("maxImageBytes = %d, want %d", cfg.Crawler.MaxImageBytes, 20<<20)
+	}
+	if cfg.Crawler.WriteBufferBytes != 32<<10 {
+		t.Errorf("writeBufferBytes = %d, want %d", cfg.Crawler.WriteBufferBytes, 32<<10)
+	}
+	if cfg.Crawler.HeadPrecheck.MinBytes != 4096 {
+		t.Errorf("headPrecheck.minBytes = %d, want 4096", cfg.Crawler.HeadPrecheck.MinBytes)
+	}
+}
+
+func TestLoad_ByteSizeInvalid(t *testing.T) {
+	for _, value := range []string{`"50 potatoes"`, "[1, 2]"} {
+		configPath := filepath.Join(t.TempDir(), "sizes.yaml")
+		if err := os.WriteFile(configPath, []byte("crawler:\n  maxImageBytes: "+value+"\n"), 0644); err != nil {
+			t.Fatalf("建立測試配置檔失敗: %v", err)
+		}
+		_, err := Load(configPath)
+		if !errors.IsConfigError(err) {
+			t.Errorf("maxImageBytes: %s 時 Load() error = %v, want ConfigError", value, err)
+		}
+	}
+}
@@ -4,11 +4,14 @@ package config
 
 import (
 	"fmt"
-	"log"
+	"log/slog"
 	"os"
+	"path"
+	"regexp"
+	"strings"
 	"time"
 
-	yaml "gopkg.in/yaml.v3"
+	"github.com/twtrubiks/ptt-spider-go/constants"
 )
 
 // Config 整體配置結構，包含爬蟲的所有配置項目.
@@ -18,13 +21,182 @@ type Config struct {
 
 // CrawlerConfig 爬蟲核心配置，控制並行度、通道大小和網路設定.
 type CrawlerConfig struct {
-	Workers     int           `yaml:"workers"`     // 並行下載工作者數量
+	Workers     WorkersConfig `yaml:"workers"`     // 並行下載工作者數量（固定數量或 min/max 範圍）
 	ParserCount int           `yaml:"parserCount"` // 內容解析器數量
 	Channels    ChannelConfig `yaml:"channels"`    // 通道緩衝區配置
 	Delays      DelayConfig   `yaml:"delays"`      // 延遲設定
 	HTTP        HTTPConfig    `yaml:"http"`        // HTTP 客戶端配置
+
+	// MarkdownWorkers Markdown 產生工人數量（預設 1），輸出檔以 I/O 為主，解析量大時可調高避免成為瓶頸
+	MarkdownWorkers int `yaml:"markdownWorkers"`
+
+	// AutoScale 依 CPU 數、記憶體用量與觀測到的錯誤率自動調整下載工人數（pool 模式），啟用時取代 workers
+	AutoScale AutoScaleConfig `yaml:"autoScale"`
+
+	// RateLimit 全域請求速率限制，所有 worker 共用同一個 token bucket
+	RateLimit RateLimitConfig `yaml:"rateLimit"`
+
+	// Retry 請求重試策略，可依 host 覆寫全域設定
+	Retry RetryConfig `yaml:"retry"`
+
+	// ValidateImages 下載後寫檔前檢查 Content-Type 與檔頭 magic bytes，
+	// 非圖片內容（如圖床的「圖片已移除」HTML 頁）不寫入磁碟
+	ValidateImages bool `yaml:"validateImages"`
+
+	// StripExif 下載後將 JPEG/PNG 解碼再編碼以移除 EXIF（可能含 GPS 座標），
+	// 重新編碼 JPEG 會有輕微畫質損失，預設關閉
+	StripExif bool `yaml:"stripExif"`
+
+	// MaxImageBytes 單張圖片下載大小上限（bytes，可寫成 "50MB" 等帶單位的字串），0 表示不限制
+	MaxImageBytes ByteSize `yaml:"maxImageBytes"`
+
+	// HeadPrecheck 派發下載前先以 HEAD 並行取得文章所有圖片的大小，
+	// 超過 maxImageBytes 或小於 minBytes 的圖片不下載
+	HeadPrecheck HeadPrecheckConfig `yaml:"headPrecheck"`
+
+	// Hosts 依圖片 URL 的 host 限制下載的圖床：allow 非空時只下載相符的圖片，相符 deny 的一律不下載（優先於 allow）。
+	// 項目為完整 host 或萬用字元樣式（如 *.imgur.com），被過濾的圖片不會出現在 README.md 等輸出檔
+	Hosts HostFilterConfig `yaml:"hosts"`
+
+	// WriteBufferBytes 下載寫檔的緩衝大小（bytes，可帶單位），累積到此大小才寫入磁碟以減少系統呼叫；0 表示不緩衝
+	WriteBufferBytes ByteSize `yaml:"writeBufferBytes"`
+
+	// RespectRobots 啟用後依各站 robots.txt 略過被禁止的路徑（結果依 host 快取），預設關閉
+	RespectRobots bool `yaml:"respectRobots"`
+
+	// LogLevel 日誌等級（debug/info/warn/error），可由 -log-level 參數覆蓋
+	LogLevel string `yaml:"logLevel"`
+
+	// Filter 看板模式的文章過濾條件，與 -push 門檻同時生效
+	Filter FilterConfig `yaml:"filter"`
+
+	// DuplicateTitles 偵測同一次執行中標題相同或相似的文章（轉錄、重發），
+	// 在各看板目錄寫入 duplicates.json 標註重複群組
+	DuplicateTitles DuplicateTitlesConfig `yaml:"duplicateTitles"`
+
+	// Dedup 跨執行的圖片去重：記錄已下載的圖片 URL，後續執行命中時建立連結或略過
+	Dedup DedupConfig `yaml:"dedup"`
+
+	// Cache 跨執行的文章快取（-cache 啟用）：記錄文章的爬取時間，ttl 內爬過的文章不再處理
+	Cache CacheConfig `yaml:"cache"`
+
+	// Adaptive 依各 host 最近的 429 回應自動調整請求前的延遲（AIMD），與 delays 的隨機延遲取較大者
+	Adaptive AdaptiveConfig `yaml:"adaptive"`
+
+	// Alert 請求錯誤率告警，錯誤率飆高（可能被封鎖）時透過 Logger.Error 提醒
+	Alert AlertConfig `yaml:"alert"`
+
+	// Performance 效能監控：定期輸出記憶體與佇列統計，堆積記憶體超過門檻時觸發 runtime.GC
+	Performance PerformanceConfig `yaml:"performance"`
+
+	// Failures 圖片下載失敗的分類統計：結束時依原因（圖片已刪除、被限流、逾時等）輸出摘要，並可匯出明細
+	Failures FailuresConfig `yaml:"failures"`
+
+	// WorkerIdleWarn 下載 worker 等待任務超過此時間時以 debug 等級提示上游可能是瓶頸，"0" 表示停用
+	WorkerIdleWarn string `yaml:"workerIdleWarn"`
+
+	// ShutdownGrace 中斷後 Markdown 工人繼續處理已排入 channel 的任務的寬限期，
+	// 逾時仍未處理的任務放棄；"0" 表示中斷時立即結束（不處理剩餘任務）
+	ShutdownGrace string `yaml:"shutdownGrace"`
+
+	// ShutdownTimeout 中斷（Ctrl+C）後等待爬蟲收尾的時間上限，逾時以非零結束碼強制結束；
+	// 期間再按一次 Ctrl+C 同樣立即強制結束。"0" 表示不限時（仍可再按 Ctrl+C 強制結束）
+	ShutdownTimeout string `yaml:"shutdownTimeout"`
+
+	// ArticleTimeout 單篇文章頁從請求（含速率限制等待與重試）到解析完成的時間上限，
+	// 逾時只略過該篇文章，避免單一卡住的請求佔用內容解析器；"0" 表示停用（只受 http.timeout 限制）
+	ArticleTimeout string `yaml:"articleTimeout"`
+
+	// DownloadMode 圖片下載模式：pool（全域 worker pool 共用，預設）或
+	// article（以文章為單位批次下載，該篇圖片全部完成後才產生 Markdown）
+	DownloadMode string `yaml:"downloadMode"`
+
+	// ArticleConcurrency article 模式下單篇文章同時下載的圖片數上限，
+	// 全部文章合計仍受 workers 限制
+	ArticleConcurrency int `yaml:"articleConcurrency"`
+
+	// MaxConcurrentPerHost 同一個圖床（URL host）同時進行的圖片下載數上限，與 workers 無關；
+	// 0 表示不限制。比隨機延遲更直接地避免對單一 host 同時開太多連線而收到 429
+	MaxConcurrentPerHost int `yaml:"maxConcurrentPerHost"`
+
+	// AcceptFallbacks 圖片下載失敗（連線錯誤或非 200）時依序改用的 Accept 標頭，
+	// 部分圖床 CDN 會依 Accept 回應不同結果；空清單表示不嘗試。每次嘗試仍套用 429 退避重試與速率限制
+	AcceptFallbacks []string `yaml:"acceptFallbacks"`
+
+	// ImageExtensions 文章中視為圖片連結的副檔名（不分大小寫，可省略前導點），
+	// 未設定時使用內建清單 .jpg/.jpeg/.png/.gif/.webp/.bmp/.avif
+	ImageExtensions []string `yaml:"imageExtensions"`
+
+	// StrictImgur imgur 嚴格模式：沒有副檔名的 imgur 連結只在確定是單圖（如 imgur.com/AbC123x）時補上 .jpg，
+	// gallery、影片（.gifv、.mp4）與其他無法判斷的連結不再猜測為圖片，避免下載到錯誤內容；預設關閉
+	StrictImgur bool `yaml:"strictImgur"`
+
+	// ExpandImgurAlbums 將文章中的 imgur 相簿（/a/、/gallery/）連結展開為各張圖片下載，
+	// 每個相簿需多一次請求，預設關閉；展開失敗時略過該相簿
+	ExpandImgurAlbums bool `yaml:"expandImgurAlbums"`
+
+	// LinkFilter 略過文章中不屬於內文的連結（回文引用的原文、簽名檔）或符合樣式的連結，
+	// 這些連結不下載也不展開相簿；預設皆不過濾
+	LinkFilter LinkFilterConfig `yaml:"linkFilter"`
+
+	// SavePushes 保存文章的推文內容（類型、推文者、內容、時間）：off（預設）、
+	// markdown（在 README.md／index.html 加上「推文」段落）、json（另外輸出 pushes.json）或 both
+	SavePushes string `yaml:"savePushes"`
+
+	// Classify 下載完成後依圖片比例或尺寸將圖片移到文章目錄下的分類子目錄（如 landscape/），
+	// README.md 等輸出檔引用移動後的路徑；啟用時需等圖片下載完成才產生 Markdown，downloadMode 固定為 article
+	Classify ClassifyConfig `yaml:"classify"`
+
+	// Pack 圖片打包模式：off（預設，每張圖片一個檔案）或 tar（同一篇文章的圖片依序寫入文章目錄下的 images.tar，
+	// 減少大量小圖的建檔與關檔開銷）。啟用時 downloadMode 固定為 article，並同時輸出內嵌圖片、可直接檢視的 index.html；
+	// 不支援 classify、dedup 與 stripExif（皆需操作個別檔案），啟用時一併關閉
+	Pack string `yaml:"pack"`
+
+	// Seed 隨機來源（下載前延遲、User-Agent 隨機挑選）的種子，相同 seed 可重現相同的隨機行為；
+	// 0 表示未指定，以啟動時間產生（-seed 優先於此設定）
+	Seed int64 `yaml:"seed"`
+
+	// Logging 日誌檔輸出（終端輸出不受影響）
+	Logging LoggingConfig `yaml:"logging"`
+
+	// Output 每篇文章目錄的輸出檔（README.md、index.html、manifest.json）與格式
+	Output OutputConfig `yaml:"output"`
+
+	// Profiles 自訂爬取策略，以 -mode 參數套用；與內建策略（aggressive/normal/gentle）同名時逐欄覆寫內建值
+	Profiles map[string]ProfileConfig `yaml:"profiles"`
+}
+
+// LoggingConfig 日誌檔配置.
+type LoggingConfig struct {
+	File          string `yaml:"file"`          // 日誌檔路徑（附加寫入），空字串表示不寫檔
+	PerBoard      bool   `yaml:"perBoard"`      // 另外為每個看板寫一份日誌檔（如 crawl-Beauty.log），主日誌檔仍記錄全部訊息
+	MaxBoardFiles int    `yaml:"maxBoardFiles"` // 看板日誌檔數量上限，超過的看板只寫入主日誌檔
+}
+
+// OutputConfig 文章輸出檔配置.
+type OutputConfig struct {
+	Format       string `yaml:"format"`       // 輸出格式：markdown（README.md，預設）、html（index.html 圖片牆）或 both
+	NumberImages bool   `yaml:"numberImages"` // 每張圖片前加上「### 圖 N」小標題，預設關閉
+	Manifest     bool   `yaml:"manifest"`     // 在 README.md 旁另外輸出 manifest.json（文章資訊與圖片清單），預設關閉
+	FrontMatter  bool   `yaml:"frontMatter"`  // 在 README.md 開頭輸出 YAML front matter（供 Hugo、Jekyll 使用），預設關閉
+	Index        bool   `yaml:"index"`        // 爬取結束後在看板目錄輸出所有文章的總覽（index.md／index.html，依 format），預設關閉
+	Report       bool   `yaml:"report"`       // 爬取結束後在輸出根目錄產生執行報告 report.html（各看板統計、錯誤分類、最慢文章），預設關閉
+
+	// TemplatePath 自訂 README.md 的 Go text/template 模板檔，可使用 MarkdownInfo 的所有欄位；為空時使用內建格式
+	TemplatePath string `yaml:"templatePath"`
+
+	// LinkBase 輸出檔中圖片與文章連結的基底：為空時使用相對於文章目錄的 ./檔名（預設），
+	// 設定後改為 linkBase/看板/文章目錄/檔名（如 "/" 產生以站台根目錄為起點的連結），讓看板總覽與文章頁共用同一個靜態根目錄
+	LinkBase string `yaml:"linkBase"`
 }
 
+// 文章輸出格式
+const (
+	OutputFormatMarkdown = "markdown"
+	OutputFormatHTML     = "html"
+	OutputFormatBoth     = "both"
+)
+
 // ChannelConfig 通道緩衝區配置，用於控制 Goroutine 間的通訊容量.
 type ChannelConfig struct {
 	ArticleInfo  int `yaml:"articleInfo"`  // 文章資訊通道緩衝區大小
@@ -38,6 +210,170 @@ type DelayConfig struct {
 	MaxMs int `yaml:"maxMs"` // 最大延遲毫秒數
 }
 
+// RateLimitConfig 請求速率限制配置，限制所有 worker 合計的對外請求速率.
+type RateLimitConfig struct {
+	RequestsPerSecond float64 `yaml:"requestsPerSecond"` // 每秒請求數上限，0 表示不限制
+	Burst             int     `yaml:"burst"`             // 可累積的額度上限（允許的瞬間突發請求數）
+
+	// Hosts 依 host 使用獨立的 token bucket（同時套用於子網域，最長相符者優先），
+	// 符合的請求只受該 host 的速率限制，未設定的 host 共用上面的預設速率
+	Hosts map[string]HostRateLimitConfig `yaml:"hosts"`
+}
+
+// HostRateLimitConfig 單一 host 的獨立速率限制.
+type HostRateLimitConfig struct {
+	RequestsPerSecond float64 `yaml:"requestsPerSecond"` // 該 host 的每秒請求數上限，0 表示不限制
+	Burst             int     `yaml:"burst"`             // 額度上限，0 表示沿用全域 burst
+}
+
+// AdaptiveConfig 自適應延遲配置.
+// 收到 429 時該 host 的延遲加倍，一段時間沒有 429 後逐步調降，延遲維持在 [minMs, maxMs] 之間.
+type AdaptiveConfig struct {
+	Enabled bool `yaml:"enabled"` // 啟用自適應延遲，預設關閉
+	MinMs   int  `yaml:"minMs"`   // 延遲下限（未收到 429 的 host）毫秒數
+	MaxMs   int  `yaml:"maxMs"`   // 延遲上限毫秒數
+}
+
+// RetryConfig 請求重試配置.
+// 全域只重試 429 與暫時性網路錯誤（逾時、連線重置）；Hosts 可針對個別 host 覆寫，host 層級優先。
+type RetryConfig struct {
+	MaxAttempts int                        `yaml:"maxAttempts"` // 每個請求最多嘗試次數（含第一次），1 表示不重試
+	Hosts       map[string]HostRetryConfig `yaml:"hosts"`       // 依 host 覆寫（同時套用於子網域，最長相符者優先）
+}
+
+// HostRetryConfig 單一 host 的重試策略，未設定的欄位沿用全域設定.
+type HostRetryConfig struct {
+	// Retryable true：不穩但值得多試，所有網路錯誤與 5xx 回應都重試；
+	// false：失敗多為永久性，任何失敗（含 429）都不重試；未設定時與全域相同
+	Retryable   *bool `yaml:"retryable"`
+	MaxAttempts int   `yaml:"maxAttempts"` // 最多嘗試次數，0 表示沿用全域 maxAttempts
+}
+
+// FilterConfig 文章過濾配置，所有條件須同時符合才會爬取該文章.
+type FilterConfig struct {
+	ExcludePushRates []int `yaml:"excludePushRates"` // 精確排除的推文數（如 [0, 100]），在門檻過濾之後套用
+}
+
+// DuplicateTitlesConfig 重複標題偵測配置.
+type DuplicateTitlesConfig struct {
+	Enabled   bool    `yaml:"enabled"`   // 啟用重複標題偵測，預設關閉
+	Threshold float64 `yaml:"threshold"` // 標題相似度門檻（0~1），1 表示正規化後完全相同才視為重複
+}
+
+// HeadPrecheckConfig 下載前 HEAD 預檢配置.
+type HeadPrecheckConfig struct {
+	Enabled  bool     `yaml:"enabled"`  // 啟用 HEAD 預檢，預設關閉
+	Workers  int      `yaml:"workers"`  // 全部文章合計同時進行的 HEAD 請求數上限
+	MinBytes ByteSize `yaml:"minBytes"` // 小於此大小的圖片不下載（如縮圖，可帶單位），0 表示不限制
+}
+
+// HostFilterConfig 圖床允許／封鎖清單配置.
+type HostFilterConfig struct {
+	Allow []string `yaml:"allow"` // 只下載 host 相符的圖片，空清單表示不限制
+	Deny  []string `yaml:"deny"`  // 不下載 host 相符的圖片，同時相符 allow 時仍不下載
+}
+
+// ClassifyConfig 下載後的圖片分類配置.
+type ClassifyConfig struct {
+	By              string  `yaml:"by"`              // 分類依據：off（預設）、orientation（landscape/portrait/square）或 size（small/medium/large）
+	SquareTolerance float64 `yaml:"squareTolerance"` // 長寬差距不超過長邊的此比例時視為 square（0~1）
+	MediumEdge      int     `yaml:"mediumEdge"`      // 長邊達此像素數為 medium，未達為 small
+	LargeEdge       int     `yaml:"largeEdge"`       // 長邊達此像素數為 large，須大於 mediumEdge
+}
+
+// 圖片分類依據
+const (
+	ClassifyByOff         = "off"
+	ClassifyByOrientation = "orientation"
+	ClassifyBySize        = "size"
+)
+
+// 圖片打包模式
+const (
+	PackOff = "off"
+	PackTar = "tar"
+)
+
+// FailuresConfig 圖片下載失敗明細的匯出配置.
+type FailuresConfig struct {
+	Report    string `yaml:"report"`    // 結束時匯出失敗明細（JSON，含各原因統計）的路徑，空字串表示不匯出
+	RetryFile string `yaml:"retryFile"` // 結束時寫出含失敗圖片的文章網址清單（每行一個，可直接以 -file 重跑）的路徑，空字串表示不寫出
+}
+
+// LinkFilterConfig 文章連結的過濾配置.
+type LinkFilterConfig struct {
+	SkipQuotes     bool     `yaml:"skipQuotes"`     // 略過引用區塊（以 ":" 開頭的行）中的連結
+	SkipSignature  bool     `yaml:"skipSignature"`  // 略過簽名檔（「※ 發信站」前最後一個「--」之後）與站台資訊中的連結，推文不受影響
+	IgnorePatterns []string `yaml:"ignorePatterns"` // 連結符合任一正規表示式時略過（比對文章中的原始連結）
+}
+
+// CacheConfig 跨執行文章快取配置，以 -cache 參數啟用.
+type CacheConfig struct {
+	Path string `yaml:"path"` // 快取檔路徑（JSON）
+	TTL  string `yaml:"ttl"`  // 文章爬取後的有效期限，期限內再次遇到時略過
+}
+
+// DedupConfig 跨執行圖片去重配置.
+type DedupConfig struct {
+	Enabled   bool   `yaml:"enabled"`   // 啟用跨執行去重，預設關閉
+	IndexPath string `yaml:"indexPath"` // 已下載索引檔路徑（JSON）
+	Mode      string `yaml:"mode"`      // 命中時的處理方式：link（建立硬連結，失敗時複製）或 skip（略過）
+
+	// PoolDir 以內容雜湊（SHA-256）定址的共用圖片目錄，空字串表示停用。
+	// 設定後下載完成的圖片會與目錄中的既有內容比對，相同內容以硬連結共用同一份檔案，
+	// 各文章目錄仍保有自己的檔名（Markdown 連結不變）
+	PoolDir string `yaml:"poolDir"`
+}
+
+// 去重命中時的處理方式
+const (
+	DedupModeLink = "link"
+	DedupModeSkip = "skip"
+)
+
+// CookieConfig 額外送出的 cookie.
+type CookieConfig struct {
+	Name   string `yaml:"name"`
+	Value  string `yaml:"value"`
+	Domain string `yaml:"domain"` // 適用的網域（含子網域），空字串表示只送往 www.ptt.cc
+	Path   string `yaml:"path"`   // 適用的路徑，空字串表示 "/"
+}
+
+// User-Agent 挑選方式
+const (
+	UARotationRoundRobin = "roundRobin"
+	UARotationRandom     = "random"
+)
+
+// 圖片下載模式
+const (
+	DownloadModePool    = "pool"
+	DownloadModeArticle = "article"
+)
+
+// 推文保存方式
+const (
+	SavePushesOff      = "off"
+	SavePushesMarkdown = "markdown"
+	SavePushesJSON     = "json"
+	SavePushesBoth     = "both"
+)
+
+// PerformanceConfig 效能監控配置.
+type PerformanceConfig struct {
+	Enabled           bool `yaml:"enabled"`           // 啟用效能監控，關閉時不建立監控器、不啟動背景 goroutine（預設開啟）
+	MemoryThresholdMB int  `yaml:"memoryThresholdMB"` // 堆積記憶體超過此值（MiB）時觸發 runtime.GC，0 表示不主動 GC
+	GCIntervalSec     int  `yaml:"gcIntervalSec"`     // 檢查記憶體並輸出統計的間隔（秒）
+}
+
+// AlertConfig 錯誤率告警配置，以滑動視窗統計請求錯誤率.
+type AlertConfig struct {
+	ErrorRate  float64 `yaml:"errorRate"`  // 錯誤率門檻（0~1），0 表示停用告警
+	Window     string  `yaml:"window"`     // 滑動視窗長度（YAML 字串）
+	MinSamples int     `yaml:"minSamples"` // 視窗內至少需要的請求數，避免少量請求誤報
+	Cooldown   string  `yaml:"cooldown"`   // 兩次告警的最短間隔（YAML 字串）
+}
+
 // HTTPConfig HTTP 客戶端配置，控制連線超時和連線池設定.
 // YAML 字串欄位在 Load 時會一次性解析為 time.Duration，避免重複解析。
 type HTTPConfig struct {
@@ -48,6 +384,20 @@ type HTTPConfig struct {
 	TLSHandshakeTimeout   string `yaml:"tlsHandshakeTimeout"`   // TLS 握手超時時間（YAML 字串）
 	ExpectContinueTimeout string `yaml:"expectContinueTimeout"` // Expect: 100-continue 超時時間（YAML 字串）
 
+	// Proxy 所有請求使用的代理，如 "http://proxy:8080"、"socks5://127.0.0.1:9050"；
+	// 空字串時沿用 HTTP_PROXY/HTTPS_PROXY/NO_PROXY 環境變數
+	Proxy string `yaml:"proxy"`
+
+	// UserAgents 請求輪流使用的 User-Agent 清單，空清單時使用 constants.DefaultUserAgent
+	UserAgents []string `yaml:"userAgents"`
+	// UserAgentRotation User-Agent 的挑選方式：roundRobin（依序輪替）或 random（每個請求隨機挑選）
+	UserAgentRotation string `yaml:"userAgentRotation"`
+
+	// Headers 每個請求額外加入的標頭（如 Accept-Language），不覆寫請求本身已設定的同名標頭
+	Headers map[string]string `yaml:"headers"`
+	// Cookies 額外的 cookie，與預設的 over18 cookie 一併送出
+	Cookies []CookieConfig `yaml:"cookies"`
+
 	// 已解析的 duration 值，Load 後即可直接使用
 	parsed                bool          `yaml:"-"`
 	timeout               time.Duration `yaml:"-"`
@@ -61,7 +411,7 @@ func parseDurationWithDefault(value string, defaultVal time.Duration, name strin
 	if d, err := time.ParseDuration(value); err == nil {
 		return d
 	}
-	log.Printf("解析 %s 失敗，使用預設值 %v", name, defaultVal)
+	slog.Warn(fmt.Sprintf("解析 %s 失敗，使用預設值 %v", name, defaultVal), "value", value)
 	return defaultVal
 }
 
@@ -79,8 +429,10 @@ func (h *HTTPConfig) parseHTTPDurations() {
 func DefaultConfig() *Config {
 	cfg := &Config{
 		Crawler: CrawlerConfig{
-			Workers:     10,
-			ParserCount: 10,
+			Workers:         FixedWorkers(10),
+			AutoScale:       AutoScaleConfig{Min: 2},
+			ParserCount:     10,
+			MarkdownWorkers: 1,
 			Channels: ChannelConfig{
 				ArticleInfo:  100,
 				DownloadTask: 200,
@@ -97,7 +449,68 @@ func DefaultConfig() *Config {
 				IdleConnTimeout:       "90s",
 				TLSHandshakeTimeout:   "10s",
 				ExpectContinueTimeout: "1s",
+				UserAgentRotation:     UARotationRoundRobin,
+			},
+			RateLimit: RateLimitConfig{
+				RequestsPerSecond: 0,
+				Burst:             1,
+			},
+			Retry: RetryConfig{
+				MaxAttempts: constants.RetryMaxAttempts,
+			},
+			Dedup: DedupConfig{
+				Enabled:   false,
+				IndexPath: ".ptt-spider/downloads.json",
+				Mode:      DedupModeLink,
+			},
+			Cache: CacheConfig{
+				Path: ".ptt-spider/cache.json",
+				TTL:  "24h",
+			},
+			Adaptive: AdaptiveConfig{
+				MaxMs: 30000,
+			},
+			Alert: AlertConfig{
+				ErrorRate:  0.5,
+				Window:     "1m",
+				MinSamples: 10,
+				Cooldown:   "5m",
+			},
+			Performance: PerformanceConfig{
+				Enabled:           true,
+				MemoryThresholdMB: 100,
+				GCIntervalSec:     30,
+			},
+			DuplicateTitles: DuplicateTitlesConfig{
+				Threshold: 1,
+			},
+			HeadPrecheck: HeadPrecheckConfig{
+				Workers: 8,
+			},
+			Logging: LoggingConfig{
+				MaxBoardFiles: 16,
 			},
+			Output: OutputConfig{
+				Format: OutputFormatMarkdown,
+			},
+			Classify: ClassifyConfig{
+				By:              ClassifyByOff,
+				SquareTolerance: 0.05,
+				MediumEdge:      800,
+				LargeEdge:       1920,
+			},
+			Pack:               PackOff,
+			WorkerIdleWarn:     "30s",
+			ShutdownGrace:      "5s",
+			ShutdownTimeout:    "30s",
+			ArticleTimeout:     "0",
+			DownloadMode:       DownloadModePool,
+			ArticleConcurrency: 4,
+			SavePushes:         SavePushesOff,
+			LogLevel:           "info",
+			ValidateImages:     true,
+			MaxImageBytes:      ByteSize(constants.MaxImageSizeBytes),
+			WriteBufferBytes:   64 * 1024,
 		},
 	}
 	cfg.Crawler.HTTP.parseHTTPDurations()
@@ -107,16 +520,23 @@ func DefaultConfig() *Config {
 // Load 載入配置檔案，支援自動降級機制.
 // 當配置檔案不存在時，會自動使用預設配置並返回 nil error.
 // 當讀取或解析失敗時，返回 error 讓呼叫方決定處理方式.
+// 非法值預設退回預設值並逐項記錄警告；以 WithStrict 啟用嚴格模式時改為返回 error.
 // 參數:
 //   - configPath: 配置檔案的完整路徑
+//   - opts: 可選配置，如 WithStrict
 //
 // 返回:
-//   - *Config: 配置物件，檔案不存在時為預設配置，讀取/解析失敗時為 nil
-//   - error: 讀取或解析失敗時返回對應錯誤
-func Load(configPath string) (*Config, error) {
+//   - *Config: 配置物件，檔案不存在時為預設配置，讀取/解析/嚴格驗證失敗時為 nil
+//   - error: 讀取或解析失敗（嚴格模式下含未知的鍵與非法值）時返回對應錯誤
+func Load(configPath string, opts ...LoadOption) (*Config, error) {
+	var o loadOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+
 	// 如果配置檔案不存在，使用預設配置
 	if _, err := os.Stat(configPath); os.IsNotExist(err) {
-		log.Printf("配置檔案 %s 不存在，使用預設配置", configPath)
+		slog.Info("配置檔案不存在，使用預設配置", "path", configPath)
 		return DefaultConfig(), nil
 	}
 
@@ -126,48 +546,305 @@ func Load(configPath string) (*Config, error) {
 	}
 
 	config := DefaultConfig()
-	if err := yaml.Unmarshal(data, config); err != nil {
+	if err := decodeYAML(data, config, o.strict); err != nil {
 		return nil, fmt.Errorf("解析配置檔案失敗: %w", err)
 	}
 
-	// 一次性解析所有 duration 字串
-	config.Crawler.HTTP.parseHTTPDurations()
-
-	// 修正非法數值，避免 panic 或死鎖
-	config.validateAndFix()
+	// 修正非法數值，避免 panic 或死鎖；duration 字串在修正後一次性解析
+	v := config.fix()
+	if o.strict {
+		if err := v.err(); err != nil {
+			return nil, fmt.Errorf("配置檔案 %s: %w", configPath, err)
+		}
+	}
+	v.log()
 
-	log.Printf("成功載入配置檔案: %s", configPath)
+	slog.Info("成功載入配置檔案", "path", configPath)
 	return config, nil
 }
 
-// fixIntIfInvalid 檢查數值是否小於下限，是則記錄警告並回傳預設值。
-func fixIntIfInvalid(value, minAllowed, defaultVal int, name string) int {
-	if value < minAllowed {
-		log.Printf("配置 %s 的值 %d 非法（最小值 %d），退回預設值 %d", name, value, minAllowed, defaultVal)
-		return defaultVal
-	}
-	return value
+// validateAndFix 驗證配置並修正非法值，每個問題各記錄一筆警告
+func (c *Config) validateAndFix() {
+	c.fix().log()
 }
 
-// validateAndFix 驗證數值配置，非法值退回預設並記錄警告。
-// workers/parserCount 必須 >= 1（0 會造成死鎖或 goroutine 洩漏），
+// fix 驗證配置，非法值退回預設並回傳發現的問題。
+// workers(.min)/parserCount/markdownWorkers 必須 >= 1（0 會造成死鎖或 goroutine 洩漏），
 // channel 緩衝區必須 >= 0（負數會造成 make(chan, -1) panic），
-// 延遲毫秒數必須 >= 0。
-func (c *Config) validateAndFix() {
+// 延遲毫秒數與每秒請求數必須 >= 0 且 delays.minMs 不大於 maxMs，rateLimit.burst 必須 >= 1，
+// 圖片大小上限必須 >= 0（0 表示不限制），時間長度設定必須可解析。
+func (c *Config) fix() *validation {
+	v := &validation{}
 	defaults := DefaultConfig()
 
-	c.Crawler.Workers = fixIntIfInvalid(c.Crawler.Workers, 1, defaults.Crawler.Workers, "workers")
-	c.Crawler.ParserCount = fixIntIfInvalid(c.Crawler.ParserCount, 1, defaults.Crawler.ParserCount, "parserCount")
+	c.validateWorkers(v, defaults)
+	c.validateAutoScale(v, defaults)
+	c.Crawler.ParserCount = v.fixInt(c.Crawler.ParserCount, 1, defaults.Crawler.ParserCount, "parserCount")
+	c.Crawler.MarkdownWorkers = v.fixInt(c.Crawler.MarkdownWorkers, 1, defaults.Crawler.MarkdownWorkers, "markdownWorkers")
 
-	c.Crawler.Channels.ArticleInfo = fixIntIfInvalid(
+	c.Crawler.Channels.ArticleInfo = v.fixInt(
 		c.Crawler.Channels.ArticleInfo, 0, defaults.Crawler.Channels.ArticleInfo, "channels.articleInfo")
-	c.Crawler.Channels.DownloadTask = fixIntIfInvalid(
+	c.Crawler.Channels.DownloadTask = v.fixInt(
 		c.Crawler.Channels.DownloadTask, 0, defaults.Crawler.Channels.DownloadTask, "channels.downloadTask")
-	c.Crawler.Channels.MarkdownTask = fixIntIfInvalid(
+	c.Crawler.Channels.MarkdownTask = v.fixInt(
 		c.Crawler.Channels.MarkdownTask, 0, defaults.Crawler.Channels.MarkdownTask, "channels.markdownTask")
 
-	c.Crawler.Delays.MinMs = fixIntIfInvalid(c.Crawler.Delays.MinMs, 0, defaults.Crawler.Delays.MinMs, "delays.minMs")
-	c.Crawler.Delays.MaxMs = fixIntIfInvalid(c.Crawler.Delays.MaxMs, 0, defaults.Crawler.Delays.MaxMs, "delays.maxMs")
+	c.Crawler.Delays.MinMs = v.fixInt(c.Crawler.Delays.MinMs, 0, defaults.Crawler.Delays.MinMs, "delays.minMs")
+	c.Crawler.Delays.MaxMs = v.fixInt(c.Crawler.Delays.MaxMs, 0, defaults.Crawler.Delays.MaxMs, "delays.maxMs")
+	if c.Crawler.Delays.MinMs > c.Crawler.Delays.MaxMs {
+		v.invalid(fmt.Sprintf("配置 delays.minMs 的值 %d 大於 delays.maxMs %d，maxMs 改為與 minMs 相同",
+			c.Crawler.Delays.MinMs, c.Crawler.Delays.MaxMs))
+		c.Crawler.Delays.MaxMs = c.Crawler.Delays.MinMs
+	}
+
+	if c.Crawler.RateLimit.RequestsPerSecond < 0 {
+		v.invalid(fmt.Sprintf("配置 rateLimit.requestsPerSecond 的值 %v 非法（最小值 0），退回預設值 %v",
+			c.Crawler.RateLimit.RequestsPerSecond, defaults.Crawler.RateLimit.RequestsPerSecond))
+		c.Crawler.RateLimit.RequestsPerSecond = defaults.Crawler.RateLimit.RequestsPerSecond
+	}
+	c.Crawler.RateLimit.Burst = v.fixInt(c.Crawler.RateLimit.Burst, 1, defaults.Crawler.RateLimit.Burst, "rateLimit.burst")
+	if len(c.Crawler.RateLimit.Hosts) > 0 {
+		// 與 retry.hosts 相同：host 比對不分大小寫，統一轉為小寫並略過空白項目
+		hosts := make(map[string]HostRateLimitConfig, len(c.Crawler.RateLimit.Hosts))
+		for host, hc := range c.Crawler.RateLimit.Hosts {
+			name := "rateLimit.hosts." + host
+			if hc.RequestsPerSecond < 0 {
+				v.invalid(fmt.Sprintf("配置 %s.requestsPerSecond 的值 %v 非法（最小值 0），退回 0（不限制）", name, hc.RequestsPerSecond))
+				hc.RequestsPerSecond = 0
+			}
+			hc.Burst = v.fixInt(hc.Burst, 0, 0, name+".burst")
+			if host = strings.ToLower(strings.TrimSpace(host)); host != "" {
+				hosts[host] = hc
+			}
+		}
+		c.Crawler.RateLimit.Hosts = hosts
+	}
+
+	c.Crawler.Retry.MaxAttempts = v.fixInt(c.Crawler.Retry.MaxAttempts, 1, defaults.Crawler.Retry.MaxAttempts, "retry.maxAttempts")
+	if len(c.Crawler.Retry.Hosts) > 0 {
+		// host 比對不分大小寫，統一轉為小寫並略過空白項目
+		hosts := make(map[string]HostRetryConfig, len(c.Crawler.Retry.Hosts))
+		for host, hc := range c.Crawler.Retry.Hosts {
+			hc.MaxAttempts = v.fixInt(hc.MaxAttempts, 0, 0, "retry.hosts."+host+".maxAttempts")
+			if host = strings.ToLower(strings.TrimSpace(host)); host != "" {
+				hosts[host] = hc
+			}
+		}
+		c.Crawler.Retry.Hosts = hosts
+	}
+
+	if c.Crawler.MaxImageBytes < 0 {
+		v.invalid(fmt.Sprintf("配置 maxImageBytes 的值 %d 非法（最小值 0），退回預設值 %d",
+			c.Crawler.MaxImageBytes, defaults.Crawler.MaxImageBytes))
+		c.Crawler.MaxImageBytes = defaults.Crawler.MaxImageBytes
+	}
+	c.Crawler.HeadPrecheck.Workers = v.fixInt(
+		c.Crawler.HeadPrecheck.Workers, 1, defaults.Crawler.HeadPrecheck.Workers, "headPrecheck.workers")
+	if c.Crawler.HeadPrecheck.MinBytes < 0 {
+		v.invalid(fmt.Sprintf("配置 headPrecheck.minBytes 的值 %d 非法（最小值 0），退回預設值 %d",
+			c.Crawler.HeadPrecheck.MinBytes, defaults.Crawler.HeadPrecheck.MinBytes))
+		c.Crawler.HeadPrecheck.MinBytes = defaults.Crawler.HeadPrecheck.MinBytes
+	}
+
+	if c.Crawler.Alert.ErrorRate < 0 || c.Crawler.Alert.ErrorRate > 1 {
+		v.invalid(fmt.Sprintf("配置 alert.errorRate 的值 %v 非法（範圍 0~1），退回預設值 %v",
+			c.Crawler.Alert.ErrorRate, defaults.Crawler.Alert.ErrorRate))
+		c.Crawler.Alert.ErrorRate = defaults.Crawler.Alert.ErrorRate
+	}
+	if t := c.Crawler.DuplicateTitles.Threshold; t <= 0 || t > 1 {
+		v.invalid(fmt.Sprintf("配置 duplicateTitles.threshold 的值 %v 非法（範圍 0~1，不含 0），退回預設值 %v",
+			t, defaults.Crawler.DuplicateTitles.Threshold))
+		c.Crawler.DuplicateTitles.Threshold = defaults.Crawler.DuplicateTitles.Threshold
+	}
+	var level slog.Level
+	if err := level.UnmarshalText([]byte(c.Crawler.LogLevel)); err != nil {
+		v.invalid(fmt.Sprintf("配置 logLevel 的值 %q 非法（可用值: debug、info、warn、error），退回預設值 %q",
+			c.Crawler.LogLevel, defaults.Crawler.LogLevel))
+		c.Crawler.LogLevel = defaults.Crawler.LogLevel
+	}
+
+	if c.Crawler.Dedup.Mode != DedupModeLink && c.Crawler.Dedup.Mode != DedupModeSkip {
+		v.invalid(fmt.Sprintf("配置 dedup.mode 的值 %q 非法（可用值: link、skip），退回預設值 %q",
+			c.Crawler.Dedup.Mode, defaults.Crawler.Dedup.Mode))
+		c.Crawler.Dedup.Mode = defaults.Crawler.Dedup.Mode
+	}
+	// 略過空白項目，避免送出空的 User-Agent 標頭
+	userAgents := c.Crawler.HTTP.UserAgents[:0]
+	for _, ua := range c.Crawler.HTTP.UserAgents {
+		if ua = strings.TrimSpace(ua); ua != "" {
+			userAgents = append(userAgents, ua)
+		}
+	}
+	c.Crawler.HTTP.UserAgents = userAgents
+	if c.Crawler.HTTP.UserAgentRotation != UARotationRoundRobin && c.Crawler.HTTP.UserAgentRotation != UARotationRandom {
+		v.invalid(fmt.Sprintf("配置 http.userAgentRotation 的值 %q 非法（可用值: roundRobin、random），退回預設值 %q",
+			c.Crawler.HTTP.UserAgentRotation, defaults.Crawler.HTTP.UserAgentRotation))
+		c.Crawler.HTTP.UserAgentRotation = defaults.Crawler.HTTP.UserAgentRotation
+	}
+	if c.Crawler.Dedup.IndexPath == "" {
+		c.Crawler.Dedup.IndexPath = defaults.Crawler.Dedup.IndexPath
+	}
+
+	c.Crawler.Adaptive.MinMs = v.fixInt(c.Crawler.Adaptive.MinMs, 0, defaults.Crawler.Adaptive.MinMs, "adaptive.minMs")
+	c.Crawler.Adaptive.MaxMs = v.fixInt(
+		c.Crawler.Adaptive.MaxMs, c.Crawler.Adaptive.MinMs, defaults.Crawler.Adaptive.MaxMs, "adaptive.maxMs")
+
+	c.Crawler.Alert.MinSamples = v.fixInt(c.Crawler.Alert.MinSamples, 1, defaults.Crawler.Alert.MinSamples, "alert.minSamples")
+	c.Crawler.Performance.MemoryThresholdMB = v.fixInt(c.Crawler.Performance.MemoryThresholdMB, 0,
+		defaults.Crawler.Performance.MemoryThresholdMB, "performance.memoryThresholdMB")
+	c.Crawler.Performance.GCIntervalSec = v.fixInt(c.Crawler.Performance.GCIntervalSec, 1,
+		defaults.Crawler.Performance.GCIntervalSec, "performance.gcIntervalSec")
+
+	if c.Crawler.DownloadMode != DownloadModePool && c.Crawler.DownloadMode != DownloadModeArticle {
+		v.invalid(fmt.Sprintf("配置 downloadMode 的值 %q 非法（可用值: pool、article），退回預設值 %q",
+			c.Crawler.DownloadMode, defaults.Crawler.DownloadMode))
+		c.Crawler.DownloadMode = defaults.Crawler.DownloadMode
+	}
+	switch c.Crawler.Output.Format {
+	case OutputFormatMarkdown, OutputFormatHTML, OutputFormatBoth:
+	default:
+		v.invalid(fmt.Sprintf("配置 output.format 的值 %q 非法（可用值: markdown、html、both），退回預設值 %q",
+			c.Crawler.Output.Format, defaults.Crawler.Output.Format))
+		c.Crawler.Output.Format = defaults.Crawler.Output.Format
+	}
+	switch c.Crawler.SavePushes {
+	case SavePushesOff, SavePushesMarkdown, SavePushesJSON, SavePushesBoth:
+	case "":
+		c.Crawler.SavePushes = SavePushesOff
+	default:
+		v.invalid(fmt.Sprintf("配置 savePushes 的值 %q 非法（可用值: off、markdown、json、both），退回預設值 %q",
+			c.Crawler.SavePushes, defaults.Crawler.SavePushes))
+		c.Crawler.SavePushes = defaults.Crawler.SavePushes
+	}
+	c.Crawler.Hosts.Allow = v.hostPatterns(c.Crawler.Hosts.Allow, "hosts.allow")
+	c.Crawler.Hosts.Deny = v.hostPatterns(c.Crawler.Hosts.Deny, "hosts.deny")
+	c.Crawler.LinkFilter.IgnorePatterns = v.regexpPatterns(c.Crawler.LinkFilter.IgnorePatterns, "linkFilter.ignorePatterns")
+	c.validateClassify(v, defaults)
+	c.validatePack(v, defaults)
+	c.Crawler.ArticleConcurrency = v.fixInt(
+		c.Crawler.ArticleConcurrency, 1, defaults.Crawler.ArticleConcurrency, "articleConcurrency")
+	c.Crawler.MaxConcurrentPerHost = v.fixInt(
+		c.Crawler.MaxConcurrentPerHost, 0, defaults.Crawler.MaxConcurrentPerHost, "maxConcurrentPerHost")
+	if c.Crawler.WriteBufferBytes < 0 {
+		v.invalid(fmt.Sprintf("配置 writeBufferBytes 的值 %d 非法（最小值 0），退回預設值 %d",
+			c.Crawler.WriteBufferBytes, defaults.Crawler.WriteBufferBytes))
+		c.Crawler.WriteBufferBytes = defaults.Crawler.WriteBufferBytes
+	}
+	c.Crawler.Logging.MaxBoardFiles = v.fixInt(
+		c.Crawler.Logging.MaxBoardFiles, 1, defaults.Crawler.Logging.MaxBoardFiles, "logging.maxBoardFiles")
+	c.fixDurations(v, defaults)
+	c.validateShutdown(v, defaults)
+	return v
+}
+
+// hostPatterns 將圖床樣式轉為小寫並略過空白項目；語法錯誤的萬用字元樣式（如未閉合的 [）記錄警告後略過
+func (v *validation) hostPatterns(patterns []string, name string) []string {
+	var valid []string
+	for _, p := range patterns {
+		p = strings.ToLower(strings.TrimSpace(p))
+		if p == "" {
+			continue
+		}
+		if _, err := path.Match(p, ""); err != nil {
+			v.invalid(fmt.Sprintf("配置 %s 的樣式 %q 非法（%v），已略過", name, p, err))
+			continue
+		}
+		valid = append(valid, p)
+	}
+	return valid
+}
+
+// regexpPatterns 略過空白項目；無法編譯的正規表示式記錄警告後略過
+func (v *validation) regexpPatterns(patterns []string, name string) []string {
+	var valid []string
+	for _, p := range patterns {
+		if strings.TrimSpace(p) == "" {
+			continue
+		}
+		if _, err := regexp.Compile(p); err != nil {
+			v.invalid(fmt.Sprintf("配置 %s 的正規表示式 %q 非法（%v），已略過", name, p, err))
+			continue
+		}
+		valid = append(valid, p)
+	}
+	return valid
+}
+
+// validatePack 驗證圖片打包模式。打包時圖片不再是個別檔案：需等該篇圖片全部寫入封存檔才產生輸出檔，
+// 因此 downloadMode 改為 article；README.md 的連結需解開封存檔才能檢視，故一併輸出內嵌圖片的 index.html；
+// 需操作個別檔案的 classify、dedup 與 stripExif 則關閉。
+func (c *Config) validatePack(v *validation, defaults *Config) {
+	cr := &c.Crawler
+	switch cr.Pack {
+	case PackOff, PackTar:
+	case "":
+		cr.Pack = PackOff
+	default:
+		v.invalid(fmt.Sprintf("配置 pack 的值 %q 非法（可用值: off、tar），退回預設值 %q", cr.Pack, defaults.Crawler.Pack))
+		cr.Pack = defaults.Crawler.Pack
+	}
+	if cr.Pack == PackOff {
+		return
+	}
+
+	if cr.DownloadMode != DownloadModeArticle {
+		v.adjust(fmt.Sprintf("配置 pack 為 %q 時需等圖片寫入封存檔才產生 Markdown，downloadMode 改為 %q", cr.Pack, DownloadModeArticle))
+		cr.DownloadMode = DownloadModeArticle
+	}
+	if cr.Output.Format == OutputFormatMarkdown {
+		v.adjust(fmt.Sprintf("配置 pack 為 %q 時 README.md 的圖片需解開封存檔才能檢視，output.format 改為 %q（index.html 內嵌圖片）",
+			cr.Pack, OutputFormatBoth))
+		cr.Output.Format = OutputFormatBoth
+	}
+	var disabled []string
+	if cr.Classify.By != ClassifyByOff {
+		cr.Classify.By = ClassifyByOff
+		disabled = append(disabled, "classify")
+	}
+	if cr.Dedup.Enabled {
+		cr.Dedup.Enabled = false
+		disabled = append(disabled, "dedup")
+	}
+	if cr.StripExif {
+		cr.StripExif = false
+		disabled = append(disabled, "stripExif")
+	}
+	if len(disabled) > 0 {
+		v.adjust(fmt.Sprintf("配置 pack 為 %q 時不支援 %s（需操作個別圖片檔），已關閉", cr.Pack, strings.Join(disabled, "、")))
+	}
+}
+
+// validateClassify 驗證圖片分類設定；啟用分類時 Markdown 需引用移動後的路徑，
+// 必須等該篇圖片全部下載完成才產生，因此將 downloadMode 改為 article。
+func (c *Config) validateClassify(v *validation, defaults *Config) {
+	cl := &c.Crawler.Classify
+	switch cl.By {
+	case ClassifyByOff, ClassifyByOrientation, ClassifyBySize:
+	case "":
+		cl.By = ClassifyByOff
+	default:
+		v.invalid(fmt.Sprintf("配置 classify.by 的值 %q 非法（可用值: off、orientation、size），退回預設值 %q",
+			cl.By, defaults.Crawler.Classify.By))
+		cl.By = defaults.Crawler.Classify.By
+	}
+	if cl.SquareTolerance < 0 || cl.SquareTolerance >= 1 {
+		v.invalid(fmt.Sprintf("配置 classify.squareTolerance 的值 %g 非法（範圍 0~1），退回預設值 %g",
+			cl.SquareTolerance, defaults.Crawler.Classify.SquareTolerance))
+		cl.SquareTolerance = defaults.Crawler.Classify.SquareTolerance
+	}
+	cl.MediumEdge = v.fixInt(cl.MediumEdge, 1, defaults.Crawler.Classify.MediumEdge, "classify.mediumEdge")
+	if cl.LargeEdge <= cl.MediumEdge {
+		v.invalid(fmt.Sprintf("配置 classify.largeEdge 的值 %d 非法（須大於 mediumEdge %d），mediumEdge 與 largeEdge 退回預設值 %d、%d",
+			cl.LargeEdge, cl.MediumEdge, defaults.Crawler.Classify.MediumEdge, defaults.Crawler.Classify.LargeEdge))
+		cl.MediumEdge = defaults.Crawler.Classify.MediumEdge
+		cl.LargeEdge = defaults.Crawler.Classify.LargeEdge
+	}
+
+	if cl.By != ClassifyByOff && c.Crawler.DownloadMode != DownloadModeArticle {
+		v.adjust(fmt.Sprintf("配置 classify.by 為 %q 時需等圖片下載完成才產生 Markdown，downloadMode 改為 %q",
+			cl.By, DownloadModeArticle))
+		c.Crawler.DownloadMode = DownloadModeArticle
+	}
 }
 
 // ensureParsed 確保 HTTP duration 已被解析。
@@ -192,6 +869,16 @@ func (c *Config) GetDelayRange() (time.Duration, time.Duration) {
 	return minDelay, maxDelay
 }
 
+// InlinePushes 回報是否將推文寫入文章的 README.md／index.html（savePushes 為 markdown 或 both）.
+func (c *Config) InlinePushes() bool {
+	return c.Crawler.SavePushes == SavePushesMarkdown || c.Crawler.SavePushes == SavePushesBoth
+}
+
+// PushesFile 回報是否另外輸出 pushes.json（savePushes 為 json 或 both）.
+func (c *Config) PushesFile() bool {
+	return c.Crawler.SavePushes == SavePushesJSON || c.Crawler.SavePushes == SavePushesBoth
+}
+
 // GetIdleConnTimeout 獲取已解析的空閒連線超時時間.
 func (c *Config) GetIdleConnTimeout() time.Duration {
 	c.ensureParsed()
@@ -209,3 +896,72 @@ func (c *Config) GetExpectContinueTimeout() time.Duration {
 	c.ensureParsed()
 	return c.Crawler.HTTP.expectContinueTimeout
 }
+
+// GetAlertWindow 獲取錯誤率告警的滑動視窗長度.
+func (c *Config) GetAlertWindow() time.Duration {
+	return parseDurationWithDefault(c.Crawler.Alert.Window, time.Minute, "告警視窗長度")
+}
+
+// GetGCInterval 獲取效能監控檢查記憶體並輸出統計的間隔.
+func (c *Config) GetGCInterval() time.Duration {
+	return time.Duration(c.Crawler.Performance.GCIntervalSec) * time.Second
+}
+
+// GetWorkerIdleWarn 取得下載 worker 閒置提示門檻，0 表示停用；無效或負值時使用預設值 30s
+func (c *Config) GetWorkerIdleWarn() time.Duration {
+	const defaultIdleWarn = 30 * time.Second
+	d := parseDurationWithDefault(c.Crawler.WorkerIdleWarn, defaultIdleWarn, "worker 閒置提示門檻")
+	if d < 0 {
+		slog.Warn(fmt.Sprintf("配置 workerIdleWarn 的值 %v 非法（最小值 0），退回預設值 %v", d, defaultIdleWarn))
+		return defaultIdleWarn
+	}
+	return d
+}
+
+// GetShutdownGrace 取得中斷後處理剩餘 Markdown 任務的寬限期，0 表示不處理；無效或負值時使用預設值 5s
+func (c *Config) GetShutdownGrace() time.Duration {
+	const defaultGrace = 5 * time.Second
+	d := parseDurationWithDefault(c.Crawler.ShutdownGrace, defaultGrace, "中斷寬限期")
+	if d < 0 {
+		slog.Warn(fmt.Sprintf("配置 shutdownGrace 的值 %v 非法（最小值 0），退回預設值 %v", d, defaultGrace))
+		return defaultGrace
+	}
+	return d
+}
+
+// GetShutdownTimeout 取得中斷後等待收尾的時間上限，0 表示不限時；無效或負值時使用預設值 30s
+func (c *Config) GetShutdownTimeout() time.Duration {
+	const defaultTimeout = 30 * time.Second
+	d := parseDurationWithDefault(c.Crawler.ShutdownTimeout, defaultTimeout, "關閉逾時時間")
+	if d < 0 {
+		slog.Warn(fmt.Sprintf("配置 shutdownTimeout 的值 %v 非法（最小值 0），退回預設值 %v", d, defaultTimeout))
+		return defaultTimeout
+	}
+	return d
+}
+
+// GetArticleTimeout 取得單篇文章頁的時間上限，0 表示停用；無效或負值時停用
+func (c *Config) GetArticleTimeout() time.Duration {
+	d := parseDurationWithDefault(c.Crawler.ArticleTimeout, 0, "文章逾時時間")
+	if d < 0 {
+		slog.Warn(fmt.Sprintf("配置 articleTimeout 的值 %v 非法（最小值 0），停用文章逾時", d))
+		return 0
+	}
+	return d
+}
+
+// GetCacheTTL 取得文章快取的有效期限；無效或非正值時使用預設值 24h
+func (c *Config) GetCacheTTL() time.Duration {
+	const defaultTTL = 24 * time.Hour
+	d := parseDurationWithDefault(c.Crawler.Cache.TTL, defaultTTL, "文章快取有效期限")
+	if d <= 0 {
+		slog.Warn(fmt.Sprintf("配置 cache.ttl 的值 %v 非法（需大於 0），退回預設值 %v", d, defaultTTL))
+		return defaultTTL
+	}
+	return d
+}
+
+// GetAlertCooldown 獲取錯誤率告警的冷卻時間.
+func (c *Config) GetAlertCooldown() time.Duration {
+	return parseDurationWithDefault(c.Crawler.Alert.Cooldown, 5*time.Minute, "告警冷卻時間")
+}
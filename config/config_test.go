@@ -3,6 +3,8 @@ package config
 import (
 	"os"
 	"path/filepath"
+	"reflect"
+	"strings"
 	"testing"
 	"time"
 )
@@ -18,8 +20,8 @@ func TestLoad(t *testing.T) {
 			name:       "Load valid config",
 			configPath: "../tests/fixtures/config_valid.yaml",
 			validate: func(t *testing.T, cfg *Config) {
-				if cfg.Crawler.Workers != 5 {
-					t.Errorf("expected workers = 5, got %d", cfg.Crawler.Workers)
+				if cfg.Crawler.Workers != FixedWorkers(5) {
+					t.Errorf("expected workers = 5, got %v", cfg.Crawler.Workers)
 				}
 				if cfg.Crawler.Delays.MinMs != 1000 {
 					t.Errorf("expected minMs = 1000, got %d", cfg.Crawler.Delays.MinMs)
@@ -55,8 +57,8 @@ func TestLoad(t *testing.T) {
 			configPath: "../tests/fixtures/non_existent.yaml",
 			validate: func(t *testing.T, cfg *Config) {
 				// Check default values
-				if cfg.Crawler.Workers != 10 {
-					t.Errorf("expected default workers = 10, got %d", cfg.Crawler.Workers)
+				if cfg.Crawler.Workers != FixedWorkers(10) {
+					t.Errorf("expected default workers = 10, got %v", cfg.Crawler.Workers)
 				}
 				if cfg.Crawler.ParserCount != 10 {
 					t.Errorf("expected default parserCount = 10, got %d", cfg.Crawler.ParserCount)
@@ -92,8 +94,8 @@ func TestDefaultConfig(t *testing.T) {
 	cfg := DefaultConfig()
 
 	// Test crawler defaults
-	if cfg.Crawler.Workers != 10 {
-		t.Errorf("expected default workers = 10, got %d", cfg.Crawler.Workers)
+	if cfg.Crawler.Workers != FixedWorkers(10) {
+		t.Errorf("expected default workers = 10, got %v", cfg.Crawler.Workers)
 	}
 	if cfg.Crawler.ParserCount != 10 {
 		t.Errorf("expected default parserCount = 10, got %d", cfg.Crawler.ParserCount)
@@ -364,8 +366,8 @@ crawler:
 	}
 
 	// Verify all values
-	if cfg.Crawler.Workers != 15 {
-		t.Errorf("expected workers = 15, got %d", cfg.Crawler.Workers)
+	if cfg.Crawler.Workers != FixedWorkers(15) {
+		t.Errorf("expected workers = 15, got %v", cfg.Crawler.Workers)
 	}
 	if cfg.Crawler.HTTP.MaxIdleConns != 150 {
 		t.Errorf("expected maxIdleConns = 150, got %d", cfg.Crawler.HTTP.MaxIdleConns)
@@ -392,19 +394,19 @@ func TestValidateAndFix(t *testing.T) {
 	}{
 		{
 			name:   "workers 為 0 退回預設",
-			mutate: func(c *Config) { c.Crawler.Workers = 0 },
+			mutate: func(c *Config) { c.Crawler.Workers = FixedWorkers(0) },
 			check: func(t *testing.T, cfg *Config) {
 				if cfg.Crawler.Workers != defaults.Crawler.Workers {
-					t.Errorf("workers = %d, want %d", cfg.Crawler.Workers, defaults.Crawler.Workers)
+					t.Errorf("workers = %v, want %v", cfg.Crawler.Workers, defaults.Crawler.Workers)
 				}
 			},
 		},
 		{
 			name:   "workers 為負數退回預設",
-			mutate: func(c *Config) { c.Crawler.Workers = -5 },
+			mutate: func(c *Config) { c.Crawler.Workers = FixedWorkers(-5) },
 			check: func(t *testing.T, cfg *Config) {
 				if cfg.Crawler.Workers != defaults.Crawler.Workers {
-					t.Errorf("workers = %d, want %d", cfg.Crawler.Workers, defaults.Crawler.Workers)
+					t.Errorf("workers = %v, want %v", cfg.Crawler.Workers, defaults.Crawler.Workers)
 				}
 			},
 		},
@@ -417,6 +419,36 @@ func TestValidateAndFix(t *testing.T) {
 				}
 			},
 		},
+		{
+			name:   "markdownWorkers 為 0 退回預設",
+			mutate: func(c *Config) { c.Crawler.MarkdownWorkers = 0 },
+			check: func(t *testing.T, cfg *Config) {
+				if cfg.Crawler.MarkdownWorkers != 1 {
+					t.Errorf("markdownWorkers = %d, want 1", cfg.Crawler.MarkdownWorkers)
+				}
+			},
+		},
+		{
+			name: "performance 門檻為負數、間隔為 0 退回預設",
+			mutate: func(c *Config) {
+				c.Crawler.Performance.MemoryThresholdMB = -1
+				c.Crawler.Performance.GCIntervalSec = 0
+			},
+			check: func(t *testing.T, cfg *Config) {
+				if got, want := cfg.Crawler.Performance, defaults.Crawler.Performance; got != want {
+					t.Errorf("performance = %+v, want %+v", got, want)
+				}
+			},
+		},
+		{
+			name:   "performance 門檻為 0 表示不主動 GC",
+			mutate: func(c *Config) { c.Crawler.Performance.MemoryThresholdMB = 0 },
+			check: func(t *testing.T, cfg *Config) {
+				if cfg.Crawler.Performance.MemoryThresholdMB != 0 {
+					t.Errorf("memoryThresholdMB = %d, want 0", cfg.Crawler.Performance.MemoryThresholdMB)
+				}
+			},
+		},
 		{
 			name:   "channel 緩衝區為負數退回預設",
 			mutate: func(c *Config) { c.Crawler.Channels.DownloadTask = -1 },
@@ -442,12 +474,45 @@ func TestValidateAndFix(t *testing.T) {
 				}
 			},
 		},
+		{
+			name:   "maxConcurrentPerHost 為負數退回不限制",
+			mutate: func(c *Config) { c.Crawler.MaxConcurrentPerHost = -1 },
+			check: func(t *testing.T, cfg *Config) {
+				if cfg.Crawler.MaxConcurrentPerHost != 0 {
+					t.Errorf("maxConcurrentPerHost = %d, want 0", cfg.Crawler.MaxConcurrentPerHost)
+				}
+			},
+		},
+		{
+			name: "shutdownTimeout 未大於 shutdownGrace 時調高",
+			mutate: func(c *Config) {
+				c.Crawler.ShutdownGrace = "1m"
+				c.Crawler.ShutdownTimeout = "10s"
+			},
+			check: func(t *testing.T, cfg *Config) {
+				if got := cfg.GetShutdownTimeout(); got != time.Minute+30*time.Second {
+					t.Errorf("shutdownTimeout = %v, want 1m30s", got)
+				}
+			},
+		},
+		{
+			name: "shutdownTimeout 為 0（不限時）時不調整",
+			mutate: func(c *Config) {
+				c.Crawler.ShutdownGrace = "1m"
+				c.Crawler.ShutdownTimeout = "0"
+			},
+			check: func(t *testing.T, cfg *Config) {
+				if cfg.Crawler.ShutdownTimeout != "0" {
+					t.Errorf("shutdownTimeout = %q, want 0", cfg.Crawler.ShutdownTimeout)
+				}
+			},
+		},
 		{
 			name:   "合法值不被修改",
-			mutate: func(c *Config) { c.Crawler.Workers = 3 },
+			mutate: func(c *Config) { c.Crawler.Workers = FixedWorkers(3) },
 			check: func(t *testing.T, cfg *Config) {
-				if cfg.Crawler.Workers != 3 {
-					t.Errorf("workers = %d, want 3", cfg.Crawler.Workers)
+				if cfg.Crawler.Workers != FixedWorkers(3) {
+					t.Errorf("workers = %v, want 3", cfg.Crawler.Workers)
 				}
 			},
 		},
@@ -488,7 +553,7 @@ crawler:
 
 	defaults := DefaultConfig()
 	if cfg.Crawler.Workers != defaults.Crawler.Workers {
-		t.Errorf("workers = %d, want %d", cfg.Crawler.Workers, defaults.Crawler.Workers)
+		t.Errorf("workers = %v, want %v", cfg.Crawler.Workers, defaults.Crawler.Workers)
 	}
 	if cfg.Crawler.ParserCount != defaults.Crawler.ParserCount {
 		t.Errorf("parserCount = %d, want %d", cfg.Crawler.ParserCount, defaults.Crawler.ParserCount)
@@ -503,3 +568,528 @@ crawler:
 		t.Errorf("markdownTask = %d, want %d", cfg.Crawler.Channels.MarkdownTask, defaults.Crawler.Channels.MarkdownTask)
 	}
 }
+
+// TestLoad_ValidateImages 驗證 validateImages 預設開啟，且可由配置檔關閉。
+func TestLoad_ValidateImages(t *testing.T) {
+	if !DefaultConfig().Crawler.ValidateImages {
+		t.Error("validateImages 預設應為 true")
+	}
+
+	configPath := filepath.Join(t.TempDir(), "validate.yaml")
+	if err := os.WriteFile(configPath, []byte("crawler:\n  validateImages: false\n"), 0644); err != nil {
+		t.Fatalf("建立測試配置檔失敗: %v", err)
+	}
+
+	cfg, err := Load(configPath)
+	if err != nil {
+		t.Fatalf("Load() unexpected error = %v", err)
+	}
+	if cfg.Crawler.ValidateImages {
+		t.Error("配置檔設定 validateImages: false 後應為 false")
+	}
+}
+
+// TestValidateAndFix_MaxImageBytes 驗證 maxImageBytes 負數退回預設，0 保留為不限制。
+func TestValidateAndFix_MaxImageBytes(t *testing.T) {
+	defaults := DefaultConfig()
+
+	cfg := DefaultConfig()
+	cfg.Crawler.MaxImageBytes = -1
+	cfg.validateAndFix()
+	if cfg.Crawler.MaxImageBytes != defaults.Crawler.MaxImageBytes {
+		t.Errorf("maxImageBytes = %d, want %d", cfg.Crawler.MaxImageBytes, defaults.Crawler.MaxImageBytes)
+	}
+
+	cfg = DefaultConfig()
+	cfg.Crawler.MaxImageBytes = 0
+	cfg.validateAndFix()
+	if cfg.Crawler.MaxImageBytes != 0 {
+		t.Errorf("maxImageBytes = %d, want 0（不限制）", cfg.Crawler.MaxImageBytes)
+	}
+}
+
+func TestValidateAndFix_RateLimit(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.Crawler.RateLimit = RateLimitConfig{RequestsPerSecond: -1, Burst: 0}
+	cfg.validateAndFix()
+
+	if cfg.Crawler.RateLimit.RequestsPerSecond != 0 {
+		t.Errorf("requestsPerSecond = %v, want 0", cfg.Crawler.RateLimit.RequestsPerSecond)
+	}
+	if cfg.Crawler.RateLimit.Burst != 1 {
+		t.Errorf("burst = %d, want 1", cfg.Crawler.RateLimit.Burst)
+	}
+}
+
+func TestValidateAndFix_RateLimitHosts(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.Crawler.RateLimit.Hosts = map[string]HostRateLimitConfig{
+		" I.Imgur.com ": {RequestsPerSecond: -2, Burst: 3},
+		"pbs.twimg.com": {RequestsPerSecond: 5, Burst: -1},
+		"  ":            {RequestsPerSecond: 1},
+	}
+	cfg.validateAndFix()
+
+	want := map[string]HostRateLimitConfig{
+		"i.imgur.com":   {RequestsPerSecond: 0, Burst: 3},
+		"pbs.twimg.com": {RequestsPerSecond: 5, Burst: 0},
+	}
+	if !reflect.DeepEqual(cfg.Crawler.RateLimit.Hosts, want) {
+		t.Errorf("rateLimit.hosts = %+v, want %+v", cfg.Crawler.RateLimit.Hosts, want)
+	}
+}
+
+func TestValidateAndFix_Alert(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.Crawler.Alert = AlertConfig{ErrorRate: 1.5, MinSamples: 0, Window: "bad", Cooldown: "30s"}
+	cfg.validateAndFix()
+
+	if cfg.Crawler.Alert.ErrorRate != 0.5 {
+		t.Errorf("errorRate = %v, want 0.5", cfg.Crawler.Alert.ErrorRate)
+	}
+	if cfg.Crawler.Alert.MinSamples != 10 {
+		t.Errorf("minSamples = %d, want 10", cfg.Crawler.Alert.MinSamples)
+	}
+	if got := cfg.GetAlertWindow(); got != time.Minute {
+		t.Errorf("無效視窗應退回預設值 1m，got %v", got)
+	}
+	if got := cfg.GetAlertCooldown(); got != 30*time.Second {
+		t.Errorf("cooldown = %v, want 30s", got)
+	}
+}
+
+func TestValidateAndFix_Adaptive(t *testing.T) {
+	tests := []struct {
+		name         string
+		minMs, maxMs int
+		wantMin      int
+		wantMax      int
+	}{
+		{"合法範圍保留", 200, 5000, 200, 5000},
+		{"負的下限退回 0", -1, 5000, 0, 5000},
+		{"上限小於下限退回預設", 2000, 1000, 2000, 30000},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := DefaultConfig()
+			cfg.Crawler.Adaptive = AdaptiveConfig{Enabled: true, MinMs: tt.minMs, MaxMs: tt.maxMs}
+			cfg.validateAndFix()
+			if got := cfg.Crawler.Adaptive; got.MinMs != tt.wantMin || got.MaxMs != tt.wantMax {
+				t.Errorf("adaptive = %+v, want minMs=%d maxMs=%d", got, tt.wantMin, tt.wantMax)
+			}
+		})
+	}
+}
+
+func TestGetWorkerIdleWarn(t *testing.T) {
+	tests := []struct {
+		input string
+		want  time.Duration
+	}{
+		{"10s", 10 * time.Second},
+		{"0", 0},
+		{"bad", 30 * time.Second},
+		{"-5s", 30 * time.Second},
+		{"", 30 * time.Second},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			cfg := DefaultConfig()
+			cfg.Crawler.WorkerIdleWarn = tt.input
+			if got := cfg.GetWorkerIdleWarn(); got != tt.want {
+				t.Errorf("GetWorkerIdleWarn() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetShutdownGrace(t *testing.T) {
+	tests := []struct {
+		input string
+		want  time.Duration
+	}{
+		{"10s", 10 * time.Second},
+		{"0", 0},
+		{"bad", 5 * time.Second},
+		{"-1s", 5 * time.Second},
+		{"", 5 * time.Second},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			cfg := DefaultConfig()
+			cfg.Crawler.ShutdownGrace = tt.input
+			if got := cfg.GetShutdownGrace(); got != tt.want {
+				t.Errorf("GetShutdownGrace() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetCacheTTL(t *testing.T) {
+	tests := []struct {
+		input string
+		want  time.Duration
+	}{
+		{"12h", 12 * time.Hour},
+		{"0", 24 * time.Hour},
+		{"bad", 24 * time.Hour},
+		{"-1h", 24 * time.Hour},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			cfg := DefaultConfig()
+			cfg.Crawler.Cache.TTL = tt.input
+			if got := cfg.GetCacheTTL(); got != tt.want {
+				t.Errorf("GetCacheTTL() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateAndFix_LogLevel(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"debug", "debug"},
+		{"WARN", "WARN"},
+		{"verbose", "info"},
+		{"", "info"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			cfg := DefaultConfig()
+			cfg.Crawler.LogLevel = tt.input
+			cfg.validateAndFix()
+			if cfg.Crawler.LogLevel != tt.want {
+				t.Errorf("logLevel = %q, want %q", cfg.Crawler.LogLevel, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoad_FilterExcludePushRates(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "filter.yaml")
+	content := "crawler:\n  filter:\n    excludePushRates: [0, 100, -1]\n"
+	if err := os.WriteFile(configPath, []byte(content), 0644); err != nil {
+		t.Fatalf("建立測試配置檔失敗: %v", err)
+	}
+
+	cfg, err := Load(configPath)
+	if err != nil {
+		t.Fatalf("Load() unexpected error = %v", err)
+	}
+	want := []int{0, 100, -1}
+	if !reflect.DeepEqual(cfg.Crawler.Filter.ExcludePushRates, want) {
+		t.Errorf("excludePushRates = %v, want %v", cfg.Crawler.Filter.ExcludePushRates, want)
+	}
+}
+
+func TestValidateAndFix_Dedup(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.Crawler.Dedup = DedupConfig{Enabled: true, IndexPath: "", Mode: "hardlink"}
+	cfg.validateAndFix()
+
+	if cfg.Crawler.Dedup.Mode != DedupModeLink {
+		t.Errorf("mode = %q, want %q", cfg.Crawler.Dedup.Mode, DedupModeLink)
+	}
+	if cfg.Crawler.Dedup.IndexPath != DefaultConfig().Crawler.Dedup.IndexPath {
+		t.Errorf("indexPath = %q, 空值應退回預設", cfg.Crawler.Dedup.IndexPath)
+	}
+	if !cfg.Crawler.Dedup.Enabled {
+		t.Error("enabled 不應被修改")
+	}
+}
+
+func TestValidateAndFix_DownloadMode(t *testing.T) {
+	tests := []struct {
+		name            string
+		mode            string
+		concurrency     int
+		wantMode        string
+		wantConcurrency int
+	}{
+		{"article 模式保留", DownloadModeArticle, 8, DownloadModeArticle, 8},
+		{"pool 模式保留", DownloadModePool, 1, DownloadModePool, 1},
+		{"無效模式退回 pool", "batch", 4, DownloadModePool, 4},
+		{"空字串退回 pool", "", 4, DownloadModePool, 4},
+		{"併發數 0 退回預設", DownloadModeArticle, 0, DownloadModeArticle, 4},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := DefaultConfig()
+			cfg.Crawler.DownloadMode = tt.mode
+			cfg.Crawler.ArticleConcurrency = tt.concurrency
+			cfg.validateAndFix()
+			if cfg.Crawler.DownloadMode != tt.wantMode {
+				t.Errorf("downloadMode = %q, want %q", cfg.Crawler.DownloadMode, tt.wantMode)
+			}
+			if cfg.Crawler.ArticleConcurrency != tt.wantConcurrency {
+				t.Errorf("articleConcurrency = %d, want %d", cfg.Crawler.ArticleConcurrency, tt.wantConcurrency)
+			}
+		})
+	}
+}
+
+func TestValidateAndFix_Classify(t *testing.T) {
+	tests := []struct {
+		name       string
+		by         string
+		tolerance  float64
+		medium     int
+		large      int
+		wantBy     string
+		wantMode   string
+		wantMedium int
+		wantLarge  int
+		wantTol    float64
+	}{
+		{"預設不分類", ClassifyByOff, 0.05, 800, 1920, ClassifyByOff, DownloadModePool, 800, 1920, 0.05},
+		{"空字串視為 off", "", 0.05, 800, 1920, ClassifyByOff, DownloadModePool, 800, 1920, 0.05},
+		{"啟用分類改為 article 模式", ClassifyByOrientation, 0.1, 800, 1920, ClassifyByOrientation, DownloadModeArticle, 800, 1920, 0.1},
+		{"無效依據退回 off", "color", 0.05, 800, 1920, ClassifyByOff, DownloadModePool, 800, 1920, 0.05},
+		{"容許比例超出範圍退回預設", ClassifyBySize, 1.5, 800, 1920, ClassifyBySize, DownloadModeArticle, 800, 1920, 0.05},
+		{"largeEdge 不大於 mediumEdge 退回預設", ClassifyBySize, 0.05, 2000, 1000, ClassifyBySize, DownloadModeArticle, 800, 1920, 0.05},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := DefaultConfig()
+			cfg.Crawler.Classify = ClassifyConfig{By: tt.by, SquareTolerance: tt.tolerance, MediumEdge: tt.medium, LargeEdge: tt.large}
+			cfg.validateAndFix()
+			got := cfg.Crawler.Classify
+			if got.By != tt.wantBy || got.SquareTolerance != tt.wantTol || got.MediumEdge != tt.wantMedium || got.LargeEdge != tt.wantLarge {
+				t.Errorf("classify = %+v, want by=%q tolerance=%g mediumEdge=%d largeEdge=%d",
+					got, tt.wantBy, tt.wantTol, tt.wantMedium, tt.wantLarge)
+			}
+			if cfg.Crawler.DownloadMode != tt.wantMode {
+				t.Errorf("downloadMode = %q, want %q", cfg.Crawler.DownloadMode, tt.wantMode)
+			}
+		})
+	}
+}
+
+func TestValidateAndFix_Pack(t *testing.T) {
+	t.Run("預設不打包", func(t *testing.T) {
+		cfg := DefaultConfig()
+		cfg.Crawler.Dedup.Enabled = true
+		cfg.validateAndFix()
+		if cfg.Crawler.Pack != PackOff || cfg.Crawler.DownloadMode != DownloadModePool || !cfg.Crawler.Dedup.Enabled {
+			t.Errorf("pack = %q, downloadMode = %q, dedup = %v", cfg.Crawler.Pack, cfg.Crawler.DownloadMode, cfg.Crawler.Dedup.Enabled)
+		}
+	})
+
+	t.Run("無效值退回 off", func(t *testing.T) {
+		cfg := DefaultConfig()
+		cfg.Crawler.Pack = "zip"
+		cfg.validateAndFix()
+		if cfg.Crawler.Pack != PackOff {
+			t.Errorf("pack = %q, want %q", cfg.Crawler.Pack, PackOff)
+		}
+	})
+
+	t.Run("tar 關閉不相容的設定", func(t *testing.T) {
+		cfg := DefaultConfig()
+		cfg.Crawler.Pack = PackTar
+		cfg.Crawler.Classify.By = ClassifyBySize
+		cfg.Crawler.Dedup.Enabled = true
+		cfg.Crawler.StripExif = true
+		cfg.validateAndFix()
+		cr := cfg.Crawler
+		if cr.DownloadMode != DownloadModeArticle {
+			t.Errorf("downloadMode = %q, want %q", cr.DownloadMode, DownloadModeArticle)
+		}
+		if cr.Output.Format != OutputFormatBoth {
+			t.Errorf("output.format = %q, want %q（需輸出內嵌圖片的 index.html）", cr.Output.Format, OutputFormatBoth)
+		}
+		if cr.Classify.By != ClassifyByOff || cr.Dedup.Enabled || cr.StripExif {
+			t.Errorf("classify.by = %q, dedup = %v, stripExif = %v, want 全部關閉", cr.Classify.By, cr.Dedup.Enabled, cr.StripExif)
+		}
+	})
+
+	t.Run("tar 保留 html 輸出", func(t *testing.T) {
+		cfg := DefaultConfig()
+		cfg.Crawler.Pack = PackTar
+		cfg.Crawler.Output.Format = OutputFormatHTML
+		cfg.validateAndFix()
+		if cfg.Crawler.Output.Format != OutputFormatHTML {
+			t.Errorf("output.format = %q, want %q", cfg.Crawler.Output.Format, OutputFormatHTML)
+		}
+	})
+}
+
+func TestLoad_AcceptFallbacks(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "accept.yaml")
+	content := "crawler:\n  acceptFallbacks:\n    - \"image/webp,image/*\"\n    - \"*/*\"\n"
+	if err := os.WriteFile(configPath, []byte(content), 0644); err != nil {
+		t.Fatalf("建立測試配置檔失敗: %v", err)
+	}
+
+	cfg, err := Load(configPath)
+	if err != nil {
+		t.Fatalf("Load() unexpected error = %v", err)
+	}
+	want := []string{"image/webp,image/*", "*/*"}
+	if !reflect.DeepEqual(cfg.Crawler.AcceptFallbacks, want) {
+		t.Errorf("acceptFallbacks = %v, want %v", cfg.Crawler.AcceptFallbacks, want)
+	}
+	if len(DefaultConfig().Crawler.AcceptFallbacks) != 0 {
+		t.Error("預設不應嘗試其他 Accept 標頭")
+	}
+}
+
+func TestValidateAndFix_UserAgents(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.Crawler.HTTP.UserAgents = []string{" UA-1 ", "", "   ", "UA-2"}
+	cfg.Crawler.HTTP.UserAgentRotation = "shuffle"
+	cfg.validateAndFix()
+
+	want := []string{"UA-1", "UA-2"}
+	if !reflect.DeepEqual(cfg.Crawler.HTTP.UserAgents, want) {
+		t.Errorf("userAgents = %q, want %q", cfg.Crawler.HTTP.UserAgents, want)
+	}
+	if cfg.Crawler.HTTP.UserAgentRotation != UARotationRoundRobin {
+		t.Errorf("userAgentRotation = %q, want %q", cfg.Crawler.HTTP.UserAgentRotation, UARotationRoundRobin)
+	}
+}
+
+func TestLoad_Retry(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "retry.yaml")
+	content := `crawler:
+  retry:
+    maxAttempts: 0
+    hosts:
+      " I.Imgur.com ":
+        retryable: true
+        maxAttempts: 5
+      dead.example.com:
+        retryable: false
+      slow.example.com:
+        maxAttempts: -1
+`
+	if err := os.WriteFile(configPath, []byte(content), 0644); err != nil {
+		t.Fatalf("建立測試配置檔失敗: %v", err)
+	}
+
+	cfg, err := Load(configPath)
+	if err != nil {
+		t.Fatalf("Load() unexpected error = %v", err)
+	}
+	retry := cfg.Crawler.Retry
+	if retry.MaxAttempts != DefaultConfig().Crawler.Retry.MaxAttempts {
+		t.Errorf("retry.maxAttempts = %d, want %d（非法值退回預設）", retry.MaxAttempts, DefaultConfig().Crawler.Retry.MaxAttempts)
+	}
+	want := map[string]HostRetryConfig{
+		"i.imgur.com":      {Retryable: new(true), MaxAttempts: 5},
+		"dead.example.com": {Retryable: new(false)},
+		"slow.example.com": {},
+	}
+	if !reflect.DeepEqual(retry.Hosts, want) {
+		t.Errorf("retry.hosts = %+v, want %+v", retry.Hosts, want)
+	}
+}
+
+func TestValidateAndFix_OutputFormat(t *testing.T) {
+	tests := []struct {
+		format string
+		want   string
+	}{
+		{OutputFormatMarkdown, OutputFormatMarkdown},
+		{OutputFormatHTML, OutputFormatHTML},
+		{OutputFormatBoth, OutputFormatBoth},
+		{"pdf", OutputFormatMarkdown},
+		{"", OutputFormatMarkdown},
+	}
+	for _, tt := range tests {
+		cfg := DefaultConfig()
+		cfg.Crawler.Output.Format = tt.format
+		cfg.validateAndFix()
+		if cfg.Crawler.Output.Format != tt.want {
+			t.Errorf("output.format %q 修正為 %q, want %q", tt.format, cfg.Crawler.Output.Format, tt.want)
+		}
+	}
+}
+
+func TestValidateAndFix_DuplicateTitles(t *testing.T) {
+	for _, tt := range []struct {
+		threshold float64
+		want      float64
+	}{
+		{0.8, 0.8},
+		{1, 1},
+		{0, 1},
+		{-0.5, 1},
+		{1.2, 1},
+	} {
+		cfg := DefaultConfig()
+		cfg.Crawler.DuplicateTitles.Threshold = tt.threshold
+		cfg.validateAndFix()
+		if got := cfg.Crawler.DuplicateTitles.Threshold; got != tt.want {
+			t.Errorf("duplicateTitles.threshold %v 修正為 %v, want %v", tt.threshold, got, tt.want)
+		}
+	}
+}
+
+func TestValidateAndFix_HeadPrecheck(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.Crawler.HeadPrecheck = HeadPrecheckConfig{Enabled: true, Workers: 0, MinBytes: -1}
+	cfg.validateAndFix()
+
+	want := HeadPrecheckConfig{Enabled: true, Workers: 8, MinBytes: 0}
+	if cfg.Crawler.HeadPrecheck != want {
+		t.Errorf("HeadPrecheck = %+v, want %+v", cfg.Crawler.HeadPrecheck, want)
+	}
+}
+
+func TestValidateAndFix_SavePushes(t *testing.T) {
+	tests := []struct {
+		value string
+		want  string
+	}{
+		{"", SavePushesOff},
+		{SavePushesMarkdown, SavePushesMarkdown},
+		{SavePushesJSON, SavePushesJSON},
+		{SavePushesBoth, SavePushesBoth},
+		{"yaml", SavePushesOff},
+	}
+	for _, tt := range tests {
+		cfg := DefaultConfig()
+		cfg.Crawler.SavePushes = tt.value
+		cfg.validateAndFix()
+		if cfg.Crawler.SavePushes != tt.want {
+			t.Errorf("savePushes %q 驗證後 = %q, want %q", tt.value, cfg.Crawler.SavePushes, tt.want)
+		}
+	}
+}
+
+func TestValidateAndFix_HostPatterns(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.Crawler.Hosts = HostFilterConfig{
+		Allow: []string{" *.IMGUR.com ", "", "[bad"},
+		Deny:  []string{"M.Imgur.com"},
+	}
+	cfg.validateAndFix()
+	if got, want := cfg.Crawler.Hosts.Allow, []string{"*.imgur.com"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("hosts.allow = %q, want %q", got, want)
+	}
+	if got, want := cfg.Crawler.Hosts.Deny, []string{"m.imgur.com"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("hosts.deny = %q, want %q", got, want)
+	}
+}
+
+func TestValidateAndFix_LinkFilterPatterns(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.Crawler.LinkFilter.IgnorePatterns = []string{`/emoticon/`, " ", `i\.imgur\.com/(`}
+	if err := cfg.Validate(); err == nil || !strings.Contains(err.Error(), "linkFilter.ignorePatterns") {
+		t.Errorf("Validate() error = %v, want 指出 linkFilter.ignorePatterns", err)
+	}
+	cfg.validateAndFix()
+	if got, want := cfg.Crawler.LinkFilter.IgnorePatterns, []string{`/emoticon/`}; !reflect.DeepEqual(got, want) {
+		t.Errorf("linkFilter.ignorePatterns = %q, want %q", got, want)
+	}
+}
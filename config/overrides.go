@@ -0,0 +1,131 @@
+package config
+
+import (
+	"flag"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// 可覆寫配置的命令列參數名稱
+const (
+	FlagWorkers     = "workers"
+	FlagParserCount = "parser-count"
+	FlagMinDelay    = "min-delay"
+	FlagMaxDelay    = "max-delay"
+	FlagTimeout     = "timeout"
+)
+
+// Overrides 是命令列參數對配置的覆寫，nil 表示未指定該參數（沿用配置檔與 -mode 策略的值）。
+// 優先順序由低到高：內建預設值 → 配置檔 → -mode 策略 → 命令列參數。
+type Overrides struct {
+	Workers     *int           // -workers：固定的下載工人數（同時停用 autoScale）
+	ParserCount *int           // -parser-count：內容解析器數量
+	MinDelayMs  *int           // -min-delay：下載前延遲下限（毫秒）
+	MaxDelayMs  *int           // -max-delay：下載前延遲上限（毫秒）
+	Timeout     *time.Duration // -timeout：HTTP 請求超時時間
+}
+
+// visitedFlags 以 fs.Visit 回傳命令列上明確指定的參數（依名稱排序）
+func visitedFlags(fs *flag.FlagSet) []*flag.Flag {
+	var set []*flag.Flag
+	fs.Visit(func(f *flag.Flag) { set = append(set, f) })
+	return set
+}
+
+// IsFlagSet 回傳命令列上是否明確指定了 name 參數
+func IsFlagSet(fs *flag.FlagSet, name string) bool {
+	return slices.ContainsFunc(visitedFlags(fs), func(f *flag.Flag) bool { return f.Name == name })
+}
+
+// OverridesFromFlags 以 visitedFlags 找出命令列上明確指定的覆寫參數並解析其值。
+// 只看明確指定的參數，參數的預設值不會蓋掉配置檔的設定；fs 中未定義的覆寫參數直接略過。
+func OverridesFromFlags(fs *flag.FlagSet) (Overrides, error) {
+	var (
+		o    Overrides
+		errs []string
+	)
+	parseInt := func(f *flag.Flag) *int {
+		n, err := strconv.Atoi(f.Value.String())
+		if err != nil {
+			errs = append(errs, fmt.Sprintf("-%s 的值 %q 不是整數", f.Name, f.Value.String()))
+			return nil
+		}
+		return &n
+	}
+	for _, f := range visitedFlags(fs) {
+		switch f.Name {
+		case FlagWorkers:
+			o.Workers = parseInt(f)
+		case FlagParserCount:
+			o.ParserCount = parseInt(f)
+		case FlagMinDelay:
+			o.MinDelayMs = parseInt(f)
+		case FlagMaxDelay:
+			o.MaxDelayMs = parseInt(f)
+		case FlagTimeout:
+			d, err := time.ParseDuration(f.Value.String())
+			if err != nil {
+				errs = append(errs, fmt.Sprintf("-%s 的值 %q 無法解析為時間長度（如 \"30s\"）", f.Name, f.Value.String()))
+				continue
+			}
+			o.Timeout = &d
+		}
+	}
+	if len(errs) > 0 {
+		return Overrides{}, fmt.Errorf("%s", strings.Join(errs, "；"))
+	}
+	return o, nil
+}
+
+// ApplyOverrides 以命令列參數覆寫配置。與配置檔不同，非法值不會退回預設值而是回傳錯誤，
+// 此時配置維持不變；覆寫後 delays 的下限大於上限（如只指定 -min-delay 而超過配置檔的 maxMs）同樣視為錯誤。
+func (c *Config) ApplyOverrides(o Overrides) error {
+	var errs []string
+	if o.Workers != nil && *o.Workers < 1 {
+		errs = append(errs, fmt.Sprintf("-%s 的值 %d 非法（最小值 1）", FlagWorkers, *o.Workers))
+	}
+	if o.ParserCount != nil && *o.ParserCount < 1 {
+		errs = append(errs, fmt.Sprintf("-%s 的值 %d 非法（最小值 1）", FlagParserCount, *o.ParserCount))
+	}
+	if o.MinDelayMs != nil && *o.MinDelayMs < 0 {
+		errs = append(errs, fmt.Sprintf("-%s 的值 %d 非法（最小值 0）", FlagMinDelay, *o.MinDelayMs))
+	}
+	if o.MaxDelayMs != nil && *o.MaxDelayMs < 0 {
+		errs = append(errs, fmt.Sprintf("-%s 的值 %d 非法（最小值 0）", FlagMaxDelay, *o.MaxDelayMs))
+	}
+	if o.Timeout != nil && *o.Timeout <= 0 {
+		errs = append(errs, fmt.Sprintf("-%s 的值 %v 非法（需大於 0）", FlagTimeout, *o.Timeout))
+	}
+
+	delays := c.Crawler.Delays
+	if o.MinDelayMs != nil {
+		delays.MinMs = *o.MinDelayMs
+	}
+	if o.MaxDelayMs != nil {
+		delays.MaxMs = *o.MaxDelayMs
+	}
+	if (o.MinDelayMs != nil || o.MaxDelayMs != nil) && delays.MinMs > delays.MaxMs {
+		errs = append(errs, fmt.Sprintf("覆寫後延遲下限 %dms 大於上限 %dms（請同時指定 -%s 與 -%s）",
+			delays.MinMs, delays.MaxMs, FlagMinDelay, FlagMaxDelay))
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("命令列參數錯誤: %s", strings.Join(errs, "；"))
+	}
+
+	if o.Workers != nil {
+		c.Crawler.Workers = FixedWorkers(*o.Workers)
+		c.Crawler.AutoScale.Enabled = false
+	}
+	if o.ParserCount != nil {
+		c.Crawler.ParserCount = *o.ParserCount
+	}
+	c.Crawler.Delays = delays
+	if o.Timeout != nil {
+		c.Crawler.HTTP.Timeout = o.Timeout.String()
+		c.Crawler.HTTP.parseHTTPDurations()
+	}
+	return nil
+}
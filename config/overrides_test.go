@@ -0,0 +1,148 @@
+package config
+
+import (
+	"flag"
+	"io"
+	"strings"
+	"testing"
+	"time"
+)
+
+// newOverrideFlags 建立與 main 相同定義的覆寫參數並解析 args
+func newOverrideFlags(t *testing.T, args ...string) *flag.FlagSet {
+	t.Helper()
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	fs.Int(FlagWorkers, 0, "")
+	fs.Int(FlagParserCount, 0, "")
+	fs.Int(FlagMinDelay, 0, "")
+	fs.Int(FlagMaxDelay, 0, "")
+	fs.Duration(FlagTimeout, 0, "")
+	fs.String("board", "Beauty", "")
+	if err := fs.Parse(args); err != nil {
+		t.Fatalf("解析參數失敗: %v", err)
+	}
+	return fs
+}
+
+func TestOverridesFromFlags(t *testing.T) {
+	t.Run("未指定任何覆寫參數", func(t *testing.T) {
+		o, err := OverridesFromFlags(newOverrideFlags(t, "-board", "Gossiping"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if o != (Overrides{}) {
+			t.Errorf("Overrides = %+v, want 全部為 nil", o)
+		}
+	})
+
+	t.Run("只收集明確指定的參數", func(t *testing.T) {
+		o, err := OverridesFromFlags(newOverrideFlags(t, "-workers", "8", "-min-delay=0", "-timeout", "10s"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if o.Workers == nil || *o.Workers != 8 {
+			t.Errorf("Workers = %v, want 8", o.Workers)
+		}
+		// 明確指定為 0 與未指定不同
+		if o.MinDelayMs == nil || *o.MinDelayMs != 0 {
+			t.Errorf("MinDelayMs = %v, want 0", o.MinDelayMs)
+		}
+		if o.Timeout == nil || *o.Timeout != 10*time.Second {
+			t.Errorf("Timeout = %v, want 10s", o.Timeout)
+		}
+		if o.ParserCount != nil || o.MaxDelayMs != nil {
+			t.Errorf("未指定的參數應為 nil: %+v", o)
+		}
+	})
+}
+
+func TestIsFlagSet(t *testing.T) {
+	fs := newOverrideFlags(t, "-board=Beauty", "-workers", "4")
+	for name, want := range map[string]bool{"board": true, FlagWorkers: true, FlagTimeout: false, "undefined": false} {
+		if got := IsFlagSet(fs, name); got != want {
+			t.Errorf("IsFlagSet(%q) = %v, want %v", name, got, want)
+		}
+	}
+}
+
+func TestApplyOverrides(t *testing.T) {
+	intPtr := func(n int) *int { return &n }
+
+	t.Run("未指定時保留配置檔的值", func(t *testing.T) {
+		cfg := DefaultConfig()
+		cfg.Crawler.Workers = WorkersConfig{Min: 2, Max: 12}
+		cfg.Crawler.Delays = DelayConfig{MinMs: 300, MaxMs: 900}
+		if err := cfg.ApplyOverrides(Overrides{}); err != nil {
+			t.Fatal(err)
+		}
+		if cfg.Crawler.Workers != (WorkersConfig{Min: 2, Max: 12}) || cfg.Crawler.Delays != (DelayConfig{MinMs: 300, MaxMs: 900}) {
+			t.Errorf("配置不應改變: %+v", cfg.Crawler)
+		}
+	})
+
+	t.Run("覆寫指定的欄位", func(t *testing.T) {
+		cfg := DefaultConfig()
+		cfg.Crawler.AutoScale = AutoScaleConfig{Enabled: true, Min: 2}
+		cfg.Crawler.Delays = DelayConfig{MinMs: 300, MaxMs: 900}
+		timeout := 5 * time.Second
+		err := cfg.ApplyOverrides(Overrides{Workers: intPtr(4), ParserCount: intPtr(2), MaxDelayMs: intPtr(2000), Timeout: &timeout})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if cfg.Crawler.Workers != FixedWorkers(4) || cfg.Crawler.AutoScale.Enabled {
+			t.Errorf("-workers 應設為固定 4 個並停用 autoScale: workers=%v autoScale=%v", cfg.Crawler.Workers, cfg.Crawler.AutoScale.Enabled)
+		}
+		if cfg.Crawler.ParserCount != 2 {
+			t.Errorf("ParserCount = %d, want 2", cfg.Crawler.ParserCount)
+		}
+		// 只指定上限時下限沿用配置檔
+		if cfg.Crawler.Delays != (DelayConfig{MinMs: 300, MaxMs: 2000}) {
+			t.Errorf("Delays = %+v, want {300 2000}", cfg.Crawler.Delays)
+		}
+		if cfg.GetTimeoutDuration() != timeout || cfg.Crawler.HTTP.Timeout != "5s" {
+			t.Errorf("timeout = %v（%q）, want 5s", cfg.GetTimeoutDuration(), cfg.Crawler.HTTP.Timeout)
+		}
+	})
+
+	t.Run("非法值回傳錯誤且不修改配置", func(t *testing.T) {
+		zero := time.Duration(0)
+		tests := []struct {
+			name string
+			o    Overrides
+			want string
+		}{
+			{"workers 為 0", Overrides{Workers: intPtr(0)}, "-workers"},
+			{"parser-count 為負數", Overrides{ParserCount: intPtr(-1)}, "-parser-count"},
+			{"min-delay 為負數", Overrides{MinDelayMs: intPtr(-5)}, "-min-delay"},
+			{"timeout 為 0", Overrides{Timeout: &zero}, "-timeout"},
+			{"只指定 min-delay 且超過配置檔的上限", Overrides{MinDelayMs: intPtr(5000)}, "大於上限"},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				cfg := DefaultConfig()
+				before := cfg.Crawler.Delays
+				err := cfg.ApplyOverrides(tt.o)
+				if err == nil || !strings.Contains(err.Error(), tt.want) {
+					t.Fatalf("err = %v, want 包含 %q", err, tt.want)
+				}
+				if cfg.Crawler.Delays != before || cfg.Crawler.Workers != DefaultConfig().Crawler.Workers {
+					t.Errorf("錯誤時不應修改配置: %+v", cfg.Crawler)
+				}
+			})
+		}
+	})
+
+	t.Run("命令列優先於 -mode 策略", func(t *testing.T) {
+		cfg := DefaultConfig()
+		if err := cfg.ApplyProfile("gentle"); err != nil {
+			t.Fatal(err)
+		}
+		if err := cfg.ApplyOverrides(Overrides{Workers: intPtr(7)}); err != nil {
+			t.Fatal(err)
+		}
+		if cfg.Crawler.Workers != FixedWorkers(7) {
+			t.Errorf("Workers = %v, want 7", cfg.Crawler.Workers)
+		}
+	})
+}
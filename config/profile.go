@@ -0,0 +1,119 @@
+package config
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+)
+
+// ProfileConfig 爬取策略：只覆寫有設定的欄位，未設定（nil）的欄位沿用 crawler 的原設定.
+type ProfileConfig struct {
+	Workers            *int             `yaml:"workers"`            // 並行下載工作者數量
+	ParserCount        *int             `yaml:"parserCount"`        // 內容解析器數量
+	ArticleConcurrency *int             `yaml:"articleConcurrency"` // article 模式單篇文章同時下載的圖片數上限
+	Delays             *DelayConfig     `yaml:"delays"`             // 請求間隔
+	RateLimit          *RateLimitConfig `yaml:"rateLimit"`          // 全域請求速率限制
+}
+
+// 內建的爬取策略名稱
+const (
+	ProfileAggressive = "aggressive"
+	ProfileNormal     = "normal"
+	ProfileGentle     = "gentle"
+)
+
+// builtinProfiles 回傳內建的爬取策略，每次呼叫都建立新值，避免套用時共用指標.
+// normal 與 DefaultConfig 相同；aggressive 提高並行度並縮短延遲；gentle 降低並行度並限制每秒請求數
+func builtinProfiles() map[string]ProfileConfig {
+	return map[string]ProfileConfig{
+		ProfileAggressive: {
+			Workers:     new(20),
+			ParserCount: new(20),
+			Delays:      &DelayConfig{MinMs: 100, MaxMs: 500},
+			RateLimit:   &RateLimitConfig{RequestsPerSecond: 0, Burst: 1},
+		},
+		ProfileNormal: {
+			Workers:            new(10),
+			ParserCount:        new(10),
+			ArticleConcurrency: new(4),
+			Delays:             &DelayConfig{MinMs: 500, MaxMs: 2000},
+			RateLimit:          &RateLimitConfig{RequestsPerSecond: 0, Burst: 1},
+		},
+		ProfileGentle: {
+			Workers:            new(3),
+			ParserCount:        new(2),
+			ArticleConcurrency: new(2),
+			Delays:             &DelayConfig{MinMs: 1500, MaxMs: 4000},
+			RateLimit:          &RateLimitConfig{RequestsPerSecond: 1, Burst: 1},
+		},
+	}
+}
+
+// overlay 以 o 中有設定的欄位覆寫 p，回傳合併後的策略
+func (p ProfileConfig) overlay(o ProfileConfig) ProfileConfig {
+	if o.Workers != nil {
+		p.Workers = o.Workers
+	}
+	if o.ParserCount != nil {
+		p.ParserCount = o.ParserCount
+	}
+	if o.ArticleConcurrency != nil {
+		p.ArticleConcurrency = o.ArticleConcurrency
+	}
+	if o.Delays != nil {
+		p.Delays = o.Delays
+	}
+	if o.RateLimit != nil {
+		p.RateLimit = o.RateLimit
+	}
+	return p
+}
+
+// ProfileNames 回傳可用的策略名稱（內建與配置檔 profiles 自訂），依字母排序.
+func (c *Config) ProfileNames() []string {
+	names := builtinProfiles()
+	for name := range c.Crawler.Profiles {
+		names[name] = ProfileConfig{}
+	}
+	return slices.Sorted(maps.Keys(names))
+}
+
+// ApplyProfile 套用指定的爬取策略（名稱不分大小寫）。
+// 配置檔 profiles 中與內建策略同名者，逐欄覆寫內建值；未設定的欄位沿用內建值。
+// 套用後重新驗證數值，非法值同樣退回預設。
+func (c *Config) ApplyProfile(name string) error {
+	key := strings.ToLower(name)
+	profile, builtin := builtinProfiles()[key]
+	custom, ok := c.Crawler.Profiles[name]
+	if !ok {
+		custom, ok = c.Crawler.Profiles[key]
+	}
+	if !builtin && !ok {
+		return fmt.Errorf("未知的爬取策略 %q（可用值: %s）", name, strings.Join(c.ProfileNames(), "、"))
+	}
+	profile = profile.overlay(custom)
+
+	if profile.Workers != nil {
+		c.Crawler.Workers = FixedWorkers(*profile.Workers)
+	}
+	if profile.ParserCount != nil {
+		c.Crawler.ParserCount = *profile.ParserCount
+	}
+	if profile.ArticleConcurrency != nil {
+		c.Crawler.ArticleConcurrency = *profile.ArticleConcurrency
+	}
+	if profile.Delays != nil {
+		c.Crawler.Delays = *profile.Delays
+	}
+	if profile.RateLimit != nil {
+		rl := *profile.RateLimit
+		// 策略只調整預設速率，未指定 hosts 時保留配置檔的各 host 速率桶
+		if rl.Hosts == nil {
+			rl.Hosts = c.Crawler.RateLimit.Hosts
+		}
+		c.Crawler.RateLimit = rl
+	}
+	c.validateAndFix()
+	return nil
+}
@@ -0,0 +1,135 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestApplyProfile_Builtin(t *testing.T) {
+	tests := []struct {
+		name        string
+		workers     int
+		parserCount int
+		delays      DelayConfig
+		rps         float64
+	}{
+		{ProfileAggressive, 20, 20, DelayConfig{MinMs: 100, MaxMs: 500}, 0},
+		{ProfileNormal, 10, 10, DelayConfig{MinMs: 500, MaxMs: 2000}, 0},
+		{"Gentle", 3, 2, DelayConfig{MinMs: 1500, MaxMs: 4000}, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := DefaultConfig()
+			cfg.Crawler.Workers = FixedWorkers(7)
+			if err := cfg.ApplyProfile(tt.name); err != nil {
+				t.Fatalf("ApplyProfile(%q) error = %v", tt.name, err)
+			}
+			if cfg.Crawler.Workers != FixedWorkers(tt.workers) || cfg.Crawler.ParserCount != tt.parserCount {
+				t.Errorf("workers/parserCount = %d/%d, want %d/%d",
+					cfg.Crawler.Workers, cfg.Crawler.ParserCount, tt.workers, tt.parserCount)
+			}
+			if cfg.Crawler.Delays != tt.delays {
+				t.Errorf("delays = %+v, want %+v", cfg.Crawler.Delays, tt.delays)
+			}
+			if cfg.Crawler.RateLimit.RequestsPerSecond != tt.rps {
+				t.Errorf("rateLimit.requestsPerSecond = %v, want %v", cfg.Crawler.RateLimit.RequestsPerSecond, tt.rps)
+			}
+		})
+	}
+}
+
+func TestApplyProfile_NormalMatchesDefaults(t *testing.T) {
+	cfg := DefaultConfig()
+	if err := cfg.ApplyProfile(ProfileNormal); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(cfg.Crawler, DefaultConfig().Crawler) {
+		t.Error("normal 策略應與預設配置相同")
+	}
+}
+
+func TestApplyProfile_ConfigOverrides(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "profiles.yaml")
+	content := `crawler:
+  workers: 8
+  profiles:
+    gentle:
+      workers: 5
+    night:
+      delays:
+        minMs: 3000
+        maxMs: 6000
+`
+	if err := os.WriteFile(configPath, []byte(content), 0644); err != nil {
+		t.Fatalf("建立測試配置檔失敗: %v", err)
+	}
+
+	cfg, err := Load(configPath)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if err := cfg.ApplyProfile(ProfileGentle); err != nil {
+		t.Fatal(err)
+	}
+	// 自訂值覆寫內建 gentle，未設定的欄位沿用內建值
+	if cfg.Crawler.Workers != FixedWorkers(5) {
+		t.Errorf("workers = %v, want 5", cfg.Crawler.Workers)
+	}
+	if cfg.Crawler.ParserCount != 2 || cfg.Crawler.RateLimit.RequestsPerSecond != 1 {
+		t.Errorf("未覆寫的欄位應沿用內建 gentle: parserCount=%d rps=%v",
+			cfg.Crawler.ParserCount, cfg.Crawler.RateLimit.RequestsPerSecond)
+	}
+
+	cfg, _ = Load(configPath)
+	if err := cfg.ApplyProfile("night"); err != nil {
+		t.Fatal(err)
+	}
+	// 自訂策略只改有設定的欄位，其餘沿用配置檔
+	if cfg.Crawler.Delays != (DelayConfig{MinMs: 3000, MaxMs: 6000}) || cfg.Crawler.Workers != FixedWorkers(8) {
+		t.Errorf("delays = %+v, workers = %v; want {3000 6000}, 8", cfg.Crawler.Delays, cfg.Crawler.Workers)
+	}
+
+	want := []string{"aggressive", "gentle", "night", "normal"}
+	if got := cfg.ProfileNames(); !reflect.DeepEqual(got, want) {
+		t.Errorf("ProfileNames() = %v, want %v", got, want)
+	}
+}
+
+func TestApplyProfile_KeepsRateLimitHosts(t *testing.T) {
+	cfg := DefaultConfig()
+	hosts := map[string]HostRateLimitConfig{"i.imgur.com": {RequestsPerSecond: 1, Burst: 2}}
+	cfg.Crawler.RateLimit.Hosts = hosts
+	if err := cfg.ApplyProfile("gentle"); err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Crawler.RateLimit.RequestsPerSecond != 1 {
+		t.Errorf("rateLimit.requestsPerSecond = %v, want 1", cfg.Crawler.RateLimit.RequestsPerSecond)
+	}
+	if !reflect.DeepEqual(cfg.Crawler.RateLimit.Hosts, hosts) {
+		t.Errorf("rateLimit.hosts = %+v, want %+v（策略未指定 hosts 時應保留）", cfg.Crawler.RateLimit.Hosts, hosts)
+	}
+}
+
+func TestApplyProfile_Unknown(t *testing.T) {
+	cfg := DefaultConfig()
+	if err := cfg.ApplyProfile("turbo"); err == nil {
+		t.Error("未知的策略應回傳錯誤")
+	}
+	if cfg.Crawler.Workers != DefaultConfig().Crawler.Workers {
+		t.Error("套用失敗時不應修改配置")
+	}
+}
+
+func TestApplyProfile_InvalidValuesFixed(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.Crawler.Profiles = map[string]ProfileConfig{"broken": {Workers: new(0)}}
+	if err := cfg.ApplyProfile("broken"); err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Crawler.Workers != DefaultConfig().Crawler.Workers {
+		t.Errorf("非法的 workers 應退回預設值，got %v", cfg.Crawler.Workers)
+	}
+}
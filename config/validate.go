@@ -0,0 +1,150 @@
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"time"
+
+	yaml "gopkg.in/yaml.v3"
+)
+
+// validation 收集驗證配置時發現的問題。invalid 是非法或無法解析的值（嚴格模式下載入失敗），
+// adjusted 是為了與其他設定相容而自動調整的值（如 pack 改為 article 模式），兩者都會修正後記錄警告。
+type validation struct {
+	invalidMsgs  []string
+	adjustedMsgs []string
+}
+
+// invalid 記錄一個非法值
+func (v *validation) invalid(msg string) {
+	v.invalidMsgs = append(v.invalidMsgs, msg)
+}
+
+// adjust 記錄一個因相容性而調整的值
+func (v *validation) adjust(msg string) {
+	v.adjustedMsgs = append(v.adjustedMsgs, msg)
+}
+
+// fixInt 檢查數值是否小於下限，是則記錄為非法值並回傳預設值
+func (v *validation) fixInt(value, minAllowed, defaultVal int, name string) int {
+	if value < minAllowed {
+		v.invalid(fmt.Sprintf("配置 %s 的值 %d 非法（最小值 %d），退回預設值 %d", name, value, minAllowed, defaultVal))
+		return defaultVal
+	}
+	return value
+}
+
+// fixDuration 檢查時間長度字串可解析且不小於 minAllowed（positive 為 true 時需大於 minAllowed），否則退回預設值
+func (v *validation) fixDuration(value *string, defaultVal string, minAllowed time.Duration, positive bool, name string) {
+	d, err := time.ParseDuration(*value)
+	switch {
+	case err != nil:
+		v.invalid(fmt.Sprintf("配置 %s 的值 %q 無法解析為時間長度（如 \"30s\"、\"5m\"），退回預設值 %q", name, *value, defaultVal))
+	case positive && d <= minAllowed:
+		v.invalid(fmt.Sprintf("配置 %s 的值 %q 非法（需大於 %v），退回預設值 %q", name, *value, minAllowed, defaultVal))
+	case d < minAllowed:
+		v.invalid(fmt.Sprintf("配置 %s 的值 %q 非法（最小值 %v），退回預設值 %q", name, *value, minAllowed, defaultVal))
+	default:
+		return
+	}
+	*value = defaultVal
+}
+
+// fixDurations 驗證所有以字串設定的時間長度，修正後重新解析 HTTP 相關的時間長度
+func (c *Config) fixDurations(v *validation, defaults *Config) {
+	cr, def := &c.Crawler, &defaults.Crawler
+	v.fixDuration(&cr.HTTP.Timeout, def.HTTP.Timeout, 0, false, "http.timeout")
+	v.fixDuration(&cr.HTTP.IdleConnTimeout, def.HTTP.IdleConnTimeout, 0, false, "http.idleConnTimeout")
+	v.fixDuration(&cr.HTTP.TLSHandshakeTimeout, def.HTTP.TLSHandshakeTimeout, 0, false, "http.tlsHandshakeTimeout")
+	v.fixDuration(&cr.HTTP.ExpectContinueTimeout, def.HTTP.ExpectContinueTimeout, 0, false, "http.expectContinueTimeout")
+	v.fixDuration(&cr.Alert.Window, def.Alert.Window, 0, true, "alert.window")
+	v.fixDuration(&cr.Alert.Cooldown, def.Alert.Cooldown, 0, false, "alert.cooldown")
+	v.fixDuration(&cr.WorkerIdleWarn, def.WorkerIdleWarn, 0, false, "workerIdleWarn")
+	v.fixDuration(&cr.ShutdownGrace, def.ShutdownGrace, 0, false, "shutdownGrace")
+	v.fixDuration(&cr.ShutdownTimeout, def.ShutdownTimeout, 0, false, "shutdownTimeout")
+	v.fixDuration(&cr.ArticleTimeout, def.ArticleTimeout, 0, false, "articleTimeout")
+	v.fixDuration(&cr.Cache.TTL, def.Cache.TTL, 0, true, "cache.ttl")
+	cr.HTTP.parseHTTPDurations()
+}
+
+// validateShutdown 確保 shutdownTimeout 大於 shutdownGrace：否則 main 的 watchShutdown
+// 會在 Markdown 工人的寬限期內強制結束程式，已排入的輸出檔來不及寫入。
+// 需在 fixDurations 之後呼叫，兩者皆已可解析；shutdownTimeout 為 "0"（不限時）時不需調整。
+func (c *Config) validateShutdown(v *validation, defaults *Config) {
+	cr := &c.Crawler
+	grace, _ := time.ParseDuration(cr.ShutdownGrace)
+	timeout, _ := time.ParseDuration(cr.ShutdownTimeout)
+	if timeout == 0 || timeout > grace {
+		return
+	}
+	// 在寬限期之後保留與預設值相同的收尾時間
+	slack, _ := time.ParseDuration(defaults.Crawler.ShutdownTimeout)
+	raised := grace + slack
+	v.adjust(fmt.Sprintf("配置 shutdownTimeout %q 未大於 shutdownGrace %q，中斷時會在寬限期內強制結束，shutdownTimeout 改為 %q",
+		cr.ShutdownTimeout, cr.ShutdownGrace, raised.String()))
+	cr.ShutdownTimeout = raised.String()
+}
+
+// log 將每個問題各記錄為一筆警告
+func (v *validation) log() {
+	for _, msg := range v.invalidMsgs {
+		slog.Warn(msg)
+	}
+	for _, msg := range v.adjustedMsgs {
+		slog.Warn(msg)
+	}
+}
+
+// err 將所有非法值合併為一個錯誤，沒有非法值時回傳 nil；相容性調整不算錯誤
+func (v *validation) err() error {
+	if len(v.invalidMsgs) == 0 {
+		return nil
+	}
+	errs := make([]error, len(v.invalidMsgs))
+	for i, msg := range v.invalidMsgs {
+		errs[i] = errors.New(msg)
+	}
+	return fmt.Errorf("配置驗證失敗（共 %d 項）:\n%w", len(errs), errors.Join(errs...))
+}
+
+// Validate 檢查配置中的非法值（如 workers 小於 1、delays.minMs 大於 maxMs、channel 大小為負數、
+// 無法解析的時間長度、不支援的列舉值），不修改配置；沒有問題時回傳 nil。
+// 回傳的錯誤以 errors.Join 合併，每個問題一行，並說明未啟用嚴格模式時的修正方式。
+func (c *Config) Validate() error {
+	// 在副本上驗證，避免修正動作改寫呼叫方的配置
+	data, err := yaml.Marshal(c)
+	if err != nil {
+		return fmt.Errorf("複製配置失敗: %w", err)
+	}
+	clone := &Config{}
+	if err := yaml.Unmarshal(data, clone); err != nil {
+		return fmt.Errorf("複製配置失敗: %w", err)
+	}
+	return clone.fix().err()
+}
+
+// LoadOption 定義 Load 的可選配置函式
+type LoadOption func(*loadOptions)
+
+type loadOptions struct {
+	strict bool
+}
+
+// WithStrict 啟用嚴格模式：配置檔含未知的鍵（如拼錯的設定名稱）或非法值時 Load 回傳錯誤，
+// 而不是記錄警告後退回預設值
+func WithStrict(strict bool) LoadOption {
+	return func(o *loadOptions) { o.strict = strict }
+}
+
+// decodeYAML 將配置檔內容解析到 cfg，嚴格模式下不接受未知的鍵
+func decodeYAML(data []byte, cfg *Config, strict bool) error {
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(strict)
+	if err := dec.Decode(cfg); err != nil && !errors.Is(err, io.EOF) {
+		return err
+	}
+	return nil
+}
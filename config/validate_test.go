@@ -0,0 +1,146 @@
+package config
+
+import (
+	"bytes"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// writeConfig 將內容寫入暫存配置檔並回傳路徑
+func writeConfig(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("建立測試配置檔失敗: %v", err)
+	}
+	return path
+}
+
+// captureWarnings 在 fn 執行期間將 slog 預設 logger 導向緩衝區，回傳記錄的警告行
+func captureWarnings(t *testing.T, fn func()) []string {
+	t.Helper()
+	var buf bytes.Buffer
+	old := slog.Default()
+	slog.SetDefault(slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelWarn})))
+	defer slog.SetDefault(old)
+	fn()
+	return strings.Split(strings.TrimSpace(buf.String()), "\n")
+}
+
+func TestValidate_Default(t *testing.T) {
+	if err := DefaultConfig().Validate(); err != nil {
+		t.Errorf("預設配置不應有驗證錯誤: %v", err)
+	}
+}
+
+func TestValidate_ReportsEachIssue(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.Crawler.Workers = FixedWorkers(0)
+	cfg.Crawler.Delays = DelayConfig{MinMs: 3000, MaxMs: 1000}
+	cfg.Crawler.Channels.DownloadTask = -1
+	cfg.Crawler.HTTP.Timeout = "30 秒"
+	cfg.Crawler.Cache.TTL = "0s"
+	cfg.Crawler.DownloadMode = "parallel"
+
+	err := cfg.Validate()
+	if err == nil {
+		t.Fatal("應回傳驗證錯誤")
+	}
+	for _, key := range []string{"workers", "delays.minMs", "channels.downloadTask", "http.timeout", "cache.ttl", "downloadMode"} {
+		if !strings.Contains(err.Error(), "配置 "+key+" ") {
+			t.Errorf("錯誤訊息應包含 %s:\n%v", key, err)
+		}
+	}
+	if !strings.Contains(err.Error(), "共 6 項") {
+		t.Errorf("錯誤訊息應列出 6 項問題:\n%v", err)
+	}
+
+	// Validate 不修改原配置
+	if cfg.Crawler.Workers != FixedWorkers(0) || cfg.Crawler.Delays.MaxMs != 1000 || cfg.Crawler.HTTP.Timeout != "30 秒" {
+		t.Errorf("Validate 不應修改配置: %+v", cfg.Crawler)
+	}
+}
+
+func TestValidate_AdjustmentsAreNotErrors(t *testing.T) {
+	// pack: tar 自動改為 article 模式屬於相容性調整，不是非法值
+	cfg := DefaultConfig()
+	cfg.Crawler.Pack = PackTar
+	if err := cfg.Validate(); err != nil {
+		t.Errorf("相容性調整不應視為錯誤: %v", err)
+	}
+}
+
+func TestLoad_Strict(t *testing.T) {
+	t.Run("未知的鍵", func(t *testing.T) {
+		path := writeConfig(t, "crawler:\n  wokers: 5\n")
+		if _, err := Load(path, WithStrict(true)); err == nil || !strings.Contains(err.Error(), "wokers") {
+			t.Errorf("嚴格模式應回報未知的鍵，err = %v", err)
+		}
+		// 非嚴格模式忽略未知的鍵
+		if _, err := Load(path); err != nil {
+			t.Errorf("非嚴格模式不應回傳錯誤: %v", err)
+		}
+	})
+
+	t.Run("非法值", func(t *testing.T) {
+		path := writeConfig(t, "crawler:\n  delays:\n    minMs: 3000\n    maxMs: 1000\n  alert:\n    window: soon\n")
+		cfg, err := Load(path, WithStrict(true))
+		if err == nil || cfg != nil {
+			t.Fatalf("嚴格模式應回傳錯誤，cfg = %v, err = %v", cfg, err)
+		}
+		if !strings.Contains(err.Error(), "delays.minMs") || !strings.Contains(err.Error(), "alert.window") {
+			t.Errorf("錯誤訊息應包含所有問題:\n%v", err)
+		}
+	})
+
+	t.Run("合法配置", func(t *testing.T) {
+		path := writeConfig(t, "crawler:\n  workers: {min: 2, max: 8}\n  http:\n    timeout: 10s\n")
+		cfg, err := Load(path, WithStrict(true))
+		if err != nil {
+			t.Fatalf("合法配置不應回傳錯誤: %v", err)
+		}
+		if cfg.GetTimeoutDuration().String() != "10s" {
+			t.Errorf("timeout = %v, want 10s", cfg.GetTimeoutDuration())
+		}
+	})
+
+	t.Run("空檔案", func(t *testing.T) {
+		if _, err := Load(writeConfig(t, ""), WithStrict(true)); err != nil {
+			t.Errorf("空配置檔不應回傳錯誤: %v", err)
+		}
+	})
+}
+
+// TestLoad_StrictRepoConfig 確保專案附帶的 config.yaml 在嚴格模式下可以載入
+func TestLoad_StrictRepoConfig(t *testing.T) {
+	if _, err := Load("../config.yaml", WithStrict(true)); err != nil {
+		t.Errorf("config.yaml 應通過嚴格驗證: %v", err)
+	}
+}
+
+func TestLoad_NonStrictLogsEachIssue(t *testing.T) {
+	path := writeConfig(t, "crawler:\n  delays:\n    minMs: 3000\n    maxMs: 1000\n  http:\n    timeout: abc\n  shutdownGrace: -1s\n")
+	var cfg *Config
+	warnings := captureWarnings(t, func() {
+		var err error
+		if cfg, err = Load(path); err != nil {
+			t.Fatalf("Load() unexpected error = %v", err)
+		}
+	})
+	if len(warnings) != 3 {
+		t.Errorf("應逐項記錄 3 筆警告，實際:\n%s", strings.Join(warnings, "\n"))
+	}
+
+	if cfg.Crawler.Delays.MaxMs != 3000 {
+		t.Errorf("delays.maxMs = %d, want 3000", cfg.Crawler.Delays.MaxMs)
+	}
+	if got := cfg.GetTimeoutDuration().String(); got != "30s" {
+		t.Errorf("timeout = %s, want 30s", got)
+	}
+	if cfg.Crawler.ShutdownGrace != "5s" {
+		t.Errorf("shutdownGrace = %q, want 5s", cfg.Crawler.ShutdownGrace)
+	}
+}
@@ -0,0 +1,66 @@
+package config
+
+import (
+	"context"
+	"os"
+	"reflect"
+	"strings"
+	"time"
+
+	yaml "gopkg.in/yaml.v3"
+)
+
+// Watch 每隔 interval 檢查配置檔的修改時間與大小，有變更時重新 Load 並呼叫 onChange，
+// 直到 ctx 取消。讀取或解析失敗時以 error 通知（cfg 為 nil），檔案再次變更時會重試；opts 同樣套用於每次 Load。
+// 以輪詢而非檔案系統事件實作，編輯器以「寫入暫存檔再改名」方式存檔時同樣能偵測。
+func Watch(ctx context.Context, path string, interval time.Duration, onChange func(cfg *Config, err error), opts ...LoadOption) {
+	last, _ := os.Stat(path)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		info, err := os.Stat(path)
+		if err != nil || (last != nil && info.ModTime().Equal(last.ModTime()) && info.Size() == last.Size()) {
+			// 檔案暫時不存在（改名存檔的空檔）或未變更
+			continue
+		}
+		last = info
+		onChange(Load(path, opts...))
+	}
+}
+
+// ChangedKeys 比較兩份配置的 crawler 區段，回傳值不同的頂層鍵名（yaml 名稱，依欄位順序），
+// 如 "workers"、"delays"、"http"。
+func (c *Config) ChangedKeys(other *Config) []string {
+	a := reflect.ValueOf(c.Crawler)
+	b := reflect.ValueOf(other.Crawler)
+	t := a.Type()
+
+	var keys []string
+	for i := range t.NumField() {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		if !sameYAML(a.Field(i).Interface(), b.Field(i).Interface()) {
+			keys = append(keys, strings.Split(field.Tag.Get("yaml"), ",")[0])
+		}
+	}
+	return keys
+}
+
+// sameYAML 以 YAML 序列化結果比較兩個值，忽略未匯出的欄位（如已解析的 HTTP duration 快取）
+func sameYAML(a, b any) bool {
+	da, errA := yaml.Marshal(a)
+	db, errB := yaml.Marshal(b)
+	if errA != nil || errB != nil {
+		return reflect.DeepEqual(a, b)
+	}
+	return string(da) == string(db)
+}
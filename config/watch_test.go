@@ -0,0 +1,94 @@
+package config
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestWatch_ReloadsOnChange(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(path, []byte("crawler:\n  workers: 3\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	changes := make(chan *Config, 1)
+	go Watch(ctx, path, 10*time.Millisecond, func(cfg *Config, err error) {
+		if err != nil {
+			t.Errorf("Watch() error = %v", err)
+			return
+		}
+		changes <- cfg
+	})
+
+	// 修改時間的精度可能較粗，同時改變檔案大小確保偵測到變更
+	time.Sleep(30 * time.Millisecond)
+	if err := os.WriteFile(path, []byte("crawler:\n  workers: 12\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case cfg := <-changes:
+		if cfg.Crawler.Workers != FixedWorkers(12) {
+			t.Errorf("Workers = %v, want 12", cfg.Crawler.Workers)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("檔案變更後未重新載入")
+	}
+}
+
+func TestWatch_ParseError(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(path, []byte("crawler:\n  workers: 3\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	errs := make(chan error, 1)
+	go Watch(ctx, path, 10*time.Millisecond, func(cfg *Config, err error) {
+		if cfg != nil {
+			t.Errorf("解析失敗時 cfg 應為 nil")
+		}
+		errs <- err
+	})
+
+	time.Sleep(30 * time.Millisecond)
+	if err := os.WriteFile(path, []byte("crawler: [broken\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case err := <-errs:
+		if err == nil {
+			t.Error("格式錯誤的配置檔應回傳錯誤")
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("檔案變更後未通知")
+	}
+}
+
+func TestChangedKeys(t *testing.T) {
+	a := DefaultConfig()
+	b := DefaultConfig()
+	if keys := a.ChangedKeys(b); len(keys) != 0 {
+		t.Errorf("相同配置 ChangedKeys() = %v, want 空", keys)
+	}
+
+	// 已解析的 HTTP duration 快取不應視為變更
+	a.GetTimeoutDuration()
+
+	b.Crawler.Workers = FixedWorkers(20)
+	b.Crawler.Delays.MaxMs = 9000
+	b.Crawler.Channels.DownloadTask = 1
+	b.Crawler.Output.Index = true
+	want := []string{"workers", "channels", "delays", "output"}
+	if keys := a.ChangedKeys(b); !slices.Equal(keys, want) {
+		t.Errorf("ChangedKeys() = %v, want %v", keys, want)
+	}
+}
@@ -0,0 +1,126 @@
+package config
+
+import (
+	"fmt"
+	"runtime"
+
+	yaml "gopkg.in/yaml.v3"
+)
+
+// WorkersConfig 下載工人數配置。配置檔可寫成純量（workers: 10，固定工人數），
+// 或 min/max 範圍（workers: {min: 4, max: 20}），pool 下載模式會依下載佇列深度在範圍內自動增減工人.
+type WorkersConfig struct {
+	Min int `yaml:"min"` // 最少工人數，也是啟動時的工人數
+	Max int `yaml:"max"` // 下載佇列積壓時最多擴充到的工人數
+}
+
+// FixedWorkers 回傳固定 n 個工人（min 與 max 相同）的配置
+func FixedWorkers(n int) WorkersConfig {
+	return WorkersConfig{Min: n, Max: n}
+}
+
+// Dynamic 回報是否依佇列深度自動調整工人數（max 大於 min）
+func (w WorkersConfig) Dynamic() bool {
+	return w.Max > w.Min
+}
+
+// String 回傳固定數量（如 "10"）或範圍（如 "4~20"），供日誌使用
+func (w WorkersConfig) String() string {
+	if w.Dynamic() {
+		return fmt.Sprintf("%d~%d", w.Min, w.Max)
+	}
+	return fmt.Sprint(w.Min)
+}
+
+// UnmarshalYAML 接受純量（固定工人數）或含 min/max 的映射；映射只設定其中一個時，另一個與之相同
+func (w *WorkersConfig) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind == yaml.ScalarNode {
+		var n int
+		if err := node.Decode(&n); err != nil {
+			return err
+		}
+		*w = FixedWorkers(n)
+		return nil
+	}
+
+	var r struct {
+		Min *int `yaml:"min"`
+		Max *int `yaml:"max"`
+	}
+	if err := node.Decode(&r); err != nil {
+		return err
+	}
+	switch {
+	case r.Min != nil && r.Max != nil:
+		*w = WorkersConfig{Min: *r.Min, Max: *r.Max}
+	case r.Min != nil:
+		*w = FixedWorkers(*r.Min)
+	case r.Max != nil:
+		*w = FixedWorkers(*r.Max)
+	}
+	return nil
+}
+
+// MarshalYAML 固定工人數時輸出純量，與配置檔的寫法一致
+func (w WorkersConfig) MarshalYAML() (any, error) {
+	if w.Dynamic() {
+		return struct {
+			Min int `yaml:"min"`
+			Max int `yaml:"max"`
+		}{w.Min, w.Max}, nil
+	}
+	return w.Min, nil
+}
+
+// validateWorkers 驗證工人數：min 必須 >= 1，max 小於 min 時調整為 min
+func (c *Config) validateWorkers(v *validation, defaults *Config) {
+	w := &c.Crawler.Workers
+	if w.Min == w.Max {
+		*w = FixedWorkers(v.fixInt(w.Min, 1, defaults.Crawler.Workers.Min, "workers"))
+		return
+	}
+	w.Min = v.fixInt(w.Min, 1, defaults.Crawler.Workers.Min, "workers.min")
+	if w.Max < w.Min {
+		v.invalid(fmt.Sprintf("配置 workers.max 的值 %d 小於 workers.min %d，改為與 min 相同", w.Max, w.Min))
+		w.Max = w.Min
+	}
+}
+
+// AutoScaleConfig 依系統資源自動調整下載工人數的配置.
+// 啟用後工人數在 [min, max] 之間：下載佇列積壓時擴充，請求錯誤率偏高（429、5xx、網路錯誤）
+// 或記憶體用量接近 memoryLimit 時縮減，恢復後再逐步擴充。
+type AutoScaleConfig struct {
+	Enabled     bool     `yaml:"enabled"`     // 啟用自動調整，預設關閉
+	Min         int      `yaml:"min"`         // 最少工人數，也是啟動時的工人數
+	Max         int      `yaml:"max"`         // 最多工人數，0 表示依 CPU 數決定（CPU 數 × autoScaleWorkersPerCPU）
+	MemoryLimit ByteSize `yaml:"memoryLimit"` // heap 用量上限（可帶單位），0 表示沿用 GOMEMLIMIT，皆未設定時不依記憶體調整
+}
+
+// autoScaleWorkersPerCPU 是 autoScale.max 未設定時每個 CPU 的工人數；下載以等待網路為主，可多於 CPU 數
+const autoScaleWorkersPerCPU = 4
+
+// validateAutoScale 驗證自動調整設定；啟用時以 [min, max] 取代 workers，
+// 由既有的工人數範圍機制（pool 模式依佇列深度增減）套用
+func (c *Config) validateAutoScale(v *validation, defaults *Config) {
+	a := &c.Crawler.AutoScale
+	a.Min = v.fixInt(a.Min, 1, defaults.Crawler.AutoScale.Min, "autoScale.min")
+	a.Max = v.fixInt(a.Max, 0, defaults.Crawler.AutoScale.Max, "autoScale.max")
+	if a.MemoryLimit < 0 {
+		v.invalid(fmt.Sprintf("配置 autoScale.memoryLimit 的值 %d 非法（最小值 0），退回預設值 %d",
+			a.MemoryLimit, defaults.Crawler.AutoScale.MemoryLimit))
+		a.MemoryLimit = defaults.Crawler.AutoScale.MemoryLimit
+	}
+	if !a.Enabled {
+		return
+	}
+
+	maxWorkers := a.Max
+	if maxWorkers == 0 {
+		maxWorkers = runtime.NumCPU() * autoScaleWorkersPerCPU
+	}
+	if maxWorkers < a.Min {
+		v.invalid(fmt.Sprintf("配置 autoScale.max 的值 %d 小於 autoScale.min %d，改為與 min 相同", maxWorkers, a.Min))
+		maxWorkers = a.Min
+	}
+	c.Crawler.Workers = WorkersConfig{Min: a.Min, Max: maxWorkers}
+}
@@ -0,0 +1,117 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	yaml "gopkg.in/yaml.v3"
+)
+
+func TestLoad_Workers(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    WorkersConfig
+	}{
+		{"純量為固定工人數", "workers: 6", FixedWorkers(6)},
+		{"min/max 範圍", "workers:\n    min: 2\n    max: 16", WorkersConfig{Min: 2, Max: 16}},
+		{"只設定 min", "workers:\n    min: 4", FixedWorkers(4)},
+		{"只設定 max", "workers:\n    max: 8", FixedWorkers(8)},
+		{"max 小於 min 時改為 min", "workers:\n    min: 5\n    max: 3", FixedWorkers(5)},
+		{"min 非法時退回預設", "workers:\n    min: 0\n    max: 20", WorkersConfig{Min: 10, Max: 20}},
+		{"固定工人數非法時退回預設", "workers: -1", FixedWorkers(10)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "config.yaml")
+			if err := os.WriteFile(path, []byte("crawler:\n  "+tt.content+"\n"), 0o644); err != nil {
+				t.Fatal(err)
+			}
+			cfg, err := Load(path)
+			if err != nil {
+				t.Fatalf("Load() error = %v", err)
+			}
+			if cfg.Crawler.Workers != tt.want {
+				t.Errorf("workers = %+v, want %+v", cfg.Crawler.Workers, tt.want)
+			}
+		})
+	}
+}
+
+func TestWorkersConfig_MarshalYAML(t *testing.T) {
+	tests := []struct {
+		workers WorkersConfig
+		want    string
+	}{
+		{FixedWorkers(10), "10\n"},
+		{WorkersConfig{Min: 2, Max: 8}, "min: 2\nmax: 8\n"},
+	}
+	for _, tt := range tests {
+		data, err := yaml.Marshal(tt.workers)
+		if err != nil {
+			t.Fatalf("yaml.Marshal(%v) error = %v", tt.workers, err)
+		}
+		if string(data) != tt.want {
+			t.Errorf("yaml.Marshal(%v) = %q, want %q", tt.workers, data, tt.want)
+		}
+
+		var got WorkersConfig
+		if err := yaml.Unmarshal(data, &got); err != nil || got != tt.workers {
+			t.Errorf("round trip = %+v, %v, want %+v", got, err, tt.workers)
+		}
+	}
+}
+
+func TestWorkersConfig_String(t *testing.T) {
+	if got := FixedWorkers(10).String(); got != "10" {
+		t.Errorf("String() = %q, want %q", got, "10")
+	}
+	if got := (WorkersConfig{Min: 2, Max: 8}).String(); got != "2~8" {
+		t.Errorf("String() = %q, want %q", got, "2~8")
+	}
+}
+
+func TestValidateAutoScale(t *testing.T) {
+	t.Run("未啟用時不影響 workers", func(t *testing.T) {
+		cfg := DefaultConfig()
+		cfg.validateAndFix()
+		if cfg.Crawler.Workers != FixedWorkers(10) {
+			t.Errorf("workers = %v, want 10", cfg.Crawler.Workers)
+		}
+	})
+
+	t.Run("max 未設定時依 CPU 數決定", func(t *testing.T) {
+		cfg := DefaultConfig()
+		cfg.Crawler.AutoScale.Enabled = true
+		cfg.validateAndFix()
+		want := WorkersConfig{Min: 2, Max: max(runtime.NumCPU()*autoScaleWorkersPerCPU, 2)}
+		if cfg.Crawler.Workers != want {
+			t.Errorf("workers = %+v, want %+v", cfg.Crawler.Workers, want)
+		}
+	})
+
+	t.Run("指定範圍取代 workers", func(t *testing.T) {
+		cfg := DefaultConfig()
+		cfg.Crawler.AutoScale = AutoScaleConfig{Enabled: true, Min: 4, Max: 12}
+		cfg.validateAndFix()
+		if want := (WorkersConfig{Min: 4, Max: 12}); cfg.Crawler.Workers != want {
+			t.Errorf("workers = %+v, want %+v", cfg.Crawler.Workers, want)
+		}
+	})
+
+	t.Run("非法值修正", func(t *testing.T) {
+		cfg := DefaultConfig()
+		cfg.Crawler.AutoScale = AutoScaleConfig{Enabled: true, Min: 0, Max: 1, MemoryLimit: -1}
+		cfg.validateAndFix()
+		a := cfg.Crawler.AutoScale
+		if a.Min != 2 || a.MemoryLimit != 0 {
+			t.Errorf("autoScale = %+v, want min 2、memoryLimit 0", a)
+		}
+		// max 小於 min 時改為與 min 相同
+		if want := FixedWorkers(2); cfg.Crawler.Workers != want {
+			t.Errorf("workers = %+v, want %+v", cfg.Crawler.Workers, want)
+		}
+	})
+}
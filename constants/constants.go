@@ -2,6 +2,8 @@
 // 包括 URL、HTTP 標頭、檔案權限和預設配置。
 package constants
 
+import "time"
+
 const (
 	// PttBaseURL 是 PTT 網站的基底 URL
 	PttBaseURL = "https://www.ptt.cc"
@@ -23,6 +25,9 @@ const (
 	// DefaultPushRate 是預設的推文數門檻
 	DefaultPushRate = 10
 
+	// DefaultCheckpointPath 是斷點續爬 checkpoint 檔的預設路徑
+	DefaultCheckpointPath = ".ptt-spider/checkpoint.json"
+
 	// Over18CookieName 是十八禁確認 cookie 的名稱
 	Over18CookieName = "over18"
 	// Over18CookieValue 是十八禁確認 cookie 的值
@@ -37,7 +42,16 @@ const (
 	// RetryBackoffFactor 是重試的指數退避倍數
 	RetryBackoffFactor = 2
 
-	// MaxImageSizeBytes 單張圖片下載大小上限（50 MB），
+	// ImgurAlbumAPIURL 是 imgur 相簿圖片清單的公開 JSON 端點，%s 為相簿 ID
+	ImgurAlbumAPIURL = "https://imgur.com/ajaxalbums/getimages/%s/hit.json"
+	// ImgurImageBaseURL 是 imgur 圖片直連的基底 URL
+	ImgurImageBaseURL = "https://i.imgur.com/"
+
+	// MaxImageSizeBytes 單張圖片下載大小上限的預設值（50 MB），可由 crawler.maxImageBytes 覆寫。
 	// 圖片連結來自文章內容（外部可控），防止超大回應寫爆磁碟
 	MaxImageSizeBytes int64 = 50 * 1024 * 1024
 )
+
+// PttLocation 是 PTT 的時區（UTC+8），文章頁的「時間」欄位以此時區記錄，輸出的發文時間也以此時區表示；
+// 使用固定時區而非 time.LoadLocation，避免依賴執行環境的 tzdata
+var PttLocation = time.FixedZone("CST", 8*60*60)
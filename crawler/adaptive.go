@@ -0,0 +1,46 @@
+package crawler
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/twtrubiks/ptt-spider-go/config"
+	"github.com/twtrubiks/ptt-spider-go/interfaces"
+	"github.com/twtrubiks/ptt-spider-go/ratelimit"
+)
+
+// newAdaptiveDelay 依配置建立自適應延遲控制器，adaptive.enabled 關閉時回傳 nil
+func newAdaptiveDelay(cfg config.AdaptiveConfig) *ratelimit.AdaptiveDelay {
+	if !cfg.Enabled {
+		return nil
+	}
+	return ratelimit.NewAdaptiveDelay(
+		time.Duration(cfg.MinMs)*time.Millisecond, time.Duration(cfg.MaxMs)*time.Millisecond)
+}
+
+// adaptiveDelayFor 回傳 rawURL 所屬 host 目前的自適應延遲，未啟用時為 0
+func (c *Crawler) adaptiveDelayFor(rawURL string) time.Duration {
+	if c.adaptive == nil {
+		return 0
+	}
+	return c.adaptive.Delay(hostOf(rawURL))
+}
+
+// throttleObserver 包裝 HTTPClient，將每次回應（含重試中的每一次）的 429 回報給自適應延遲控制器
+type throttleObserver struct {
+	interfaces.HTTPClient
+	c *Crawler
+}
+
+// Do 送出請求，回應 429 時調升該 host 的延遲
+func (o throttleObserver) Do(req *http.Request) (*http.Response, error) {
+	resp, err := o.HTTPClient.Do(req)
+	if err == nil && resp.StatusCode == http.StatusTooManyRequests {
+		host := strings.ToLower(req.URL.Hostname())
+		if delay, raised := o.c.adaptive.Throttled(host); raised {
+			o.c.logger.Warn("%s 回應 HTTP 429，延遲調升至 %v", host, delay)
+		}
+	}
+	return resp, err
+}
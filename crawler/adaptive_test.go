@@ -0,0 +1,62 @@
+package crawler
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/twtrubiks/ptt-spider-go/config"
+	"github.com/twtrubiks/ptt-spider-go/mocks"
+)
+
+// TestDoRequest_AdaptiveDelay 驗證收到 429 後只調升該 host 的延遲，下載與解析前的 delayFor 隨之變長
+func TestDoRequest_AdaptiveDelay(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.Crawler.Delays = config.DelayConfig{MinMs: 0, MaxMs: 0}
+	cfg.Crawler.Retry.MaxAttempts = 1
+	cfg.Crawler.Adaptive = config.AdaptiveConfig{Enabled: true, MinMs: 0, MaxMs: 1000}
+
+	client := &mocks.MockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			status := http.StatusOK
+			if req.URL.Host == "i.imgur.com" {
+				status = http.StatusTooManyRequests
+			}
+			return &http.Response{StatusCode: status, Body: io.NopCloser(strings.NewReader("")), Request: req}, nil
+		},
+	}
+	var warns []string
+	logger := &mocks.MockLogger{WarnFunc: func(format string, args ...any) {
+		warns = append(warns, fmt.Sprintf(format, args...))
+	}}
+	c := NewCrawlerWithDependencies(client, mocks.NewMockParser(), mocks.NewMockMarkdownGenerator(),
+		"test", 1, 0, "", cfg, WithLogger(logger))
+
+	for _, url := range []string{"https://i.imgur.com/a.jpg", "https://www.ptt.cc/bbs/Beauty/index.html"} {
+		req, _ := http.NewRequestWithContext(context.Background(), "GET", url, nil)
+		if resp, err := c.doRequest(context.Background(), req); err == nil {
+			_ = resp.Body.Close()
+		}
+	}
+
+	if got := c.delayFor("https://i.imgur.com/b.jpg"); got != 100*time.Millisecond {
+		t.Errorf("收到 429 的 host 延遲 = %v, want 100ms", got)
+	}
+	if got := c.delayFor("https://www.ptt.cc/bbs/Beauty/M.1.A.html"); got != 0 {
+		t.Errorf("未收到 429 的 host 延遲 = %v, want 0", got)
+	}
+	if !slices.ContainsFunc(warns, func(m string) bool { return strings.Contains(m, "i.imgur.com 回應 HTTP 429，延遲調升至 100ms") }) {
+		t.Errorf("調升延遲時應記錄警告, got %v", warns)
+	}
+}
+
+func TestNewAdaptiveDelay_Disabled(t *testing.T) {
+	if newAdaptiveDelay(config.DefaultConfig().Crawler.Adaptive) != nil {
+		t.Error("預設不應啟用自適應延遲")
+	}
+}
@@ -0,0 +1,105 @@
+package crawler
+
+import (
+	"sync"
+	"time"
+
+	"github.com/twtrubiks/ptt-spider-go/config"
+	"github.com/twtrubiks/ptt-spider-go/ui"
+)
+
+// requestOutcome 是滑動視窗中的一筆請求結果
+type requestOutcome struct {
+	at     time.Time
+	failed bool
+}
+
+// errorRateMonitor 以滑動時間視窗統計請求錯誤率，超過門檻時透過 Logger.Error 告警。
+// 告警後在冷卻時間內不重複觸發（去抖動，期間錯誤率回落後再升高也不觸發），錯誤率回落到門檻以下時記錄恢復訊息。
+// 可供多個 worker 並行呼叫。
+type errorRateMonitor struct {
+	threshold  float64       // 錯誤率門檻（0~1）
+	window     time.Duration // 滑動視窗長度
+	minSamples int           // 視窗內樣本數達此值才判斷，避免少量請求誤報
+	cooldown   time.Duration // 兩次告警的最短間隔
+	logger     ui.Logger
+	now        func() time.Time // 可替換的時鐘，供測試使用
+
+	mu        sync.Mutex
+	outcomes  []requestOutcome
+	alerting  bool      // 目前是否處於告警狀態
+	lastAlert time.Time // 上次告警時間
+}
+
+// newErrorRateMonitor 建立錯誤率監控器，threshold <= 0 時回傳 nil（停用）
+func newErrorRateMonitor(threshold float64, window time.Duration, minSamples int, cooldown time.Duration, logger ui.Logger) *errorRateMonitor {
+	if threshold <= 0 {
+		return nil
+	}
+	return &errorRateMonitor{
+		threshold:  threshold,
+		window:     window,
+		minSamples: minSamples,
+		cooldown:   cooldown,
+		logger:     logger,
+		now:        time.Now,
+	}
+}
+
+// Record 記錄一筆請求結果，並視需要觸發告警或恢復訊息
+func (m *errorRateMonitor) Record(failed bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := m.now()
+	m.outcomes = append(m.outcomes, requestOutcome{at: now, failed: failed})
+	m.prune(now)
+
+	total := len(m.outcomes)
+	if total < m.minSamples {
+		return
+	}
+	failures := 0
+	for _, o := range m.outcomes {
+		if o.failed {
+			failures++
+		}
+	}
+	rate := float64(failures) / float64(total)
+
+	if rate < m.threshold {
+		if m.alerting {
+			m.alerting = false
+			m.logger.Info("請求錯誤率已回落至 %.0f%%（%d/%d），解除告警", rate*100, failures, total)
+		}
+		return
+	}
+
+	// 冷卻時間與 alerting 無關：錯誤率回落後很快再次升高時同樣不重複告警，避免反覆跳動
+	if now.Sub(m.lastAlert) < m.cooldown {
+		return
+	}
+	m.alerting = true
+	m.lastAlert = now
+	m.logger.Error("告警：最近 %v 內請求錯誤率 %.0f%%（%d/%d）超過門檻 %.0f%%，可能已被限流或封鎖",
+		m.window, rate*100, failures, total, m.threshold*100)
+}
+
+// prune 移除視窗外的舊紀錄，呼叫方需持有鎖
+func (m *errorRateMonitor) prune(now time.Time) {
+	cutoff := now.Add(-m.window)
+	i := 0
+	for i < len(m.outcomes) && m.outcomes[i].at.Before(cutoff) {
+		i++
+	}
+	m.outcomes = m.outcomes[i:]
+}
+
+// newAlertMonitor 依配置建立錯誤率監控器，alert.errorRate 為 0 時回傳 nil
+func newAlertMonitor(cfg *config.Config, logger ui.Logger) *errorRateMonitor {
+	a := cfg.Crawler.Alert
+	if a.ErrorRate <= 0 {
+		return nil
+	}
+	return newErrorRateMonitor(a.ErrorRate, cfg.GetAlertWindow(), a.MinSamples, cfg.GetAlertCooldown(), logger)
+}
@@ -0,0 +1,137 @@
+package crawler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/twtrubiks/ptt-spider-go/config"
+	"github.com/twtrubiks/ptt-spider-go/mocks"
+)
+
+// newTestMonitor 建立使用可控時鐘的監控器，並回傳告警與恢復訊息計數
+func newTestMonitor(t *testing.T) (*errorRateMonitor, *time.Time, *int, *int) {
+	t.Helper()
+	var alerts, recoveries int
+	logger := &mocks.MockLogger{
+		ErrorFunc: func(_ string, _ ...any) { alerts++ },
+		InfoFunc:  func(_ string, _ ...any) { recoveries++ },
+	}
+	now := time.Unix(0, 0)
+	m := newErrorRateMonitor(0.5, time.Minute, 4, 5*time.Minute, logger)
+	m.now = func() time.Time { return now }
+	return m, &now, &alerts, &recoveries
+}
+
+func TestErrorRateMonitor_Disabled(t *testing.T) {
+	if newErrorRateMonitor(0, time.Minute, 1, time.Minute, &mocks.MockLogger{}) != nil {
+		t.Error("門檻為 0 時應停用監控")
+	}
+}
+
+func TestErrorRateMonitor_RequiresMinSamples(t *testing.T) {
+	m, _, alerts, _ := newTestMonitor(t)
+	for i := 0; i < 3; i++ {
+		m.Record(true)
+	}
+	if *alerts != 0 {
+		t.Errorf("樣本數不足時不應告警，got %d", *alerts)
+	}
+	m.Record(true)
+	if *alerts != 1 {
+		t.Errorf("達到樣本數且超過門檻時應告警 1 次，got %d", *alerts)
+	}
+}
+
+func TestErrorRateMonitor_CooldownDebounces(t *testing.T) {
+	m, now, alerts, _ := newTestMonitor(t)
+	for i := 0; i < 10; i++ {
+		m.Record(true)
+	}
+	if *alerts != 1 {
+		t.Fatalf("冷卻時間內應只告警 1 次，got %d", *alerts)
+	}
+
+	*now = now.Add(30 * time.Second)
+	m.Record(true)
+	if *alerts != 1 {
+		t.Errorf("冷卻時間內不應再次告警，got %d", *alerts)
+	}
+
+	*now = now.Add(5 * time.Minute)
+	for i := 0; i < 4; i++ {
+		m.Record(true)
+	}
+	if *alerts != 2 {
+		t.Errorf("冷卻時間過後應可再次告警，got %d", *alerts)
+	}
+}
+
+func TestErrorRateMonitor_RecoveryAndWindow(t *testing.T) {
+	m, now, alerts, recoveries := newTestMonitor(t)
+	for i := 0; i < 4; i++ {
+		m.Record(true)
+	}
+	if *alerts != 1 {
+		t.Fatalf("應告警 1 次，got %d", *alerts)
+	}
+
+	// 舊的失敗紀錄滑出視窗後，成功請求使錯誤率回落
+	*now = now.Add(2 * time.Minute)
+	for i := 0; i < 4; i++ {
+		m.Record(false)
+	}
+	if *recoveries != 1 {
+		t.Errorf("錯誤率回落時應記錄 1 次恢復訊息，got %d", *recoveries)
+	}
+}
+
+func TestErrorRateMonitor_CooldownAfterRecovery(t *testing.T) {
+	m, now, alerts, recoveries := newTestMonitor(t)
+	for i := 0; i < 4; i++ {
+		m.Record(true)
+	}
+
+	// 錯誤率回落後，冷卻時間內再次升高不應告警
+	*now = now.Add(2 * time.Minute)
+	for i := 0; i < 4; i++ {
+		m.Record(false)
+	}
+	*now = now.Add(2 * time.Minute)
+	for i := 0; i < 4; i++ {
+		m.Record(true)
+	}
+	if *recoveries != 1 || *alerts != 1 {
+		t.Fatalf("冷卻時間內回落後再升高不應告警，alerts = %d, recoveries = %d", *alerts, *recoveries)
+	}
+
+	*now = now.Add(2 * time.Minute)
+	for i := 0; i < 4; i++ {
+		m.Record(true)
+	}
+	if *alerts != 2 {
+		t.Errorf("冷卻時間過後應可再次告警，got %d", *alerts)
+	}
+}
+
+func TestDoRequest_RecordsFailuresForAlert(t *testing.T) {
+	var alerts int
+	client := mocks.NewMockHTTPClient()
+	client.DoFunc = func(_ *http.Request) (*http.Response, error) {
+		return nil, errors.New("connection refused")
+	}
+	cfg := config.DefaultConfig()
+	cfg.Crawler.Alert.MinSamples = 2
+	c := NewCrawlerWithDependencies(client, mocks.NewMockParser(), mocks.NewMockMarkdownGenerator(), "test", 1, 0, "", cfg)
+	c.alertMonitor.logger = &mocks.MockLogger{ErrorFunc: func(_ string, _ ...any) { alerts++ }}
+
+	for i := 0; i < 3; i++ {
+		req, _ := http.NewRequest(http.MethodGet, "http://example.com", nil)
+		_, _ = c.doRequest(context.Background(), req)
+	}
+	if alerts != 1 {
+		t.Errorf("連續失敗應觸發 1 次告警，got %d", alerts)
+	}
+}
@@ -0,0 +1,96 @@
+package crawler
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/twtrubiks/ptt-spider-go/config"
+	"github.com/twtrubiks/ptt-spider-go/types"
+	"github.com/twtrubiks/ptt-spider-go/workerpool"
+)
+
+const (
+	// autoscaleInterval 是檢查下載佇列深度、調整工人數的間隔
+	autoscaleInterval = 500 * time.Millisecond
+	// autoscaleIdleChecks 下載佇列連續為空達此次數後才開始縮減工人，避免短暫空檔就退場
+	autoscaleIdleChecks = 4
+)
+
+// workerRange 回傳目前的下載工人數範圍，熱更新後的值優先於啟動時的配置
+func (c *Crawler) workerRange() config.WorkersConfig {
+	if w := c.workers.Load(); w != nil {
+		return *w
+	}
+	return c.config.Crawler.Workers
+}
+
+// scaleTarget 依目前工人數 size、佇列中的任務數 depth 與佇列連續為空的次數 idleChecks
+// 決定新的工人數：先夾回 [min, max]；積壓的任務多於工人數時加倍（不超過 max）；
+// 閒置達 autoscaleIdleChecks 次後每次減少一個（不低於 min）
+func scaleTarget(size, depth, idleChecks int, w config.WorkersConfig) int {
+	switch {
+	case size < w.Min:
+		return w.Min
+	case size > w.Max:
+		return w.Max
+	case depth > size:
+		return min(size*2, w.Max)
+	case idleChecks >= autoscaleIdleChecks:
+		return max(size-1, w.Min)
+	}
+	return size
+}
+
+// autoscaleWorkers 每隔 interval 依下載佇列深度調整工人池大小，直到 ctx 取消或工人池停止。
+// min 與 max 相同時維持固定工人數（熱更新改為範圍後才開始調整）；
+// 啟用 autoScale 時工人數另外不超過 resourceGovernor 依錯誤率與記憶體決定的上限。
+func (c *Crawler) autoscaleWorkers(ctx context.Context, pool *workerpool.Pool[types.DownloadTask],
+	tasks chan types.DownloadTask, interval time.Duration, wg *sync.WaitGroup) {
+	defer wg.Done()
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	idleChecks := 0
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-pool.Stopping():
+			return
+		case <-ticker.C:
+		}
+
+		depth := len(tasks)
+		if depth == 0 {
+			idleChecks++
+		} else {
+			idleChecks = 0
+		}
+
+		size := pool.Size()
+		target := scaleTarget(size, depth, idleChecks, c.workerRange())
+		limited := false
+		if c.resources != nil {
+			ceiling, reason := c.resources.update(size)
+			if reason != "" {
+				c.logger.Warn("%s，下載工人數上限降為 %d", reason, ceiling)
+			}
+			if target > ceiling {
+				target, limited = ceiling, true
+			}
+		}
+		switch {
+		case target > size:
+			pool.Resize(target)
+			c.logger.Info("下載佇列積壓 %d 個任務，工人數由 %d 增加為 %d", depth, size, target)
+		case target < size && limited:
+			pool.Resize(target)
+			c.logger.Info("受資源用量上限限制，工人數由 %d 減少為 %d", size, target)
+		case target < size:
+			pool.Resize(target)
+			c.logger.Info("下載佇列閒置，工人數由 %d 減少為 %d", size, target)
+		}
+	}
+}
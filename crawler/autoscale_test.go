@@ -0,0 +1,154 @@
+package crawler
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/twtrubiks/ptt-spider-go/config"
+	"github.com/twtrubiks/ptt-spider-go/mocks"
+	"github.com/twtrubiks/ptt-spider-go/types"
+)
+
+func TestScaleTarget(t *testing.T) {
+	w := config.WorkersConfig{Min: 2, Max: 8}
+	tests := []struct {
+		name              string
+		size, depth, idle int
+		workers           config.WorkersConfig
+		want              int
+	}{
+		{"積壓多於工人數時加倍", 2, 5, 0, w, 4},
+		{"加倍不超過 max", 6, 20, 0, w, 8},
+		{"積壓不多於工人數時維持", 4, 4, 0, w, 4},
+		{"閒置未達門檻時維持", 4, 0, autoscaleIdleChecks - 1, w, 4},
+		{"閒置達門檻時減少一個", 4, 0, autoscaleIdleChecks, w, 3},
+		{"減少不低於 min", 2, 0, autoscaleIdleChecks, w, 2},
+		{"低於 min 時補足", 1, 0, 0, w, 2},
+		{"高於 max 時縮減", 10, 30, 0, w, 8},
+		{"固定工人數時不調整", 5, 50, 0, config.FixedWorkers(5), 5},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := scaleTarget(tt.size, tt.depth, tt.idle, tt.workers); got != tt.want {
+				t.Errorf("scaleTarget(%d, %d, %d, %v) = %d, want %d", tt.size, tt.depth, tt.idle, tt.workers, got, tt.want)
+			}
+		})
+	}
+}
+
+// TestAutoscaleWorkers_Burst 驗證大量任務湧入時工人數擴充到 max、實際並行下載，佇列清空後縮回 min
+func TestAutoscaleWorkers_Burst(t *testing.T) {
+	t.Chdir(t.TempDir())
+
+	var running, peak atomic.Int32
+	client := &mocks.MockHTTPClient{
+		DoFunc: func(_ *http.Request) (*http.Response, error) {
+			n := running.Add(1)
+			defer running.Add(-1)
+			for p := peak.Load(); n > p && !peak.CompareAndSwap(p, n); p = peak.Load() {
+			}
+			time.Sleep(20 * time.Millisecond)
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       io.NopCloser(strings.NewReader(pngHeader + "image-data")),
+			}, nil
+		},
+	}
+	log := &reloadLog{}
+	cfg := config.DefaultConfig()
+	cfg.Crawler.Delays = config.DelayConfig{}
+	cfg.Crawler.Workers = config.WorkersConfig{Min: 1, Max: 4}
+	c := NewCrawlerWithDependencies(client, mocks.NewMockParser(), mocks.NewMockMarkdownGenerator(),
+		"test", 1, 0, "", cfg, WithLogger(&mocks.MockLogger{InfoFunc: log.record}))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	tasks := make(chan types.DownloadTask, 64)
+	pool := c.newDownloadPool(tasks, cfg.Crawler.Workers.Min)
+	pool.Start(ctx)
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go c.autoscaleWorkers(ctx, pool, tasks, 10*time.Millisecond, &wg)
+
+	for i := range 40 {
+		task := types.DownloadTask{
+			ImageURL: fmt.Sprintf("https://i.imgur.com/%d.png", i),
+			SavePath: fmt.Sprintf("%d.png", i),
+		}
+		if err := pool.Submit(ctx, task); err != nil {
+			t.Fatalf("Submit() error = %v", err)
+		}
+	}
+
+	waitFor(t, func() bool { return pool.Size() == 4 }, "佇列積壓時工人數應擴充到 max")
+	waitFor(t, func() bool { return len(tasks) == 0 && running.Load() == 0 }, "任務應全部處理完")
+	waitFor(t, func() bool { return pool.Size() == 1 }, "佇列清空後工人數應縮回 min")
+
+	pool.Stop()
+	pool.Wait()
+	wg.Wait()
+
+	if peak.Load() < 2 {
+		t.Errorf("同時下載數峰值 = %d, 擴充後應並行下載", peak.Load())
+	}
+	if log.count("工人數由 1 增加為 2") != 1 || log.count("減少為 1") != 1 {
+		t.Errorf("logs = %v, want 擴充與縮減記錄", log.lines)
+	}
+}
+
+// TestAutoscaleWorkers_StopsWithPool 驗證工人池停止或 ctx 取消時監看 goroutine 結束
+func TestAutoscaleWorkers_StopsWithPool(t *testing.T) {
+	for _, cancelCtx := range []bool{false, true} {
+		c, _ := newReloadTestCrawler(config.DefaultConfig())
+		ctx, cancel := context.WithCancel(context.Background())
+		tasks := make(chan types.DownloadTask)
+		pool := c.newDownloadPool(tasks, 1)
+		pool.Start(ctx)
+
+		var wg sync.WaitGroup
+		wg.Add(1)
+		go c.autoscaleWorkers(ctx, pool, tasks, time.Hour, &wg)
+		if cancelCtx {
+			cancel()
+		} else {
+			pool.Stop()
+		}
+		wg.Wait()
+		cancel()
+		pool.Stop()
+		pool.Wait()
+	}
+}
+
+func TestApplyConfig_WorkerRange(t *testing.T) {
+	c, log := newReloadTestCrawler(config.DefaultConfig())
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	c.pool = c.newDownloadPool(make(chan types.DownloadTask), 10)
+	c.pool.Start(ctx)
+	defer func() {
+		c.pool.Stop()
+		c.pool.Wait()
+	}()
+
+	next := config.DefaultConfig()
+	next.Crawler.Workers = config.WorkersConfig{Min: 2, Max: 6}
+	c.applyConfig(next)
+
+	if got := c.pool.Size(); got != 6 {
+		t.Errorf("工人數應夾回範圍上限, Size() = %d, want 6", got)
+	}
+	if got := c.workerRange(); got != next.Crawler.Workers {
+		t.Errorf("workerRange() = %v, want %v", got, next.Crawler.Workers)
+	}
+	if log.count("下載工人數範圍已調整為 2~6") != 1 {
+		t.Errorf("logs = %v, want 範圍調整記錄", log.lines)
+	}
+}
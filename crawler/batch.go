@@ -0,0 +1,67 @@
+package crawler
+
+import (
+	"context"
+	"sync"
+
+	"github.com/twtrubiks/ptt-spider-go/config"
+	"github.com/twtrubiks/ptt-spider-go/types"
+)
+
+// articleMode 回傳是否以文章為單位批次下載（downloadMode: article）。
+// 重試模式沒有文章可批次處理，一律使用工人池。
+func (c *Crawler) articleMode() bool {
+	return c.config.Crawler.DownloadMode == config.DownloadModeArticle && c.retryFailures == ""
+}
+
+// newWorkerIDPool 建立容量為 n、內含工人編號 1..n 的 channel。
+// article 模式不啟動常駐的下載工人，改由各篇文章向此池借用編號，
+// 讓全域同時下載數仍以 workers 為上限，進度畫面的工人編號也維持在 1..n。
+func newWorkerIDPool(n int) chan int {
+	ids := make(chan int, n)
+	for i := 1; i <= n; i++ {
+		ids <- i
+	}
+	return ids
+}
+
+// downloadArticleImages 並行下載同一篇文章的所有圖片並等待全部完成。
+// 單篇同時下載數以 articleConcurrency 為上限，每張圖片下載前需先借到工人編號。
+// 回傳 true 表示過程中被中斷，呼叫端不應再產生 Markdown。
+func (c *Crawler) downloadArticleImages(ctx context.Context, tasks []types.DownloadTask) bool {
+	sem := make(chan struct{}, max(c.config.Crawler.ArticleConcurrency, 1))
+	var wg sync.WaitGroup
+
+	for _, task := range tasks {
+		select {
+		case <-ctx.Done():
+		case sem <- struct{}{}:
+		}
+		if ctx.Err() != nil {
+			break
+		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			var id int
+			select {
+			case <-ctx.Done():
+				return
+			case id = <-c.workerIDs:
+			}
+			defer func() { c.workerIDs <- id }()
+
+			c.downloadOne(ctx, id, task)
+		}()
+	}
+	wg.Wait()
+
+	if ctx.Err() != nil {
+		c.logger.Warn("文章圖片批次下載時被中斷")
+		return true
+	}
+	return false
+}
@@ -0,0 +1,181 @@
+package crawler
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/twtrubiks/ptt-spider-go/config"
+	"github.com/twtrubiks/ptt-spider-go/internal/fileutil"
+	"github.com/twtrubiks/ptt-spider-go/mocks"
+	"github.com/twtrubiks/ptt-spider-go/types"
+)
+
+// slowImageClient 回傳圖片（/img/ 路徑）前先等待 delay，並記錄同時進行中的圖片請求數最大值；
+// 其他請求（列表頁、文章頁）直接以路徑作為內容回傳
+func slowImageClient(delay time.Duration, peak *int32) *mocks.MockHTTPClient {
+	var inFlight int32
+	return &mocks.MockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			if !strings.HasPrefix(req.URL.Path, "/img/") {
+				return &http.Response{
+					StatusCode: http.StatusOK,
+					Body:       io.NopCloser(strings.NewReader(req.URL.Path)),
+					Request:    req,
+				}, nil
+			}
+			n := atomic.AddInt32(&inFlight, 1)
+			defer atomic.AddInt32(&inFlight, -1)
+			for {
+				old := atomic.LoadInt32(peak)
+				if n <= old || atomic.CompareAndSwapInt32(peak, old, n) {
+					break
+				}
+			}
+			time.Sleep(delay)
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       io.NopCloser(strings.NewReader(pngHeader + req.URL.Path)),
+				Request:    req,
+			}, nil
+		},
+	}
+}
+
+func TestDownloadArticleImages_ConcurrencyLimit(t *testing.T) {
+	tests := []struct {
+		name               string
+		workers            int
+		articleConcurrency int
+		wantPeak           int32
+	}{
+		{"受單篇上限限制", 8, 2, 2},
+		{"受全域 workers 限制", 3, 8, 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			cfg := config.DefaultConfig()
+			cfg.Crawler.Workers = config.FixedWorkers(tt.workers)
+			cfg.Crawler.ArticleConcurrency = tt.articleConcurrency
+			cfg.Crawler.Delays = config.DelayConfig{MinMs: 0, MaxMs: 0}
+
+			var peak int32
+			c := NewCrawlerWithDependencies(slowImageClient(20*time.Millisecond, &peak), mocks.NewMockParser(),
+				mocks.NewMockMarkdownGenerator(), "test", 1, 0, "", cfg, WithLogger(&mocks.MockLogger{}))
+			c.workerIDs = newWorkerIDPool(tt.workers)
+
+			tasks := make([]types.DownloadTask, 10)
+			for i := range tasks {
+				tasks[i] = types.DownloadTask{
+					ImageURL: fmt.Sprintf("http://example.com/img/%d.png", i),
+					SavePath: filepath.Join(dir, fmt.Sprintf("%d.png", i)),
+				}
+			}
+
+			if c.downloadArticleImages(context.Background(), tasks) {
+				t.Fatal("downloadArticleImages() 不應回報中斷")
+			}
+			if peak != tt.wantPeak {
+				t.Errorf("同時下載數最大值 = %d, want %d", peak, tt.wantPeak)
+			}
+			for _, task := range tasks {
+				if _, err := os.Stat(task.SavePath); err != nil {
+					t.Errorf("圖片應已下載完成: %v", err)
+				}
+			}
+			if len(c.workerIDs) != tt.workers {
+				t.Errorf("工人編號應全數歸還，剩餘 %d, want %d", len(c.workerIDs), tt.workers)
+			}
+		})
+	}
+}
+
+func TestDownloadArticleImages_Cancelled(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.Crawler.Workers = config.FixedWorkers(1)
+	c := NewCrawlerWithDependencies(mocks.NewMockHTTPClient(), mocks.NewMockParser(),
+		mocks.NewMockMarkdownGenerator(), "test", 1, 0, "", cfg, WithLogger(&mocks.MockLogger{}))
+	c.workerIDs = newWorkerIDPool(1)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	tasks := []types.DownloadTask{{ImageURL: "http://example.com/a.png", SavePath: filepath.Join(t.TempDir(), "a.png")}}
+	if !c.downloadArticleImages(ctx, tasks) {
+		t.Error("context 已取消時應回報中斷")
+	}
+}
+
+// TestRun_ArticleMode 驗證 article 模式下 Markdown 產生時該篇圖片皆已寫入磁碟
+func TestRun_ArticleMode(t *testing.T) {
+	t.Chdir(t.TempDir())
+
+	imgURLs := []string{
+		"http://example.com/img/1.png",
+		"http://example.com/img/2.png",
+		"http://example.com/img/3.png",
+	}
+	parser := &mocks.MockParser{
+		ParseMaxPageFunc: func(_ io.Reader) (int, error) { return 1, nil },
+		ParseArticlesFunc: func(_ io.Reader) ([]types.ArticleInfo, error) {
+			return []types.ArticleInfo{
+				{Title: "第一篇", URL: "https://www.ptt.cc/bbs/test/M.1.A.html", PushRate: 10},
+				{Title: "第二篇", URL: "https://www.ptt.cc/bbs/test/M.2.A.html", PushRate: 10},
+			}, nil
+		},
+		ParseArticleContentFunc: func(body io.Reader) (types.ArticleContent, error) {
+			data, _ := io.ReadAll(body)
+			return types.ArticleContent{Title: filepath.Base(string(data)), ImageURLs: imgURLs}, nil
+		},
+	}
+
+	var (
+		mu        sync.Mutex
+		generated int
+		missing   []string
+	)
+	mdgen := &mocks.MockMarkdownGenerator{
+		GenerateFunc: func(info types.MarkdownInfo) error {
+			mu.Lock()
+			defer mu.Unlock()
+			generated++
+			for _, name := range fileutil.ImageFileNames(info.ImageURLs) {
+				if _, err := os.Stat(filepath.Join(info.SaveDir, name)); err != nil {
+					missing = append(missing, filepath.Join(info.SaveDir, name))
+				}
+			}
+			return nil
+		},
+	}
+
+	cfg := config.DefaultConfig()
+	cfg.Crawler.Workers = config.FixedWorkers(2)
+	cfg.Crawler.ParserCount = 2
+	cfg.Crawler.DownloadMode = config.DownloadModeArticle
+	cfg.Crawler.ArticleConcurrency = 2
+	cfg.Crawler.Delays = config.DelayConfig{MinMs: 0, MaxMs: 0}
+
+	var peak int32
+	c := NewCrawlerWithDependencies(slowImageClient(5*time.Millisecond, &peak), parser, mdgen,
+		"test", 1, 0, "", cfg, WithLogger(&mocks.MockLogger{}))
+	c.Run(context.Background())
+
+	if generated != 2 {
+		t.Errorf("Markdown 產生次數 = %d, want 2", generated)
+	}
+	if len(missing) > 0 {
+		t.Errorf("產生 Markdown 時圖片尚未下載完成: %v", missing)
+	}
+	if peak > int32(cfg.Crawler.Workers.Max) {
+		t.Errorf("同時下載數最大值 = %d，不應超過 workers %d", peak, cfg.Crawler.Workers.Max)
+	}
+}
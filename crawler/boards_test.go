@@ -0,0 +1,203 @@
+package crawler
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/twtrubiks/ptt-spider-go/config"
+	"github.com/twtrubiks/ptt-spider-go/mocks"
+	"github.com/twtrubiks/ptt-spider-go/types"
+)
+
+func TestParseBoards(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  []string
+	}{
+		{"單一看板", "Beauty", []string{"Beauty"}},
+		{"逗號分隔", "Beauty,Gossiping", []string{"Beauty", "Gossiping"}},
+		{"去除空白與空項目", " Beauty , ,Gossiping,", []string{"Beauty", "Gossiping"}},
+		{"不分大小寫去重，保留第一次的寫法", "Beauty,beauty,BEAUTY", []string{"Beauty"}},
+		{"空字串", "", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := parseBoards(tt.input); !slices.Equal(got, tt.want) {
+				t.Errorf("parseBoards(%q) = %v, want %v", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateBoards(t *testing.T) {
+	tests := []struct {
+		name    string
+		board   string
+		fileURL string
+		want    []string
+		wantErr bool
+	}{
+		{"多個合法看板", "Beauty,Gossiping", "", []string{"Beauty", "Gossiping"}, false},
+		{"其中一個不合法", "Beauty,../etc", "", nil, true},
+		{"看板模式只有分隔符號", ",", "", nil, true},
+		{"檔案模式可不指定看板", "", "urls.txt", nil, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := validateBoards(tt.board, tt.fileURL)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("validateBoards() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("validateBoards() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// newMultiBoardCrawler 建立一個回應內容為請求路徑的 crawler：
+// 列表頁解析出的文章 URL 即為該列表頁路徑，方便驗證各看板都有被爬取。
+func newMultiBoardCrawler(board string, pages int, do func(req *http.Request)) *Crawler {
+	client := &mocks.MockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			if do != nil {
+				do(req)
+			}
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       io.NopCloser(strings.NewReader(req.URL.Path)),
+			}, nil
+		},
+	}
+	parser := &mocks.MockParser{
+		ParseMaxPageFunc: func(_ io.Reader) (int, error) { return 10, nil },
+		ParseArticlesFunc: func(body io.Reader) ([]types.ArticleInfo, error) {
+			path, _ := io.ReadAll(body)
+			return []types.ArticleInfo{{Title: "T", URL: string(path), PushRate: 50}}, nil
+		},
+	}
+	cfg := config.DefaultConfig()
+	cfg.Crawler.Delays = config.DelayConfig{MinMs: 0, MaxMs: 0}
+	return NewCrawlerWithDependencies(client, parser, mocks.NewMockMarkdownGenerator(), board, pages, 0, "", cfg)
+}
+
+func TestArticleProducer_MultipleBoards(t *testing.T) {
+	progressCh := make(chan types.ProgressEvent, 10)
+	c := newMultiBoardCrawler("Beauty,Gossiping", 2, nil)
+	c.progress = progressCh
+
+	ch := make(chan types.ArticleInfo, 10)
+	c.articleProducer(context.Background(), ch)
+	close(progressCh)
+
+	var got []string
+	for a := range ch {
+		got = append(got, a.Board+" "+a.URL)
+	}
+	want := []string{
+		"Beauty /bbs/Beauty/index10.html",
+		"Beauty /bbs/Beauty/index9.html",
+		"Gossiping /bbs/Gossiping/index10.html",
+		"Gossiping /bbs/Gossiping/index9.html",
+	}
+	if !slices.Equal(got, want) {
+		t.Errorf("送出的文章 = %v, want %v", got, want)
+	}
+
+	// 進度以所有看板的總頁數計算
+	var last types.ProgressEvent
+	for evt := range progressCh {
+		last = evt
+	}
+	if last.CurrentPage != 4 || last.TotalPages != 4 {
+		t.Errorf("最後進度 = %d/%d, want 4/4", last.CurrentPage, last.TotalPages)
+	}
+}
+
+func TestArticleProducer_CancelStopsRemainingBoards(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var requested []string
+	c := newMultiBoardCrawler("Beauty,Gossiping,NBA", 3, func(req *http.Request) {
+		requested = append(requested, req.URL.Path)
+		if strings.HasPrefix(req.URL.Path, "/bbs/Beauty/index9") {
+			cancel()
+		}
+	})
+
+	ch := make(chan types.ArticleInfo, 10)
+	c.articleProducer(ctx, ch)
+
+	for _, path := range requested {
+		if !strings.HasPrefix(path, "/bbs/Beauty/") {
+			t.Errorf("取消後不應再爬取其他看板，卻請求了 %s", path)
+		}
+	}
+}
+
+func TestDispatchTasks_NamespacesByBoard(t *testing.T) {
+	c := newMultiBoardCrawler("Beauty,Gossiping", 1, nil)
+	downloadCh := make(chan types.DownloadTask, 4)
+	markdownCh := make(chan types.MarkdownInfo, 4)
+	imgs := []string{"https://i.imgur.com/a.jpg"}
+
+	// 兩個看板的文章標題與推文數相同，目錄名相同但位於各自的看板目錄下
+	c.dispatchTasks(context.Background(), "同名", types.ArticleInfo{URL: "u1", Board: "Beauty", PushRate: 10}, imgs, downloadCh, markdownCh)
+	c.dispatchTasks(context.Background(), "同名", types.ArticleInfo{URL: "u2", Board: "Gossiping", PushRate: 10}, imgs, downloadCh, markdownCh)
+	// 檔案模式的文章沒有看板標記，沿用第一個看板
+	c.dispatchTasks(context.Background(), "同名", types.ArticleInfo{URL: "u3", PushRate: 10}, imgs, downloadCh, markdownCh)
+	close(markdownCh)
+
+	var got []string
+	for info := range markdownCh {
+		got = append(got, info.SaveDir)
+	}
+	want := []string{
+		filepath.Join("Beauty", "同名_10"),
+		filepath.Join("Gossiping", "同名_10"),
+		filepath.Join("Beauty", "同名_10_2"),
+	}
+	if !slices.Equal(got, want) {
+		t.Errorf("SaveDir = %v, want %v", got, want)
+	}
+}
+
+func TestDispatchTasks_OutputDir(t *testing.T) {
+	out := filepath.Join("downloads", "ptt")
+	c := newMultiBoardCrawler("Beauty", 1, nil)
+	WithOutputDir(out)(c)
+	downloadCh := make(chan types.DownloadTask, 4)
+	markdownCh := make(chan types.MarkdownInfo, 4)
+
+	c.dispatchTasks(context.Background(), "標題", types.ArticleInfo{URL: "u1", Board: "Beauty", PushRate: 10},
+		[]string{"https://i.imgur.com/a.jpg"}, downloadCh, markdownCh)
+
+	info := <-markdownCh
+	task := <-downloadCh
+	wantDir := filepath.Join(out, "Beauty", "標題_10")
+	if info.SaveDir != wantDir {
+		t.Errorf("SaveDir = %q, want %q", info.SaveDir, wantDir)
+	}
+	// output.linkBase 的連結以輸出根目錄為起點，不含 -output 的路徑
+	if info.SiteDir != "Beauty/標題_10" {
+		t.Errorf("SiteDir = %q, want %q", info.SiteDir, "Beauty/標題_10")
+	}
+	if want := filepath.Join(wantDir, "a.jpg"); task.SavePath != want {
+		t.Errorf("SavePath = %q, want %q", task.SavePath, want)
+	}
+
+	// 看板總覽依 SaveDir 的上層分組，日誌仍需對應回看板名稱
+	if got := c.dirBoard(filepath.Dir(info.SaveDir)); got != "Beauty" {
+		t.Errorf("dirBoard() = %q, want %q", got, "Beauty")
+	}
+	if got := c.dirBoard(out); got != "" {
+		t.Errorf("dirBoard(輸出根目錄) = %q, want 空字串", got)
+	}
+}
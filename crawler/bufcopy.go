@@ -0,0 +1,58 @@
+package crawler
+
+import (
+	"bufio"
+	"io"
+	"sync"
+)
+
+// bufferedCopier 以固定大小的寫入緩衝將下載內容寫入檔案。
+// 網路回應每次 Read 通常只有幾 KB，直接 io.Copy 到檔案時每次 Read 都對應一次 write 系統呼叫；
+// 先累積到緩衝區再寫入可減少大量小圖下載時的系統呼叫次數。
+// 緩衝區透過 sync.Pool 在 worker 之間重複使用，避免每張圖片都重新配置。
+type bufferedCopier struct {
+	size int
+	pool sync.Pool // *copyBuffers
+}
+
+// copyBuffers 一次複製所需的讀取緩衝與寫入緩衝
+type copyBuffers struct {
+	buf []byte
+	w   *bufio.Writer
+}
+
+// newBufferedCopier 建立寫入緩衝為 size bytes 的 copier，size <= 0 時回傳 nil（直接使用 io.Copy）
+func newBufferedCopier(size int) *bufferedCopier {
+	if size <= 0 {
+		return nil
+	}
+	b := &bufferedCopier{size: size}
+	b.pool.New = func() any {
+		return &copyBuffers{buf: make([]byte, size), w: bufio.NewWriterSize(nil, size)}
+	}
+	return b
+}
+
+// writerOnly、readerOnly 隱藏 ReadFrom/WriteTo，
+// 避免 io.CopyBuffer 繞過緩衝直接呼叫 *os.File.ReadFrom
+type writerOnly struct{ io.Writer }
+type readerOnly struct{ io.Reader }
+
+// Copy 將 src 完整寫入 dst 並回傳寫入的 bytes 數；copier 為 nil 時等同 io.Copy
+func (b *bufferedCopier) Copy(dst io.Writer, src io.Reader) (int64, error) {
+	if b == nil {
+		return io.Copy(dst, src)
+	}
+	bufs := b.pool.Get().(*copyBuffers)
+	defer func() {
+		bufs.w.Reset(nil)
+		b.pool.Put(bufs)
+	}()
+
+	bufs.w.Reset(dst)
+	n, err := io.CopyBuffer(writerOnly{bufs.w}, readerOnly{src}, bufs.buf)
+	if err != nil {
+		return n, err
+	}
+	return n, bufs.w.Flush()
+}
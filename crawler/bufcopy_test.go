@@ -0,0 +1,125 @@
+package crawler
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// chunkReader 模擬網路回應：每次 Read 最多回傳 chunk bytes
+type chunkReader struct {
+	data  []byte
+	chunk int
+}
+
+func (r *chunkReader) Read(p []byte) (int, error) {
+	if len(r.data) == 0 {
+		return 0, io.EOF
+	}
+	n := min(len(p), r.chunk, len(r.data))
+	copy(p, r.data[:n])
+	r.data = r.data[n:]
+	return n, nil
+}
+
+// countingWriter 記錄 Write 呼叫次數（對應寫入檔案的系統呼叫）
+type countingWriter struct {
+	buf    bytes.Buffer
+	writes int
+}
+
+func (w *countingWriter) Write(p []byte) (int, error) {
+	w.writes++
+	return w.buf.Write(p)
+}
+
+type failingWriter struct{}
+
+func (failingWriter) Write([]byte) (int, error) { return 0, errors.New("disk full") }
+
+func TestBufferedCopier_Copy(t *testing.T) {
+	data := bytes.Repeat([]byte("0123456789"), 20_000) // 200 KB
+
+	tests := []struct {
+		name       string
+		copier     *bufferedCopier
+		wantWrites int
+	}{
+		{"不緩衝", newBufferedCopier(0), len(data)/1460 + 1},
+		{"64 KB 緩衝", newBufferedCopier(64 * 1024), len(data)/(64*1024) + 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var w countingWriter
+			n, err := tt.copier.Copy(&w, &chunkReader{data: data, chunk: 1460})
+			if err != nil {
+				t.Fatalf("Copy() error = %v", err)
+			}
+			if n != int64(len(data)) || !bytes.Equal(w.buf.Bytes(), data) {
+				t.Fatalf("Copy() 寫入 %d bytes，內容不一致", n)
+			}
+			if w.writes != tt.wantWrites {
+				t.Errorf("Write 呼叫 %d 次, want %d", w.writes, tt.wantWrites)
+			}
+		})
+	}
+}
+
+func TestBufferedCopier_ReusesBuffers(t *testing.T) {
+	c := newBufferedCopier(4096)
+	for i := range 3 {
+		var w bytes.Buffer
+		data := bytes.Repeat([]byte{byte('a' + i)}, 5000+i)
+		if _, err := c.Copy(&w, bytes.NewReader(data)); err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(w.Bytes(), data) {
+			t.Fatalf("第 %d 次複製內容不一致（緩衝區重用時不應殘留前次資料）", i)
+		}
+	}
+}
+
+func TestBufferedCopier_WriteError(t *testing.T) {
+	_, err := newBufferedCopier(4096).Copy(failingWriter{}, bytes.NewReader(make([]byte, 100)))
+	if err == nil {
+		t.Error("寫入失敗時（含最後 Flush）應回傳錯誤")
+	}
+}
+
+// BenchmarkCopyToFile 比較直接 io.Copy 與緩衝寫入大量小圖到檔案的吞吐：
+//
+//	go test ./crawler -run '^$' -bench CopyToFile -benchmem
+func BenchmarkCopyToFile(b *testing.B) {
+	dir := b.TempDir()
+	data := bytes.Repeat([]byte{0xAB}, 100*1024) // 100 KB 的小圖
+
+	for _, bc := range []struct {
+		name string
+		size int
+	}{
+		{"io.Copy", 0},
+		{"buffer-32KB", 32 * 1024},
+		{"buffer-64KB", 64 * 1024},
+		{"buffer-256KB", 256 * 1024},
+	} {
+		b.Run(bc.name, func(b *testing.B) {
+			copier := newBufferedCopier(bc.size)
+			path := filepath.Join(dir, bc.name+".jpg")
+			b.SetBytes(int64(len(data)))
+			for b.Loop() {
+				f, err := os.Create(path)
+				if err != nil {
+					b.Fatal(err)
+				}
+				// 每次 Read 約一個 TCP 封包大小，接近實際下載時的讀取粒度
+				if _, err := copier.Copy(f, &chunkReader{data: data, chunk: 1460}); err != nil {
+					b.Fatal(err)
+				}
+				_ = f.Close()
+			}
+		})
+	}
+}
@@ -0,0 +1,44 @@
+package crawler
+
+import (
+	"time"
+
+	"github.com/twtrubiks/ptt-spider-go/interfaces"
+	"github.com/twtrubiks/ptt-spider-go/types"
+	"github.com/twtrubiks/ptt-spider-go/ui"
+)
+
+// WithCache 啟用跨執行的文章快取：ttl 內爬過的文章不再處理，本次完整處理的文章記錄爬取時間到 cm
+func WithCache(cm interfaces.CacheManager, ttl time.Duration) Option {
+	return func(c *Crawler) {
+		c.cache = cm
+		c.cacheTTL = ttl
+	}
+}
+
+// skipCached 回報文章是否在快取有效期限內爬過，是則記錄並略過
+func (c *Crawler) skipCached(log ui.Logger, article types.ArticleInfo) bool {
+	if c.cache == nil {
+		return false
+	}
+	crawledAt, ok := c.cache.Get(article.URL)
+	if !ok {
+		return false
+	}
+	if age := time.Since(crawledAt); age < c.cacheTTL {
+		log.Info("文章已於 %s 前爬取過（有效期限 %s），略過: %s",
+			age.Truncate(time.Second), c.cacheTTL, c.getLogMessage(article))
+		return true
+	}
+	return false
+}
+
+// saveCache 將文章快取寫回磁碟，失敗時僅記錄錯誤；試跑模式不寫入
+func (c *Crawler) saveCache() {
+	if c.cache == nil || c.dryRun != nil {
+		return
+	}
+	if err := c.cache.Save(); err != nil {
+		c.logger.Error("儲存文章快取失敗: %v", err)
+	}
+}
@@ -0,0 +1,91 @@
+package crawler
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/twtrubiks/ptt-spider-go/cache"
+	"github.com/twtrubiks/ptt-spider-go/config"
+	"github.com/twtrubiks/ptt-spider-go/mocks"
+	"github.com/twtrubiks/ptt-spider-go/types"
+)
+
+func TestRun_Cache(t *testing.T) {
+	t.Chdir(t.TempDir())
+
+	const (
+		fresh  = "https://www.ptt.cc/bbs/test/M.1.A.html"
+		stale  = "https://www.ptt.cc/bbs/test/M.2.A.html"
+		fresh2 = "https://www.ptt.cc/bbs/test/M.3.A.html"
+	)
+	var (
+		mu      sync.Mutex
+		fetched []string
+	)
+	client := &mocks.MockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			mu.Lock()
+			fetched = append(fetched, req.URL.String())
+			mu.Unlock()
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       io.NopCloser(strings.NewReader(pngHeader)),
+				Request:    req,
+			}, nil
+		},
+	}
+	parser := &mocks.MockParser{
+		ParseMaxPageFunc: func(_ io.Reader) (int, error) { return 1, nil },
+		ParseArticlesFunc: func(_ io.Reader) ([]types.ArticleInfo, error) {
+			return []types.ArticleInfo{
+				{Title: "剛爬過", URL: fresh, PushRate: 10},
+				{Title: "已過期", URL: stale, PushRate: 10},
+				{Title: "新文章", URL: fresh2, PushRate: 10},
+			}, nil
+		},
+		ParseArticleContentFunc: func(_ io.Reader) (types.ArticleContent, error) {
+			return types.ArticleContent{Title: "文章", ImageURLs: []string{"http://example.com/img/1.png"}}, nil
+		},
+	}
+
+	cfg := config.DefaultConfig()
+	cfg.Crawler.Delays = config.DelayConfig{MinMs: 0, MaxMs: 0}
+
+	path := filepath.Join(t.TempDir(), "cache.json")
+	cm, err := cache.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	staleAt := time.Now().Add(-2 * time.Hour)
+	cm.Set(fresh, time.Now().Add(-time.Minute))
+	cm.Set(stale, staleAt)
+
+	c := NewCrawlerWithDependencies(client, parser, mocks.NewMockMarkdownGenerator(),
+		"test", 1, 0, "", cfg, WithLogger(&mocks.MockLogger{}), WithCache(cm, time.Hour))
+	c.Run(context.Background())
+
+	if slices.Contains(fetched, fresh) {
+		t.Error("有效期限內爬過的文章不應重新爬取")
+	}
+	if !slices.Contains(fetched, stale) || !slices.Contains(fetched, fresh2) {
+		t.Errorf("過期與未記錄的文章應爬取, fetched = %v", fetched)
+	}
+
+	// Run 結束時應已寫入磁碟，本次完成的文章更新爬取時間
+	loaded, err := cache.Open(path)
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	for _, u := range []string{stale, fresh2} {
+		if at, ok := loaded.Get(u); !ok || !at.After(staleAt) {
+			t.Errorf("文章 %s 的爬取時間 = %v, %v，應更新為本次執行", u, at, ok)
+		}
+	}
+}
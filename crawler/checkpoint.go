@@ -0,0 +1,106 @@
+package crawler
+
+import (
+	"context"
+	"time"
+
+	"github.com/twtrubiks/ptt-spider-go/checkpoint"
+	"github.com/twtrubiks/ptt-spider-go/types"
+	"github.com/twtrubiks/ptt-spider-go/ui"
+)
+
+// checkpointSaveInterval 執行期間定期寫入 checkpoint 的間隔，避免異常終止時遺失太多進度
+const checkpointSaveInterval = 30 * time.Second
+
+// WithCheckpoint 啟用斷點續爬：略過 cp 中已完成的文章，並將本次完整處理的文章記錄到 cp
+func WithCheckpoint(cp *checkpoint.Checkpoint) Option {
+	return func(c *Crawler) { c.checkpoint = cp }
+}
+
+// skipCompleted 回報文章是否已在先前的執行中完成，是則記錄並略過
+func (c *Crawler) skipCompleted(log ui.Logger, article types.ArticleInfo) bool {
+	if c.checkpoint == nil || !c.checkpoint.IsDone(article.URL) {
+		return false
+	}
+	log.Info("文章已於先前執行完成，略過: %s", c.getLogMessage(article))
+	return true
+}
+
+// trackArticle 開始追蹤文章的剩餘工作：downloads 張圖片加上一次 Markdown 產生，全部完成才算處理完畢
+func (c *Crawler) trackArticle(articleURL string, downloads int) {
+	if !c.tracksCompletion() {
+		return
+	}
+	c.pendingMu.Lock()
+	defer c.pendingMu.Unlock()
+	if c.pending == nil {
+		c.pending = make(map[string]int)
+	}
+	c.pending[articleURL] = downloads + 1
+}
+
+// articleTaskDone 記錄文章的一項工作（圖片下載或 Markdown 產生）已完成，全部完成時標記到 checkpoint 與文章快取。
+// 下載失敗（如 404）同樣視為已處理，續爬時不會為了同一張失效圖片重爬整篇文章；
+// 被中斷的工作不應呼叫此函式，文章會在下次續爬時重新處理。
+func (c *Crawler) articleTaskDone(articleURL string) {
+	if !c.tracksCompletion() {
+		return
+	}
+	c.pendingMu.Lock()
+	n, ok := c.pending[articleURL]
+	if ok {
+		n--
+		if n > 0 {
+			c.pending[articleURL] = n
+		} else {
+			delete(c.pending, articleURL)
+		}
+	}
+	c.pendingMu.Unlock()
+
+	if ok && n == 0 {
+		c.markArticleDone(articleURL)
+	}
+}
+
+// tracksCompletion 回報是否需要追蹤文章何時處理完畢（啟用 checkpoint 或文章快取時）
+func (c *Crawler) tracksCompletion() bool {
+	return c.checkpoint != nil || c.cache != nil
+}
+
+// markArticleDone 將文章標記為已完成；沒有後續工作的文章（如沒有圖片）直接呼叫
+func (c *Crawler) markArticleDone(articleURL string) {
+	if c.checkpoint != nil {
+		c.checkpoint.MarkDone(articleURL)
+	}
+	if c.cache != nil {
+		c.cache.Set(articleURL, time.Now())
+	}
+}
+
+// saveCheckpoint 將 checkpoint 寫回磁碟，失敗時僅記錄錯誤；試跑模式不寫入
+func (c *Crawler) saveCheckpoint() {
+	if c.checkpoint == nil || c.dryRun != nil {
+		return
+	}
+	if err := c.checkpoint.Save(); err != nil {
+		c.logger.Error("儲存 checkpoint 失敗: %v", err)
+	}
+}
+
+// saveCheckpointPeriodically 每隔 interval 寫入一次 checkpoint 與文章快取，直到 ctx 取消或 stop 關閉
+func (c *Crawler) saveCheckpointPeriodically(ctx context.Context, interval time.Duration, stop <-chan struct{}) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-stop:
+			return
+		case <-ticker.C:
+			c.saveCheckpoint()
+			c.saveCache()
+		}
+	}
+}
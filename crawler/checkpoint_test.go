@@ -0,0 +1,130 @@
+package crawler
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/twtrubiks/ptt-spider-go/checkpoint"
+	"github.com/twtrubiks/ptt-spider-go/config"
+	"github.com/twtrubiks/ptt-spider-go/mocks"
+	"github.com/twtrubiks/ptt-spider-go/types"
+)
+
+func TestRun_Checkpoint(t *testing.T) {
+	t.Chdir(t.TempDir())
+
+	const (
+		done     = "https://www.ptt.cc/bbs/test/M.1.A.html"
+		withImgs = "https://www.ptt.cc/bbs/test/M.2.A.html"
+		noImgs   = "https://www.ptt.cc/bbs/test/M.3.A.html"
+	)
+	var (
+		mu      sync.Mutex
+		fetched []string
+	)
+	client := &mocks.MockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			mu.Lock()
+			fetched = append(fetched, req.URL.String())
+			mu.Unlock()
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       io.NopCloser(strings.NewReader(pngHeader + req.URL.Path)),
+				Request:    req,
+			}, nil
+		},
+	}
+	parser := &mocks.MockParser{
+		ParseMaxPageFunc: func(_ io.Reader) (int, error) { return 1, nil },
+		ParseArticlesFunc: func(_ io.Reader) ([]types.ArticleInfo, error) {
+			return []types.ArticleInfo{
+				{Title: "已完成", URL: done, PushRate: 10},
+				{Title: "有圖", URL: withImgs, PushRate: 10},
+				{Title: "無圖", URL: noImgs, PushRate: 10},
+			}, nil
+		},
+		ParseArticleContentFunc: func(body io.Reader) (types.ArticleContent, error) {
+			data, _ := io.ReadAll(body)
+			if strings.Contains(string(data), "M.2.A") {
+				return types.ArticleContent{Title: "有圖", ImageURLs: []string{
+					"http://example.com/img/1.png",
+					"http://example.com/img/missing.png",
+				}}, nil
+			}
+			return types.ArticleContent{Title: "無圖"}, nil
+		},
+	}
+
+	cfg := config.DefaultConfig()
+	cfg.Crawler.Delays = config.DelayConfig{MinMs: 0, MaxMs: 0}
+
+	path := filepath.Join(t.TempDir(), "checkpoint.json")
+	cp := checkpoint.New(path)
+	cp.MarkDone(done)
+
+	c := NewCrawlerWithDependencies(client, parser, mocks.NewMockMarkdownGenerator(),
+		"test", 1, 0, "", cfg, WithLogger(&mocks.MockLogger{}), WithCheckpoint(cp))
+	c.Run(context.Background())
+
+	if slices.Contains(fetched, done) {
+		t.Error("checkpoint 中已完成的文章不應重新爬取")
+	}
+
+	// Run 結束時應已寫入磁碟，重新載入可看到本次完成的文章
+	loaded, err := checkpoint.Load(path)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	for _, u := range []string{done, withImgs, noImgs} {
+		if !loaded.IsDone(u) {
+			t.Errorf("文章 %s 應標記為已完成", u)
+		}
+	}
+}
+
+func TestArticleTaskDone(t *testing.T) {
+	const articleURL = "https://www.ptt.cc/bbs/test/M.1.A.html"
+	cp := checkpoint.New(filepath.Join(t.TempDir(), "checkpoint.json"))
+	c := NewCrawlerWithDependencies(&mocks.MockHTTPClient{}, mocks.NewMockParser(), mocks.NewMockMarkdownGenerator(),
+		"test", 1, 0, "", config.DefaultConfig(), WithLogger(&mocks.MockLogger{}), WithCheckpoint(cp))
+
+	c.trackArticle(articleURL, 2)
+	c.articleTaskDone(articleURL) // 第一張圖片
+	c.articleTaskDone(articleURL) // Markdown
+	if cp.IsDone(articleURL) {
+		t.Fatal("仍有圖片未下載時不應標記為已完成")
+	}
+	c.articleTaskDone(articleURL) // 第二張圖片
+	if !cp.IsDone(articleURL) {
+		t.Error("所有工作完成後應標記為已完成")
+	}
+}
+
+func TestDownloadOne_InterruptedNotDone(t *testing.T) {
+	const articleURL = "https://www.ptt.cc/bbs/test/M.1.A.html"
+	cp := checkpoint.New(filepath.Join(t.TempDir(), "checkpoint.json"))
+	cfg := config.DefaultConfig()
+	cfg.Crawler.Delays = config.DelayConfig{MinMs: 0, MaxMs: 0}
+	c := NewCrawlerWithDependencies(&mocks.MockHTTPClient{}, mocks.NewMockParser(), mocks.NewMockMarkdownGenerator(),
+		"test", 1, 0, "", cfg, WithLogger(&mocks.MockLogger{}), WithCheckpoint(cp))
+
+	c.trackArticle(articleURL, 1)
+	c.articleTaskDone(articleURL) // Markdown 已產生
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	c.downloadOne(ctx, 1, types.DownloadTask{
+		ImageURL:   "http://example.com/a.png",
+		SavePath:   filepath.Join(t.TempDir(), "a.png"),
+		ArticleURL: articleURL,
+	})
+	if cp.IsDone(articleURL) {
+		t.Error("下載被中斷的文章不應標記為已完成，續爬時需重新處理")
+	}
+}
@@ -0,0 +1,119 @@
+package crawler
+
+import (
+	"os"
+	"path"
+	"path/filepath"
+
+	"github.com/twtrubiks/ptt-spider-go/config"
+	"github.com/twtrubiks/ptt-spider-go/constants"
+	"github.com/twtrubiks/ptt-spider-go/internal/fileutil"
+	"github.com/twtrubiks/ptt-spider-go/internal/imageutil"
+	"github.com/twtrubiks/ptt-spider-go/types"
+)
+
+// 圖片分類子目錄名稱
+const (
+	classLandscape = "landscape"
+	classPortrait  = "portrait"
+	classSquare    = "square"
+	classSmall     = "small"
+	classMedium    = "medium"
+	classLarge     = "large"
+	classUnknown   = "unknown" // 無法解碼（格式不支援或檔案損毀）的圖片
+)
+
+// classifyEnabled 回傳是否啟用下載後的圖片分類
+func (c *Crawler) classifyEnabled() bool {
+	by := c.config.Crawler.Classify.By
+	return by == config.ClassifyByOrientation || by == config.ClassifyBySize
+}
+
+// imageClass 依分類設定與圖片寬高回傳分類子目錄名稱
+func imageClass(cfg config.ClassifyConfig, width, height int) string {
+	if width <= 0 || height <= 0 {
+		return classUnknown
+	}
+	long, short := max(width, height), min(width, height)
+
+	if cfg.By == config.ClassifyBySize {
+		switch {
+		case long >= cfg.LargeEdge:
+			return classLarge
+		case long >= cfg.MediumEdge:
+			return classMedium
+		default:
+			return classSmall
+		}
+	}
+
+	switch {
+	case float64(long-short) <= float64(long)*cfg.SquareTolerance:
+		return classSquare
+	case width > height:
+		return classLandscape
+	default:
+		return classPortrait
+	}
+}
+
+// classifyImage 將已存檔的圖片移到文章目錄下的分類子目錄，回傳圖片目前的路徑。
+// 無法解碼的圖片歸入 unknown；檔案不存在（如 dedup 略過）或移動失敗時保留原位置。
+func (c *Crawler) classifyImage(id int, task types.DownloadTask) string {
+	if !c.classifyEnabled() {
+		return task.SavePath
+	}
+	if _, err := os.Stat(task.SavePath); err != nil {
+		return task.SavePath
+	}
+	log := c.boardLogger(task.Board)
+
+	class := classUnknown
+	if width, height, err := imageutil.Dimensions(task.SavePath); err == nil {
+		class = imageClass(c.config.Crawler.Classify, width, height)
+	} else {
+		log.Debug("工人 #%d 無法取得圖片尺寸，歸入 %s: %s, 錯誤: %v", id, classUnknown, task.SavePath, err)
+	}
+
+	dir, name := filepath.Split(task.SavePath)
+	dest := filepath.Join(dir, class, name)
+	if err := os.MkdirAll(filepath.Join(dir, class), constants.DirPermission); err != nil {
+		log.Warn("工人 #%d 建立分類目錄失敗，保留原位置: %s, 錯誤: %v", id, task.SavePath, err)
+		return task.SavePath
+	}
+	if err := os.Rename(task.SavePath, dest); err != nil {
+		log.Warn("工人 #%d 移動圖片到分類目錄失敗，保留原位置: %s, 錯誤: %v", id, task.SavePath, err)
+		return task.SavePath
+	}
+	log.Debug("工人 #%d 圖片分類為 %s: %s", id, class, dest)
+
+	c.classifiedMu.Lock()
+	defer c.classifiedMu.Unlock()
+	if c.classified == nil {
+		c.classified = make(map[string]string)
+	}
+	// Markdown 連結一律使用 /，與作業系統的路徑分隔符號無關
+	c.classified[task.SavePath] = path.Join(class, name)
+	return dest
+}
+
+// classifiedFiles 回傳文章各圖片相對於 saveDir 的路徑，供 Markdown 引用分類後的位置。
+// 未啟用分類時回傳 nil（由 markdown 端依 URL 推導檔名）；未分類的圖片（如下載失敗）沿用原檔名。
+// 取出的記錄會一併刪除，已產生 Markdown 的文章不再佔用記憶體。
+func (c *Crawler) classifiedFiles(saveDir string, imgURLs []string) []string {
+	if !c.classifyEnabled() {
+		return nil
+	}
+	files := fileutil.ImageFileNames(imgURLs)
+
+	c.classifiedMu.Lock()
+	defer c.classifiedMu.Unlock()
+	for i, name := range files {
+		key := filepath.Join(saveDir, name)
+		if rel, ok := c.classified[key]; ok {
+			files[i] = rel
+			delete(c.classified, key)
+		}
+	}
+	return files
+}
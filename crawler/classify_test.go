@@ -0,0 +1,128 @@
+package crawler
+
+import (
+	"bytes"
+	"context"
+	"image"
+	"image/png"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/twtrubiks/ptt-spider-go/config"
+	"github.com/twtrubiks/ptt-spider-go/mocks"
+	"github.com/twtrubiks/ptt-spider-go/types"
+)
+
+func TestImageClass(t *testing.T) {
+	orientation := config.DefaultConfig().Crawler.Classify
+	orientation.By = config.ClassifyByOrientation
+	size := config.DefaultConfig().Crawler.Classify
+	size.By = config.ClassifyBySize
+
+	tests := []struct {
+		name          string
+		cfg           config.ClassifyConfig
+		width, height int
+		want          string
+	}{
+		{"橫圖", orientation, 1600, 900, classLandscape},
+		{"直圖", orientation, 900, 1600, classPortrait},
+		{"正方形", orientation, 1000, 1000, classSquare},
+		{"差距在容許範圍內視為正方形", orientation, 1000, 960, classSquare},
+		{"差距超過容許範圍", orientation, 1000, 940, classLandscape},
+		{"尺寸為 0 無法分類", orientation, 0, 100, classUnknown},
+		{"長邊未達 mediumEdge", size, 799, 600, classSmall},
+		{"長邊達 mediumEdge", size, 600, 800, classMedium},
+		{"長邊達 largeEdge", size, 1920, 1080, classLarge},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := imageClass(tt.cfg, tt.width, tt.height); got != tt.want {
+				t.Errorf("imageClass(%d, %d) = %q, want %q", tt.width, tt.height, got, tt.want)
+			}
+		})
+	}
+}
+
+// encodePNG 產生指定寬高的 PNG 內容
+func encodePNG(t *testing.T, width, height int) string {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, image.NewGray(image.Rect(0, 0, width, height))); err != nil {
+		t.Fatalf("編碼 PNG 失敗: %v", err)
+	}
+	return buf.String()
+}
+
+// TestDispatchTasks_Classify 驗證圖片在下載成功後依比例移到分類子目錄、無法解碼的歸入 unknown，
+// 下載失敗的圖片不分類，且 Markdown 任務引用移動後的路徑
+func TestDispatchTasks_Classify(t *testing.T) {
+	t.Chdir(t.TempDir())
+
+	bodies := map[string]string{
+		"/wide.png":   encodePNG(t, 40, 20),
+		"/tall.png":   encodePNG(t, 20, 40),
+		"/square.png": encodePNG(t, 30, 30),
+		// 通過檔頭檢查但無法解碼
+		"/broken.png": pngHeader + "broken",
+	}
+	client := &mocks.MockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			body, ok := bodies[req.URL.Path]
+			if !ok {
+				return &http.Response{StatusCode: http.StatusNotFound, Body: io.NopCloser(strings.NewReader("")), Request: req}, nil
+			}
+			return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(body)), Request: req}, nil
+		},
+	}
+
+	cfg := config.DefaultConfig()
+	cfg.Crawler.Delays = config.DelayConfig{MinMs: 0, MaxMs: 0}
+	cfg.Crawler.Classify.By = config.ClassifyByOrientation
+	cfg.Crawler.DownloadMode = config.DownloadModeArticle
+	cfg.Crawler.Retry.MaxAttempts = 1
+	c := NewCrawlerWithDependencies(client, mocks.NewMockParser(), mocks.NewMockMarkdownGenerator(),
+		"Beauty", 1, 0, "", cfg, WithLogger(&mocks.MockLogger{}))
+	c.workerIDs = newWorkerIDPool(2)
+
+	imgs := []string{
+		"https://example.com/wide.png",
+		"https://example.com/tall.png",
+		"https://example.com/square.png",
+		"https://example.com/broken.png",
+		"https://example.com/missing.png",
+	}
+	markdownCh := make(chan types.MarkdownInfo, 1)
+	c.dispatchTasks(context.Background(), "標題", types.ArticleInfo{URL: "u1", Board: "Beauty", PushRate: 10},
+		imgs, nil, markdownCh)
+
+	info := <-markdownCh
+	want := []string{"landscape/wide.png", "portrait/tall.png", "square/square.png", "unknown/broken.png", "missing.png"}
+	if !slices.Equal(info.ImageFiles, want) {
+		t.Errorf("ImageFiles = %v, want %v", info.ImageFiles, want)
+	}
+	for _, f := range want[:4] {
+		if _, err := os.Stat(filepath.Join(info.SaveDir, filepath.FromSlash(f))); err != nil {
+			t.Errorf("分類後的圖片應存在: %v", err)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(info.SaveDir, "wide.png")); !os.IsNotExist(err) {
+		t.Errorf("分類後原位置不應留下檔案, err = %v", err)
+	}
+	if len(c.classified) != 0 {
+		t.Errorf("產生 Markdown 任務後應清除分類記錄, got %v", c.classified)
+	}
+}
+
+func TestClassifiedFiles_Disabled(t *testing.T) {
+	c := NewCrawlerWithDependencies(&mocks.MockHTTPClient{}, mocks.NewMockParser(), mocks.NewMockMarkdownGenerator(),
+		"Beauty", 1, 0, "", config.DefaultConfig(), WithLogger(&mocks.MockLogger{}))
+	if got := c.classifiedFiles("dir", []string{"https://example.com/a.jpg"}); got != nil {
+		t.Errorf("未啟用分類時應回傳 nil, got %v", got)
+	}
+}
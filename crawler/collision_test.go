@@ -9,6 +9,7 @@ import (
 	"testing"
 
 	"github.com/twtrubiks/ptt-spider-go/config"
+	"github.com/twtrubiks/ptt-spider-go/internal/fileutil"
 	"github.com/twtrubiks/ptt-spider-go/mocks"
 	"github.com/twtrubiks/ptt-spider-go/types"
 )
@@ -46,8 +47,8 @@ func newCollisionTestCrawler(imgURLs []string) *Crawler {
 		},
 	}
 	mockParser := &mocks.MockParser{
-		ParseArticleContentFunc: func(_ io.Reader) (string, []string, error) {
-			return "Same Title", imgURLs, nil
+		ParseArticleContentFunc: func(_ io.Reader) (types.ArticleContent, error) {
+			return types.ArticleContent{Title: "Same Title", ImageURLs: imgURLs}, nil
 		},
 	}
 	cfg := &config.Config{
@@ -120,3 +121,41 @@ func TestProcessArticle_SameTitleGetsDistinctDirs(t *testing.T) {
 		t.Errorf("second dir = %q, want %q", dirs[1], filepath.Join("test", "Same Title_10_2"))
 	}
 }
+
+// TestProcessArticle_CollidingNamesMatchMarkdown 驗證碰撞後加上序號的檔名與 Markdown 引用的檔名一致，
+// 僅大小寫不同的檔名（在不分大小寫的檔案系統上會互相覆蓋）同樣視為碰撞
+func TestProcessArticle_CollidingNamesMatchMarkdown(t *testing.T) {
+	imgURLs := []string{
+		"https://host1.com/image.jpg",
+		"https://host2.com/image.jpg",
+		"https://i.imgur.com/AbC.jpg",
+		"https://i.imgur.com/abc.jpg",
+	}
+	c := newCollisionTestCrawler(imgURLs)
+
+	downloadChan := make(chan types.DownloadTask, 10)
+	markdownChan := make(chan types.MarkdownInfo, 10)
+	article := types.ArticleInfo{Title: "Same Title", URL: "http://example.com/article", PushRate: 10}
+	c.processArticle(context.Background(), article, downloadChan, markdownChan)
+	close(downloadChan)
+	close(markdownChan)
+
+	var saved []string
+	for task := range downloadChan {
+		saved = append(saved, filepath.Base(task.SavePath))
+	}
+	want := []string{"image.jpg", "image_2.jpg", "AbC.jpg", "abc_2.jpg"}
+	if strings.Join(saved, ",") != strings.Join(want, ",") {
+		t.Errorf("存檔名稱 = %v, want %v", saved, want)
+	}
+
+	// Markdown 端沒有 ImageFiles 時以同一列表推導檔名
+	info := <-markdownChan
+	got := info.ImageFiles
+	if len(got) == 0 {
+		got = fileutil.ImageFileNames(info.ImageURLs)
+	}
+	if strings.Join(got, ",") != strings.Join(saved, ",") {
+		t.Errorf("Markdown 引用的檔名 = %v, want 與存檔名稱 %v 一致", got, saved)
+	}
+}
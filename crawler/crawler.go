@@ -5,31 +5,51 @@ package crawler
 
 import (
 	"bufio"
+	"bytes"
+	"cmp"
 	"context"
+	"errors"
 	"fmt"
+	"hash/fnv"
 	"io"
+	"log/slog"
 	"math/rand/v2"
 	"net/http"
+	"net/url"
 	"os"
 	"path/filepath"
 	"regexp"
+	"runtime"
+	"slices"
 	"strings"
 	"sync"
+	"sync/atomic"
+	"text/template"
 	"time"
 
+	"github.com/twtrubiks/ptt-spider-go/checkpoint"
 	"github.com/twtrubiks/ptt-spider-go/config"
 	"github.com/twtrubiks/ptt-spider-go/constants"
+	"github.com/twtrubiks/ptt-spider-go/dedup"
+	crawlererrors "github.com/twtrubiks/ptt-spider-go/errors"
 	"github.com/twtrubiks/ptt-spider-go/interfaces"
 	"github.com/twtrubiks/ptt-spider-go/internal/fileutil"
+	"github.com/twtrubiks/ptt-spider-go/internal/imageutil"
 	"github.com/twtrubiks/ptt-spider-go/internal/ioutil"
 	"github.com/twtrubiks/ptt-spider-go/markdown"
+	"github.com/twtrubiks/ptt-spider-go/metrics"
 	"github.com/twtrubiks/ptt-spider-go/performance"
 	"github.com/twtrubiks/ptt-spider-go/ptt"
+	"github.com/twtrubiks/ptt-spider-go/ratelimit"
+	"github.com/twtrubiks/ptt-spider-go/robots"
+	"github.com/twtrubiks/ptt-spider-go/titledup"
 	"github.com/twtrubiks/ptt-spider-go/types"
 	"github.com/twtrubiks/ptt-spider-go/ui"
+	"github.com/twtrubiks/ptt-spider-go/workerpool"
 )
 
-const defaultMonitorInterval = 30 * time.Second
+// sniffLen 是 http.DetectContentType 判斷內容類型時最多參考的位元組數
+const sniffLen = 512
 
 var (
 	// 用於清理檔名中的非法字元（含反斜線，防止 Windows 上的路徑穿越）
@@ -39,29 +59,69 @@ var (
 	validBoardPattern = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
 )
 
-// validateBoard 驗證看板名稱。
-// 看板模式（fileURL 為空）必須指定看板名稱；
-// 名稱只允許英數字、底線與連字號，防止路徑穿越。
-func validateBoard(board, fileURL string) error {
-	if board == "" {
-		if fileURL == "" {
-			return fmt.Errorf("看板模式必須指定看板名稱")
+// parseBoards 將逗號分隔的看板清單拆成 []string，去除空白與重複項目。
+// PTT 看板名稱不分大小寫，重複判斷以不分大小寫比對，保留第一次出現的寫法。
+func parseBoards(board string) []string {
+	var boards []string
+	for _, name := range strings.Split(board, ",") {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
 		}
-		return nil
+		if slices.ContainsFunc(boards, func(b string) bool { return strings.EqualFold(b, name) }) {
+			continue
+		}
+		boards = append(boards, name)
 	}
-	if !validBoardPattern.MatchString(board) {
-		return fmt.Errorf("看板名稱 %q 不合法，只允許英數字、底線與連字號", board)
+	return boards
+}
+
+// validateBoards 解析並驗證看板清單（逗號分隔）。
+// 看板模式（fileURL 為空）必須至少指定一個看板；
+// 名稱只允許英數字、底線與連字號，防止路徑穿越。
+func validateBoards(board, fileURL string) ([]string, error) {
+	boards := parseBoards(board)
+	if len(boards) == 0 && fileURL == "" {
+		return nil, fmt.Errorf("看板模式必須指定看板名稱")
 	}
-	return nil
+	for _, b := range boards {
+		if !validBoardPattern.MatchString(b) {
+			return nil, fmt.Errorf("看板名稱 %q 不合法，只允許英數字、底線與連字號", b)
+		}
+	}
+	return boards, nil
 }
 
-// randomDelay 根據設定的延遲範圍回傳隨機延遲時間，當 min >= max 時直接回傳 min
-func randomDelay(minDelay, maxDelay time.Duration) time.Duration {
-	if maxDelay <= minDelay {
+// randomDelay 以 r 在設定的延遲範圍內取隨機延遲時間（毫秒精度），
+// 範圍不足 1ms（含 min >= max）時直接回傳 min，避免 IntN(0) panic
+func randomDelay(r *rand.Rand, minDelay, maxDelay time.Duration) time.Duration {
+	rangeMs := int((maxDelay - minDelay) / time.Millisecond)
+	if rangeMs <= 0 {
 		return minDelay
 	}
-	rangeMs := int((maxDelay - minDelay) / time.Millisecond)
-	return minDelay + time.Duration(rand.IntN(rangeMs))*time.Millisecond
+	return minDelay + time.Duration(r.IntN(rangeMs))*time.Millisecond
+}
+
+// newSeed 回傳隨機來源的種子：使用配置的 seed，未指定（0）時以目前時間產生
+func newSeed(seed int64) uint64 {
+	if seed == 0 {
+		return uint64(time.Now().UnixNano())
+	}
+	return uint64(seed)
+}
+
+// randFor 以種子與對象（文章或圖片 URL）衍生獨立的亂數序列。
+// 同一 seed 下每個對象取得的隨機值固定，不受 worker 排程與處理順序影響，重跑時可重現。
+func (c *Crawler) randFor(key string) *rand.Rand {
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(key))
+	return rand.New(rand.NewPCG(c.seed, h.Sum64()))
+}
+
+// delayFor 回傳處理 key（文章或圖片 URL）前的延遲：隨機延遲與該 host 的自適應延遲取較大者
+func (c *Crawler) delayFor(key string) time.Duration {
+	minDelay, maxDelay := c.delayRange()
+	return max(randomDelay(c.randFor(key), minDelay, maxDelay), c.adaptiveDelayFor(key))
 }
 
 // WorkerChannels 包含所有工人使用的 channels
@@ -73,9 +133,9 @@ type WorkerChannels struct {
 
 // Workers 包含所有工人的 WaitGroup
 type Workers struct {
-	Parsers     *sync.WaitGroup
-	Downloaders *sync.WaitGroup
-	Markdown    *sync.WaitGroup
+	Parsers  *sync.WaitGroup
+	Markdown *sync.WaitGroup
+	Scaler   *sync.WaitGroup // 依佇列深度調整下載工人數的 goroutine（pool 模式）
 }
 
 // Option 定義 Crawler 的可選配置函式
@@ -92,30 +152,185 @@ func WithLogger(l ui.Logger) Option {
 	return func(c *Crawler) { c.logger = l }
 }
 
+// WithRateLimiter 設定自訂的請求速率限制器，覆寫 rateLimit 配置建立的限制器。
+func WithRateLimiter(l interfaces.RateLimiter) Option {
+	return func(c *Crawler) { c.limiter = l }
+}
+
+// WithMetrics 設定自訂的指標收集器，預設使用 metrics.NewCollector()。
+func WithMetrics(m interfaces.MetricsCollector) Option {
+	return func(c *Crawler) { c.metrics = m }
+}
+
+// WithTitleFilter 只爬取標題符合正規表示式的文章（nil 表示不過濾）。
+// 看板模式比對列表頁的標題，檔案模式比對解析後的文章標題。
+func WithTitleFilter(re *regexp.Regexp) Option {
+	return func(c *Crawler) { c.titlePattern = re }
+}
+
+// WithTitleContains 只爬取標題包含指定字串的文章（不分大小寫，空字串表示不過濾）。
+func WithTitleContains(substr string) Option {
+	return func(c *Crawler) { c.titleContains = substr }
+}
+
+// WithAuthors 只爬取指定作者的文章（帳號不分大小寫，空清單表示不過濾）。
+// 看板模式比對列表頁的作者，檔案模式比對解析後的文章作者。
+func WithAuthors(authors []string) Option {
+	return func(c *Crawler) { c.authors = newAuthorSet(authors) }
+}
+
+// WithDirection 設定看板列表的爬取方向，預設為 DirectionNewest。
+func WithDirection(d Direction) Option {
+	return func(c *Crawler) { c.direction = d }
+}
+
+// WithFromPage 設定看板模式的起始頁碼（0 表示依方向從最新頁或第 1 頁開始），
+// 與 pages 一起決定爬取範圍，超出看板實際頁數的部分不會被請求。
+func WithFromPage(page int) Option {
+	return func(c *Crawler) { c.fromPage = page }
+}
+
+// WithPageRange 以絕對頁碼範圍取代 fromPage 與 pages 決定的爬取範圍（零值表示不使用），
+// 呼叫端應將 pages 設為 r.Len() 以正確計算進度；範圍超過看板實際頁數時略過該看板。
+func WithPageRange(r PageRange) Option {
+	return func(c *Crawler) { c.pageRange = r }
+}
+
+// WithStdin 設定 -file=- 時讀取文章網址的來源，預設為 os.Stdin。
+func WithStdin(r io.Reader) Option {
+	return func(c *Crawler) { c.stdin = r }
+}
+
+// WithRetryFailures 改為重試模式：讀取先前 failures.report 匯出的失敗明細，
+// 將其中的圖片直接排入下載工人池，不重新爬取列表與文章。優先於看板模式與檔案模式。
+func WithRetryFailures(path string) Option {
+	return func(c *Crawler) { c.retryFailures = path }
+}
+
+// WithQuiet 啟用後不輸出逐篇文章、逐張圖片與各工人啟動結束的例行訊息（如「延遲 … 後下載」「下載完成」），
+// 警告、錯誤與結束時的統計摘要照常輸出
+func WithQuiet(enabled bool) Option {
+	return func(c *Crawler) { c.quiet = enabled }
+}
+
+// WithDateRange 只爬取列表頁日期介於 since 與 until 之間（皆含當日）的文章，零值表示不限制該端。
+// 僅作用於看板模式（檔案模式沒有列表頁日期）。
+func WithDateRange(since, until time.Time) Option {
+	return func(c *Crawler) {
+		c.since = since
+		c.until = until
+	}
+}
+
+// WithOutputDir 設定輸出根目錄，看板目錄建立在其下（空字串表示目前工作目錄）。
+func WithOutputDir(dir string) Option {
+	return func(c *Crawler) { c.outputDir = dir }
+}
+
 // Crawler 結構體包含爬蟲的所有狀態和配置.
 // 支援看板模式和檔案模式兩種爬取方式.
 type Crawler struct {
-	client            interfaces.HTTPClient        // HTTP 客戶端，用於發送請求
-	parser            interfaces.Parser            // HTML 解析器
-	markdownGenerator interfaces.MarkdownGenerator // Markdown 生成器
-	optimizer         *performance.Optimizer       // 效能優化器
-	logger            ui.Logger                    // 日誌輸出器
-	progress          chan<- types.ProgressEvent   // 進度事件 channel（nil 時不發送）
-	board             string                       // 看板名稱（看板模式時使用）
-	pages             int                          // 要爬取的頁數
-	pushRate          int                          // 推文數門檻
-	fileURL           string                       // 檔案路徑（檔案模式時使用）
-	config            *config.Config               // 配置物件
+	client            interfaces.HTTPClient                // HTTP 客戶端，用於發送請求
+	parser            interfaces.Parser                    // HTML 解析器
+	markdownGenerator interfaces.MarkdownGenerator         // Markdown 生成器
+	optimizer         *performance.Optimizer               // 效能優化器
+	logger            ui.Logger                            // 日誌輸出器
+	progress          chan<- types.ProgressEvent           // 進度事件 channel（nil 時不發送）
+	boards            []string                             // 看板名稱清單（看板模式依序爬取；檔案模式以第一個作為儲存目錄）
+	pages             int                                  // 要爬取的頁數
+	pushRate          int                                  // 推文數門檻
+	filter            *articleFilter                       // 看板模式的文章過濾條件（門檻與 config filter）
+	titlePattern      *regexp.Regexp                       // 標題須符合的正規表示式（-title-filter，nil 時不過濾）
+	titleContains     string                               // 標題須包含的字串（-title-contains，空字串時不過濾）
+	authors           map[string]struct{}                  // 作者白名單，帳號轉為小寫（-author，nil 時不過濾）
+	since             time.Time                            // 列表頁日期下限（-since，零值時不限制）
+	until             time.Time                            // 列表頁日期上限（-until，零值時不限制）
+	direction         Direction                            // 看板列表的爬取方向（-direction）
+	fromPage          int                                  // 起始頁碼（-from-page，0 表示依方向從最新頁或第 1 頁開始）
+	pageRange         PageRange                            // 絕對頁碼範圍（-page-start/-page-end），零值表示未指定
+	failedBoards      []error                              // 無法取得文章列表的看板（只由 producer 寫入）
+	producerErr       error                                // producer 無法產生任何文章的原因，Run 在 producer 結束後回傳（只由 producer 寫入）
+	fileURL           string                               // 檔案路徑（檔案模式時使用，FileURLStdin 表示標準輸入）
+	stdin             io.Reader                            // fileURL 為 FileURLStdin 時讀取的來源，nil 表示 os.Stdin
+	retryFailures     string                               // 重試模式讀取的失敗明細路徑（-retry-failures），空字串表示未啟用
+	outputDir         string                               // 輸出根目錄（-output，空字串表示目前工作目錄）
+	quiet             bool                                 // 不輸出逐篇文章、逐張圖片的例行訊息（-quiet）
+	statusInterval    time.Duration                        // 管線狀態的輸出間隔（-progress），0 表示未啟用
+	statusBar         *ui.StatusBar                        // 顯示管線狀態的終端機狀態列（nil 時改為定期輸出日誌）
+	config            *config.Config                       // 配置物件
+	robotsChecker     *robots.Checker                      // robots.txt 檢查器（respectRobots 關閉時為 nil）
+	limiter           interfaces.RateLimiter               // 全域請求速率限制器（未設定速率時為 nil）
+	alertMonitor      *errorRateMonitor                    // 錯誤率告警（alert.errorRate 為 0 時為 nil）
+	retry             *retryPolicies                       // 依 host 選擇的請求重試策略
+	metrics           interfaces.MetricsCollector          // 執行期指標收集器，結束時輸出摘要
+	dedupIndex        *dedup.Index                         // 跨執行的已下載圖片索引（dedup.enabled 關閉時為 nil）
+	dedupPool         *dedup.Pool                          // 以內容雜湊定址的共用圖片目錄（未設定 dedup.poolDir 時為 nil）
+	tracker           *progressTracker                     // 整體進度與 ETA 估算（Run 開始時建立）
+	workerIDs         chan int                             // article 下載模式的工人編號池（pool 模式為 nil）
+	copier            *bufferedCopier                      // 下載寫檔的緩衝複製（writeBufferBytes 為 0 時為 nil）
+	checkpoint        *checkpoint.Checkpoint               // 斷點續爬進度（未啟用 -resume/-checkpoint 時為 nil）
+	cache             interfaces.CacheManager              // 跨執行的文章快取（未啟用 -cache 時為 nil）
+	cacheTTL          time.Duration                        // 文章快取的有效期限
+	reloader          *configReloader                      // 配置檔熱更新（未啟用 -watch-config 時為 nil）
+	pool              *workerpool.Pool[types.DownloadTask] // pool 下載模式的工人池（article 模式為 nil）
+	dryRun            *dryRunPlan                          // 試跑模式的預計下載統計（未啟用 -dry-run 時為 nil）
+	headPrecheck      *headPrechecker                      // 下載前的 HEAD 大小預檢（headPrecheck.enabled 關閉時為 nil）
+	hostFilter        *hostFilter                          // 圖床允許／封鎖清單（crawler.hosts 皆為空時為 nil）
+	resources         *resourceGovernor                    // 依錯誤率與記憶體限制工人數上限（autoScale 未啟用時為 nil）
+	runStats          *runStats                            // 執行報告的看板與文章統計（output.report 關閉時為 nil）
+	failures          failureLog                           // 圖片下載失敗的明細，結束時依原因輸出摘要
+	startGoroutines   int                                  // Run 開始時的 goroutine 數，結束時比較以偵測洩漏
+	seed              uint64                               // 隨機延遲的種子（config seed，未指定時為建立時間）
+
+	// 依 host 的獨立速率限制器（rateLimit.hosts，未設定時為 nil），未設定的 host 使用 limiter
+	hostLimiters map[string]*ratelimit.TokenBucket
+
+	// 每個 host 同時進行的下載數上限（maxConcurrentPerHost 為 0 時為 nil）
+	hostSems *hostSemaphores
+
+	// 依各 host 的 429 回應調整的延遲（adaptive.enabled 關閉時為 nil）
+	adaptive *ratelimit.AdaptiveDelay
+
+	// 回應 405/501、不支援 HEAD 請求的 host，之後不再對它送出 HEAD
+	noHeadHosts sync.Map
+
+	// 已記錄過 robots.txt 檢查失敗的 host，每個 host 只警告一次
+	robotsWarned sync.Map
+
+	// 熱更新後的延遲範圍（未更新時為 nil，使用 config），執行中的 worker 並行讀取
+	delays atomic.Pointer[config.DelayConfig]
+
+	// 熱更新後的下載工人數範圍（未更新時為 nil，使用 config），由自動調整工人數的 goroutine 讀取
+	workers atomic.Pointer[config.WorkersConfig]
+
+	// 每篇文章尚未完成的工作數（圖片下載 + Markdown 產生），歸零時標記到 checkpoint
+	pendingMu sync.Mutex
+	pending   map[string]int
 
 	// 目錄名註冊表：不同文章的標題與推文數可能相同（如 Re: 系列），
 	// 撞名時加序號後綴避免互相覆蓋。contentParser 並行呼叫，需以 mutex 保護。
 	dirMu    sync.Mutex
 	usedDirs map[string]string // 目錄名 → 文章 URL
+
+	// 各 Markdown 工人已產生的文章，結束後產生看板總覽（output.index）
+	indexed indexCollector
+
+	// 重複標題偵測（duplicateTitles.enabled 時使用）
+	titleMu        sync.Mutex
+	titleDetectors map[string]*titledup.Detector // 看板目錄 → 該看板的偵測器
+
+	// 已分類圖片的新位置（classify.by 啟用時使用），產生 Markdown 時取出
+	classifiedMu sync.Mutex
+	classified   map[string]string // 原存檔路徑 → 相對於文章目錄的路徑
+
+	// 圖片打包器（pack: tar 時使用，否則為 nil）
+	packer *imagePacker
 }
 
 // emit 發送進度事件到 progress channel，channel 為 nil 時不執行任何操作。
 // 使用 non-blocking send 避免阻塞 crawler 的工作流程。
 func (c *Crawler) emit(evt types.ProgressEvent) {
+	c.tracker.observe(evt)
 	if c.progress == nil {
 		return
 	}
@@ -127,14 +342,15 @@ func (c *Crawler) emit(evt types.ProgressEvent) {
 
 // NewCrawler 建立一個新的 Crawler 實例.
 // 參數:
-//   - board: 看板名稱
-//   - pages: 要爬取的頁數
+//   - board: 看板名稱，多個看板以逗號分隔（如 "Beauty,Gossiping"）
+//   - pages: 每個看板要爬取的頁數
 //   - pushRate: 推文數門檻
 //   - fileURL: 包含文章 URL 的檔案路徑（為空時使用看板模式）
 //   - cfg: 配置物件
 //   - opts: 可選配置（WithProgress、WithLogger 等）
 func NewCrawler(board string, pages, pushRate int, fileURL string, cfg *config.Config, opts ...Option) (*Crawler, error) {
-	if err := validateBoard(board, fileURL); err != nil {
+	boards, err := validateBoards(board, fileURL)
+	if err != nil {
 		return nil, err
 	}
 
@@ -143,29 +359,94 @@ func NewCrawler(board string, pages, pushRate int, fileURL string, cfg *config.C
 		return nil, fmt.Errorf("建立 client 失敗: %w", err)
 	}
 
+	dedupIndex, err := openDedupIndex(cfg)
+	if err != nil {
+		return nil, err
+	}
+	dedupPool, err := openDedupPool(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	var mdTemplate *template.Template
+	if path := cfg.Crawler.Output.TemplatePath; path != "" {
+		if mdTemplate, err = markdown.LoadTemplate(path); err != nil {
+			return nil, err
+		}
+	}
+
+	mdgen := markdown.NewGenerator(
+		markdown.WithFormats(outputFormats(cfg.Crawler.Output.Format)...),
+		markdown.WithTemplate(mdTemplate),
+		markdown.WithLinkBase(cfg.Crawler.Output.LinkBase),
+		markdown.WithNumberedImages(cfg.Crawler.Output.NumberImages),
+		markdown.WithManifest(cfg.Crawler.Output.Manifest),
+		markdown.WithFrontMatter(cfg.Crawler.Output.FrontMatter),
+		markdown.WithInlinePushes(cfg.InlinePushes()),
+		markdown.WithPushesFile(cfg.PushesFile()),
+	)
+	parser := ptt.NewParser(
+		ptt.WithImageExtensions(cfg.Crawler.ImageExtensions),
+		ptt.WithStrictImgur(cfg.Crawler.StrictImgur),
+		ptt.WithSkipQuotedLinks(cfg.Crawler.LinkFilter.SkipQuotes),
+		ptt.WithSkipSignatureLinks(cfg.Crawler.LinkFilter.SkipSignature),
+		ptt.WithIgnoreLinks(cfg.Crawler.LinkFilter.IgnorePatterns),
+	)
+
 	c := &Crawler{
 		client:            client,
-		parser:            ptt.NewParser(),
-		markdownGenerator: markdown.NewGenerator(),
+		parser:            parser,
+		markdownGenerator: mdgen,
 		logger:            ui.NewStyledLogger(),
-		board:             board,
+		metrics:           metrics.NewCollector(),
+		dedupIndex:        dedupIndex,
+		dedupPool:         dedupPool,
+		copier:            newBufferedCopier(int(cfg.Crawler.WriteBufferBytes)),
+		boards:            boards,
 		pages:             pages,
 		pushRate:          pushRate,
 		fileURL:           fileURL,
 		config:            cfg,
 	}
 
+	if cfg.Crawler.RespectRobots {
+		c.robotsChecker = robots.NewChecker(requestClient{c})
+	}
+	c.limiter = newRateLimiter(cfg)
+	c.hostLimiters = newHostLimiters(cfg.Crawler.RateLimit)
+	c.hostSems = newHostSemaphores(cfg.Crawler.MaxConcurrentPerHost)
+	c.adaptive = newAdaptiveDelay(cfg.Crawler.Adaptive)
+	c.retry = newRetryPolicies(cfg.Crawler.Retry)
+	c.headPrecheck = newHeadPrechecker(cfg.Crawler.HeadPrecheck)
+	c.hostFilter = newHostFilter(cfg.Crawler.Hosts)
+	c.resources = newResourceGovernor(cfg)
+	c.runStats = newRunStats(cfg.Crawler.Output.Report)
+	c.seed = newSeed(cfg.Crawler.Seed)
+	c.filter = newArticleFilter(pushRate, cfg.Crawler.Filter)
+	c.packer = newImagePacker(cfg)
+
 	for _, opt := range opts {
 		opt(c)
 	}
 
 	// 在 opts 套用之後建立，確保使用最終的 logger（TUI 模式會注入 NoopLogger）
-	c.optimizer = performance.NewOptimizer(defaultMonitorInterval, c.logger)
+	c.optimizer = newOptimizer(cfg, c.logger)
+	c.alertMonitor = newAlertMonitor(cfg, c.logger)
 
 	return c, nil
 }
 
-// NewCrawlerWithDependencies 建立一個新的 Crawler 實例，支援依賴注入
+// newOptimizer 依 performance 配置建立效能監控器，performance.enabled 關閉時回傳 nil（不啟動背景 goroutine）
+func newOptimizer(cfg *config.Config, logger ui.Logger) *performance.Optimizer {
+	p := cfg.Crawler.Performance
+	if !p.Enabled {
+		return nil
+	}
+	return performance.NewOptimizer(p.MemoryThresholdMB, cfg.GetGCInterval(), logger)
+}
+
+// NewCrawlerWithDependencies 建立一個新的 Crawler 實例，支援依賴注入.
+// opts 可注入 Logger（WithLogger）等可選依賴，未指定時使用 PlainLogger。
 func NewCrawlerWithDependencies(
 	client interfaces.HTTPClient,
 	parser interfaces.Parser,
@@ -174,21 +455,119 @@ func NewCrawlerWithDependencies(
 	pages, pushRate int,
 	fileURL string,
 	cfg *config.Config,
+	opts ...Option,
 ) *Crawler {
-	logger := ui.NewPlainLogger()
-
-	return &Crawler{
+	c := &Crawler{
 		client:            client,
 		parser:            parser,
 		markdownGenerator: markdownGen,
-		optimizer:         performance.NewOptimizer(defaultMonitorInterval, logger),
-		logger:            logger,
-		board:             board,
+		logger:            ui.NewPlainLogger(),
+		metrics:           metrics.NewCollector(),
+		copier:            newBufferedCopier(int(cfg.Crawler.WriteBufferBytes)),
+		boards:            parseBoards(board),
 		pages:             pages,
 		pushRate:          pushRate,
 		fileURL:           fileURL,
 		config:            cfg,
+		direction:         DirectionNewest,
+	}
+	if cfg.Crawler.RespectRobots {
+		c.robotsChecker = robots.NewChecker(requestClient{c})
 	}
+	c.limiter = newRateLimiter(cfg)
+	c.hostLimiters = newHostLimiters(cfg.Crawler.RateLimit)
+	c.hostSems = newHostSemaphores(cfg.Crawler.MaxConcurrentPerHost)
+	c.adaptive = newAdaptiveDelay(cfg.Crawler.Adaptive)
+	c.retry = newRetryPolicies(cfg.Crawler.Retry)
+	c.headPrecheck = newHeadPrechecker(cfg.Crawler.HeadPrecheck)
+	c.hostFilter = newHostFilter(cfg.Crawler.Hosts)
+	c.resources = newResourceGovernor(cfg)
+	c.runStats = newRunStats(cfg.Crawler.Output.Report)
+	c.seed = newSeed(cfg.Crawler.Seed)
+	c.filter = newArticleFilter(pushRate, cfg.Crawler.Filter)
+	c.packer = newImagePacker(cfg)
+
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	c.optimizer = newOptimizer(cfg, c.logger)
+	c.alertMonitor = newAlertMonitor(cfg, c.logger)
+
+	idx, err := openDedupIndex(cfg)
+	if err != nil {
+		c.logger.Error("載入下載索引失敗，停用跨執行去重: %v", err)
+	}
+	c.dedupIndex = idx
+
+	pool, err := openDedupPool(cfg)
+	if err != nil {
+		c.logger.Error("開啟共用圖片目錄失敗，停用內容去重: %v", err)
+	}
+	c.dedupPool = pool
+	return c
+}
+
+// outputFormats 將 crawler.output.format 設定值對應為要輸出的檔案格式
+func outputFormats(format string) []markdown.Format {
+	switch format {
+	case config.OutputFormatHTML:
+		return []markdown.Format{markdown.FormatHTML}
+	case config.OutputFormatBoth:
+		return []markdown.Format{markdown.FormatMarkdown, markdown.FormatHTML}
+	default:
+		return []markdown.Format{markdown.FormatMarkdown}
+	}
+}
+
+// newRateLimiter 依配置建立全域速率限制器，requestsPerSecond 為 0 時回傳 nil（不限速）
+func newRateLimiter(cfg *config.Config) interfaces.RateLimiter {
+	rl := cfg.Crawler.RateLimit
+	if rl.RequestsPerSecond <= 0 {
+		return nil
+	}
+	return ratelimit.NewTokenBucket(rl.RequestsPerSecond, rl.Burst)
+}
+
+// doRequest 發送對外請求：先向目標 host 適用的速率限制器取得額度，再依目標 host 的重試策略交由 doWithRetry 處理 429 重試。
+// 所有對 PTT 與圖床的請求都應經過此函式，速率限制與錯誤率告警才能涵蓋全部 worker。
+// 取消（ctx 結束）造成的失敗不計入錯誤率。
+func (c *Crawler) doRequest(ctx context.Context, req *http.Request) (*http.Response, error) {
+	if limiter := c.limiterFor(req.URL.Hostname()); limiter != nil {
+		if err := limiter.Wait(ctx); err != nil {
+			return nil, err
+		}
+	}
+	c.logger.Debug("%s %s", req.Method, req.URL)
+	client := c.client
+	if c.adaptive != nil {
+		client = throttleObserver{HTTPClient: client, c: c}
+	}
+	start := time.Now()
+	resp, err := doWithRetry(ctx, client, req, c.retry.forHost(req.URL.Hostname()), c.logger)
+	if ctx.Err() == nil {
+		status := 0
+		if err == nil {
+			status = resp.StatusCode
+		}
+		if c.metrics != nil {
+			c.metrics.RecordRequest(status, time.Since(start), err)
+		}
+		c.resources.record(status, err)
+	}
+	if c.alertMonitor != nil && ctx.Err() == nil {
+		c.alertMonitor.Record(err != nil || resp.StatusCode >= http.StatusBadRequest)
+	}
+	return resp, err
+}
+
+// requestClient 以 doRequest 實作 interfaces.HTTPClient，
+// 讓 robots.Checker 等外部元件的請求同樣經過速率限制與重試策略
+type requestClient struct{ c *Crawler }
+
+// Do 經由 doRequest 送出請求
+func (rc requestClient) Do(req *http.Request) (*http.Response, error) {
+	return rc.c.doRequest(req.Context(), req)
 }
 
 // initializeChannels 初始化所有工人使用的 channels
@@ -202,18 +581,35 @@ func (c *Crawler) initializeChannels() *WorkerChannels {
 
 // startWorkers 啟動所有工人並返回 WaitGroup
 func (c *Crawler) startWorkers(ctx context.Context, channels *WorkerChannels) *Workers {
-	var parsersWg, downloadersWg, markdownWg sync.WaitGroup
-
-	// 啟動下載工人池
-	numWorkers := c.config.Crawler.Workers
-	downloadersWg.Add(numWorkers)
-	for i := 1; i <= numWorkers; i++ {
-		go c.downloadWorker(ctx, i, channels.DownloadTask, &downloadersWg)
+	var parsersWg, markdownWg, scalerWg sync.WaitGroup
+
+	// 啟動下載工人池；article 模式由解析器逐篇下載，只需準備工人編號池
+	workers := c.config.Crawler.Workers
+	if c.articleMode() {
+		// article 模式不自動調整工人數，以上限作為全域並行數
+		c.workerIDs = newWorkerIDPool(workers.Max)
+		c.logger.Info("以文章為單位批次下載（單篇上限 %d 張並行，全域上限 %d）",
+			c.config.Crawler.ArticleConcurrency, workers.Max)
+	} else {
+		// 先指定 c.pool 再啟動工人，熱更新 workers 時以 Resize 調整；以 min 個工人啟動
+		c.pool = c.newDownloadPool(channels.DownloadTask, workers.Min)
+		c.pool.Start(ctx)
+		if c.resources != nil {
+			c.logger.Info("自動調整下載工人數（%s），依佇列深度、請求錯誤率與記憶體用量增減", workers)
+		}
+		// 固定工人數且不熱更新時不需要監看佇列
+		if workers.Dynamic() || c.reloader != nil {
+			scalerWg.Add(1)
+			go c.autoscaleWorkers(ctx, c.pool, channels.DownloadTask, autoscaleInterval, &scalerWg)
+		}
 	}
 
-	// 啟動 Markdown 文件產生工人
-	markdownWg.Add(1)
-	go c.markdownWorker(ctx, channels.MarkdownTask, &markdownWg)
+	// 啟動 Markdown 文件產生工人；各篇文章的輸出互不相干，可並行產生
+	markdownWorkers := c.config.Crawler.MarkdownWorkers
+	markdownWg.Add(markdownWorkers)
+	for i := 1; i <= markdownWorkers; i++ {
+		go c.markdownWorker(ctx, i, channels.MarkdownTask, &markdownWg)
+	}
 
 	// 啟動內容解析器
 	parserCount := c.config.Crawler.ParserCount
@@ -223,18 +619,21 @@ func (c *Crawler) startWorkers(ctx context.Context, channels *WorkerChannels) *W
 	}
 
 	return &Workers{
-		Parsers:     &parsersWg,
-		Downloaders: &downloadersWg,
-		Markdown:    &markdownWg,
+		Parsers:  &parsersWg,
+		Markdown: &markdownWg,
+		Scaler:   &scalerWg,
 	}
 }
 
 // startProducer 根據模式啟動相應的生產者
-func (c *Crawler) startProducer(ctx context.Context, articleChan chan<- types.ArticleInfo) {
-	if c.fileURL != "" {
-		c.articleProducerFromFile(ctx, articleChan)
-	} else {
-		c.articleProducer(ctx, articleChan)
+func (c *Crawler) startProducer(ctx context.Context, channels *WorkerChannels) {
+	switch {
+	case c.retryFailures != "":
+		c.produceFromFailures(ctx, channels.ArticleInfo, channels.DownloadTask)
+	case c.fileURL != "":
+		c.produceFromFile(ctx, channels.ArticleInfo)
+	default:
+		c.articleProducer(ctx, channels.ArticleInfo)
 	}
 }
 
@@ -248,12 +647,28 @@ func (c *Crawler) waitAndCleanup(workers *Workers, channels *WorkerChannels) {
 	// 同步等待不會 deadlock：parsers 產出時 downloaders/markdown 已在並行消費，
 	// 且 dispatch 的 select 均有 ctx.Done() 分支。
 	workers.Parsers.Wait()
-	close(channels.DownloadTask)
+	if c.packer != nil {
+		// 中斷時批次下載未完成的文章仍留有開啟的封存檔，寫入結尾讓已下載的圖片可以解開
+		if err := c.packer.closeAll(); err != nil {
+			c.logger.Error("%v", err)
+		}
+	}
+	if c.pool != nil {
+		// 下載任務 channel 是工人池的佇列，由 Stop 關閉；工人處理完剩餘任務後結束
+		c.pool.Stop()
+	} else {
+		close(channels.DownloadTask)
+	}
 	close(channels.MarkdownTask)
 
 	// 等待所有下載和 Markdown 任務完成
-	workers.Downloaders.Wait()
+	if c.pool != nil {
+		c.pool.Wait()
+	}
+	workers.Scaler.Wait()
 	workers.Markdown.Wait()
+	// 全部 Markdown 工人結束後才產生看板總覽，避免遺漏其他工人仍在處理的文章
+	c.generateIndexes(c.indexed.take())
 }
 
 // logCompletion 記錄完成信息
@@ -266,11 +681,26 @@ func (c *Crawler) logCompletion(ctx context.Context, startTime time.Time) {
 		c.logger.Success("爬蟲結束，總耗時: %s", duration)
 	}
 
+	c.logMetrics()
+	c.logFailures()
+	if c.dryRun != nil {
+		c.logDryRunSummary()
+	}
+	c.writeReport(ctx, startTime, duration)
+
+	// 中斷時同樣寫入，下次以 -resume 從這裡繼續（試跑模式不寫入）
+	if c.checkpoint != nil && c.dryRun == nil {
+		c.saveCheckpoint()
+		c.logger.Info("checkpoint 已儲存: %s（已完成 %d 篇文章）", c.checkpoint.Path(), c.checkpoint.Len())
+	}
+	c.saveCache()
+
 	// 記錄最終記憶體狀態
 	if c.optimizer != nil {
 		finalStats := c.optimizer.GetMemoryStats()
 		c.logger.Info("最終記憶體狀態: %s", finalStats.String())
 	}
+	c.checkGoroutineLeak(c.startGoroutines)
 
 	c.emit(types.ProgressEvent{
 		Type:    types.EventCrawlerDone,
@@ -283,12 +713,16 @@ func (c *Crawler) logCompletion(ctx context.Context, startTime time.Time) {
 // 架構說明:
 // 1. articleProducer: 產生文章 URL 列表
 // 2. contentParser: 解析文章內容並提取圖片 URL（多個並行）
-// 3. downloadWorker: 下載圖片檔案（多個並行）
-// 4. markdownWorker: 生成 Markdown 檔案（單個）
+// 3. 下載工人池（workerpool.Pool）: 下載圖片檔案（多個並行）
+// 4. markdownWorker: 生成 Markdown 檔案（數量由 markdownWorkers 設定，預設 1 個）
 //
-// 支援優雅關閉，當 context 被取消時會停止所有 worker.
-func (c *Crawler) Run(ctx context.Context) {
+// 支援優雅關閉，當 context 被取消時會停止所有 worker（中斷不視為錯誤）.
+//
+// 完全無法產生文章時回傳錯誤：看板模式所有看板都無法取得最大頁數（或頁碼範圍超出），
+// 檔案模式網址檔無法開啟或讀取。部分文章或圖片失敗只記錄警告，仍回傳 nil.
+func (c *Crawler) Run(ctx context.Context) error {
 	startTime := time.Now()
+	c.startGoroutines = runtime.NumGoroutine()
 	c.logger.Info("爬蟲啟動...")
 
 	// 啟動效能監控
@@ -301,39 +735,118 @@ func (c *Crawler) Run(ctx context.Context) {
 		c.logger.Info("初始記憶體狀態: %s", initialStats.String())
 	}
 
+	// 啟動時先載入 PTT 的 robots.txt，後續查詢皆使用快取
+	if c.robotsChecker != nil {
+		c.robotsAllowed(ctx, constants.PttBaseURL+"/")
+	}
+
+	// 定期輸出整體進度與 ETA
+	c.tracker = newProgressTracker(time.Now)
+	// Run 返回前必須等它結束：TUI 模式在 Run 返回後會關閉 progress channel
+	stopReport, reportDone := make(chan struct{}), make(chan struct{})
+	go func() {
+		defer close(reportDone)
+		c.reportProgress(ctx, progressReportInterval, stopReport)
+	}()
+	defer func() {
+		close(stopReport)
+		<-reportDone
+	}()
+
+	if c.tracksCompletion() {
+		stopSave, saveDone := make(chan struct{}), make(chan struct{})
+		go func() {
+			defer close(saveDone)
+			c.saveCheckpointPeriodically(ctx, checkpointSaveInterval, stopSave)
+		}()
+		defer func() {
+			close(stopSave)
+			<-saveDone
+		}()
+	}
+
 	// 初始化 channels 和 workers
+	c.prepareReload()
 	channels := c.initializeChannels()
+	if c.optimizer != nil {
+		// 佇列在監控啟動後才建立，之後的記憶體監控一併輸出各佇列的填充程度
+		c.optimizer.SetPipelineStats(channels.queueDepths)
+	}
 	workers := c.startWorkers(ctx, channels)
+	stopStatus := c.startStatusReport(ctx, channels)
+
+	// 在 startWorkers 之後啟動，熱更新工人數時工人池已建立
+	if c.reloader != nil {
+		watchCtx, stopWatch := context.WithCancel(ctx)
+		watchDone := make(chan struct{})
+		go func() {
+			defer close(watchDone)
+			c.watchConfig(watchCtx)
+		}()
+		defer func() {
+			stopWatch()
+			<-watchDone
+		}()
+	}
 
 	// 非同步啟動生產者，避免 context 取消時阻塞在 channel 寫入造成 deadlock
 	producerDone := make(chan struct{})
 	go func() {
 		defer close(producerDone)
-		c.startProducer(ctx, channels.ArticleInfo)
+		c.startProducer(ctx, channels)
 	}()
 
 	// 等待完成和清理
 	c.waitAndCleanup(workers, channels)
+	c.saveDedupIndex()
+	c.writeDuplicateTitles()
 
 	// ctx 取消時 workers 會提前退出，此時 producer 可能仍在執行。
 	// 必須等它結束才能返回，否則 TUI 模式在 Run 返回後關閉 progress channel，
 	// producer 的 emit 會對已關閉的 channel 做 send 而 panic。
 	<-producerDone
+	stopStatus()
 
 	// 記錄完成信息和最終記憶體狀態
 	c.logCompletion(ctx, startTime)
+	return c.producerErr
+}
+
+// robotsAllowed 檢查 robots.txt 是否允許爬取該 URL，respectRobots 關閉時一律允許。
+// robots.txt 抓取失敗時視為允許，每個 host 只在第一次失敗時記錄警告（中斷造成的失敗不記錄）；被禁止時記錄警告。
+func (c *Crawler) robotsAllowed(ctx context.Context, rawURL string) bool {
+	if c.robotsChecker == nil {
+		return true
+	}
+	allowed, err := c.robotsChecker.Allowed(ctx, rawURL)
+	if err != nil && ctx.Err() == nil {
+		host := rawURL
+		if u, perr := url.Parse(rawURL); perr == nil {
+			host = u.Host
+		}
+		if _, warned := c.robotsWarned.LoadOrStore(host, struct{}{}); !warned {
+			c.logger.Warn("robots.txt 檢查失敗，%s 暫時視為允許: %v", host, err)
+		}
+	}
+	if !allowed {
+		c.logger.Warn("robots.txt 禁止爬取，已略過: %s", rawURL)
+	}
+	return allowed
 }
 
 // fetchMaxPage 從看板首頁取得最大頁數
-func (c *Crawler) fetchMaxPage(ctx context.Context) (int, error) {
-	pageURL := fmt.Sprintf("%s/bbs/%s/index.html", constants.PttBaseURL, c.board)
+func (c *Crawler) fetchMaxPage(ctx context.Context, board string) (int, error) {
+	pageURL := fmt.Sprintf("%s/bbs/%s/index.html", constants.PttBaseURL, board)
+	if !c.robotsAllowed(ctx, pageURL) {
+		return 0, fmt.Errorf("robots.txt 禁止爬取: %s", pageURL)
+	}
 
 	req, err := http.NewRequestWithContext(ctx, "GET", pageURL, nil)
 	if err != nil {
 		return 0, fmt.Errorf("建立請求失敗: %w", err)
 	}
 
-	resp, err := doWithRetry(ctx, c.client, req, c.logger)
+	resp, err := c.doRequest(ctx, req)
 	if err != nil {
 		return 0, fmt.Errorf("發送請求失敗: %w", err)
 	}
@@ -346,81 +859,130 @@ func (c *Crawler) fetchMaxPage(ctx context.Context) (int, error) {
 	return c.parser.ParseMaxPage(resp.Body)
 }
 
-// articleProducer 產生文章資訊到 channel
+// articleProducer 依序爬取每個看板的列表頁，產生文章資訊到 channel。
+// 所有看板共用同一個 channel；單一看板失敗時繼續下一個，context 取消時立即結束。
 func (c *Crawler) articleProducer(ctx context.Context, articleInfoChan chan<- types.ArticleInfo) {
 	defer close(articleInfoChan)
 
-	maxPage, err := c.fetchMaxPage(ctx)
-	if err != nil {
+	totalPages := c.pages * len(c.boards)
+	c.tracker.setTotal(unitPage, totalPages)
+	for i, board := range c.boards {
 		if ctx.Err() != nil {
-			c.logger.Warn("獲取最大頁數時被中斷: %v", ctx.Err())
+			c.logger.Warn("文章列表爬取被中斷")
 			return
 		}
-		c.logger.Error("獲取最大頁數失敗: %v", err)
-		return
+		if !c.produceBoard(ctx, board, i*c.pages, totalPages, articleInfoChan) {
+			return
+		}
+	}
+}
+
+// boardFailed 記錄無法爬取的看板；所有看板都無法爬取時 Run 回傳錯誤
+func (c *Crawler) boardFailed(board string, err error) {
+	c.failedBoards = append(c.failedBoards, fmt.Errorf("看板 %s: %w", board, err))
+	if len(c.failedBoards) == len(c.boards) {
+		c.producerErr = crawlererrors.NewNetworkError("所有看板皆無法取得文章列表", errors.Join(c.failedBoards...))
+	}
+}
+
+// produceBoard 依爬取方向爬取單一看板的 c.pages 頁（見 boardPages）並送出符合條件的文章。
+// pageOffset 為此看板之前已排定的頁數，用於計算跨看板的整體進度。
+// 回傳 false 表示被中斷，呼叫端應停止爬取其餘看板。
+func (c *Crawler) produceBoard(ctx context.Context, board string, pageOffset, totalPages int, articleInfoChan chan<- types.ArticleInfo) bool {
+	log := c.boardLogger(board)
+	maxPage, err := c.fetchMaxPage(ctx, board)
+	if err != nil {
+		if ctx.Err() != nil {
+			log.Warn("獲取最大頁數時被中斷: %v", ctx.Err())
+			return false
+		}
+		log.Error("看板 %s 獲取最大頁數失敗: %v", board, err)
+		c.tracker.addTotal(-c.pages)
+		c.boardFailed(board, err)
+		return true
 	}
 
-	c.logger.Info("看板 %s 最大頁數為: %d", c.board, maxPage)
+	log.Info("看板 %s 最大頁數為: %d", board, maxPage)
 
-	for i := 0; i < c.pages; i++ {
+	if err := c.pageRange.Check(maxPage); err != nil {
+		log.Error("看板 %s %v，略過此看板", board, err)
+		c.tracker.addTotal(-c.pages)
+		c.boardFailed(board, err)
+		return true
+	}
+	if c.fromPage > maxPage {
+		log.Warn("起始頁 %d 超過看板 %s 實際頁數 %d", c.fromPage, board, maxPage)
+	}
+	pages := c.boardPages(maxPage)
+	if len(pages) < c.pages {
+		// 已越過看板第一頁或最新頁，index0.html 等頁面不存在
+		log.Warn("要求頁數 %d 超過看板 %s 可爬的頁數（%s 方向剩 %d 頁），提前結束",
+			c.pages, board, c.direction, len(pages))
+		c.tracker.addTotal(-(c.pages - len(pages)))
+	}
+
+	for i, currentPage := range pages {
 		// 檢查 context 是否已取消
 		select {
 		case <-ctx.Done():
-			c.logger.Warn("文章列表爬取被中斷")
-			return
+			log.Warn("文章列表爬取被中斷")
+			return false
 		default:
 		}
 
-		currentPage := maxPage - i
-		if currentPage < 1 {
-			// 已越過看板第一頁，index0.html 等頁面不存在
-			c.logger.Warn("要求頁數 %d 超過看板實際頁數 %d，提前結束", c.pages, maxPage)
-			return
+		pageURL := fmt.Sprintf("%s/bbs/%s/index%d.html", constants.PttBaseURL, board, currentPage)
+		if !c.robotsAllowed(ctx, pageURL) {
+			continue
 		}
-		pageURL := fmt.Sprintf("%s/bbs/%s/index%d.html", constants.PttBaseURL, c.board, currentPage)
-		c.logger.Info("正在爬取看板列表: %s", pageURL)
+		c.routineLogger(log).Info("正在爬取看板列表: %s", pageURL)
 
 		req, err := http.NewRequestWithContext(ctx, "GET", pageURL, nil)
 		if err != nil {
-			c.logger.Error("建立請求失敗: %s, 錯誤: %v", pageURL, err)
+			log.Error("建立請求失敗: %s, 錯誤: %v", pageURL, err)
 			continue
 		}
 
-		resp, err := doWithRetry(ctx, c.client, req, c.logger)
+		resp, err := c.doRequest(ctx, req)
 		if err != nil {
 			if ctx.Err() != nil {
-				c.logger.Warn("列表頁爬取被中斷")
-				return
+				log.Warn("列表頁爬取被中斷")
+				return false
 			}
-			c.logger.Error("爬取列表頁失敗: %s, 錯誤: %v", pageURL, err)
+			log.Error("爬取列表頁失敗: %s, 錯誤: %v", pageURL, err)
 			continue
 		}
 
 		articles, err := c.parser.ParseArticles(resp.Body)
 		ioutil.CloseWithLog(resp.Body, "回應 Body")
 		if err != nil {
-			c.logger.Error("解析列表頁失敗: %s, 錯誤: %v", pageURL, err)
+			log.Error("解析列表頁失敗: %s, 錯誤: %v", pageURL, err)
+			c.recordError(metrics.ErrorParse)
 			continue
 		}
 
+		done := pageOffset + i + 1
 		c.emit(types.ProgressEvent{
 			Type:        types.EventPageParsed,
-			CurrentPage: i + 1,
-			TotalPages:  c.pages,
-			Message:     fmt.Sprintf("解析第 %d/%d 頁完成，共 %d 篇文章", i+1, c.pages, len(articles)),
+			CurrentPage: done,
+			TotalPages:  totalPages,
+			Message:     fmt.Sprintf("解析第 %d/%d 頁完成（%s），共 %d 篇文章", done, totalPages, board, len(articles)),
 		})
 
+		now := time.Now()
 		for _, article := range articles {
-			if article.PushRate >= c.pushRate {
+			article.Board = board
+			if c.filter.accept(article) && c.matchTitle(article.Title) && c.matchAuthor(article.Author) &&
+				c.matchDate(article.Date, now) && !c.skipCompleted(log, article) {
 				select {
 				case <-ctx.Done():
-					c.logger.Warn("文章列表發送被中斷")
-					return
+					log.Warn("文章列表發送被中斷")
+					return false
 				case articleInfoChan <- article:
 				}
 			}
 		}
 	}
+	return true
 }
 
 // contentParser 從文章資訊解析內容，並分派下載和 Markdown 任務
@@ -443,23 +1005,59 @@ func (c *Crawler) contentParser(ctx context.Context, wg *sync.WaitGroup, article
 
 // processArticle 處理單一文章的解析和任務分派
 func (c *Crawler) processArticle(ctx context.Context, article types.ArticleInfo, downloadTaskChan chan<- types.DownloadTask, markdownTaskChan chan<- types.MarkdownInfo) {
+	log := c.boardLogger(c.articleBoard(article))
+	if c.skipCached(log, article) {
+		c.tracker.addTotal(-1) // 不會發送 EventArticleParsed，自 ETA 總量扣除
+		return
+	}
 	logMsg := c.getLogMessage(article)
-	c.logger.Info("正在解析文章: %s", logMsg)
+	c.routineLogger(log).Info("正在解析文章: %s", logMsg)
 
-	if c.shouldStop(ctx, "內容解析器在延遲時被中斷") {
+	if c.shouldStop(ctx, article.URL, "內容解析器在延遲時被中斷") {
 		return
 	}
 
-	parsedTitle, imgURLs, err := c.fetchAndParseArticle(ctx, article)
+	parseStart := time.Now()
+	content, err := c.fetchAndParseArticle(ctx, article)
 	if err != nil {
 		return // 錯誤已在函數內記錄
 	}
+	parseElapsed := time.Since(parseStart)
 
 	// 同一張圖可能在原文與推文中重複出現，派發前先去重，
 	// 避免多個 worker 同時寫入同一檔案造成毀損
-	imgURLs = uniqueStrings(imgURLs)
+	imgURLs := uniqueStrings(content.ImageURLs)
 
-	finalTitle := c.determineFinalTitle(article, parsedTitle)
+	finalTitle := c.determineFinalTitle(article, content.Title)
+	// 檔案模式的文章資訊沒有作者，以文章頁解析結果為準
+	if content.Author != "" {
+		article.Author = content.Author
+	}
+	article.Pushes = content.Pushes
+	article.Comments = content.Comments
+	article.Body = content.Body
+	article.PostedAt = content.Date
+
+	// 看板模式已在 producer 依列表頁的標題與作者過濾；檔案模式要解析後才知道
+	if c.fileURL != "" {
+		if !c.matchTitle(finalTitle) {
+			log.Info("標題不符合過濾條件，已略過: %s", logMsg)
+			c.tracker.addTotal(-1) // 不會發送 EventArticleParsed，自 ETA 總量扣除
+			return
+		}
+		if !c.matchAuthor(content.Author) {
+			log.Info("作者 %q 不在過濾清單中，已略過: %s", content.Author, logMsg)
+			c.tracker.addTotal(-1)
+			return
+		}
+	}
+
+	// 相簿展開需要額外請求，放在過濾之後，被略過的文章不會多發請求
+	if c.config.Crawler.ExpandImgurAlbums && len(content.AlbumURLs) > 0 {
+		imgURLs = uniqueStrings(c.expandImgurAlbums(ctx, imgURLs, content.AlbumURLs))
+	}
+	// 在派發下載與 Markdown 任務之前過濾，輸出檔的圖片列表只包含實際下載的圖片
+	imgURLs = c.filterImageHosts(log, imgURLs)
 
 	c.emit(types.ProgressEvent{
 		Type:         types.EventArticleParsed,
@@ -467,9 +1065,12 @@ func (c *Crawler) processArticle(ctx context.Context, article types.ArticleInfo,
 		ImageCount:   len(imgURLs),
 		Message:      fmt.Sprintf("文章「%s」解析完成，發現 %d 張圖片", finalTitle, len(imgURLs)),
 	})
+	c.recordArticle(c.articleBoard(article), finalTitle, article.URL, len(imgURLs), parseElapsed)
 
 	if len(imgURLs) > 0 {
 		c.dispatchTasks(ctx, finalTitle, article, imgURLs, downloadTaskChan, markdownTaskChan)
+	} else {
+		c.markArticleDone(article.URL)
 	}
 }
 
@@ -481,10 +1082,9 @@ func (c *Crawler) getLogMessage(article types.ArticleInfo) string {
 	return article.URL
 }
 
-// shouldStop 檢查是否應該停止，並處理延遲
-func (c *Crawler) shouldStop(ctx context.Context, msg string) bool {
-	minDelay, maxDelay := c.config.GetDelayRange()
-	delay := randomDelay(minDelay, maxDelay)
+// shouldStop 在處理 key 對應的對象前延遲，延遲期間收到中斷時記錄 msg 並回傳 true
+func (c *Crawler) shouldStop(ctx context.Context, key, msg string) bool {
+	delay := c.delayFor(key)
 
 	timer := time.NewTimer(delay)
 	select {
@@ -497,44 +1097,98 @@ func (c *Crawler) shouldStop(ctx context.Context, msg string) bool {
 	}
 }
 
-// fetchAndParseArticle 獲取並解析文章內容
-func (c *Crawler) fetchAndParseArticle(ctx context.Context, article types.ArticleInfo) (string, []string, error) {
+// fetchAndParseArticle 獲取並解析文章內容。
+// 設定 articleTimeout 時以衍生自 ctx 的逾時 context 執行，逾時只回傳錯誤讓解析器繼續處理下一篇。
+func (c *Crawler) fetchAndParseArticle(ctx context.Context, article types.ArticleInfo) (types.ArticleContent, error) {
+	log := c.boardLogger(c.articleBoard(article))
+	parent := ctx
+	timeout := c.config.GetArticleTimeout()
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+	// timedOut 判斷錯誤是否來自本篇的逾時（而非整體被中斷），逾時時記錄警告
+	timedOut := func() bool {
+		if parent.Err() != nil || !errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			return false
+		}
+		log.Warn("文章超過 articleTimeout %v 仍未完成，已略過: %s", timeout, article.URL)
+		return true
+	}
+
+	if !c.robotsAllowed(ctx, article.URL) {
+		return types.ArticleContent{}, fmt.Errorf("robots.txt 禁止爬取: %s", article.URL)
+	}
+
 	req, err := http.NewRequestWithContext(ctx, "GET", article.URL, nil)
 	if err != nil {
-		c.logger.Error("建立文章請求失敗: %s, 錯誤: %v", article.URL, err)
-		return "", nil, err
+		log.Error("建立文章請求失敗: %s, 錯誤: %v", article.URL, err)
+		return types.ArticleContent{}, err
 	}
 
-	resp, err := doWithRetry(ctx, c.client, req, c.logger)
+	resp, err := c.doRequest(ctx, req)
 	if err != nil {
+		if timedOut() {
+			return types.ArticleContent{}, err
+		}
 		if ctx.Err() != nil {
-			c.logger.Warn("文章爬取被中斷")
-			return "", nil, err
+			log.Warn("文章爬取被中斷")
+			return types.ArticleContent{}, err
 		}
-		c.logger.Error("爬取文章頁失敗: %s, 錯誤: %v", article.URL, err)
-		return "", nil, err
+		log.Error("爬取文章頁失敗: %s, 錯誤: %v", article.URL, err)
+		return types.ArticleContent{}, err
 	}
 	defer ioutil.CloseWithLog(resp.Body, "回應 Body")
 
 	if resp.StatusCode != http.StatusOK {
 		err := fmt.Errorf("HTTP 狀態錯誤: %d", resp.StatusCode)
-		c.logger.Error("爬取文章頁失敗: %s, 錯誤: %v", article.URL, err)
-		return "", nil, err
+		log.Error("爬取文章頁失敗: %s, 錯誤: %v", article.URL, err)
+		return types.ArticleContent{}, err
+	}
+
+	// 保存推文時需解析兩次（內容與推文），先將文章頁讀入記憶體
+	var body io.Reader = resp.Body
+	var page []byte
+	if c.config.InlinePushes() || c.config.PushesFile() {
+		if page, err = io.ReadAll(resp.Body); err != nil {
+			if timedOut() {
+				return types.ArticleContent{}, err
+			}
+			log.Error("讀取文章頁失敗: %s, 錯誤: %v", article.URL, err)
+			return types.ArticleContent{}, err
+		}
+		body = bytes.NewReader(page)
 	}
 
-	parsedTitle, imgURLs, err := c.parser.ParseArticleContent(resp.Body)
+	content, err := c.parser.ParseArticleContent(body)
 	if err != nil {
-		c.logger.Error("解析文章頁失敗: %s, 錯誤: %v", article.URL, err)
-		return "", nil, err
+		// 逾時時讀取 Body 中斷，解析器只會看到讀取錯誤
+		if timedOut() {
+			return types.ArticleContent{}, err
+		}
+		log.Error("解析文章頁失敗: %s, 錯誤: %v", article.URL, err)
+		c.recordError(metrics.ErrorParse)
+		return types.ArticleContent{}, err
+	}
+	if page != nil {
+		// 推文解析失敗不影響圖片下載
+		if content.Comments, err = c.parser.ParseArticlePushes(bytes.NewReader(page)); err != nil {
+			log.Warn("解析推文失敗，略過推文: %s, 錯誤: %v", article.URL, err)
+		}
 	}
 
-	return parsedTitle, imgURLs, nil
+	if c.metrics != nil {
+		c.metrics.RecordArticleParsed()
+	}
+	return content, nil
 }
 
 // determineFinalTitle 決定最終使用的標題
 func (c *Crawler) determineFinalTitle(article types.ArticleInfo, parsedTitle string) string {
+	// 列表頁或 CSV/JSON 清單提供的標題優先，沒有時使用文章頁解析的標題
 	finalTitle := article.Title
-	if (c.fileURL != "" && parsedTitle != "") || (finalTitle == "" && parsedTitle != "") {
+	if finalTitle == "" {
 		finalTitle = parsedTitle
 	}
 	return finalTitle
@@ -543,15 +1197,43 @@ func (c *Crawler) determineFinalTitle(article types.ArticleInfo, parsedTitle str
 // dispatchTasks 分派下載和 Markdown 任務
 func (c *Crawler) dispatchTasks(ctx context.Context, finalTitle string, article types.ArticleInfo, imgURLs []string, downloadTaskChan chan<- types.DownloadTask, markdownTaskChan chan<- types.MarkdownInfo) {
 	dirName := fmt.Sprintf("%s_%d", cleanFileName(finalTitle), article.PushRate)
-	saveDir := filepath.Join(c.board, c.uniqueDirName(dirName, article.URL))
+	// 目錄以看板分隔，不同看板的同名文章不會互相覆蓋
+	board := c.articleBoard(article)
+	saveDir := c.uniqueDirName(filepath.Join(c.boardDir(board), dirName), article.URL)
+	c.recordTitle(board, finalTitle, article.URL, saveDir)
 
 	// 檔名一次算好（含碰撞序號後綴），與 markdown 端共用同一推導邏輯
 	fileNames := fileutil.ImageFileNames(imgURLs)
 
-	// 分派下載任務
+	tasks := make([]types.DownloadTask, len(imgURLs))
 	for i, imgURL := range imgURLs {
-		if c.dispatchDownloadTask(ctx, imgURL, filepath.Join(saveDir, fileNames[i]), downloadTaskChan) {
-			return // 被中斷
+		tasks[i] = types.DownloadTask{
+			ImageURL:   imgURL,
+			SavePath:   filepath.Join(saveDir, fileNames[i]),
+			Board:      board,
+			ArticleURL: article.URL,
+		}
+	}
+	c.trackArticle(article.URL, len(tasks))
+
+	// 先並行取得所有圖片大小，超出限制的圖片不必排入下載
+	if c.headPrecheck != nil {
+		tasks = c.precheckSizes(ctx, tasks)
+	}
+
+	if c.workerIDs != nil {
+		// article 模式：該篇圖片全部下載完成後才產生 Markdown
+		stopped := c.downloadArticleImages(ctx, tasks)
+		c.closeArchive(board, saveDir)
+		if stopped {
+			return
+		}
+	} else {
+		// 分派下載任務
+		for _, task := range tasks {
+			if c.dispatchDownloadTask(ctx, task, downloadTaskChan) {
+				return // 被中斷
+			}
 		}
 	}
 
@@ -559,6 +1241,23 @@ func (c *Crawler) dispatchTasks(ctx context.Context, finalTitle string, article
 	c.dispatchMarkdownTask(ctx, finalTitle, article, imgURLs, saveDir, markdownTaskChan)
 }
 
+// articleBoard 回傳文章所屬的看板目錄：看板模式使用列表頁所屬看板，
+// 檔案模式（未標記看板）沿用第一個指定的看板，皆未指定時存放於目前目錄。
+func (c *Crawler) articleBoard(article types.ArticleInfo) string {
+	if article.Board != "" {
+		return article.Board
+	}
+	if len(c.boards) > 0 {
+		return c.boards[0]
+	}
+	return ""
+}
+
+// boardDir 回傳看板在輸出根目錄下的目錄，Markdown 的 SaveDir 與下載的 SavePath 皆以此為基準
+func (c *Crawler) boardDir(board string) string {
+	return filepath.Join(c.outputDir, board)
+}
+
 // uniqueDirName 回傳未被其他文章佔用的目錄名。
 // 目錄名已被不同文章佔用時，加上 _2、_3… 序號後綴；
 // 同一篇文章（相同 URL）重複處理時回傳相同目錄名。
@@ -585,15 +1284,12 @@ func (c *Crawler) uniqueDirName(dirName, articleURL string) string {
 }
 
 // dispatchDownloadTask 分派單個下載任務
-func (c *Crawler) dispatchDownloadTask(ctx context.Context, imgURL, savePath string, downloadTaskChan chan<- types.DownloadTask) bool {
+func (c *Crawler) dispatchDownloadTask(ctx context.Context, task types.DownloadTask, downloadTaskChan chan<- types.DownloadTask) bool {
 	select {
 	case <-ctx.Done():
 		c.logger.Warn("分派下載任務時被中斷")
 		return true
-	case downloadTaskChan <- types.DownloadTask{
-		ImageURL: imgURL,
-		SavePath: savePath,
-	}:
+	case downloadTaskChan <- task:
 		return false
 	}
 }
@@ -606,39 +1302,101 @@ func (c *Crawler) dispatchMarkdownTask(ctx context.Context, finalTitle string, a
 	case markdownTaskChan <- types.MarkdownInfo{
 		Title:      finalTitle,
 		ArticleURL: article.URL,
+		Author:     article.Author,
+		Date:       article.PostedAt,
 		PushCount:  article.PushRate,
+		Pushes:     article.Pushes,
+		Comments:   article.Comments,
+		Body:       article.Body,
 		ImageURLs:  imgURLs,
+		ImageFiles: c.classifiedFiles(saveDir, imgURLs),
+		Archive:    c.archiveName(saveDir),
 		SaveDir:    saveDir,
+		SiteDir:    c.siteDir(saveDir),
 	}:
 	}
 }
 
+// siteDir 回傳文章目錄相對於輸出根目錄、以 / 分隔的路徑
+func (c *Crawler) siteDir(saveDir string) string {
+	rel, err := filepath.Rel(cmp.Or(c.outputDir, "."), saveDir)
+	if err != nil {
+		return filepath.ToSlash(saveDir)
+	}
+	return filepath.ToSlash(rel)
+}
+
 // markdownWorker 處理 Markdown 檔案的產生
-func (c *Crawler) markdownWorker(ctx context.Context, tasks <-chan types.MarkdownInfo, wg *sync.WaitGroup) {
+func (c *Crawler) markdownWorker(ctx context.Context, id int, tasks <-chan types.MarkdownInfo, wg *sync.WaitGroup) {
 	defer wg.Done()
-	c.logger.Info("Markdown 工人啟動")
+	c.routineLogger(c.logger).Info("Markdown 工人 #%d 啟動", id)
 
 	for {
 		select {
 		case <-ctx.Done():
-			c.logger.Warn("Markdown 工人收到中斷信號")
+			c.logger.Warn("Markdown 工人 #%d 收到中斷信號", id)
+			c.indexed.interrupt()
+			c.drainMarkdownTasks(tasks)
 			return
 		case task, ok := <-tasks:
 			if !ok {
-				c.logger.Success("Markdown 工人結束")
+				c.routineLogger(c.logger).Success("Markdown 工人 #%d 結束", id)
 				return
 			}
-			c.logger.Info("正在為文章「%s」產生 Markdown 檔案", task.Title)
-			if err := c.markdownGenerator.Generate(task); err != nil {
-				c.logger.Error("產生 Markdown 失敗: %v", err)
+			// 啟用 output.index 時累積已產生的文章，全部工人結束後寫入看板總覽
+			if c.handleMarkdownTask(task) && c.config.Crawler.Output.Index {
+				// 總覽用不到推文與內文，不必一直保留到結束
+				task.Comments = nil
+				task.Body = ""
+				c.indexed.add(task)
 			}
 		}
 	}
 }
 
-// cleanFileName 清理檔名中的非法字元
-func cleanFileName(name string) string {
-	return invalidChars.ReplaceAllString(name, "")
+// handleMarkdownTask 產生單篇文章的輸出檔（試跑模式只估算），成功時回傳 true
+func (c *Crawler) handleMarkdownTask(task types.MarkdownInfo) bool {
+	if c.dryRun != nil {
+		c.planMarkdown(task)
+	} else {
+		c.routineLogger(c.logger).Info("正在為文章「%s」產生 Markdown 檔案", task.Title)
+		if err := c.markdownGenerator.Generate(task); err != nil {
+			c.logger.Error("產生 Markdown 失敗: %v", err)
+			return false
+		}
+	}
+	c.articleTaskDone(task.ArticleURL)
+	return true
+}
+
+// drainMarkdownTasks 在中斷後繼續處理已排入 channel 的 Markdown 任務，
+// 直到 channel 關閉（解析器皆已結束）或超過 shutdownGrace 寬限期，減少已解析文章的輸出遺失。
+// 中斷時不產生看板總覽，與未處理完的文章一起留待續爬。
+func (c *Crawler) drainMarkdownTasks(tasks <-chan types.MarkdownInfo) {
+	grace := c.config.GetShutdownGrace()
+	if grace <= 0 {
+		return
+	}
+	timer := time.NewTimer(grace)
+	defer timer.Stop()
+
+	drained := 0
+	for {
+		select {
+		case <-timer.C:
+			c.logger.Warn("中斷寬限期 %v 已到，已處理 %d 個剩餘的 Markdown 任務，其餘放棄", grace, drained)
+			return
+		case task, ok := <-tasks:
+			if !ok {
+				if drained > 0 {
+					c.logger.Info("中斷後已處理 %d 個剩餘的 Markdown 任務", drained)
+				}
+				return
+			}
+			c.handleMarkdownTask(task)
+			drained++
+		}
+	}
 }
 
 // uniqueStrings 去除重複項目，保留首次出現的順序，回傳新的 slice
@@ -656,26 +1414,56 @@ func uniqueStrings(items []string) []string {
 }
 
 // fetchImage 下載圖片並檢查 HTTP 狀態碼，回傳回應或 nil（表示應跳過）
-func (c *Crawler) fetchImage(ctx context.Context, id int, imageURL string) *http.Response {
-	req, err := http.NewRequestWithContext(ctx, "GET", imageURL, nil)
-	if err != nil {
-		c.logger.Error("工人 #%d 建立請求失敗: %s, 錯誤: %v", id, imageURL, err)
+func (c *Crawler) fetchImage(ctx context.Context, id int, task types.DownloadTask) *http.Response {
+	log := c.boardLogger(task.Board)
+	imageURL := task.ImageURL
+	if !c.robotsAllowed(ctx, imageURL) {
 		return nil
 	}
 
-	resp, err := doWithRetry(ctx, c.client, req, c.logger)
-	if err != nil {
+	// 第一次使用 client 預設標頭，失敗時依序改用 acceptFallbacks 的 Accept 標頭再試
+	accepts := append([]string{""}, c.config.Crawler.AcceptFallbacks...)
+	for i, accept := range accepts {
+		req, err := http.NewRequestWithContext(ctx, "GET", imageURL, nil)
+		if err != nil {
+			log.Error("工人 #%d 建立請求失敗: %s, 錯誤: %v", id, imageURL, err)
+			return nil
+		}
+		if accept != "" {
+			req.Header.Set("Accept", accept)
+		}
+
+		resp, err := c.doRequest(ctx, req)
+		if err == nil && resp.StatusCode == http.StatusOK {
+			if accept != "" {
+				log.Info("工人 #%d 改用 Accept %q 後下載成功: %s", id, accept, imageURL)
+			}
+			return resp
+		}
+		if err == nil {
+			ioutil.CloseWithLog(resp.Body, fmt.Sprintf("工人 #%d 回應 Body", id))
+		}
+		// 中斷（Ctrl+C）造成的失敗不記入失敗明細與重跑清單
 		if ctx.Err() != nil {
-			c.logger.Warn("下載工人 #%d 下載被中斷", id)
-		} else {
-			c.logger.Error("工人 #%d 下載失敗 (GET): %s, 錯誤: %v", id, imageURL, err)
+			log.Warn("下載工人 #%d 下載被中斷", id)
+			return nil
 		}
-		return nil
-	}
 
-	if resp.StatusCode != http.StatusOK {
-		c.logger.Error("工人 #%d 下載失敗 (狀態碼 %d): %s", id, resp.StatusCode, imageURL)
-		ioutil.CloseWithLog(resp.Body, fmt.Sprintf("工人 #%d 回應 Body", id))
+		// 429 重試已用盡時換 Accept 也無濟於事，直接視為失敗
+		if i < len(accepts)-1 && !errors.Is(err, errRetryExhausted) {
+			log.Warn("工人 #%d 下載失敗（%s），改用 Accept %q 重試: %s",
+				id, fetchFailureReason(resp, err), accepts[i+1], imageURL)
+			continue
+		}
+
+		c.recordFailure(task, fetchFailure(resp, err))
+		if err != nil {
+			logDownload(log, slog.LevelError, eventDownloadFailed, id, imageURL, []slog.Attr{slog.Any(ui.FieldError, err)},
+				"工人 #%d 下載失敗 (GET): %s, 錯誤: %v", id, imageURL, err)
+			return nil
+		}
+		logDownload(log, slog.LevelError, eventDownloadFailed, id, imageURL, []slog.Attr{slog.Int(ui.FieldStatus, resp.StatusCode)},
+			"工人 #%d 下載失敗 (狀態碼 %d): %s", id, resp.StatusCode, imageURL)
 		c.emit(types.ProgressEvent{
 			Type:     types.EventDownloadFail,
 			WorkerID: id,
@@ -683,53 +1471,154 @@ func (c *Crawler) fetchImage(ctx context.Context, id int, imageURL string) *http
 		})
 		return nil
 	}
-	return resp
+	return nil
 }
 
 // saveToFile 將回應 Body 儲存至指定路徑。
-// 下載大小受 constants.MaxImageSizeBytes 限制，超限或中途失敗的半截檔會被刪除。
-func (c *Crawler) saveToFile(resp *http.Response, savePath string, id int) {
+// 下載大小受 maxImageBytes 限制（0 表示不限制）：Content-Length 已超限時直接略過，
+// 實際內容超限或中途失敗的半截檔會被刪除。
+// 啟用 validateImages 時，寫檔前先檢查內容是否為圖片，避免留下毀損或空白檔案。
+// 回傳圖片是否成功寫入。
+func (c *Crawler) saveToFile(resp *http.Response, task types.DownloadTask, id int) bool {
+	log := c.boardLogger(task.Board)
+	savePath := task.SavePath
 	defer ioutil.CloseWithLog(resp.Body, fmt.Sprintf("工人 #%d 回應 Body", id))
 
+	imageURL := responseURL(resp)
+	pathAttr := slog.String(ui.FieldPath, savePath)
+
+	maxBytes := int64(c.config.Crawler.MaxImageBytes)
+	if maxBytes > 0 && resp.ContentLength > maxBytes {
+		logDownload(log, slog.LevelError, eventDownloadFailed, id, imageURL,
+			[]slog.Attr{pathAttr, slog.Int64(ui.FieldBytes, resp.ContentLength)},
+			"工人 #%d 圖片 Content-Length %d 超過大小上限 %d bytes，已略過: %s",
+			id, resp.ContentLength, maxBytes, savePath)
+		c.recordError(metrics.ErrorOversize)
+		c.downloadFailed(task, crawlererrors.FailureOversize,
+			fmt.Sprintf("Content-Length %d 超過大小上限 %d bytes", resp.ContentLength, maxBytes), nil)
+		c.emit(types.ProgressEvent{
+			Type:     types.EventDownloadFail,
+			WorkerID: id,
+			Message:  fmt.Sprintf("超過大小上限: %s", savePath),
+		})
+		return false
+	}
+
+	var body io.Reader = resp.Body
+	if c.config.Crawler.ValidateImages {
+		br := bufio.NewReaderSize(resp.Body, sniffLen)
+		// Peek 在內容不足 sniffLen 時會回傳 io.EOF，此時仍以已讀到的部分判斷
+		head, err := br.Peek(sniffLen)
+		if err != nil && err != io.EOF {
+			logDownload(log, slog.LevelError, eventDownloadFailed, id, imageURL, []slog.Attr{pathAttr, slog.Any(ui.FieldError, err)},
+				"工人 #%d 讀取回應失敗: %s, 錯誤: %v", id, savePath, err)
+			c.downloadFailed(task, crawlererrors.ClassifyDownloadFailure(0, err), "讀取回應失敗", err)
+			c.emit(types.ProgressEvent{
+				Type:     types.EventDownloadFail,
+				WorkerID: id,
+				Message:  fmt.Sprintf("讀取失敗: %s", savePath),
+			})
+			return false
+		}
+		if !isImageContent(resp.Header.Get("Content-Type"), head) {
+			logDownload(log, slog.LevelWarn, eventDownloadFailed, id, imageURL,
+				[]slog.Attr{pathAttr, slog.String(ui.FieldContentType, resp.Header.Get("Content-Type"))},
+				"工人 #%d 回應內容不是圖片（Content-Type: %q），已略過: %s",
+				id, resp.Header.Get("Content-Type"), savePath)
+			c.recordError(metrics.ErrorInvalidImage)
+			c.downloadFailed(task, crawlererrors.FailureInvalidImage,
+				fmt.Sprintf("回應內容不是圖片（Content-Type: %q）", resp.Header.Get("Content-Type")), nil)
+			c.emit(types.ProgressEvent{
+				Type:     types.EventDownloadFail,
+				WorkerID: id,
+				Message:  fmt.Sprintf("非圖片內容: %s", savePath),
+			})
+			return false
+		}
+		body = br
+	}
+
+	if c.packer != nil {
+		return c.packImage(body, task, id, imageURL)
+	}
+
 	dir := filepath.Dir(savePath)
 	if err := os.MkdirAll(dir, constants.DirPermission); err != nil {
-		c.logger.Error("工人 #%d 建立目錄失敗: %s, 錯誤: %v", id, dir, err)
-		return
+		logDownload(log, slog.LevelError, eventDownloadFailed, id, imageURL, []slog.Attr{pathAttr, slog.Any(ui.FieldError, err)},
+			"工人 #%d 建立目錄失敗: %s, 錯誤: %v", id, dir, err)
+		c.recordError(metrics.ErrorWrite)
+		c.downloadFailed(task, crawlererrors.FailureWrite, "建立目錄失敗", err)
+		return false
 	}
 
 	file, err := os.Create(savePath)
 	if err != nil {
-		c.logger.Error("工人 #%d 建立檔案失敗: %s, 錯誤: %v", id, savePath, err)
-		return
+		logDownload(log, slog.LevelError, eventDownloadFailed, id, imageURL, []slog.Attr{pathAttr, slog.Any(ui.FieldError, err)},
+			"工人 #%d 建立檔案失敗: %s, 錯誤: %v", id, savePath, err)
+		c.recordError(metrics.ErrorWrite)
+		c.downloadFailed(task, crawlererrors.FailureWrite, "建立檔案失敗", err)
+		return false
 	}
 
 	// 多讀 1 byte 用於偵測回應是否超過大小上限
-	written, err := io.Copy(file, io.LimitReader(resp.Body, constants.MaxImageSizeBytes+1))
+	if maxBytes > 0 {
+		body = io.LimitReader(body, maxBytes+1)
+	}
+	written, err := c.copier.Copy(file, body)
 	ioutil.CloseWithLog(file, fmt.Sprintf("工人 #%d 檔案", id))
 
 	if err != nil {
-		c.logger.Error("工人 #%d 寫入檔案失敗: %s, 錯誤: %v", id, savePath, err)
-		c.removeIncompleteFile(savePath, id)
+		logDownload(log, slog.LevelError, eventDownloadFailed, id, imageURL, []slog.Attr{pathAttr, slog.Any(ui.FieldError, err)},
+			"工人 #%d 寫入檔案失敗: %s, 錯誤: %v", id, savePath, err)
+		c.recordError(metrics.ErrorWrite)
+		c.downloadFailed(task, crawlererrors.FailureWrite, "寫入檔案失敗", err)
+		removeIncompleteFile(log, savePath, id)
 		c.emit(types.ProgressEvent{
 			Type:     types.EventDownloadFail,
 			WorkerID: id,
 			Message:  fmt.Sprintf("寫入失敗: %s", savePath),
 		})
-		return
+		return false
 	}
 
-	if written > constants.MaxImageSizeBytes {
-		c.logger.Error("工人 #%d 圖片超過大小上限 %d bytes，已捨棄: %s", id, constants.MaxImageSizeBytes, savePath)
-		c.removeIncompleteFile(savePath, id)
-		c.emit(types.ProgressEvent{
-			Type:     types.EventDownloadFail,
-			WorkerID: id,
-			Message:  fmt.Sprintf("超過大小上限: %s", savePath),
-		})
-		return
+	if maxBytes > 0 && written > maxBytes {
+		removeIncompleteFile(log, savePath, id)
+		c.oversizeDiscarded(log, id, imageURL, task, written)
+		return false
+	}
+
+	if c.config.Crawler.StripExif {
+		stripExif(log, savePath, id)
 	}
 
-	c.logger.Success("工人 #%d 下載完成: %s", id, savePath)
+	c.downloadSaved(log, id, imageURL, savePath, written)
+	return true
+}
+
+// oversizeDiscarded 記錄讀取後才發現超過大小上限而捨棄的圖片
+func (c *Crawler) oversizeDiscarded(log ui.Logger, id int, imageURL string, task types.DownloadTask, written int64) {
+	savePath := task.SavePath
+	logDownload(log, slog.LevelError, eventDownloadFailed, id, imageURL,
+		[]slog.Attr{slog.String(ui.FieldPath, savePath), slog.Int64(ui.FieldBytes, written)},
+		"工人 #%d 圖片超過大小上限 %d bytes，已捨棄: %s", id, int64(c.config.Crawler.MaxImageBytes), savePath)
+	c.recordError(metrics.ErrorOversize)
+	c.downloadFailed(task, crawlererrors.FailureOversize,
+		fmt.Sprintf("圖片超過大小上限 %d bytes", int64(c.config.Crawler.MaxImageBytes)), nil)
+	c.emit(types.ProgressEvent{
+		Type:     types.EventDownloadFail,
+		WorkerID: id,
+		Message:  fmt.Sprintf("超過大小上限: %s", savePath),
+	})
+}
+
+// downloadSaved 記錄已成功存檔（或寫入封存檔）的圖片
+func (c *Crawler) downloadSaved(log ui.Logger, id int, imageURL, savePath string, written int64) {
+	if c.metrics != nil {
+		c.metrics.RecordImageDownloaded(written)
+	}
+	logDownload(c.routineLogger(log), ui.LevelSuccess, eventDownloadDone, id, imageURL,
+		[]slog.Attr{slog.String(ui.FieldPath, savePath), slog.Int64(ui.FieldBytes, written)},
+		"工人 #%d 下載完成: %s", id, savePath)
 	c.emit(types.ProgressEvent{
 		Type:     types.EventDownloadDone,
 		WorkerID: id,
@@ -737,69 +1626,197 @@ func (c *Crawler) saveToFile(resp *http.Response, savePath string, id int) {
 	})
 }
 
+// stripExif 重新編碼已下載的圖片以移除 EXIF 等 metadata。
+// 不支援的格式（如 GIF、WebP）保留原檔並記錄，其他失敗也不影響下載結果。
+func stripExif(log ui.Logger, savePath string, id int) {
+	err := imageutil.StripMetadata(savePath)
+	switch {
+	case err == nil:
+	case errors.Is(err, imageutil.ErrUnsupportedFormat):
+		log.Info("工人 #%d 圖片格式不支援移除 EXIF，保留原檔: %s", id, savePath)
+	default:
+		log.Warn("工人 #%d 移除 EXIF 失敗，保留原檔: %s, 錯誤: %v", id, savePath, err)
+	}
+}
+
 // removeIncompleteFile 刪除下載失敗或超限留下的半截檔
-func (c *Crawler) removeIncompleteFile(savePath string, id int) {
+func removeIncompleteFile(log ui.Logger, savePath string, id int) {
 	if err := os.Remove(savePath); err != nil {
-		c.logger.Error("工人 #%d 清除半截檔失敗: %s, 錯誤: %v", id, savePath, err)
+		log.Error("工人 #%d 清除半截檔失敗: %s, 錯誤: %v", id, savePath, err)
 	}
 }
 
-// downloadWorker 是 Worker Pool 中的一個工人 Goroutine
-func (c *Crawler) downloadWorker(ctx context.Context, id int, tasks <-chan types.DownloadTask, wg *sync.WaitGroup) {
-	defer wg.Done()
-	c.logger.Info("下載工人 #%d 啟動", id)
+// isImageContent 判斷回應內容是否為圖片。
+// 以檔頭 magic bytes 嗅探為主；嗅探結果無法辨識（如 AVIF）時，
+// 才採信伺服器回報的 image/* Content-Type。
+func isImageContent(contentType string, head []byte) bool {
+	sniffed := http.DetectContentType(head)
+	if strings.HasPrefix(sniffed, "image/") {
+		return true
+	}
+	return sniffed == "application/octet-stream" &&
+		strings.HasPrefix(strings.ToLower(strings.TrimSpace(contentType)), "image/")
+}
 
-	for {
-		select {
-		case <-ctx.Done():
-			c.logger.Warn("下載工人 #%d 收到中斷信號", id)
-			return
-		case task, ok := <-tasks:
-			if !ok {
-				c.logger.Success("下載工人 #%d 結束", id)
-				return
-			}
+// downloadOne 以工人 id 的身分下載單一圖片（含去重、隨機延遲、下載與存檔）。
+// 回傳 true 表示在延遲時被中斷，呼叫端應停止處理後續任務。
+func (c *Crawler) downloadOne(ctx context.Context, id int, task types.DownloadTask) (stopped bool) {
+	log := c.boardLogger(task.Board)
+	defer func() {
+		// 被中斷的下載不算完成，續爬時會重新處理該文章
+		if !stopped && ctx.Err() == nil {
+			c.articleTaskDone(task.ArticleURL)
+		}
+	}()
 
-			minDelay, maxDelay := c.config.GetDelayRange()
-			delay := randomDelay(minDelay, maxDelay)
-			c.logger.Info("工人 #%d 延遲 %v 後下載: %s", id, delay, task.ImageURL)
+	// 試跑模式只估算大小，不建立連結或寫入檔案
+	if c.dryRun != nil {
+		c.planDownload(ctx, id, task)
+		return false
+	}
 
-			timer := time.NewTimer(delay)
-			select {
-			case <-ctx.Done():
-				timer.Stop()
-				c.logger.Warn("下載工人 #%d 在延遲時被中斷", id)
-				return
-			case <-timer.C:
-			}
+	// 先前執行已下載過的圖片不需再發出請求，也不必延遲
+	if c.reuseDownloaded(id, task) {
+		c.classifyImage(id, task)
+		return false
+	}
 
-			c.emit(types.ProgressEvent{
-				Type:     types.EventDownloadStart,
-				WorkerID: id,
-				Message:  task.ImageURL,
-			})
+	delay := c.delayFor(task.ImageURL)
+	c.routineLogger(log).Debug("工人 #%d 延遲 %v 後下載: %s", id, delay, task.ImageURL)
 
-			if resp := c.fetchImage(ctx, id, task.ImageURL); resp != nil {
-				c.saveToFile(resp, task.SavePath, id)
-			}
-		}
+	timer := time.NewTimer(delay)
+	select {
+	case <-ctx.Done():
+		timer.Stop()
+		log.Warn("下載工人 #%d 在延遲時被中斷", id)
+		return true
+	case <-timer.C:
+	}
+
+	// 同一 host 的下載名額持有到寫檔完成，回應 Body 讀取期間連線仍在使用
+	release, ok := c.hostSems.acquire(ctx, hostOf(task.ImageURL))
+	if !ok {
+		log.Warn("下載工人 #%d 在等待 host 下載名額時被中斷", id)
+		return true
+	}
+	defer release()
+
+	logDownload(c.routineLogger(log), slog.LevelDebug, eventDownloadStart, id, task.ImageURL, nil,
+		"工人 #%d 開始下載: %s", id, task.ImageURL)
+	c.emit(types.ProgressEvent{
+		Type:     types.EventDownloadStart,
+		WorkerID: id,
+		Message:  task.ImageURL,
+	})
+
+	saved := false
+	if resp := c.fetchImage(ctx, id, task); resp != nil {
+		saved = c.saveToFile(resp, task, id)
+	}
+	// 被中斷的下載不計入報告
+	if ctx.Err() == nil {
+		c.recordDownload(task, saved)
 	}
+	if saved {
+		// 分類在存檔成功之後進行，後續的共用與索引記錄使用移動後的路徑
+		task.SavePath = c.classifyImage(id, task)
+		c.shareDownloaded(id, task)
+		c.recordDownloaded(id, task)
+	}
+	return false
 }
 
-// articleProducerFromFile 從檔案讀取 URL 並產生文章資訊
-func (c *Crawler) articleProducerFromFile(ctx context.Context, articleInfoChan chan<- types.ArticleInfo) {
-	defer close(articleInfoChan)
-	c.logger.Info("啟動檔案模式...")
+// produceFromFile 開啟檔案模式的 URL 來源並產生文章資訊：fileURL 為 FileURLStdin（"-"）時讀取標準輸入，
+// 否則開啟檔案；.csv/.json 檔案以 articleProducerFromList 讀取帶有標題、推文數的清單
+func (c *Crawler) produceFromFile(ctx context.Context, articleInfoChan chan<- types.ArticleInfo) {
+	if c.fileURL == FileURLStdin {
+		c.logger.Info("啟動檔案模式（從標準輸入讀取文章網址）...")
+		stdin := c.stdin
+		if stdin == nil {
+			stdin = os.Stdin
+		}
+		c.articleProducerFromFile(ctx, stdin, articleInfoChan)
+		return
+	}
 
+	c.logger.Info("啟動檔案模式...")
 	file, err := os.Open(c.fileURL)
 	if err != nil {
+		close(articleInfoChan)
 		c.logger.Error("開啟檔案失敗: %v", err)
+		c.producerErr = crawlererrors.NewFileError("開啟網址檔案失敗", err)
 		return
 	}
 	defer ioutil.CloseWithLog(file, "檔案")
+	if format := fileListFormat(c.fileURL); format != "" {
+		c.articleProducerFromList(ctx, file, format, articleInfoChan)
+		return
+	}
+	c.articleProducerFromFile(ctx, file, articleInfoChan)
+}
+
+// articleProducerFromList 解析 CSV/JSON 清單（見 parseArticleList）並送出其中的文章，結束時關閉 articleInfoChan。
+// 清單提供的標題與推文數直接作為文章資訊，未提供標題時沿用文章頁解析的標題。
+func (c *Crawler) articleProducerFromList(ctx context.Context, r io.Reader, format string, articleInfoChan chan<- types.ArticleInfo) {
+	defer close(articleInfoChan)
+
+	articles, err := parseArticleList(r, format, c.logger.Warn)
+	if err != nil {
+		c.logger.Error("讀取文章清單失敗: %v", err)
+		c.producerErr = crawlererrors.NewParseError("讀取文章清單失敗", err)
+		return
+	}
+	c.tracker.setTotal(unitArticle, len(articles))
+	for _, article := range articles {
+		if !c.sendFileArticle(ctx, article, articleInfoChan) {
+			return
+		}
+	}
+}
+
+// sendFileArticle 送出檔案模式的一篇文章，已完成（checkpoint/快取）的文章略過並自 ETA 總量扣除。
+// 回傳 false 表示 ctx 已取消，呼叫端應停止讀取。
+func (c *Crawler) sendFileArticle(ctx context.Context, article types.ArticleInfo, articleInfoChan chan<- types.ArticleInfo) bool {
+	if c.skipCompleted(c.logger, article) {
+		c.tracker.addTotal(-1)
+		return true
+	}
+	select {
+	case <-ctx.Done():
+		c.logger.Warn("檔案模式文章發送被中斷")
+		return false
+	case articleInfoChan <- article:
+		return true
+	}
+}
+
+// articleProducerFromFile 從 r 逐行讀取 URL 並產生文章資訊，結束時關閉 articleInfoChan。
+// r 可以 Seek（一般檔案）時先計算有效網址數作為 ETA 的總量再從頭讀取；
+// 否則（標準輸入、管線）邊讀邊累加總量，上游仍在輸出時即可開始爬取。
+func (c *Crawler) articleProducerFromFile(ctx context.Context, r io.Reader, articleInfoChan chan<- types.ArticleInfo) {
+	defer close(articleInfoChan)
+
+	seeker, seekable := r.(io.Seeker)
+	if seekable {
+		// 標準輸入為管線時 *os.File 雖實作 io.Seeker，實際 Seek 會失敗
+		_, err := seeker.Seek(0, io.SeekCurrent)
+		seekable = err == nil
+	}
+	if seekable {
+		c.tracker.setTotal(unitArticle, countArticleURLs(r))
+		if _, err := seeker.Seek(0, io.SeekStart); err != nil {
+			c.logger.Error("讀取檔案失敗: %v", err)
+			c.producerErr = crawlererrors.NewFileError("讀取網址檔案失敗", err)
+			return
+		}
+	} else {
+		c.tracker.setTotal(unitArticle, 0)
+	}
 
-	scanner := bufio.NewScanner(file)
+	scanner := bufio.NewScanner(r)
+	lineNo := 0
 	for scanner.Scan() {
+		lineNo++
 		// 檢查 context 是否已取消
 		select {
 		case <-ctx.Done():
@@ -809,21 +1826,25 @@ func (c *Crawler) articleProducerFromFile(ctx context.Context, articleInfoChan c
 		}
 
 		line := strings.TrimSpace(scanner.Text())
-		if strings.HasPrefix(line, constants.PttBaseURL+"/bbs/") {
-			// 檔案模式下，推文數為 0，因為我們需要下載所有指定的文章
-			select {
-			case <-ctx.Done():
-				c.logger.Warn("檔案模式文章發送被中斷")
-				return
-			case articleInfoChan <- types.ArticleInfo{
-				URL:      line,
-				PushRate: 0, // 預設值，因為我們不知道推文數
-			}:
-			}
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		articleURL, ok := normalizeArticleURL(line)
+		if !ok {
+			c.logger.Warn("第 %d 行不是有效的 PTT 文章網址，已略過: %s", lineNo, line)
+			continue
+		}
+		if !seekable {
+			c.tracker.addTotal(1)
+		}
+		// 純文字清單沒有推文數，以 0 計，檔案中指定的文章全部下載
+		if !c.sendFileArticle(ctx, types.ArticleInfo{URL: articleURL}, articleInfoChan) {
+			return
 		}
 	}
 
 	if err := scanner.Err(); err != nil {
 		c.logger.Error("讀取檔案時發生錯誤: %v", err)
+		c.producerErr = crawlererrors.NewFileError("讀取網址檔案時發生錯誤", err)
 	}
 }
@@ -4,9 +4,13 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"maps"
+	"math/rand/v2"
 	"net/http"
+	"slices"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -22,7 +26,7 @@ func TestNewCrawlerWithDependencies(t *testing.T) {
 
 	cfg := &config.Config{
 		Crawler: config.CrawlerConfig{
-			Workers:     2,
+			Workers:     config.FixedWorkers(2),
 			ParserCount: 1,
 			Channels: config.ChannelConfig{
 				ArticleInfo:  10,
@@ -53,8 +57,8 @@ func TestNewCrawlerWithDependencies(t *testing.T) {
 	if crawler.markdownGenerator != mockMarkdownGen {
 		t.Error("MarkdownGenerator should be set to mock generator")
 	}
-	if crawler.board != "testboard" {
-		t.Errorf("Board = %s, want testboard", crawler.board)
+	if len(crawler.boards) != 1 || crawler.boards[0] != "testboard" {
+		t.Errorf("Boards = %v, want [testboard]", crawler.boards)
 	}
 	if crawler.pages != 3 {
 		t.Errorf("Pages = %d, want 3", crawler.pages)
@@ -64,6 +68,34 @@ func TestNewCrawlerWithDependencies(t *testing.T) {
 	}
 }
 
+func TestNewCrawler_PerformanceConfig(t *testing.T) {
+	newWithDeps := func(cfg *config.Config) *Crawler {
+		return NewCrawlerWithDependencies(mocks.NewMockHTTPClient(), mocks.NewMockParser(), mocks.NewMockMarkdownGenerator(),
+			"test", 1, 0, "", cfg)
+	}
+	newDefault := func(cfg *config.Config) *Crawler {
+		c, err := NewCrawler("test", 1, 0, "", cfg)
+		if err != nil {
+			t.Fatalf("NewCrawler failed: %v", err)
+		}
+		return c
+	}
+
+	for name, build := range map[string]func(*config.Config) *Crawler{"NewCrawler": newDefault, "NewCrawlerWithDependencies": newWithDeps} {
+		t.Run(name, func(t *testing.T) {
+			cfg := config.DefaultConfig()
+			if c := build(cfg); c.optimizer == nil {
+				t.Error("performance.enabled 預設開啟，應建立效能監控器")
+			}
+
+			cfg.Crawler.Performance.Enabled = false
+			if c := build(cfg); c.optimizer != nil {
+				t.Error("performance.enabled 關閉時不應建立效能監控器")
+			}
+		})
+	}
+}
+
 func TestCrawler_InitializeChannels(t *testing.T) {
 	cfg := &config.Config{
 		Crawler: config.CrawlerConfig{
@@ -166,8 +198,11 @@ func TestCrawler_ContentParserWithMocks(t *testing.T) {
 	mockMarkdownGen := &mocks.MockMarkdownGenerator{}
 
 	// Set up mock parser
-	mockParser.ParseArticleContentFunc = func(_ io.Reader) (string, []string, error) {
-		return "Parsed Title", []string{"http://example.com/image1.jpg", "http://example.com/image2.jpg"}, nil
+	mockParser.ParseArticleContentFunc = func(_ io.Reader) (types.ArticleContent, error) {
+		return types.ArticleContent{
+			Title:     "Parsed Title",
+			ImageURLs: []string{"http://example.com/image1.jpg", "http://example.com/image2.jpg"},
+		}, nil
 	}
 
 	// Set up mock client
@@ -185,7 +220,7 @@ func TestCrawler_ContentParserWithMocks(t *testing.T) {
 
 	cfg := &config.Config{
 		Crawler: config.CrawlerConfig{
-			Workers:     2,
+			Workers:     config.FixedWorkers(2),
 			ParserCount: 1,
 			Delays: config.DelayConfig{
 				MinMs: 100,
@@ -295,7 +330,7 @@ func TestCrawler_MarkdownWorkerWithMocks(t *testing.T) {
 
 	// Start markdown worker
 	wg.Add(1)
-	go crawler.markdownWorker(ctx, markdownChan, &wg)
+	go crawler.markdownWorker(ctx, 1, markdownChan, &wg)
 
 	// Send test tasks
 	testInfo1 := types.MarkdownInfo{Title: "Article 1", ArticleURL: "http://example.com/1"}
@@ -318,6 +353,137 @@ func TestCrawler_MarkdownWorkerWithMocks(t *testing.T) {
 	}
 }
 
+// TestMarkdownWorker_DrainOnCancel 驗證中斷後仍在寬限期內處理已排入 channel 的任務，
+// 寬限期到期或設為 0 時不再等待
+func TestMarkdownWorker_DrainOnCancel(t *testing.T) {
+	tests := []struct {
+		name      string
+		grace     string
+		closeChan bool
+		wantDone  int
+	}{
+		{"寬限期內處理剩餘任務", "5s", true, 2},
+		{"寬限期到期即結束", "200ms", false, 2},
+		{"寬限期 0 不處理", "0", false, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var generated atomic.Int32
+			gen := &mocks.MockMarkdownGenerator{GenerateFunc: func(types.MarkdownInfo) error {
+				generated.Add(1)
+				return nil
+			}}
+			// worker 記錄「收到中斷信號」時通知測試，之後送入的任務必定由 drain 處理
+			interrupted := make(chan struct{})
+			var once sync.Once
+			logger := &mocks.MockLogger{WarnFunc: func(format string, _ ...any) {
+				if strings.Contains(format, "收到中斷信號") {
+					once.Do(func() { close(interrupted) })
+				}
+			}}
+			cfg := config.DefaultConfig()
+			cfg.Crawler.ShutdownGrace = tt.grace
+			c := NewCrawlerWithDependencies(mocks.NewMockHTTPClient(), mocks.NewMockParser(), gen,
+				"testboard", 1, 0, "", cfg, WithLogger(logger))
+
+			ctx, cancel := context.WithCancel(context.Background())
+			cancel()
+			ch := make(chan types.MarkdownInfo, 2)
+			var wg sync.WaitGroup
+			wg.Add(1)
+			go c.markdownWorker(ctx, 1, ch, &wg)
+
+			select {
+			case <-interrupted:
+			case <-time.After(2 * time.Second):
+				t.Fatal("markdownWorker 未進入中斷處理")
+			}
+			if tt.wantDone > 0 {
+				ch <- types.MarkdownInfo{Title: "A", ArticleURL: "u1"}
+				ch <- types.MarkdownInfo{Title: "B", ArticleURL: "u2"}
+			}
+			if tt.closeChan {
+				close(ch)
+			}
+
+			done := make(chan struct{})
+			go func() {
+				wg.Wait()
+				close(done)
+			}()
+			select {
+			case <-done:
+			case <-time.After(2 * time.Second):
+				t.Fatal("markdownWorker 未在寬限期後結束")
+			}
+			if got := int(generated.Load()); got != tt.wantDone {
+				t.Errorf("產生的 Markdown 數 = %d, want %d", got, tt.wantDone)
+			}
+		})
+	}
+}
+
+// TestStartWorkers_MultipleMarkdownWorkers 驗證 markdownWorkers > 1 時所有任務都會處理，
+// 且看板總覽包含每個工人產生的文章
+func TestStartWorkers_MultipleMarkdownWorkers(t *testing.T) {
+	const total = 50
+	var (
+		generated atomic.Int32
+		inFlight  atomic.Int32
+		maxFlight atomic.Int32
+		indexed   []string
+	)
+	gen := &mocks.MockMarkdownGenerator{
+		GenerateFunc: func(types.MarkdownInfo) error {
+			n := inFlight.Add(1)
+			for {
+				m := maxFlight.Load()
+				if n <= m || maxFlight.CompareAndSwap(m, n) {
+					break
+				}
+			}
+			time.Sleep(time.Millisecond)
+			inFlight.Add(-1)
+			generated.Add(1)
+			return nil
+		},
+		GenerateIndexFunc: func(_ string, articles []types.MarkdownInfo) error {
+			for _, a := range articles {
+				indexed = append(indexed, a.ArticleURL)
+			}
+			return nil
+		},
+	}
+	cfg := config.DefaultConfig()
+	cfg.Crawler.MarkdownWorkers = 4
+	cfg.Crawler.Output.Index = true
+	c := NewCrawlerWithDependencies(mocks.NewMockHTTPClient(), mocks.NewMockParser(), gen,
+		"testboard", 1, 0, "", cfg, WithLogger(&mocks.MockLogger{}))
+
+	ctx := context.Background()
+	channels := c.initializeChannels()
+	w := c.startWorkers(ctx, channels)
+	for i := range total {
+		channels.MarkdownTask <- types.MarkdownInfo{
+			ArticleURL: fmt.Sprintf("https://www.ptt.cc/bbs/testboard/M.%d.A.html", i),
+			SaveDir:    fmt.Sprintf("testboard/a_%d", i),
+		}
+	}
+	close(channels.ArticleInfo)
+	c.waitAndCleanup(w, channels)
+
+	if got := int(generated.Load()); got != total {
+		t.Errorf("產生的 Markdown 數 = %d, want %d", got, total)
+	}
+	if len(indexed) != total {
+		t.Errorf("總覽文章數 = %d, want %d", len(indexed), total)
+	}
+	if maxFlight.Load() < 2 {
+		t.Errorf("最多同時產生 %d 篇，預期多個 Markdown 工人並行", maxFlight.Load())
+	}
+}
+
 func TestCrawler_ErrorHandling(t *testing.T) {
 	mockClient := &mocks.MockHTTPClient{}
 	mockParser := &mocks.MockParser{}
@@ -401,12 +567,20 @@ func TestRandomDelay(t *testing.T) {
 			wantMin: 0,
 			wantMax: 0,
 		},
+		{
+			name:    "range below one millisecond returns min",
+			min:     100 * time.Millisecond,
+			max:     100*time.Millisecond + 500*time.Microsecond,
+			wantMin: 100 * time.Millisecond,
+			wantMax: 100 * time.Millisecond,
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
+			r := rand.New(rand.NewPCG(1, 2))
 			for range 20 {
-				got := randomDelay(tt.min, tt.max)
+				got := randomDelay(r, tt.min, tt.max)
 				if got < tt.wantMin || got > tt.wantMax {
 					t.Errorf("randomDelay(%v, %v) = %v, want between %v and %v",
 						tt.min, tt.max, got, tt.wantMin, tt.wantMax)
@@ -415,3 +589,50 @@ func TestRandomDelay(t *testing.T) {
 		})
 	}
 }
+
+// TestDelayFor_FixedDelay 驗證配置的延遲下限等於上限（含皆為 0）時，解析與下載前的延遲固定為下限，不會 panic
+func TestDelayFor_FixedDelay(t *testing.T) {
+	for _, ms := range []int{0, 250} {
+		cfg := config.DefaultConfig()
+		cfg.Crawler.Delays = config.DelayConfig{MinMs: ms, MaxMs: ms}
+		c := NewCrawlerWithDependencies(mocks.NewMockHTTPClient(), mocks.NewMockParser(), mocks.NewMockMarkdownGenerator(), "test", 1, 0, "", cfg)
+		want := time.Duration(ms) * time.Millisecond
+		for i := range 5 {
+			if got := c.delayFor(fmt.Sprintf("https://i.imgur.com/%d.jpg", i)); got != want {
+				t.Errorf("delays %dms: delayFor = %v, want %v", ms, got, want)
+			}
+		}
+	}
+}
+
+// TestDelayFor_Seed 驗證相同 seed 下每個對象的延遲固定，與處理順序無關；不同 seed 產生不同的延遲序列。
+func TestDelayFor_Seed(t *testing.T) {
+	newSeeded := func(seed int64) *Crawler {
+		cfg := config.DefaultConfig()
+		cfg.Crawler.Seed = seed
+		cfg.Crawler.Delays = config.DelayConfig{MinMs: 0, MaxMs: 100000}
+		return NewCrawlerWithDependencies(mocks.NewMockHTTPClient(), mocks.NewMockParser(), mocks.NewMockMarkdownGenerator(), "test", 1, 0, "", cfg)
+	}
+	keys := make([]string, 10)
+	for i := range keys {
+		keys[i] = fmt.Sprintf("https://i.imgur.com/%d.jpg", i)
+	}
+	delays := func(c *Crawler, keys []string) map[string]time.Duration {
+		got := make(map[string]time.Duration, len(keys))
+		for _, key := range keys {
+			got[key] = c.delayFor(key)
+		}
+		return got
+	}
+
+	first := delays(newSeeded(42), keys)
+	// 以相反順序取得，模擬不同的 worker 排程
+	reversed := slices.Clone(keys)
+	slices.Reverse(reversed)
+	if second := delays(newSeeded(42), reversed); !maps.Equal(first, second) {
+		t.Errorf("相同 seed 的延遲應一致:\n%v\n%v", first, second)
+	}
+	if other := delays(newSeeded(43), keys); maps.Equal(first, other) {
+		t.Error("不同 seed 應產生不同的延遲")
+	}
+}
@@ -3,8 +3,10 @@ package crawler
 import (
 	"context"
 	"errors"
+	"fmt"
 	"io"
 	"net/http"
+	"net/http/httptest"
 	"os"
 	"path/filepath"
 	"regexp"
@@ -12,9 +14,11 @@ import (
 	"testing"
 	"testing/iotest"
 	"time"
+	"unicode/utf8"
 
 	"github.com/twtrubiks/ptt-spider-go/config"
 	"github.com/twtrubiks/ptt-spider-go/mocks"
+	"github.com/twtrubiks/ptt-spider-go/ptt"
 	"github.com/twtrubiks/ptt-spider-go/types"
 )
 
@@ -91,8 +95,8 @@ func TestNewCrawler(t *testing.T) {
 					t.Error("NewCrawler() returned nil crawler")
 					return
 				}
-				if crawler.board != tt.board {
-					t.Errorf("Expected board %s, got %s", tt.board, crawler.board)
+				if got := strings.Join(crawler.boards, ","); got != tt.board {
+					t.Errorf("Expected board %s, got %s", tt.board, got)
 				}
 				if crawler.pages != tt.pages {
 					t.Errorf("Expected pages %d, got %d", tt.pages, crawler.pages)
@@ -130,14 +134,40 @@ func TestCleanFileName(t *testing.T) {
 		{"", ""},
 		{"///", ""},
 		{"file with spaces", "file with spaces"}, // spaces should be preserved
+		{"tab\tand\nnewline\x00", "tabandnewline"}, // 控制字元
+		// 結尾的句點與空白會被 Windows 自動去除
+		{"[問卦] 有沒有...", "[問卦] 有沒有"},
+		{"標題 . ", "標題"},
+		{"...", ""},
+		// Windows 保留名稱（不分大小寫，含副檔名）
+		{"CON", "CON_"},
+		{"con.txt", "con_.txt"},
+		{"Aux", "Aux_"},
+		{"COM1", "COM1_"},
+		{"lpt9.tar.gz", "lpt9_.tar.gz"},
+		{"NUL .md", "NUL _.md"},
+		{"CONSOLE", "CONSOLE"},
+		{"[正妹] CON", "[正妹] CON"},
+		// 超過長度上限時截斷，不切斷中文字並保留副檔名
+		{strings.Repeat("正", 100), strings.Repeat("正", 66)},
+		{strings.Repeat("a", 300) + ".jpg", strings.Repeat("a", 196) + ".jpg"},
+		{strings.Repeat("正", 100) + "...好看", strings.Repeat("正", 66)},
+		{strings.Repeat("a", 199) + " 正妹", strings.Repeat("a", 199)}, // 截斷後露出的結尾空白同樣去除
 	}
 
 	for _, tt := range tests {
-		t.Run(tt.input, func(t *testing.T) {
+		name := tt.input
+		if len(name) > 40 {
+			name = fmt.Sprintf("%.40s_%dbytes", name, len(name))
+		}
+		t.Run(name, func(t *testing.T) {
 			result := cleanFileName(tt.input)
 			if result != tt.expected {
 				t.Errorf("cleanFileName(%q) = %q, want %q", tt.input, result, tt.expected)
 			}
+			if len(result) > maxFileNameBytes || !utf8.ValidString(result) {
+				t.Errorf("cleanFileName(%q) = %q 超過 %d bytes 或不是合法的 UTF-8", tt.input, result, maxFileNameBytes)
+			}
 		})
 	}
 }
@@ -185,7 +215,7 @@ func TestCrawlerWithContext(t *testing.T) {
 	cfg := config.DefaultConfig()
 
 	// Create a minimal config for faster testing
-	cfg.Crawler.Workers = 1
+	cfg.Crawler.Workers = config.FixedWorkers(1)
 	cfg.Crawler.ParserCount = 1
 	cfg.Crawler.Delays.MinMs = 100
 	cfg.Crawler.Delays.MaxMs = 200
@@ -212,7 +242,7 @@ func TestCrawlerWithContext(t *testing.T) {
 
 func TestCrawlerFileMode(t *testing.T) {
 	cfg := config.DefaultConfig()
-	cfg.Crawler.Workers = 1
+	cfg.Crawler.Workers = config.FixedWorkers(1)
 	cfg.Crawler.ParserCount = 1
 
 	// Create a temporary file with test URLs
@@ -256,7 +286,7 @@ func TestCrawlerConfigValidation(t *testing.T) {
 	// Test that crawler respects configuration values
 	cfg := &config.Config{
 		Crawler: config.CrawlerConfig{
-			Workers:     5,
+			Workers:     config.FixedWorkers(5),
 			ParserCount: 3,
 			Channels: config.ChannelConfig{
 				ArticleInfo:  50,
@@ -284,8 +314,8 @@ func TestCrawlerConfigValidation(t *testing.T) {
 	}
 
 	// Verify configuration is properly set
-	if crawler.config.Crawler.Workers != 5 {
-		t.Errorf("Expected workers 5, got %d", crawler.config.Crawler.Workers)
+	if crawler.config.Crawler.Workers != config.FixedWorkers(5) {
+		t.Errorf("Expected workers 5, got %v", crawler.config.Crawler.Workers)
 	}
 	if crawler.config.Crawler.ParserCount != 3 {
 		t.Errorf("Expected parserCount 3, got %d", crawler.config.Crawler.ParserCount)
@@ -307,7 +337,7 @@ func TestCrawlerConfigValidation(t *testing.T) {
 func TestCrawlerErrorHandling(t *testing.T) {
 	// Test crawler with empty file path (board mode)
 	cfg := config.DefaultConfig()
-	cfg.Crawler.Workers = 1
+	cfg.Crawler.Workers = config.FixedWorkers(1)
 	cfg.Crawler.ParserCount = 1
 
 	// Test board mode with short timeout - should handle network errors gracefully
@@ -335,7 +365,7 @@ func TestCrawlerChannelBuffers(t *testing.T) {
 	// Test that channels are created with correct buffer sizes
 	cfg := &config.Config{
 		Crawler: config.CrawlerConfig{
-			Workers:     2,
+			Workers:     config.FixedWorkers(2),
 			ParserCount: 2,
 			Channels: config.ChannelConfig{
 				ArticleInfo:  10,
@@ -374,6 +404,9 @@ func TestCrawlerChannelBuffers(t *testing.T) {
 	}
 }
 
+// pngHeader 是 PNG 檔頭 magic bytes，讓測試內容通過圖片內容檢查
+const pngHeader = "\x89PNG\r\n\x1a\n"
+
 // endlessReader 產生無限長度的資料流，用於模擬超大回應
 type endlessReader struct{}
 
@@ -396,10 +429,10 @@ func TestSaveToFile_ExceedsSizeLimit(t *testing.T) {
 
 	resp := &http.Response{
 		StatusCode: http.StatusOK,
-		Body:       io.NopCloser(endlessReader{}),
+		Body:       io.NopCloser(io.MultiReader(strings.NewReader(pngHeader), endlessReader{})),
 	}
 
-	c.saveToFile(resp, savePath, 1)
+	c.saveToFile(resp, types.DownloadTask{SavePath: savePath}, 1)
 
 	if _, err := os.Stat(savePath); !os.IsNotExist(err) {
 		t.Errorf("超過大小上限的檔案應被刪除，但仍存在: %s", savePath)
@@ -419,7 +452,7 @@ func TestSaveToFile_PartialDownloadRemoved(t *testing.T) {
 		)),
 	}
 
-	c.saveToFile(resp, savePath, 1)
+	c.saveToFile(resp, types.DownloadTask{SavePath: savePath}, 1)
 
 	if _, err := os.Stat(savePath); !os.IsNotExist(err) {
 		t.Errorf("下載中途失敗的半截檔應被刪除，但仍存在: %s", savePath)
@@ -431,13 +464,13 @@ func TestSaveToFile_Success(t *testing.T) {
 	c := newTestCrawlerForSave(t)
 	savePath := filepath.Join(t.TempDir(), "ok.jpg")
 
-	content := "fake image bytes"
+	content := pngHeader + "fake image bytes"
 	resp := &http.Response{
 		StatusCode: http.StatusOK,
 		Body:       io.NopCloser(strings.NewReader(content)),
 	}
 
-	c.saveToFile(resp, savePath, 1)
+	c.saveToFile(resp, types.DownloadTask{SavePath: savePath}, 1)
 
 	data, err := os.ReadFile(savePath)
 	if err != nil {
@@ -448,6 +481,70 @@ func TestSaveToFile_Success(t *testing.T) {
 	}
 }
 
+// TestSaveToFile_RejectsNonImage 驗證圖床回傳 HTML（如「圖片已移除」頁面）時不會寫檔，
+// 也不會留下空目錄。
+func TestSaveToFile_RejectsNonImage(t *testing.T) {
+	c := newTestCrawlerForSave(t)
+	saveDir := filepath.Join(t.TempDir(), "article")
+	savePath := filepath.Join(saveDir, "removed.jpg")
+
+	resp := &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     http.Header{"Content-Type": []string{"text/html; charset=utf-8"}},
+		Body:       io.NopCloser(strings.NewReader("<html><body>removed</body></html>")),
+	}
+
+	c.saveToFile(resp, types.DownloadTask{SavePath: savePath}, 1)
+
+	if _, err := os.Stat(saveDir); !os.IsNotExist(err) {
+		t.Errorf("非圖片內容不應建立目錄或檔案，但 %s 存在", saveDir)
+	}
+}
+
+// TestSaveToFile_ValidationDisabled 驗證關閉 validateImages 時維持原本行為，照樣寫檔。
+func TestSaveToFile_ValidationDisabled(t *testing.T) {
+	c := newTestCrawlerForSave(t)
+	c.config.Crawler.ValidateImages = false
+	savePath := filepath.Join(t.TempDir(), "any.jpg")
+
+	resp := &http.Response{
+		StatusCode: http.StatusOK,
+		Body:       io.NopCloser(strings.NewReader("<html></html>")),
+	}
+
+	c.saveToFile(resp, types.DownloadTask{SavePath: savePath}, 1)
+
+	if _, err := os.Stat(savePath); err != nil {
+		t.Errorf("關閉驗證時應照常寫檔: %v", err)
+	}
+}
+
+func TestIsImageContent(t *testing.T) {
+	tests := []struct {
+		name        string
+		contentType string
+		head        string
+		want        bool
+	}{
+		{"PNG magic bytes", "", pngHeader, true},
+		{"JPEG magic bytes", "image/jpeg", "\xff\xd8\xff\xe0", true},
+		{"GIF magic bytes 但 header 錯誤", "text/plain", "GIF89a", true},
+		{"HTML 頁面", "text/html", "<!DOCTYPE html><html>", false},
+		{"HTML 頁面謊稱圖片", "image/jpeg", "<html><body></body></html>", false},
+		{"無法嗅探時採信 image/* header", "image/avif", "\x00\x00\x00\x1cftypavif", true},
+		{"無法嗅探且 header 非圖片", "application/octet-stream", "\x00\x01\x02", false},
+		{"空內容", "", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isImageContent(tt.contentType, []byte(tt.head)); got != tt.want {
+				t.Errorf("isImageContent(%q, %q) = %v, want %v", tt.contentType, tt.head, got, tt.want)
+			}
+		})
+	}
+}
+
 // TestArticleProducer_StopsAtFirstPage 驗證 pages 大於看板實際頁數時，
 // 不會請求 index0.html、index-1.html 等不存在的頁面。
 func TestArticleProducer_StopsAtFirstPage(t *testing.T) {
@@ -504,7 +601,7 @@ func TestFetchAndParseArticle_Non200(t *testing.T) {
 		"test", 1, 0, "", config.DefaultConfig(),
 	)
 
-	_, _, err := c.fetchAndParseArticle(context.Background(), types.ArticleInfo{
+	_, err := c.fetchAndParseArticle(context.Background(), types.ArticleInfo{
 		URL: "https://www.ptt.cc/bbs/test/M.123.A.html",
 	})
 	if err == nil {
@@ -522,6 +619,8 @@ func TestArticleProducerFromFile_RequiresURLPrefix(t *testing.T) {
 		"參考 https://www.ptt.cc/bbs/Beauty/M.333.A.html 這篇",
 		"https://evil.example.com/?u=https://www.ptt.cc/bbs/x",
 		"",
+		"# 註解行",
+		`"www.ptt.cc/bbs/Beauty/M.444.A.html"`,
 	}, "\n")
 	if err := os.WriteFile(tmpFile, []byte(content), 0644); err != nil {
 		t.Fatalf("建立測試檔失敗: %v", err)
@@ -533,7 +632,7 @@ func TestArticleProducerFromFile_RequiresURLPrefix(t *testing.T) {
 	)
 
 	ch := make(chan types.ArticleInfo, 10)
-	c.articleProducerFromFile(context.Background(), ch)
+	c.produceFromFile(context.Background(), ch)
 
 	var got []string
 	for a := range ch {
@@ -543,6 +642,7 @@ func TestArticleProducerFromFile_RequiresURLPrefix(t *testing.T) {
 	want := []string{
 		"https://www.ptt.cc/bbs/Beauty/M.111.A.html",
 		"https://www.ptt.cc/bbs/Beauty/M.222.A.html",
+		"https://www.ptt.cc/bbs/Beauty/M.444.A.html",
 	}
 	if len(got) != len(want) {
 		t.Fatalf("期望 %d 個 URL，實際 %d 個: %v", len(want), len(got), got)
@@ -554,6 +654,68 @@ func TestArticleProducerFromFile_RequiresURLPrefix(t *testing.T) {
 	}
 }
 
+// TestArticleProducerFromFile_Stdin 驗證 -file=- 從標準輸入讀取網址，套用相同的行過濾，
+// 並在無法 Seek 的來源上邊讀邊累加 ETA 總量
+func TestArticleProducerFromFile_Stdin(t *testing.T) {
+	stdin := strings.NewReader("https://www.ptt.cc/bbs/Beauty/M.111.A.html\n# 註解行\nnot a url\nptt.cc/bbs/Beauty/M.222.A.html\n")
+	c := NewCrawlerWithDependencies(
+		mocks.NewMockHTTPClient(), mocks.NewMockParser(), mocks.NewMockMarkdownGenerator(),
+		"test", 0, 0, FileURLStdin, config.DefaultConfig(),
+		WithStdin(struct{ io.Reader }{stdin}), // 包裝後不再實作 io.Seeker，模擬管線
+	)
+	c.tracker = newProgressTracker(nil)
+
+	ch := make(chan types.ArticleInfo, 10)
+	c.produceFromFile(context.Background(), ch)
+
+	var got []string
+	for a := range ch {
+		got = append(got, a.URL)
+	}
+	want := "https://www.ptt.cc/bbs/Beauty/M.111.A.html,https://www.ptt.cc/bbs/Beauty/M.222.A.html"
+	if strings.Join(got, ",") != want {
+		t.Errorf("URLs = %v, want %s", got, want)
+	}
+	if snap := c.tracker.snapshot(); snap.UnitsTotal != 2 {
+		t.Errorf("ETA 總量 = %d, want 2", snap.UnitsTotal)
+	}
+}
+
+// TestArticleProducerFromFile_Canceled 驗證 context 取消後不再讀取來源，並關閉 channel
+func TestArticleProducerFromFile_Canceled(t *testing.T) {
+	pr, pw := io.Pipe()
+	defer pw.Close()
+	c := NewCrawlerWithDependencies(
+		mocks.NewMockHTTPClient(), mocks.NewMockParser(), mocks.NewMockMarkdownGenerator(),
+		"test", 0, 0, FileURLStdin, config.DefaultConfig(), WithStdin(pr),
+	)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	ch := make(chan types.ArticleInfo) // 無緩衝：第二筆網址會卡在送出，直到 ctx 取消
+	done := make(chan struct{})
+	go func() {
+		c.produceFromFile(ctx, ch)
+		close(done)
+	}()
+
+	go func() {
+		_, _ = io.WriteString(pw, "https://www.ptt.cc/bbs/Beauty/M.111.A.html\nhttps://www.ptt.cc/bbs/Beauty/M.222.A.html\n")
+	}()
+	if a := <-ch; a.URL != "https://www.ptt.cc/bbs/Beauty/M.111.A.html" {
+		t.Fatalf("第一筆 URL = %q", a.URL)
+	}
+	cancel()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("context 取消後 producer 應結束")
+	}
+	if _, ok := <-ch; ok {
+		t.Error("producer 結束時應關閉 channel")
+	}
+}
+
 // TestRunWaitsForProducer 驗證 ctx 取消時 Run 會等待 producer goroutine 結束才返回。
 // 若 Run 提前返回，TUI 模式會關閉 progress channel，
 // 仍在執行的 producer 隨後呼叫 emit 就會對已關閉的 channel 做 send 而 panic。
@@ -623,10 +785,10 @@ func TestRunWaitsForParsers(t *testing.T) {
 				{Title: "test", URL: "https://www.ptt.cc/bbs/test/M.111.A.html", PushRate: 10},
 			}, nil
 		},
-		ParseArticleContentFunc: func(io.Reader) (string, []string, error) {
+		ParseArticleContentFunc: func(io.Reader) (types.ArticleContent, error) {
 			close(entered) // 通知測試：parser 已進入文章內容解析
 			<-release      // 卡住，模擬解析進行中（此期間 ctx 被取消）
-			return "title", nil, nil
+			return types.ArticleContent{Title: "title"}, nil
 		},
 	}
 
@@ -672,3 +834,202 @@ func TestRunWaitsForParsers(t *testing.T) {
 	// parser 此時必已結束，不會再有 emit。
 	close(progressCh)
 }
+
+// TestSaveToFile_StripExifUnsupportedKeepsFile 驗證啟用 stripExif 時，
+// 無法重新編碼的格式仍保留原始下載檔案。
+func TestSaveToFile_StripExifUnsupportedKeepsFile(t *testing.T) {
+	c := newTestCrawlerForSave(t)
+	c.config.Crawler.StripExif = true
+	savePath := filepath.Join(t.TempDir(), "fake.png")
+
+	content := pngHeader + "not a real png body"
+	resp := &http.Response{
+		StatusCode: http.StatusOK,
+		Body:       io.NopCloser(strings.NewReader(content)),
+	}
+
+	c.saveToFile(resp, types.DownloadTask{SavePath: savePath}, 1)
+
+	data, err := os.ReadFile(savePath)
+	if err != nil {
+		t.Fatalf("讀取下載檔案失敗: %v", err)
+	}
+	if string(data) != content {
+		t.Errorf("檔案內容 = %q, want %q", string(data), content)
+	}
+}
+
+// runDownloadWorker 以單一工人的下載工人池處理單一下載任務直到結束
+func runDownloadWorker(t *testing.T, c *Crawler, task types.DownloadTask) {
+	t.Helper()
+	pool := c.newDownloadPool(make(chan types.DownloadTask, 1), 1)
+	pool.Start(context.Background())
+	if err := pool.Submit(context.Background(), task); err != nil {
+		t.Fatalf("Submit() error = %v", err)
+	}
+	pool.Stop()
+	pool.Wait()
+}
+
+// TestDownloadWorker_MaxImageBytes 驗證超過 maxImageBytes 的回應不會留下任何檔案，
+// 包含 Content-Length 已超限（不建立檔案）與實際內容超限（刪除半截檔）兩種情況。
+func TestDownloadWorker_MaxImageBytes(t *testing.T) {
+	body := pngHeader + strings.Repeat("x", 100)
+
+	tests := []struct {
+		name          string
+		contentLength int64
+	}{
+		{"Content-Length 超限", int64(len(body))},
+		{"未提供 Content-Length，讀取時超限", -1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := &mocks.MockHTTPClient{
+				DoFunc: func(_ *http.Request) (*http.Response, error) {
+					return &http.Response{
+						StatusCode:    http.StatusOK,
+						ContentLength: tt.contentLength,
+						Body:          io.NopCloser(strings.NewReader(body)),
+					}, nil
+				},
+			}
+			cfg := config.DefaultConfig()
+			cfg.Crawler.Delays = config.DelayConfig{MinMs: 0, MaxMs: 0}
+			cfg.Crawler.MaxImageBytes = 50
+
+			c := NewCrawlerWithDependencies(client, mocks.NewMockParser(), mocks.NewMockMarkdownGenerator(), "test", 1, 0, "", cfg)
+			savePath := filepath.Join(t.TempDir(), "big.png")
+			runDownloadWorker(t, c, types.DownloadTask{ImageURL: "http://example.com/big.png", SavePath: savePath})
+
+			if _, err := os.Stat(savePath); !os.IsNotExist(err) {
+				t.Errorf("超過大小上限的圖片不應寫入: %s", savePath)
+			}
+		})
+	}
+}
+
+// TestSaveToFile_ZeroMaxImageBytesUnlimited 驗證 maxImageBytes 為 0 時不限制大小。
+func TestSaveToFile_ZeroMaxImageBytesUnlimited(t *testing.T) {
+	c := newTestCrawlerForSave(t)
+	c.config.Crawler.MaxImageBytes = 0
+	savePath := filepath.Join(t.TempDir(), "large.png")
+
+	content := pngHeader + strings.Repeat("x", 4096)
+	resp := &http.Response{
+		StatusCode:    http.StatusOK,
+		ContentLength: int64(len(content)),
+		Body:          io.NopCloser(strings.NewReader(content)),
+	}
+
+	c.saveToFile(resp, types.DownloadTask{SavePath: savePath}, 1)
+
+	info, err := os.Stat(savePath)
+	if err != nil {
+		t.Fatalf("maxImageBytes 為 0 時應寫入檔案: %v", err)
+	}
+	if info.Size() != int64(len(content)) {
+		t.Errorf("檔案大小 = %d, want %d", info.Size(), len(content))
+	}
+}
+
+// TestFetchAndParseArticle_ArticleTimeout 驗證 articleTimeout 讓卡住的文章頁在逾時後略過，
+// 不影響其後的文章，且逾時不會被當成整體中斷
+func TestFetchAndParseArticle_ArticleTimeout(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.Contains(r.URL.Path, "slow") {
+			select {
+			case <-r.Context().Done(): // 用戶端逾時取消後結束，server.Close 不會卡住
+			case <-time.After(5 * time.Second):
+			}
+			return
+		}
+		_, _ = io.WriteString(w, `<div id="main-content"><span class="article-meta-tag">標題</span><span class="article-meta-value">快速文章</span></div>`)
+	}))
+	defer server.Close()
+
+	var warnings []string
+	logger := &mocks.MockLogger{WarnFunc: func(format string, args ...any) {
+		warnings = append(warnings, fmt.Sprintf(format, args...))
+	}}
+	cfg := config.DefaultConfig()
+	cfg.Crawler.ArticleTimeout = "200ms"
+	c := NewCrawlerWithDependencies(server.Client(), ptt.NewParser(), mocks.NewMockMarkdownGenerator(),
+		"test", 1, 0, "", cfg, WithLogger(logger))
+
+	ctx := context.Background()
+	start := time.Now()
+	_, err := c.fetchAndParseArticle(ctx, types.ArticleInfo{URL: server.URL + "/bbs/test/M.slow.A.html"})
+	if err == nil {
+		t.Fatal("卡住的文章頁應在逾時後回傳錯誤")
+	}
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Errorf("應在 articleTimeout 後返回，實際耗時 %v", elapsed)
+	}
+	if len(warnings) != 1 || !strings.Contains(warnings[0], "articleTimeout") {
+		t.Errorf("應記錄一筆逾時警告，實際: %v", warnings)
+	}
+	if ctx.Err() != nil {
+		t.Fatal("文章逾時不應影響上層 context")
+	}
+
+	// 下一篇文章照常處理
+	content, err := c.fetchAndParseArticle(ctx, types.ArticleInfo{URL: server.URL + "/bbs/test/M.fast.A.html"})
+	if err != nil {
+		t.Fatalf("逾時後的下一篇文章應成功: %v", err)
+	}
+	if content.Title != "快速文章" {
+		t.Errorf("Title = %q, want 快速文章", content.Title)
+	}
+}
+
+// TestRun_ReturnsError 驗證完全無法產生文章時 Run 回傳錯誤，部分看板失敗或被中斷時仍回傳 nil
+func TestRun_ReturnsError(t *testing.T) {
+	// failing 中的看板首頁回傳 500，其餘看板只有一頁且沒有文章
+	newBoardCrawler := func(board string, failing ...string) *Crawler {
+		client := &mocks.MockHTTPClient{DoFunc: func(req *http.Request) (*http.Response, error) {
+			status := http.StatusOK
+			for _, b := range failing {
+				if strings.Contains(req.URL.Path, "/bbs/"+b+"/") {
+					status = http.StatusInternalServerError
+				}
+			}
+			return &http.Response{StatusCode: status, Body: io.NopCloser(strings.NewReader("<html></html>"))}, nil
+		}}
+		parser := &mocks.MockParser{ParseMaxPageFunc: func(io.Reader) (int, error) { return 1, nil }}
+		cfg := config.DefaultConfig()
+		cfg.Crawler.Delays = config.DelayConfig{}
+		return NewCrawlerWithDependencies(client, parser, mocks.NewMockMarkdownGenerator(), board, 1, 0, "", cfg,
+			WithLogger(&mocks.MockLogger{}))
+	}
+
+	t.Run("所有看板皆失敗", func(t *testing.T) {
+		err := newBoardCrawler("Beauty,Gossiping", "Beauty", "Gossiping").Run(context.Background())
+		if err == nil || !strings.Contains(err.Error(), "Beauty") || !strings.Contains(err.Error(), "Gossiping") {
+			t.Errorf("Run() error = %v, want 包含兩個看板的錯誤", err)
+		}
+	})
+
+	t.Run("部分看板失敗", func(t *testing.T) {
+		if err := newBoardCrawler("Beauty,Gossiping", "Beauty").Run(context.Background()); err != nil {
+			t.Errorf("部分看板失敗應視為成功，Run() error = %v", err)
+		}
+	})
+
+	t.Run("被中斷", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		if err := newBoardCrawler("Beauty", "Beauty").Run(ctx); err != nil {
+			t.Errorf("中斷不應視為錯誤，Run() error = %v", err)
+		}
+	})
+
+	t.Run("網址檔不存在", func(t *testing.T) {
+		c := NewCrawlerWithDependencies(mocks.NewMockHTTPClient(), mocks.NewMockParser(), mocks.NewMockMarkdownGenerator(),
+			"", 0, 0, filepath.Join(t.TempDir(), "missing.txt"), config.DefaultConfig(), WithLogger(&mocks.MockLogger{}))
+		if err := c.Run(context.Background()); err == nil || !strings.Contains(err.Error(), "開啟網址檔案失敗") {
+			t.Errorf("Run() error = %v, want 開啟網址檔案失敗", err)
+		}
+	})
+}
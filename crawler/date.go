@@ -0,0 +1,72 @@
+package crawler
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// 列表頁日期不含年份，推算年份時容許的未來偏移：
+// 本機時鐘或時區與 PTT（台灣時間）略有差異時，今天的文章不會被誤判為去年。
+const dateFutureTolerance = 24 * time.Hour
+
+// ParseDate 解析 -since/-until 參數，接受 YYYY-MM-DD 或 MM/DD（年份依 now 推算，見 inferYear）。
+// 回傳該日 00:00（now 的時區）。
+func ParseDate(s string, now time.Time) (time.Time, error) {
+	s = strings.TrimSpace(s)
+	if t, err := time.ParseInLocation("2006-01-02", s, now.Location()); err == nil {
+		return t, nil
+	}
+	if t, ok := parseMonthDay(s, now); ok {
+		return t, nil
+	}
+	return time.Time{}, fmt.Errorf("無效的日期 %q（格式: MM/DD 或 YYYY-MM-DD）", s)
+}
+
+// parseMonthDay 解析 PTT 列表頁的 "M/DD" 日期（可能有前導空白，如 " 1/05"），年份依 now 推算
+func parseMonthDay(s string, now time.Time) (time.Time, bool) {
+	m, d, found := strings.Cut(strings.TrimSpace(s), "/")
+	if !found {
+		return time.Time{}, false
+	}
+	month, err := strconv.Atoi(m)
+	if err != nil || month < 1 || month > 12 {
+		return time.Time{}, false
+	}
+	day, err := strconv.Atoi(d)
+	if err != nil || day < 1 || day > 31 {
+		return time.Time{}, false
+	}
+	return inferYear(time.Month(month), day, now), true
+}
+
+// inferYear 為不含年份的月/日補上年份：取不晚於 now 的最近一次。
+// 例如 1 月初看到 "12/31" 時為去年的 12/31，而非今年年底。
+func inferYear(month time.Month, day int, now time.Time) time.Time {
+	t := time.Date(now.Year(), month, day, 0, 0, 0, 0, now.Location())
+	if t.After(now.Add(dateFutureTolerance)) {
+		t = time.Date(now.Year()-1, month, day, 0, 0, 0, 0, now.Location())
+	}
+	return t
+}
+
+// matchDate 回報列表頁日期是否落在 -since/-until 範圍內（皆含當日）。
+// 未指定範圍時一律通過；日期缺漏或無法解析時也放行，避免改版後所有文章被靜默略過。
+func (c *Crawler) matchDate(date string, now time.Time) bool {
+	if c.since.IsZero() && c.until.IsZero() {
+		return true
+	}
+	t, ok := parseMonthDay(date, now)
+	if !ok {
+		c.logger.Debug("無法解析文章日期 %q，不套用日期過濾", date)
+		return true
+	}
+	if !c.since.IsZero() && t.Before(c.since) {
+		return false
+	}
+	if !c.until.IsZero() && t.After(c.until) {
+		return false
+	}
+	return true
+}
@@ -0,0 +1,149 @@
+package crawler
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/twtrubiks/ptt-spider-go/config"
+	"github.com/twtrubiks/ptt-spider-go/mocks"
+	"github.com/twtrubiks/ptt-spider-go/types"
+)
+
+func ymd(year int, month time.Month, d int) time.Time {
+	return time.Date(year, month, d, 0, 0, 0, 0, time.UTC)
+}
+
+func TestInferYear(t *testing.T) {
+	tests := []struct {
+		name  string
+		now   time.Time
+		month time.Month
+		day   int
+		want  time.Time
+	}{
+		{"同年過去日期", time.Date(2024, 6, 15, 12, 0, 0, 0, time.UTC), 3, 1, ymd(2024, 3, 1)},
+		{"今天", time.Date(2024, 6, 15, 12, 0, 0, 0, time.UTC), 6, 15, ymd(2024, 6, 15)},
+		{"1 月初看到 12 月底為去年", time.Date(2025, 1, 2, 8, 0, 0, 0, time.UTC), 12, 31, ymd(2024, 12, 31)},
+		{"1 月初看到 1 月初為今年", time.Date(2025, 1, 2, 8, 0, 0, 0, time.UTC), 1, 1, ymd(2025, 1, 1)},
+		{"12 月底看到 1 月為今年年初", time.Date(2024, 12, 31, 23, 0, 0, 0, time.UTC), 1, 1, ymd(2024, 1, 1)},
+		{"時區較快的隔天在容許範圍內", time.Date(2025, 1, 1, 1, 0, 0, 0, time.UTC), 1, 2, ymd(2025, 1, 2)},
+		{"超過容許範圍的未來日期為去年", time.Date(2024, 6, 15, 12, 0, 0, 0, time.UTC), 6, 17, ymd(2023, 6, 17)},
+		{"非閏年的 2/29 推算為上一個閏年", time.Date(2025, 1, 10, 0, 0, 0, 0, time.UTC), 2, 29, ymd(2024, 2, 29)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := inferYear(tt.month, tt.day, tt.now); !got.Equal(tt.want) {
+				t.Errorf("inferYear(%d/%d, now=%v) = %v, want %v", tt.month, tt.day, tt.now, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseDate(t *testing.T) {
+	now := time.Date(2025, 1, 5, 10, 0, 0, 0, time.UTC)
+	tests := []struct {
+		input   string
+		want    time.Time
+		wantErr bool
+	}{
+		{"2024-12-20", ymd(2024, 12, 20), false},
+		{"12/20", ymd(2024, 12, 20), false},
+		{"1/03", ymd(2025, 1, 3), false},
+		{" 1/3 ", ymd(2025, 1, 3), false},
+		{"13/01", time.Time{}, true},
+		{"1/32", time.Time{}, true},
+		{"2024/12/20", time.Time{}, true},
+		{"yesterday", time.Time{}, true},
+		{"", time.Time{}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := ParseDate(tt.input, now)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseDate(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			}
+			if !got.Equal(tt.want) {
+				t.Errorf("ParseDate(%q) = %v, want %v", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMatchDate(t *testing.T) {
+	now := time.Date(2025, 1, 5, 10, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name  string
+		since time.Time
+		until time.Time
+		date  string
+		want  bool
+	}{
+		{"未指定範圍時全部通過", time.Time{}, time.Time{}, "1/01", true},
+		{"since 當日包含在內", ymd(2024, 12, 30), time.Time{}, "12/30", true},
+		{"早於 since", ymd(2024, 12, 30), time.Time{}, "12/29", false},
+		{"跨年後的日期晚於去年 since", ymd(2024, 12, 30), time.Time{}, "1/02", true},
+		{"until 當日包含在內", time.Time{}, ymd(2025, 1, 2), "1/02", true},
+		{"晚於 until", time.Time{}, ymd(2025, 1, 2), "1/03", false},
+		{"範圍內", ymd(2024, 12, 1), ymd(2024, 12, 31), "12/25", true},
+		{"日期缺漏時放行", ymd(2024, 12, 1), time.Time{}, "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Crawler{logger: &mocks.MockLogger{}, since: tt.since, until: tt.until}
+			if got := c.matchDate(tt.date, now); got != tt.want {
+				t.Errorf("matchDate(%q) = %v, want %v", tt.date, got, tt.want)
+			}
+		})
+	}
+}
+
+// TestArticleProducer_DateFilter 以相對今天的日期驗證 producer 套用 -since/-until
+func TestArticleProducer_DateFilter(t *testing.T) {
+	now := time.Now()
+	listDate := func(daysAgo int) string {
+		d := now.AddDate(0, 0, -daysAgo)
+		return fmt.Sprintf("%d/%02d", d.Month(), d.Day())
+	}
+	client := &mocks.MockHTTPClient{
+		DoFunc: func(_ *http.Request) (*http.Response, error) {
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       io.NopCloser(strings.NewReader("<html></html>")),
+			}, nil
+		},
+	}
+	parser := &mocks.MockParser{
+		ParseMaxPageFunc: func(_ io.Reader) (int, error) { return 1, nil },
+		ParseArticlesFunc: func(_ io.Reader) ([]types.ArticleInfo, error) {
+			return []types.ArticleInfo{
+				{Title: "A", URL: "http://example.com/a", PushRate: 10, Date: listDate(0)},
+				{Title: "B", URL: "http://example.com/b", PushRate: 10, Date: listDate(3)},
+				{Title: "C", URL: "http://example.com/c", PushRate: 10, Date: listDate(10)},
+			}, nil
+		},
+	}
+
+	since, _ := ParseDate(listDate(5), now)
+	until, _ := ParseDate(listDate(1), now)
+	c := NewCrawlerWithDependencies(client, parser, mocks.NewMockMarkdownGenerator(), "test", 1, 0, "", config.DefaultConfig(),
+		WithDateRange(since, until))
+
+	ch := make(chan types.ArticleInfo, 10)
+	c.articleProducer(context.Background(), ch)
+
+	var got []string
+	for a := range ch {
+		got = append(got, a.Title)
+	}
+	if strings.Join(got, ",") != "B" {
+		t.Errorf("送出的文章 = %v, want [B]", got)
+	}
+}
@@ -0,0 +1,103 @@
+package crawler
+
+import (
+	"os"
+	"time"
+
+	"github.com/twtrubiks/ptt-spider-go/config"
+	"github.com/twtrubiks/ptt-spider-go/dedup"
+	"github.com/twtrubiks/ptt-spider-go/internal/fileutil"
+	"github.com/twtrubiks/ptt-spider-go/types"
+)
+
+// openDedupIndex 依配置載入跨執行的下載索引，dedup.enabled 關閉時回傳 nil
+func openDedupIndex(cfg *config.Config) (*dedup.Index, error) {
+	if !cfg.Crawler.Dedup.Enabled {
+		return nil, nil
+	}
+	return dedup.Open(cfg.Crawler.Dedup.IndexPath)
+}
+
+// openDedupPool 依配置開啟以內容雜湊定址的共用圖片目錄，未啟用 dedup 或未設定 poolDir 時回傳 nil
+func openDedupPool(cfg *config.Config) (*dedup.Pool, error) {
+	if !cfg.Crawler.Dedup.Enabled || cfg.Crawler.Dedup.PoolDir == "" {
+		return nil, nil
+	}
+	return dedup.OpenPool(cfg.Crawler.Dedup.PoolDir)
+}
+
+// reuseDownloaded 檢查圖片是否在先前的執行中下載過，命中時依 dedup.mode 建立連結或略過。
+// 回傳 true 表示已處理完畢、不需再下載；索引中的檔案已不存在時回傳 false 重新下載。
+func (c *Crawler) reuseDownloaded(id int, task types.DownloadTask) bool {
+	if c.dedupIndex == nil {
+		return false
+	}
+	entry, ok := c.dedupIndex.Lookup(task.ImageURL)
+	if !ok {
+		return false
+	}
+	if _, err := os.Stat(entry.Path); err != nil {
+		return false
+	}
+
+	if entry.Path != task.SavePath && c.config.Crawler.Dedup.Mode == config.DedupModeLink {
+		if err := fileutil.LinkOrCopy(entry.Path, task.SavePath); err != nil {
+			c.boardLogger(task.Board).Warn("工人 #%d 連結已下載圖片失敗，改為重新下載: %s, 錯誤: %v", id, task.SavePath, err)
+			return false
+		}
+		c.routineLogger(c.boardLogger(task.Board)).Info("工人 #%d 圖片已下載過，建立連結: %s -> %s", id, task.SavePath, entry.Path)
+	} else {
+		c.routineLogger(c.boardLogger(task.Board)).Info("工人 #%d 圖片已下載過，略過: %s", id, task.ImageURL)
+	}
+
+	c.emit(types.ProgressEvent{
+		Type:     types.EventDownloadDone,
+		WorkerID: id,
+		Message:  task.SavePath,
+	})
+	return true
+}
+
+// shareDownloaded 將剛下載的圖片加入共用目錄，內容與先前的圖片相同時改為共用同一份檔案。
+// 失敗時記錄警告並保留獨立的檔案，不影響下載結果。
+func (c *Crawler) shareDownloaded(id int, task types.DownloadTask) {
+	if c.dedupPool == nil {
+		return
+	}
+	shared, err := c.dedupPool.Store(task.SavePath)
+	if err != nil {
+		c.boardLogger(task.Board).Warn("工人 #%d 加入共用圖片目錄失敗，保留獨立檔案: %s, 錯誤: %v", id, task.SavePath, err)
+		return
+	}
+	if shared {
+		c.routineLogger(c.boardLogger(task.Board)).Info("工人 #%d 圖片內容與先前下載的相同，已改為共用檔案: %s", id, task.SavePath)
+	}
+}
+
+// recordDownloaded 將成功下載的圖片寫入索引（以最終檔案內容計算雜湊，涵蓋 stripExif 的改寫）
+func (c *Crawler) recordDownloaded(id int, task types.DownloadTask) {
+	if c.dedupIndex == nil {
+		return
+	}
+	sum, size, err := dedup.HashFile(task.SavePath)
+	if err != nil {
+		c.boardLogger(task.Board).Warn("工人 #%d 計算圖片雜湊失敗，未加入下載索引: %s, 錯誤: %v", id, task.SavePath, err)
+		return
+	}
+	c.dedupIndex.Record(task.ImageURL, dedup.Entry{
+		SHA256:       sum,
+		Path:         task.SavePath,
+		Size:         size,
+		DownloadedAt: time.Now(),
+	})
+}
+
+// saveDedupIndex 在爬蟲結束時將下載索引寫回磁碟，試跑模式不寫入
+func (c *Crawler) saveDedupIndex() {
+	if c.dedupIndex == nil || c.dryRun != nil {
+		return
+	}
+	if err := c.dedupIndex.Save(); err != nil {
+		c.logger.Error("儲存下載索引失敗: %v", err)
+	}
+}
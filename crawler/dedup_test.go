@@ -4,12 +4,16 @@ import (
 	"context"
 	"io"
 	"net/http"
+	"os"
+	"path/filepath"
 	"reflect"
 	"strings"
 	"testing"
 
 	"github.com/twtrubiks/ptt-spider-go/config"
+	"github.com/twtrubiks/ptt-spider-go/dedup"
 	"github.com/twtrubiks/ptt-spider-go/mocks"
+	"github.com/twtrubiks/ptt-spider-go/ptt"
 	"github.com/twtrubiks/ptt-spider-go/types"
 )
 
@@ -59,12 +63,12 @@ func TestProcessArticle_DeduplicatesImageURLs(t *testing.T) {
 
 	// 同一張圖在原文與推文中各出現一次
 	mockParser := &mocks.MockParser{
-		ParseArticleContentFunc: func(_ io.Reader) (string, []string, error) {
-			return "Test Title", []string{
+		ParseArticleContentFunc: func(_ io.Reader) (types.ArticleContent, error) {
+			return types.ArticleContent{Title: "Test Title", ImageURLs: []string{
 				"http://example.com/img1.jpg",
 				"http://example.com/img1.jpg",
 				"http://example.com/img2.jpg",
-			}, nil
+			}}, nil
 		},
 	}
 
@@ -106,3 +110,169 @@ func TestProcessArticle_DeduplicatesImageURLs(t *testing.T) {
 		}
 	}
 }
+
+// TestProcessArticle_DuplicateImages 以實際解析器解析重複貼圖的文章，
+// 驗證每個不重複的 URL 只產生一個下載任務，Markdown 圖片清單也不重複。
+func TestProcessArticle_DuplicateImages(t *testing.T) {
+	html, err := os.ReadFile("../tests/fixtures/article_duplicate_images.html")
+	if err != nil {
+		t.Fatalf("讀取 fixture 失敗: %v", err)
+	}
+	t.Chdir(t.TempDir())
+
+	client := &mocks.MockHTTPClient{
+		DoFunc: func(_ *http.Request) (*http.Response, error) {
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       io.NopCloser(strings.NewReader(string(html))),
+			}, nil
+		},
+	}
+	cfg := config.DefaultConfig()
+	cfg.Crawler.Delays = config.DelayConfig{MinMs: 0, MaxMs: 0}
+	c := NewCrawlerWithDependencies(client, ptt.NewParser(), mocks.NewMockMarkdownGenerator(),
+		"test", 1, 0, "", cfg, WithLogger(&mocks.MockLogger{}))
+
+	downloadChan := make(chan types.DownloadTask, 10)
+	markdownChan := make(chan types.MarkdownInfo, 10)
+	c.processArticle(context.Background(), types.ArticleInfo{Title: "重複圖片測試", URL: "http://example.com/a"}, downloadChan, markdownChan)
+	close(downloadChan)
+	close(markdownChan)
+
+	want := "https://i.imgur.com/dup1.jpg,https://i.imgur.com/dup2.png"
+	var got []string
+	for task := range downloadChan {
+		got = append(got, task.ImageURL)
+	}
+	if strings.Join(got, ",") != want {
+		t.Errorf("下載任務 = %v, want %s", got, want)
+	}
+	for info := range markdownChan {
+		if strings.Join(info.ImageURLs, ",") != want {
+			t.Errorf("Markdown 圖片清單 = %v, want %s", info.ImageURLs, want)
+		}
+	}
+}
+
+// newDedupCrawler 建立啟用跨執行去重的 Crawler，並回傳圖片請求次數的計數器
+func newDedupCrawler(t *testing.T, indexPath, mode string) (*Crawler, *int) {
+	t.Helper()
+	requests := 0
+	client := &mocks.MockHTTPClient{
+		DoFunc: func(_ *http.Request) (*http.Response, error) {
+			requests++
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       io.NopCloser(strings.NewReader(pngHeader + "image-data")),
+			}, nil
+		},
+	}
+	cfg := config.DefaultConfig()
+	cfg.Crawler.Delays = config.DelayConfig{MinMs: 0, MaxMs: 0}
+	cfg.Crawler.Dedup = config.DedupConfig{Enabled: true, IndexPath: indexPath, Mode: mode}
+
+	c := NewCrawlerWithDependencies(client, mocks.NewMockParser(), mocks.NewMockMarkdownGenerator(), "test", 1, 0, "", cfg)
+	if c.dedupIndex == nil {
+		t.Fatal("啟用 dedup 時應載入下載索引")
+	}
+	return c, &requests
+}
+
+func TestDownloadWorker_DedupAcrossRuns(t *testing.T) {
+	const imageURL = "https://i.imgur.com/abc.png"
+
+	tests := []struct {
+		name       string
+		mode       string
+		wantSecond bool // 第二次執行的目標路徑是否應存在
+	}{
+		{"link 模式建立連結", config.DedupModeLink, true},
+		{"skip 模式直接略過", config.DedupModeSkip, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			indexPath := filepath.Join(dir, "downloads.json")
+			firstPath := filepath.Join(dir, "first", "abc.png")
+			secondPath := filepath.Join(dir, "second", "abc.png")
+
+			// 第一次執行：下載並寫入索引
+			c, requests := newDedupCrawler(t, indexPath, tt.mode)
+			runDownloadWorker(t, c, types.DownloadTask{ImageURL: imageURL, SavePath: firstPath})
+			c.saveDedupIndex()
+			if *requests != 1 {
+				t.Fatalf("第一次執行應下載 1 次，got %d", *requests)
+			}
+
+			// 第二次執行（重新載入索引）：同一 URL 出現在另一篇文章
+			c2, requests2 := newDedupCrawler(t, indexPath, tt.mode)
+			runDownloadWorker(t, c2, types.DownloadTask{ImageURL: imageURL, SavePath: secondPath})
+			if *requests2 != 0 {
+				t.Errorf("已下載過的圖片不應再發出請求，got %d 次", *requests2)
+			}
+
+			_, err := os.Stat(secondPath)
+			if exists := err == nil; exists != tt.wantSecond {
+				t.Errorf("第二次的目標檔存在 = %v, want %v", exists, tt.wantSecond)
+			}
+		})
+	}
+}
+
+func TestDownloadWorker_DedupMissingFileRedownloads(t *testing.T) {
+	dir := t.TempDir()
+	indexPath := filepath.Join(dir, "downloads.json")
+	savePath := filepath.Join(dir, "a", "abc.png")
+	task := types.DownloadTask{ImageURL: "https://i.imgur.com/abc.png", SavePath: savePath}
+
+	c, _ := newDedupCrawler(t, indexPath, config.DedupModeLink)
+	runDownloadWorker(t, c, task)
+	c.saveDedupIndex()
+
+	// 使用者手動刪除了先前下載的檔案
+	if err := os.Remove(savePath); err != nil {
+		t.Fatal(err)
+	}
+
+	c2, requests := newDedupCrawler(t, indexPath, config.DedupModeLink)
+	runDownloadWorker(t, c2, task)
+	if *requests != 1 {
+		t.Errorf("索引中的檔案已不存在時應重新下載，got %d 次請求", *requests)
+	}
+	if _, err := os.Stat(savePath); err != nil {
+		t.Errorf("重新下載後檔案應存在: %v", err)
+	}
+}
+
+// TestDownloadWorker_DedupPool 驗證不同 URL 但內容相同的圖片在設定 poolDir 後共用同一份檔案，
+// 各文章目錄仍保有自己的檔名
+func TestDownloadWorker_DedupPool(t *testing.T) {
+	dir := t.TempDir()
+	c, requests := newDedupCrawler(t, filepath.Join(dir, "downloads.json"), config.DedupModeLink)
+	pool, err := dedup.OpenPool(filepath.Join(dir, "pool"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	c.dedupPool = pool
+
+	firstPath := filepath.Join(dir, "article1", "a.png")
+	secondPath := filepath.Join(dir, "article2", "b.png")
+	runDownloadWorker(t, c, types.DownloadTask{ImageURL: "https://i.imgur.com/a.png", SavePath: firstPath})
+	runDownloadWorker(t, c, types.DownloadTask{ImageURL: "https://example.com/b.png", SavePath: secondPath})
+
+	if *requests != 2 {
+		t.Errorf("不同 URL 仍需各自下載，got %d 次請求", *requests)
+	}
+	first, err := os.Stat(firstPath)
+	if err != nil {
+		t.Fatalf("第一張圖片應存在: %v", err)
+	}
+	second, err := os.Stat(secondPath)
+	if err != nil {
+		t.Fatalf("第二張圖片應存在: %v", err)
+	}
+	if !os.SameFile(first, second) {
+		t.Error("內容相同的圖片應共用同一份檔案")
+	}
+}
@@ -0,0 +1,104 @@
+package crawler
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Direction 看板列表頁的爬取方向
+type Direction string
+
+// 看板列表頁的爬取方向
+const (
+	DirectionNewest Direction = "newest" // 從新頁往舊頁爬（預設）
+	DirectionOldest Direction = "oldest" // 從舊頁往新頁爬
+)
+
+// ParseDirection 解析 -direction 參數（不分大小寫），空字串視為 newest
+func ParseDirection(s string) (Direction, error) {
+	switch d := Direction(strings.ToLower(strings.TrimSpace(s))); d {
+	case "":
+		return DirectionNewest, nil
+	case DirectionNewest, DirectionOldest:
+		return d, nil
+	default:
+		return "", fmt.Errorf("無效的爬取方向 %q（可用值: newest、oldest）", s)
+	}
+}
+
+// PageRange 是以絕對頁碼指定的爬取範圍（-page-start/-page-end，皆含），零值表示未指定
+type PageRange struct {
+	Start int
+	End   int
+}
+
+// NewPageRange 驗證並建立頁碼範圍：start、end 需同時指定（皆為 0 表示未指定），
+// 且 1 <= start <= end；是否超過看板實際頁數要等取得最新頁碼後才能檢查（見 PageRange.Check）
+func NewPageRange(start, end int) (PageRange, error) {
+	switch {
+	case start == 0 && end == 0:
+		return PageRange{}, nil
+	case start == 0 || end == 0:
+		return PageRange{}, fmt.Errorf("-page-start 與 -page-end 需同時指定（目前為 %d、%d）", start, end)
+	case start < 1:
+		return PageRange{}, fmt.Errorf("-page-start 需大於等於 1: %d", start)
+	case start > end:
+		return PageRange{}, fmt.Errorf("-page-start %d 不可大於 -page-end %d", start, end)
+	}
+	return PageRange{Start: start, End: end}, nil
+}
+
+// IsSet 回傳是否有指定頁碼範圍
+func (r PageRange) IsSet() bool {
+	return r.Start > 0
+}
+
+// Len 回傳範圍內的頁數
+func (r PageRange) Len() int {
+	if !r.IsSet() {
+		return 0
+	}
+	return r.End - r.Start + 1
+}
+
+// Check 檢查範圍是否落在看板實際的頁碼 [1, maxPage] 之內
+func (r PageRange) Check(maxPage int) error {
+	if r.End > maxPage {
+		return fmt.Errorf("頁碼範圍 %d-%d 超過看板實際頁數 %d", r.Start, r.End, maxPage)
+	}
+	return nil
+}
+
+// boardPages 依爬取方向與起始頁計算要爬取的頁碼，結果一定落在 1..maxPage 之內，最多 c.pages 頁。
+// 有指定頁碼範圍時只爬取範圍內的頁面（newest 由 End 往 Start、oldest 由 Start 往 End）；否則
+// newest：從 fromPage（未指定或超過最新頁時為最新頁）往舊頁爬；
+// oldest：從 fromPage（未指定時為第 1 頁）往新頁爬，起始頁超過最新頁時沒有可爬的頁面。
+func (c *Crawler) boardPages(maxPage int) []int {
+	pages := make([]int, 0, max(min(c.pages, maxPage), 0))
+	if r := c.pageRange; r.IsSet() {
+		for p := r.Start; p <= min(r.End, maxPage); p++ {
+			pages = append(pages, p)
+		}
+		if c.direction != DirectionOldest {
+			slices.Reverse(pages)
+		}
+		return pages
+	}
+	if c.direction == DirectionOldest {
+		start := max(c.fromPage, 1)
+		for p := start; p <= maxPage && len(pages) < c.pages; p++ {
+			pages = append(pages, p)
+		}
+		return pages
+	}
+
+	start := c.fromPage
+	if start < 1 || start > maxPage {
+		start = maxPage
+	}
+	for p := start; p >= 1 && len(pages) < c.pages; p-- {
+		pages = append(pages, p)
+	}
+	return pages
+}
@@ -0,0 +1,188 @@
+package crawler
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/twtrubiks/ptt-spider-go/config"
+	"github.com/twtrubiks/ptt-spider-go/mocks"
+	"github.com/twtrubiks/ptt-spider-go/types"
+)
+
+func TestParseDirection(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    Direction
+		wantErr bool
+	}{
+		{"", DirectionNewest, false},
+		{"newest", DirectionNewest, false},
+		{"OLDEST", DirectionOldest, false},
+		{" oldest ", DirectionOldest, false},
+		{"backward", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := ParseDirection(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseDirection(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ParseDirection(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBoardPages(t *testing.T) {
+	tests := []struct {
+		name      string
+		direction Direction
+		fromPage  int
+		pages     int
+		maxPage   int
+		want      []int
+	}{
+		{"newest 預設從最新頁開始", DirectionNewest, 0, 3, 10, []int{10, 9, 8}},
+		{"newest 指定起始頁", DirectionNewest, 5, 3, 10, []int{5, 4, 3}},
+		{"newest 停在第 1 頁", DirectionNewest, 2, 5, 10, []int{2, 1}},
+		{"newest 起始頁超過最新頁時從最新頁開始", DirectionNewest, 20, 2, 10, []int{10, 9}},
+		{"oldest 預設從第 1 頁開始", DirectionOldest, 0, 3, 10, []int{1, 2, 3}},
+		{"oldest 指定起始頁", DirectionOldest, 4, 3, 10, []int{4, 5, 6}},
+		{"oldest 停在最新頁", DirectionOldest, 9, 5, 10, []int{9, 10}},
+		{"oldest 起始頁超過最新頁時沒有頁面", DirectionOldest, 20, 2, 10, []int{}},
+		{"pages 大於看板頁數", DirectionOldest, 0, 5, 2, []int{1, 2}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Crawler{pages: tt.pages, direction: tt.direction, fromPage: tt.fromPage}
+			got := c.boardPages(tt.maxPage)
+			if fmt.Sprint(got) != fmt.Sprint(tt.want) {
+				t.Errorf("boardPages(%d) = %v, want %v", tt.maxPage, got, tt.want)
+			}
+		})
+	}
+}
+
+// TestArticleProducer_Direction 驗證 producer 依爬取方向的順序請求列表頁
+func TestArticleProducer_Direction(t *testing.T) {
+	var requestedURLs []string
+	client := &mocks.MockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			requestedURLs = append(requestedURLs, req.URL.Path)
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       io.NopCloser(strings.NewReader("<html></html>")),
+			}, nil
+		},
+	}
+	parser := &mocks.MockParser{
+		ParseMaxPageFunc:  func(_ io.Reader) (int, error) { return 10, nil },
+		ParseArticlesFunc: func(_ io.Reader) ([]types.ArticleInfo, error) { return nil, nil },
+	}
+
+	c := NewCrawlerWithDependencies(client, parser, mocks.NewMockMarkdownGenerator(), "test", 3, 0, "", config.DefaultConfig(),
+		WithLogger(&mocks.MockLogger{}), WithDirection(DirectionOldest), WithFromPage(9))
+
+	ch := make(chan types.ArticleInfo, 10)
+	c.articleProducer(context.Background(), ch)
+
+	want := "/bbs/test/index.html,/bbs/test/index9.html,/bbs/test/index10.html"
+	if got := strings.Join(requestedURLs, ","); got != want {
+		t.Errorf("請求順序 = %s, want %s", got, want)
+	}
+}
+
+func TestNewPageRange(t *testing.T) {
+	tests := []struct {
+		name       string
+		start, end int
+		want       PageRange
+		wantErr    bool
+	}{
+		{"未指定", 0, 0, PageRange{}, false},
+		{"合法範圍", 3, 7, PageRange{Start: 3, End: 7}, false},
+		{"單一頁", 5, 5, PageRange{Start: 5, End: 5}, false},
+		{"只指定起始頁", 3, 0, PageRange{}, true},
+		{"只指定結束頁", 0, 7, PageRange{}, true},
+		{"起始頁為負數", -1, 7, PageRange{}, true},
+		{"起始頁大於結束頁", 8, 7, PageRange{}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewPageRange(tt.start, tt.end)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("NewPageRange(%d, %d) error = %v, wantErr %v", tt.start, tt.end, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("NewPageRange(%d, %d) = %+v, want %+v", tt.start, tt.end, got, tt.want)
+			}
+		})
+	}
+
+	r := PageRange{Start: 3, End: 7}
+	if r.Len() != 5 {
+		t.Errorf("Len() = %d, want 5", r.Len())
+	}
+	if err := r.Check(7); err != nil {
+		t.Errorf("Check(7) error = %v", err)
+	}
+	if err := r.Check(6); err == nil {
+		t.Error("結束頁超過看板頁數時 Check 應回傳錯誤")
+	}
+}
+
+func TestBoardPages_PageRange(t *testing.T) {
+	r := PageRange{Start: 3, End: 5}
+	newest := &Crawler{pages: r.Len(), direction: DirectionNewest, pageRange: r}
+	if got := fmt.Sprint(newest.boardPages(10)); got != "[5 4 3]" {
+		t.Errorf("newest boardPages = %s, want [5 4 3]", got)
+	}
+	// fromPage 在指定範圍時不作用
+	oldest := &Crawler{pages: r.Len(), direction: DirectionOldest, fromPage: 9, pageRange: r}
+	if got := fmt.Sprint(oldest.boardPages(10)); got != "[3 4 5]" {
+		t.Errorf("oldest boardPages = %s, want [3 4 5]", got)
+	}
+}
+
+// TestArticleProducer_PageRangeExceedsMaxPage 驗證範圍超過看板實際頁數時略過該看板，不請求任何列表頁
+func TestArticleProducer_PageRangeExceedsMaxPage(t *testing.T) {
+	var requestedURLs []string
+	client := &mocks.MockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			requestedURLs = append(requestedURLs, req.URL.Path)
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       io.NopCloser(strings.NewReader("<html></html>")),
+			}, nil
+		},
+	}
+	parser := &mocks.MockParser{
+		ParseMaxPageFunc:  func(_ io.Reader) (int, error) { return 10, nil },
+		ParseArticlesFunc: func(_ io.Reader) ([]types.ArticleInfo, error) { return nil, nil },
+	}
+	var errorsLogged []string
+	logger := &mocks.MockLogger{ErrorFunc: func(format string, args ...any) {
+		errorsLogged = append(errorsLogged, fmt.Sprintf(format, args...))
+	}}
+
+	r := PageRange{Start: 8, End: 12}
+	c := NewCrawlerWithDependencies(client, parser, mocks.NewMockMarkdownGenerator(), "test", r.Len(), 0, "", config.DefaultConfig(),
+		WithLogger(logger), WithPageRange(r))
+
+	ch := make(chan types.ArticleInfo, 10)
+	c.articleProducer(context.Background(), ch)
+
+	if got := strings.Join(requestedURLs, ","); got != "/bbs/test/index.html" {
+		t.Errorf("只應請求最新頁以取得頁數，實際: %s", got)
+	}
+	if len(errorsLogged) != 1 || !strings.Contains(errorsLogged[0], "超過看板實際頁數 10") {
+		t.Errorf("應記錄範圍超出的錯誤，實際: %v", errorsLogged)
+	}
+}
@@ -0,0 +1,40 @@
+package crawler
+
+import (
+	"time"
+
+	"github.com/twtrubiks/ptt-spider-go/types"
+	"github.com/twtrubiks/ptt-spider-go/workerpool"
+)
+
+// newDownloadPool 建立 pool 下載模式的常駐下載工人池，以 tasks 為佇列、目標 n 個工人，
+// 每個工人以 downloadOne 處理任務。工人數可在執行期以 Resize 調整（workers 熱更新）。
+func (c *Crawler) newDownloadPool(tasks chan types.DownloadTask, n int) *workerpool.Pool[types.DownloadTask] {
+	return workerpool.New(tasks, n, c.downloadOne,
+		workerpool.WithIdleWarn(c.config.GetWorkerIdleWarn(), c.logWorkerIdle),
+		workerpool.WithHooks(c.logWorkerStart, c.logWorkerExit))
+}
+
+// logWorkerIdle 在下載工人閒置達 workerIdleWarn 時以 Debug 等級提示瓶頸可能在上游（parser 或 producer）
+func (c *Crawler) logWorkerIdle(id int, idle time.Duration, received bool) {
+	if received {
+		c.logger.Debug("下載工人 #%d 閒置 %v 後領到任務", id, idle.Round(time.Millisecond))
+		return
+	}
+	c.logger.Debug("下載工人 #%d 已閒置 %v 未領到任務，瓶頸可能在上游（parser 或 producer）",
+		id, idle.Round(time.Millisecond))
+}
+
+// logWorkerStart 記錄下載工人啟動
+func (c *Crawler) logWorkerStart(id int) {
+	c.routineLogger(c.logger).Info("下載工人 #%d 啟動", id)
+}
+
+// logWorkerExit 記錄下載工人結束，err 不為 nil 表示因中斷而結束
+func (c *Crawler) logWorkerExit(id int, totalIdle time.Duration, err error) {
+	if err != nil {
+		c.logger.Warn("下載工人 #%d 收到中斷信號", id)
+		return
+	}
+	c.routineLogger(c.logger).Success("下載工人 #%d 結束（累計閒置 %v）", id, totalIdle.Round(time.Millisecond))
+}
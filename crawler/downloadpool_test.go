@@ -0,0 +1,108 @@
+package crawler
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/twtrubiks/ptt-spider-go/config"
+	"github.com/twtrubiks/ptt-spider-go/mocks"
+	"github.com/twtrubiks/ptt-spider-go/types"
+)
+
+// newIdleTestCrawler 建立記錄 Debug 訊息的 crawler
+func newIdleTestCrawler(idleWarn string) (*Crawler, func() []string) {
+	var mu sync.Mutex
+	var lines []string
+	logger := &mocks.MockLogger{
+		DebugFunc: func(format string, _ ...any) {
+			mu.Lock()
+			defer mu.Unlock()
+			lines = append(lines, format)
+		},
+	}
+	cfg := config.DefaultConfig()
+	cfg.Crawler.WorkerIdleWarn = idleWarn
+	c := NewCrawlerWithDependencies(mocks.NewMockHTTPClient(), mocks.NewMockParser(), mocks.NewMockMarkdownGenerator(),
+		"test", 1, 0, "", cfg, WithLogger(logger))
+	return c, func() []string {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]string(nil), lines...)
+	}
+}
+
+func TestDownloadPool_WarnsWhenIdle(t *testing.T) {
+	t.Chdir(t.TempDir())
+	c, debugLines := newIdleTestCrawler("10ms")
+	tasks := make(chan types.DownloadTask, 1)
+	pool := c.newDownloadPool(tasks, 1)
+	pool.Start(context.Background())
+
+	time.Sleep(50 * time.Millisecond)
+	if err := pool.Submit(context.Background(), types.DownloadTask{ImageURL: "https://i.imgur.com/a.jpg", SavePath: "a.jpg"}); err != nil {
+		t.Fatalf("Submit() error = %v", err)
+	}
+	pool.Stop()
+	pool.Wait()
+
+	lines := debugLines()
+	var warned, received bool
+	for _, l := range lines {
+		warned = warned || strings.Contains(l, "未領到任務")
+		received = received || strings.Contains(l, "後領到任務")
+	}
+	if !warned || !received {
+		t.Errorf("Debug lines = %v, want 閒置提示與領到任務記錄", lines)
+	}
+}
+
+func TestDownloadPool_NoWarnWhenDisabled(t *testing.T) {
+	c, debugLines := newIdleTestCrawler("0")
+	pool := c.newDownloadPool(make(chan types.DownloadTask), 1)
+	pool.Start(context.Background())
+
+	time.Sleep(20 * time.Millisecond)
+	pool.Stop()
+	pool.Wait()
+
+	for _, l := range debugLines() {
+		if strings.Contains(l, "閒置") {
+			t.Errorf("門檻為 0 時不應有閒置提示: %q", l)
+		}
+	}
+}
+
+func TestDownloadPool_ExitLogs(t *testing.T) {
+	tests := []struct {
+		name   string
+		cancel bool
+		want   string
+	}{
+		{"佇列關閉後正常結束", false, "結束（累計閒置"},
+		{"中斷時結束", true, "收到中斷信號"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, log := newReloadTestCrawler(config.DefaultConfig())
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			pool := c.newDownloadPool(make(chan types.DownloadTask), 1)
+			pool.Start(ctx)
+			if tt.cancel {
+				cancel()
+			} else {
+				pool.Stop()
+			}
+			pool.Wait()
+
+			if log.count(tt.want) != 1 {
+				t.Errorf("logs = %v, want %q", log.lines, tt.want)
+			}
+		})
+	}
+}
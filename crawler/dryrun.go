@@ -0,0 +1,124 @@
+package crawler
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"sync/atomic"
+
+	"github.com/twtrubiks/ptt-spider-go/config"
+	"github.com/twtrubiks/ptt-spider-go/markdown"
+	"github.com/twtrubiks/ptt-spider-go/performance"
+	"github.com/twtrubiks/ptt-spider-go/types"
+)
+
+// dryRunPlan 累計 -dry-run 模式下預計的下載與輸出檔，可供多個 goroutine 並行更新
+type dryRunPlan struct {
+	downloads   atomic.Int64 // 預計下載的圖片數
+	reused      atomic.Int64 // 已在下載索引中、不需重新下載的圖片數
+	bytes       atomic.Int64 // 已知大小的圖片合計 bytes
+	unknownSize atomic.Int64 // 無法由 HEAD 取得大小的圖片數
+	files       atomic.Int64 // 預計產生的文章輸出檔數
+}
+
+// WithDryRun 啟用試跑模式：照常爬取列表頁與文章頁，但不下載圖片、不建立目錄或寫入任何輸出檔，
+// 改為記錄每個預計的下載與輸出檔；圖片大小以 HEAD 請求的 Content-Length 估算。
+// checkpoint 只讀取（-resume 仍會略過已完成的文章），不會寫回。
+func WithDryRun(enabled bool) Option {
+	return func(c *Crawler) {
+		if enabled {
+			c.dryRun = &dryRunPlan{}
+		} else {
+			c.dryRun = nil
+		}
+	}
+}
+
+// planDownload 取代 downloadOne 的實際下載：以 HEAD 請求估算圖片大小並記錄預計的存檔路徑。
+// HEAD 請求同樣經過速率限制，但不套用下載前的隨機延遲。
+func (c *Crawler) planDownload(ctx context.Context, id int, task types.DownloadTask) {
+	log := c.boardLogger(task.Board)
+
+	if c.dedupIndex != nil {
+		if entry, ok := c.dedupIndex.Lookup(task.ImageURL); ok {
+			if _, err := os.Stat(entry.Path); err == nil {
+				c.dryRun.reused.Add(1)
+				log.Info("[dry-run] 工人 #%d 圖片已下載過，不需重新下載: %s", id, task.ImageURL)
+				c.emitPlanned(id, task)
+				return
+			}
+		}
+	}
+
+	// 已經過 HEAD 預檢的任務不必再送一次
+	size := task.Size
+	if size <= 0 {
+		size = c.headSize(ctx, task.ImageURL)
+	}
+	if ctx.Err() != nil {
+		return
+	}
+	c.dryRun.downloads.Add(1)
+	sizeText := "大小未知"
+	if size >= 0 {
+		c.dryRun.bytes.Add(size)
+		sizeText = performance.FormatBytes(uint64(size))
+	} else {
+		c.dryRun.unknownSize.Add(1)
+	}
+	log.Info("[dry-run] 工人 #%d 預計下載: %s → %s（%s）", id, task.ImageURL, task.SavePath, sizeText)
+	c.emitPlanned(id, task)
+}
+
+// emitPlanned 以下載完成事件回報已規劃的圖片，讓進度與 ETA 照常推進
+func (c *Crawler) emitPlanned(id int, task types.DownloadTask) {
+	c.emit(types.ProgressEvent{
+		Type:     types.EventDownloadDone,
+		WorkerID: id,
+		Message:  "[dry-run] " + task.SavePath,
+	})
+}
+
+// planMarkdown 取代 markdownWorker 的 Generate：記錄文章目錄中預計產生的輸出檔
+func (c *Crawler) planMarkdown(task types.MarkdownInfo) {
+	files := plannedOutputFiles(c.config.Crawler.Output)
+	if c.config.PushesFile() {
+		files = append(files, markdown.PushesFileName)
+	}
+	c.dryRun.files.Add(int64(len(files)))
+	c.logger.Info("[dry-run] 預計為文章「%s」產生 %s（%d 張圖片）於目錄: %s",
+		task.Title, strings.Join(files, "、"), len(task.ImageURLs), task.SaveDir)
+}
+
+// plannedOutputFiles 回傳依 output 配置在每個文章目錄產生的檔名
+func plannedOutputFiles(output config.OutputConfig) []string {
+	var files []string
+	for _, f := range outputFormats(output.Format) {
+		switch f {
+		case markdown.FormatMarkdown:
+			files = append(files, markdown.MarkdownFileName)
+		case markdown.FormatHTML:
+			files = append(files, markdown.HTMLFileName)
+		}
+	}
+	if output.Manifest {
+		files = append(files, markdown.ManifestFileName)
+	}
+	return files
+}
+
+// logDryRunSummary 在結束時輸出試跑的預計下載總量
+func (c *Crawler) logDryRunSummary() {
+	p := c.dryRun
+	summary := fmt.Sprintf("[dry-run] 預計下載 %d 張圖片，預估大小 %s",
+		p.downloads.Load(), performance.FormatBytes(uint64(p.bytes.Load())))
+	if n := p.unknownSize.Load(); n > 0 {
+		summary += fmt.Sprintf("（另有 %d 張無法取得大小）", n)
+	}
+	if n := p.reused.Load(); n > 0 {
+		summary += fmt.Sprintf("，%d 張已下載過", n)
+	}
+	summary += fmt.Sprintf("；預計產生 %d 個文章輸出檔，未寫入任何檔案", p.files.Load())
+	c.logger.Success("%s", summary)
+}
@@ -0,0 +1,109 @@
+package crawler
+
+import (
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/twtrubiks/ptt-spider-go/config"
+	"github.com/twtrubiks/ptt-spider-go/markdown"
+	"github.com/twtrubiks/ptt-spider-go/mocks"
+	"github.com/twtrubiks/ptt-spider-go/types"
+)
+
+func TestDownloadWorker_DryRun(t *testing.T) {
+	t.Chdir(t.TempDir())
+
+	var mu sync.Mutex
+	var methods []string
+	client := &mocks.MockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			mu.Lock()
+			methods = append(methods, req.Method)
+			mu.Unlock()
+			if strings.HasSuffix(req.URL.Path, "missing.jpg") {
+				return newTestResponse(http.StatusMethodNotAllowed), nil
+			}
+			return &http.Response{
+				StatusCode:    http.StatusOK,
+				ContentLength: 1500,
+				Body:          io.NopCloser(strings.NewReader("")),
+			}, nil
+		},
+	}
+	cfg := config.DefaultConfig()
+	cfg.Crawler.Delays = config.DelayConfig{}
+	c := NewCrawlerWithDependencies(client, mocks.NewMockParser(), mocks.NewMockMarkdownGenerator(),
+		"test", 1, 0, "", cfg, WithDryRun(true))
+
+	tasks := []types.DownloadTask{
+		{ImageURL: "https://i.imgur.com/a.jpg", SavePath: filepath.Join("test", "文章_10", "a.jpg")},
+		{ImageURL: "https://i.imgur.com/b.jpg", SavePath: filepath.Join("test", "文章_10", "b.jpg")},
+		{ImageURL: "https://i.imgur.com/missing.jpg", SavePath: filepath.Join("test", "文章_10", "missing.jpg")},
+	}
+	for _, task := range tasks {
+		runDownloadWorker(t, c, task)
+	}
+
+	if _, err := os.Stat("test"); !os.IsNotExist(err) {
+		t.Errorf("試跑模式不應建立目錄，Stat() error = %v", err)
+	}
+	if !slices.Equal(methods, []string{http.MethodHead, http.MethodHead, http.MethodHead}) {
+		t.Errorf("請求方法 = %v, want 只有 HEAD", methods)
+	}
+	if got := c.dryRun.downloads.Load(); got != 3 {
+		t.Errorf("預計下載數 = %d, want 3", got)
+	}
+	if got := c.dryRun.bytes.Load(); got != 3000 {
+		t.Errorf("預估大小 = %d, want 3000", got)
+	}
+	if got := c.dryRun.unknownSize.Load(); got != 1 {
+		t.Errorf("無法取得大小的圖片數 = %d, want 1", got)
+	}
+}
+
+func TestMarkdownWorker_DryRun(t *testing.T) {
+	generated := 0
+	gen := &mocks.MockMarkdownGenerator{
+		GenerateFunc:      func(types.MarkdownInfo) error { generated++; return nil },
+		GenerateIndexFunc: func(string, []types.MarkdownInfo) error { generated++; return nil },
+	}
+	cfg := config.DefaultConfig()
+	cfg.Crawler.Output = config.OutputConfig{Format: config.OutputFormatBoth, Manifest: true, Index: true}
+	c := NewCrawlerWithDependencies(mocks.NewMockHTTPClient(), mocks.NewMockParser(), gen,
+		"test", 1, 0, "", cfg, WithDryRun(true))
+
+	runMarkdownWorker(c,
+		types.MarkdownInfo{Title: "a", ArticleURL: "a", SaveDir: filepath.Join("test", "a_1")},
+		types.MarkdownInfo{Title: "b", ArticleURL: "b", SaveDir: filepath.Join("test", "b_1")},
+	)
+
+	if generated != 0 {
+		t.Errorf("試跑模式不應呼叫 Generate/GenerateIndex，實際呼叫 %d 次", generated)
+	}
+	if got := c.dryRun.files.Load(); got != 6 {
+		t.Errorf("預計輸出檔數 = %d, want 6（2 篇 × README.md、index.html、manifest.json）", got)
+	}
+}
+
+func TestPlannedOutputFiles(t *testing.T) {
+	tests := []struct {
+		output config.OutputConfig
+		want   []string
+	}{
+		{config.OutputConfig{}, []string{markdown.MarkdownFileName}},
+		{config.OutputConfig{Format: config.OutputFormatHTML}, []string{markdown.HTMLFileName}},
+		{config.OutputConfig{Format: config.OutputFormatMarkdown, Manifest: true},
+			[]string{markdown.MarkdownFileName, markdown.ManifestFileName}},
+	}
+	for _, tt := range tests {
+		if got := plannedOutputFiles(tt.output); !slices.Equal(got, tt.want) {
+			t.Errorf("plannedOutputFiles(%+v) = %v, want %v", tt.output, got, tt.want)
+		}
+	}
+}
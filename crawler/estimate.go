@@ -0,0 +1,30 @@
+package crawler
+
+import (
+	"fmt"
+	"strings"
+)
+
+// estimatedArticlesPerPage 估算請求數時每頁列表的文章數（PTT 看板列表每頁約 20 篇）
+const estimatedArticlesPerPage = 20
+
+// CrawlEstimate 是看板模式啟動前估算的爬取範圍，供啟動前確認
+type CrawlEstimate struct {
+	Boards   []string // 要爬取的看板
+	Pages    int      // 每個看板的頁數
+	Requests int      // 預估的 PTT 請求數（首頁、列表頁與文章頁，不含圖片下載）
+}
+
+// EstimateCrawl 估算看板模式的請求數：每個看板查詢最大頁數的首頁一次，
+// 加上 pages 頁列表與每頁約 20 篇文章。實際數量依過濾條件與推文數門檻而減少，為上限估計。
+func EstimateCrawl(board string, pages int) CrawlEstimate {
+	boards := parseBoards(board)
+	perBoard := 1 + pages + pages*estimatedArticlesPerPage
+	return CrawlEstimate{Boards: boards, Pages: pages, Requests: len(boards) * perBoard}
+}
+
+// String 回傳「將爬取 X 看板 Y 頁，預估 Z 請求」格式的說明
+func (e CrawlEstimate) String() string {
+	return fmt.Sprintf("將爬取 %s 看板各 %d 頁，預估 %d 個請求（不含圖片下載）",
+		strings.Join(e.Boards, "、"), e.Pages, e.Requests)
+}
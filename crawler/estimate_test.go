@@ -0,0 +1,14 @@
+package crawler
+
+import "testing"
+
+func TestEstimateCrawl(t *testing.T) {
+	e := EstimateCrawl("Beauty, Gossiping,beauty", 50)
+	// 每個看板：首頁 1 + 列表 50 + 文章 50×20；重複的看板只算一次
+	if want := 2 * (1 + 50 + 1000); e.Requests != want || len(e.Boards) != 2 {
+		t.Errorf("EstimateCrawl = %+v, want %d 個請求、2 個看板", e, want)
+	}
+	if got, want := e.String(), "將爬取 Beauty、Gossiping 看板各 50 頁，預估 2102 個請求（不含圖片下載）"; got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+}
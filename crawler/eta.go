@@ -0,0 +1,177 @@
+package crawler
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/twtrubiks/ptt-spider-go/types"
+)
+
+// progressReportInterval 是定期輸出進度與 ETA 的間隔
+const progressReportInterval = 10 * time.Second
+
+// 進度單位：看板模式以列表頁計算，檔案模式以文章計算
+const (
+	unitPage    = "頁"
+	unitArticle = "篇"
+)
+
+// progressTracker 依已完成的工作量與經過時間估算剩餘時間（ETA）。
+// 工作量分成兩部分：已知總量的單位（看板模式的列表頁、檔案模式的文章），
+// 以及已發現但尚未下載完的圖片；ETA 取兩者中較晚完成的一方。
+// 所有進度事件都經過 emit，因此只需在 emit 中呼叫 observe 即可追蹤。
+type progressTracker struct {
+	mu    sync.Mutex
+	now   func() time.Time
+	start time.Time
+
+	unit        string
+	unitsTotal  int
+	unitsDone   int
+	imagesFound int
+	imagesDone  int
+}
+
+// progressSnapshot 是某一時間點的進度與 ETA
+type progressSnapshot struct {
+	Unit        string
+	UnitsDone   int
+	UnitsTotal  int
+	ImagesDone  int
+	ImagesFound int
+	ETA         time.Duration
+	ETAKnown    bool // 尚無任何完成的工作時無法估算
+}
+
+func newProgressTracker(now func() time.Time) *progressTracker {
+	if now == nil {
+		now = time.Now
+	}
+	return &progressTracker{now: now, start: now()}
+}
+
+// setTotal 設定進度單位與總量（producer 得知總量時呼叫）
+func (p *progressTracker) setTotal(unit string, total int) {
+	if p == nil {
+		return
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.unit = unit
+	p.unitsTotal = total
+}
+
+// addTotal 調整總量，例如看板頁數不足或看板無法爬取時扣除不會執行的頁數
+func (p *progressTracker) addTotal(delta int) {
+	if p == nil {
+		return
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.unitsTotal = max(p.unitsTotal+delta, p.unitsDone)
+}
+
+// addImages 增加待下載的圖片數（重試模式不經過文章解析，直接以失敗明細的張數計算）
+func (p *progressTracker) addImages(n int) {
+	if p == nil {
+		return
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.imagesFound += n
+}
+
+// observe 依進度事件更新已完成的工作量
+func (p *progressTracker) observe(evt types.ProgressEvent) {
+	if p == nil {
+		return
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	switch evt.Type {
+	case types.EventPageParsed:
+		if p.unit == unitPage {
+			p.unitsDone++
+		}
+	case types.EventArticleParsed:
+		if p.unit == unitArticle {
+			p.unitsDone++
+		}
+		p.imagesFound += evt.ImageCount
+	case types.EventDownloadDone, types.EventDownloadFail:
+		p.imagesDone++
+	}
+}
+
+// snapshot 回傳目前進度，ETA 以已處理速率推估剩餘量所需時間
+func (p *progressTracker) snapshot() progressSnapshot {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	snap := progressSnapshot{
+		Unit:        p.unit,
+		UnitsDone:   p.unitsDone,
+		UnitsTotal:  p.unitsTotal,
+		ImagesDone:  p.imagesDone,
+		ImagesFound: p.imagesFound,
+	}
+	elapsed := p.now().Sub(p.start)
+
+	if p.unitsDone > 0 {
+		snap.ETA = estimateRemaining(elapsed, p.unitsDone, p.unitsTotal-p.unitsDone)
+		snap.ETAKnown = true
+	}
+	if p.imagesDone > 0 {
+		snap.ETA = max(snap.ETA, estimateRemaining(elapsed, p.imagesDone, p.imagesFound-p.imagesDone))
+		snap.ETAKnown = true
+	}
+	return snap
+}
+
+// estimateRemaining 以 elapsed 內完成 done 個的速率，推估完成 remaining 個所需時間
+func estimateRemaining(elapsed time.Duration, done, remaining int) time.Duration {
+	if done <= 0 || remaining <= 0 {
+		return 0
+	}
+	return time.Duration(float64(elapsed) / float64(done) * float64(remaining))
+}
+
+// String 回傳供日誌與 TUI 顯示的進度摘要
+func (s progressSnapshot) String() string {
+	msg := fmt.Sprintf("圖片 %d/%d", s.ImagesDone, s.ImagesFound)
+	if s.UnitsTotal > 0 {
+		msg = fmt.Sprintf("%d/%d %s，%s", s.UnitsDone, s.UnitsTotal, s.Unit, msg)
+	}
+	if !s.ETAKnown {
+		return msg + "，預估剩餘時間計算中"
+	}
+	return fmt.Sprintf("%s，預估剩餘 %s", msg, s.ETA.Round(time.Second))
+}
+
+// reportProgress 每隔 interval 輸出一次進度與 ETA（日誌與 EventProgress 事件），
+// 直到 ctx 取消或 stop 關閉。啟用 -progress 時只發送事件，日誌由管線狀態取代。
+func (c *Crawler) reportProgress(ctx context.Context, interval time.Duration, stop <-chan struct{}) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-stop:
+			return
+		case <-ticker.C:
+			snap := c.tracker.snapshot()
+			if c.statusInterval <= 0 {
+				c.logger.Info("進度: %s", snap)
+			}
+			c.emit(types.ProgressEvent{
+				Type:    types.EventProgress,
+				Message: snap.String(),
+				ETA:     snap.ETA,
+			})
+		}
+	}
+}
@@ -0,0 +1,221 @@
+package crawler
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/twtrubiks/ptt-spider-go/config"
+	"github.com/twtrubiks/ptt-spider-go/mocks"
+	"github.com/twtrubiks/ptt-spider-go/types"
+)
+
+// fakeClock 是可手動推進的時鐘
+type fakeClock struct{ t time.Time }
+
+func (f *fakeClock) now() time.Time { return f.t }
+
+func TestProgressTracker_ETA(t *testing.T) {
+	tests := []struct {
+		name      string
+		unit      string
+		total     int
+		events    []types.ProgressEvent
+		elapsed   time.Duration
+		wantETA   time.Duration
+		wantKnown bool
+	}{
+		{
+			name:    "尚無完成的工作時無法估算",
+			unit:    unitPage,
+			total:   10,
+			elapsed: 5 * time.Second,
+		},
+		{
+			name:  "依列表頁速率估算",
+			unit:  unitPage,
+			total: 10,
+			events: []types.ProgressEvent{
+				{Type: types.EventPageParsed},
+				{Type: types.EventPageParsed},
+			},
+			elapsed:   10 * time.Second, // 5s/頁，剩 8 頁
+			wantETA:   40 * time.Second,
+			wantKnown: true,
+		},
+		{
+			name:  "頁面已爬完時由圖片積壓決定",
+			unit:  unitPage,
+			total: 1,
+			events: []types.ProgressEvent{
+				{Type: types.EventPageParsed},
+				{Type: types.EventArticleParsed, ImageCount: 10},
+				{Type: types.EventDownloadDone},
+				{Type: types.EventDownloadFail},
+			},
+			elapsed:   20 * time.Second, // 10s/張，剩 8 張
+			wantETA:   80 * time.Second,
+			wantKnown: true,
+		},
+		{
+			name:  "檔案模式以文章計算",
+			unit:  unitArticle,
+			total: 4,
+			events: []types.ProgressEvent{
+				{Type: types.EventPageParsed}, // 檔案模式不會有，且不應計入
+				{Type: types.EventArticleParsed},
+			},
+			elapsed:   3 * time.Second,
+			wantETA:   9 * time.Second,
+			wantKnown: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clock := &fakeClock{t: time.Unix(0, 0)}
+			p := newProgressTracker(clock.now)
+			p.setTotal(tt.unit, tt.total)
+			for _, evt := range tt.events {
+				p.observe(evt)
+			}
+			clock.t = clock.t.Add(tt.elapsed)
+
+			snap := p.snapshot()
+			if snap.ETAKnown != tt.wantKnown || snap.ETA != tt.wantETA {
+				t.Errorf("ETA = %v (known=%v), want %v (known=%v)", snap.ETA, snap.ETAKnown, tt.wantETA, tt.wantKnown)
+			}
+		})
+	}
+}
+
+func TestProgressTracker_AddTotal(t *testing.T) {
+	p := newProgressTracker(nil)
+	p.setTotal(unitPage, 6)
+	p.observe(types.ProgressEvent{Type: types.EventPageParsed})
+	p.observe(types.ProgressEvent{Type: types.EventPageParsed})
+
+	p.addTotal(-3)
+	if got := p.snapshot().UnitsTotal; got != 3 {
+		t.Errorf("UnitsTotal = %d, want 3", got)
+	}
+	// 總量不會低於已完成量
+	p.addTotal(-10)
+	if got := p.snapshot().UnitsTotal; got != 2 {
+		t.Errorf("UnitsTotal = %d, want 2", got)
+	}
+}
+
+func TestProgressTracker_NilSafe(_ *testing.T) {
+	var p *progressTracker
+	p.setTotal(unitPage, 1)
+	p.addTotal(1)
+	p.observe(types.ProgressEvent{Type: types.EventPageParsed})
+}
+
+func TestProgressSnapshot_String(t *testing.T) {
+	tests := []struct {
+		name string
+		snap progressSnapshot
+		want string
+	}{
+		{
+			name: "已知總量",
+			snap: progressSnapshot{Unit: unitPage, UnitsDone: 2, UnitsTotal: 5, ImagesDone: 3, ImagesFound: 9, ETA: 90*time.Second + 400*time.Millisecond, ETAKnown: true},
+			want: "2/5 頁，圖片 3/9，預估剩餘 1m30s",
+		},
+		{
+			name: "尚無法估算",
+			snap: progressSnapshot{Unit: unitArticle, UnitsTotal: 3},
+			want: "0/3 篇，圖片 0/0，預估剩餘時間計算中",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.snap.String(); got != tt.want {
+				t.Errorf("String() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCountArticleURLs(t *testing.T) {
+	input := strings.Join([]string{
+		"https://www.ptt.cc/bbs/Beauty/M.1.A.ABC.html",
+		"",
+		"# 註解",
+		"not a url",
+		"ptt.cc/bbs/Beauty/M.2.A.DEF.html",
+	}, "\n")
+	if got := countArticleURLs(strings.NewReader(input)); got != 2 {
+		t.Errorf("countArticleURLs() = %d, want 2", got)
+	}
+}
+
+func TestArticleProducerFromFile_SetsTotal(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "urls.txt")
+	content := "https://www.ptt.cc/bbs/Beauty/M.1.A.ABC.html\n# skip\nhttps://www.ptt.cc/bbs/Beauty/M.2.A.DEF.html\n"
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	c := NewCrawlerWithDependencies(mocks.NewMockHTTPClient(), mocks.NewMockParser(), mocks.NewMockMarkdownGenerator(), "", 0, 0, path, config.DefaultConfig())
+	c.tracker = newProgressTracker(nil)
+
+	ch := make(chan types.ArticleInfo, 10)
+	c.produceFromFile(context.Background(), ch)
+
+	n := 0
+	for range ch {
+		n++
+	}
+	snap := c.tracker.snapshot()
+	if snap.Unit != unitArticle || snap.UnitsTotal != 2 || n != 2 {
+		t.Errorf("total = %d %s, sent = %d, want 2 篇 / 2", snap.UnitsTotal, snap.Unit, n)
+	}
+}
+
+func TestReportProgress(t *testing.T) {
+	var mu sync.Mutex
+	var lines []string
+	logger := &mocks.MockLogger{
+		InfoFunc: func(format string, args ...any) {
+			mu.Lock()
+			defer mu.Unlock()
+			lines = append(lines, format)
+		},
+	}
+	progressCh := make(chan types.ProgressEvent, 10)
+	c := NewCrawlerWithDependencies(mocks.NewMockHTTPClient(), mocks.NewMockParser(), mocks.NewMockMarkdownGenerator(), "test", 1, 0, "", config.DefaultConfig(),
+		WithLogger(logger), WithProgress(progressCh))
+	c.tracker = newProgressTracker(nil)
+	c.tracker.setTotal(unitPage, 2)
+
+	stop := make(chan struct{})
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		c.reportProgress(context.Background(), 5*time.Millisecond, stop)
+	}()
+
+	select {
+	case evt := <-progressCh:
+		if evt.Type != types.EventProgress || !strings.Contains(evt.Message, "0/2 頁") {
+			t.Errorf("event = %+v, want EventProgress with page progress", evt)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("未收到 EventProgress")
+	}
+	close(stop)
+	<-done
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(lines) == 0 || lines[0] != "進度: %s" {
+		t.Errorf("Info lines = %v, want 進度 log", lines)
+	}
+}
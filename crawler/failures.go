@@ -0,0 +1,278 @@
+package crawler
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net/http"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/twtrubiks/ptt-spider-go/constants"
+	crawlererrors "github.com/twtrubiks/ptt-spider-go/errors"
+	"github.com/twtrubiks/ptt-spider-go/types"
+)
+
+// failureRecord 是一張下載失敗圖片的明細
+type failureRecord struct {
+	URL        string                      `json:"url"`                  // 圖片 URL
+	SavePath   string                      `json:"savePath"`             // 預計的存檔路徑
+	Board      string                      `json:"board,omitempty"`      // 所屬看板（檔案模式為空）
+	ArticleURL string                      `json:"articleUrl,omitempty"` // 所屬文章 URL
+	Reason     crawlererrors.FailureReason `json:"reason"`               // 失敗原因分類
+	Status     int                         `json:"status,omitempty"`     // HTTP 狀態碼，未取得回應時省略
+	Error      string                      `json:"error"`                // 錯誤訊息
+}
+
+// failureCount 是一種失敗原因的張數
+type failureCount struct {
+	Reason crawlererrors.FailureReason `json:"reason"`
+	Label  string                      `json:"label"` // 原因的中文說明
+	Count  int                         `json:"count"`
+}
+
+// failureReport 是 failures.report 匯出的 JSON 內容
+type failureReport struct {
+	Total    int             `json:"total"`    // 下載失敗的圖片數
+	Reasons  []failureCount  `json:"reasons"`  // 依張數由多到少排列的原因統計
+	Failures []failureRecord `json:"failures"` // 失敗明細，依發生順序排列
+}
+
+// failureLog 累計本次執行中所有圖片下載失敗的明細，可供多個下載工人並行記錄
+type failureLog struct {
+	mu      sync.Mutex
+	records []failureRecord
+}
+
+// add 記錄一筆失敗
+func (l *failureLog) add(r failureRecord) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.records = append(l.records, r)
+}
+
+// snapshot 回傳目前所有失敗明細的複本
+func (l *failureLog) snapshot() []failureRecord {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return slices.Clone(l.records)
+}
+
+// countFailures 依原因統計張數，由多到少排列，張數相同時依原因名稱排列
+func countFailures(records []failureRecord) []failureCount {
+	counts := make(map[crawlererrors.FailureReason]int)
+	for _, r := range records {
+		counts[r.Reason]++
+	}
+	result := make([]failureCount, 0, len(counts))
+	for reason, n := range counts {
+		result = append(result, failureCount{Reason: reason, Label: reason.Label(), Count: n})
+	}
+	slices.SortFunc(result, func(a, b failureCount) int {
+		return cmp.Or(b.Count-a.Count, cmp.Compare(a.Reason, b.Reason))
+	})
+	return result
+}
+
+// recordFailure 記錄一張下載失敗的圖片：原因分類與狀態碼取自 err 的 CrawlerError.Context，
+// 同時計入指標收集器的 DownloadFailures
+func (c *Crawler) recordFailure(task types.DownloadTask, err error) {
+	reason := crawlererrors.DownloadFailureReason(err)
+	if c.metrics != nil {
+		c.metrics.RecordDownloadFailure(string(reason))
+	}
+	c.failures.add(failureRecord{
+		URL:        task.ImageURL,
+		SavePath:   task.SavePath,
+		Board:      task.Board,
+		ArticleURL: task.ArticleURL,
+		Reason:     reason,
+		Status:     crawlererrors.DownloadStatus(err),
+		Error:      err.Error(),
+	})
+}
+
+// downloadFailed 以指定的原因記錄一張下載失敗的圖片；中斷造成的失敗（如讀取回應時 context 被取消）不記錄
+func (c *Crawler) downloadFailed(task types.DownloadTask, reason crawlererrors.FailureReason, message string, cause error) {
+	if errors.Is(cause, context.Canceled) {
+		return
+	}
+	c.recordFailure(task, crawlererrors.NewDownloadError(reason, message, cause))
+}
+
+// fetchFailure 將 GET 失敗（請求錯誤或非 200 回應）包裝為帶原因分類與狀態碼的下載錯誤，
+// 429 重試用盡時沒有回應，仍視為被限流
+func fetchFailure(resp *http.Response, err error) *crawlererrors.CrawlerError {
+	switch {
+	case errors.Is(err, errRetryExhausted):
+		return crawlererrors.NewDownloadError(crawlererrors.FailureRateLimited, "下載失敗", err).
+			WithContext(crawlererrors.ContextKeyStatus, http.StatusTooManyRequests)
+	case err != nil:
+		return crawlererrors.NewDownloadError(crawlererrors.ClassifyDownloadFailure(0, err), "下載失敗", err)
+	default:
+		return crawlererrors.NewDownloadError(crawlererrors.ClassifyDownloadFailure(resp.StatusCode, nil),
+			fmt.Sprintf("狀態碼 %d", resp.StatusCode), nil).
+			WithContext(crawlererrors.ContextKeyStatus, resp.StatusCode)
+	}
+}
+
+// formatFailureCounts 輸出如 "圖片已刪除(not_found)=3, 被限流(rate_limited)=1"
+func formatFailureCounts(counts []failureCount) string {
+	parts := make([]string, len(counts))
+	for i, fc := range counts {
+		parts[i] = fmt.Sprintf("%s(%s)=%d", fc.Label, fc.Reason, fc.Count)
+	}
+	return strings.Join(parts, ", ")
+}
+
+// logFailures 在爬蟲結束時輸出下載失敗的原因摘要，並依 failures.report 匯出明細、
+// 依 failures.retryFile 寫出重跑清單；沒有失敗時不輸出。
+// 重試模式即使全部成功也會匯出（total 為 0），避免再次重試時讀到舊的明細。
+func (c *Crawler) logFailures() {
+	records := c.failures.snapshot()
+	c.writeRetryList(records)
+	if len(records) == 0 && c.retryFailures == "" {
+		return
+	}
+	counts := countFailures(records)
+	if len(records) > 0 {
+		c.logger.Warn("下載失敗 %d 張，依原因: %s", len(records), formatFailureCounts(counts))
+	}
+
+	if path := c.config.Crawler.Failures.Report; path != "" {
+		if err := writeFailureReport(path, failureReport{Total: len(records), Reasons: counts, Failures: records}); err != nil {
+			c.logger.Error("匯出下載失敗明細失敗: %v", err)
+		} else {
+			c.logger.Info("下載失敗明細已匯出: %s", path)
+		}
+	}
+}
+
+// writeRetryList 將有圖片下載失敗的文章網址寫入 failures.retryFile。
+// 本次沒有需要重跑的文章時刪除先前執行留下的清單，避免之後以 -file 重跑到過期的網址（試跑模式不寫入也不刪除）。
+func (c *Crawler) writeRetryList(records []failureRecord) {
+	path := c.config.Crawler.Failures.RetryFile
+	if path == "" || c.dryRun != nil {
+		return
+	}
+	urls := retryArticleURLs(records)
+	if len(urls) == 0 {
+		if err := os.Remove(path); err == nil {
+			c.logger.Info("本次沒有需要重跑的文章，已刪除先前的重跑清單: %s", path)
+		} else if !errors.Is(err, fs.ErrNotExist) {
+			c.logger.Error("刪除先前的重跑清單失敗: %v", err)
+		}
+		return
+	}
+	if err := writeRetryFile(path, urls); err != nil {
+		c.logger.Error("寫出重跑清單失敗: %v", err)
+		return
+	}
+	c.logger.Info("%d 篇文章有圖片下載失敗，重跑清單已寫出: %s（以 -file %s 重跑）", len(urls), path, path)
+}
+
+// retryArticleURLs 取出失敗明細所屬的文章網址，依第一次失敗的順序排列並去除重複；沒有文章網址的明細略過
+func retryArticleURLs(records []failureRecord) []string {
+	seen := make(map[string]bool, len(records))
+	var urls []string
+	for _, r := range records {
+		if r.ArticleURL == "" || seen[r.ArticleURL] {
+			continue
+		}
+		seen[r.ArticleURL] = true
+		urls = append(urls, r.ArticleURL)
+	}
+	return urls
+}
+
+// readFailureReport 讀取 failures.report 匯出的失敗明細
+func readFailureReport(path string) (failureReport, error) {
+	var r failureReport
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return r, crawlererrors.NewFileError("讀取失敗明細失敗", err)
+	}
+	if err := json.Unmarshal(data, &r); err != nil {
+		return r, crawlererrors.NewParseError(fmt.Sprintf("解析失敗明細失敗: %s", path), err)
+	}
+	return r, nil
+}
+
+// retryTasks 將失敗明細還原為下載任務：缺少 URL 或存檔路徑的明細無法重試，
+// 同一存檔路徑只保留第一筆；回傳略過的筆數
+func retryTasks(records []failureRecord) (tasks []types.DownloadTask, skipped int) {
+	seen := make(map[string]bool, len(records))
+	for _, r := range records {
+		if r.URL == "" || r.SavePath == "" || seen[r.SavePath] {
+			skipped++
+			continue
+		}
+		seen[r.SavePath] = true
+		tasks = append(tasks, types.DownloadTask{
+			ImageURL:   r.URL,
+			SavePath:   r.SavePath,
+			Board:      r.Board,
+			ArticleURL: r.ArticleURL,
+		})
+	}
+	return tasks, skipped
+}
+
+// produceFromFailures 是重試模式的生產者：讀取失敗明細，將圖片直接排入下載佇列，不重新解析文章。
+// 文章 channel 在全部排入後才關閉：解析器隨即結束，waitAndCleanup 等解析器結束後才關閉下載佇列，
+// 因此不會對已關閉的佇列送出任務。
+func (c *Crawler) produceFromFailures(ctx context.Context, articleInfoChan chan<- types.ArticleInfo, downloadTaskChan chan<- types.DownloadTask) {
+	defer close(articleInfoChan)
+
+	report, err := readFailureReport(c.retryFailures)
+	if err != nil {
+		c.logger.Error("%v", err)
+		c.producerErr = err
+		return
+	}
+	tasks, skipped := retryTasks(report.Failures)
+	if skipped > 0 {
+		c.logger.Warn("失敗明細中有 %d 筆缺少 URL、存檔路徑或重複，已略過", skipped)
+	}
+	c.logger.Info("重試 %s 中的 %d 張圖片", c.retryFailures, len(tasks))
+	c.tracker.addImages(len(tasks))
+
+	for _, task := range tasks {
+		select {
+		case downloadTaskChan <- task:
+		case <-ctx.Done():
+			c.logger.Warn("重試下載被中斷")
+			return
+		}
+	}
+}
+
+// writeFailureReport 將失敗明細寫入 path（JSON），必要時建立上層目錄
+func writeFailureReport(path string, r failureReport) error {
+	data, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return err
+	}
+	return writeFailureFile(path, append(data, '\n'))
+}
+
+// writeRetryFile 將文章網址以 -file 可讀取的格式（每行一個）寫入 path
+func writeRetryFile(path string, urls []string) error {
+	return writeFailureFile(path, []byte(strings.Join(urls, "\n")+"\n"))
+}
+
+// writeFailureFile 將 data 寫入 path，必要時建立上層目錄
+func writeFailureFile(path string, data []byte) error {
+	if dir := filepath.Dir(path); dir != "." {
+		if err := os.MkdirAll(dir, constants.DirPermission); err != nil {
+			return err
+		}
+	}
+	return os.WriteFile(path, data, constants.FilePermission)
+}
@@ -0,0 +1,280 @@
+package crawler
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/twtrubiks/ptt-spider-go/config"
+	crawlererrors "github.com/twtrubiks/ptt-spider-go/errors"
+	"github.com/twtrubiks/ptt-spider-go/metrics"
+	"github.com/twtrubiks/ptt-spider-go/mocks"
+	"github.com/twtrubiks/ptt-spider-go/types"
+)
+
+func TestFetchFailure(t *testing.T) {
+	tests := []struct {
+		name       string
+		resp       *http.Response
+		err        error
+		wantReason crawlererrors.FailureReason
+		wantStatus int
+	}{
+		{"404", &http.Response{StatusCode: http.StatusNotFound}, nil, crawlererrors.FailureNotFound, http.StatusNotFound},
+		{"502", &http.Response{StatusCode: http.StatusBadGateway}, nil, crawlererrors.FailureServerError, http.StatusBadGateway},
+		{"429 重試用盡", nil, fmt.Errorf("重試 3 次後仍收到 429: %w", errRetryExhausted), crawlererrors.FailureRateLimited, http.StatusTooManyRequests},
+		{"逾時", nil, crawlererrors.NewClassifiedNetworkError("請求失敗", context.DeadlineExceeded), crawlererrors.FailureTimeout, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := fetchFailure(tt.resp, tt.err)
+			if got := crawlererrors.DownloadFailureReason(err); got != tt.wantReason {
+				t.Errorf("reason = %q, want %q", got, tt.wantReason)
+			}
+			if got := crawlererrors.DownloadStatus(err); got != tt.wantStatus {
+				t.Errorf("status = %d, want %d", got, tt.wantStatus)
+			}
+		})
+	}
+}
+
+func TestCountFailures(t *testing.T) {
+	records := []failureRecord{
+		{Reason: crawlererrors.FailureTimeout},
+		{Reason: crawlererrors.FailureNotFound},
+		{Reason: crawlererrors.FailureRateLimited},
+		{Reason: crawlererrors.FailureNotFound},
+	}
+	got := formatFailureCounts(countFailures(records))
+	// 張數多的在前，張數相同時依原因名稱排列
+	if want := "圖片已刪除(not_found)=2, 被限流(rate_limited)=1, 逾時(timeout)=1"; got != want {
+		t.Errorf("formatFailureCounts = %q, want %q", got, want)
+	}
+}
+
+// TestDownloadOne_RecordsFailures 驗證下載失敗依原因分類記錄明細、計入指標並可匯出
+func TestDownloadOne_RecordsFailures(t *testing.T) {
+	dir := t.TempDir()
+	client := &mocks.MockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			resp := &http.Response{StatusCode: http.StatusOK, Header: http.Header{}, Body: io.NopCloser(strings.NewReader("")), Request: req}
+			switch req.URL.Path {
+			case "/gone.jpg":
+				resp.StatusCode = http.StatusNotFound
+			case "/removed.jpg":
+				resp.Header.Set("Content-Type", "text/html")
+				resp.Body = io.NopCloser(strings.NewReader("<html>圖片已移除</html>"))
+			default:
+				resp.Body = io.NopCloser(strings.NewReader(pngHeader))
+			}
+			return resp, nil
+		},
+	}
+	cfg := config.DefaultConfig()
+	cfg.Crawler.Delays = config.DelayConfig{MinMs: 0, MaxMs: 0}
+	cfg.Crawler.ValidateImages = true
+	cfg.Crawler.Failures.Report = filepath.Join(dir, "report", "failures.json")
+	collector := metrics.NewCollector()
+	var warnings []string
+	logger := &mocks.MockLogger{WarnFunc: func(format string, args ...any) { warnings = append(warnings, fmt.Sprintf(format, args...)) }}
+	c := NewCrawlerWithDependencies(client, mocks.NewMockParser(), mocks.NewMockMarkdownGenerator(),
+		"test", 1, 0, "", cfg, WithLogger(logger), WithMetrics(collector))
+
+	for _, name := range []string{"gone.jpg", "removed.jpg", "ok.png"} {
+		c.downloadOne(context.Background(), 1, types.DownloadTask{
+			ImageURL:   "https://i.imgur.com/" + name,
+			SavePath:   filepath.Join(dir, name),
+			Board:      "Beauty",
+			ArticleURL: "https://www.ptt.cc/bbs/Beauty/M.1.A.AAA.html",
+		})
+	}
+
+	snap := collector.Snapshot()
+	if snap.DownloadFailures["not_found"] != 1 || snap.DownloadFailures["invalid_image"] != 1 || len(snap.DownloadFailures) != 2 {
+		t.Errorf("DownloadFailures = %v", snap.DownloadFailures)
+	}
+
+	warnings = nil
+	c.logFailures()
+	if len(warnings) != 1 || !strings.Contains(warnings[0], "下載失敗 2 張") || !strings.Contains(warnings[0], "圖片已刪除(not_found)=1") {
+		t.Errorf("摘要 = %q", warnings)
+	}
+
+	data, err := os.ReadFile(cfg.Crawler.Failures.Report)
+	if err != nil {
+		t.Fatalf("應匯出失敗明細: %v", err)
+	}
+	var report failureReport
+	if err := json.Unmarshal(data, &report); err != nil {
+		t.Fatalf("解析失敗明細: %v", err)
+	}
+	if report.Total != 2 || len(report.Reasons) != 2 || len(report.Failures) != 2 {
+		t.Fatalf("report = %+v", report)
+	}
+	gone := report.Failures[0]
+	if gone.URL != "https://i.imgur.com/gone.jpg" || gone.Reason != crawlererrors.FailureNotFound ||
+		gone.Status != http.StatusNotFound || gone.Board != "Beauty" || gone.SavePath != filepath.Join(dir, "gone.jpg") {
+		t.Errorf("failures[0] = %+v", gone)
+	}
+	if removed := report.Failures[1]; removed.Reason != crawlererrors.FailureInvalidImage || !strings.Contains(removed.Error, "text/html") {
+		t.Errorf("failures[1] = %+v", removed)
+	}
+}
+
+// TestFetchImage_CanceledNotRecorded 驗證中斷（ctx 取消）後的下載失敗不記入失敗明細
+func TestFetchImage_CanceledNotRecorded(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	client := &mocks.MockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			// 請求送出後才收到中斷，回應仍是失敗狀態碼
+			cancel()
+			return &http.Response{StatusCode: http.StatusServiceUnavailable, Body: io.NopCloser(strings.NewReader("")), Request: req}, nil
+		},
+	}
+	collector := metrics.NewCollector()
+	c := NewCrawlerWithDependencies(client, mocks.NewMockParser(), mocks.NewMockMarkdownGenerator(),
+		"test", 1, 0, "", config.DefaultConfig(), WithLogger(&mocks.MockLogger{}), WithMetrics(collector))
+
+	if resp := c.fetchImage(ctx, 1, types.DownloadTask{ImageURL: "https://i.imgur.com/a.jpg"}); resp != nil {
+		t.Fatal("下載失敗時應回傳 nil")
+	}
+	c.downloadFailed(types.DownloadTask{ImageURL: "https://i.imgur.com/b.jpg"},
+		crawlererrors.FailureTimeout, "讀取回應失敗", context.Canceled)
+
+	if got := c.failures.snapshot(); len(got) != 0 {
+		t.Errorf("中斷造成的失敗不應記錄, got %+v", got)
+	}
+	if got := collector.Snapshot().DownloadFailures; len(got) != 0 {
+		t.Errorf("中斷造成的失敗不應計入指標, got %v", got)
+	}
+}
+
+func TestLogFailures_NoFailures(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "failures.json")
+	cfg := config.DefaultConfig()
+	cfg.Crawler.Failures.Report = path
+	c := NewCrawlerWithDependencies(&mocks.MockHTTPClient{}, mocks.NewMockParser(), mocks.NewMockMarkdownGenerator(),
+		"test", 1, 0, "", cfg, WithLogger(&mocks.MockLogger{}))
+	c.logFailures()
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Errorf("沒有失敗時不應匯出明細, err = %v", err)
+	}
+}
+
+// TestLogFailures_RemovesStaleRetryFile 驗證本次沒有失敗時刪除先前執行留下的重跑清單
+func TestLogFailures_RemovesStaleRetryFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "failures.txt")
+	if err := os.WriteFile(path, []byte("https://www.ptt.cc/bbs/Beauty/M.1.A.AAA.html\n"), 0644); err != nil {
+		t.Fatalf("建立舊的重跑清單失敗: %v", err)
+	}
+	cfg := config.DefaultConfig()
+	cfg.Crawler.Failures.RetryFile = path
+	c := NewCrawlerWithDependencies(&mocks.MockHTTPClient{}, mocks.NewMockParser(), mocks.NewMockMarkdownGenerator(),
+		"test", 1, 0, "", cfg, WithLogger(&mocks.MockLogger{}))
+	c.logFailures()
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Errorf("沒有失敗時應刪除舊的重跑清單, err = %v", err)
+	}
+}
+
+func TestLogFailures_RetryFile(t *testing.T) {
+	dir := t.TempDir()
+	cfg := config.DefaultConfig()
+	cfg.Crawler.Failures.RetryFile = filepath.Join(dir, "retry", "failures.txt")
+	c := NewCrawlerWithDependencies(&mocks.MockHTTPClient{}, mocks.NewMockParser(), mocks.NewMockMarkdownGenerator(),
+		"test", 1, 0, "", cfg, WithLogger(&mocks.MockLogger{}))
+
+	const a, b = "https://www.ptt.cc/bbs/Beauty/M.1.A.AAA.html", "https://www.ptt.cc/bbs/Beauty/M.2.A.BBB.html"
+	for _, articleURL := range []string{b, a, b, ""} {
+		c.downloadFailed(types.DownloadTask{ImageURL: "https://i.imgur.com/x.jpg", ArticleURL: articleURL},
+			crawlererrors.FailureTimeout, "下載失敗", nil)
+	}
+	c.logFailures()
+
+	data, err := os.ReadFile(cfg.Crawler.Failures.RetryFile)
+	if err != nil {
+		t.Fatalf("應寫出重跑清單: %v", err)
+	}
+	if want := b + "\n" + a + "\n"; string(data) != want {
+		t.Errorf("重跑清單 = %q, want %q", data, want)
+	}
+
+	// 重跑清單可直接以檔案模式讀回
+	for line := range strings.Lines(string(data)) {
+		if _, ok := normalizeArticleURL(line); !ok {
+			t.Errorf("-file 無法讀取 %q", line)
+		}
+	}
+}
+
+// TestRun_RetryFailures 驗證重試模式只重新下載失敗明細中的圖片，並將仍失敗的寫回明細
+func TestRun_RetryFailures(t *testing.T) {
+	dir := t.TempDir()
+	reportPath := filepath.Join(dir, "failures.json")
+	previous := failureReport{Total: 3, Failures: []failureRecord{
+		{URL: "https://i.imgur.com/ok.png", SavePath: filepath.Join(dir, "Beauty", "ok.png"), Board: "Beauty",
+			ArticleURL: "https://www.ptt.cc/bbs/Beauty/M.1.A.AAA.html", Reason: crawlererrors.FailureTimeout},
+		{URL: "https://i.imgur.com/gone.jpg", SavePath: filepath.Join(dir, "Beauty", "gone.jpg"), Board: "Beauty",
+			ArticleURL: "https://www.ptt.cc/bbs/Beauty/M.1.A.AAA.html", Reason: crawlererrors.FailureServerError},
+		{URL: "https://i.imgur.com/nopath.jpg", Reason: crawlererrors.FailureTimeout},
+	}}
+	if err := writeFailureReport(reportPath, previous); err != nil {
+		t.Fatal(err)
+	}
+
+	var requested []string
+	client := &mocks.MockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			requested = append(requested, req.URL.String())
+			if req.URL.Path == "/gone.jpg" {
+				return &http.Response{StatusCode: http.StatusNotFound, Header: http.Header{}, Body: io.NopCloser(strings.NewReader("")), Request: req}, nil
+			}
+			return &http.Response{StatusCode: http.StatusOK, Header: http.Header{}, Body: io.NopCloser(strings.NewReader(pngHeader)), Request: req}, nil
+		},
+	}
+	parser := &mocks.MockParser{ParseArticlesFunc: func(io.Reader) ([]types.ArticleInfo, error) {
+		t.Error("重試模式不應解析文章列表")
+		return nil, nil
+	}}
+	cfg := config.DefaultConfig()
+	cfg.Crawler.Delays = config.DelayConfig{}
+	cfg.Crawler.Workers = config.FixedWorkers(1) // 單一工人，requested 不需加鎖
+	cfg.Crawler.DownloadMode = config.DownloadModeArticle
+	cfg.Crawler.Failures.Report = reportPath
+	c := NewCrawlerWithDependencies(client, parser, mocks.NewMockMarkdownGenerator(), "Beauty", 1, 0, "", cfg,
+		WithLogger(&mocks.MockLogger{}), WithRetryFailures(reportPath))
+
+	if err := c.Run(context.Background()); err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	if len(requested) != 2 {
+		t.Errorf("requested = %v, want 只重試兩張有存檔路徑的圖片", requested)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "Beauty", "ok.png")); err != nil {
+		t.Errorf("重試成功的圖片應存檔: %v", err)
+	}
+
+	report, err := readFailureReport(reportPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if report.Total != 1 || len(report.Failures) != 1 || report.Failures[0].URL != "https://i.imgur.com/gone.jpg" ||
+		report.Failures[0].Reason != crawlererrors.FailureNotFound {
+		t.Errorf("重試後的明細 = %+v, want 只剩 gone.jpg（not_found）", report)
+	}
+
+	t.Run("明細不存在", func(t *testing.T) {
+		c := NewCrawlerWithDependencies(mocks.NewMockHTTPClient(), mocks.NewMockParser(), mocks.NewMockMarkdownGenerator(),
+			"Beauty", 1, 0, "", config.DefaultConfig(), WithLogger(&mocks.MockLogger{}),
+			WithRetryFailures(filepath.Join(dir, "missing.json")))
+		if err := c.Run(context.Background()); err == nil || !strings.Contains(err.Error(), "讀取失敗明細失敗") {
+			t.Errorf("Run() error = %v, want 讀取失敗明細失敗", err)
+		}
+	})
+}
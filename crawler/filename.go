@@ -0,0 +1,78 @@
+package crawler
+
+import (
+	"path/filepath"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// maxFileNameBytes 是清理後檔名的位元組上限。多數檔案系統單一路徑元件的上限為 255 bytes，
+// 中文一個字佔 3 bytes，長標題很容易超過；保留空間給呼叫端附加的推文數與碰撞序號後綴。
+const maxFileNameBytes = 200
+
+// maxExtensionBytes 是截斷時視為副檔名保留的長度上限，超過時（如標題中的 "..." 之後的文字）不視為副檔名
+const maxExtensionBytes = 10
+
+// windowsReservedNames 是 Windows 保留的裝置名稱，不分大小寫，加上任何副檔名（如 con.txt）同樣無法建立
+var windowsReservedNames = map[string]bool{
+	"CON": true, "PRN": true, "AUX": true, "NUL": true,
+	"COM1": true, "COM2": true, "COM3": true, "COM4": true, "COM5": true, "COM6": true, "COM7": true, "COM8": true, "COM9": true,
+	"LPT1": true, "LPT2": true, "LPT3": true, "LPT4": true, "LPT5": true, "LPT6": true, "LPT7": true, "LPT8": true, "LPT9": true,
+}
+
+// cleanFileName 將文章標題等字串清理為各平台都能使用的檔名：
+// 移除非法字元與控制字元、截斷至 maxFileNameBytes（保留副檔名，不切斷 UTF-8 字元）、
+// 去除結尾的句點與空白（Windows 會自動去除而造成路徑不一致），並在 Windows 保留名稱後加上底線
+func cleanFileName(name string) string {
+	name = invalidChars.ReplaceAllString(name, "")
+	name = strings.Map(func(r rune) rune {
+		if unicode.IsControl(r) {
+			return -1
+		}
+		return r
+	}, name)
+	name = truncateFileName(name, maxFileNameBytes)
+	name = strings.TrimRight(name, ". ")
+
+	// 保留名稱以第一個句點前的部分判斷，Windows 同樣忽略其結尾空白
+	base, rest, hasExt := strings.Cut(name, ".")
+	if windowsReservedNames[strings.ToUpper(strings.TrimRight(base, " "))] {
+		name = base + "_"
+		if hasExt {
+			name += "." + rest
+		}
+	}
+	return name
+}
+
+// truncateFileName 將 name 截斷至 limit bytes 以內：短的英數字副檔名保留，其餘從主檔名結尾截斷，
+// 截斷位置退到 UTF-8 字元的開頭
+func truncateFileName(name string, limit int) string {
+	if len(name) <= limit {
+		return name
+	}
+	ext := filepath.Ext(name)
+	if !isShortExtension(ext) || len(ext) >= limit {
+		ext = ""
+	}
+	stem := name[:len(name)-len(ext)]
+	cut := limit - len(ext)
+	for cut > 0 && !utf8.RuneStart(stem[cut]) {
+		cut--
+	}
+	return stem[:cut] + ext
+}
+
+// isShortExtension 回傳 ext 是否像真正的副檔名（如 ".jpg"）：不超過 maxExtensionBytes 且只含 ASCII 英數字
+func isShortExtension(ext string) bool {
+	if len(ext) < 2 || len(ext) > maxExtensionBytes {
+		return false
+	}
+	for _, r := range ext[1:] {
+		if r > unicode.MaxASCII || !(unicode.IsLetter(r) || unicode.IsDigit(r)) {
+			return false
+		}
+	}
+	return true
+}
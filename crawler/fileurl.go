@@ -0,0 +1,183 @@
+package crawler
+
+import (
+	"bufio"
+	"cmp"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/url"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/twtrubiks/ptt-spider-go/constants"
+	"github.com/twtrubiks/ptt-spider-go/types"
+)
+
+// FileURLStdin 是 -file 的特殊值，表示從標準輸入讀取文章網址（如 grep ... | ptt-spider -file=-）
+const FileURLStdin = "-"
+
+// articlePathPattern 比對 PTT 文章路徑，如 /bbs/Beauty/M.1234567890.A.ABC.html
+var articlePathPattern = regexp.MustCompile(`^/bbs/[A-Za-z0-9_-]+/[MG]\.\d+\.A(\.[0-9A-Fa-f]{3})?\.html$`)
+
+// urlQuotes 是使用者複製貼上時常見的包覆字元
+const urlQuotes = "\"'`“”‘’「」『』<>"
+
+// normalizeArticleURL 修正檔案模式中手動貼上的 URL 並驗證是否為 PTT 文章網址。
+// 會去除前後空白與引號、補上缺少的 https://、將 http 與 ptt.cc 統一為 https://www.ptt.cc，
+// 並移除 query 與 fragment。無法修正為合法文章網址時回傳 false。
+func normalizeArticleURL(line string) (string, bool) {
+	s := strings.TrimSpace(line)
+	for prev := ""; s != prev; {
+		prev = s
+		s = strings.TrimSpace(strings.Trim(s, urlQuotes))
+	}
+	if s == "" || strings.ContainsAny(s, " \t") {
+		return "", false
+	}
+
+	switch {
+	case strings.HasPrefix(s, "//"):
+		s = "https:" + s
+	case !strings.Contains(s, "://"):
+		s = "https://" + s
+	}
+
+	u, err := url.Parse(s)
+	if err != nil {
+		return "", false
+	}
+	if scheme := strings.ToLower(u.Scheme); scheme != "https" && scheme != "http" {
+		return "", false
+	}
+	if host := strings.ToLower(u.Host); host != "www.ptt.cc" && host != "ptt.cc" {
+		return "", false
+	}
+	if !articlePathPattern.MatchString(u.Path) {
+		return "", false
+	}
+	return constants.PttBaseURL + u.Path, true
+}
+
+// countArticleURLs 計算 r 中有效的文章網址行數（略過空行、# 註解與無效網址），
+// 與 articleProducerFromFile 實際送出的文章數一致，用於估算檔案模式的 ETA。
+func countArticleURLs(r io.Reader) int {
+	n := 0
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if _, ok := normalizeArticleURL(line); ok {
+			n++
+		}
+	}
+	return n
+}
+
+// 檔案模式中帶有文章資訊的清單格式，依副檔名判斷（不分大小寫），其他副檔名逐行讀取網址
+const (
+	fileListCSV  = ".csv"
+	fileListJSON = ".json"
+)
+
+// fileListFormat 回傳 path 的清單格式，不是 CSV/JSON 時回傳空字串
+func fileListFormat(path string) string {
+	switch ext := strings.ToLower(filepath.Ext(path)); ext {
+	case fileListCSV, fileListJSON:
+		return ext
+	default:
+		return ""
+	}
+}
+
+// fileListEntry 是 JSON 清單中的一筆文章；推文數接受 pushRate 與匯出格式的 push_rate 兩種鍵
+type fileListEntry struct {
+	URL          string `json:"url"`
+	Title        string `json:"title"`
+	PushRate     *int   `json:"pushRate"`
+	PushRateJSON *int   `json:"push_rate"`
+}
+
+// parseArticleList 解析 CSV 或 JSON 清單為文章資訊，網址以 normalizeArticleURL 修正，
+// 無效的網址或推文數以 warn 記錄後略過該筆（推文數無效時只略過該欄位）。
+// CSV 第一列為標題列，需有 url 欄位，title、pushRate 欄位可選（欄位名稱不分大小寫）；
+// JSON 為物件陣列，如 [{"url": "...", "title": "...", "pushRate": 30}]。
+func parseArticleList(r io.Reader, format string, warn func(format string, args ...any)) ([]types.ArticleInfo, error) {
+	if format == fileListJSON {
+		var entries []fileListEntry
+		if err := json.NewDecoder(r).Decode(&entries); err != nil {
+			return nil, fmt.Errorf("解析 JSON 清單失敗: %w", err)
+		}
+		articles := make([]types.ArticleInfo, 0, len(entries))
+		for i, e := range entries {
+			articleURL, ok := normalizeArticleURL(e.URL)
+			if !ok {
+				warn("第 %d 筆不是有效的 PTT 文章網址，已略過: %s", i+1, e.URL)
+				continue
+			}
+			article := types.ArticleInfo{URL: articleURL, Title: strings.TrimSpace(e.Title)}
+			if pushRate := cmp.Or(e.PushRate, e.PushRateJSON); pushRate != nil {
+				article.PushRate = *pushRate
+			}
+			articles = append(articles, article)
+		}
+		return articles, nil
+	}
+
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1 // 容許各列欄位數不同，缺少的欄位視為空白
+	reader.TrimLeadingSpace = true
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("讀取 CSV 標題列失敗: %w", err)
+	}
+	columns := map[string]int{"url": -1, "title": -1, "pushrate": -1}
+	for i, name := range header {
+		// Excel 匯出的 CSV 開頭可能帶有 UTF-8 BOM
+		name = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))
+		name = strings.ReplaceAll(name, "_", "") // push_rate 與 pushRate 視為相同
+		if _, known := columns[name]; known && columns[name] < 0 {
+			columns[name] = i
+		}
+	}
+	if columns["url"] < 0 {
+		return nil, fmt.Errorf("CSV 清單缺少 url 欄位（標題列: %s）", strings.Join(header, ","))
+	}
+	field := func(record []string, name string) string {
+		if i := columns[name]; i >= 0 && i < len(record) {
+			return strings.TrimSpace(record[i])
+		}
+		return ""
+	}
+
+	var articles []types.ArticleInfo
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			return articles, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("解析 CSV 清單失敗: %w", err)
+		}
+		line, _ := reader.FieldPos(0)
+		articleURL, ok := normalizeArticleURL(field(record, "url"))
+		if !ok {
+			warn("第 %d 行不是有效的 PTT 文章網址，已略過: %s", line, field(record, "url"))
+			continue
+		}
+		article := types.ArticleInfo{URL: articleURL, Title: field(record, "title")}
+		if s := field(record, "pushrate"); s != "" {
+			if n, err := strconv.Atoi(s); err == nil {
+				article.PushRate = n
+			} else {
+				warn("第 %d 行的推文數 %q 不是整數，以 0 計", line, s)
+			}
+		}
+		articles = append(articles, article)
+	}
+}
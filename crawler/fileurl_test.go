@@ -0,0 +1,181 @@
+package crawler
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/twtrubiks/ptt-spider-go/config"
+	"github.com/twtrubiks/ptt-spider-go/mocks"
+	"github.com/twtrubiks/ptt-spider-go/types"
+)
+
+func TestNormalizeArticleURL(t *testing.T) {
+	const want = "https://www.ptt.cc/bbs/Beauty/M.1234567890.A.ABC.html"
+
+	tests := []struct {
+		name   string
+		input  string
+		want   string
+		wantOK bool
+	}{
+		{"標準網址", want, want, true},
+		{"前後空白", "  " + want + "\t", want, true},
+		{"雙引號", `"` + want + `"`, want, true},
+		{"單引號與外層空白", " '" + want + "' ", want, true},
+		{"全形引號", "「" + want + "」", want, true},
+		{"多層包覆", `"<` + want + `>"`, want, true},
+		{"缺少協定", "www.ptt.cc/bbs/Beauty/M.1234567890.A.ABC.html", want, true},
+		{"缺少 www", "ptt.cc/bbs/Beauty/M.1234567890.A.ABC.html", want, true},
+		{"協定相對網址", "//www.ptt.cc/bbs/Beauty/M.1234567890.A.ABC.html", want, true},
+		{"http 升級為 https", "http://www.ptt.cc/bbs/Beauty/M.1234567890.A.ABC.html", want, true},
+		{"大寫 host", "https://WWW.PTT.CC/bbs/Beauty/M.1234567890.A.ABC.html", want, true},
+		{"移除 query 與 fragment", want + "?utm_source=x#push", want, true},
+		{"無雜湊後綴的舊格式", "https://www.ptt.cc/bbs/Beauty/M.111.A.html", "https://www.ptt.cc/bbs/Beauty/M.111.A.html", true},
+		{"空字串", "", "", false},
+		{"只有引號", `""`, "", false},
+		{"看板列表頁", "https://www.ptt.cc/bbs/Beauty/index.html", "", false},
+		{"非 PTT 網域", "https://evil.example.com/bbs/Beauty/M.1234567890.A.ABC.html", "", false},
+		{"偽造子網域", "https://www.ptt.cc.evil.com/bbs/Beauty/M.1234567890.A.ABC.html", "", false},
+		{"網址夾在句子中", "參考 " + want + " 這篇", "", false},
+		{"非 http 協定", "ftp://www.ptt.cc/bbs/Beauty/M.1234567890.A.ABC.html", "", false},
+		{"路徑穿越", "https://www.ptt.cc/bbs/../etc/M.1.A.html", "", false},
+		{"多餘路徑", "https://www.ptt.cc/bbs/Beauty/M.1234567890.A.ABC.html/extra", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := normalizeArticleURL(tt.input)
+			if ok != tt.wantOK || got != tt.want {
+				t.Errorf("normalizeArticleURL(%q) = (%q, %v), want (%q, %v)", tt.input, got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestFileListFormat(t *testing.T) {
+	tests := map[string]string{
+		"urls.csv":      fileListCSV,
+		"export.JSON":   fileListJSON,
+		"urls.txt":      "",
+		"urls":          "",
+		FileURLStdin:    "",
+		"dir.csv/a.txt": "",
+	}
+	for path, want := range tests {
+		if got := fileListFormat(path); got != want {
+			t.Errorf("fileListFormat(%q) = %q, want %q", path, got, want)
+		}
+	}
+}
+
+func TestParseArticleList(t *testing.T) {
+	const (
+		url1 = "https://www.ptt.cc/bbs/Beauty/M.111.A.html"
+		url2 = "https://www.ptt.cc/bbs/Beauty/M.222.A.html"
+	)
+
+	tests := []struct {
+		name         string
+		format       string
+		input        string
+		want         []types.ArticleInfo
+		wantWarnings int
+		wantErr      string
+	}{
+		{
+			name:   "CSV 含標題與推文數",
+			format: fileListCSV,
+			input:  "\ufeffTitle,URL,push_rate\n\"[正妹] 逗號, 標題\",www.ptt.cc/bbs/Beauty/M.111.A.html,42\n,\"" + url2 + "\"\n",
+			want: []types.ArticleInfo{
+				{URL: url1, Title: "[正妹] 逗號, 標題", PushRate: 42},
+				{URL: url2},
+			},
+		},
+		{
+			name:         "CSV 略過無效網址並忽略無效推文數",
+			format:       fileListCSV,
+			input:        "url,pushRate\nhttps://example.com/x,10\n" + url1 + ",爆\n",
+			want:         []types.ArticleInfo{{URL: url1}},
+			wantWarnings: 2,
+		},
+		{
+			name:    "CSV 缺少 url 欄位",
+			format:  fileListCSV,
+			input:   "title,pushRate\nfoo,1\n",
+			wantErr: "缺少 url 欄位",
+		},
+		{
+			name:   "JSON 物件陣列",
+			format: fileListJSON,
+			input:  `[{"url": "` + url1 + `", "title": " 標題 ", "pushRate": -3}, {"url": "` + url2 + `", "push_rate": 7, "author": "ignored"}, {"url": "bad"}]`,
+			want: []types.ArticleInfo{
+				{URL: url1, Title: "標題", PushRate: -3},
+				{URL: url2, PushRate: 7},
+			},
+			wantWarnings: 1,
+		},
+		{
+			name:    "JSON 格式錯誤",
+			format:  fileListJSON,
+			input:   `{"url": "` + url1 + `"}`,
+			wantErr: "解析 JSON 清單失敗",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var warnings []string
+			got, err := parseArticleList(strings.NewReader(tt.input), tt.format, func(format string, args ...any) {
+				warnings = append(warnings, fmt.Sprintf(format, args...))
+			})
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("err = %v, want 包含 %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parseArticleList() error = %v", err)
+			}
+			if fmt.Sprint(got) != fmt.Sprint(tt.want) {
+				t.Errorf("articles = %+v, want %+v", got, tt.want)
+			}
+			if len(warnings) != tt.wantWarnings {
+				t.Errorf("warnings = %v, want %d 筆", warnings, tt.wantWarnings)
+			}
+		})
+	}
+}
+
+// TestProduceFromFile_List 驗證 .json 清單的標題與推文數直接帶入送出的文章資訊
+func TestProduceFromFile_List(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "articles.json")
+	content := `[{"url": "https://www.ptt.cc/bbs/Beauty/M.111.A.html", "title": "精選", "pushRate": 88}]`
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	c := NewCrawlerWithDependencies(mocks.NewMockHTTPClient(), mocks.NewMockParser(), mocks.NewMockMarkdownGenerator(),
+		"test", 0, 0, path, config.DefaultConfig())
+	c.tracker = newProgressTracker(nil)
+	ch := make(chan types.ArticleInfo, 10)
+	c.produceFromFile(context.Background(), ch)
+
+	var got []types.ArticleInfo
+	for a := range ch {
+		got = append(got, a)
+	}
+	if len(got) != 1 || got[0].Title != "精選" || got[0].PushRate != 88 {
+		t.Errorf("articles = %+v, want 標題「精選」推文數 88", got)
+	}
+	if snap := c.tracker.snapshot(); snap.UnitsTotal != 1 {
+		t.Errorf("ETA 總量 = %d, want 1", snap.UnitsTotal)
+	}
+	// 清單提供的標題優先於文章頁解析的標題
+	if title := c.determineFinalTitle(got[0], "文章頁標題"); title != "精選" {
+		t.Errorf("determineFinalTitle() = %q, want 精選", title)
+	}
+}
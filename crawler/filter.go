@@ -0,0 +1,74 @@
+package crawler
+
+import (
+	"strings"
+
+	"github.com/twtrubiks/ptt-spider-go/config"
+	"github.com/twtrubiks/ptt-spider-go/types"
+)
+
+// articleFilter 決定看板模式下哪些文章要送入解析流程。
+// 各條件以 AND 組合：先檢查推文數門檻，再排除 excludePushRates 中的精確值。
+type articleFilter struct {
+	minPush     int              // 推文數門檻（-push）
+	excludePush map[int]struct{} // 精確排除的推文數
+}
+
+// newArticleFilter 依推文數門檻與 config filter 建立文章過濾器
+func newArticleFilter(pushRate int, cfg config.FilterConfig) *articleFilter {
+	f := &articleFilter{minPush: pushRate}
+	if len(cfg.ExcludePushRates) > 0 {
+		f.excludePush = make(map[int]struct{}, len(cfg.ExcludePushRates))
+		for _, n := range cfg.ExcludePushRates {
+			f.excludePush[n] = struct{}{}
+		}
+	}
+	return f
+}
+
+// accept 回報文章是否符合所有過濾條件
+func (f *articleFilter) accept(article types.ArticleInfo) bool {
+	if article.PushRate < f.minPush {
+		return false
+	}
+	if _, excluded := f.excludePush[article.PushRate]; excluded {
+		return false
+	}
+	return true
+}
+
+// matchTitle 回報標題是否符合 -title-filter 與 -title-contains（兩者皆指定時須同時符合）
+func (c *Crawler) matchTitle(title string) bool {
+	if c.titlePattern != nil && !c.titlePattern.MatchString(title) {
+		return false
+	}
+	if c.titleContains != "" && !strings.Contains(strings.ToLower(title), strings.ToLower(c.titleContains)) {
+		return false
+	}
+	return true
+}
+
+// newAuthorSet 將作者清單轉為小寫集合，忽略空白項目；清單為空時回傳 nil（不過濾）
+func newAuthorSet(authors []string) map[string]struct{} {
+	var set map[string]struct{}
+	for _, a := range authors {
+		a = strings.ToLower(strings.TrimSpace(a))
+		if a == "" {
+			continue
+		}
+		if set == nil {
+			set = make(map[string]struct{}, len(authors))
+		}
+		set[a] = struct{}{}
+	}
+	return set
+}
+
+// matchAuthor 回報作者是否在 -author 清單中（不分大小寫），未指定清單時一律通過
+func (c *Crawler) matchAuthor(author string) bool {
+	if c.authors == nil {
+		return true
+	}
+	_, ok := c.authors[strings.ToLower(strings.TrimSpace(author))]
+	return ok
+}
@@ -0,0 +1,397 @@
+package crawler
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"regexp"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/twtrubiks/ptt-spider-go/config"
+	"github.com/twtrubiks/ptt-spider-go/mocks"
+	"github.com/twtrubiks/ptt-spider-go/types"
+)
+
+func TestArticleFilter_Accept(t *testing.T) {
+	tests := []struct {
+		name     string
+		pushRate int
+		exclude  []int
+		push     int
+		want     bool
+	}{
+		{"無排除清單時只看門檻", 10, nil, 10, true},
+		{"低於門檻", 10, nil, 9, false},
+		{"精確排除", 0, []int{0, 100}, 100, false},
+		{"不在排除清單", 0, []int{0, 100}, 99, true},
+		{"低於門檻的排除值仍不通過", 10, []int{5}, 5, false},
+		{"排除負數推文", -10, []int{-1}, -1, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newArticleFilter(tt.pushRate, config.FilterConfig{ExcludePushRates: tt.exclude})
+			if got := f.accept(types.ArticleInfo{PushRate: tt.push}); got != tt.want {
+				t.Errorf("accept(push=%d) = %v, want %v", tt.push, got, tt.want)
+			}
+		})
+	}
+}
+
+// TestArticleProducer_ExcludePushRates 驗證 producer 同時套用門檻與精確排除
+func TestArticleProducer_ExcludePushRates(t *testing.T) {
+	client := &mocks.MockHTTPClient{
+		DoFunc: func(_ *http.Request) (*http.Response, error) {
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       io.NopCloser(strings.NewReader("<html></html>")),
+			}, nil
+		},
+	}
+	parser := &mocks.MockParser{
+		ParseMaxPageFunc: func(_ io.Reader) (int, error) { return 1, nil },
+		ParseArticlesFunc: func(_ io.Reader) ([]types.ArticleInfo, error) {
+			return []types.ArticleInfo{
+				{Title: "A", URL: "http://example.com/a", PushRate: 3},
+				{Title: "B", URL: "http://example.com/b", PushRate: 10},
+				{Title: "C", URL: "http://example.com/c", PushRate: 100},
+				{Title: "D", URL: "http://example.com/d", PushRate: 42},
+			}, nil
+		},
+	}
+
+	cfg := config.DefaultConfig()
+	cfg.Crawler.Filter.ExcludePushRates = []int{100, 3}
+	c := NewCrawlerWithDependencies(client, parser, mocks.NewMockMarkdownGenerator(), "test", 1, 5, "", cfg)
+
+	ch := make(chan types.ArticleInfo, 10)
+	c.articleProducer(context.Background(), ch)
+
+	var got []string
+	for a := range ch {
+		got = append(got, a.Title)
+	}
+	if strings.Join(got, ",") != "B,D" {
+		t.Errorf("送出的文章 = %v, want [B D]", got)
+	}
+}
+
+func TestMatchTitle(t *testing.T) {
+	tests := []struct {
+		name     string
+		pattern  string
+		contains string
+		title    string
+		want     bool
+	}{
+		{"未設定條件時全部通過", "", "", "[正妹] 任意標題", true},
+		{"符合正規表示式", `^\[正妹\]`, "", "[正妹] 台北", true},
+		{"不符合正規表示式", `^\[正妹\]`, "", "Re: [正妹] 台北", false},
+		{"包含字串不分大小寫", "", "cosplay", "[正妹] COSPLAY 合集", true},
+		{"不包含字串", "", "cosplay", "[正妹] 台北", false},
+		{"兩者皆指定須同時符合", `^\[正妹\]`, "台北", "[正妹] 台中", false},
+		{"兩者皆符合", `^\[正妹\]`, "台北", "[正妹] 台北", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Crawler{titleContains: tt.contains}
+			if tt.pattern != "" {
+				c.titlePattern = regexp.MustCompile(tt.pattern)
+			}
+			if got := c.matchTitle(tt.title); got != tt.want {
+				t.Errorf("matchTitle(%q) = %v, want %v", tt.title, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestArticleProducer_TitleFilter(t *testing.T) {
+	client := &mocks.MockHTTPClient{
+		DoFunc: func(_ *http.Request) (*http.Response, error) {
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       io.NopCloser(strings.NewReader("<html></html>")),
+			}, nil
+		},
+	}
+	parser := &mocks.MockParser{
+		ParseMaxPageFunc: func(_ io.Reader) (int, error) { return 1, nil },
+		ParseArticlesFunc: func(_ io.Reader) ([]types.ArticleInfo, error) {
+			return []types.ArticleInfo{
+				{Title: "[正妹] 台北", URL: "http://example.com/a", PushRate: 10},
+				{Title: "[神人] 台北", URL: "http://example.com/b", PushRate: 10},
+				{Title: "[正妹] 台中", URL: "http://example.com/c", PushRate: 10},
+				{Title: "[正妹] 台北 2", URL: "http://example.com/d", PushRate: 1},
+			}, nil
+		},
+	}
+
+	c := NewCrawlerWithDependencies(client, parser, mocks.NewMockMarkdownGenerator(), "test", 1, 5, "", config.DefaultConfig(),
+		WithTitleFilter(regexp.MustCompile(`^\[正妹\]`)), WithTitleContains("台北"))
+
+	ch := make(chan types.ArticleInfo, 10)
+	c.articleProducer(context.Background(), ch)
+
+	var got []string
+	for a := range ch {
+		got = append(got, a.URL)
+	}
+	// b 不符合正規表示式、c 不包含「台北」、d 低於推文門檻
+	if strings.Join(got, ",") != "http://example.com/a" {
+		t.Errorf("送出的文章 = %v, want [http://example.com/a]", got)
+	}
+}
+
+func TestProcessArticle_FileModeTitleFilter(t *testing.T) {
+	tests := []struct {
+		name      string
+		title     string
+		wantTasks bool
+	}{
+		{"解析後的標題符合", "[正妹] 台北", true},
+		{"解析後的標題不符合", "[神人] 台北", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := &mocks.MockHTTPClient{
+				DoFunc: func(_ *http.Request) (*http.Response, error) {
+					return &http.Response{
+						StatusCode: http.StatusOK,
+						Body:       io.NopCloser(strings.NewReader("<html></html>")),
+					}, nil
+				},
+			}
+			parser := &mocks.MockParser{
+				ParseArticleContentFunc: func(_ io.Reader) (types.ArticleContent, error) {
+					return types.ArticleContent{Title: tt.title, ImageURLs: []string{"https://i.imgur.com/a.jpg"}}, nil
+				},
+			}
+			cfg := config.DefaultConfig()
+			cfg.Crawler.Delays = config.DelayConfig{MinMs: 0, MaxMs: 0}
+			c := NewCrawlerWithDependencies(client, parser, mocks.NewMockMarkdownGenerator(), "test", 0, 0, "urls.txt", cfg,
+				WithTitleFilter(regexp.MustCompile(`^\[正妹\]`)))
+
+			downloadChan := make(chan types.DownloadTask, 10)
+			markdownChan := make(chan types.MarkdownInfo, 10)
+			// 檔案模式的文章沒有列表頁標題，只能以解析後的標題過濾
+			c.processArticle(context.Background(), types.ArticleInfo{URL: "http://example.com/a"}, downloadChan, markdownChan)
+
+			if got := len(downloadChan) > 0; got != tt.wantTasks {
+				t.Errorf("有下載任務 = %v, want %v", got, tt.wantTasks)
+			}
+		})
+	}
+}
+
+func TestMatchAuthor(t *testing.T) {
+	tests := []struct {
+		name    string
+		authors []string
+		author  string
+		want    bool
+	}{
+		{"未指定清單時全部通過", nil, "anyone", true},
+		{"只有空白項目視為未指定", []string{"", " "}, "anyone", true},
+		{"在清單中", []string{"alice", "bob"}, "bob", true},
+		{"不分大小寫", []string{"Alice"}, "aLICE", true},
+		{"清單項目前後空白", []string{" alice "}, "alice", true},
+		{"不在清單中", []string{"alice"}, "carol", false},
+		{"作者未知時不通過", []string{"alice"}, "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Crawler{authors: newAuthorSet(tt.authors)}
+			if got := c.matchAuthor(tt.author); got != tt.want {
+				t.Errorf("matchAuthor(%q) = %v, want %v", tt.author, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestArticleProducer_AuthorFilter(t *testing.T) {
+	client := &mocks.MockHTTPClient{
+		DoFunc: func(_ *http.Request) (*http.Response, error) {
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       io.NopCloser(strings.NewReader("<html></html>")),
+			}, nil
+		},
+	}
+	parser := &mocks.MockParser{
+		ParseMaxPageFunc: func(_ io.Reader) (int, error) { return 1, nil },
+		ParseArticlesFunc: func(_ io.Reader) ([]types.ArticleInfo, error) {
+			return []types.ArticleInfo{
+				{Title: "A", URL: "http://example.com/a", Author: "Alice", PushRate: 10},
+				{Title: "B", URL: "http://example.com/b", Author: "carol", PushRate: 10},
+				{Title: "C", URL: "http://example.com/c", Author: "BOB", PushRate: 10},
+			}, nil
+		},
+	}
+
+	c := NewCrawlerWithDependencies(client, parser, mocks.NewMockMarkdownGenerator(), "test", 1, 0, "", config.DefaultConfig(),
+		WithAuthors([]string{"alice", "bob"}))
+
+	ch := make(chan types.ArticleInfo, 10)
+	c.articleProducer(context.Background(), ch)
+
+	var got []string
+	for a := range ch {
+		got = append(got, a.Title)
+	}
+	if strings.Join(got, ",") != "A,C" {
+		t.Errorf("送出的文章 = %v, want [A C]", got)
+	}
+}
+
+func TestProcessArticle_FileModeAuthorFilter(t *testing.T) {
+	tests := []struct {
+		name      string
+		author    string
+		wantTasks bool
+	}{
+		{"解析後的作者在清單中", "Alice", true},
+		{"解析後的作者不在清單中", "carol", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := &mocks.MockHTTPClient{
+				DoFunc: func(_ *http.Request) (*http.Response, error) {
+					return &http.Response{
+						StatusCode: http.StatusOK,
+						Body:       io.NopCloser(strings.NewReader("<html></html>")),
+					}, nil
+				},
+			}
+			parser := &mocks.MockParser{
+				ParseArticleContentFunc: func(_ io.Reader) (types.ArticleContent, error) {
+					return types.ArticleContent{Title: "T", Author: tt.author, ImageURLs: []string{"https://i.imgur.com/a.jpg"}}, nil
+				},
+			}
+			cfg := config.DefaultConfig()
+			cfg.Crawler.Delays = config.DelayConfig{MinMs: 0, MaxMs: 0}
+			c := NewCrawlerWithDependencies(client, parser, mocks.NewMockMarkdownGenerator(), "test", 0, 0, "urls.txt", cfg,
+				WithAuthors([]string{"alice"}))
+
+			downloadChan := make(chan types.DownloadTask, 10)
+			markdownChan := make(chan types.MarkdownInfo, 10)
+			// 檔案模式的文章沒有列表頁作者，只能以解析後的作者過濾
+			c.processArticle(context.Background(), types.ArticleInfo{URL: "http://example.com/a"}, downloadChan, markdownChan)
+
+			if got := len(downloadChan) > 0; got != tt.wantTasks {
+				t.Errorf("有下載任務 = %v, want %v", got, tt.wantTasks)
+			}
+			// Markdown（與 manifest）的作者取自文章頁解析結果
+			if tt.wantTasks {
+				if info := <-markdownChan; info.Author != tt.author {
+					t.Errorf("MarkdownInfo.Author = %q, want %q", info.Author, tt.author)
+				}
+			}
+		})
+	}
+}
+
+func TestProcessArticle_PushStats(t *testing.T) {
+	client := &mocks.MockHTTPClient{
+		DoFunc: func(_ *http.Request) (*http.Response, error) {
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       io.NopCloser(strings.NewReader("<html></html>")),
+			}, nil
+		},
+	}
+	pushes := types.PushStats{PushCount: 3, BooCount: 1, NeutralCount: 2}
+	posted := time.Date(2026, 1, 5, 2, 0, 0, 0, time.UTC)
+	parser := &mocks.MockParser{
+		ParseArticleContentFunc: func(_ io.Reader) (types.ArticleContent, error) {
+			return types.ArticleContent{Title: "T", ImageURLs: []string{"https://i.imgur.com/a.jpg"}, Pushes: pushes, Body: "內文", Date: posted}, nil
+		},
+	}
+	cfg := config.DefaultConfig()
+	cfg.Crawler.Delays = config.DelayConfig{}
+	c := NewCrawlerWithDependencies(client, parser, mocks.NewMockMarkdownGenerator(), "test", 1, 0, "", cfg)
+
+	downloadChan := make(chan types.DownloadTask, 10)
+	markdownChan := make(chan types.MarkdownInfo, 10)
+	c.processArticle(context.Background(), types.ArticleInfo{URL: "http://example.com/a", Title: "T", PushRate: 2}, downloadChan, markdownChan)
+
+	// 列表頁的淨推文數與文章頁的推噓統計並存
+	info := <-markdownChan
+	if info.PushCount != 2 || info.Pushes != pushes {
+		t.Errorf("MarkdownInfo PushCount = %d, Pushes = %+v, want 2, %+v", info.PushCount, info.Pushes, pushes)
+	}
+	if info.Body != "內文" {
+		t.Errorf("MarkdownInfo Body = %q, want 內文", info.Body)
+	}
+	if !info.Date.Equal(posted) {
+		t.Errorf("MarkdownInfo Date = %v, want %v", info.Date, posted)
+	}
+}
+
+func TestProcessArticle_SavePushes(t *testing.T) {
+	comments := []types.Push{{Tag: "推", Author: "alice", Content: "正", Time: "01/05 10:00"}}
+	tests := []struct {
+		savePushes string
+		want       []types.Push
+	}{
+		{config.SavePushesOff, nil},
+		{config.SavePushesMarkdown, comments},
+		{config.SavePushesJSON, comments},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.savePushes, func(t *testing.T) {
+			client := &mocks.MockHTTPClient{
+				DoFunc: func(_ *http.Request) (*http.Response, error) {
+					return &http.Response{
+						StatusCode: http.StatusOK,
+						Body:       io.NopCloser(strings.NewReader("<html>page</html>")),
+					}, nil
+				},
+			}
+			var contentBody, pushesBody string
+			parser := &mocks.MockParser{
+				ParseArticleContentFunc: func(r io.Reader) (types.ArticleContent, error) {
+					data, _ := io.ReadAll(r)
+					contentBody = string(data)
+					return types.ArticleContent{Title: "T", ImageURLs: []string{"https://i.imgur.com/a.jpg"}}, nil
+				},
+				ParseArticlePushesFunc: func(r io.Reader) ([]types.Push, error) {
+					data, _ := io.ReadAll(r)
+					pushesBody = string(data)
+					return comments, nil
+				},
+			}
+			cfg := config.DefaultConfig()
+			cfg.Crawler.Delays = config.DelayConfig{}
+			cfg.Crawler.SavePushes = tt.savePushes
+			c := NewCrawlerWithDependencies(client, parser, mocks.NewMockMarkdownGenerator(), "test", 1, 0, "", cfg)
+
+			markdownChan := make(chan types.MarkdownInfo, 10)
+			c.processArticle(context.Background(), types.ArticleInfo{URL: "http://example.com/a", Title: "T"},
+				make(chan types.DownloadTask, 10), markdownChan)
+
+			info := <-markdownChan
+			if !slices.Equal(info.Comments, tt.want) {
+				t.Errorf("MarkdownInfo.Comments = %+v, want %+v", info.Comments, tt.want)
+			}
+			// 兩次解析讀到相同的文章頁；未保存推文時不解析推文
+			if contentBody != "<html>page</html>" {
+				t.Errorf("ParseArticleContent 讀到 %q", contentBody)
+			}
+			wantPushesBody := ""
+			if tt.want != nil {
+				wantPushesBody = "<html>page</html>"
+			}
+			if pushesBody != wantPushesBody {
+				t.Errorf("ParseArticlePushes 讀到 %q, want %q", pushesBody, wantPushesBody)
+			}
+		})
+	}
+}
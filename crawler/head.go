@@ -0,0 +1,161 @@
+package crawler
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+
+	"github.com/twtrubiks/ptt-spider-go/config"
+	"github.com/twtrubiks/ptt-spider-go/internal/ioutil"
+	"github.com/twtrubiks/ptt-spider-go/metrics"
+	"github.com/twtrubiks/ptt-spider-go/performance"
+	"github.com/twtrubiks/ptt-spider-go/types"
+)
+
+// headPrechecker 是下載前 HEAD 預檢的共用設定，所有解析器共用同一個並行上限
+type headPrechecker struct {
+	sem      chan struct{} // 全部文章合計同時進行的 HEAD 請求數上限
+	minBytes int64         // 小於此大小的圖片不下載，0 表示不限制
+}
+
+// newHeadPrechecker 依配置建立 HEAD 預檢，headPrecheck.enabled 關閉時回傳 nil
+func newHeadPrechecker(cfg config.HeadPrecheckConfig) *headPrechecker {
+	if !cfg.Enabled {
+		return nil
+	}
+	return &headPrechecker{
+		sem:      make(chan struct{}, max(cfg.Workers, 1)),
+		minBytes: int64(cfg.MinBytes),
+	}
+}
+
+// headResult 是一次 HEAD 預檢的結果
+type headResult struct {
+	size   int64 // Content-Length，請求失敗、非 200 或未提供時為 -1
+	status int   // 回應狀態碼，未取得回應時為 0
+}
+
+// gone 回報圖片是否已確定不存在（404/410），這類連結不必排入下載
+func (r headResult) gone() bool {
+	return r.status == http.StatusNotFound || r.status == http.StatusGone
+}
+
+// headSize 以 HEAD 請求取得圖片大小，失敗、非 200 或未提供 Content-Length 時回傳 -1
+func (c *Crawler) headSize(ctx context.Context, imageURL string) int64 {
+	return c.headCheck(ctx, imageURL).size
+}
+
+// headCheck 以 HEAD 請求取得圖片大小與狀態碼。
+// 請求經過 doRequest，同樣受速率限制與重試策略約束。
+// host 回應 405/501（不支援 HEAD）時記錄該 host，之後對它不再送出 HEAD。
+func (c *Crawler) headCheck(ctx context.Context, imageURL string) headResult {
+	unknown := headResult{size: -1}
+	host := hostOf(imageURL)
+	if _, unsupported := c.noHeadHosts.Load(host); unsupported {
+		return unknown
+	}
+	if !c.robotsAllowed(ctx, imageURL) {
+		return unknown
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, imageURL, nil)
+	if err != nil {
+		return unknown
+	}
+	resp, err := c.doRequest(ctx, req)
+	if err != nil {
+		c.logger.Debug("HEAD 請求失敗: %s, 錯誤: %v", imageURL, err)
+		return unknown
+	}
+	defer ioutil.CloseWithLog(resp.Body, "HEAD 回應 Body")
+
+	result := headResult{size: -1, status: resp.StatusCode}
+	switch resp.StatusCode {
+	case http.StatusOK:
+		result.size = resp.ContentLength
+	case http.StatusMethodNotAllowed, http.StatusNotImplemented:
+		if _, loaded := c.noHeadHosts.LoadOrStore(host, struct{}{}); !loaded {
+			c.logger.Info("%s 不支援 HEAD 請求（狀態碼 %d），之後直接下載不預檢大小", host, resp.StatusCode)
+		}
+	default:
+		c.logger.Debug("HEAD 請求狀態碼 %d: %s", resp.StatusCode, imageURL)
+	}
+	return result
+}
+
+// hostOf 回傳 URL 的 host（小寫），無法解析時回傳空字串
+func hostOf(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return ""
+	}
+	return strings.ToLower(u.Hostname())
+}
+
+// precheckSizes 並行以 HEAD 取得文章所有圖片的大小，回傳應下載的任務（已填入 Size，並計入指標的預計下載量）。
+// 已不存在（404/410）、Content-Length 為 0、超過 maxImageBytes 或小於 headPrecheck.minBytes 的圖片
+// 直接視為已處理，不會派發下載；無法取得大小的圖片照常下載，由下載時的大小檢查把關。
+func (c *Crawler) precheckSizes(ctx context.Context, tasks []types.DownloadTask) []types.DownloadTask {
+	results := make([]headResult, len(tasks))
+	var wg sync.WaitGroup
+	for i, task := range tasks {
+		select {
+		case <-ctx.Done():
+		case c.headPrecheck.sem <- struct{}{}:
+		}
+		if ctx.Err() != nil {
+			break
+		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() { <-c.headPrecheck.sem }()
+			results[i] = c.headCheck(ctx, task.ImageURL)
+		}()
+	}
+	wg.Wait()
+	if ctx.Err() != nil {
+		return tasks
+	}
+
+	maxBytes := int64(c.config.Crawler.MaxImageBytes)
+	minBytes := c.headPrecheck.minBytes
+	kept := tasks[:0]
+	for i, task := range tasks {
+		r := results[i]
+		size := performance.FormatBytes(uint64(max(r.size, 0)))
+		var reason string
+		switch {
+		case r.gone():
+			reason = fmt.Sprintf("圖片已不存在（狀態碼 %d）", r.status)
+		case r.size < 0:
+			kept = append(kept, task)
+			continue
+		case r.size == 0:
+			reason = "Content-Length 為 0"
+		case maxBytes > 0 && r.size > maxBytes:
+			reason = fmt.Sprintf("圖片大小 %s 超過大小上限 %s", size, performance.FormatBytes(uint64(maxBytes)))
+			c.recordError(metrics.ErrorOversize)
+		case r.size < minBytes:
+			reason = fmt.Sprintf("圖片大小 %s 小於大小下限 %s", size, performance.FormatBytes(uint64(minBytes)))
+		default:
+			task.Size = r.size
+			if c.metrics != nil {
+				c.metrics.RecordImagePrechecked(r.size)
+			}
+			kept = append(kept, task)
+			continue
+		}
+
+		c.boardLogger(task.Board).Info("HEAD 預檢：%s，不下載: %s", reason, task.ImageURL)
+		c.emit(types.ProgressEvent{
+			Type:    types.EventDownloadFail,
+			Message: fmt.Sprintf("%s: %s", reason, task.SavePath),
+		})
+		c.articleTaskDone(task.ArticleURL)
+	}
+	return kept
+}
@@ -0,0 +1,174 @@
+package crawler
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/twtrubiks/ptt-spider-go/config"
+	"github.com/twtrubiks/ptt-spider-go/mocks"
+	"github.com/twtrubiks/ptt-spider-go/types"
+)
+
+// newHeadTestCrawler 建立啟用 HEAD 預檢的 crawler，HEAD 回應的大小與狀態碼取自 URL 的 size、status 參數
+func newHeadTestCrawler(t *testing.T, precheck config.HeadPrecheckConfig, opts ...Option) (*Crawler, *atomic.Int32) {
+	t.Helper()
+	var heads atomic.Int32
+	client := &mocks.MockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			if req.Method != http.MethodHead {
+				t.Errorf("預檢只應送出 HEAD，實際 %s", req.Method)
+			}
+			heads.Add(1)
+			if req.URL.Host == "nohead.example.com" {
+				return newTestResponse(http.StatusMethodNotAllowed), nil
+			}
+			if s := req.URL.Query().Get("status"); s != "" {
+				code, _ := strconv.Atoi(s)
+				return newTestResponse(code), nil
+			}
+			size := int64(-1)
+			if s := req.URL.Query().Get("size"); s != "" {
+				size, _ = strconv.ParseInt(s, 10, 64)
+			}
+			return &http.Response{
+				StatusCode:    http.StatusOK,
+				ContentLength: size,
+				Body:          io.NopCloser(strings.NewReader("")),
+			}, nil
+		},
+	}
+	cfg := config.DefaultConfig()
+	cfg.Crawler.MaxImageBytes = 1000
+	precheck.Enabled = true
+	cfg.Crawler.HeadPrecheck = precheck
+	c := NewCrawlerWithDependencies(client, mocks.NewMockParser(), mocks.NewMockMarkdownGenerator(),
+		"test", 1, 0, "", cfg, opts...)
+	return c, &heads
+}
+
+func TestPrecheckSizes_FiltersBySize(t *testing.T) {
+	c, _ := newHeadTestCrawler(t, config.HeadPrecheckConfig{Workers: 2, MinBytes: 100})
+
+	tasks := []types.DownloadTask{
+		{ImageURL: "https://i.imgur.com/ok.jpg?size=500"},
+		{ImageURL: "https://i.imgur.com/big.jpg?size=5000"},
+		{ImageURL: "https://i.imgur.com/thumb.jpg?size=10"},
+		{ImageURL: "https://i.imgur.com/unknown.jpg"},
+		{ImageURL: "https://i.imgur.com/edge.jpg?size=1000"},
+	}
+	kept := c.precheckSizes(context.Background(), tasks)
+
+	var got []string
+	for _, task := range kept {
+		got = append(got, task.ImageURL+"="+strconv.FormatInt(task.Size, 10))
+	}
+	want := []string{
+		"https://i.imgur.com/ok.jpg?size=500=500",
+		"https://i.imgur.com/unknown.jpg=0",
+		"https://i.imgur.com/edge.jpg?size=1000=1000",
+	}
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("precheckSizes() = %v, want %v", got, want)
+	}
+}
+
+func TestPrecheckSizes_SkipsDeadLinks(t *testing.T) {
+	var expected atomic.Int64
+	collector := &mocks.MockMetricsCollector{
+		RecordImagePrecheckedFunc: func(bytes int64) { expected.Add(bytes) },
+	}
+	c, _ := newHeadTestCrawler(t, config.HeadPrecheckConfig{Workers: 2}, WithMetrics(collector))
+
+	tasks := []types.DownloadTask{
+		{ImageURL: "https://i.imgur.com/ok.jpg?size=500"},
+		{ImageURL: "https://i.imgur.com/removed.jpg?status=404"},
+		{ImageURL: "https://i.imgur.com/gone.jpg?status=410"},
+		{ImageURL: "https://i.imgur.com/empty.jpg?size=0"},
+		{ImageURL: "https://i.imgur.com/flaky.jpg?status=503"},
+	}
+	kept := c.precheckSizes(context.Background(), tasks)
+
+	var got []string
+	for _, task := range kept {
+		got = append(got, task.ImageURL)
+	}
+	// 503 可能只是暫時失敗，照常下載
+	want := []string{"https://i.imgur.com/ok.jpg?size=500", "https://i.imgur.com/flaky.jpg?status=503"}
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("precheckSizes() = %v, want %v", got, want)
+	}
+	if n := expected.Load(); n != 500 {
+		t.Errorf("預計下載量 = %d, want 500（只計入排入下載且已知大小的圖片）", n)
+	}
+}
+
+func TestHeadSize_UnsupportedHostFallback(t *testing.T) {
+	c, heads := newHeadTestCrawler(t, config.HeadPrecheckConfig{Workers: 4})
+
+	tasks := []types.DownloadTask{
+		{ImageURL: "https://nohead.example.com/a.jpg"},
+		{ImageURL: "https://nohead.example.com/b.jpg"},
+	}
+	// 第一次預檢後記住該 host 不支援 HEAD，圖片照常下載
+	if kept := c.precheckSizes(context.Background(), tasks[:1]); len(kept) != 1 {
+		t.Fatalf("不支援 HEAD 的圖片應照常下載，kept = %v", kept)
+	}
+	if kept := c.precheckSizes(context.Background(), tasks[1:]); len(kept) != 1 {
+		t.Fatalf("不支援 HEAD 的圖片應照常下載，kept = %v", kept)
+	}
+	if n := heads.Load(); n != 1 {
+		t.Errorf("HEAD 請求數 = %d, want 1（之後不再對該 host 送出 HEAD）", n)
+	}
+}
+
+func TestPrecheckSizes_RateLimitedAndBounded(t *testing.T) {
+	var waits atomic.Int32
+	limiter := &mocks.MockRateLimiter{
+		WaitFunc: func(context.Context) error {
+			waits.Add(1)
+			return nil
+		},
+	}
+	c, heads := newHeadTestCrawler(t, config.HeadPrecheckConfig{Workers: 3}, WithRateLimiter(limiter))
+
+	// 包一層 client 記錄同時進行的 HEAD 數
+	var mu sync.Mutex
+	inflight, peak := 0, 0
+	inner := c.client
+	c.client = &mocks.MockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			mu.Lock()
+			inflight++
+			peak = max(peak, inflight)
+			mu.Unlock()
+			defer func() {
+				mu.Lock()
+				inflight--
+				mu.Unlock()
+			}()
+			return inner.Do(req)
+		},
+	}
+
+	tasks := make([]types.DownloadTask, 20)
+	for i := range tasks {
+		tasks[i] = types.DownloadTask{ImageURL: "https://i.imgur.com/" + strconv.Itoa(i) + ".jpg?size=10"}
+	}
+	c.precheckSizes(context.Background(), tasks)
+
+	if n := heads.Load(); n != 20 {
+		t.Errorf("HEAD 請求數 = %d, want 20", n)
+	}
+	if n := waits.Load(); n != 20 {
+		t.Errorf("速率限制器 Wait 次數 = %d, want 20（HEAD 也應受速率限制）", n)
+	}
+	if peak > 3 {
+		t.Errorf("同時進行的 HEAD 數最多 %d, want <= 3", peak)
+	}
+}
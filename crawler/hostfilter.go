@@ -0,0 +1,67 @@
+package crawler
+
+import (
+	"path"
+
+	"github.com/twtrubiks/ptt-spider-go/config"
+	"github.com/twtrubiks/ptt-spider-go/ui"
+)
+
+// hostFilter 依 crawler.hosts 的允許／封鎖清單決定圖片是否下載，樣式已由 config 轉為小寫
+type hostFilter struct {
+	allow []string // 非空時只允許相符的 host
+	deny  []string // 相符時一律不允許，優先於 allow
+}
+
+// newHostFilter 依配置建立圖床過濾器，兩個清單皆為空時回傳 nil（不過濾）
+func newHostFilter(cfg config.HostFilterConfig) *hostFilter {
+	if len(cfg.Allow) == 0 && len(cfg.Deny) == 0 {
+		return nil
+	}
+	return &hostFilter{allow: cfg.Allow, deny: cfg.Deny}
+}
+
+// allowed 回報 host（小寫）是否通過過濾：相符 deny 時不允許；allow 非空時需相符其中一項
+func (f *hostFilter) allowed(host string) bool {
+	if matchHostPatterns(f.deny, host) {
+		return false
+	}
+	return len(f.allow) == 0 || matchHostPatterns(f.allow, host)
+}
+
+// matchHostPatterns 回報 host 是否符合任一樣式。樣式為完整 host（只比對相同的 host），
+// 或 path.Match 語法的萬用字元樣式：*.imgur.com 符合 i.imgur.com 等子網域，但不含 imgur.com 本身
+func matchHostPatterns(patterns []string, host string) bool {
+	if host == "" {
+		return false
+	}
+	for _, p := range patterns {
+		if p == host {
+			return true
+		}
+		if ok, _ := path.Match(p, host); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// filterImageHosts 回傳 host 通過 crawler.hosts 過濾的圖片 URL，被過濾的圖片不會派發下載，
+// 也不會出現在 Markdown 等輸出檔中；未設定過濾時原樣回傳
+func (c *Crawler) filterImageHosts(log ui.Logger, imgURLs []string) []string {
+	if c.hostFilter == nil {
+		return imgURLs
+	}
+	kept := imgURLs[:0:0]
+	for _, imgURL := range imgURLs {
+		if c.hostFilter.allowed(hostOf(imgURL)) {
+			kept = append(kept, imgURL)
+			continue
+		}
+		log.Debug("圖床不在允許清單或位於封鎖清單，不下載: %s", imgURL)
+	}
+	if skipped := len(imgURLs) - len(kept); skipped > 0 {
+		log.Info("依圖床過濾略過 %d 張圖片", skipped)
+	}
+	return kept
+}
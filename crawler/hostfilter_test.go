@@ -0,0 +1,156 @@
+package crawler
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/twtrubiks/ptt-spider-go/config"
+	"github.com/twtrubiks/ptt-spider-go/mocks"
+	"github.com/twtrubiks/ptt-spider-go/types"
+)
+
+func TestMatchHostPatterns(t *testing.T) {
+	tests := []struct {
+		name     string
+		patterns []string
+		host     string
+		want     bool
+	}{
+		{"完整 host 相同", []string{"i.imgur.com"}, "i.imgur.com", true},
+		{"完整 host 不含子網域", []string{"imgur.com"}, "i.imgur.com", false},
+		{"萬用字元符合子網域", []string{"*.imgur.com"}, "i.imgur.com", true},
+		{"萬用字元符合多層子網域", []string{"*.imgur.com"}, "a.b.imgur.com", true},
+		{"萬用字元不含網域本身", []string{"*.imgur.com"}, "imgur.com", false},
+		{"萬用字元不符合相似網域", []string{"*.imgur.com"}, "notimgur.com", false},
+		{"中段萬用字元", []string{"cdn*.example.com"}, "cdn2.example.com", true},
+		{"單一字元萬用字元", []string{"i?.example.com"}, "i1.example.com", true},
+		{"任一樣式相符即可", []string{"example.com", "*.imgur.com"}, "i.imgur.com", true},
+		{"* 符合所有 host", []string{"*"}, "pbs.twimg.com", true},
+		{"空 host 不符合", []string{"*"}, "", false},
+		{"空清單", nil, "i.imgur.com", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := matchHostPatterns(tt.patterns, tt.host); got != tt.want {
+				t.Errorf("matchHostPatterns(%v, %q) = %v, want %v", tt.patterns, tt.host, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHostFilter_Allowed(t *testing.T) {
+	if newHostFilter(config.HostFilterConfig{}) != nil {
+		t.Fatal("未設定清單時應回傳 nil")
+	}
+
+	tests := []struct {
+		name string
+		cfg  config.HostFilterConfig
+		host string
+		want bool
+	}{
+		{"只設 allow：相符", config.HostFilterConfig{Allow: []string{"*.imgur.com"}}, "i.imgur.com", true},
+		{"只設 allow：不相符", config.HostFilterConfig{Allow: []string{"*.imgur.com"}}, "pbs.twimg.com", false},
+		{"只設 allow：無法解析 host", config.HostFilterConfig{Allow: []string{"*"}}, "", false},
+		{"只設 deny：相符", config.HostFilterConfig{Deny: []string{"*.bad.com"}}, "img.bad.com", false},
+		{"只設 deny：不相符", config.HostFilterConfig{Deny: []string{"*.bad.com"}}, "i.imgur.com", true},
+		{"只設 deny：無法解析 host", config.HostFilterConfig{Deny: []string{"*"}}, "", true},
+		{
+			"兩者皆相符時 deny 優先",
+			config.HostFilterConfig{Allow: []string{"*.imgur.com"}, Deny: []string{"m.imgur.com"}},
+			"m.imgur.com", false,
+		},
+		{
+			"相符 allow 且不相符 deny",
+			config.HostFilterConfig{Allow: []string{"*.imgur.com"}, Deny: []string{"m.imgur.com"}},
+			"i.imgur.com", true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := newHostFilter(tt.cfg).allowed(tt.host); got != tt.want {
+				t.Errorf("allowed(%q) = %v, want %v", tt.host, got, tt.want)
+			}
+		})
+	}
+}
+
+// TestProcessArticle_HostFilter 驗證被圖床過濾的圖片不會派發下載，也不會出現在 Markdown 圖片清單中
+func TestProcessArticle_HostFilter(t *testing.T) {
+	t.Chdir(t.TempDir())
+
+	client := &mocks.MockHTTPClient{
+		DoFunc: func(_ *http.Request) (*http.Response, error) {
+			return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(""))}, nil
+		},
+	}
+	parser := &mocks.MockParser{
+		ParseArticleContentFunc: func(io.Reader) (types.ArticleContent, error) {
+			return types.ArticleContent{ImageURLs: []string{
+				"https://i.imgur.com/a.jpg",
+				"https://pbs.twimg.com/b.jpg",
+				"https://m.imgur.com/c.jpg",
+				"https://I.IMGUR.COM:443/d.jpg",
+			}}, nil
+		},
+	}
+	cfg := config.DefaultConfig()
+	cfg.Crawler.Delays = config.DelayConfig{MinMs: 0, MaxMs: 0}
+	cfg.Crawler.Hosts = config.HostFilterConfig{Allow: []string{"*.imgur.com"}, Deny: []string{"m.imgur.com"}}
+	c := NewCrawlerWithDependencies(client, parser, mocks.NewMockMarkdownGenerator(),
+		"test", 1, 0, "", cfg, WithLogger(&mocks.MockLogger{}))
+
+	downloadChan := make(chan types.DownloadTask, 10)
+	markdownChan := make(chan types.MarkdownInfo, 10)
+	c.processArticle(context.Background(), types.ArticleInfo{Title: "圖床過濾", URL: "http://example.com/a"}, downloadChan, markdownChan)
+	close(downloadChan)
+	close(markdownChan)
+
+	want := []string{"https://i.imgur.com/a.jpg", "https://I.IMGUR.COM:443/d.jpg"}
+	var got []string
+	for task := range downloadChan {
+		got = append(got, task.ImageURL)
+	}
+	if !slices.Equal(got, want) {
+		t.Errorf("下載任務 = %v, want %v", got, want)
+	}
+	info, ok := <-markdownChan
+	if !ok {
+		t.Fatal("應派發 Markdown 任務")
+	}
+	if !slices.Equal(info.ImageURLs, want) {
+		t.Errorf("Markdown 圖片清單 = %v, want %v", info.ImageURLs, want)
+	}
+}
+
+// TestProcessArticle_HostFilterAllImagesFiltered 驗證圖片全部被過濾時不派發任何任務，文章仍視為處理完成
+func TestProcessArticle_HostFilterAllImagesFiltered(t *testing.T) {
+	t.Chdir(t.TempDir())
+
+	client := &mocks.MockHTTPClient{
+		DoFunc: func(_ *http.Request) (*http.Response, error) {
+			return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(""))}, nil
+		},
+	}
+	parser := &mocks.MockParser{
+		ParseArticleContentFunc: func(io.Reader) (types.ArticleContent, error) {
+			return types.ArticleContent{ImageURLs: []string{"https://pbs.twimg.com/b.jpg"}}, nil
+		},
+	}
+	cfg := config.DefaultConfig()
+	cfg.Crawler.Delays = config.DelayConfig{MinMs: 0, MaxMs: 0}
+	cfg.Crawler.Hosts = config.HostFilterConfig{Deny: []string{"*.twimg.com"}}
+	c := NewCrawlerWithDependencies(client, parser, mocks.NewMockMarkdownGenerator(),
+		"test", 1, 0, "", cfg, WithLogger(&mocks.MockLogger{}))
+
+	downloadChan := make(chan types.DownloadTask, 10)
+	markdownChan := make(chan types.MarkdownInfo, 10)
+	c.processArticle(context.Background(), types.ArticleInfo{Title: "全部過濾", URL: "http://example.com/a"}, downloadChan, markdownChan)
+	if len(downloadChan) != 0 || len(markdownChan) != 0 {
+		t.Errorf("不應派發任務，下載 %d 個、Markdown %d 個", len(downloadChan), len(markdownChan))
+	}
+}
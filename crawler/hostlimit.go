@@ -0,0 +1,100 @@
+package crawler
+
+import (
+	"context"
+	"strings"
+	"sync"
+
+	"github.com/twtrubiks/ptt-spider-go/config"
+	"github.com/twtrubiks/ptt-spider-go/interfaces"
+	"github.com/twtrubiks/ptt-spider-go/ratelimit"
+)
+
+// matchHost 在 m 中查找 host 本身或其上層網域的設定，多個相符時使用最長者（最具體的設定）
+func matchHost[T any](m map[string]T, host string) (T, bool) {
+	host = strings.ToLower(host)
+	for {
+		if v, ok := m[host]; ok {
+			return v, true
+		}
+		dot := strings.IndexByte(host, '.')
+		if dot < 0 {
+			var zero T
+			return zero, false
+		}
+		host = host[dot+1:]
+	}
+}
+
+// newHostLimiters 為 rateLimit.hosts 中的每個 host 建立獨立的 token bucket，
+// burst 未設定時沿用全域 burst；沒有設定任何 host 時回傳 nil。
+// map 建立後不再增減，執行期只以 SetRate 調整各桶的速率，可供多個 goroutine 並行讀取。
+func newHostLimiters(cfg config.RateLimitConfig) map[string]*ratelimit.TokenBucket {
+	if len(cfg.Hosts) == 0 {
+		return nil
+	}
+	limiters := make(map[string]*ratelimit.TokenBucket, len(cfg.Hosts))
+	for host, hc := range cfg.Hosts {
+		limiters[strings.ToLower(host)] = ratelimit.NewTokenBucket(hc.RequestsPerSecond, hostBurst(cfg, hc))
+	}
+	return limiters
+}
+
+// hostBurst 回傳 host 速率桶的額度上限，未設定時沿用全域 burst
+func hostBurst(cfg config.RateLimitConfig, hc config.HostRateLimitConfig) int {
+	if hc.Burst > 0 {
+		return hc.Burst
+	}
+	return cfg.Burst
+}
+
+// limiterFor 回傳請求 host 適用的速率限制器：有設定獨立速率的 host（含子網域）使用自己的桶，
+// 其餘共用全域限制器（未設定速率時為 nil）
+func (c *Crawler) limiterFor(host string) interfaces.RateLimiter {
+	if l, ok := matchHost(c.hostLimiters, host); ok {
+		return l
+	}
+	return c.limiter
+}
+
+// hostSemaphores 限制每個 host 同時進行的下載數，各 host 的 semaphore 在第一次使用時建立
+type hostSemaphores struct {
+	size int
+	mu   sync.Mutex
+	sems map[string]chan struct{}
+}
+
+// newHostSemaphores 建立每個 host 最多 n 個同時下載的限制器，n <= 0 時回傳 nil（不限制）
+func newHostSemaphores(n int) *hostSemaphores {
+	if n <= 0 {
+		return nil
+	}
+	return &hostSemaphores{size: n, sems: make(map[string]chan struct{})}
+}
+
+// sem 回傳 host 的 semaphore，不存在時建立
+func (h *hostSemaphores) sem(host string) chan struct{} {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	s, ok := h.sems[host]
+	if !ok {
+		s = make(chan struct{}, h.size)
+		h.sems[host] = s
+	}
+	return s
+}
+
+// acquire 等待取得 host 的下載名額，回傳釋放名額的函式；ctx 取消時回傳 false。
+// h 為 nil（未設定上限）時立即成功。
+func (h *hostSemaphores) acquire(ctx context.Context, host string) (release func(), ok bool) {
+	if h == nil {
+		return func() {}, true
+	}
+	s := h.sem(host)
+	select {
+	case s <- struct{}{}:
+		return func() { <-s }, true
+	case <-ctx.Done():
+		return nil, false
+	}
+}
@@ -0,0 +1,124 @@
+package crawler
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/twtrubiks/ptt-spider-go/config"
+	"github.com/twtrubiks/ptt-spider-go/mocks"
+	"github.com/twtrubiks/ptt-spider-go/types"
+)
+
+// concurrencyCounter 記錄每個 host 與全部 host 合計的同時請求數峰值
+type concurrencyCounter struct {
+	mu      sync.Mutex
+	current map[string]int
+	peak    map[string]int
+	total   int
+	maxAll  int
+}
+
+func (c *concurrencyCounter) enter(host string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.current[host]++
+	c.peak[host] = max(c.peak[host], c.current[host])
+	c.total++
+	c.maxAll = max(c.maxAll, c.total)
+}
+
+func (c *concurrencyCounter) leave(host string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.current[host]--
+	c.total--
+}
+
+// TestDownloadOne_MaxConcurrentPerHost 驗證同一 host 的同時下載數不超過 maxConcurrentPerHost，
+// 不同 host 各自計算，合計並行數仍可超過該上限。
+func TestDownloadOne_MaxConcurrentPerHost(t *testing.T) {
+	t.Chdir(t.TempDir())
+
+	const perHost = 2
+	counter := &concurrencyCounter{current: map[string]int{}, peak: map[string]int{}}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		host, _, _ := net.SplitHostPort(r.Host)
+		counter.enter(host)
+		defer counter.leave(host)
+		time.Sleep(100 * time.Millisecond)
+		_, _ = w.Write([]byte(pngHeader + "image"))
+	}))
+	defer server.Close()
+	_, port, _ := net.SplitHostPort(server.Listener.Addr().String())
+
+	cfg := config.DefaultConfig()
+	cfg.Crawler.Delays = config.DelayConfig{}
+	cfg.Crawler.MaxConcurrentPerHost = perHost
+	c := NewCrawlerWithDependencies(server.Client(), mocks.NewMockParser(), mocks.NewMockMarkdownGenerator(),
+		"test", 1, 0, "", cfg)
+
+	// 127.0.0.1 與 localhost 指向同一個測試伺服器，但對限制器而言是兩個 host
+	var wg sync.WaitGroup
+	for i := range 12 {
+		host := []string{"127.0.0.1", "localhost"}[i%2]
+		task := types.DownloadTask{
+			ImageURL: fmt.Sprintf("http://%s/%d.png", net.JoinHostPort(host, port), i),
+			SavePath: filepath.Join("out", fmt.Sprintf("%d.png", i)),
+		}
+		wg.Go(func() { c.downloadOne(context.Background(), i, task) })
+	}
+	wg.Wait()
+
+	for _, host := range []string{"127.0.0.1", "localhost"} {
+		if got := counter.peak[host]; got > perHost || got == 0 {
+			t.Errorf("%s 同時請求數峰值 = %d, want 1~%d", host, got, perHost)
+		}
+	}
+	if counter.maxAll <= perHost {
+		t.Errorf("合計同時請求數峰值 = %d, 不同 host 應各自計算（want > %d）", counter.maxAll, perHost)
+	}
+	for i := range 12 {
+		if _, err := os.Stat(filepath.Join("out", fmt.Sprintf("%d.png", i))); err != nil {
+			t.Errorf("圖片 %d 未下載: %v", i, err)
+		}
+	}
+}
+
+func TestHostSemaphores_AcquireCancelled(t *testing.T) {
+	sems := newHostSemaphores(1)
+	release, ok := sems.acquire(context.Background(), "i.imgur.com")
+	if !ok {
+		t.Fatal("第一次 acquire 應成功")
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, ok := sems.acquire(ctx, "i.imgur.com"); ok {
+		t.Error("名額用完且 ctx 已取消時 acquire 應失敗")
+	}
+	if r, ok := sems.acquire(context.Background(), "pbs.twimg.com"); !ok {
+		t.Error("其他 host 不受影響")
+	} else {
+		r()
+	}
+
+	release()
+	if r, ok := sems.acquire(context.Background(), "i.imgur.com"); !ok {
+		t.Error("釋放後應可再次取得名額")
+	} else {
+		r()
+	}
+
+	// 未設定上限時不限制，即使 ctx 已取消也立即成功
+	if _, ok := newHostSemaphores(0).acquire(ctx, "i.imgur.com"); !ok {
+		t.Error("nil 限制器應立即成功")
+	}
+}
@@ -0,0 +1,119 @@
+package crawler
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+
+	"github.com/twtrubiks/ptt-spider-go/constants"
+	"github.com/twtrubiks/ptt-spider-go/internal/ioutil"
+)
+
+// imgurAlbumMaxBytes 相簿 JSON 回應的大小上限，避免異常回應耗盡記憶體
+const imgurAlbumMaxBytes = 1 << 20
+
+// imgurAlbumResponse 對應 imgur 相簿 JSON 端點的回應（只取用到的欄位）
+type imgurAlbumResponse struct {
+	Data struct {
+		Images []struct {
+			Hash string `json:"hash"`
+			Ext  string `json:"ext"`
+		} `json:"images"`
+	} `json:"data"`
+	Success bool `json:"success"`
+}
+
+// imgurAlbumID 從 imgur 相簿（/a/ID）或 gallery（/gallery/ID）連結取出相簿 ID。
+// 新版網址的 ID 接在標題後（如 /gallery/cute-cats-AbC12），取最後一個 "-" 之後的部分。
+func imgurAlbumID(albumURL string) (string, bool) {
+	u, err := url.Parse(albumURL)
+	if err != nil {
+		return "", false
+	}
+	if host := u.Hostname(); host != "imgur.com" && !strings.HasSuffix(host, ".imgur.com") {
+		return "", false
+	}
+	parts := strings.Split(strings.Trim(u.Path, "/"), "/")
+	if len(parts) < 2 || (parts[0] != "a" && parts[0] != "gallery") {
+		return "", false
+	}
+	id := parts[1]
+	if i := strings.LastIndex(id, "-"); i >= 0 {
+		id = id[i+1:]
+	}
+	return id, id != ""
+}
+
+// expandImgurAlbums 將相簿連結展開為成員圖片並附加到 imgURLs 之後。
+// 展開失敗的相簿記錄警告後略過，不影響文章其他圖片的下載。
+func (c *Crawler) expandImgurAlbums(ctx context.Context, imgURLs, albumURLs []string) []string {
+	for _, albumURL := range albumURLs {
+		images, err := c.fetchImgurAlbum(ctx, albumURL)
+		if err != nil {
+			if ctx.Err() != nil {
+				return imgURLs
+			}
+			c.logger.Warn("展開 imgur 相簿失敗，已略過: %s, 錯誤: %v", albumURL, err)
+			continue
+		}
+		c.logger.Info("imgur 相簿展開為 %d 張圖片: %s", len(images), albumURL)
+
+		// gallery 連結在解析時會被猜成單張圖片（補上 .jpg），展開成功後以實際成員取代
+		guessed := albumURL + ".jpg"
+		imgURLs = slices.DeleteFunc(imgURLs, func(u string) bool { return u == guessed })
+		imgURLs = append(imgURLs, images...)
+	}
+	return imgURLs
+}
+
+// fetchImgurAlbum 透過 imgur 的公開 JSON 端點取得相簿內所有圖片的直連 URL
+func (c *Crawler) fetchImgurAlbum(ctx context.Context, albumURL string) ([]string, error) {
+	id, ok := imgurAlbumID(albumURL)
+	if !ok {
+		return nil, fmt.Errorf("無法辨識的 imgur 相簿連結")
+	}
+	apiURL := fmt.Sprintf(constants.ImgurAlbumAPIURL, url.PathEscape(id))
+	if !c.robotsAllowed(ctx, apiURL) {
+		return nil, fmt.Errorf("robots.txt 禁止爬取: %s", apiURL)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "GET", apiURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("建立請求失敗: %w", err)
+	}
+	resp, err := c.doRequest(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	defer ioutil.CloseWithLog(resp.Body, "imgur 相簿回應 Body")
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("狀態碼 %d", resp.StatusCode)
+	}
+
+	var album imgurAlbumResponse
+	if err := json.NewDecoder(io.LimitReader(resp.Body, imgurAlbumMaxBytes)).Decode(&album); err != nil {
+		return nil, fmt.Errorf("解析相簿資料失敗: %w", err)
+	}
+	if !album.Success || len(album.Data.Images) == 0 {
+		return nil, fmt.Errorf("相簿不存在或沒有圖片")
+	}
+
+	images := make([]string, 0, len(album.Data.Images))
+	for _, img := range album.Data.Images {
+		if img.Hash == "" {
+			continue
+		}
+		ext := img.Ext
+		if ext == "" {
+			ext = ".jpg"
+		}
+		images = append(images, constants.ImgurImageBaseURL+img.Hash+ext)
+	}
+	return images, nil
+}
@@ -0,0 +1,167 @@
+package crawler
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/twtrubiks/ptt-spider-go/config"
+	"github.com/twtrubiks/ptt-spider-go/mocks"
+	"github.com/twtrubiks/ptt-spider-go/types"
+)
+
+func TestImgurAlbumID(t *testing.T) {
+	tests := []struct {
+		input  string
+		wantID string
+		wantOK bool
+	}{
+		{"https://imgur.com/a/AbC12", "AbC12", true},
+		{"http://imgur.com/a/AbC12/", "AbC12", true},
+		{"https://imgur.com/gallery/XyZ9", "XyZ9", true},
+		{"https://imgur.com/gallery/cute-cats-XyZ9", "XyZ9", true},
+		{"https://m.imgur.com/a/AbC12#0", "AbC12", true},
+		{"https://i.imgur.com/AbC12.jpg", "", false},
+		{"https://imgur.com/a/", "", false},
+		{"https://example.com/a/AbC12", "", false},
+		{"https://notimgur.com/a/AbC12", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			id, ok := imgurAlbumID(tt.input)
+			if id != tt.wantID || ok != tt.wantOK {
+				t.Errorf("imgurAlbumID(%q) = %q, %v, want %q, %v", tt.input, id, ok, tt.wantID, tt.wantOK)
+			}
+		})
+	}
+}
+
+// imgurAlbumClient 依相簿 ID 回傳預先定義的 JSON；未定義的 ID 回傳 404，並計算相簿 API 請求數
+func imgurAlbumClient(albums map[string]string, apiCalls *int32) *mocks.MockHTTPClient {
+	return &mocks.MockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			status, body := http.StatusOK, "<html></html>"
+			if strings.HasPrefix(req.URL.Path, "/ajaxalbums/getimages/") {
+				atomic.AddInt32(apiCalls, 1)
+				id := strings.Split(strings.TrimPrefix(req.URL.Path, "/ajaxalbums/getimages/"), "/")[0]
+				var ok bool
+				if body, ok = albums[id]; !ok {
+					status, body = http.StatusNotFound, ""
+				}
+			}
+			return &http.Response{
+				StatusCode: status,
+				Body:       io.NopCloser(strings.NewReader(body)),
+				Request:    req,
+			}, nil
+		},
+	}
+}
+
+func TestExpandImgurAlbums(t *testing.T) {
+	albums := map[string]string{
+		"Album1": `{"data":{"count":2,"images":[{"hash":"one","ext":".png"},{"hash":"two","ext":""}]},"success":true}`,
+		"Empty":  `{"data":[],"success":true,"status":200}`,
+		"Broken": `not json`,
+		"Gal1":   `{"data":{"images":[{"hash":"g1","ext":".gif"}]},"success":true}`,
+	}
+
+	tests := []struct {
+		name   string
+		images []string
+		albums []string
+		want   []string
+	}{
+		{
+			name:   "相簿展開為成員圖片",
+			images: []string{"https://i.imgur.com/x.jpg"},
+			albums: []string{"https://imgur.com/a/Album1"},
+			want:   []string{"https://i.imgur.com/x.jpg", "https://i.imgur.com/one.png", "https://i.imgur.com/two.jpg"},
+		},
+		{
+			name:   "gallery 展開後取代猜測的單張圖片",
+			images: []string{"https://imgur.com/gallery/Gal1.jpg"},
+			albums: []string{"https://imgur.com/gallery/Gal1"},
+			want:   []string{"https://i.imgur.com/g1.gif"},
+		},
+		{
+			name:   "請求失敗時略過相簿",
+			images: []string{"https://imgur.com/gallery/Missing.jpg"},
+			albums: []string{"https://imgur.com/a/Gone", "https://imgur.com/gallery/Missing"},
+			want:   []string{"https://imgur.com/gallery/Missing.jpg"},
+		},
+		{
+			name:   "空相簿或無法解析的回應時略過",
+			albums: []string{"https://imgur.com/a/Empty", "https://imgur.com/a/Broken"},
+			want:   nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var apiCalls int32
+			c := NewCrawlerWithDependencies(imgurAlbumClient(albums, &apiCalls), mocks.NewMockParser(),
+				mocks.NewMockMarkdownGenerator(), "test", 1, 0, "", config.DefaultConfig(), WithLogger(&mocks.MockLogger{}))
+
+			got := c.expandImgurAlbums(context.Background(), tt.images, tt.albums)
+			if strings.Join(got, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("expandImgurAlbums() = %v, want %v", got, tt.want)
+			}
+			if int(apiCalls) != len(tt.albums) {
+				t.Errorf("相簿 API 請求數 = %d, want %d", apiCalls, len(tt.albums))
+			}
+		})
+	}
+}
+
+func TestProcessArticle_ExpandImgurAlbums(t *testing.T) {
+	albums := map[string]string{
+		"Album1": `{"data":{"images":[{"hash":"one","ext":".png"},{"hash":"two","ext":".jpg"}]},"success":true}`,
+	}
+
+	tests := []struct {
+		name         string
+		enabled      bool
+		wantTasks    int
+		wantAPICalls int32
+	}{
+		{"預設關閉時不發出額外請求", false, 1, 0},
+		{"啟用後相簿圖片成為下載任務", true, 2, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var apiCalls int32
+			parser := &mocks.MockParser{
+				ParseArticleContentFunc: func(_ io.Reader) (types.ArticleContent, error) {
+					return types.ArticleContent{
+						Title:     "相簿文",
+						ImageURLs: []string{"https://i.imgur.com/one.png"},
+						AlbumURLs: []string{"https://imgur.com/a/Album1"},
+					}, nil
+				},
+			}
+			cfg := config.DefaultConfig()
+			cfg.Crawler.Delays = config.DelayConfig{MinMs: 0, MaxMs: 0}
+			cfg.Crawler.ExpandImgurAlbums = tt.enabled
+			c := NewCrawlerWithDependencies(imgurAlbumClient(albums, &apiCalls), parser, mocks.NewMockMarkdownGenerator(),
+				"test", 1, 0, "", cfg, WithLogger(&mocks.MockLogger{}))
+
+			downloadChan := make(chan types.DownloadTask, 10)
+			markdownChan := make(chan types.MarkdownInfo, 10)
+			c.processArticle(context.Background(), types.ArticleInfo{Title: "相簿文", URL: "http://example.com/a"}, downloadChan, markdownChan)
+
+			// 原文直連與相簿成員重複的圖片只下載一次
+			if len(downloadChan) != tt.wantTasks {
+				t.Errorf("下載任務數 = %d, want %d", len(downloadChan), tt.wantTasks)
+			}
+			if apiCalls != tt.wantAPICalls {
+				t.Errorf("相簿 API 請求數 = %d, want %d", apiCalls, tt.wantAPICalls)
+			}
+		})
+	}
+}
@@ -0,0 +1,83 @@
+package crawler
+
+import (
+	"path/filepath"
+	"sync"
+
+	"github.com/twtrubiks/ptt-spider-go/types"
+)
+
+// indexCollector 累積各 Markdown 工人已產生的文章，供全部工人結束後一次產生看板總覽。
+// 任一工人因中斷結束時不產生總覽，與未處理完的文章一起留待續爬。
+type indexCollector struct {
+	mu          sync.Mutex
+	articles    []types.MarkdownInfo
+	interrupted bool
+}
+
+// add 記錄一篇已產生的文章
+func (ic *indexCollector) add(a types.MarkdownInfo) {
+	ic.mu.Lock()
+	defer ic.mu.Unlock()
+	ic.articles = append(ic.articles, a)
+}
+
+// interrupt 標記有工人因中斷結束
+func (ic *indexCollector) interrupt() {
+	ic.mu.Lock()
+	defer ic.mu.Unlock()
+	ic.interrupted = true
+}
+
+// take 取出累積的文章並清空，曾經中斷時回傳 nil
+func (ic *indexCollector) take() []types.MarkdownInfo {
+	ic.mu.Lock()
+	defer ic.mu.Unlock()
+	articles := ic.articles
+	ic.articles = nil
+	if ic.interrupted {
+		return nil
+	}
+	return articles
+}
+
+// generateIndexes 依看板目錄分組，將本次產生的文章合併進每個看板的文章總覽
+func (c *Crawler) generateIndexes(articles []types.MarkdownInfo) {
+	if len(articles) == 0 {
+		return
+	}
+	byDir := make(map[string][]types.MarkdownInfo)
+	var dirs []string
+	for _, a := range articles {
+		dir := filepath.Dir(a.SaveDir)
+		if dir == "." {
+			dir = "" // 未指定看板與輸出根目錄時文章直接存放於目前目錄
+		}
+		if _, ok := byDir[dir]; !ok {
+			dirs = append(dirs, dir)
+		}
+		byDir[dir] = append(byDir[dir], a)
+	}
+
+	for _, dir := range dirs {
+		log := c.boardLogger(c.dirBoard(dir))
+		if c.dryRun != nil {
+			log.Info("[dry-run] 預計產生文章總覽（%d 篇文章）於目錄: %s", len(byDir[dir]), filepath.Join(".", dir))
+			continue
+		}
+		if err := c.markdownGenerator.GenerateIndex(dir, byDir[dir]); err != nil {
+			c.logger.Error("產生文章總覽失敗: %v", err)
+			continue
+		}
+		log.Success("已更新文章總覽（本次 %d 篇文章）於目錄: %s", len(byDir[dir]), filepath.Join(".", dir))
+	}
+}
+
+// dirBoard 由看板目錄反推看板名稱（去除輸出根目錄），供依看板分檔的日誌使用
+func (c *Crawler) dirBoard(dir string) string {
+	board, err := filepath.Rel(c.boardDir(""), dir)
+	if err != nil || board == "." {
+		return ""
+	}
+	return board
+}
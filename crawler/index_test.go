@@ -0,0 +1,69 @@
+package crawler
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"sync"
+	"testing"
+
+	"github.com/twtrubiks/ptt-spider-go/config"
+	"github.com/twtrubiks/ptt-spider-go/mocks"
+	"github.com/twtrubiks/ptt-spider-go/types"
+)
+
+// runMarkdownWorker 將 tasks 全部送入 markdownWorker 並關閉 channel，等待 worker 結束
+func runMarkdownWorker(c *Crawler, tasks ...types.MarkdownInfo) {
+	ch := make(chan types.MarkdownInfo, len(tasks))
+	for _, task := range tasks {
+		ch <- task
+	}
+	close(ch)
+	var wg sync.WaitGroup
+	wg.Add(1)
+	c.markdownWorker(context.Background(), 1, ch, &wg)
+	wg.Wait()
+	c.generateIndexes(c.indexed.take())
+}
+
+func TestMarkdownWorker_GeneratesIndexPerBoard(t *testing.T) {
+	for _, enabled := range []bool{true, false} {
+		indexes := make(map[string][]string) // 看板目錄 → 文章 URL
+		gen := &mocks.MockMarkdownGenerator{
+			GenerateFunc: func(info types.MarkdownInfo) error {
+				if info.ArticleURL == "fail" {
+					return errors.New("disk full")
+				}
+				return nil
+			},
+			GenerateIndexFunc: func(dir string, articles []types.MarkdownInfo) error {
+				for _, a := range articles {
+					indexes[dir] = append(indexes[dir], a.ArticleURL)
+				}
+				return nil
+			},
+		}
+		cfg := config.DefaultConfig()
+		cfg.Crawler.Output.Index = enabled
+		c := NewCrawlerWithDependencies(&mocks.MockHTTPClient{}, mocks.NewMockParser(), gen, "Beauty,Gossiping", 1, 0, "", cfg)
+
+		runMarkdownWorker(c,
+			types.MarkdownInfo{ArticleURL: "a", SaveDir: "Beauty/a_1"},
+			types.MarkdownInfo{ArticleURL: "b", SaveDir: "Gossiping/b_2"},
+			types.MarkdownInfo{ArticleURL: "fail", SaveDir: "Beauty/fail_3"}, // 產生失敗的文章不列入總覽
+			types.MarkdownInfo{ArticleURL: "c", SaveDir: "Beauty/c_4"},
+			types.MarkdownInfo{ArticleURL: "d", SaveDir: "d_5"}, // 未指定看板
+		)
+
+		if !enabled {
+			if len(indexes) != 0 {
+				t.Errorf("未啟用 output.index 時不應產生總覽: %v", indexes)
+			}
+			continue
+		}
+		want := map[string][]string{"Beauty": {"a", "c"}, "Gossiping": {"b"}, "": {"d"}}
+		if !reflect.DeepEqual(indexes, want) {
+			t.Errorf("總覽 = %v, want %v", indexes, want)
+		}
+	}
+}
@@ -0,0 +1,37 @@
+package crawler
+
+import (
+	"runtime"
+	"time"
+)
+
+const (
+	// goroutineLeakThreshold 結束時 goroutine 數比起始多出超過此數量才視為可能洩漏。
+	// 收尾時仍在執行的輔助 goroutine（效能監控、進度報告、checkpoint 定期寫入、配置檔監看）不超過此數
+	goroutineLeakThreshold = 10
+	// goroutineSettleTimeout 比較前等待收尾中 goroutine 結束的時間上限（如關閉閒置連線後的讀寫迴圈）
+	goroutineSettleTimeout = 500 * time.Millisecond
+)
+
+// checkGoroutineLeak 比較結束與起始（baseline）的 goroutine 數，異常增長時警告可能有洩漏。
+// 比較前先關閉 HTTP 閒置連線，避免 keep-alive 連線的讀寫 goroutine 被誤判為洩漏。
+func (c *Crawler) checkGoroutineLeak(baseline int) {
+	if closer, ok := c.client.(interface{ CloseIdleConnections() }); ok {
+		closer.CloseIdleConnections()
+	}
+
+	limit := baseline + goroutineLeakThreshold
+	current := runtime.NumGoroutine()
+	deadline := time.Now().Add(goroutineSettleTimeout)
+	for current > limit && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+		current = runtime.NumGoroutine()
+	}
+
+	if current > limit {
+		c.logger.Warn("可能有 goroutine 洩漏：結束時 %d 個，起始時 %d 個（增加 %d 個，門檻 %d）",
+			current, baseline, current-baseline, goroutineLeakThreshold)
+		return
+	}
+	c.logger.Debug("goroutine 數：起始 %d 個，結束 %d 個", baseline, current)
+}
@@ -0,0 +1,73 @@
+package crawler
+
+import (
+	"fmt"
+	"runtime"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/twtrubiks/ptt-spider-go/config"
+	"github.com/twtrubiks/ptt-spider-go/mocks"
+)
+
+// closeIdleClient 記錄 CloseIdleConnections 是否被呼叫
+type closeIdleClient struct {
+	mocks.MockHTTPClient
+	closed bool
+}
+
+func (c *closeIdleClient) CloseIdleConnections() { c.closed = true }
+
+// settledGoroutines 等待前面測試遺留的收尾 goroutine（如 httptest 連線的讀寫迴圈）結束後
+// 回傳 goroutine 數，避免基準值偏高而使洩漏判斷失準
+func settledGoroutines() int {
+	n := runtime.NumGoroutine()
+	for range 100 {
+		time.Sleep(10 * time.Millisecond)
+		next := runtime.NumGoroutine()
+		if next == n {
+			return n
+		}
+		n = next
+	}
+	return n
+}
+
+func TestCheckGoroutineLeak(t *testing.T) {
+	var (
+		mu    sync.Mutex
+		warns []string
+	)
+	logger := &mocks.MockLogger{
+		WarnFunc: func(format string, args ...any) {
+			mu.Lock()
+			defer mu.Unlock()
+			warns = append(warns, fmt.Sprintf(format, args...))
+		},
+	}
+	client := &closeIdleClient{}
+	c := NewCrawlerWithDependencies(client, mocks.NewMockParser(), mocks.NewMockMarkdownGenerator(),
+		"test", 1, 0, "", config.DefaultConfig(), WithLogger(logger))
+
+	baseline := settledGoroutines()
+	c.checkGoroutineLeak(baseline)
+	if len(warns) != 0 {
+		t.Errorf("goroutine 數未增加時不應警告: %v", warns)
+	}
+	if !client.closed {
+		t.Error("比較前應關閉 HTTP 閒置連線")
+	}
+
+	// 模擬洩漏：啟動超過門檻數量、永遠不會結束的 goroutine
+	block := make(chan struct{})
+	defer close(block)
+	for range goroutineLeakThreshold + 5 {
+		go func() { <-block }()
+	}
+	c.checkGoroutineLeak(baseline)
+	if len(warns) != 1 || !strings.Contains(warns[0], "goroutine 洩漏") {
+		t.Errorf("goroutine 異常增長時應警告一次，got %v", warns)
+	}
+}
@@ -0,0 +1,47 @@
+package crawler
+
+import (
+	"log/slog"
+	"net/http"
+
+	"github.com/twtrubiks/ptt-spider-go/ui"
+)
+
+// 下載流程的結構化事件名稱（JSON 日誌的 event 欄位）
+const (
+	eventDownloadStart  = "download_start"
+	eventDownloadDone   = "download_done"
+	eventDownloadFailed = "download_failed"
+)
+
+// logDownload 輸出下載事件，附加 worker_id 與 url 欄位；純文字模式下只輸出格式化訊息
+func logDownload(log ui.Logger, level slog.Level, event string, id int, imageURL string, extra []slog.Attr, format string, args ...any) {
+	attrs := append([]slog.Attr{slog.Int(ui.FieldWorkerID, id), slog.String(ui.FieldURL, imageURL)}, extra...)
+	ui.LogEvent(log, level, event, attrs, format, args...)
+}
+
+// routineLogger 回傳逐篇文章、逐張圖片等例行訊息使用的 Logger：啟用 -quiet 時只保留警告與錯誤，
+// 大量爬取時不會被「下載完成」等訊息洗版
+func (c *Crawler) routineLogger(log ui.Logger) ui.Logger {
+	if !c.quiet {
+		return log
+	}
+	return ui.NewLevelLogger(log, slog.LevelWarn)
+}
+
+// boardLogger 回傳看板專屬的 Logger：c.logger 支援 ui.BoardRouter（依看板分檔的日誌）時，
+// 訊息會同時寫入該看板的日誌檔；否則直接回傳 c.logger
+func (c *Crawler) boardLogger(board string) ui.Logger {
+	if r, ok := c.logger.(ui.BoardRouter); ok && board != "" {
+		return r.ForBoard(board)
+	}
+	return c.logger
+}
+
+// responseURL 取得回應對應的請求 URL（測試用的假回應可能沒有 Request）
+func responseURL(resp *http.Response) string {
+	if resp.Request == nil || resp.Request.URL == nil {
+		return ""
+	}
+	return resp.Request.URL.String()
+}
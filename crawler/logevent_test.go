@@ -0,0 +1,190 @@
+package crawler
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/twtrubiks/ptt-spider-go/config"
+	"github.com/twtrubiks/ptt-spider-go/mocks"
+	"github.com/twtrubiks/ptt-spider-go/types"
+	"github.com/twtrubiks/ptt-spider-go/ui"
+)
+
+// collectEvents 解析 NDJSON 輸出，回傳帶 event 欄位的紀錄
+func collectEvents(t *testing.T, buf *bytes.Buffer) []map[string]any {
+	t.Helper()
+	var events []map[string]any
+	scanner := bufio.NewScanner(buf)
+	for scanner.Scan() {
+		var rec map[string]any
+		if err := json.Unmarshal(scanner.Bytes(), &rec); err != nil {
+			t.Fatalf("非合法 JSON 行: %q", scanner.Text())
+		}
+		if _, ok := rec["event"]; ok {
+			events = append(events, rec)
+		}
+	}
+	return events
+}
+
+func TestDownloadWorker_StructuredEvents(t *testing.T) {
+	body := pngHeader + "image-data"
+	tests := []struct {
+		name       string
+		status     int
+		wantEvents []string
+		wantLast   map[string]any
+	}{
+		{
+			name:       "下載成功",
+			status:     http.StatusOK,
+			wantEvents: []string{eventDownloadStart, eventDownloadDone},
+			wantLast:   map[string]any{"level": "INFO", "bytes": float64(len(body)), "success": true},
+		},
+		{
+			name:       "下載失敗",
+			status:     http.StatusNotFound,
+			wantEvents: []string{eventDownloadStart, eventDownloadFailed},
+			wantLast:   map[string]any{"level": "ERROR", "status": float64(http.StatusNotFound)},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := &mocks.MockHTTPClient{
+				DoFunc: func(req *http.Request) (*http.Response, error) {
+					return &http.Response{
+						StatusCode: tt.status,
+						Body:       io.NopCloser(strings.NewReader(body)),
+						Request:    req,
+					}, nil
+				},
+			}
+			cfg := config.DefaultConfig()
+			cfg.Crawler.Delays = config.DelayConfig{MinMs: 0, MaxMs: 0}
+
+			var buf bytes.Buffer
+			logger := ui.NewSlogLogger(slog.New(ui.NewJSONHandler(&buf, slog.LevelDebug)))
+			c := NewCrawlerWithDependencies(client, mocks.NewMockParser(), mocks.NewMockMarkdownGenerator(),
+				"test", 1, 0, "", cfg, WithLogger(logger))
+
+			imageURL := "http://example.com/a.png"
+			runDownloadWorker(t, c, types.DownloadTask{ImageURL: imageURL, SavePath: filepath.Join(t.TempDir(), "a.png")})
+
+			events := collectEvents(t, &buf)
+			if len(events) != len(tt.wantEvents) {
+				t.Fatalf("事件數 = %d, want %d: %v", len(events), len(tt.wantEvents), events)
+			}
+			for i, name := range tt.wantEvents {
+				if events[i]["event"] != name {
+					t.Errorf("第 %d 個事件 = %v, want %s", i, events[i]["event"], name)
+				}
+				if events[i]["url"] != imageURL || events[i]["worker_id"] != float64(1) {
+					t.Errorf("事件 %s 缺少 url 或 worker_id: %v", name, events[i])
+				}
+			}
+			last := events[len(events)-1]
+			for k, v := range tt.wantLast {
+				if last[k] != v {
+					t.Errorf("欄位 %s = %v, want %v", k, last[k], v)
+				}
+			}
+		})
+	}
+}
+
+// TestDownloadWorker_Quiet 驗證 -quiet 時不輸出延遲、開始與完成下載等例行訊息，下載失敗仍照常輸出
+func TestDownloadWorker_Quiet(t *testing.T) {
+	tests := []struct {
+		name       string
+		status     int
+		wantEvents []string
+	}{
+		{"下載成功不輸出", http.StatusOK, nil},
+		{"下載失敗仍輸出", http.StatusNotFound, []string{eventDownloadFailed}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := &mocks.MockHTTPClient{
+				DoFunc: func(req *http.Request) (*http.Response, error) {
+					return &http.Response{StatusCode: tt.status, Body: io.NopCloser(strings.NewReader(pngHeader)), Request: req}, nil
+				},
+			}
+			cfg := config.DefaultConfig()
+			cfg.Crawler.Delays = config.DelayConfig{}
+
+			var buf bytes.Buffer
+			logger := ui.NewSlogLogger(slog.New(ui.NewJSONHandler(&buf, slog.LevelDebug)))
+			c := NewCrawlerWithDependencies(client, mocks.NewMockParser(), mocks.NewMockMarkdownGenerator(),
+				"test", 1, 0, "", cfg, WithLogger(logger), WithQuiet(true))
+
+			runDownloadWorker(t, c, types.DownloadTask{ImageURL: "http://example.com/a.png", SavePath: filepath.Join(t.TempDir(), "a.png")})
+
+			if strings.Contains(buf.String(), "延遲") {
+				t.Errorf("-quiet 時不應輸出下載前延遲:\n%s", buf.String())
+			}
+			var got []string
+			for _, e := range collectEvents(t, &buf) {
+				got = append(got, e["event"].(string))
+			}
+			if !slices.Equal(got, tt.wantEvents) {
+				t.Errorf("事件 = %v, want %v", got, tt.wantEvents)
+			}
+		})
+	}
+}
+
+func TestDownloadWorker_RoutesLogsByBoard(t *testing.T) {
+	client := &mocks.MockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       io.NopCloser(strings.NewReader(pngHeader + "image-data")),
+				Request:    req,
+			}, nil
+		},
+	}
+	cfg := config.DefaultConfig()
+	cfg.Crawler.Delays = config.DelayConfig{MinMs: 0, MaxMs: 0}
+
+	dir := t.TempDir()
+	mainPath := filepath.Join(dir, "crawl.log")
+	var base bytes.Buffer
+	newLog := func(w io.Writer) ui.Logger { return ui.NewFileLogger(w, "json", slog.LevelDebug) }
+	logger := ui.NewBoardFileLogger(newLog(&base), mainPath, 4, newLog)
+	c := NewCrawlerWithDependencies(client, mocks.NewMockParser(), mocks.NewMockMarkdownGenerator(),
+		"Beauty,Gossiping", 1, 0, "", cfg, WithLogger(logger))
+
+	runDownloadWorker(t, c, types.DownloadTask{
+		ImageURL: "http://example.com/a.png",
+		SavePath: filepath.Join(dir, "Beauty", "a.png"),
+		Board:    "Beauty",
+	})
+	if err := logger.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+
+	data, err := os.ReadFile(ui.BoardLogPath(mainPath, "Beauty"))
+	if err != nil {
+		t.Fatalf("應建立 Beauty 看板日誌檔: %v", err)
+	}
+	events := collectEvents(t, bytes.NewBuffer(data))
+	if len(events) == 0 || events[len(events)-1]["event"] != eventDownloadDone {
+		t.Errorf("Beauty 看板日誌應記錄下載事件，got %v", events)
+	}
+	if len(collectEvents(t, &base)) != len(events) {
+		t.Error("看板訊息應同時寫入主日誌")
+	}
+	if _, err := os.Stat(ui.BoardLogPath(mainPath, "Gossiping")); !os.IsNotExist(err) {
+		t.Errorf("未使用的看板不應建立日誌檔, err = %v", err)
+	}
+}
@@ -0,0 +1,69 @@
+package crawler
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/twtrubiks/ptt-spider-go/performance"
+	"github.com/twtrubiks/ptt-spider-go/types"
+)
+
+// logMetrics 在爬蟲結束時輸出執行期指標摘要
+func (c *Crawler) logMetrics() {
+	if c.metrics == nil {
+		return
+	}
+	snap := c.metrics.Snapshot()
+
+	c.logger.Info("請求統計: 共 %d 次，錯誤 %d 次，延遲 平均 %v / P50 %v / P95 %v / P99 %v",
+		snap.TotalRequests, snap.TotalErrors, snap.AvgLatency, snap.P50Latency, snap.P95Latency, snap.P99Latency)
+	c.logger.Info("下載統計: 解析文章 %d 篇，下載圖片 %d 張，寫入 %s",
+		snap.ArticlesParsed, snap.ImagesDownloaded, performance.FormatBytes(uint64(snap.BytesWritten)))
+	if snap.ImagesPrechecked > 0 {
+		c.logger.Info("HEAD 預檢: %d 張圖片排入下載，預計 %s", snap.ImagesPrechecked, performance.FormatBytes(uint64(snap.BytesExpected)))
+	}
+	if len(snap.StatusCodes) > 0 {
+		c.logger.Info("狀態碼分布: %s", formatStatusCodes(snap))
+	}
+	if len(snap.ErrorsByType) > 0 {
+		c.logger.Info("錯誤類型: %s", formatErrorsByType(snap))
+	}
+}
+
+// formatStatusCodes 依狀態碼排序輸出，如 "200=12, 404=1"
+func formatStatusCodes(snap types.MetricsSnapshot) string {
+	codes := make([]int, 0, len(snap.StatusCodes))
+	for code := range snap.StatusCodes {
+		codes = append(codes, code)
+	}
+	slices.Sort(codes)
+
+	parts := make([]string, 0, len(codes))
+	for _, code := range codes {
+		parts = append(parts, fmt.Sprintf("%d=%d", code, snap.StatusCodes[code]))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// formatErrorsByType 依錯誤類型名稱排序輸出，如 "network=2, parse=1"
+func formatErrorsByType(snap types.MetricsSnapshot) string {
+	kinds := make([]string, 0, len(snap.ErrorsByType))
+	for kind := range snap.ErrorsByType {
+		kinds = append(kinds, kind)
+	}
+	slices.Sort(kinds)
+
+	parts := make([]string, 0, len(kinds))
+	for _, kind := range kinds {
+		parts = append(parts, fmt.Sprintf("%s=%d", kind, snap.ErrorsByType[kind]))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// recordError 記錄指定類型的錯誤，未設定收集器時不執行任何操作
+func (c *Crawler) recordError(kind string) {
+	if c.metrics != nil {
+		c.metrics.RecordError(kind)
+	}
+}
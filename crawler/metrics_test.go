@@ -0,0 +1,109 @@
+package crawler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/twtrubiks/ptt-spider-go/config"
+	"github.com/twtrubiks/ptt-spider-go/metrics"
+	"github.com/twtrubiks/ptt-spider-go/mocks"
+	"github.com/twtrubiks/ptt-spider-go/types"
+)
+
+// TestRun_CollectsMetrics 以 mock 跑完整流程，驗證請求、狀態碼、錯誤類型與下載量的統計
+func TestRun_CollectsMetrics(t *testing.T) {
+	t.Chdir(t.TempDir())
+
+	imageBody := pngHeader + "image-data"
+	client := &mocks.MockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			status, body := http.StatusOK, req.URL.Path
+			switch req.URL.Path {
+			case "/img/ok.png":
+				body = imageBody
+			case "/img/missing.png":
+				status = http.StatusNotFound
+			}
+			return &http.Response{
+				StatusCode: status,
+				Body:       io.NopCloser(strings.NewReader(body)),
+				Request:    req,
+			}, nil
+		},
+	}
+	parser := &mocks.MockParser{
+		ParseMaxPageFunc: func(_ io.Reader) (int, error) { return 1, nil },
+		ParseArticlesFunc: func(_ io.Reader) ([]types.ArticleInfo, error) {
+			return []types.ArticleInfo{
+				{Title: "ok", URL: "https://www.ptt.cc/bbs/test/M.1.A.html", PushRate: 10},
+				{Title: "bad", URL: "https://www.ptt.cc/bbs/test/M.2.A.html", PushRate: 10},
+			}, nil
+		},
+		ParseArticleContentFunc: func(body io.Reader) (types.ArticleContent, error) {
+			data, _ := io.ReadAll(body)
+			if strings.HasSuffix(string(data), "M.2.A.html") {
+				return types.ArticleContent{}, errors.New("unexpected html")
+			}
+			return types.ArticleContent{Title: "ok", ImageURLs: []string{"http://example.com/img/ok.png", "http://example.com/img/missing.png"}}, nil
+		},
+	}
+
+	cfg := config.DefaultConfig()
+	cfg.Crawler.Workers = config.FixedWorkers(2)
+	cfg.Crawler.ParserCount = 2
+	cfg.Crawler.Delays = config.DelayConfig{MinMs: 0, MaxMs: 0}
+
+	var (
+		mu        sync.Mutex
+		infoLines []string
+	)
+	logger := &mocks.MockLogger{
+		InfoFunc: func(format string, args ...any) {
+			mu.Lock()
+			defer mu.Unlock()
+			infoLines = append(infoLines, fmt.Sprintf(format, args...))
+		},
+	}
+	collector := metrics.NewCollector()
+	c := NewCrawlerWithDependencies(client, parser, mocks.NewMockMarkdownGenerator(),
+		"test", 1, 0, "", cfg, WithMetrics(collector), WithLogger(logger))
+
+	c.Run(context.Background())
+
+	snap := collector.Snapshot()
+	// index.html + index1.html + 2 篇文章 + 2 張圖片
+	if snap.TotalRequests != 6 {
+		t.Errorf("TotalRequests = %d, want 6", snap.TotalRequests)
+	}
+	if snap.StatusCodes[http.StatusOK] != 5 || snap.StatusCodes[http.StatusNotFound] != 1 {
+		t.Errorf("StatusCodes = %v, want 200=5 404=1", snap.StatusCodes)
+	}
+	if snap.ErrorsByType[metrics.ErrorHTTPStatus] != 1 || snap.ErrorsByType[metrics.ErrorParse] != 1 || snap.TotalErrors != 2 {
+		t.Errorf("ErrorsByType = %v (total %d), want http_status=1 parse=1", snap.ErrorsByType, snap.TotalErrors)
+	}
+	if snap.ArticlesParsed != 1 {
+		t.Errorf("ArticlesParsed = %d, want 1", snap.ArticlesParsed)
+	}
+	if snap.ImagesDownloaded != 1 || snap.BytesWritten != int64(len(imageBody)) {
+		t.Errorf("下載統計 = %d 張 / %d bytes, want 1 / %d", snap.ImagesDownloaded, snap.BytesWritten, len(imageBody))
+	}
+
+	// logCompletion 應輸出指標摘要
+	mu.Lock()
+	defer mu.Unlock()
+	var summary string
+	for _, line := range infoLines {
+		if strings.HasPrefix(line, "請求統計") || strings.HasPrefix(line, "狀態碼分布") {
+			summary += line + "\n"
+		}
+	}
+	if !strings.Contains(summary, "共 6 次") || !strings.Contains(summary, "200=5, 404=1") {
+		t.Errorf("結束時應輸出指標摘要，got %q", summary)
+	}
+}
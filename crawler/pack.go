@@ -0,0 +1,242 @@
+package crawler
+
+import (
+	"archive/tar"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/twtrubiks/ptt-spider-go/config"
+	"github.com/twtrubiks/ptt-spider-go/constants"
+	crawlererrors "github.com/twtrubiks/ptt-spider-go/errors"
+	"github.com/twtrubiks/ptt-spider-go/internal/ioutil"
+	"github.com/twtrubiks/ptt-spider-go/markdown"
+	"github.com/twtrubiks/ptt-spider-go/metrics"
+	"github.com/twtrubiks/ptt-spider-go/types"
+	"github.com/twtrubiks/ptt-spider-go/ui"
+)
+
+// imagePacker 將同一篇文章的圖片依序寫入文章目錄下的 images.tar（pack: tar 時使用），
+// 大量小圖不必各自建檔、關檔。封存檔在第一張圖片寫入時建立，
+// 該篇圖片全部下載完成後由 close 寫入結尾並關閉，README.md 等輸出檔才會產生。
+type imagePacker struct {
+	mu       sync.Mutex
+	archives map[string]*imageArchive // 文章目錄 → 寫入中的封存檔
+}
+
+// imageArchive 寫入中的單一封存檔；同一篇文章的圖片並行下載，寫入 tar 需互斥
+type imageArchive struct {
+	mu    sync.Mutex
+	file  *os.File
+	tw    *tar.Writer
+	sizes map[string]int64 // 封存檔內的檔名 → 大小，供執行報告計入下載量
+}
+
+// newImagePacker 依配置建立圖片打包器，pack 為 off 時回傳 nil（每張圖片一個檔案）
+func newImagePacker(cfg *config.Config) *imagePacker {
+	if cfg.Crawler.Pack != config.PackTar {
+		return nil
+	}
+	return &imagePacker{archives: make(map[string]*imageArchive)}
+}
+
+// archive 取得 saveDir 寫入中的封存檔，尚未建立時建立目錄與 images.tar
+func (p *imagePacker) archive(saveDir string) (*imageArchive, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if a, ok := p.archives[saveDir]; ok {
+		return a, nil
+	}
+
+	if err := os.MkdirAll(saveDir, constants.DirPermission); err != nil {
+		return nil, fmt.Errorf("建立目錄失敗: %w", err)
+	}
+	file, err := os.Create(filepath.Join(saveDir, markdown.ImageArchiveFileName))
+	if err != nil {
+		return nil, fmt.Errorf("建立封存檔失敗: %w", err)
+	}
+	a := &imageArchive{file: file, tw: tar.NewWriter(file), sizes: make(map[string]int64)}
+	p.archives[saveDir] = a
+	return a, nil
+}
+
+// add 將 r 中大小為 size 的圖片內容以 savePath 的檔名寫入所屬文章目錄的封存檔
+func (p *imagePacker) add(savePath string, r io.Reader, size int64) error {
+	a, err := p.archive(filepath.Dir(savePath))
+	if err != nil {
+		return err
+	}
+	name := filepath.Base(savePath)
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	hdr := &tar.Header{
+		Name:    name,
+		Mode:    int64(constants.FilePermission),
+		Size:    size,
+		ModTime: time.Now(),
+	}
+	if err := a.tw.WriteHeader(hdr); err != nil {
+		return fmt.Errorf("寫入封存檔失敗: %w", err)
+	}
+	if _, err := io.Copy(a.tw, r); err != nil {
+		return fmt.Errorf("寫入封存檔失敗: %w", err)
+	}
+	a.sizes[name] = size
+	return nil
+}
+
+// size 回傳已寫入封存檔的圖片大小，不在封存檔中（或未啟用打包）時回傳 false
+func (p *imagePacker) size(savePath string) (int64, bool) {
+	if p == nil {
+		return 0, false
+	}
+	p.mu.Lock()
+	a, ok := p.archives[filepath.Dir(savePath)]
+	p.mu.Unlock()
+	if !ok {
+		return 0, false
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	n, ok := a.sizes[filepath.Base(savePath)]
+	return n, ok
+}
+
+// close 寫入 saveDir 封存檔的結尾並關閉；該篇沒有任何圖片寫入時不做任何事
+func (p *imagePacker) close(saveDir string) error {
+	p.mu.Lock()
+	a, ok := p.archives[saveDir]
+	delete(p.archives, saveDir)
+	p.mu.Unlock()
+	if !ok {
+		return nil
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	err := a.tw.Close()
+	if cerr := a.file.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		return fmt.Errorf("關閉封存檔 %s 失敗: %w", a.file.Name(), err)
+	}
+	return nil
+}
+
+// closeAll 關閉所有尚未關閉的封存檔（如中斷時批次下載未完成的文章），回傳第一個錯誤
+func (p *imagePacker) closeAll() error {
+	p.mu.Lock()
+	dirs := make([]string, 0, len(p.archives))
+	for dir := range p.archives {
+		dirs = append(dirs, dir)
+	}
+	p.mu.Unlock()
+
+	var first error
+	for _, dir := range dirs {
+		if err := p.close(dir); err != nil && first == nil {
+			first = err
+		}
+	}
+	return first
+}
+
+// packImage 將圖片寫入封存檔，取代 saveToFile 的建檔與寫檔；大小上限與失敗時的記錄方式與寫入個別檔案相同。
+// tar 標頭需先寫入大小，因此先將回應串流至文章目錄下的暫存檔，確定大小後再複製進封存檔，
+// 下載中的圖片不佔用記憶體，也不會在下載期間鎖住封存檔。
+func (c *Crawler) packImage(body io.Reader, task types.DownloadTask, id int, imageURL string) bool {
+	log := c.boardLogger(task.Board)
+	savePath := task.SavePath
+	pathAttr := slog.String(ui.FieldPath, savePath)
+
+	dir := filepath.Dir(savePath)
+	if err := os.MkdirAll(dir, constants.DirPermission); err != nil {
+		logDownload(log, slog.LevelError, eventDownloadFailed, id, imageURL, []slog.Attr{pathAttr, slog.Any(ui.FieldError, err)},
+			"工人 #%d 建立目錄失敗: %s, 錯誤: %v", id, dir, err)
+		c.recordError(metrics.ErrorWrite)
+		c.downloadFailed(task, crawlererrors.FailureWrite, "建立目錄失敗", err)
+		return false
+	}
+	spool, err := os.CreateTemp(dir, "."+filepath.Base(savePath)+".*.part")
+	if err != nil {
+		logDownload(log, slog.LevelError, eventDownloadFailed, id, imageURL, []slog.Attr{pathAttr, slog.Any(ui.FieldError, err)},
+			"工人 #%d 建立暫存檔失敗: %s, 錯誤: %v", id, savePath, err)
+		c.recordError(metrics.ErrorWrite)
+		c.downloadFailed(task, crawlererrors.FailureWrite, "建立暫存檔失敗", err)
+		return false
+	}
+	defer func() {
+		ioutil.CloseWithLog(spool, fmt.Sprintf("工人 #%d 暫存檔", id))
+		removeIncompleteFile(log, spool.Name(), id)
+	}()
+
+	// 多讀 1 byte 用於偵測回應是否超過大小上限
+	maxBytes := int64(c.config.Crawler.MaxImageBytes)
+	if maxBytes > 0 {
+		body = io.LimitReader(body, maxBytes+1)
+	}
+	written, err := c.copier.Copy(spool, body)
+	if err != nil {
+		logDownload(log, slog.LevelError, eventDownloadFailed, id, imageURL, []slog.Attr{pathAttr, slog.Any(ui.FieldError, err)},
+			"工人 #%d 讀取回應失敗: %s, 錯誤: %v", id, savePath, err)
+		c.downloadFailed(task, crawlererrors.ClassifyDownloadFailure(0, err), "讀取回應失敗", err)
+		c.emit(types.ProgressEvent{
+			Type:     types.EventDownloadFail,
+			WorkerID: id,
+			Message:  fmt.Sprintf("讀取失敗: %s", savePath),
+		})
+		return false
+	}
+	if maxBytes > 0 && written > maxBytes {
+		c.oversizeDiscarded(log, id, imageURL, task, written)
+		return false
+	}
+
+	if _, err = spool.Seek(0, io.SeekStart); err == nil {
+		err = c.packer.add(savePath, spool, written)
+	}
+	if err != nil {
+		logDownload(log, slog.LevelError, eventDownloadFailed, id, imageURL, []slog.Attr{pathAttr, slog.Any(ui.FieldError, err)},
+			"工人 #%d 寫入封存檔失敗: %s, 錯誤: %v", id, savePath, err)
+		c.recordError(metrics.ErrorWrite)
+		c.downloadFailed(task, crawlererrors.FailureWrite, "寫入封存檔失敗", err)
+		c.emit(types.ProgressEvent{
+			Type:     types.EventDownloadFail,
+			WorkerID: id,
+			Message:  fmt.Sprintf("寫入失敗: %s", savePath),
+		})
+		return false
+	}
+
+	c.downloadSaved(log, id, imageURL, savePath, written)
+	return true
+}
+
+// archiveName 回傳 Markdown 引用的封存檔名。未啟用打包，或該篇沒有任何圖片寫入封存檔
+// （圖片全部下載失敗或被過濾）而未建立 images.tar 時為空字串，輸出檔不引用不存在的封存檔。
+func (c *Crawler) archiveName(saveDir string) string {
+	if c.packer == nil {
+		return ""
+	}
+	if _, err := os.Stat(filepath.Join(saveDir, markdown.ImageArchiveFileName)); err != nil {
+		return ""
+	}
+	return markdown.ImageArchiveFileName
+}
+
+// closeArchive 關閉文章的封存檔，關閉失敗時記錄錯誤（封存檔可能不完整）
+func (c *Crawler) closeArchive(board, saveDir string) {
+	if c.packer == nil {
+		return
+	}
+	if err := c.packer.close(saveDir); err != nil {
+		c.boardLogger(board).Error("%v", err)
+		c.recordError(metrics.ErrorWrite)
+	}
+}
@@ -0,0 +1,143 @@
+package crawler
+
+import (
+	"archive/tar"
+	"errors"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/twtrubiks/ptt-spider-go/config"
+	"github.com/twtrubiks/ptt-spider-go/markdown"
+	"github.com/twtrubiks/ptt-spider-go/mocks"
+	"github.com/twtrubiks/ptt-spider-go/types"
+)
+
+// readTar 讀出封存檔內的檔名 → 內容
+func readTar(t *testing.T, path string) map[string]string {
+	t.Helper()
+	file, err := os.Open(path)
+	if err != nil {
+		t.Fatalf("開啟封存檔失敗: %v", err)
+	}
+	defer file.Close()
+
+	entries := make(map[string]string)
+	tr := tar.NewReader(file)
+	for {
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			return entries
+		}
+		if err != nil {
+			t.Fatalf("讀取封存檔失敗: %v", err)
+		}
+		data, err := io.ReadAll(tr)
+		if err != nil {
+			t.Fatalf("讀取封存檔內容失敗: %v", err)
+		}
+		entries[hdr.Name] = string(data)
+	}
+}
+
+// TestSaveToFile_Pack 驗證打包模式下圖片寫入文章目錄的 images.tar，不產生個別檔案
+func TestSaveToFile_Pack(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.Crawler.Pack = config.PackTar
+	cfg.Crawler.MaxImageBytes = 64
+	c := NewCrawlerWithDependencies(&mocks.MockHTTPClient{}, mocks.NewMockParser(), mocks.NewMockMarkdownGenerator(),
+		"test", 1, 0, "", cfg)
+	saveDir := filepath.Join(t.TempDir(), "article")
+
+	bodies := map[string]string{
+		"a.png":   pngHeader + "aaa",
+		"b.png":   pngHeader + "bbb",
+		"big.png": pngHeader + strings.Repeat("x", 64),
+	}
+	for name, body := range bodies {
+		resp := &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     http.Header{"Content-Type": []string{"image/png"}},
+			Body:       io.NopCloser(strings.NewReader(body)),
+		}
+		saved := c.saveToFile(resp, types.DownloadTask{SavePath: filepath.Join(saveDir, name)}, 1)
+		if want := name != "big.png"; saved != want {
+			t.Errorf("saveToFile(%s) = %v, want %v", name, saved, want)
+		}
+	}
+	if n, ok := c.packer.size(filepath.Join(saveDir, "a.png")); !ok || n != int64(len(bodies["a.png"])) {
+		t.Errorf("packer.size(a.png) = %d, %v, want %d, true", n, ok, len(bodies["a.png"]))
+	}
+	c.closeArchive("", saveDir)
+
+	entries, err := os.ReadDir(saveDir)
+	if err != nil {
+		t.Fatalf("讀取文章目錄失敗: %v", err)
+	}
+	if len(entries) != 1 || entries[0].Name() != markdown.ImageArchiveFileName {
+		t.Errorf("打包模式不應留下個別圖片檔或暫存檔, 目錄內容 = %v", entries)
+	}
+	got := readTar(t, filepath.Join(saveDir, markdown.ImageArchiveFileName))
+	if len(got) != 2 || got["a.png"] != bodies["a.png"] || got["b.png"] != bodies["b.png"] {
+		t.Errorf("封存檔內容 = %v, want a.png 與 b.png（超過大小上限的 big.png 不寫入）", got)
+	}
+}
+
+// TestImagePacker_CloseAll 驗證 closeAll 關閉所有寫入中的封存檔，沒有圖片的文章不建立封存檔
+func TestImagePacker_CloseAll(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.Crawler.Pack = config.PackTar
+	p := newImagePacker(cfg)
+	root := t.TempDir()
+
+	dirs := []string{filepath.Join(root, "one"), filepath.Join(root, "two")}
+	for _, dir := range dirs {
+		if err := p.add(filepath.Join(dir, "x.jpg"), strings.NewReader("data"), 4); err != nil {
+			t.Fatalf("add failed: %v", err)
+		}
+	}
+	if err := p.close(filepath.Join(root, "empty")); err != nil {
+		t.Errorf("沒有圖片的文章 close 應不做任何事, err = %v", err)
+	}
+	if err := p.closeAll(); err != nil {
+		t.Fatalf("closeAll failed: %v", err)
+	}
+
+	for _, dir := range dirs {
+		if got := readTar(t, filepath.Join(dir, markdown.ImageArchiveFileName)); got["x.jpg"] != "data" {
+			t.Errorf("%s 封存檔內容 = %v", dir, got)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(root, "empty")); !os.IsNotExist(err) {
+		t.Errorf("沒有圖片的文章不應建立目錄, err = %v", err)
+	}
+	if newImagePacker(config.DefaultConfig()) != nil {
+		t.Error("pack 為 off 時 newImagePacker 應回傳 nil")
+	}
+}
+
+// TestArchiveName_NoImagesPacked 驗證該篇沒有任何圖片寫入封存檔時不引用 images.tar，
+// 避免輸出檔讀取不存在的封存檔而失敗
+func TestArchiveName_NoImagesPacked(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.Crawler.Pack = config.PackTar
+	c := NewCrawlerWithDependencies(&mocks.MockHTTPClient{}, mocks.NewMockParser(), mocks.NewMockMarkdownGenerator(),
+		"test", 1, 0, "", cfg)
+	saveDir := filepath.Join(t.TempDir(), "article")
+
+	c.closeArchive("", saveDir)
+	if got := c.archiveName(saveDir); got != "" {
+		t.Errorf("沒有圖片寫入時 archiveName = %q, want 空字串", got)
+	}
+
+	if err := c.packer.add(filepath.Join(saveDir, "a.jpg"), strings.NewReader("data"), 4); err != nil {
+		t.Fatalf("add failed: %v", err)
+	}
+	c.closeArchive("", saveDir)
+	if got := c.archiveName(saveDir); got != markdown.ImageArchiveFileName {
+		t.Errorf("已寫入圖片時 archiveName = %q, want %q", got, markdown.ImageArchiveFileName)
+	}
+}
@@ -5,7 +5,6 @@ import (
 	"io"
 	"net/http"
 	"strings"
-	"sync"
 	"testing"
 	"time"
 
@@ -43,6 +42,21 @@ func TestWithLogger_Option(t *testing.T) {
 	}
 }
 
+func TestNewCrawlerWithDependencies_Options(t *testing.T) {
+	cfg := config.DefaultConfig()
+	logger := ui.NewNoopLogger()
+
+	c := NewCrawlerWithDependencies(mocks.NewMockHTTPClient(), mocks.NewMockParser(), mocks.NewMockMarkdownGenerator(),
+		"test", 1, 0, "", cfg, WithLogger(logger))
+
+	if c.logger != logger {
+		t.Error("WithLogger should set custom logger")
+	}
+	if c.alertMonitor == nil || c.alertMonitor.logger != logger {
+		t.Error("alert monitor should use the injected logger")
+	}
+}
+
 func TestEmit_NilChannel(t *testing.T) {
 	c := &Crawler{} // progress is nil
 
@@ -169,8 +183,11 @@ func TestProgress_ProcessArticleEmitsArticleEvent(t *testing.T) {
 	}
 
 	mockParser := &mocks.MockParser{
-		ParseArticleContentFunc: func(_ io.Reader) (string, []string, error) {
-			return "Parsed Title", []string{"http://example.com/img1.jpg", "http://example.com/img2.jpg"}, nil
+		ParseArticleContentFunc: func(_ io.Reader) (types.ArticleContent, error) {
+			return types.ArticleContent{
+				Title:     "Parsed Title",
+				ImageURLs: []string{"http://example.com/img1.jpg", "http://example.com/img2.jpg"},
+			}, nil
 		},
 	}
 
@@ -232,16 +249,7 @@ func TestProgress_DownloadWorkerEmitsEvents(t *testing.T) {
 	c := NewCrawlerWithDependencies(mockClient, mocks.NewMockParser(), mocks.NewMockMarkdownGenerator(), "test", 1, 0, "", cfg)
 	c.progress = progressCh
 
-	taskChan := make(chan types.DownloadTask, 1)
-	taskChan <- types.DownloadTask{ImageURL: "http://example.com/img.jpg", SavePath: "/tmp/test.jpg"}
-	close(taskChan)
-
-	var wg sync.WaitGroup
-	wg.Add(1)
-	ctx := context.Background()
-
-	go c.downloadWorker(ctx, 1, taskChan, &wg)
-	wg.Wait()
+	runDownloadWorker(t, c, types.DownloadTask{ImageURL: "http://example.com/img.jpg", SavePath: "/tmp/test.jpg"})
 
 	close(progressCh)
 	var startEvents, failEvents []types.ProgressEvent
@@ -0,0 +1,144 @@
+package crawler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/twtrubiks/ptt-spider-go/config"
+	"github.com/twtrubiks/ptt-spider-go/mocks"
+	"github.com/twtrubiks/ptt-spider-go/types"
+)
+
+// TestDoRequest_WaitsOnRateLimiter 驗證每個對外請求（列表、文章、圖片）都會先向限速器取得額度。
+func TestDoRequest_WaitsOnRateLimiter(t *testing.T) {
+	var waits atomic.Int32
+	limiter := &mocks.MockRateLimiter{
+		WaitFunc: func(_ context.Context) error {
+			waits.Add(1)
+			return nil
+		},
+	}
+
+	cfg := config.DefaultConfig()
+	cfg.Crawler.Delays = config.DelayConfig{MinMs: 0, MaxMs: 0}
+	c := NewCrawlerWithDependencies(mocks.NewMockHTTPClient(), mocks.NewMockParser(), mocks.NewMockMarkdownGenerator(), "test", 1, 0, "", cfg)
+	c.limiter = limiter
+
+	ctx := context.Background()
+	if _, err := c.fetchMaxPage(ctx, "test"); err != nil {
+		t.Fatalf("fetchMaxPage() error = %v", err)
+	}
+	if _, err := c.fetchAndParseArticle(ctx, types.ArticleInfo{URL: "http://example.com/article"}); err != nil {
+		t.Fatalf("fetchAndParseArticle() error = %v", err)
+	}
+	if resp := c.fetchImage(ctx, 1, types.DownloadTask{ImageURL: "http://example.com/img.jpg"}); resp != nil {
+		_ = resp.Body.Close()
+	}
+
+	if got := waits.Load(); got != 3 {
+		t.Errorf("限速器 Wait 呼叫次數 = %d, want 3", got)
+	}
+}
+
+// TestDoRequest_LimiterErrorSkipsRequest 驗證限速器等待失敗（如 context 取消）時不會發出請求。
+func TestDoRequest_LimiterErrorSkipsRequest(t *testing.T) {
+	client := &mocks.MockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			t.Errorf("限速器失敗時不應發出請求: %s", req.URL)
+			return nil, nil
+		},
+	}
+	c := NewCrawlerWithDependencies(client, mocks.NewMockParser(), mocks.NewMockMarkdownGenerator(), "test", 1, 0, "", config.DefaultConfig())
+	c.limiter = &mocks.MockRateLimiter{
+		WaitFunc: func(_ context.Context) error { return context.Canceled },
+	}
+
+	req, _ := http.NewRequestWithContext(context.Background(), "GET", "http://example.com", nil)
+	if _, err := c.doRequest(context.Background(), req); !errors.Is(err, context.Canceled) {
+		t.Errorf("doRequest() error = %v, want context.Canceled", err)
+	}
+}
+
+func TestNewRateLimiter(t *testing.T) {
+	cfg := config.DefaultConfig()
+	if newRateLimiter(cfg) != nil {
+		t.Error("requestsPerSecond 為 0 時不應建立限速器")
+	}
+
+	cfg.Crawler.RateLimit.RequestsPerSecond = 5
+	if newRateLimiter(cfg) == nil {
+		t.Error("requestsPerSecond > 0 時應建立限速器")
+	}
+}
+
+// TestDoRequest_HostLimiter 驗證有設定獨立速率的 host（含子網域）使用自己的桶，其餘 host 使用預設限速器。
+func TestDoRequest_HostLimiter(t *testing.T) {
+	var defaultWaits atomic.Int32
+	cfg := config.DefaultConfig()
+	cfg.Crawler.RateLimit.Hosts = map[string]config.HostRateLimitConfig{
+		"imgur.com": {RequestsPerSecond: 0.001, Burst: 1},
+	}
+	c := NewCrawlerWithDependencies(mocks.NewMockHTTPClient(), mocks.NewMockParser(), mocks.NewMockMarkdownGenerator(), "test", 1, 0, "", cfg)
+	c.limiter = &mocks.MockRateLimiter{
+		WaitFunc: func(_ context.Context) error {
+			defaultWaits.Add(1)
+			return nil
+		},
+	}
+
+	do := func(ctx context.Context, url string) error {
+		req, _ := http.NewRequestWithContext(ctx, "GET", url, nil)
+		resp, err := c.doRequest(ctx, req)
+		if resp != nil {
+			_ = resp.Body.Close()
+		}
+		return err
+	}
+
+	ctx := context.Background()
+	if err := do(ctx, "https://i.imgur.com/a.jpg"); err != nil {
+		t.Fatalf("doRequest() error = %v", err)
+	}
+	// imgur 的桶已用完，下一個請求需等待，逾時即表示受 imgur 的桶限制
+	timeoutCtx, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+	defer cancel()
+	if err := do(timeoutCtx, "https://imgur.com/b.jpg"); err == nil {
+		t.Error("imgur.com 的額度用完後應等待")
+	}
+	if got := defaultWaits.Load(); got != 0 {
+		t.Errorf("設定獨立速率的 host 不應使用預設限速器，Wait 呼叫次數 = %d", got)
+	}
+
+	// 未設定的 host 不受 imgur 的桶影響，使用預設限速器
+	if err := do(ctx, "https://www.ptt.cc/bbs/Beauty/index.html"); err != nil {
+		t.Fatalf("doRequest() error = %v", err)
+	}
+	if got := defaultWaits.Load(); got != 1 {
+		t.Errorf("預設限速器 Wait 呼叫次數 = %d, want 1", got)
+	}
+}
+
+func TestMatchHost(t *testing.T) {
+	m := map[string]int{"imgur.com": 1, "i.imgur.com": 2}
+	tests := []struct {
+		host string
+		want int
+		ok   bool
+	}{
+		{"i.imgur.com", 2, true},
+		{"I.IMGUR.COM", 2, true},
+		{"m.imgur.com", 1, true},
+		{"imgur.com", 1, true},
+		{"notimgur.com", 0, false},
+		{"www.ptt.cc", 0, false},
+	}
+	for _, tt := range tests {
+		if got, ok := matchHost(m, tt.host); got != tt.want || ok != tt.ok {
+			t.Errorf("matchHost(%q) = %d, %v, want %d, %v", tt.host, got, ok, tt.want, tt.ok)
+		}
+	}
+}
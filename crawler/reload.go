@@ -0,0 +1,165 @@
+package crawler
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/twtrubiks/ptt-spider-go/config"
+	"github.com/twtrubiks/ptt-spider-go/ratelimit"
+)
+
+// reloadPollInterval 是檢查配置檔是否變更的間隔
+const reloadPollInterval = 2 * time.Second
+
+// reloadableKeys 是執行期可熱更新的配置鍵，其餘鍵變更時只提示需重新啟動
+var reloadableKeys = []string{"workers", "delays", "rateLimit"}
+
+// rateSetter 是可在執行期調整速率的限速器（ratelimit.TokenBucket）
+type rateSetter interface {
+	SetRate(rps float64, burst int)
+}
+
+// configReloader 監看配置檔並將可熱更新的參數套用到執行中的爬蟲
+type configReloader struct {
+	path string
+	// prepare 在套用前處理新配置（如重新套用 -mode 策略），回傳錯誤時放棄本次變更
+	prepare func(*config.Config) error
+	// applied 是目前生效的配置，只由監看 goroutine 讀寫
+	applied *config.Config
+	// loadOpts 是重新載入時使用的選項（如 -strict-config 的嚴格模式）
+	loadOpts []config.LoadOption
+}
+
+// WithConfigReload 啟用配置檔熱更新：執行期間監看 path，變更時套用 workers、delays 與 rateLimit，
+// 其餘設定（如 channel 大小）需重新啟動才會生效。prepare 可為 nil；opts 套用於每次重新載入。
+func WithConfigReload(path string, prepare func(*config.Config) error, opts ...config.LoadOption) Option {
+	return func(c *Crawler) { c.reloader = &configReloader{path: path, prepare: prepare, loadOpts: opts} }
+}
+
+// delayRange 回傳目前的隨機延遲範圍，熱更新後的值優先於啟動時的配置
+func (c *Crawler) delayRange() (time.Duration, time.Duration) {
+	if d := c.delays.Load(); d != nil {
+		return time.Duration(d.MinMs) * time.Millisecond, time.Duration(d.MaxMs) * time.Millisecond
+	}
+	return c.config.GetDelayRange()
+}
+
+// prepareReload 在 worker 啟動前準備熱更新需要的狀態：
+// 未設定速率時先建立不限速的限速器，之後調高速率限制才有對象可套用
+func (c *Crawler) prepareReload() {
+	if c.reloader == nil {
+		return
+	}
+	cfg := *c.config
+	c.reloader.applied = &cfg
+	if c.limiter == nil {
+		c.limiter = ratelimit.NewTokenBucket(0, c.config.Crawler.RateLimit.Burst)
+	}
+}
+
+// watchConfig 監看配置檔直到 ctx 取消
+func (c *Crawler) watchConfig(ctx context.Context) {
+	c.logger.Info("監看配置檔變更: %s（可熱更新: %s）", c.reloader.path, strings.Join(reloadableKeys, ", "))
+	config.Watch(ctx, c.reloader.path, reloadPollInterval, func(cfg *config.Config, err error) {
+		if err == nil && c.reloader.prepare != nil {
+			err = c.reloader.prepare(cfg)
+		}
+		if err != nil {
+			c.logger.Warn("重新載入配置檔失敗，保留目前設定: %v", err)
+			return
+		}
+		c.applyConfig(cfg)
+	}, c.reloader.loadOpts...)
+}
+
+// applyConfig 比較新舊配置並套用可熱更新的變更，不可熱更新的變更只記錄警告
+func (c *Crawler) applyConfig(cfg *config.Config) {
+	old := c.reloader.applied
+	changed := old.ChangedKeys(cfg)
+	if len(changed) == 0 {
+		return
+	}
+
+	var restart []string
+	for _, key := range changed {
+		switch key {
+		case "delays":
+			delays := cfg.Crawler.Delays
+			c.delays.Store(&delays)
+			c.logger.Info("已套用新的延遲範圍: %d ~ %d ms", delays.MinMs, delays.MaxMs)
+		case "rateLimit":
+			c.applyRateLimit(cfg.Crawler.RateLimit)
+		case "workers":
+			if !c.applyWorkers(cfg.Crawler.Workers) {
+				restart = append(restart, key)
+				cfg.Crawler.Workers = old.Crawler.Workers
+			}
+		default:
+			restart = append(restart, key)
+		}
+	}
+	if len(restart) > 0 {
+		c.logger.Warn("配置 %s 已變更，需重新啟動才會生效", strings.Join(restart, ", "))
+	}
+	c.reloader.applied = cfg
+}
+
+// applyRateLimit 調整全域限速器與各 host 限速器的速率
+func (c *Crawler) applyRateLimit(rl config.RateLimitConfig) {
+	c.applyHostRateLimits(rl)
+	setter, ok := c.limiter.(rateSetter)
+	if !ok {
+		c.logger.Warn("目前的速率限制器不支援執行期調整，rateLimit 變更需重新啟動才會生效")
+		return
+	}
+	setter.SetRate(rl.RequestsPerSecond, rl.Burst)
+	if rl.RequestsPerSecond <= 0 {
+		c.logger.Info("已取消請求速率限制")
+		return
+	}
+	c.logger.Info("已套用新的請求速率限制: 每秒 %g 個（burst %d）", rl.RequestsPerSecond, rl.Burst)
+}
+
+// applyHostRateLimits 調整已存在的 host 限速器速率。
+// host 限速器的 map 在 worker 間並行讀取，新增或移除 host 需重新啟動才會生效。
+func (c *Crawler) applyHostRateLimits(rl config.RateLimitConfig) {
+	var restart []string
+	for host, hc := range rl.Hosts {
+		bucket, ok := c.hostLimiters[host]
+		if !ok {
+			restart = append(restart, host)
+			continue
+		}
+		bucket.SetRate(hc.RequestsPerSecond, hostBurst(rl, hc))
+		c.logger.Info("已套用 %s 的請求速率限制: 每秒 %g 個（burst %d）", host, hc.RequestsPerSecond, hostBurst(rl, hc))
+	}
+	for host := range c.hostLimiters {
+		if _, ok := rl.Hosts[host]; !ok {
+			restart = append(restart, host)
+		}
+	}
+	if len(restart) > 0 {
+		slices.Sort(restart)
+		c.logger.Warn("rateLimit.hosts 新增或移除了 %s，需重新啟動才會生效", strings.Join(restart, ", "))
+	}
+}
+
+// applyWorkers 調整下載工人數（範圍），回傳 false 表示目前模式無法熱更新。
+// 固定工人數時直接調整；範圍時先將目前工人數夾回範圍內，之後由 autoscaleWorkers 依佇列深度調整
+func (c *Crawler) applyWorkers(w config.WorkersConfig) bool {
+	// article 模式的工人編號池在啟動時建立，容量無法安全調整
+	if c.pool == nil {
+		return false
+	}
+	c.workers.Store(&w)
+	n := min(max(c.pool.Size(), w.Min), w.Max)
+	from := c.pool.Resize(n)
+	if w.Dynamic() {
+		c.logger.Info("下載工人數範圍已調整為 %s，目前 %d 個", w, n)
+		return true
+	}
+	c.logger.Info("下載工人數已由 %d 調整為 %d", from, n)
+	return true
+}
@@ -0,0 +1,188 @@
+package crawler
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/twtrubiks/ptt-spider-go/config"
+	"github.com/twtrubiks/ptt-spider-go/mocks"
+	"github.com/twtrubiks/ptt-spider-go/ratelimit"
+	"github.com/twtrubiks/ptt-spider-go/types"
+)
+
+// reloadLog 收集 Info/Success/Warn 訊息（已格式化）
+type reloadLog struct {
+	mu    sync.Mutex
+	lines []string
+}
+
+func (l *reloadLog) record(format string, args ...any) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.lines = append(l.lines, fmt.Sprintf(format, args...))
+}
+
+// count 回傳包含 substr 的訊息數
+func (l *reloadLog) count(substr string) int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	n := 0
+	for _, line := range l.lines {
+		if strings.Contains(line, substr) {
+			n++
+		}
+	}
+	return n
+}
+
+// newReloadTestCrawler 建立啟用熱更新的 crawler，並完成 Run 開始時的準備
+func newReloadTestCrawler(cfg *config.Config) (*Crawler, *reloadLog) {
+	log := &reloadLog{}
+	logger := &mocks.MockLogger{InfoFunc: log.record, SuccessFunc: log.record, WarnFunc: log.record}
+	c := NewCrawlerWithDependencies(mocks.NewMockHTTPClient(), mocks.NewMockParser(), mocks.NewMockMarkdownGenerator(),
+		"test", 1, 0, "", cfg, WithLogger(logger), WithConfigReload("config.yaml", nil))
+	c.prepareReload()
+	return c, log
+}
+
+func TestApplyConfig_DelaysAndRateLimit(t *testing.T) {
+	c, log := newReloadTestCrawler(config.DefaultConfig())
+
+	// 未設定速率時仍建立不限速的限速器，供之後調整
+	tb, ok := c.limiter.(*ratelimit.TokenBucket)
+	if !ok {
+		t.Fatalf("limiter = %T, want *ratelimit.TokenBucket", c.limiter)
+	}
+	for range 10 {
+		if !tb.Allow() {
+			t.Fatal("熱更新前應不限速")
+		}
+	}
+
+	cfg := config.DefaultConfig()
+	cfg.Crawler.Delays = config.DelayConfig{MinMs: 10, MaxMs: 20}
+	cfg.Crawler.RateLimit = config.RateLimitConfig{RequestsPerSecond: 0.001, Burst: 1}
+	c.applyConfig(cfg)
+
+	if minDelay, maxDelay := c.delayRange(); minDelay != 10*time.Millisecond || maxDelay != 20*time.Millisecond {
+		t.Errorf("delayRange() = %v, %v, want 10ms, 20ms", minDelay, maxDelay)
+	}
+	// 原始配置不應被修改
+	if c.config.Crawler.Delays.MinMs == 10 {
+		t.Error("熱更新不應修改啟動時的配置物件")
+	}
+	if !tb.Allow() || tb.Allow() {
+		t.Error("套用 burst 1 後應只允許 1 次請求")
+	}
+	if log.count("需重新啟動") != 0 {
+		t.Errorf("可熱更新的變更不應警告需重新啟動: %v", log.lines)
+	}
+}
+
+func TestApplyConfig_RateLimitHosts(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.Crawler.RateLimit.Hosts = map[string]config.HostRateLimitConfig{"i.imgur.com": {}}
+	c, log := newReloadTestCrawler(cfg)
+	bucket := c.hostLimiters["i.imgur.com"]
+
+	updated := config.DefaultConfig()
+	updated.Crawler.RateLimit.Hosts = map[string]config.HostRateLimitConfig{
+		"i.imgur.com":   {RequestsPerSecond: 0.001, Burst: 1},
+		"pbs.twimg.com": {RequestsPerSecond: 1},
+	}
+	c.applyConfig(updated)
+
+	if !bucket.Allow() || bucket.Allow() {
+		t.Error("已存在的 host 應套用新的速率（burst 1）")
+	}
+	if _, ok := c.hostLimiters["pbs.twimg.com"]; ok {
+		t.Error("執行期不應新增 host 限速器")
+	}
+	if log.count("pbs.twimg.com，需重新啟動") != 1 {
+		t.Errorf("新增 host 應警告需重新啟動: %v", log.lines)
+	}
+}
+
+func TestApplyConfig_RestartRequired(t *testing.T) {
+	c, log := newReloadTestCrawler(config.DefaultConfig())
+
+	cfg := config.DefaultConfig()
+	cfg.Crawler.Channels.DownloadTask = 1
+	cfg.Crawler.ParserCount = 99
+	c.applyConfig(cfg)
+
+	if log.count("parserCount, channels 已變更，需重新啟動") != 1 {
+		t.Errorf("應警告 parserCount 與 channels 需重新啟動: %v", log.lines)
+	}
+
+	// 內容未再變更時不重複警告
+	c.applyConfig(cfg)
+	if n := log.count("需重新啟動"); n != 1 {
+		t.Errorf("重複套用相同配置警告 %d 次，want 1", n)
+	}
+}
+
+func TestApplyConfig_WorkersArticleMode(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.Crawler.DownloadMode = config.DownloadModeArticle
+	c, log := newReloadTestCrawler(cfg)
+
+	next := config.DefaultConfig()
+	next.Crawler.DownloadMode = config.DownloadModeArticle
+	next.Crawler.Workers = config.FixedWorkers(cfg.Crawler.Workers.Min + 5)
+	c.applyConfig(next)
+
+	if log.count("workers 已變更，需重新啟動") != 1 {
+		t.Errorf("article 模式調整 workers 應警告需重新啟動: %v", log.lines)
+	}
+}
+
+func TestDownloadPool_Resize(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.Crawler.Workers = config.FixedWorkers(2)
+	c, log := newReloadTestCrawler(cfg)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	c.pool = c.newDownloadPool(make(chan types.DownloadTask), 2)
+	c.pool.Start(ctx)
+
+	next := config.DefaultConfig()
+	next.Crawler.Workers = config.FixedWorkers(5)
+	c.applyConfig(next)
+	waitFor(t, func() bool { return log.count("啟動") == 5 }, "擴充後應共有 5 個工人啟動")
+	if log.count("#5 啟動") != 1 {
+		t.Errorf("新工人編號應接續為 3~5: %v", log.lines)
+	}
+
+	next = config.DefaultConfig()
+	next.Crawler.Workers = config.FixedWorkers(1)
+	c.applyConfig(next)
+	waitFor(t, func() bool { return log.count("結束") == 4 }, "縮減後應有 4 個工人結束")
+
+	// 停止後不再增加工人
+	c.pool.Stop()
+	if c.pool.Resize(10); log.count("啟動") != 5 {
+		t.Error("工人池停止後不應再啟動新工人")
+	}
+	c.pool.Wait()
+	if n := log.count("結束"); n != 5 {
+		t.Errorf("全部結束的工人數 = %d, want 5", n)
+	}
+}
+
+// waitFor 在 1 秒內輪詢 cond，逾時則失敗
+func waitFor(t *testing.T, cond func() bool, msg string) {
+	t.Helper()
+	deadline := time.Now().Add(time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatal(msg)
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
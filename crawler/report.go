@@ -0,0 +1,143 @@
+package crawler
+
+import (
+	"cmp"
+	"context"
+	"os"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/twtrubiks/ptt-spider-go/report"
+	"github.com/twtrubiks/ptt-spider-go/types"
+)
+
+// runStats 累計執行報告需要的各看板與各文章統計，可供多個 goroutine 並行更新
+type runStats struct {
+	mu       sync.Mutex
+	boards   map[string]*report.BoardStats
+	articles map[string]*report.ArticleStats // 文章 URL → 統計
+	started  map[string]time.Time            // 文章 URL → 開始解析文章頁的時間
+}
+
+// newRunStats 依配置建立報告統計，output.report 關閉時回傳 nil
+func newRunStats(enabled bool) *runStats {
+	if !enabled {
+		return nil
+	}
+	return &runStats{
+		boards:   make(map[string]*report.BoardStats),
+		articles: make(map[string]*report.ArticleStats),
+		started:  make(map[string]time.Time),
+	}
+}
+
+// board 回傳看板的統計，不存在時建立；呼叫端需持有 mu
+func (s *runStats) board(name string) *report.BoardStats {
+	b, ok := s.boards[name]
+	if !ok {
+		b = &report.BoardStats{Board: name}
+		s.boards[name] = b
+	}
+	return b
+}
+
+// recordArticle 記錄一篇解析完成的文章與解析文章頁的耗時
+func (c *Crawler) recordArticle(board, title, articleURL string, images int, elapsed time.Duration) {
+	s := c.runStats
+	if s == nil {
+		return
+	}
+	now := time.Now()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.board(board).Articles++
+	s.started[articleURL] = now.Add(-elapsed)
+	s.articles[articleURL] = &report.ArticleStats{
+		Board:    board,
+		Title:    title,
+		URL:      articleURL,
+		Images:   images,
+		Duration: elapsed,
+	}
+}
+
+// recordDownload 記錄一張圖片的下載結果，所屬文章的耗時延長至此刻。
+// 同一篇文章的圖片由多個工人並行下載，耗時以開始解析到最後一張下載結束的實際經過時間計算，
+// 而非各張下載時間的加總。成功時以存檔大小計入下載量（移除 EXIF 後的大小）。
+func (c *Crawler) recordDownload(task types.DownloadTask, ok bool) {
+	s := c.runStats
+	if s == nil {
+		return
+	}
+	var written int64
+	if ok {
+		if n, packed := c.packer.size(task.SavePath); packed {
+			written = n
+		} else if info, err := os.Stat(task.SavePath); err == nil {
+			written = info.Size()
+		}
+	}
+
+	now := time.Now()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	b := s.board(task.Board)
+	if ok {
+		b.Images++
+		b.Bytes += written
+	} else {
+		b.Failed++
+	}
+	if a, found := s.articles[task.ArticleURL]; found {
+		a.Duration = max(a.Duration, now.Sub(s.started[task.ArticleURL]))
+	}
+}
+
+// snapshot 回傳依看板名稱排序的看板統計與所有文章統計
+func (s *runStats) snapshot() ([]report.BoardStats, []report.ArticleStats) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	boards := make([]report.BoardStats, 0, len(s.boards))
+	for _, b := range s.boards {
+		boards = append(boards, *b)
+	}
+	slices.SortFunc(boards, func(a, b report.BoardStats) int { return cmp.Compare(a.Board, b.Board) })
+
+	articles := make([]report.ArticleStats, 0, len(s.articles))
+	for _, a := range s.articles {
+		articles = append(articles, *a)
+	}
+	return boards, articles
+}
+
+// writeReport 在輸出根目錄產生 report.html，output.report 關閉時不做任何事；試跑模式不寫入
+func (c *Crawler) writeReport(ctx context.Context, startTime time.Time, duration time.Duration) {
+	if c.runStats == nil {
+		return
+	}
+	if c.dryRun != nil {
+		c.logger.Info("[dry-run] 未產生執行報告 %s", report.FileName)
+		return
+	}
+
+	boards, articles := c.runStats.snapshot()
+	r := report.Report{
+		StartedAt:   startTime,
+		Duration:    duration,
+		Interrupted: ctx.Err() != nil,
+		Boards:      boards,
+		Articles:    articles,
+	}
+	if c.metrics != nil {
+		snap := c.metrics.Snapshot()
+		r.Metrics = &snap
+	}
+
+	path, err := report.Write(c.outputDir, r)
+	if err != nil {
+		c.logger.Error("寫入執行報告失敗: %v", err)
+		return
+	}
+	c.logger.Success("執行報告已產生: %s", path)
+}
@@ -0,0 +1,102 @@
+package crawler
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/twtrubiks/ptt-spider-go/config"
+	"github.com/twtrubiks/ptt-spider-go/mocks"
+	"github.com/twtrubiks/ptt-spider-go/report"
+	"github.com/twtrubiks/ptt-spider-go/types"
+)
+
+func TestWriteReport(t *testing.T) {
+	t.Chdir(t.TempDir())
+
+	client := &mocks.MockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			if strings.HasSuffix(req.URL.Path, "missing.jpg") {
+				return newTestResponse(http.StatusNotFound), nil
+			}
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       io.NopCloser(strings.NewReader(pngHeader + "image")),
+			}, nil
+		},
+	}
+	cfg := config.DefaultConfig()
+	cfg.Crawler.Delays = config.DelayConfig{}
+	cfg.Crawler.Output.Report = true
+	c := NewCrawlerWithDependencies(client, mocks.NewMockParser(), mocks.NewMockMarkdownGenerator(),
+		"Beauty", 1, 0, "", cfg, WithOutputDir("out"))
+
+	const articleURL = "https://www.ptt.cc/bbs/Beauty/M.1.html"
+	c.recordArticle("Beauty", "正妹", articleURL, 2, time.Second)
+	for _, name := range []string{"a.jpg", "missing.jpg"} {
+		runDownloadWorker(t, c, types.DownloadTask{
+			ImageURL:   "https://i.imgur.com/" + name,
+			SavePath:   filepath.Join("out", "Beauty", "正妹_10", name),
+			Board:      "Beauty",
+			ArticleURL: articleURL,
+		})
+	}
+
+	boards, articles := c.runStats.snapshot()
+	want := report.BoardStats{Board: "Beauty", Articles: 1, Images: 1, Failed: 1, Bytes: int64(len(pngHeader + "image"))}
+	if len(boards) != 1 || boards[0] != want {
+		t.Errorf("看板統計 = %+v, want [%+v]", boards, want)
+	}
+	if len(articles) != 1 || articles[0].Duration < time.Second {
+		t.Errorf("文章統計 = %+v, want 耗時含解析的 1s", articles)
+	}
+
+	c.writeReport(context.Background(), time.Now(), time.Minute)
+	data, err := os.ReadFile(filepath.Join("out", report.FileName))
+	if err != nil {
+		t.Fatalf("讀取執行報告失敗: %v", err)
+	}
+	if !strings.Contains(string(data), "正妹") {
+		t.Error("執行報告缺少最慢文章的標題")
+	}
+}
+
+func TestWriteReport_Disabled(t *testing.T) {
+	t.Chdir(t.TempDir())
+
+	c := NewCrawlerWithDependencies(&mocks.MockHTTPClient{}, mocks.NewMockParser(), mocks.NewMockMarkdownGenerator(),
+		"Beauty", 1, 0, "", config.DefaultConfig())
+	c.recordArticle("Beauty", "正妹", "u1", 1, time.Second)
+	c.writeReport(context.Background(), time.Now(), time.Minute)
+
+	if _, err := os.Stat(report.FileName); !os.IsNotExist(err) {
+		t.Errorf("output.report 關閉時不應產生報告，Stat() error = %v", err)
+	}
+}
+
+func TestRecordDownload_WallClockDuration(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.Crawler.Output.Report = true
+	c := NewCrawlerWithDependencies(&mocks.MockHTTPClient{}, mocks.NewMockParser(), mocks.NewMockMarkdownGenerator(),
+		"Beauty", 1, 0, "", cfg)
+
+	// 解析花了 1s，之後多張圖片並行下載：耗時為實際經過時間，不應把每張的時間重複加總
+	const articleURL = "https://www.ptt.cc/bbs/Beauty/M.1.html"
+	c.recordArticle("Beauty", "正妹", articleURL, 3, time.Second)
+	for range 3 {
+		c.recordDownload(types.DownloadTask{Board: "Beauty", ArticleURL: articleURL}, false)
+	}
+
+	_, articles := c.runStats.snapshot()
+	if len(articles) != 1 {
+		t.Fatalf("文章統計 = %+v, want 1 筆", articles)
+	}
+	if d := articles[0].Duration; d < time.Second || d >= 2*time.Second {
+		t.Errorf("文章耗時 = %v, want 約 1s 的實際經過時間", d)
+	}
+}
@@ -0,0 +1,134 @@
+package crawler
+
+import (
+	"fmt"
+	"math"
+	"net/http"
+	"runtime"
+	"runtime/debug"
+	"sync/atomic"
+
+	"github.com/twtrubiks/ptt-spider-go/config"
+)
+
+const (
+	// resourceErrorRateHigh 平滑後的錯誤率高於此值時縮減工人數上限
+	resourceErrorRateHigh = 0.2
+	// resourceErrorRateLow 平滑後的錯誤率低於此值才允許擴充；與 High 之間的區間維持不變，避免在門檻附近來回調整
+	resourceErrorRateLow = 0.05
+	// resourceMemoryHigh heap 用量佔記憶體上限的比例高於此值時縮減
+	resourceMemoryHigh = 0.85
+	// resourceMemoryLow heap 用量比例低於此值才允許擴充
+	resourceMemoryLow = 0.7
+	// resourceSmoothing 錯誤率指數移動平均中新樣本的權重，單一檢查週期的突發錯誤不會立刻觸發縮減
+	resourceSmoothing = 0.3
+	// resourceCooldownChecks 每次調整上限後至少間隔的檢查次數，讓工人數變化反映到錯誤率後再判斷
+	resourceCooldownChecks = 4
+)
+
+// resourceGovernor 依觀測到的請求錯誤率與記憶體用量決定下載工人數的上限（AIMD）：
+// 資源吃緊時上限縮減為目前工人數的 3/4，恢復正常後每次只增加一個，兩次調整之間有冷卻期，
+// 避免工人數抖動。實際工人數由 autoscaleWorkers 依佇列深度決定，不超過此上限。
+// record 可供多個 goroutine 並行呼叫；update 只由 autoscaleWorkers 呼叫。
+type resourceGovernor struct {
+	min, max    int
+	memoryLimit uint64        // heap 用量上限，0 表示不依記憶體調整
+	readMemory  func() uint64 // 取得目前 heap 用量，可替換以供測試
+
+	requests atomic.Int64 // 累計請求數
+	failures atomic.Int64 // 累計代表資源吃緊的失敗數（網路錯誤、429、5xx）
+
+	lastRequests int64
+	lastFailures int64
+	errorRate    float64 // 平滑後的錯誤率
+	sampled      bool    // 是否已有錯誤率樣本
+	ceiling      int     // 目前的工人數上限
+	cooldown     int     // 剩餘的冷卻檢查次數
+}
+
+// newResourceGovernor 依配置建立資源監控，未啟用 autoScale 或為 article 模式（不調整工人數）時回傳 nil
+func newResourceGovernor(cfg *config.Config) *resourceGovernor {
+	if !cfg.Crawler.AutoScale.Enabled || cfg.Crawler.DownloadMode == config.DownloadModeArticle {
+		return nil
+	}
+	w := cfg.Crawler.Workers
+	return &resourceGovernor{
+		min:         w.Min,
+		max:         w.Max,
+		memoryLimit: autoScaleMemoryLimit(cfg.Crawler.AutoScale.MemoryLimit),
+		readMemory:  heapInUse,
+		ceiling:     w.Max,
+	}
+}
+
+// autoScaleMemoryLimit 回傳 autoScale 使用的記憶體上限：配置值優先，其次為 GOMEMLIMIT，皆未設定時回傳 0
+func autoScaleMemoryLimit(configured config.ByteSize) uint64 {
+	if configured > 0 {
+		return uint64(configured)
+	}
+	if limit := debug.SetMemoryLimit(-1); limit != math.MaxInt64 {
+		return uint64(limit)
+	}
+	return 0
+}
+
+// heapInUse 回傳目前 heap 中仍在使用的物件大小
+func heapInUse() uint64 {
+	var m runtime.MemStats
+	runtime.ReadMemStats(&m)
+	return m.HeapAlloc
+}
+
+// record 記錄一筆請求結果。只有網路錯誤、429 與 5xx 視為資源吃緊（對方限流或過載），
+// 404 等失效連結與工人數無關，不計入錯誤率
+func (g *resourceGovernor) record(status int, err error) {
+	if g == nil {
+		return
+	}
+	g.requests.Add(1)
+	if err != nil || status == http.StatusTooManyRequests || status >= http.StatusInternalServerError {
+		g.failures.Add(1)
+	}
+}
+
+// update 依上次檢查以來的請求結果與目前記憶體用量調整並回傳工人數上限；
+// 上限縮減時 reason 說明原因，其餘情況為空字串
+func (g *resourceGovernor) update(size int) (ceiling int, reason string) {
+	requests, failures := g.requests.Load(), g.failures.Load()
+	if n := requests - g.lastRequests; n > 0 {
+		rate := float64(failures-g.lastFailures) / float64(n)
+		if g.sampled {
+			g.errorRate = resourceSmoothing*rate + (1-resourceSmoothing)*g.errorRate
+		} else {
+			g.errorRate, g.sampled = rate, true
+		}
+	}
+	g.lastRequests, g.lastFailures = requests, failures
+
+	memRatio := 0.0
+	if g.memoryLimit > 0 {
+		memRatio = float64(g.readMemory()) / float64(g.memoryLimit)
+	}
+
+	if g.cooldown > 0 {
+		g.cooldown--
+		return g.ceiling, ""
+	}
+	switch {
+	case g.errorRate > resourceErrorRateHigh || memRatio > resourceMemoryHigh:
+		next := max(min(g.ceiling, size)*3/4, g.min)
+		if next >= g.ceiling {
+			return g.ceiling, ""
+		}
+		g.ceiling = next
+		g.cooldown = resourceCooldownChecks
+		if memRatio > resourceMemoryHigh {
+			return g.ceiling, fmt.Sprintf("記憶體用量達上限的 %.0f%%", memRatio*100)
+		}
+		return g.ceiling, fmt.Sprintf("請求錯誤率 %.0f%%", g.errorRate*100)
+	case g.errorRate < resourceErrorRateLow && memRatio < resourceMemoryLow && g.ceiling < g.max:
+		g.ceiling++
+		g.cooldown = resourceCooldownChecks
+	}
+	return g.ceiling, ""
+}
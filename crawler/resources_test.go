@@ -0,0 +1,157 @@
+package crawler
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/twtrubiks/ptt-spider-go/config"
+)
+
+// newTestGovernor 建立範圍為 [min, max]、不依記憶體調整的資源監控
+func newTestGovernor(minWorkers, maxWorkers int) *resourceGovernor {
+	return &resourceGovernor{min: minWorkers, max: maxWorkers, ceiling: maxWorkers, readMemory: func() uint64 { return 0 }}
+}
+
+// recordBatch 記錄 n 筆請求，其中 failed 筆回應 503
+func recordBatch(g *resourceGovernor, n, failed int) {
+	for i := range n {
+		status := http.StatusOK
+		if i < failed {
+			status = http.StatusServiceUnavailable
+		}
+		g.record(status, nil)
+	}
+}
+
+func TestNewResourceGovernor(t *testing.T) {
+	cfg := config.DefaultConfig()
+	if newResourceGovernor(cfg) != nil {
+		t.Error("未啟用 autoScale 時應回傳 nil")
+	}
+
+	cfg.Crawler.AutoScale = config.AutoScaleConfig{Enabled: true, Min: 2, Max: 16, MemoryLimit: 1 << 30}
+	cfg.Crawler.Workers = config.WorkersConfig{Min: 2, Max: 16}
+	g := newResourceGovernor(cfg)
+	if g == nil || g.min != 2 || g.max != 16 || g.ceiling != 16 || g.memoryLimit != 1<<30 {
+		t.Fatalf("governor = %+v", g)
+	}
+
+	cfg.Crawler.DownloadMode = config.DownloadModeArticle
+	if newResourceGovernor(cfg) != nil {
+		t.Error("article 模式不調整工人數，應回傳 nil")
+	}
+}
+
+func TestResourceGovernor_Record(t *testing.T) {
+	g := newTestGovernor(1, 4)
+	g.record(http.StatusOK, nil)
+	g.record(http.StatusNotFound, nil) // 失效連結與工人數無關
+	g.record(http.StatusTooManyRequests, nil)
+	g.record(http.StatusBadGateway, nil)
+	g.record(0, errors.New("connection reset"))
+	if got, want := g.requests.Load(), int64(5); got != want {
+		t.Errorf("requests = %d, want %d", got, want)
+	}
+	if got, want := g.failures.Load(), int64(3); got != want {
+		t.Errorf("failures = %d, want %d", got, want)
+	}
+
+	var nilGovernor *resourceGovernor
+	nilGovernor.record(http.StatusOK, nil) // 未啟用時不應 panic
+}
+
+func TestResourceGovernor_ErrorsShrinkThenRecoverSlowly(t *testing.T) {
+	g := newTestGovernor(2, 16)
+
+	// 錯誤率偏高：上限縮減為目前工人數的 3/4
+	recordBatch(g, 20, 10)
+	ceiling, reason := g.update(16)
+	if ceiling != 12 || !strings.Contains(reason, "錯誤率") {
+		t.Fatalf("update = %d, %q, want 12 與錯誤率原因", ceiling, reason)
+	}
+
+	// 冷卻期內即使錯誤持續也不再調整，避免工人數在錯誤率反映前連續下探
+	for range resourceCooldownChecks {
+		recordBatch(g, 20, 10)
+		if ceiling, _ := g.update(12); ceiling != 12 {
+			t.Fatalf("冷卻期內上限 = %d, want 12", ceiling)
+		}
+	}
+	recordBatch(g, 20, 10)
+	if ceiling, _ := g.update(12); ceiling != 9 {
+		t.Fatalf("冷卻期後上限 = %d, want 9", ceiling)
+	}
+
+	// 錯誤停止後平滑的錯誤率逐步下降，回落到低門檻以下才開始擴充，且每個冷卻週期只增加一個
+	var history []int
+	for range 60 {
+		recordBatch(g, 20, 0)
+		ceiling, _ := g.update(ceiling)
+		history = append(history, ceiling)
+	}
+	for i := 1; i < len(history); i++ {
+		if d := history[i] - history[i-1]; d < 0 || d > 1 {
+			t.Fatalf("恢復期間上限應單調且每次最多增加 1: %v", history)
+		}
+	}
+	if history[0] != 9 {
+		t.Errorf("平滑錯誤率仍高於低門檻時不應立即擴充: %v", history)
+	}
+	if last := history[len(history)-1]; last <= 9 || last > 16 {
+		t.Errorf("恢復後上限 = %d，應回升且不超過 max: %v", last, history)
+	}
+}
+
+func TestResourceGovernor_HysteresisHoldsSteady(t *testing.T) {
+	g := newTestGovernor(2, 16)
+	g.ceiling = 10
+	// 錯誤率 10% 介於低門檻與高門檻之間：既不縮減也不擴充
+	for range 20 {
+		recordBatch(g, 20, 2)
+		if ceiling, reason := g.update(10); ceiling != 10 || reason != "" {
+			t.Fatalf("update = %d, %q, want 維持 10", ceiling, reason)
+		}
+	}
+}
+
+func TestResourceGovernor_NeverBelowMin(t *testing.T) {
+	g := newTestGovernor(3, 8)
+	for range 50 {
+		recordBatch(g, 10, 10)
+		if ceiling, _ := g.update(g.ceiling); ceiling < 3 {
+			t.Fatalf("上限 %d 低於 min 3", ceiling)
+		}
+	}
+	if g.ceiling != 3 {
+		t.Errorf("持續錯誤後上限 = %d, want 3", g.ceiling)
+	}
+}
+
+func TestResourceGovernor_MemoryPressure(t *testing.T) {
+	g := newTestGovernor(2, 16)
+	g.memoryLimit = 1000
+	used := uint64(900)
+	g.readMemory = func() uint64 { return used }
+
+	ceiling, reason := g.update(16)
+	if ceiling != 12 || !strings.Contains(reason, "記憶體") {
+		t.Fatalf("update = %d, %q, want 12 與記憶體原因", ceiling, reason)
+	}
+
+	// 用量降到低門檻以下後才恢復擴充
+	used = 750
+	for range resourceCooldownChecks * 3 {
+		if ceiling, _ := g.update(12); ceiling != 12 {
+			t.Fatalf("用量介於門檻之間時上限 = %d, want 12", ceiling)
+		}
+	}
+	used = 100
+	for range resourceCooldownChecks + 1 {
+		ceiling, _ = g.update(12)
+	}
+	if ceiling != 13 {
+		t.Errorf("用量回落後上限 = %d, want 13", ceiling)
+	}
+}
@@ -2,26 +2,112 @@ package crawler
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
 	"strconv"
+	"strings"
 	"time"
 
+	"github.com/twtrubiks/ptt-spider-go/config"
 	"github.com/twtrubiks/ptt-spider-go/constants"
+	crawlererrors "github.com/twtrubiks/ptt-spider-go/errors"
 	"github.com/twtrubiks/ptt-spider-go/interfaces"
 	"github.com/twtrubiks/ptt-spider-go/internal/ioutil"
 	"github.com/twtrubiks/ptt-spider-go/ui"
 )
 
-// doWithRetry 包裝 client.Do，收到 HTTP 429 時自動以指數退避重試。
-// 非 429 錯誤碼或網路錯誤不會重試，直接回傳。
-// 重試用盡後回傳 nil 和錯誤。
+// errRetryExhausted 表示 429 重試次數已用盡，呼叫端不應再以其他方式重試同一請求
+var errRetryExhausted = errors.New("429 重試次數已用盡")
+
+// retryPolicy 單一請求的重試策略
+type retryPolicy struct {
+	maxAttempts int  // 最多嘗試次數（含第一次）
+	retryAll    bool // 所有網路錯誤與 5xx 回應都重試（不穩但值得多試的 host）
+}
+
+// defaultRetryPolicy 未設定重試配置時的策略：只重試 429 與暫時性網路錯誤
+var defaultRetryPolicy = retryPolicy{maxAttempts: constants.RetryMaxAttempts}
+
+// retryPolicies 依 host 選擇重試策略，host 層級的設定優先於全域設定
+type retryPolicies struct {
+	global retryPolicy
+	hosts  map[string]retryPolicy
+}
+
+// newRetryPolicies 合併全域與各 host 的重試配置，host 未設定的欄位沿用全域值
+func newRetryPolicies(cfg config.RetryConfig) *retryPolicies {
+	global := defaultRetryPolicy
+	if cfg.MaxAttempts > 0 {
+		global.maxAttempts = cfg.MaxAttempts
+	}
+	p := &retryPolicies{global: global}
+	if len(cfg.Hosts) > 0 {
+		p.hosts = make(map[string]retryPolicy, len(cfg.Hosts))
+	}
+	for host, hc := range cfg.Hosts {
+		policy := global
+		if hc.MaxAttempts > 0 {
+			policy.maxAttempts = hc.MaxAttempts
+		}
+		if hc.Retryable != nil {
+			policy.retryAll = *hc.Retryable
+			if !*hc.Retryable {
+				policy.maxAttempts = 1
+			}
+		}
+		p.hosts[strings.ToLower(host)] = policy
+	}
+	return p
+}
+
+// forHost 回傳 host 適用的策略：host 本身或其上層網域有設定時使用最長相符者，否則使用全域策略
+func (p *retryPolicies) forHost(host string) retryPolicy {
+	if p == nil {
+		return defaultRetryPolicy
+	}
+	if policy, ok := matchHost(p.hosts, host); ok {
+		return policy
+	}
+	return p.global
+}
+
+// doWithRetry 包裝 client.Do，收到 HTTP 429 時自動以指數退避重試，最多嘗試 policy.maxAttempts 次。
+// 網路錯誤會包裝為帶細分類（errors.ContextKeyNetKind）的 NetworkError，
+// 只有逾時、連線重置等暫時性錯誤會以相同退避重試；DNS、TLS、連線被拒直接回傳。
+// 非 429 錯誤碼不會重試。重試用盡後回傳 nil 和錯誤。
+// policy.retryAll 時所有網路錯誤與 5xx 回應也會重試，5xx 重試用盡後回傳最後一次的回應。
 // 重試訊息透過注入的 logger 輸出，避免 TUI 模式下直寫 stderr 破壞畫面。
-func doWithRetry(ctx context.Context, client interfaces.HTTPClient, req *http.Request, logger ui.Logger) (*http.Response, error) {
-	for attempt := 1; attempt <= constants.RetryMaxAttempts; attempt++ {
+func doWithRetry(ctx context.Context, client interfaces.HTTPClient, req *http.Request, policy retryPolicy, logger ui.Logger) (*http.Response, error) {
+	maxAttempts := max(policy.maxAttempts, 1)
+	for attempt := 1; attempt <= maxAttempts; attempt++ {
 		resp, err := client.Do(req)
 		if err != nil {
-			return nil, err
+			if ctx.Err() != nil {
+				return nil, err
+			}
+			netErr := crawlererrors.NewClassifiedNetworkError("請求失敗", err)
+			kind := crawlererrors.NetKind(netErr)
+			if !(kind.Retryable() || policy.retryAll) || attempt == maxAttempts {
+				return nil, netErr
+			}
+			delay := calcRetryDelay(nil, attempt)
+			logger.Warn("請求失敗（%s），第 %d/%d 次重試，等待 %v: %s, 錯誤: %v",
+				kind, attempt, maxAttempts, delay, req.URL, err)
+			if !sleepCtx(ctx, delay) {
+				return nil, ctx.Err()
+			}
+			continue
+		}
+
+		if policy.retryAll && resp.StatusCode >= http.StatusInternalServerError && attempt < maxAttempts {
+			delay := calcRetryDelay(nil, attempt)
+			logger.Warn("收到 HTTP %d，第 %d/%d 次重試，等待 %v: %s", resp.StatusCode, attempt, maxAttempts, delay, req.URL)
+			ioutil.CloseWithLog(resp.Body, "5xx 重試回應 Body")
+			if !sleepCtx(ctx, delay) {
+				return nil, ctx.Err()
+			}
+			continue
 		}
 
 		if resp.StatusCode != http.StatusTooManyRequests {
@@ -30,20 +116,16 @@ func doWithRetry(ctx context.Context, client interfaces.HTTPClient, req *http.Re
 
 		// 429: 計算退避時間並重試
 		delay := calcRetryDelay(resp, attempt)
-		logger.Warn("收到 HTTP 429，第 %d/%d 次重試，等待 %v: %s", attempt, constants.RetryMaxAttempts, delay, req.URL)
+		logger.Warn("收到 HTTP 429，第 %d/%d 次重試，等待 %v: %s", attempt, maxAttempts, delay, req.URL)
 
 		ioutil.CloseWithLog(resp.Body, "429 重試回應 Body")
 
-		if attempt == constants.RetryMaxAttempts {
-			return nil, fmt.Errorf("重試 %d 次後仍收到 429: %s", constants.RetryMaxAttempts, req.URL)
+		if attempt == maxAttempts {
+			return nil, fmt.Errorf("重試 %d 次後仍收到 429: %s: %w", maxAttempts, req.URL, errRetryExhausted)
 		}
 
-		timer := time.NewTimer(delay)
-		select {
-		case <-ctx.Done():
-			timer.Stop()
+		if !sleepCtx(ctx, delay) {
 			return nil, ctx.Err()
-		case <-timer.C:
 		}
 	}
 
@@ -51,6 +133,26 @@ func doWithRetry(ctx context.Context, client interfaces.HTTPClient, req *http.Re
 	return nil, fmt.Errorf("重試邏輯異常: %s", req.URL)
 }
 
+// sleepCtx 等待 delay，期間 ctx 被取消時提前返回 false
+func sleepCtx(ctx context.Context, delay time.Duration) bool {
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return false
+	case <-timer.C:
+		return true
+	}
+}
+
+// fetchFailureReason 描述單次請求失敗的原因：網路錯誤或非 200 狀態碼
+func fetchFailureReason(resp *http.Response, err error) string {
+	if err != nil {
+		return err.Error()
+	}
+	return fmt.Sprintf("狀態碼 %d", resp.StatusCode)
+}
+
 // calcRetryDelay 計算第 attempt 次重試的等待時間。
 // 若 response 包含 Retry-After header（秒數或 HTTP-date 格式），則優先使用。
 // 否則使用指數退避公式：initialDelay * factor^(attempt-1)，上限為 maxDelay。
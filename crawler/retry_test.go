@@ -4,13 +4,18 @@ import (
 	"context"
 	"errors"
 	"io"
+	"net"
 	"net/http"
+	"net/url"
 	"strings"
 	"testing"
 	"time"
 
+	"github.com/twtrubiks/ptt-spider-go/config"
 	"github.com/twtrubiks/ptt-spider-go/constants"
+	crawlererrors "github.com/twtrubiks/ptt-spider-go/errors"
 	"github.com/twtrubiks/ptt-spider-go/mocks"
+	"github.com/twtrubiks/ptt-spider-go/types"
 	"github.com/twtrubiks/ptt-spider-go/ui"
 )
 
@@ -43,7 +48,7 @@ func TestDoWithRetry_UsesInjectedLogger(t *testing.T) {
 	}
 
 	req, _ := http.NewRequestWithContext(context.Background(), "GET", "http://example.com", nil)
-	resp, err := doWithRetry(context.Background(), client, req, logger)
+	resp, err := doWithRetry(context.Background(), client, req, defaultRetryPolicy, logger)
 
 	if err != nil {
 		t.Fatalf("期望無錯誤，但收到: %v", err)
@@ -66,7 +71,7 @@ func TestDoWithRetry_ImmediateSuccess(t *testing.T) {
 	}
 
 	req, _ := http.NewRequestWithContext(context.Background(), "GET", "http://example.com", nil)
-	resp, err := doWithRetry(context.Background(), client, req, ui.NewNoopLogger())
+	resp, err := doWithRetry(context.Background(), client, req, defaultRetryPolicy, ui.NewNoopLogger())
 
 	if err != nil {
 		t.Fatalf("期望無錯誤，但收到: %v", err)
@@ -97,7 +102,7 @@ func TestDoWithRetry_429ThenSuccess(t *testing.T) {
 	}
 
 	req, _ := http.NewRequestWithContext(context.Background(), "GET", "http://example.com", nil)
-	resp, err := doWithRetry(context.Background(), client, req, ui.NewNoopLogger())
+	resp, err := doWithRetry(context.Background(), client, req, defaultRetryPolicy, ui.NewNoopLogger())
 
 	if err != nil {
 		t.Fatalf("期望無錯誤，但收到: %v", err)
@@ -125,7 +130,7 @@ func TestDoWithRetry_ExhaustedRetries(t *testing.T) {
 	}
 
 	req, _ := http.NewRequestWithContext(context.Background(), "GET", "http://example.com", nil)
-	resp, err := doWithRetry(context.Background(), client, req, ui.NewNoopLogger())
+	resp, err := doWithRetry(context.Background(), client, req, defaultRetryPolicy, ui.NewNoopLogger())
 
 	if err == nil {
 		t.Fatal("期望收到錯誤，但沒有")
@@ -139,6 +144,9 @@ func TestDoWithRetry_ExhaustedRetries(t *testing.T) {
 	if !strings.Contains(err.Error(), "429") {
 		t.Fatalf("錯誤訊息應包含 429，但收到: %v", err)
 	}
+	if !errors.Is(err, errRetryExhausted) {
+		t.Fatalf("錯誤應包裝 errRetryExhausted，但收到: %v", err)
+	}
 }
 
 func TestDoWithRetry_ContextCancelled(t *testing.T) {
@@ -164,7 +172,7 @@ func TestDoWithRetry_ContextCancelled(t *testing.T) {
 	}
 
 	req, _ := http.NewRequestWithContext(ctx, "GET", "http://example.com", nil)
-	resp, err := doWithRetry(ctx, client, req, ui.NewNoopLogger())
+	resp, err := doWithRetry(ctx, client, req, defaultRetryPolicy, ui.NewNoopLogger())
 
 	if err == nil {
 		t.Fatal("期望收到錯誤，但沒有")
@@ -186,7 +194,7 @@ func TestDoWithRetry_NetworkError(t *testing.T) {
 	}
 
 	req, _ := http.NewRequestWithContext(context.Background(), "GET", "http://example.com", nil)
-	resp, err := doWithRetry(context.Background(), client, req, ui.NewNoopLogger())
+	resp, err := doWithRetry(context.Background(), client, req, defaultRetryPolicy, ui.NewNoopLogger())
 
 	if err == nil {
 		t.Fatal("期望收到錯誤，但沒有")
@@ -199,6 +207,178 @@ func TestDoWithRetry_NetworkError(t *testing.T) {
 	}
 }
 
+// TestDoWithRetry_NetworkErrorKinds 驗證網路錯誤依細分類決定是否重試：
+// DNS 失敗不重試，逾時以退避重試後成功
+func TestDoWithRetry_NetworkErrorKinds(t *testing.T) {
+	dnsErr := &url.Error{Op: "Get", URL: "http://example.com", Err: &net.DNSError{Err: "no such host", Name: "example.com", IsNotFound: true}}
+	timeoutErr := &url.Error{Op: "Get", URL: "http://example.com", Err: context.DeadlineExceeded}
+
+	tests := []struct {
+		name      string
+		firstErr  error
+		wantCalls int
+		wantKind  crawlererrors.NetErrorKind // 空字串表示預期成功
+	}{
+		{"DNS 失敗不重試", dnsErr, 1, crawlererrors.NetKindDNS},
+		{"逾時重試後成功", timeoutErr, 2, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calls := 0
+			client := &mocks.MockHTTPClient{
+				DoFunc: func(_ *http.Request) (*http.Response, error) {
+					calls++
+					if calls == 1 {
+						return nil, tt.firstErr
+					}
+					return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader("ok"))}, nil
+				},
+			}
+
+			req, _ := http.NewRequestWithContext(context.Background(), "GET", "http://example.com", nil)
+			resp, err := doWithRetry(context.Background(), client, req, defaultRetryPolicy, ui.NewNoopLogger())
+
+			if calls != tt.wantCalls {
+				t.Errorf("請求次數 = %d, want %d", calls, tt.wantCalls)
+			}
+			if tt.wantKind == "" {
+				if err != nil {
+					t.Fatalf("期望重試後成功，但收到: %v", err)
+				}
+				_ = resp.Body.Close()
+				return
+			}
+			if !crawlererrors.IsNetworkError(err) || crawlererrors.NetKind(err) != tt.wantKind {
+				t.Errorf("錯誤 = %v, want NetworkError(%s)", err, tt.wantKind)
+			}
+		})
+	}
+}
+
+func TestRetryPolicies_ForHost(t *testing.T) {
+	policies := newRetryPolicies(config.RetryConfig{
+		MaxAttempts: 4,
+		Hosts: map[string]config.HostRetryConfig{
+			"imgur.com":        {Retryable: new(true)},
+			"i.imgur.com":      {MaxAttempts: 6},
+			"dead.example.com": {Retryable: new(false), MaxAttempts: 5},
+		},
+	})
+
+	tests := []struct {
+		host string
+		want retryPolicy
+	}{
+		{"www.ptt.cc", retryPolicy{maxAttempts: 4}},
+		{"imgur.com", retryPolicy{maxAttempts: 4, retryAll: true}},
+		{"m.imgur.com", retryPolicy{maxAttempts: 4, retryAll: true}},
+		// 最長相符者優先，未設定的 retryable 沿用全域而非上層網域
+		{"I.IMGUR.COM", retryPolicy{maxAttempts: 6}},
+		// 不可重試的 host 一律只嘗試一次
+		{"dead.example.com", retryPolicy{maxAttempts: 1}},
+		{"example.com", retryPolicy{maxAttempts: 4}},
+	}
+	for _, tt := range tests {
+		if got := policies.forHost(tt.host); got != tt.want {
+			t.Errorf("forHost(%q) = %+v, want %+v", tt.host, got, tt.want)
+		}
+	}
+
+	var nilPolicies *retryPolicies
+	if got := nilPolicies.forHost("www.ptt.cc"); got != defaultRetryPolicy {
+		t.Errorf("未設定時 forHost() = %+v, want %+v", got, defaultRetryPolicy)
+	}
+}
+
+// TestDoWithRetry_HostPolicy 驗證 host 層級的策略：retryAll 時連線被拒與 5xx 也重試，
+// 不可重試的 host 收到 429 直接回傳錯誤
+func TestDoWithRetry_HostPolicy(t *testing.T) {
+	refused := &url.Error{Op: "Get", URL: "http://example.com", Err: &net.OpError{Op: "dial", Err: errors.New("connection refused")}}
+
+	tests := []struct {
+		name       string
+		policy     retryPolicy
+		responses  []func() (*http.Response, error)
+		wantCalls  int
+		wantStatus int // 0 表示預期錯誤
+	}{
+		{
+			name:   "retryAll 重試連線被拒",
+			policy: retryPolicy{maxAttempts: 2, retryAll: true},
+			responses: []func() (*http.Response, error){
+				func() (*http.Response, error) { return nil, refused },
+				func() (*http.Response, error) { return newTestResponse(http.StatusOK), nil },
+			},
+			wantCalls:  2,
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:   "retryAll 重試 5xx，用盡後回傳最後的回應",
+			policy: retryPolicy{maxAttempts: 2, retryAll: true},
+			responses: []func() (*http.Response, error){
+				func() (*http.Response, error) { return newTestResponse(http.StatusBadGateway), nil },
+				func() (*http.Response, error) { return newTestResponse(http.StatusServiceUnavailable), nil },
+			},
+			wantCalls:  2,
+			wantStatus: http.StatusServiceUnavailable,
+		},
+		{
+			name:   "全域策略不重試連線被拒",
+			policy: defaultRetryPolicy,
+			responses: []func() (*http.Response, error){
+				func() (*http.Response, error) { return nil, refused },
+			},
+			wantCalls: 1,
+		},
+		{
+			name:   "不可重試的 host 不重試 429",
+			policy: retryPolicy{maxAttempts: 1},
+			responses: []func() (*http.Response, error){
+				func() (*http.Response, error) { return newTestResponse(http.StatusTooManyRequests), nil },
+			},
+			wantCalls: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calls := 0
+			client := &mocks.MockHTTPClient{
+				DoFunc: func(_ *http.Request) (*http.Response, error) {
+					calls++
+					return tt.responses[min(calls, len(tt.responses))-1]()
+				},
+			}
+
+			req, _ := http.NewRequestWithContext(context.Background(), "GET", "http://example.com", nil)
+			resp, err := doWithRetry(context.Background(), client, req, tt.policy, ui.NewNoopLogger())
+
+			if calls != tt.wantCalls {
+				t.Errorf("請求次數 = %d, want %d", calls, tt.wantCalls)
+			}
+			if tt.wantStatus == 0 {
+				if err == nil {
+					t.Error("期望收到錯誤，但沒有")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("期望無錯誤，但收到: %v", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+			if resp.StatusCode != tt.wantStatus {
+				t.Errorf("狀態碼 = %d, want %d", resp.StatusCode, tt.wantStatus)
+			}
+		})
+	}
+}
+
+// newTestResponse 建立指定狀態碼、Body 為空的回應
+func newTestResponse(status int) *http.Response {
+	return &http.Response{StatusCode: status, Body: io.NopCloser(strings.NewReader(""))}
+}
+
 func TestDoWithRetry_Non429ErrorCode(t *testing.T) {
 	client := &mocks.MockHTTPClient{
 		DoFunc: func(_ *http.Request) (*http.Response, error) {
@@ -210,7 +390,7 @@ func TestDoWithRetry_Non429ErrorCode(t *testing.T) {
 	}
 
 	req, _ := http.NewRequestWithContext(context.Background(), "GET", "http://example.com", nil)
-	resp, err := doWithRetry(context.Background(), client, req, ui.NewNoopLogger())
+	resp, err := doWithRetry(context.Background(), client, req, defaultRetryPolicy, ui.NewNoopLogger())
 
 	if err != nil {
 		t.Fatalf("期望無錯誤，但收到: %v", err)
@@ -242,7 +422,7 @@ func TestDoWithRetry_RetryAfterHeaderSeconds(t *testing.T) {
 
 	req, _ := http.NewRequestWithContext(context.Background(), "GET", "http://example.com", nil)
 	start := time.Now()
-	resp, err := doWithRetry(context.Background(), client, req, ui.NewNoopLogger())
+	resp, err := doWithRetry(context.Background(), client, req, defaultRetryPolicy, ui.NewNoopLogger())
 	elapsed := time.Since(start)
 
 	if err != nil {
@@ -327,3 +507,61 @@ func TestCalcRetryDelay_NilResponse(t *testing.T) {
 		t.Errorf("期望延遲 %v，但收到 %v", expected, delay)
 	}
 }
+
+// TestFetchImage_AcceptFallbacks 驗證圖片下載失敗時依序改用 acceptFallbacks 的 Accept 標頭重試
+func TestFetchImage_AcceptFallbacks(t *testing.T) {
+	tests := []struct {
+		name        string
+		fallbacks   []string
+		okAccept    string // 回應 200 的 Accept 值，其餘回應 406
+		netErr      bool   // 預設標頭的請求回傳連線錯誤
+		wantOK      bool
+		wantAccepts []string
+	}{
+		{"預設標頭成功時不重試", []string{"image/*"}, "", false, true, []string{""}},
+		{"改用第二個 Accept 成功", []string{"image/webp", "image/*"}, "image/*", false, true, []string{"", "image/webp", "image/*"}},
+		{"全部失敗", []string{"image/*"}, "never", false, false, []string{"", "image/*"}},
+		{"未設定清單時只請求一次", nil, "image/*", false, false, []string{""}},
+		{"連線錯誤也會換 Accept 重試", []string{"*/*"}, "*/*", true, true, []string{"", "*/*"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var accepts []string
+			client := &mocks.MockHTTPClient{
+				DoFunc: func(req *http.Request) (*http.Response, error) {
+					accept := req.Header.Get("Accept")
+					accepts = append(accepts, accept)
+					if tt.netErr && accept == "" {
+						return nil, errors.New("connection reset")
+					}
+					status := http.StatusNotAcceptable
+					if accept == tt.okAccept {
+						status = http.StatusOK
+					}
+					return &http.Response{
+						StatusCode: status,
+						Body:       io.NopCloser(strings.NewReader("")),
+						Request:    req,
+					}, nil
+				},
+			}
+
+			cfg := config.DefaultConfig()
+			cfg.Crawler.AcceptFallbacks = tt.fallbacks
+			c := NewCrawlerWithDependencies(client, mocks.NewMockParser(), mocks.NewMockMarkdownGenerator(),
+				"test", 1, 0, "", cfg, WithLogger(ui.NewNoopLogger()))
+
+			resp := c.fetchImage(context.Background(), 1, types.DownloadTask{ImageURL: "http://example.com/img.jpg"})
+			if (resp != nil) != tt.wantOK {
+				t.Errorf("fetchImage() 成功 = %v, want %v", resp != nil, tt.wantOK)
+			}
+			if resp != nil {
+				_ = resp.Body.Close()
+			}
+			if strings.Join(accepts, "|") != strings.Join(tt.wantAccepts, "|") {
+				t.Errorf("送出的 Accept = %q, want %q", accepts, tt.wantAccepts)
+			}
+		})
+	}
+}
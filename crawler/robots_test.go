@@ -0,0 +1,109 @@
+package crawler
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/twtrubiks/ptt-spider-go/config"
+	"github.com/twtrubiks/ptt-spider-go/mocks"
+	"github.com/twtrubiks/ptt-spider-go/types"
+)
+
+// TestFetchAndParseArticle_RespectRobots 驗證啟用 respectRobots 時，
+// robots.txt 禁止的文章不會被請求。
+func TestFetchAndParseArticle_RespectRobots(t *testing.T) {
+	var mu sync.Mutex
+	var requested []string
+	client := &mocks.MockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			mu.Lock()
+			requested = append(requested, req.URL.Path)
+			mu.Unlock()
+			body := "<html></html>"
+			if req.URL.Path == "/robots.txt" {
+				body = "User-agent: *\nDisallow: /bbs/Secret/\n"
+			}
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       io.NopCloser(strings.NewReader(body)),
+			}, nil
+		},
+	}
+
+	cfg := config.DefaultConfig()
+	cfg.Crawler.RespectRobots = true
+	c := NewCrawlerWithDependencies(client, mocks.NewMockParser(), mocks.NewMockMarkdownGenerator(), "test", 1, 0, "", cfg)
+
+	ctx := context.Background()
+	if _, err := c.fetchAndParseArticle(ctx, types.ArticleInfo{URL: "https://www.ptt.cc/bbs/Secret/M.1.A.ABC.html"}); err == nil {
+		t.Error("robots.txt 禁止的文章應回傳錯誤")
+	}
+	if _, err := c.fetchAndParseArticle(ctx, types.ArticleInfo{URL: "https://www.ptt.cc/bbs/Beauty/M.1.A.ABC.html"}); err != nil {
+		t.Errorf("允許的文章不應回傳錯誤: %v", err)
+	}
+
+	for _, path := range requested {
+		if strings.HasPrefix(path, "/bbs/Secret/") {
+			t.Errorf("不應請求被禁止的路徑: %s", path)
+		}
+	}
+}
+
+// TestRobotsAllowed_DisabledByDefault 驗證未啟用 respectRobots 時不會抓取 robots.txt。
+func TestRobotsAllowed_DisabledByDefault(t *testing.T) {
+	client := &mocks.MockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			t.Errorf("未啟用時不應發出請求: %s", req.URL)
+			return nil, nil
+		},
+	}
+	c := NewCrawlerWithDependencies(client, mocks.NewMockParser(), mocks.NewMockMarkdownGenerator(), "test", 1, 0, "", config.DefaultConfig())
+
+	if !c.robotsAllowed(context.Background(), "https://www.ptt.cc/bbs/Secret/index.html") {
+		t.Error("未啟用 respectRobots 時應一律允許")
+	}
+}
+
+// TestRobotsAllowed_SharedRequestPathAndWarnOnce 驗證 robots.txt 請求經由 doRequest 計入指標，
+// 抓取失敗時每次查詢都重試，但同一 host 只警告一次
+func TestRobotsAllowed_SharedRequestPathAndWarnOnce(t *testing.T) {
+	var robotsFetches int
+	client := &mocks.MockHTTPClient{
+		DoFunc: func(_ *http.Request) (*http.Response, error) {
+			robotsFetches++
+			return &http.Response{
+				StatusCode: http.StatusBadGateway,
+				Body:       io.NopCloser(strings.NewReader("")),
+			}, nil
+		},
+	}
+	var warns []string
+	logger := &mocks.MockLogger{WarnFunc: func(format string, args ...any) {
+		warns = append(warns, fmt.Sprintf(format, args...))
+	}}
+
+	cfg := config.DefaultConfig()
+	cfg.Crawler.RespectRobots = true
+	c := NewCrawlerWithDependencies(client, mocks.NewMockParser(), mocks.NewMockMarkdownGenerator(),
+		"test", 1, 0, "", cfg, WithLogger(logger))
+
+	for range 3 {
+		if !c.robotsAllowed(context.Background(), "https://www.ptt.cc/bbs/Beauty/index.html") {
+			t.Fatal("robots.txt 抓取失敗時應允許")
+		}
+	}
+	if robotsFetches != 3 {
+		t.Errorf("暫時性失敗不應快取，robots.txt 應抓取 3 次，實際 %d 次", robotsFetches)
+	}
+	if len(warns) != 1 {
+		t.Errorf("同一 host 應只警告一次，實際: %v", warns)
+	}
+	if got := c.metrics.Snapshot().TotalRequests; got != 3 {
+		t.Errorf("robots.txt 請求應經由 doRequest 計入指標，Requests = %d, want 3", got)
+	}
+}
@@ -0,0 +1,21 @@
+package crawler
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// runTimestampLayout 是執行命名空間目錄名稱中時間戳的格式
+const runTimestampLayout = "20060102-150405"
+
+// RunNamespace 回傳 -run-name 對應的輸出命名空間目錄名稱（如 "beauty-weekly_20260105-093000"），
+// 以任務名稱加上開始時間區分同一任務的多次執行；看板目錄、執行報告等輸出都建立在此目錄下。
+// 名稱必須是 cleanFileName 不會改動的檔名：不可含路徑分隔符號或無法作為檔名的字元，避免輸出落到命名空間之外。
+func RunNamespace(name string, start time.Time) (string, error) {
+	name = strings.TrimSpace(name)
+	if name == "" || name == "." || name == ".." || cleanFileName(name) != name {
+		return "", fmt.Errorf("無效的任務名稱 %q（不可為空、不可為 Windows 保留名稱或以句點結尾，也不可含 \\ / : * ? \" < > | 等字元）", name)
+	}
+	return name + "_" + start.Format(runTimestampLayout), nil
+}
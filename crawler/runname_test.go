@@ -0,0 +1,36 @@
+package crawler
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRunNamespace(t *testing.T) {
+	start := time.Date(2026, 1, 5, 9, 30, 0, 0, time.Local)
+	tests := []struct {
+		name    string
+		input   string
+		want    string
+		wantErr bool
+	}{
+		{"一般名稱", "beauty-weekly", "beauty-weekly_20260105-093000", false},
+		{"中文名稱並去除前後空白", " 每週正妹 ", "每週正妹_20260105-093000", false},
+		{"空字串", "", "", true},
+		{"上層目錄", "..", "", true},
+		{"含路徑分隔符號", "a/b", "", true},
+		{"含反斜線", `a\b`, "", true},
+		{"含非法字元", "run:1", "", true},
+		{"Windows 保留名稱", "nul", "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := RunNamespace(tt.input, start)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("RunNamespace(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("RunNamespace(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,129 @@
+package crawler
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/twtrubiks/ptt-spider-go/performance"
+	"github.com/twtrubiks/ptt-spider-go/ui"
+)
+
+// -progress 未指定間隔時，終端機狀態列與定期日誌的更新間隔
+const (
+	statusBarInterval = time.Second
+	statusLogInterval = progressReportInterval
+)
+
+// WithProgressReport 啟用 -progress：每隔 interval 輸出一次管線狀態（已解析文章數、圖片完成與失敗張數、各佇列深度與 ETA）。
+// bar 不為 nil 時更新終端機狀態列，否則輸出一行「狀態」日誌；interval 為 0 時狀態列每秒、日誌每 10 秒更新。
+// 啟用後原本定期輸出的「進度」日誌已包含在狀態中，不再另外輸出
+func WithProgressReport(interval time.Duration, bar *ui.StatusBar) Option {
+	return func(c *Crawler) {
+		if interval <= 0 {
+			interval = statusLogInterval
+			if bar != nil {
+				interval = statusBarInterval
+			}
+		}
+		c.statusInterval = interval
+		c.statusBar = bar
+	}
+}
+
+// queueDepths 回傳文章、下載與 Markdown 三個佇列目前的深度，依管線順序排列
+func (ch *WorkerChannels) queueDepths() performance.PipelineStats {
+	return performance.PipelineStats{
+		{Name: "文章", Len: len(ch.ArticleInfo), Cap: cap(ch.ArticleInfo)},
+		{Name: "下載", Len: len(ch.DownloadTask), Cap: cap(ch.DownloadTask)},
+		{Name: "Markdown", Len: len(ch.MarkdownTask), Cap: cap(ch.MarkdownTask)},
+	}
+}
+
+// pipelineStatus 是某一時間點的管線狀態：處理量取自指標收集器，佇列深度取自 channel 長度
+type pipelineStatus struct {
+	Articles   int64 // 已解析的文章數
+	Downloaded int64 // 下載完成的圖片數
+	Failed     int64 // 下載失敗的圖片數
+	Queues     performance.PipelineStats
+	Progress   progressSnapshot
+}
+
+// pipelineStatus 讀取目前的管線狀態
+func (c *Crawler) pipelineStatus(channels *WorkerChannels) pipelineStatus {
+	s := pipelineStatus{Queues: channels.queueDepths(), Progress: c.tracker.snapshot()}
+	if c.metrics != nil {
+		snap := c.metrics.Snapshot()
+		s.Articles = snap.ArticlesParsed
+		s.Downloaded = snap.ImagesDownloaded
+		for _, n := range snap.DownloadFailures {
+			s.Failed += n
+		}
+	}
+	return s
+}
+
+// String 回傳如 "3/10 頁，文章 12 篇，圖片 340 張（失敗 3），佇列 文章 5/100、下載 80/1000、Markdown 0/50，預估剩餘 1m20s"，
+// 內容保持在一行內，供終端機狀態列顯示
+func (s pipelineStatus) String() string {
+	var b strings.Builder
+	if p := s.Progress; p.UnitsTotal > 0 {
+		fmt.Fprintf(&b, "%d/%d %s，", p.UnitsDone, p.UnitsTotal, p.Unit)
+	}
+	fmt.Fprintf(&b, "文章 %d 篇，圖片 %d 張（失敗 %d），佇列", s.Articles, s.Downloaded, s.Failed)
+	for i, q := range s.Queues {
+		sep := " "
+		if i > 0 {
+			sep = "、"
+		}
+		fmt.Fprintf(&b, "%s%s %d/%d", sep, q.Name, q.Len, q.Cap)
+	}
+	if s.Progress.ETAKnown {
+		fmt.Fprintf(&b, "，預估剩餘 %s", s.Progress.ETA.Round(time.Second))
+	}
+	return b.String()
+}
+
+// startStatusReport 在啟用 -progress 時於背景定期輸出管線狀態，回傳的函式會停止輸出、清除狀態列並等待結束
+func (c *Crawler) startStatusReport(ctx context.Context, channels *WorkerChannels) func() {
+	if c.statusInterval <= 0 {
+		return func() {}
+	}
+	stop, done := make(chan struct{}), make(chan struct{})
+	go func() {
+		defer close(done)
+		c.reportStatus(ctx, channels, c.statusInterval, stop)
+	}()
+	return func() {
+		close(stop)
+		<-done
+	}
+}
+
+// reportStatus 每隔 interval 輸出一次管線狀態，直到 ctx 取消或 stop 關閉。
+// 狀態列模式啟動時立即顯示，結束時清除，之後的結束統計照常輸出
+func (c *Crawler) reportStatus(ctx context.Context, channels *WorkerChannels, interval time.Duration, stop <-chan struct{}) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	if c.statusBar != nil {
+		c.statusBar.Set(c.pipelineStatus(channels).String())
+		defer c.statusBar.Clear()
+	}
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-stop:
+			return
+		case <-ticker.C:
+			status := c.pipelineStatus(channels)
+			if c.statusBar != nil {
+				c.statusBar.Set(status.String())
+			} else {
+				c.logger.Info("狀態: %s", status)
+			}
+		}
+	}
+}
@@ -0,0 +1,159 @@
+package crawler
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/twtrubiks/ptt-spider-go/config"
+	"github.com/twtrubiks/ptt-spider-go/mocks"
+	"github.com/twtrubiks/ptt-spider-go/performance"
+	"github.com/twtrubiks/ptt-spider-go/types"
+	"github.com/twtrubiks/ptt-spider-go/ui"
+)
+
+// syncBuffer 是可供狀態列 goroutine 與測試並行存取的 buffer
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
+
+func TestPipelineStatus_String(t *testing.T) {
+	queues := performance.PipelineStats{
+		{Name: "文章", Len: 5, Cap: 100},
+		{Name: "下載", Len: 80, Cap: 1000},
+		{Name: "Markdown", Len: 0, Cap: 50},
+	}
+	tests := []struct {
+		name   string
+		status pipelineStatus
+		want   string
+	}{
+		{
+			name:   "尚無總量與 ETA",
+			status: pipelineStatus{Queues: queues},
+			want:   "文章 0 篇，圖片 0 張（失敗 0），佇列 文章 5/100、下載 80/1000、Markdown 0/50",
+		},
+		{
+			name: "含頁數進度與 ETA",
+			status: pipelineStatus{
+				Articles: 12, Downloaded: 340, Failed: 3, Queues: queues,
+				Progress: progressSnapshot{Unit: unitPage, UnitsDone: 3, UnitsTotal: 10, ETA: 80 * time.Second, ETAKnown: true},
+			},
+			want: "3/10 頁，文章 12 篇，圖片 340 張（失敗 3），佇列 文章 5/100、下載 80/1000、Markdown 0/50，預估剩餘 1m20s",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.status.String(); got != tt.want {
+				t.Errorf("String() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+// newStatusCrawler 建立已累計部分指標與佇列積壓的 Crawler
+func newStatusCrawler(t *testing.T, opts ...Option) (*Crawler, *WorkerChannels) {
+	t.Helper()
+	c := NewCrawlerWithDependencies(mocks.NewMockHTTPClient(), mocks.NewMockParser(), mocks.NewMockMarkdownGenerator(),
+		"test", 1, 0, "", config.DefaultConfig(), opts...)
+	c.tracker = newProgressTracker(nil)
+	c.metrics.RecordArticleParsed()
+	c.metrics.RecordImageDownloaded(100)
+	c.metrics.RecordImageDownloaded(100)
+	c.metrics.RecordDownloadFailure("not_found")
+
+	channels := c.initializeChannels()
+	channels.DownloadTask <- types.DownloadTask{}
+	return c, channels
+}
+
+func TestReportStatus_Log(t *testing.T) {
+	var mu sync.Mutex
+	var lines []string
+	logger := &mocks.MockLogger{
+		InfoFunc: func(format string, args ...any) {
+			mu.Lock()
+			defer mu.Unlock()
+			lines = append(lines, fmt.Sprintf(format, args...))
+		},
+	}
+	c, channels := newStatusCrawler(t, WithLogger(logger), WithProgressReport(5*time.Millisecond, nil))
+
+	stop := c.startStatusReport(context.Background(), channels)
+	deadline := time.Now().Add(2 * time.Second)
+	for {
+		mu.Lock()
+		n := len(lines)
+		mu.Unlock()
+		if n > 0 || time.Now().After(deadline) {
+			break
+		}
+		time.Sleep(time.Millisecond)
+	}
+	stop()
+
+	mu.Lock()
+	defer mu.Unlock()
+	want := fmt.Sprintf("狀態: 文章 1 篇，圖片 2 張（失敗 1），佇列 文章 0/%d、下載 1/%d",
+		cap(channels.ArticleInfo), cap(channels.DownloadTask))
+	if len(lines) == 0 || !strings.HasPrefix(lines[0], want) {
+		t.Errorf("Info lines = %v, want prefix %q", lines, want)
+	}
+}
+
+func TestReportStatus_StatusBar(t *testing.T) {
+	var buf syncBuffer
+	bar := ui.NewStatusBar(&buf)
+	// 間隔很長，只驗證啟動時立即顯示與結束時清除
+	c, channels := newStatusCrawler(t, WithLogger(bar.Logger(ui.NewNoopLogger())), WithProgressReport(time.Hour, bar))
+
+	stop := c.startStatusReport(context.Background(), channels)
+	stop()
+
+	out := buf.String()
+	if !strings.HasPrefix(out, "文章 1 篇，圖片 2 張（失敗 1）") {
+		t.Errorf("狀態列應在啟動時立即顯示，output = %q", out)
+	}
+	if !strings.HasSuffix(out, "\r\033[K") {
+		t.Errorf("狀態列應在結束時清除，output = %q", out)
+	}
+}
+
+func TestWithProgressReport_DefaultInterval(t *testing.T) {
+	tests := []struct {
+		name     string
+		interval time.Duration
+		bar      *ui.StatusBar
+		want     time.Duration
+	}{
+		{"日誌預設 10 秒", 0, nil, statusLogInterval},
+		{"狀態列預設每秒", 0, ui.NewStatusBar(&bytes.Buffer{}), statusBarInterval},
+		{"指定間隔", 3 * time.Second, nil, 3 * time.Second},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Crawler{}
+			WithProgressReport(tt.interval, tt.bar)(c)
+			if c.statusInterval != tt.want {
+				t.Errorf("statusInterval = %v, want %v", c.statusInterval, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,51 @@
+package crawler
+
+import (
+	"path/filepath"
+
+	"github.com/twtrubiks/ptt-spider-go/titledup"
+)
+
+// recordTitle 將文章加入所屬看板的重複標題偵測，duplicateTitles 未啟用時不做任何事
+func (c *Crawler) recordTitle(board, title, articleURL, saveDir string) {
+	if !c.config.Crawler.DuplicateTitles.Enabled {
+		return
+	}
+	c.titleMu.Lock()
+	if c.titleDetectors == nil {
+		c.titleDetectors = make(map[string]*titledup.Detector)
+	}
+	d, ok := c.titleDetectors[board]
+	if !ok {
+		d = titledup.NewDetector(c.config.Crawler.DuplicateTitles.Threshold)
+		c.titleDetectors[board] = d
+	}
+	c.titleMu.Unlock()
+
+	d.Add(titledup.Article{Title: title, URL: articleURL, Dir: saveDir})
+}
+
+// writeDuplicateTitles 為每個看板寫入本次執行的重複標題群組索引（看板目錄下的 duplicates.json）
+func (c *Crawler) writeDuplicateTitles() {
+	c.titleMu.Lock()
+	defer c.titleMu.Unlock()
+	for board, d := range c.titleDetectors {
+		if c.dryRun != nil {
+			if groups := len(d.Groups()); groups > 0 {
+				c.boardLogger(board).Warn("[dry-run] %d 篇文章中發現 %d 組標題重複的文章（未寫入 %s）",
+					d.Len(), groups, titledup.IndexFileName)
+			}
+			continue
+		}
+		dir := c.boardDir(board)
+		groups, err := d.WriteIndex(dir)
+		if err != nil {
+			c.logger.Error("寫入重複標題索引失敗: %v", err)
+			continue
+		}
+		if groups > 0 {
+			c.boardLogger(board).Warn("%d 篇文章中發現 %d 組標題重複的文章，已記錄於 %s",
+				d.Len(), groups, filepath.Join(dir, titledup.IndexFileName))
+		}
+	}
+}
@@ -0,0 +1,81 @@
+package crawler
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/twtrubiks/ptt-spider-go/config"
+	"github.com/twtrubiks/ptt-spider-go/mocks"
+	"github.com/twtrubiks/ptt-spider-go/titledup"
+	"github.com/twtrubiks/ptt-spider-go/types"
+)
+
+// TestWriteDuplicateTitles 驗證轉錄文章與原文被標註為同一重複群組，並寫入看板目錄的索引
+func TestWriteDuplicateTitles(t *testing.T) {
+	t.Chdir(t.TempDir())
+
+	titles := map[string]string{
+		"http://example.com/a": "[正妹] 今天的正妹",
+		"http://example.com/b": "Fw: [正妹] 今天的正妹",
+		"http://example.com/c": "[正妹] 另一篇",
+	}
+	client := &mocks.MockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       io.NopCloser(strings.NewReader(req.URL.String())),
+			}, nil
+		},
+	}
+	parser := &mocks.MockParser{
+		ParseArticleContentFunc: func(body io.Reader) (types.ArticleContent, error) {
+			url, _ := io.ReadAll(body)
+			return types.ArticleContent{Title: titles[string(url)], ImageURLs: []string{"https://i.imgur.com/a.jpg"}}, nil
+		},
+	}
+	cfg := config.DefaultConfig()
+	cfg.Crawler.Delays = config.DelayConfig{MinMs: 0, MaxMs: 0}
+	cfg.Crawler.DuplicateTitles.Enabled = true
+	c := NewCrawlerWithDependencies(client, parser, mocks.NewMockMarkdownGenerator(), "Beauty", 1, 0, "", cfg)
+
+	downloadChan := make(chan types.DownloadTask, 10)
+	markdownChan := make(chan types.MarkdownInfo, 10)
+	for _, u := range []string{"http://example.com/a", "http://example.com/b", "http://example.com/c"} {
+		c.processArticle(context.Background(), types.ArticleInfo{URL: u, Board: "Beauty"}, downloadChan, markdownChan)
+	}
+	c.writeDuplicateTitles()
+
+	data, err := os.ReadFile(filepath.Join("Beauty", titledup.IndexFileName))
+	if err != nil {
+		t.Fatalf("應寫入重複標題索引: %v", err)
+	}
+	var idx titledup.Index
+	if err := json.Unmarshal(data, &idx); err != nil {
+		t.Fatal(err)
+	}
+	if len(idx.Groups) != 1 || len(idx.Groups[0].Articles) != 2 {
+		t.Fatalf("索引 = %s, want 一組包含原文與轉錄", data)
+	}
+	if got := idx.Groups[0].Articles[1]; got.URL != "http://example.com/b" || got.Dir != filepath.Join("Beauty", "Fw [正妹] 今天的正妹_0") {
+		t.Errorf("轉錄文章 = %+v", got)
+	}
+}
+
+func TestWriteDuplicateTitles_Disabled(t *testing.T) {
+	t.Chdir(t.TempDir())
+	c := NewCrawlerWithDependencies(&mocks.MockHTTPClient{}, mocks.NewMockParser(), mocks.NewMockMarkdownGenerator(),
+		"Beauty", 1, 0, "", config.DefaultConfig())
+	c.recordTitle("Beauty", "[正妹] 同標題", "a", "Beauty/a")
+	c.recordTitle("Beauty", "[正妹] 同標題", "b", "Beauty/b")
+	c.writeDuplicateTitles()
+
+	if _, err := os.Stat(filepath.Join("Beauty", titledup.IndexFileName)); !os.IsNotExist(err) {
+		t.Errorf("未啟用時不應寫入索引, err = %v", err)
+	}
+}
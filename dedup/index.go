@@ -0,0 +1,146 @@
+// Package dedup 維護跨執行的已下載圖片索引（圖片 URL → 內容雜湊與本地路徑），
+// 讓後續執行遇到已下載過的圖片時可建立連結或直接略過，不必重新下載。
+package dedup
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	stderrors "errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/twtrubiks/ptt-spider-go/constants"
+	"github.com/twtrubiks/ptt-spider-go/internal/fileutil"
+	"github.com/twtrubiks/ptt-spider-go/internal/ioutil"
+)
+
+// Entry 是一張已下載圖片的索引紀錄
+type Entry struct {
+	SHA256       string    `json:"sha256"`       // 圖片內容的 SHA-256（十六進位）
+	Path         string    `json:"path"`         // 首次下載時的本地路徑
+	Size         int64     `json:"size"`         // 檔案大小（bytes）
+	DownloadedAt time.Time `json:"downloadedAt"` // 下載時間
+}
+
+// Stats 是索引的統計資訊
+type Stats struct {
+	Entries      int   // 索引中的 URL 數
+	UniqueHashes int   // 不重複的圖片內容數
+	TotalBytes   int64 // 索引中所有圖片的大小總和（依 URL 計）
+	MissingFiles int   // 索引路徑已不存在的紀錄數
+}
+
+// Index 是持久化為 JSON 檔的已下載圖片索引，可供多個 worker 並行使用。
+type Index struct {
+	path string
+
+	mu      sync.Mutex
+	entries map[string]Entry
+	dirty   bool
+}
+
+// Open 載入索引檔，檔案不存在時回傳空索引
+func Open(path string) (*Index, error) {
+	idx := &Index{path: path, entries: make(map[string]Entry)}
+
+	data, err := os.ReadFile(path)
+	if stderrors.Is(err, fs.ErrNotExist) {
+		return idx, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("讀取下載索引失敗: %w", err)
+	}
+	if err := json.Unmarshal(data, &idx.entries); err != nil {
+		return nil, fmt.Errorf("解析下載索引失敗: %s: %w", path, err)
+	}
+	if idx.entries == nil {
+		idx.entries = make(map[string]Entry)
+	}
+	return idx, nil
+}
+
+// Lookup 查詢圖片 URL 是否已下載過
+func (idx *Index) Lookup(imageURL string) (Entry, bool) {
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+	e, ok := idx.entries[imageURL]
+	return e, ok
+}
+
+// Record 記錄一張已下載的圖片，覆寫同一 URL 的舊紀錄
+func (idx *Index) Record(imageURL string, e Entry) {
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+	idx.entries[imageURL] = e
+	idx.dirty = true
+}
+
+// Save 將索引寫回磁碟（原子寫入），沒有變更時不做任何事
+func (idx *Index) Save() error {
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+	if !idx.dirty {
+		return nil
+	}
+
+	data, err := json.MarshalIndent(idx.entries, "", "  ")
+	if err != nil {
+		return fmt.Errorf("序列化下載索引失敗: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(idx.path), constants.DirPermission); err != nil {
+		return fmt.Errorf("建立下載索引目錄失敗: %w", err)
+	}
+	if err := fileutil.WriteFileAtomic(idx.path, data); err != nil {
+		return fmt.Errorf("寫入下載索引失敗: %w", err)
+	}
+	idx.dirty = false
+	return nil
+}
+
+// Stats 統計索引內容，並檢查紀錄的檔案是否仍存在
+func (idx *Index) Stats() Stats {
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+
+	hashes := make(map[string]struct{}, len(idx.entries))
+	st := Stats{Entries: len(idx.entries)}
+	for _, e := range idx.entries {
+		hashes[e.SHA256] = struct{}{}
+		st.TotalBytes += e.Size
+		if _, err := os.Stat(e.Path); err != nil {
+			st.MissingFiles++
+		}
+	}
+	st.UniqueHashes = len(hashes)
+	return st
+}
+
+// Clear 刪除索引檔，檔案不存在時不視為錯誤
+func Clear(path string) error {
+	if err := os.Remove(path); err != nil && !stderrors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("刪除下載索引失敗: %w", err)
+	}
+	return nil
+}
+
+// HashFile 計算檔案內容的 SHA-256（十六進位）與大小
+func HashFile(path string) (string, int64, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", 0, err
+	}
+	defer ioutil.CloseWithLog(f, "雜湊檔案")
+
+	h := sha256.New()
+	n, err := io.Copy(h, f)
+	if err != nil {
+		return "", 0, err
+	}
+	return hex.EncodeToString(h.Sum(nil)), n, nil
+}
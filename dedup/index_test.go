@@ -0,0 +1,107 @@
+package dedup
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestIndex_SaveAndReopen(t *testing.T) {
+	dir := t.TempDir()
+	indexPath := filepath.Join(dir, "state", "downloads.json")
+
+	idx, err := Open(indexPath)
+	if err != nil {
+		t.Fatalf("Open() 不存在的索引應回傳空索引，got error %v", err)
+	}
+	if _, ok := idx.Lookup("https://i.imgur.com/a.jpg"); ok {
+		t.Fatal("空索引不應命中")
+	}
+
+	entry := Entry{SHA256: "abc", Path: filepath.Join(dir, "a.jpg"), Size: 3, DownloadedAt: time.Unix(0, 0).UTC()}
+	idx.Record("https://i.imgur.com/a.jpg", entry)
+	if err := idx.Save(); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	reopened, err := Open(indexPath)
+	if err != nil {
+		t.Fatalf("重新開啟索引失敗: %v", err)
+	}
+	got, ok := reopened.Lookup("https://i.imgur.com/a.jpg")
+	if !ok || got != entry {
+		t.Errorf("Lookup() = %+v, %v; want %+v, true", got, ok, entry)
+	}
+}
+
+func TestIndex_SaveWithoutChangesSkipsWrite(t *testing.T) {
+	indexPath := filepath.Join(t.TempDir(), "downloads.json")
+	idx, _ := Open(indexPath)
+	if err := idx.Save(); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+	if _, err := os.Stat(indexPath); !os.IsNotExist(err) {
+		t.Error("沒有變更時不應建立索引檔")
+	}
+}
+
+func TestOpen_InvalidJSON(t *testing.T) {
+	indexPath := filepath.Join(t.TempDir(), "downloads.json")
+	if err := os.WriteFile(indexPath, []byte("{broken"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Open(indexPath); err == nil {
+		t.Error("索引檔格式錯誤時應回傳錯誤")
+	}
+}
+
+func TestIndex_Stats(t *testing.T) {
+	dir := t.TempDir()
+	existing := filepath.Join(dir, "a.jpg")
+	if err := os.WriteFile(existing, []byte("img"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	idx, _ := Open(filepath.Join(dir, "downloads.json"))
+	idx.Record("u1", Entry{SHA256: "h1", Path: existing, Size: 3})
+	idx.Record("u2", Entry{SHA256: "h1", Path: existing, Size: 3})
+	idx.Record("u3", Entry{SHA256: "h2", Path: filepath.Join(dir, "gone.jpg"), Size: 10})
+
+	st := idx.Stats()
+	want := Stats{Entries: 3, UniqueHashes: 2, TotalBytes: 16, MissingFiles: 1}
+	if st != want {
+		t.Errorf("Stats() = %+v, want %+v", st, want)
+	}
+}
+
+func TestClear(t *testing.T) {
+	indexPath := filepath.Join(t.TempDir(), "downloads.json")
+	if err := os.WriteFile(indexPath, []byte("{}"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := Clear(indexPath); err != nil {
+		t.Fatalf("Clear() error = %v", err)
+	}
+	if _, err := os.Stat(indexPath); !os.IsNotExist(err) {
+		t.Error("Clear() 後索引檔應被刪除")
+	}
+	if err := Clear(indexPath); err != nil {
+		t.Errorf("索引檔不存在時 Clear() 不應回傳錯誤，got %v", err)
+	}
+}
+
+func TestHashFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "a.txt")
+	if err := os.WriteFile(path, []byte("abc"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	sum, size, err := HashFile(path)
+	if err != nil {
+		t.Fatalf("HashFile() error = %v", err)
+	}
+	const want = "ba7816bf8f01cfea414140de5dae2223b00361a396177a9cb410ff61f20015ad"
+	if sum != want || size != 3 {
+		t.Errorf("HashFile() = %s, %d; want %s, 3", sum, size, want)
+	}
+}
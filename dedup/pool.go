@@ -0,0 +1,85 @@
+package dedup
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/twtrubiks/ptt-spider-go/constants"
+	"github.com/twtrubiks/ptt-spider-go/internal/fileutil"
+)
+
+// Pool 是以內容雜湊定址的共用圖片目錄：每種內容只保留一份檔案（poolDir/ab/abcdef…），
+// 各文章目錄中的圖片都是指向它的硬連結，可供多個 worker 並行使用。
+type Pool struct {
+	dir string
+
+	mu    sync.Mutex
+	paths map[string]string // SHA-256 → 共用目錄中的檔案路徑
+}
+
+// OpenPool 開啟（必要時建立）共用圖片目錄
+func OpenPool(dir string) (*Pool, error) {
+	if err := os.MkdirAll(dir, constants.DirPermission); err != nil {
+		return nil, fmt.Errorf("建立共用圖片目錄失敗: %w", err)
+	}
+	return &Pool{dir: dir, paths: make(map[string]string)}, nil
+}
+
+// Store 將剛下載的檔案加入共用目錄。
+// 已有相同內容時，以指向共用檔案的硬連結取代 path 並回傳 true；
+// 否則將 path 連結（無法連結時複製）進共用目錄，回傳 false。
+// 無法建立硬連結（如跨檔案系統）時回傳錯誤，path 保持原本的獨立檔案。
+func (p *Pool) Store(path string) (bool, error) {
+	sum, _, err := HashFile(path)
+	if err != nil {
+		return false, fmt.Errorf("計算雜湊失敗: %w", err)
+	}
+
+	// 整個比對與連結過程持鎖，避免兩個 worker 同時為相同內容建立共用檔案
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	shared, ok := p.paths[sum]
+	if !ok {
+		// 先前執行留下的共用檔案同樣可以沿用
+		shared = filepath.Join(p.dir, sum[:2], sum)
+		if _, err := os.Stat(shared); err != nil {
+			if err := fileutil.LinkOrCopy(path, shared); err != nil {
+				return false, fmt.Errorf("加入共用目錄失敗: %w", err)
+			}
+			p.paths[sum] = shared
+			return false, nil
+		}
+		p.paths[sum] = shared
+	}
+
+	if same, err := sameFile(path, shared); err != nil || same {
+		return same, err
+	}
+	// 先連結到暫存名稱再 rename 覆蓋，過程中 path 一直是完整的圖片
+	tmp := path + ".pool-tmp"
+	_ = os.Remove(tmp)
+	if err := os.Link(shared, tmp); err != nil {
+		return false, fmt.Errorf("連結共用檔案失敗: %w", err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		_ = os.Remove(tmp)
+		return false, fmt.Errorf("取代為共用檔案失敗: %w", err)
+	}
+	return true, nil
+}
+
+// sameFile 回報兩個路徑是否為同一個檔案（硬連結）
+func sameFile(a, b string) (bool, error) {
+	fa, err := os.Stat(a)
+	if err != nil {
+		return false, err
+	}
+	fb, err := os.Stat(b)
+	if err != nil {
+		return false, err
+	}
+	return os.SameFile(fa, fb), nil
+}
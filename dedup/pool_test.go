@@ -0,0 +1,118 @@
+package dedup
+
+import (
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+)
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func mustSameFile(t *testing.T, a, b string) bool {
+	t.Helper()
+	same, err := sameFile(a, b)
+	if err != nil {
+		t.Fatalf("sameFile(%s, %s) error = %v", a, b, err)
+	}
+	return same
+}
+
+func TestPool_Store(t *testing.T) {
+	dir := t.TempDir()
+	pool, err := OpenPool(filepath.Join(dir, "pool"))
+	if err != nil {
+		t.Fatalf("OpenPool() error = %v", err)
+	}
+
+	first := filepath.Join(dir, "article1", "a.jpg")
+	second := filepath.Join(dir, "article2", "b.jpg")
+	other := filepath.Join(dir, "article3", "c.jpg")
+	writeFile(t, first, "same-bytes")
+	writeFile(t, second, "same-bytes")
+	writeFile(t, other, "other-bytes")
+
+	if shared, err := pool.Store(first); err != nil || shared {
+		t.Fatalf("首次加入 Store() = %v, %v; want false, nil", shared, err)
+	}
+	if shared, err := pool.Store(second); err != nil || !shared {
+		t.Fatalf("相同內容 Store() = %v, %v; want true, nil", shared, err)
+	}
+	if shared, err := pool.Store(other); err != nil || shared {
+		t.Fatalf("不同內容 Store() = %v, %v; want false, nil", shared, err)
+	}
+
+	if !mustSameFile(t, first, second) {
+		t.Error("相同內容的圖片應為同一個檔案的硬連結")
+	}
+	if mustSameFile(t, first, other) {
+		t.Error("不同內容的圖片不應共用檔案")
+	}
+	// 文章目錄中的檔名與內容維持不變，Markdown 連結不受影響
+	if data, _ := os.ReadFile(second); string(data) != "same-bytes" {
+		t.Errorf("取代後內容 = %q", data)
+	}
+	if shared, err := pool.Store(second); err != nil || !shared {
+		t.Errorf("重複加入已共用的檔案 Store() = %v, %v; want true, nil", shared, err)
+	}
+}
+
+func TestPool_ReusesPreviousRun(t *testing.T) {
+	dir := t.TempDir()
+	poolDir := filepath.Join(dir, "pool")
+	first := filepath.Join(dir, "run1", "a.jpg")
+	second := filepath.Join(dir, "run2", "a.jpg")
+	writeFile(t, first, "same-bytes")
+	writeFile(t, second, "same-bytes")
+
+	pool, _ := OpenPool(poolDir)
+	if _, err := pool.Store(first); err != nil {
+		t.Fatal(err)
+	}
+
+	reopened, _ := OpenPool(poolDir)
+	if shared, err := reopened.Store(second); err != nil || !shared {
+		t.Fatalf("沿用先前執行的共用檔案 Store() = %v, %v; want true, nil", shared, err)
+	}
+	if !mustSameFile(t, first, second) {
+		t.Error("跨執行的相同內容應共用同一個檔案")
+	}
+}
+
+func TestPool_ConcurrentStore(t *testing.T) {
+	dir := t.TempDir()
+	pool, _ := OpenPool(filepath.Join(dir, "pool"))
+
+	const n = 8
+	paths := make([]string, n)
+	for i := range paths {
+		paths[i] = filepath.Join(dir, "article", string(rune('a'+i))+".jpg")
+		writeFile(t, paths[i], "same-bytes")
+	}
+
+	var wg sync.WaitGroup
+	for _, p := range paths {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := pool.Store(p); err != nil {
+				t.Errorf("Store(%s) error = %v", p, err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	for _, p := range paths[1:] {
+		if !mustSameFile(t, paths[0], p) {
+			t.Errorf("並行加入後 %s 應與其他相同內容共用檔案", p)
+		}
+	}
+}
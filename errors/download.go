@@ -0,0 +1,114 @@
+package errors
+
+import (
+	stderrors "errors"
+	"net/http"
+)
+
+// FailureReason 圖片下載失敗的原因分類，記錄於 CrawlerError.Context[ContextKeyFailureReason]，
+// 用來區分圖片被刪除、被限流還是網路問題
+type FailureReason string
+
+// 圖片下載失敗的原因分類
+const (
+	FailureNotFound     FailureReason = "not_found"     // 404/410：圖片已被刪除
+	FailureForbidden    FailureReason = "forbidden"     // 401/403：防盜連或需要登入
+	FailureRateLimited  FailureReason = "rate_limited"  // 429：被限流（含重試用盡）
+	FailureServerError  FailureReason = "server_error"  // 5xx：圖床異常
+	FailureHTTPStatus   FailureReason = "http_status"   // 其他非 200 狀態碼
+	FailureTimeout      FailureReason = "timeout"       // 連線或讀取逾時
+	FailureNetwork      FailureReason = "network"       // DNS、TLS、連線被拒或重置等其他網路問題
+	FailureInvalidImage FailureReason = "invalid_image" // 回應內容不是圖片
+	FailureOversize     FailureReason = "oversize"      // 超過大小上限
+	FailureWrite        FailureReason = "write"         // 建立或寫入檔案失敗
+)
+
+// 下載失敗在 CrawlerError.Context 中的鍵
+const (
+	ContextKeyFailureReason = "failureReason" // 失敗原因分類（FailureReason）
+	ContextKeyStatus        = "status"        // HTTP 狀態碼（int），未取得回應時不記錄
+)
+
+// failureLabels 是各失敗原因的中文說明，供日誌摘要與報告使用
+var failureLabels = map[FailureReason]string{
+	FailureNotFound:     "圖片已刪除",
+	FailureForbidden:    "拒絕存取",
+	FailureRateLimited:  "被限流",
+	FailureServerError:  "伺服器錯誤",
+	FailureHTTPStatus:   "其他狀態碼",
+	FailureTimeout:      "逾時",
+	FailureNetwork:      "網路錯誤",
+	FailureInvalidImage: "非圖片內容",
+	FailureOversize:     "超過大小上限",
+	FailureWrite:        "寫入失敗",
+}
+
+// Label 回傳失敗原因的中文說明，未知的分類回傳原始字串
+func (r FailureReason) Label() string {
+	if label, ok := failureLabels[r]; ok {
+		return label
+	}
+	return string(r)
+}
+
+// ClassifyDownloadFailure 依回應狀態碼或請求錯誤判斷下載失敗的原因。
+// err 不為 nil 時依網路錯誤細分類（NetKind）區分逾時與其他網路問題，否則依 status 分類。
+func ClassifyDownloadFailure(status int, err error) FailureReason {
+	if err != nil {
+		if NetKind(err) == NetKindTimeout {
+			return FailureTimeout
+		}
+		return FailureNetwork
+	}
+	switch {
+	case status == http.StatusNotFound || status == http.StatusGone:
+		return FailureNotFound
+	case status == http.StatusUnauthorized || status == http.StatusForbidden:
+		return FailureForbidden
+	case status == http.StatusTooManyRequests:
+		return FailureRateLimited
+	case status >= http.StatusInternalServerError:
+		return FailureServerError
+	default:
+		return FailureHTTPStatus
+	}
+}
+
+// NewDownloadError 建立圖片下載失敗的錯誤，並將原因分類記錄於 Context。
+// 錯誤類型依原因決定：寫檔失敗為 FileError，內容檢查失敗為 ValidationError，其餘為 NetworkError。
+func NewDownloadError(reason FailureReason, message string, cause error) *CrawlerError {
+	errorType := NetworkError
+	switch reason {
+	case FailureWrite:
+		errorType = FileError
+	case FailureInvalidImage, FailureOversize:
+		errorType = ValidationError
+	}
+	return NewCrawlerErrorWithCause(errorType, message, cause).WithContext(ContextKeyFailureReason, reason)
+}
+
+// DownloadFailureReason 取出錯誤鏈中 CrawlerError 記錄的下載失敗原因，沒有時依底層錯誤分類
+func DownloadFailureReason(err error) FailureReason {
+	var crawlerErr *CrawlerError
+	if stderrors.As(err, &crawlerErr) {
+		if reason, ok := crawlerErr.GetContext(ContextKeyFailureReason); ok {
+			if r, ok := reason.(FailureReason); ok {
+				return r
+			}
+		}
+	}
+	return ClassifyDownloadFailure(0, err)
+}
+
+// DownloadStatus 取出錯誤鏈中 CrawlerError 記錄的 HTTP 狀態碼，沒有時回傳 0
+func DownloadStatus(err error) int {
+	var crawlerErr *CrawlerError
+	if stderrors.As(err, &crawlerErr) {
+		if status, ok := crawlerErr.GetContext(ContextKeyStatus); ok {
+			if s, ok := status.(int); ok {
+				return s
+			}
+		}
+	}
+	return 0
+}
@@ -0,0 +1,90 @@
+package errors
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"syscall"
+	"testing"
+)
+
+func TestClassifyDownloadFailure(t *testing.T) {
+	tests := []struct {
+		name   string
+		status int
+		err    error
+		want   FailureReason
+	}{
+		{"404 圖片已刪除", http.StatusNotFound, nil, FailureNotFound},
+		{"410 圖片已刪除", http.StatusGone, nil, FailureNotFound},
+		{"403 防盜連", http.StatusForbidden, nil, FailureForbidden},
+		{"401 需要登入", http.StatusUnauthorized, nil, FailureForbidden},
+		{"429 被限流", http.StatusTooManyRequests, nil, FailureRateLimited},
+		{"503 伺服器錯誤", http.StatusServiceUnavailable, nil, FailureServerError},
+		{"其他狀態碼", http.StatusBadRequest, nil, FailureHTTPStatus},
+		{"逾時", 0, urlErr(timeoutError{}), FailureTimeout},
+		{"context 逾時", 0, context.DeadlineExceeded, FailureTimeout},
+		{"連線被拒", 0, urlErr(dialErr(syscall.ECONNREFUSED)), FailureNetwork},
+		{"已分類的網路錯誤沿用細分類", 0, NewClassifiedNetworkError("請求失敗", urlErr(timeoutError{})), FailureTimeout},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ClassifyDownloadFailure(tt.status, tt.err); got != tt.want {
+				t.Errorf("ClassifyDownloadFailure(%d, %v) = %q, want %q", tt.status, tt.err, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewDownloadError(t *testing.T) {
+	tests := []struct {
+		reason FailureReason
+		check  func(error) bool
+	}{
+		{FailureWrite, IsFileError},
+		{FailureInvalidImage, IsValidationError},
+		{FailureOversize, IsValidationError},
+		{FailureNotFound, IsNetworkError},
+		{FailureTimeout, IsNetworkError},
+	}
+	for _, tt := range tests {
+		err := NewDownloadError(tt.reason, "下載失敗", nil)
+		if !tt.check(err) {
+			t.Errorf("NewDownloadError(%q) 的錯誤類型 = %v", tt.reason, err.Type)
+		}
+		// 經過包裝後仍可取出原因
+		if got := DownloadFailureReason(fmt.Errorf("工人 #1: %w", err)); got != tt.reason {
+			t.Errorf("DownloadFailureReason = %q, want %q", got, tt.reason)
+		}
+	}
+}
+
+func TestDownloadFailureReason_Fallback(t *testing.T) {
+	// 沒有記錄原因的網路錯誤依細分類判斷
+	if got := DownloadFailureReason(NewClassifiedNetworkError("請求失敗", urlErr(timeoutError{}))); got != FailureTimeout {
+		t.Errorf("DownloadFailureReason = %q, want %q", got, FailureTimeout)
+	}
+	if got := DownloadFailureReason(errors.New("connection reset by peer")); got != FailureNetwork {
+		t.Errorf("DownloadFailureReason = %q, want %q", got, FailureNetwork)
+	}
+}
+
+func TestDownloadStatus(t *testing.T) {
+	err := NewDownloadError(FailureNotFound, "狀態碼 404", nil).WithContext(ContextKeyStatus, http.StatusNotFound)
+	if got := DownloadStatus(err); got != http.StatusNotFound {
+		t.Errorf("DownloadStatus = %d, want 404", got)
+	}
+	if got := DownloadStatus(NewDownloadError(FailureTimeout, "下載失敗", context.DeadlineExceeded)); got != 0 {
+		t.Errorf("未取得回應時 DownloadStatus = %d, want 0", got)
+	}
+}
+
+func TestFailureReason_Label(t *testing.T) {
+	if got := FailureNotFound.Label(); got != "圖片已刪除" {
+		t.Errorf("Label = %q", got)
+	}
+	if got := FailureReason("unknown").Label(); got != "unknown" {
+		t.Errorf("未知原因的 Label = %q, want 原始字串", got)
+	}
+}
@@ -0,0 +1,98 @@
+package errors
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	stderrors "errors"
+	"io"
+	"net"
+	"syscall"
+)
+
+// NetErrorKind 網路錯誤的細分類，記錄於 CrawlerError.Context[ContextKeyNetKind]
+type NetErrorKind string
+
+// 網路錯誤的細分類
+const (
+	NetKindDNS      NetErrorKind = "dns"      // 網域名稱解析失敗
+	NetKindTimeout  NetErrorKind = "timeout"  // 連線或讀取逾時
+	NetKindTLS      NetErrorKind = "tls"      // TLS 握手或憑證驗證失敗
+	NetKindRefused  NetErrorKind = "refused"  // 連線被拒絕（對方未監聽該埠）
+	NetKindReset    NetErrorKind = "reset"    // 連線被對方重置或中途斷線
+	NetKindCanceled NetErrorKind = "canceled" // 請求被 context 取消
+	NetKindOther    NetErrorKind = "other"    // 其他無法細分的錯誤
+)
+
+// ContextKeyNetKind 網路錯誤細分類在 CrawlerError.Context 中的鍵
+const ContextKeyNetKind = "netKind"
+
+// ClassifyNetError 以 errors.As/errors.Is 檢查底層錯誤型別，判斷網路錯誤的細分類。
+// 檢查順序由具體到籠統：取消 → DNS → TLS → 逾時 → 拒絕/重置 → 其他。
+func ClassifyNetError(err error) NetErrorKind {
+	if stderrors.Is(err, context.Canceled) {
+		return NetKindCanceled
+	}
+
+	var dnsErr *net.DNSError
+	if stderrors.As(err, &dnsErr) {
+		if dnsErr.IsTimeout {
+			return NetKindTimeout
+		}
+		return NetKindDNS
+	}
+
+	var (
+		recordErr   tls.RecordHeaderError
+		certErr     *tls.CertificateVerificationError
+		alertErr    tls.AlertError
+		unknownAuth x509.UnknownAuthorityError
+		hostnameErr x509.HostnameError
+		certInvalid x509.CertificateInvalidError
+	)
+	if stderrors.As(err, &recordErr) || stderrors.As(err, &certErr) || stderrors.As(err, &alertErr) ||
+		stderrors.As(err, &unknownAuth) || stderrors.As(err, &hostnameErr) || stderrors.As(err, &certInvalid) {
+		return NetKindTLS
+	}
+
+	var netErr net.Error
+	if stderrors.Is(err, context.DeadlineExceeded) || (stderrors.As(err, &netErr) && netErr.Timeout()) {
+		return NetKindTimeout
+	}
+
+	if stderrors.Is(err, syscall.ECONNREFUSED) {
+		return NetKindRefused
+	}
+	var opErr *net.OpError
+	// 伺服器在回應前關閉連線時，http.Client 回傳的是 io.EOF / io.ErrUnexpectedEOF
+	if stderrors.Is(err, syscall.ECONNRESET) || stderrors.Is(err, syscall.EPIPE) ||
+		stderrors.Is(err, io.EOF) || stderrors.Is(err, io.ErrUnexpectedEOF) ||
+		(stderrors.As(err, &opErr) && opErr.Op == "read") {
+		return NetKindReset
+	}
+	return NetKindOther
+}
+
+// Retryable 回報此類網路錯誤是否值得重試：逾時與連線重置多為暫時性問題；
+// DNS、TLS、連線被拒通常短時間內不會恢復，重試只會拖慢整體進度
+func (k NetErrorKind) Retryable() bool {
+	return k == NetKindTimeout || k == NetKindReset
+}
+
+// NewClassifiedNetworkError 建立網路錯誤，並將底層錯誤的細分類記錄於 Context
+func NewClassifiedNetworkError(message string, cause error) *CrawlerError {
+	return NewNetworkError(message, cause).WithContext(ContextKeyNetKind, ClassifyNetError(cause))
+}
+
+// NetKind 取出錯誤鏈中 CrawlerError 記錄的網路錯誤細分類，沒有時直接分類底層錯誤
+func NetKind(err error) NetErrorKind {
+	var crawlerErr *CrawlerError
+	if stderrors.As(err, &crawlerErr) {
+		if kind, ok := crawlerErr.GetContext(ContextKeyNetKind); ok {
+			if k, ok := kind.(NetErrorKind); ok {
+				return k
+			}
+		}
+	}
+	return ClassifyNetError(err)
+}
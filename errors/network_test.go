@@ -0,0 +1,93 @@
+package errors
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/url"
+	"os"
+	"syscall"
+	"testing"
+)
+
+// timeoutError 模擬 net.Error 的逾時錯誤（如 http.Client.Timeout 觸發時）
+type timeoutError struct{}
+
+func (timeoutError) Error() string   { return "i/o timeout" }
+func (timeoutError) Timeout() bool   { return true }
+func (timeoutError) Temporary() bool { return true }
+
+// urlErr 模擬 http.Client.Do 回傳的 *url.Error 包裝
+func urlErr(err error) error {
+	return &url.Error{Op: "Get", URL: "https://i.imgur.com/a.jpg", Err: err}
+}
+
+func dialErr(err error) error {
+	return &net.OpError{Op: "dial", Net: "tcp", Err: err}
+}
+
+func TestClassifyNetError(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want NetErrorKind
+	}{
+		{"DNS 找不到主機", urlErr(dialErr(&net.DNSError{Err: "no such host", Name: "x.invalid", IsNotFound: true})), NetKindDNS},
+		{"DNS 查詢逾時", urlErr(dialErr(&net.DNSError{Err: "timeout", Name: "x", IsTimeout: true})), NetKindTimeout},
+		{"連線逾時", urlErr(dialErr(timeoutError{})), NetKindTimeout},
+		{"context 逾時", urlErr(context.DeadlineExceeded), NetKindTimeout},
+		{"連線被拒", urlErr(dialErr(os.NewSyscallError("connect", syscall.ECONNREFUSED))), NetKindRefused},
+		{"連線被重置", urlErr(&net.OpError{Op: "read", Net: "tcp", Err: os.NewSyscallError("read", syscall.ECONNRESET)}), NetKindReset},
+		{"伺服器提前關閉連線", urlErr(io.EOF), NetKindReset},
+		{"憑證不受信任", urlErr(&tls.CertificateVerificationError{Err: x509.UnknownAuthorityError{}}), NetKindTLS},
+		{"憑證主機名稱不符", urlErr(x509.HostnameError{Host: "example.com", Certificate: &x509.Certificate{}}), NetKindTLS},
+		{"非 TLS 伺服器", urlErr(tls.RecordHeaderError{Msg: "first record does not look like a TLS handshake"}), NetKindTLS},
+		{"被取消", urlErr(context.Canceled), NetKindCanceled},
+		{"其他錯誤", errors.New("connection refused"), NetKindOther},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ClassifyNetError(tt.err); got != tt.want {
+				t.Errorf("ClassifyNetError(%v) = %q, want %q", tt.err, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNetErrorKind_Retryable(t *testing.T) {
+	retryable := map[NetErrorKind]bool{
+		NetKindDNS:      false,
+		NetKindTimeout:  true,
+		NetKindTLS:      false,
+		NetKindRefused:  false,
+		NetKindReset:    true,
+		NetKindCanceled: false,
+		NetKindOther:    false,
+	}
+	for kind, want := range retryable {
+		if got := kind.Retryable(); got != want {
+			t.Errorf("%s.Retryable() = %v, want %v", kind, got, want)
+		}
+	}
+}
+
+func TestNewClassifiedNetworkError(t *testing.T) {
+	cause := urlErr(dialErr(&net.DNSError{Err: "no such host", Name: "x.invalid", IsNotFound: true}))
+	err := fmt.Errorf("下載失敗: %w", NewClassifiedNetworkError("請求失敗", cause))
+
+	if !IsNetworkError(err) {
+		t.Error("應為 NetworkError")
+	}
+	if got := NetKind(err); got != NetKindDNS {
+		t.Errorf("NetKind() = %q, want %q", got, NetKindDNS)
+	}
+	var dnsErr *net.DNSError
+	if !errors.As(err, &dnsErr) {
+		t.Error("包裝後仍應能以 errors.As 取得底層 *net.DNSError")
+	}
+}
@@ -2,8 +2,10 @@
 package interfaces
 
 import (
+	"context"
 	"io"
 	"net/http"
+	"time"
 
 	"github.com/twtrubiks/ptt-spider-go/types"
 )
@@ -17,14 +19,70 @@ type HTTPClient interface {
 type Parser interface {
 	// ParseArticles 解析文章列表頁面
 	ParseArticles(body io.Reader) ([]types.ArticleInfo, error)
-	// ParseArticleContent 解析文章內容頁面，返回標題和圖片 URLs
-	ParseArticleContent(body io.Reader) (title string, imageURLs []string, err error)
+	// ParseArticleContent 解析文章內容頁面，返回標題、作者和圖片 URLs
+	ParseArticleContent(body io.Reader) (types.ArticleContent, error)
+	// ParseArticlePushes 解析文章內容頁的推文（類型、推文者、內容與時間）
+	ParseArticlePushes(body io.Reader) ([]types.Push, error)
 	// ParseMaxPage 從看板首頁 HTML 解析最大頁數
 	ParseMaxPage(body io.Reader) (int, error)
 }
 
+// RateLimiter 定義請求速率限制器介面，限制所有 worker 合計的對外請求速率
+type RateLimiter interface {
+	// Allow 在目前有可用額度時消耗一個額度並回傳 true，不會阻塞
+	Allow() bool
+	// Wait 阻塞直到取得一個額度，context 取消時回傳其錯誤
+	Wait(ctx context.Context) error
+}
+
 // MarkdownGenerator 定義 Markdown 生成器介面
 type MarkdownGenerator interface {
-	// Generate 根據提供的資訊生成 Markdown 檔案
+	// Generate 根據提供的資訊在 info.SaveDir 生成文章輸出檔（預設為 README.md）
 	Generate(info types.MarkdownInfo) error
+	// GenerateIndex 將 articles 合併進看板目錄 dir 既有的總覽（標題、推文數、文章目錄連結與縮圖）並重新產生
+	GenerateIndex(dir string, articles []types.MarkdownInfo) error
+}
+
+// MetricsCollector 定義執行期指標收集器介面，實作需可供多個 worker 並行呼叫
+type MetricsCollector interface {
+	// RecordRequest 記錄一次對外請求的狀態碼與耗時，err 不為 nil 時 statusCode 為 0
+	RecordRequest(statusCode int, duration time.Duration, err error)
+	// RecordError 記錄一次指定類型的錯誤（如 parse、write）
+	RecordError(kind string)
+	// RecordImageDownloaded 記錄一張成功下載的圖片與寫入的位元組數
+	RecordImageDownloaded(bytes int64)
+	// RecordImagePrechecked 記錄一張經 HEAD 預檢取得大小、將排入下載的圖片與其預計位元組數
+	RecordImagePrechecked(bytes int64)
+	// RecordDownloadFailure 記錄一張下載失敗的圖片與失敗原因分類（如 not_found、rate_limited、timeout）
+	RecordDownloadFailure(reason string)
+	// RecordArticleParsed 記錄一篇成功解析的文章
+	RecordArticleParsed()
+	// Snapshot 回傳目前的指標快照
+	Snapshot() types.MetricsSnapshot
+}
+
+// CacheManager 定義跨執行的文章爬取時間快取介面，實作需可供多個 goroutine 並行呼叫
+type CacheManager interface {
+	// Get 回傳文章最後一次爬取的時間，未記錄時回傳 false
+	Get(articleURL string) (time.Time, bool)
+	// Set 記錄文章在 crawledAt 爬取完成
+	Set(articleURL string, crawledAt time.Time)
+	// Delete 移除文章的紀錄
+	Delete(articleURL string)
+	// Clear 移除所有紀錄
+	Clear()
+	// Save 將紀錄寫回持久化儲存
+	Save() error
+}
+
+// WorkerPool 定義常駐工人池介面：固定數量的工人從佇列領取任務處理
+type WorkerPool[T any] interface {
+	// Start 以 ctx 啟動工人，ctx 取消時工人在領取下一個任務前結束
+	Start(ctx context.Context)
+	// Submit 將任務排入佇列，佇列已滿時阻塞；ctx 取消時回傳其錯誤，Stop 之後回傳錯誤而非 panic
+	Submit(ctx context.Context, task T) error
+	// Stop 不再接受新任務，工人處理完佇列中剩餘的任務後結束
+	Stop()
+	// Wait 阻塞直到所有工人結束
+	Wait()
 }
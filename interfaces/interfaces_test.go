@@ -14,6 +14,9 @@ func TestInterfacesExist(t *testing.T) {
 		_ HTTPClient        = nil
 		_ Parser            = nil
 		_ MarkdownGenerator = nil
+		_ RateLimiter       = nil
+		_ MetricsCollector  = nil
+		_ WorkerPool[int]   = nil
 	)
 
 	// 如果編譯通過，則測試通過
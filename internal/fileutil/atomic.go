@@ -0,0 +1,39 @@
+package fileutil
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/twtrubiks/ptt-spider-go/constants"
+	"github.com/twtrubiks/ptt-spider-go/internal/ioutil"
+)
+
+// WriteFileAtomic 先寫入同目錄的暫存檔再 rename 覆蓋目標檔，
+// 寫到一半中斷時不會留下毀損的目標檔。
+func WriteFileAtomic(path string, data []byte) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".tmp-*")
+	if err != nil {
+		return fmt.Errorf("建立暫存檔失敗: %w", err)
+	}
+	tmpPath := tmp.Name()
+
+	if _, err := tmp.Write(data); err != nil {
+		ioutil.CloseWithLog(tmp, "暫存檔")
+		_ = os.Remove(tmpPath)
+		return fmt.Errorf("寫入暫存檔失敗: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		_ = os.Remove(tmpPath)
+		return fmt.Errorf("關閉暫存檔失敗: %w", err)
+	}
+	if err := os.Chmod(tmpPath, constants.FilePermission); err != nil {
+		_ = os.Remove(tmpPath)
+		return fmt.Errorf("設定檔案權限失敗: %w", err)
+	}
+	if err := os.Rename(tmpPath, path); err != nil {
+		_ = os.Remove(tmpPath)
+		return fmt.Errorf("覆蓋原檔失敗: %w", err)
+	}
+	return nil
+}
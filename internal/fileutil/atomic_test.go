@@ -0,0 +1,35 @@
+package fileutil
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestWriteFileAtomic(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "data.json")
+
+	if err := os.WriteFile(path, []byte("old"), 0600); err != nil {
+		t.Fatalf("建立原檔失敗: %v", err)
+	}
+	if err := WriteFileAtomic(path, []byte("new")); err != nil {
+		t.Fatalf("WriteFileAtomic() error = %v", err)
+	}
+
+	got, err := os.ReadFile(path)
+	if err != nil || string(got) != "new" {
+		t.Errorf("檔案內容 = %q (err %v), want %q", got, err, "new")
+	}
+	entries, _ := os.ReadDir(dir)
+	if len(entries) != 1 {
+		t.Errorf("不應留下暫存檔，目錄內有 %d 個檔案", len(entries))
+	}
+}
+
+func TestWriteFileAtomic_MissingDir(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "missing", "data.json")
+	if err := WriteFileAtomic(path, []byte("x")); err == nil {
+		t.Error("目錄不存在時應回傳錯誤")
+	}
+}
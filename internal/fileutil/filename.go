@@ -1,6 +1,6 @@
-// Package fileutil 提供從圖片 URL 推導本地檔名的共用邏輯，
-// 供 crawler（下載存檔）與 markdown（產生圖片連結）使用，
-// 確保下載的檔名與 README 中的連結一致。
+// Package fileutil 提供檔案相關的共用工具：從圖片 URL 推導本地檔名
+// （供 crawler 下載存檔與 markdown 產生圖片連結使用，確保兩者檔名一致），
+// 以及原子寫檔。
 package fileutil
 
 import (
@@ -10,23 +10,60 @@ import (
 	"strings"
 )
 
+// fallbackImageName 是 URL path 取不出檔名（如 "https://example.com/"）時使用的檔名
+const fallbackImageName = "image.jpg"
+
 // ImageFileName 從圖片 URL 推導本地儲存檔名。
-// 以 URL path 的最後一段為檔名（忽略 query string 與 fragment），
-// imgur 無副檔名的連結會補上 .jpg。
+// 以 URL path 的最後一段為檔名（忽略 query string 與 fragment）；
+// imgur 連結改以圖片 ID 為檔名（見 imgurFileName），無副檔名時補上 .jpg。
 func ImageFileName(imgURL string) string {
-	name := path.Base(imgURL)
-	if u, err := url.Parse(imgURL); err == nil {
-		name = path.Base(u.Path)
+	u, err := url.Parse(imgURL)
+	if err != nil {
+		return baseName(imgURL)
 	}
-	if strings.Contains(imgURL, "imgur.com") && !strings.Contains(name, ".") {
-		name += ".jpg"
+	if name, ok := imgurFileName(u); ok {
+		return name
+	}
+	return baseName(u.Path)
+}
+
+// baseName 回傳 p 的最後一段，取不出檔名時回傳 fallbackImageName
+func baseName(p string) string {
+	name := path.Base(p)
+	if name == "." || name == "/" {
+		return fallbackImageName
 	}
 	return name
 }
 
+// imgurFileName 以 imgur 連結的圖片 ID 推導檔名，同一張圖片的各種連結形式得到相同檔名：
+// 直連（i.imgur.com/ID.png）、無副檔名（imgur.com/ID）、結尾斜線（imgur.com/ID/），
+// 以及 gallery／相簿連結（/gallery/ID、新版 /gallery/標題-ID，取最後一個 "-" 之後的部分）。
+// 不是 imgur 連結或取不出 ID 時回傳 false。
+func imgurFileName(u *url.URL) (string, bool) {
+	if host := u.Hostname(); host != "imgur.com" && !strings.HasSuffix(host, ".imgur.com") {
+		return "", false
+	}
+	last := path.Base(strings.TrimRight(u.Path, "/"))
+	ext := path.Ext(last)
+	id := strings.TrimSuffix(last, ext)
+	// imgur 的 ID 只含英數字，"-" 之前是新版網址的標題
+	if i := strings.LastIndex(id, "-"); i >= 0 {
+		id = id[i+1:]
+	}
+	if id == "" || id == "." || id == "/" {
+		return "", false
+	}
+	if ext == "" {
+		ext = ".jpg"
+	}
+	return id + ext, true
+}
+
 // ImageFileNames 將圖片 URL 列表轉換為本地檔名列表，與輸入一一對應。
 // 不同 URL 推導出相同檔名時，後者在副檔名前加上 _2、_3… 序號後綴，
-// 避免同一目錄下互相覆蓋。給定相同輸入時輸出為確定性結果，
+// 避免同一目錄下互相覆蓋。比對不分大小寫：imgur 的 ID 區分大小寫（a.jpg 與 A.jpg 是不同圖片），
+// 但 Windows 與 macOS 預設的檔案系統不區分，視為同名才不會互相覆蓋。給定相同輸入時輸出為確定性結果，
 // crawler 與 markdown 以同一列表呼叫即可得到一致的檔名。
 func ImageFileNames(imgURLs []string) []string {
 	names := make([]string, 0, len(imgURLs))
@@ -37,12 +74,12 @@ func ImageFileNames(imgURLs []string) []string {
 		stem := strings.TrimSuffix(base, ext)
 		name := base
 		for i := 2; ; i++ {
-			if _, ok := taken[name]; !ok {
+			if _, ok := taken[strings.ToLower(name)]; !ok {
 				break
 			}
 			name = fmt.Sprintf("%s_%d%s", stem, i, ext)
 		}
-		taken[name] = struct{}{}
+		taken[strings.ToLower(name)] = struct{}{}
 		names = append(names, name)
 	}
 	return names
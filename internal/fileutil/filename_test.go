@@ -41,6 +41,46 @@ func TestImageFileName(t *testing.T) {
 			imgURL: "https://imgur.com/abc?x=1",
 			want:   "abc.jpg",
 		},
+		{
+			name:   "imgur 結尾斜線",
+			imgURL: "https://imgur.com/AbC12/",
+			want:   "AbC12.jpg",
+		},
+		{
+			name:   "imgur gallery 連結",
+			imgURL: "https://imgur.com/gallery/AbC12",
+			want:   "AbC12.jpg",
+		},
+		{
+			name:   "imgur 新版 gallery 連結取標題後的 ID",
+			imgURL: "https://imgur.com/gallery/cute-cats-AbC12",
+			want:   "AbC12.jpg",
+		},
+		{
+			name:   "gallery 猜測的單張圖片與直連同名",
+			imgURL: "https://imgur.com/gallery/cute-cats-AbC12.jpg",
+			want:   "AbC12.jpg",
+		},
+		{
+			name:   "imgur 直連保留副檔名",
+			imgURL: "https://i.imgur.com/AbC12.png",
+			want:   "AbC12.png",
+		},
+		{
+			name:   "網址其他部分含 imgur.com 不視為 imgur",
+			imgURL: "https://example.com/photo?from=imgur.com",
+			want:   "photo",
+		},
+		{
+			name:   "path 為空時使用預設檔名",
+			imgURL: "https://example.com/",
+			want:   "image.jpg",
+		},
+		{
+			name:   "imgur 首頁取不出 ID",
+			imgURL: "https://imgur.com/",
+			want:   "image.jpg",
+		},
 	}
 
 	for _, tt := range tests {
@@ -81,6 +121,15 @@ func TestImageFileNames(t *testing.T) {
 			},
 			want: []string{"a_2.jpg", "a.jpg", "a_3.jpg"},
 		},
+		{
+			name: "僅大小寫不同視為碰撞",
+			urls: []string{
+				"https://i.imgur.com/AbC.jpg",
+				"https://i.imgur.com/abc.jpg",
+				"https://i.imgur.com/ABC.JPG",
+			},
+			want: []string{"AbC.jpg", "abc_2.jpg", "ABC_3.JPG"},
+		},
 		{
 			name: "空列表",
 			urls: nil,
@@ -0,0 +1,38 @@
+package fileutil
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/twtrubiks/ptt-spider-go/constants"
+	"github.com/twtrubiks/ptt-spider-go/internal/ioutil"
+)
+
+// LinkOrCopy 以硬連結共用已存在的檔案，跨檔案系統等無法連結的情況改為複製
+func LinkOrCopy(src, dst string) error {
+	if err := os.MkdirAll(filepath.Dir(dst), constants.DirPermission); err != nil {
+		return fmt.Errorf("建立目錄失敗: %w", err)
+	}
+	if err := os.Link(src, dst); err == nil {
+		return nil
+	}
+
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer ioutil.CloseWithLog(in, "來源檔案")
+
+	out, err := os.OpenFile(dst, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, constants.FilePermission)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		ioutil.CloseWithLog(out, "目標檔案")
+		_ = os.Remove(dst)
+		return err
+	}
+	return out.Close()
+}
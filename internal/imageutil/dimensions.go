@@ -0,0 +1,26 @@
+package imageutil
+
+import (
+	"fmt"
+	"image"
+	_ "image/gif" // 註冊 GIF 解碼器，JPEG 與 PNG 已由 strip.go 引入
+	"os"
+
+	"github.com/twtrubiks/ptt-spider-go/internal/ioutil"
+)
+
+// Dimensions 只解碼檔頭取得圖片的寬高，不讀取整張圖片。
+// 支援 JPEG、PNG 與 GIF；其他格式（如 WebP、AVIF）或損毀的檔案回傳錯誤。
+func Dimensions(path string) (width, height int, err error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return 0, 0, fmt.Errorf("開啟圖片失敗: %w", err)
+	}
+	defer ioutil.CloseWithLog(file, "圖片檔案")
+
+	cfg, _, err := image.DecodeConfig(file)
+	if err != nil {
+		return 0, 0, fmt.Errorf("解碼圖片尺寸失敗: %w", err)
+	}
+	return cfg.Width, cfg.Height, nil
+}
@@ -0,0 +1,40 @@
+package imageutil
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/gif"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestDimensions(t *testing.T) {
+	dir := t.TempDir()
+
+	var buf bytes.Buffer
+	img := image.NewPaletted(image.Rect(0, 0, 30, 20), color.Palette{color.Black, color.White})
+	if err := gif.Encode(&buf, img, nil); err != nil {
+		t.Fatalf("編碼 GIF 失敗: %v", err)
+	}
+	gifPath := filepath.Join(dir, "a.gif")
+	if err := os.WriteFile(gifPath, buf.Bytes(), 0644); err != nil {
+		t.Fatal(err)
+	}
+	width, height, err := Dimensions(gifPath)
+	if err != nil || width != 30 || height != 20 {
+		t.Errorf("Dimensions() = %d, %d, %v, want 30, 20, nil", width, height, err)
+	}
+
+	brokenPath := filepath.Join(dir, "broken.webp")
+	if err := os.WriteFile(brokenPath, []byte("RIFF....WEBP"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := Dimensions(brokenPath); err == nil {
+		t.Error("不支援的格式應回傳錯誤")
+	}
+	if _, _, err := Dimensions(filepath.Join(dir, "missing.png")); err == nil {
+		t.Error("檔案不存在應回傳錯誤")
+	}
+}
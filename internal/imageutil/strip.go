@@ -0,0 +1,53 @@
+// Package imageutil 提供下載後的圖片後處理工具，例如移除 EXIF 等 metadata。
+package imageutil
+
+import (
+	"bytes"
+	stderrors "errors"
+	"fmt"
+	"image"
+	"image/jpeg"
+	"image/png"
+	"os"
+
+	"github.com/twtrubiks/ptt-spider-go/internal/fileutil"
+)
+
+// ErrUnsupportedFormat 表示圖片格式不支援重新編碼（如 GIF、WebP），呼叫方應略過處理
+var ErrUnsupportedFormat = stderrors.New("不支援的圖片格式")
+
+// jpegQuality 是重新編碼 JPEG 時使用的品質，盡量降低重新壓縮造成的畫質損失
+const jpegQuality = 95
+
+// StripMetadata 將圖片解碼後重新編碼寫回原路徑，藉此移除 EXIF（可能含 GPS 座標）等 metadata。
+// 僅支援 JPEG 與 PNG；其他格式回傳 ErrUnsupportedFormat 且不修改檔案。
+// 寫入先落在同目錄暫存檔再 rename，避免中途失敗破壞原檔。
+func StripMetadata(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("讀取圖片失敗: %w", err)
+	}
+
+	_, format, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil || (format != "jpeg" && format != "png") {
+		return ErrUnsupportedFormat
+	}
+
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return fmt.Errorf("解碼圖片失敗: %w", err)
+	}
+
+	var buf bytes.Buffer
+	switch format {
+	case "jpeg":
+		err = jpeg.Encode(&buf, img, &jpeg.Options{Quality: jpegQuality})
+	case "png":
+		err = png.Encode(&buf, img)
+	}
+	if err != nil {
+		return fmt.Errorf("重新編碼圖片失敗: %w", err)
+	}
+
+	return fileutil.WriteFileAtomic(path, buf.Bytes())
+}
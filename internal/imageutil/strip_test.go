@@ -0,0 +1,89 @@
+package imageutil
+
+import (
+	"bytes"
+	stderrors "errors"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// jpegWithExif 產生一張 JPEG，並在 SOI 之後插入含 GPS 標記字串的 APP1 (Exif) 區段
+func jpegWithExif(t *testing.T) []byte {
+	t.Helper()
+	img := image.NewRGBA(image.Rect(0, 0, 4, 4))
+	img.Set(1, 1, color.RGBA{R: 255, A: 255})
+
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, img, nil); err != nil {
+		t.Fatalf("編碼 JPEG 失敗: %v", err)
+	}
+	encoded := buf.Bytes()
+
+	payload := []byte("Exif\x00\x00GPS-SECRET")
+	segLen := len(payload) + 2
+	app1 := append([]byte{0xFF, 0xE1, byte(segLen >> 8), byte(segLen)}, payload...)
+
+	out := append([]byte{}, encoded[:2]...) // SOI
+	out = append(out, app1...)
+	return append(out, encoded[2:]...)
+}
+
+func TestStripMetadata_JPEG(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "exif.jpg")
+	if err := os.WriteFile(path, jpegWithExif(t), 0644); err != nil {
+		t.Fatalf("寫入測試檔失敗: %v", err)
+	}
+
+	if err := StripMetadata(path); err != nil {
+		t.Fatalf("StripMetadata() error = %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("讀取結果失敗: %v", err)
+	}
+	if bytes.Contains(data, []byte("GPS-SECRET")) || bytes.Contains(data, []byte("Exif")) {
+		t.Error("重新編碼後不應保留 EXIF 區段")
+	}
+	if _, format, err := image.DecodeConfig(bytes.NewReader(data)); err != nil || format != "jpeg" {
+		t.Errorf("結果應仍為可解碼的 JPEG，format = %q, err = %v", format, err)
+	}
+}
+
+func TestStripMetadata_PNG(t *testing.T) {
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, image.NewGray(image.Rect(0, 0, 2, 2))); err != nil {
+		t.Fatalf("編碼 PNG 失敗: %v", err)
+	}
+	path := filepath.Join(t.TempDir(), "plain.png")
+	if err := os.WriteFile(path, buf.Bytes(), 0644); err != nil {
+		t.Fatalf("寫入測試檔失敗: %v", err)
+	}
+
+	if err := StripMetadata(path); err != nil {
+		t.Fatalf("StripMetadata() error = %v", err)
+	}
+}
+
+func TestStripMetadata_UnsupportedFormat(t *testing.T) {
+	original := []byte("GIF89a not really decodable")
+	path := filepath.Join(t.TempDir(), "anim.gif")
+	if err := os.WriteFile(path, original, 0644); err != nil {
+		t.Fatalf("寫入測試檔失敗: %v", err)
+	}
+
+	err := StripMetadata(path)
+	if !stderrors.Is(err, ErrUnsupportedFormat) {
+		t.Fatalf("StripMetadata() error = %v, want ErrUnsupportedFormat", err)
+	}
+
+	data, _ := os.ReadFile(path)
+	if !bytes.Equal(data, original) {
+		t.Error("不支援的格式不應修改原檔")
+	}
+}
@@ -3,12 +3,12 @@ package ioutil
 
 import (
 	"io"
-	"log"
+	"log/slog"
 )
 
 // CloseWithLog 關閉資源並記錄錯誤。
 func CloseWithLog(closer io.Closer, name string) {
 	if err := closer.Close(); err != nil {
-		log.Printf("關閉 %s 失敗: %v", name, err)
+		slog.Warn("關閉資源失敗", "name", name, "error", err)
 	}
 }
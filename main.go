@@ -3,32 +3,147 @@ package main
 import (
 	"context"
 	"flag"
+	"fmt"
+	"io"
+	"log/slog"
+	"net"
 	"os"
 	"os/signal"
+	"path/filepath"
+	"regexp"
+	"strings"
 	"syscall"
+	"time"
 
+	"github.com/twtrubiks/ptt-spider-go/cache"
+	"github.com/twtrubiks/ptt-spider-go/checkpoint"
 	"github.com/twtrubiks/ptt-spider-go/config"
 	"github.com/twtrubiks/ptt-spider-go/constants"
 	"github.com/twtrubiks/ptt-spider-go/crawler"
+	"github.com/twtrubiks/ptt-spider-go/dedup"
+	"github.com/twtrubiks/ptt-spider-go/internal/ioutil"
+	"github.com/twtrubiks/ptt-spider-go/metrics"
+	"github.com/twtrubiks/ptt-spider-go/performance"
 	"github.com/twtrubiks/ptt-spider-go/types"
 	"github.com/twtrubiks/ptt-spider-go/ui"
 )
 
 func main() {
+	os.Exit(run())
+}
+
+// run 解析命令列並執行爬蟲，回傳程式結束碼。
+// 結束碼交由 main 呼叫 os.Exit，run 返回前 defer 的日誌檔關閉、metrics 伺服器關閉等收尾都會執行。
+func run() int {
 	// 定義命令列參數
-	board := flag.String("board", constants.DefaultBoard, "看板名稱")
-	pages := flag.Int("pages", constants.DefaultPages, "要爬取的頁數")
+	board := new(string)
+	flag.Var(newBoardsValue(constants.DefaultBoard, board), "board", "`看板名稱`，多個看板以逗號分隔或重複指定（如 -board=Beauty,Gossiping）")
+	pages := flag.Int("pages", constants.DefaultPages, "每個看板要爬取的頁數")
 	pushRate := flag.Int("push", constants.DefaultPushRate, "推文數門檻")
-	fileURL := flag.String("file", "", "包含文章 URL 的文字檔路徑 (優先於看板模式)")
+	fileURL := flag.String("file", "", "包含文章 URL 的文字檔路徑 (優先於看板模式)，- 表示從標準輸入讀取")
+	retryFailures := flag.String("retry-failures", "", "重新下載先前 failures.report 匯出的失敗圖片（JSON 路徑），不重新爬取文章，仍失敗的寫回失敗明細")
 	configPath := flag.String("config", "config.yaml", "配置檔案路徑")
+	strictConfig := flag.Bool("strict-config", false, "配置檔含未知的鍵或非法值時直接結束，而不是記錄警告後退回預設值")
 	tuiMode := flag.Bool("tui", false, "啟動互動式 TUI 選單（含即時進度畫面）")
+	logLevel := flag.String("log-level", "", "日誌等級 debug/info/warn/error（未指定時使用配置檔 crawler.logLevel）")
+	logFormat := flag.String("log-format", "text", "日誌格式 text/json（json 每行輸出一個 NDJSON 物件）")
+	quiet := flag.Bool("quiet", false, "不輸出逐篇文章、逐張圖片的例行訊息（如「下載完成」），只顯示警告、錯誤與結束時的統計")
+	progress := flag.Bool("progress", false, "定期顯示已處理文章數、下載完成／失敗張數與佇列深度（終端機上以固定在底部的狀態列顯示）")
+	progressInterval := flag.Duration("progress-interval", 0, "-progress 的更新間隔（如 5s，未指定時狀態列每秒、日誌每 10 秒）")
+	dedupStats := flag.Bool("dedup-stats", false, "顯示跨執行下載索引的統計後結束")
+	dedupClear := flag.Bool("dedup-clear", false, "清除跨執行下載索引後結束")
+	titleFilter := flag.String("title-filter", "", "只爬取標題符合此正規表示式的文章（如 '^\\[正妹\\]'）")
+	titleContains := flag.String("title-contains", "", "只爬取標題包含此字串的文章（不分大小寫）")
+	author := flag.String("author", "", "只爬取指定作者的文章，多位作者以逗號分隔（帳號不分大小寫）")
+	since := flag.String("since", "", "只爬取此日期（含）之後發文的文章，格式 MM/DD 或 YYYY-MM-DD（僅看板模式）")
+	until := flag.String("until", "", "只爬取此日期（含）之前發文的文章，格式 MM/DD 或 YYYY-MM-DD（僅看板模式）")
+	directionFlag := flag.String("direction", "newest", "看板列表的爬取方向 newest（新到舊）/oldest（舊到新）")
+	fromPage := flag.Int("from-page", 0, "看板模式的起始頁碼，0 表示依方向從最新頁或第 1 頁開始")
+	pageStart := flag.Int("page-start", 0, "看板模式爬取範圍的起始頁碼（含），需與 -page-end 同時指定，取代 -pages")
+	pageEnd := flag.Int("page-end", 0, "看板模式爬取範圍的結束頁碼（含），需與 -page-start 同時指定，取代 -pages")
+	mode := flag.String("mode", "", "套用爬取策略 aggressive/normal/gentle 或配置檔 crawler.profiles 自訂的策略（未指定時使用配置檔原設定）")
+	resume := flag.Bool("resume", false, "從 checkpoint 繼續先前中斷的爬取，略過已完成的文章")
+	useCache := flag.Bool("cache", false, "略過 crawler.cache.ttl 內已爬取過的文章，並記錄本次完成的文章（快取檔為 crawler.cache.path）")
+	checkpointPath := flag.String("checkpoint", "", "checkpoint 檔路徑，指定後記錄已完成的文章供 -resume 使用（預設 "+constants.DefaultCheckpointPath+"）")
+	metricsAddr := flag.String("metrics-addr", "", "Prometheus 指標端點的監聽位址，例如 :9090（未指定時不開啟）")
+	outputDir := flag.String("output", "", "輸出根目錄，看板目錄建立在其下（未指定時為目前目錄）")
+	runName := flag.String("run-name", "", "任務名稱，所有輸出改放在輸出根目錄下的 <任務名稱>_<開始時間> 目錄，區分多次任務的結果")
+	dryRun := flag.Bool("dry-run", false, "試跑：照常解析列表與文章，只記錄預計下載的圖片與輸出檔（以 HEAD 估算大小），不寫入任何檔案")
+	seed := flag.Int64("seed", 0, "隨機種子，相同種子可重現下載前延遲與 User-Agent 隨機挑選（未指定時使用配置檔 crawler.seed，皆未指定時以時間產生）")
+	assumeYes := flag.Bool("yes", false, "不詢問，直接開始大範圍爬取（預估請求數超過門檻時預設會要求確認）")
+	watchConfig := flag.Bool("watch-config", false, "執行期間監看配置檔，變更時熱更新 workers、delays 與 rateLimit")
+	// 以下參數只在明確指定時覆寫配置檔（及 -mode 策略）的值，預設值不會蓋掉配置檔的設定
+	flag.Int(config.FlagWorkers, 0, "下載工人數，覆寫配置檔 crawler.workers（並停用 autoScale）")
+	flag.Int(config.FlagParserCount, 0, "內容解析器數量，覆寫配置檔 crawler.parserCount")
+	flag.Int(config.FlagMinDelay, 0, "下載前延遲下限（毫秒），覆寫配置檔 crawler.delays.minMs")
+	flag.Int(config.FlagMaxDelay, 0, "下載前延遲上限（毫秒），覆寫配置檔 crawler.delays.maxMs")
+	flag.Duration(config.FlagTimeout, 0, "HTTP 請求超時時間（如 10s），覆寫配置檔 crawler.http.timeout")
 
 	flag.Parse()
 
-	logger := ui.NewStyledLogger()
+	// 命令列指定的等級在載入配置前就套用，讓配置載入訊息也受控制
+	initialLevel := slog.LevelInfo
+	if *logLevel != "" {
+		level, err := ui.ParseLevel(*logLevel)
+		if err != nil {
+			ui.NewStyledLogger().Error("%v", err)
+			return 1
+		}
+		initialLevel = level
+	}
+	logger, err := setupLogger(*logFormat, initialLevel)
+	if err != nil {
+		ui.NewStyledLogger().Error("%v", err)
+		return 1
+	}
+
+	// 標題過濾在啟動時就編譯，無效的正規表示式不必等到 TUI 表單或爬蟲開始才發現
+	var titlePattern *regexp.Regexp
+	if *titleFilter != "" {
+		titlePattern, err = regexp.Compile(*titleFilter)
+		if err != nil {
+			logger.Error("無效的 -title-filter 正規表示式 %q: %v", *titleFilter, err)
+			return 1
+		}
+	}
+
+	sinceDate, untilDate, err := parseDateRange(*since, *until, time.Now())
+	if err != nil {
+		logger.Error("%v", err)
+		return 1
+	}
+
+	direction, err := crawler.ParseDirection(*directionFlag)
+	if err != nil {
+		logger.Error("%v", err)
+		return 1
+	}
+	if *fromPage < 0 {
+		logger.Error("-from-page 不可為負數: %d", *fromPage)
+		return 1
+	}
+	if *runName != "" {
+		namespace, err := crawler.RunNamespace(*runName, time.Now())
+		if err != nil {
+			logger.Error("%v", err)
+			return 1
+		}
+		*outputDir = filepath.Join(*outputDir, namespace)
+	}
+	pageRange, err := crawler.NewPageRange(*pageStart, *pageEnd)
+	if err != nil {
+		logger.Error("%v", err)
+		return 1
+	}
+	if pageRange.IsSet() && (*fromPage != 0 || config.IsFlagSet(flag.CommandLine, "pages")) {
+		logger.Error("-page-start/-page-end 不可與 -pages、-from-page 同時使用")
+		return 1
+	}
+
+	dedupCommand := *dedupStats || *dedupClear
 
 	// TUI 互動模式
-	if *tuiMode {
+	if *tuiMode && !dedupCommand {
 		tuiCfg, err := ui.RunStartupForm(
 			constants.DefaultBoard,
 			constants.DefaultPages,
@@ -36,49 +151,379 @@ func main() {
 		)
 		if err != nil {
 			logger.Error("TUI 表單錯誤: %v", err)
-			os.Exit(1)
+			return 1
 		}
 		*board = tuiCfg.Board
 		*pages = tuiCfg.Pages
 		*pushRate = tuiCfg.PushRate
 		*fileURL = tuiCfg.FileURL
 	}
+	if *retryFailures != "" && (*tuiMode || *fileURL != "") {
+		logger.Error("-retry-failures 不可與 -tui、-file 同時使用")
+		return 1
+	}
+	if *tuiMode && *fileURL == crawler.FileURLStdin {
+		// TUI 以標準輸入讀取鍵盤操作，無法同時作為網址來源
+		logger.Error("-tui 無法搭配 -file=- 使用，請改為指定網址檔案路徑")
+		return 1
+	}
 
 	// 載入配置
-	cfg, err := config.Load(*configPath)
+	cfg, err := config.Load(*configPath, config.WithStrict(*strictConfig))
 	if err != nil {
 		logger.Error("載入配置失敗: %v", err)
-		os.Exit(1)
+		return 1
+	}
+	if *mode != "" {
+		if err := cfg.ApplyProfile(*mode); err != nil {
+			logger.Error("%v", err)
+			return 1
+		}
+	}
+	overrides, err := config.OverridesFromFlags(flag.CommandLine)
+	if err != nil {
+		logger.Error("%v", err)
+		return 1
+	}
+	if err := cfg.ApplyOverrides(overrides); err != nil {
+		logger.Error("%v", err)
+		return 1
+	}
+
+	levelName := cfg.Crawler.LogLevel
+	if *logLevel != "" {
+		levelName = *logLevel
+	}
+	level, err := ui.ParseLevel(levelName)
+	if err != nil {
+		logger.Error("%v", err)
+		return 1
+	}
+	// 格式已在前面驗證過，此處只會重新套用最終等級
+	logger, _ = setupLogger(*logFormat, level)
+	if sl, ok := logger.(*ui.SlogLogger); ok && *fileURL == "" && *retryFailures == "" {
+		logger = sl.With(ui.FieldBoard, *board)
+	}
+
+	if dedupCommand {
+		if err := runDedupCommand(logger, cfg.Crawler.Dedup.IndexPath, *dedupClear); err != nil {
+			logger.Error("%v", err)
+			return 1
+		}
+		return 0
+	}
+
+	if pageRange.IsSet() {
+		*pages = pageRange.Len()
+	}
+	if *retryFailures != "" && cfg.Crawler.Pack == config.PackTar {
+		// 打包模式的圖片寫入文章的封存檔，無法單獨補上一張
+		logger.Error("-retry-failures 無法搭配 crawler.pack: tar 使用")
+		return 1
+	}
+	if *fileURL == "" && *retryFailures == "" && !*assumeYes && !confirmCrawl(logger, crawler.EstimateCrawl(*board, *pages)) {
+		logger.Warn("已取消爬取")
+		return 0
+	}
+
+	logger, closeLogs, err := setupLogFiles(logger, cfg.Crawler.Logging, *logFormat, level)
+	if err != nil {
+		logger.Error("%v", err)
+		return 1
+	}
+	defer closeLogs()
+
+	// 狀態列與彩色日誌共用 stderr，日誌須經由狀態列輸出才不會交錯；
+	// JSON 日誌或輸出被導向檔案時改為定期輸出一行日誌，TUI 模式本身已有進度畫面
+	var statusBar *ui.StatusBar
+	if *progress && !*tuiMode && *logFormat == "text" && ui.IsTerminal(os.Stderr) {
+		statusBar = ui.NewStatusBar(os.Stderr)
+		logger = statusBar.Logger(logger)
+	}
+
+	// 種子在建立 client 與 crawler 前決定並記錄，重跑時以 -seed 指定即可重現
+	if *seed != 0 {
+		cfg.Crawler.Seed = *seed
+	}
+	if cfg.Crawler.Seed == 0 {
+		cfg.Crawler.Seed = time.Now().UnixNano()
+	}
+	logger.Info("隨機種子: %d（以 -seed %d 可重現本次的延遲與 User-Agent 挑選）", cfg.Crawler.Seed, cfg.Crawler.Seed)
+
+	if *runName != "" {
+		// 相對路徑的失敗明細與重跑清單同樣放在命名空間內，不同任務的檔案不會互相覆寫
+		for _, path := range []*string{&cfg.Crawler.Failures.Report, &cfg.Crawler.Failures.RetryFile} {
+			if *path != "" && !filepath.IsAbs(*path) {
+				*path = filepath.Join(*outputDir, *path)
+			}
+		}
+		logger.Info("任務 %s 的輸出目錄: %s", *runName, *outputDir)
+	}
+	if *retryFailures != "" && cfg.Crawler.Failures.Report == "" {
+		// 未另外指定時，仍失敗的圖片寫回原本的明細，可反覆以同一指令重試
+		cfg.Crawler.Failures.Report = *retryFailures
 	}
 
 	// 建立 context
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
+	opts := []crawler.Option{
+		crawler.WithTitleFilter(titlePattern),
+		crawler.WithTitleContains(*titleContains),
+		crawler.WithAuthors(strings.Split(*author, ",")),
+		crawler.WithDateRange(sinceDate, untilDate),
+		crawler.WithDirection(direction),
+		crawler.WithFromPage(*fromPage),
+		crawler.WithPageRange(pageRange),
+		crawler.WithOutputDir(*outputDir),
+		crawler.WithDryRun(*dryRun),
+		crawler.WithRetryFailures(*retryFailures),
+		crawler.WithQuiet(*quiet),
+	}
+	if *progress {
+		opts = append(opts, crawler.WithProgressReport(*progressInterval, statusBar))
+	}
+	if *resume || *checkpointPath != "" {
+		cp, err := openCheckpoint(*checkpointPath, *resume)
+		if err != nil {
+			logger.Error("%v", err)
+			return 1
+		}
+		if *resume {
+			logger.Info("從 checkpoint 繼續: %s（已完成 %d 篇文章）", cp.Path(), cp.Len())
+		}
+		opts = append(opts, crawler.WithCheckpoint(cp))
+	}
+	if *useCache {
+		cm, err := cache.Open(cfg.Crawler.Cache.Path)
+		if err != nil {
+			logger.Error("%v", err)
+			return 1
+		}
+		ttl := cfg.GetCacheTTL()
+		if n := cm.Prune(time.Now().Add(-ttl)); n > 0 {
+			logger.Info("已移除文章快取中超過有效期限 %s 的 %d 筆紀錄", ttl, n)
+		}
+		logger.Info("文章快取: %s（已記錄 %d 篇文章，%s 內爬過的文章略過）", cm.Path(), cm.Len(), ttl)
+		opts = append(opts, crawler.WithCache(cm, ttl))
+	}
+	if *watchConfig {
+		// 重新載入的配置同樣套用 -mode 策略與命令列覆寫，避免它們設定的值被配置檔原值蓋回
+		// 種子沿用啟動時決定的值，避免配置檔未設定 seed 時誤報需重新啟動
+		opts = append(opts, crawler.WithConfigReload(*configPath, func(c *config.Config) error {
+			c.Crawler.Seed = cfg.Crawler.Seed
+			if *mode != "" {
+				if err := c.ApplyProfile(*mode); err != nil {
+					return err
+				}
+			}
+			return c.ApplyOverrides(overrides)
+		}, config.WithStrict(*strictConfig)))
+	}
+
+	stopMetrics := func() {}
+	if *metricsAddr != "" {
+		collector := metrics.NewCollector()
+		stopMetrics, err = startMetricsServer(ctx, logger, *metricsAddr, collector)
+		if err != nil {
+			logger.Error("%v", err)
+			return 1
+		}
+		opts = append(opts, crawler.WithMetrics(collector))
+	}
+
 	if *tuiMode {
-		runWithTUI(ctx, cancel, logger, *board, *pages, *pushRate, *fileURL, cfg)
+		err = runWithTUI(ctx, cancel, logger, *board, *pages, *pushRate, *fileURL, cfg, opts...)
 	} else {
-		runWithCLI(ctx, cancel, logger, *board, *pages, *pushRate, *fileURL, cfg)
+		err = runWithCLI(ctx, cancel, logger, *board, *pages, *pushRate, *fileURL, cfg, opts...)
+	}
+	stopMetrics()
+	if err != nil {
+		logger.Error("%v", err)
+		return 1
 	}
+	return 0
+}
+
+// startMetricsServer 在 addr 開啟 Prometheus 指標端點，回傳的函式會關閉伺服器並等待結束。
+// ctx 取消（例如收到中斷信號）時伺服器也會自行關閉。
+func startMetricsServer(ctx context.Context, logger ui.Logger, addr string, collector *metrics.Collector) (func(), error) {
+	ln, err := net.Listen("tcp", addr)
+	if err != nil {
+		return nil, fmt.Errorf("監聽 metrics 位址 %s 失敗: %w", addr, err)
+	}
+	logger.Info("Prometheus 指標端點: http://%s/metrics", ln.Addr())
+
+	serverCtx, stop := context.WithCancel(ctx)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		if err := metrics.Serve(serverCtx, ln, collector); err != nil {
+			logger.Error("metrics 伺服器錯誤: %v", err)
+		}
+	}()
+
+	return func() {
+		stop()
+		<-done
+	}, nil
+}
+
+// parseDateRange 解析 -since/-until，空字串表示不限制該端；兩端皆指定時 since 不可晚於 until
+func parseDateRange(since, until string, now time.Time) (time.Time, time.Time, error) {
+	var sinceDate, untilDate time.Time
+	var err error
+	if since != "" {
+		if sinceDate, err = crawler.ParseDate(since, now); err != nil {
+			return time.Time{}, time.Time{}, fmt.Errorf("-since: %w", err)
+		}
+	}
+	if until != "" {
+		if untilDate, err = crawler.ParseDate(until, now); err != nil {
+			return time.Time{}, time.Time{}, fmt.Errorf("-until: %w", err)
+		}
+	}
+	if !sinceDate.IsZero() && !untilDate.IsZero() && sinceDate.After(untilDate) {
+		return time.Time{}, time.Time{}, fmt.Errorf("-since %s 晚於 -until %s",
+			sinceDate.Format(time.DateOnly), untilDate.Format(time.DateOnly))
+	}
+	return sinceDate, untilDate, nil
+}
+
+// boardsValue 實作 flag.Value，讓 -board 可重複指定：
+// 第一次指定時取代預設值，之後以逗號附加，交由 crawler 拆分成看板清單。
+type boardsValue struct {
+	p   *string
+	set bool
+}
+
+func newBoardsValue(def string, p *string) *boardsValue {
+	*p = def
+	return &boardsValue{p: p}
+}
+
+func (b *boardsValue) String() string {
+	if b == nil || b.p == nil {
+		return ""
+	}
+	return *b.p
+}
+
+func (b *boardsValue) Set(v string) error {
+	if b.set {
+		*b.p += "," + v
+		return nil
+	}
+	*b.p = v
+	b.set = true
+	return nil
+}
+
+// setupLogger 依日誌格式與等級建立 Logger，並同步設定全域 slog（config 等套件使用）。
+// text 維持原本的彩色輸出；json 讓所有日誌（含全域 slog 與 log 套件）都輸出為 NDJSON。
+func setupLogger(format string, level slog.Level) (ui.Logger, error) {
+	switch format {
+	case "text":
+		slog.SetLogLoggerLevel(level)
+		return ui.NewLevelLogger(ui.NewStyledLogger(), level), nil
+	case "json":
+		slog.SetDefault(slog.New(ui.NewJSONHandler(os.Stderr, level)))
+		return ui.NewSlogLogger(nil), nil
+	default:
+		return nil, fmt.Errorf("無效的日誌格式 %q（可用值: text、json）", format)
+	}
+}
+
+// setupLogFiles 依 logging 配置另外將日誌寫入檔案：主日誌檔記錄所有訊息，
+// perBoard 啟用時再依看板分檔（最多 maxBoardFiles 個）。回傳的函式會關閉所有日誌檔。
+func setupLogFiles(logger ui.Logger, cfg config.LoggingConfig, format string, level slog.Level) (ui.Logger, func(), error) {
+	if cfg.File == "" {
+		return logger, func() {}, nil
+	}
+	f, err := ui.OpenLogFile(cfg.File)
+	if err != nil {
+		return logger, nil, err
+	}
+	newLog := func(w io.Writer) ui.Logger { return ui.NewFileLogger(w, format, level) }
+	logger = ui.NewTeeLogger(logger, newLog(f))
+	if !cfg.PerBoard {
+		return logger, func() { ioutil.CloseWithLog(f, "日誌檔") }, nil
+	}
+
+	boards := ui.NewBoardFileLogger(logger, cfg.File, cfg.MaxBoardFiles, newLog)
+	return boards, func() {
+		ioutil.CloseWithLog(boards, "看板日誌檔")
+		ioutil.CloseWithLog(f, "日誌檔")
+	}, nil
+}
+
+// confirmRequestThreshold 看板模式預估請求數超過此值時，啟動前要求確認（約 50 頁）
+const confirmRequestThreshold = 1000
+
+// confirmCrawl 預估請求數超過門檻時顯示爬取範圍並要求確認，回傳是否繼續。
+// 非互動環境（stdin 不是終端機，如 cron 或管線）無法回答，記錄範圍後直接繼續。
+func confirmCrawl(logger ui.Logger, est crawler.CrawlEstimate) bool {
+	if est.Requests <= confirmRequestThreshold {
+		return true
+	}
+	if !ui.IsTerminal(os.Stdin) {
+		logger.Warn("%s；非互動環境，略過確認", est)
+		return true
+	}
+	return ui.Confirm(os.Stdin, os.Stderr, est.String()+"，確定要開始嗎？（以 -yes 略過確認）")
+}
+
+// openCheckpoint 開啟斷點續爬的 checkpoint：resume 時載入既有進度，否則從頭記錄（覆寫既有檔案）
+func openCheckpoint(path string, resume bool) (*checkpoint.Checkpoint, error) {
+	if path == "" {
+		path = constants.DefaultCheckpointPath
+	}
+	if !resume {
+		return checkpoint.New(path), nil
+	}
+	return checkpoint.Load(path)
+}
+
+// runDedupCommand 執行下載索引的維護指令：clearIndex 為 true 時刪除索引，否則顯示統計
+func runDedupCommand(logger ui.Logger, indexPath string, clearIndex bool) error {
+	if clearIndex {
+		if err := dedup.Clear(indexPath); err != nil {
+			return err
+		}
+		logger.Success("已清除下載索引: %s", indexPath)
+		return nil
+	}
+
+	idx, err := dedup.Open(indexPath)
+	if err != nil {
+		return err
+	}
+	st := idx.Stats()
+	logger.Info("下載索引: %s", indexPath)
+	logger.Info("已記錄圖片 %d 張（不重複內容 %d 張），共 %s，檔案已不存在 %d 張",
+		st.Entries, st.UniqueHashes, performance.FormatBytes(uint64(st.TotalBytes)), st.MissingFiles)
+	return nil
 }
 
 // runWithTUI 使用即時進度 TUI 模式執行爬蟲
-func runWithTUI(ctx context.Context, cancel context.CancelFunc, logger ui.Logger, board string, pages, pushRate int, fileURL string, cfg *config.Config) {
+func runWithTUI(ctx context.Context, cancel context.CancelFunc, logger ui.Logger, board string, pages, pushRate int, fileURL string, cfg *config.Config, opts ...crawler.Option) error {
 	progressCh := make(chan types.ProgressEvent, 200)
 
-	c, err := crawler.NewCrawler(board, pages, pushRate, fileURL, cfg,
+	opts = append(opts,
 		crawler.WithProgress(progressCh),
 		crawler.WithLogger(ui.NewNoopLogger()),
 	)
+	c, err := crawler.NewCrawler(board, pages, pushRate, fileURL, cfg, opts...)
 	if err != nil {
-		logger.Error("建立爬蟲失敗: %v", err)
-		os.Exit(1)
+		return fmt.Errorf("建立爬蟲失敗: %w", err)
 	}
 
 	// 在背景執行爬蟲，完成後關閉 progress channel
 	done := make(chan struct{})
+	var runErr error
 	go func() {
-		c.Run(ctx)
+		runErr = c.Run(ctx)
 		close(progressCh)
 		close(done)
 	}()
@@ -90,38 +535,93 @@ func runWithTUI(ctx context.Context, cancel context.CancelFunc, logger ui.Logger
 	}
 
 	if err := ui.RunLiveTUI(liveCfg, progressCh, cancel); err != nil {
-		logger.Error("TUI 錯誤: %v", err)
-		os.Exit(1)
+		return fmt.Errorf("TUI 錯誤: %w", err)
 	}
 
 	// TUI 退出後等待爬蟲收尾（waitAndCleanup），
-	// 避免 Ctrl+C 時直接退出留下寫到一半的圖片檔
+	// 避免 Ctrl+C 時直接退出留下寫到一半的圖片檔；收尾卡住時同樣可再按 Ctrl+C 或等 shutdownTimeout 強制結束
 	select {
 	case <-done:
 	default:
-		logger.Warn("正在等待爬蟲收尾...")
-		<-done
+		sigChan := make(chan os.Signal, 1)
+		signal.Notify(sigChan, os.Interrupt, syscall.SIGTERM)
+		defer signal.Stop(sigChan)
+		timeout := cfg.GetShutdownTimeout()
+		logger.Warn("正在等待爬蟲收尾...（%s）", shutdownHint(timeout))
+		watchShutdown(logger, sigChan, timeout, done)
+		logger.Success("已優雅關閉爬蟲")
 	}
+	if runErr != nil {
+		return fmt.Errorf("爬取失敗: %w", runErr)
+	}
+	return nil
 }
 
 // runWithCLI 使用傳統 CLI 模式（彩色 log 輸出）執行爬蟲
-func runWithCLI(ctx context.Context, cancel context.CancelFunc, logger ui.Logger, board string, pages, pushRate int, fileURL string, cfg *config.Config) {
-	c, err := crawler.NewCrawler(board, pages, pushRate, fileURL, cfg)
+func runWithCLI(ctx context.Context, cancel context.CancelFunc, logger ui.Logger, board string, pages, pushRate int, fileURL string, cfg *config.Config, opts ...crawler.Option) error {
+	c, err := crawler.NewCrawler(board, pages, pushRate, fileURL, cfg, append(opts, crawler.WithLogger(logger))...)
 	if err != nil {
-		logger.Error("建立爬蟲失敗: %v", err)
-		os.Exit(1)
+		return fmt.Errorf("建立爬蟲失敗: %w", err)
 	}
 
 	// 監聽系統信號
 	sigChan := make(chan os.Signal, 1)
 	signal.Notify(sigChan, os.Interrupt, syscall.SIGTERM)
+	done := make(chan struct{})
+	interrupted := make(chan struct{})
 
 	go func() {
-		<-sigChan
-		logger.Warn("收到中斷信號，正在優雅關閉爬蟲...")
+		select {
+		case <-sigChan:
+		case <-done:
+			return
+		}
+		close(interrupted)
+		timeout := cfg.GetShutdownTimeout()
+		logger.Warn("收到中斷信號，正在優雅關閉爬蟲...（%s）", shutdownHint(timeout))
 		cancel()
+		watchShutdown(logger, sigChan, timeout, done)
 	}()
 
 	// 啟動爬蟲
-	c.Run(ctx)
+	runErr := c.Run(ctx)
+	close(done)
+	select {
+	case <-interrupted:
+		logger.Success("已優雅關閉爬蟲")
+	default:
+	}
+	if runErr != nil {
+		return fmt.Errorf("爬取失敗: %w", runErr)
+	}
+	return nil
+}
+
+// shutdownHint 說明優雅關閉期間如何強制結束
+func shutdownHint(timeout time.Duration) string {
+	if timeout <= 0 {
+		return "再按一次 Ctrl+C 強制結束"
+	}
+	return fmt.Sprintf("%v 內未完成或再按一次 Ctrl+C 將強制結束", timeout)
+}
+
+// watchShutdown 在優雅關閉期間監看：done 關閉表示已正常收尾；
+// 期間再次收到中斷信號，或超過 timeout（0 表示不限時）仍未收尾時，記錄原因並以結束碼 1 強制結束程式。
+// 強制結束不會執行 defer，尚未寫入的檔案與 checkpoint 可能遺失。
+func watchShutdown(logger ui.Logger, sigChan <-chan os.Signal, timeout time.Duration, done <-chan struct{}) {
+	var deadline <-chan time.Time
+	if timeout > 0 {
+		timer := time.NewTimer(timeout)
+		defer timer.Stop()
+		deadline = timer.C
+	}
+	select {
+	case <-done:
+	case <-sigChan:
+		logger.Error("再次收到中斷信號，強制結束（未完成的下載與輸出檔可能不完整）")
+		os.Exit(1)
+	case <-deadline:
+		logger.Error("優雅關閉超過 shutdownTimeout %v 仍未完成，強制結束（未完成的下載與輸出檔可能不完整）", timeout)
+		os.Exit(1)
+	}
 }
@@ -0,0 +1,50 @@
+package markdown
+
+import (
+	"archive/tar"
+	"encoding/base64"
+	"errors"
+	"html/template"
+	"io"
+	"net/http"
+	"os"
+
+	"github.com/twtrubiks/ptt-spider-go/internal/ioutil"
+)
+
+// ImageArchiveFileName 是打包模式（pack: tar）下文章目錄中圖片封存檔的檔名
+const ImageArchiveFileName = "images.tar"
+
+// readArchive 讀出封存檔內的所有圖片，回傳檔名 → 內容
+func readArchive(path string) (map[string][]byte, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer ioutil.CloseWithLog(file, "圖片封存檔")
+
+	images := make(map[string][]byte)
+	tr := tar.NewReader(file)
+	for {
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			return images, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		if hdr.Typeflag != tar.TypeReg {
+			continue
+		}
+		data, err := io.ReadAll(tr)
+		if err != nil {
+			return nil, err
+		}
+		images[hdr.Name] = data
+	}
+}
+
+// dataURI 將圖片內容編碼為 data URI，MIME 類型由檔頭判斷
+func dataURI(data []byte) template.URL {
+	return template.URL("data:" + http.DetectContentType(data) + ";base64," + base64.StdEncoding.EncodeToString(data))
+}
@@ -0,0 +1,62 @@
+package markdown
+
+import "github.com/twtrubiks/ptt-spider-go/types"
+
+// Format 是文章目錄中的輸出格式
+type Format string
+
+// 支援的輸出格式
+const (
+	FormatMarkdown Format = "markdown" // README.md（預設）
+	FormatHTML     Format = "html"     // index.html 圖片牆
+)
+
+// renderer 將文章資訊轉為單一輸出檔的內容
+type renderer interface {
+	// name 是錯誤訊息中的格式名稱
+	name() string
+	// fileName 是輸出檔在文章目錄中的檔名
+	fileName() string
+	// render 產生檔案內容，imageFiles 為與 info.ImageURLs 一一對應的本地檔名
+	render(info types.MarkdownInfo, imageFiles []string) ([]byte, error)
+}
+
+// WithFormats 指定要輸出的格式（可同時輸出多種），未指定時只輸出 Markdown
+func WithFormats(formats ...Format) GeneratorOption {
+	return func(g *GeneratorImpl) { g.formats = formats }
+}
+
+// outputFormats 回傳設定的輸出格式，未指定時只輸出 Markdown
+func (g *GeneratorImpl) outputFormats() []Format {
+	if len(g.formats) == 0 {
+		return []Format{FormatMarkdown}
+	}
+	return g.formats
+}
+
+// renderers 依設定組出本次要產生的輸出檔，manifest.json 與 pushes.json 附加在最後
+func (g *GeneratorImpl) renderers() []renderer {
+	formats := g.outputFormats()
+	rs := make([]renderer, 0, len(formats)+2)
+	for _, f := range formats {
+		switch f {
+		case FormatMarkdown:
+			if g.template != nil {
+				rs = append(rs, templateRenderer{tmpl: g.template, linkBase: g.linkBase})
+				continue
+			}
+			rs = append(rs, markdownRenderer{
+				numberImages: g.numberImages, inlinePushes: g.inlinePushes, frontMatter: g.frontMatter, linkBase: g.linkBase,
+			})
+		case FormatHTML:
+			rs = append(rs, htmlRenderer{numberImages: g.numberImages, inlinePushes: g.inlinePushes, linkBase: g.linkBase})
+		}
+	}
+	if g.manifest {
+		rs = append(rs, manifestRenderer{})
+	}
+	if g.pushesFile {
+		rs = append(rs, pushesRenderer{})
+	}
+	return rs
+}
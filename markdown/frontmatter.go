@@ -0,0 +1,102 @@
+package markdown
+
+import (
+	"bytes"
+	"path"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/twtrubiks/ptt-spider-go/constants"
+	"github.com/twtrubiks/ptt-spider-go/types"
+	yaml "gopkg.in/yaml.v3"
+)
+
+// replyPrefix 比對標題開頭的回覆、轉寄前綴（如「Re: 」「Fw: 」），分類標籤在前綴之後
+var replyPrefix = regexp.MustCompile(`^(?i:(re|fw|fwd)\s*:\s*)+`)
+
+// FrontMatter 是 README.md 開頭 YAML front matter 的欄位，供 Hugo、Jekyll 等靜態網站生成器讀取
+type FrontMatter struct {
+	Title     string    `yaml:"title"`            // 文章標題
+	Date      time.Time `yaml:"date,omitempty"`   // 發文時間（文章頁的「時間」欄位，沒有時由文章網址推導），無法取得時省略
+	Author    string    `yaml:"author,omitempty"` // 作者帳號，未知時省略
+	Tags      []string  `yaml:"tags,omitempty"`   // 文章分類（標題開頭的 [正妹] 等），沒有分類時省略
+	PushCount int       `yaml:"pushCount"`        // 推文數
+	Source    string    `yaml:"source,omitempty"` // 原始文章網址
+	Images    []string  `yaml:"images,omitempty"` // 圖片相對於文章目錄的路徑；沒有圖片或圖片打包於封存檔時省略
+}
+
+// WithFrontMatter 啟用後在 README.md 開頭輸出 YAML front matter（title、date、author、tags、pushCount、source、images）
+func WithFrontMatter(enabled bool) GeneratorOption {
+	return func(g *GeneratorImpl) { g.frontMatter = enabled }
+}
+
+// newFrontMatter 由 MarkdownInfo 建立 front matter，imageFiles 為與 info.ImageURLs 一一對應的本地路徑
+func newFrontMatter(info types.MarkdownInfo, imageFiles []string) FrontMatter {
+	fm := FrontMatter{Title: info.Title, Author: info.Author, PushCount: info.PushCount, Source: info.ArticleURL}
+	if t, ok := articleDate(info); ok {
+		fm.Date = t
+	}
+	// 打包模式的圖片位於封存檔內，沒有可引用的路徑
+	if info.Archive == "" && len(imageFiles) > 0 {
+		fm.Images = imageFiles
+	}
+	if category := titleCategory(info.Title); category != "" {
+		fm.Tags = []string{category}
+	}
+	return fm
+}
+
+// writeFrontMatter 以「---」包夾寫入 front matter；字串的引號與跳脫交由 YAML 編碼器處理，
+// 標題含冒號、引號、# 或換行時仍是合法的 YAML
+func writeFrontMatter(builder *strings.Builder, info types.MarkdownInfo, imageFiles []string) error {
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(newFrontMatter(info, imageFiles)); err != nil {
+		return err
+	}
+	if err := enc.Close(); err != nil {
+		return err
+	}
+	builder.WriteString("---\n")
+	builder.Write(buf.Bytes())
+	builder.WriteString("---\n\n")
+	return nil
+}
+
+// articleDate 回傳文章的發文時間：優先使用文章頁的「時間」欄位，沒有時由文章網址推導
+func articleDate(info types.MarkdownInfo) (time.Time, bool) {
+	if !info.Date.IsZero() {
+		return info.Date.In(constants.PttLocation), true
+	}
+	return articleTime(info.ArticleURL)
+}
+
+// articleTime 由 PTT 文章網址（如 M.1234567890.A.ABC.html）中的 Unix 時間推導發文時間
+func articleTime(articleURL string) (time.Time, bool) {
+	parts := strings.Split(path.Base(articleURL), ".")
+	if len(parts) < 3 || parts[0] != "M" {
+		return time.Time{}, false
+	}
+	sec, err := strconv.ParseInt(parts[1], 10, 64)
+	if err != nil || sec <= 0 {
+		return time.Time{}, false
+	}
+	return time.Unix(sec, 0).In(constants.PttLocation), true
+}
+
+// titleCategory 回傳標題開頭方括號內的分類（如「Re: [正妹] 標題」的「正妹」），沒有分類時回傳空字串
+func titleCategory(title string) string {
+	title = strings.TrimSpace(replyPrefix.ReplaceAllString(strings.TrimSpace(title), ""))
+	rest, ok := strings.CutPrefix(title, "[")
+	if !ok {
+		return ""
+	}
+	category, _, ok := strings.Cut(rest, "]")
+	if !ok {
+		return ""
+	}
+	return strings.TrimSpace(category)
+}
@@ -0,0 +1,181 @@
+package markdown
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/twtrubiks/ptt-spider-go/constants"
+	"github.com/twtrubiks/ptt-spider-go/types"
+	yaml "gopkg.in/yaml.v3"
+)
+
+// readFrontMatter 讀取 README.md 並解析開頭的 front matter，回傳解析結果與其後的內容
+func readFrontMatter(t *testing.T, dir string) (map[string]any, string) {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join(dir, MarkdownFileName))
+	if err != nil {
+		t.Fatalf("讀取 README.md 失敗: %v", err)
+	}
+	content, ok := strings.CutPrefix(string(data), "---\n")
+	if !ok {
+		t.Fatalf("README.md 應以 --- 開頭:\n%s", data)
+	}
+	header, body, ok := strings.Cut(content, "\n---\n")
+	if !ok {
+		t.Fatalf("front matter 缺少結尾的 ---:\n%s", data)
+	}
+	var fm map[string]any
+	if err := yaml.Unmarshal([]byte(header), &fm); err != nil {
+		t.Fatalf("front matter 不是合法 YAML: %v\n%s", err, header)
+	}
+	return fm, body
+}
+
+func TestGeneratorImpl_FrontMatter(t *testing.T) {
+	info := types.MarkdownInfo{
+		Title:      "[正妹] 測試",
+		ArticleURL: "https://www.ptt.cc/bbs/Beauty/M.1234567890.A.ABC.html",
+		PushCount:  42,
+		ImageURLs:  []string{"https://i.imgur.com/a.jpg"},
+		SaveDir:    t.TempDir(),
+	}
+	if err := NewGenerator(WithFrontMatter(true)).Generate(info); err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+
+	fm, body := readFrontMatter(t, info.SaveDir)
+	want := map[string]any{
+		"title":     "[正妹] 測試",
+		"date":      time.Unix(1234567890, 0).In(constants.PttLocation),
+		"tags":      []any{"正妹"},
+		"pushCount": 42,
+		"source":    "https://www.ptt.cc/bbs/Beauty/M.1234567890.A.ABC.html",
+		"images":    []any{"a.jpg"},
+	}
+	if date, ok := fm["date"].(time.Time); ok && date.Equal(want["date"].(time.Time)) {
+		fm["date"] = want["date"]
+	}
+	if !reflect.DeepEqual(fm, want) {
+		t.Errorf("front matter = %#v, want %#v", fm, want)
+	}
+	if !strings.HasPrefix(body, "\n# [正妹] 測試\n") {
+		t.Errorf("front matter 之後應為文章標題，實際:\n%s", body)
+	}
+}
+
+func TestGeneratorImpl_FrontMatterEscaping(t *testing.T) {
+	titles := []string{
+		`Re: [問卦] 有沒有 "引號" 跟 key: value 的八卦？`,
+		"# 井號開頭 & 星號 * 與 | 管線",
+		"反斜線 \\ 與\t定位字元",
+		"換行\n---\n不應結束 front matter",
+		"'單引號' 與 {大括號} [中括號",
+	}
+	for _, title := range titles {
+		info := types.MarkdownInfo{Title: title, ArticleURL: "https://www.ptt.cc/bbs/Beauty/M.1234567890.A.ABC.html", SaveDir: t.TempDir()}
+		if err := NewGenerator(WithFrontMatter(true)).Generate(info); err != nil {
+			t.Fatalf("Generate failed: %v", err)
+		}
+		fm, _ := readFrontMatter(t, info.SaveDir)
+		if fm["title"] != title {
+			t.Errorf("title = %q, want %q", fm["title"], title)
+		}
+	}
+}
+
+func TestGeneratorImpl_FrontMatterDisabled(t *testing.T) {
+	info := types.MarkdownInfo{Title: "[正妹] 測試", ArticleURL: "https://www.ptt.cc/bbs/Beauty/M.1234567890.A.ABC.html", SaveDir: t.TempDir()}
+	if err := NewGenerator().Generate(info); err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+	data, err := os.ReadFile(filepath.Join(info.SaveDir, MarkdownFileName))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.HasPrefix(string(data), "---") {
+		t.Errorf("未啟用時不應輸出 front matter:\n%s", data)
+	}
+}
+
+func TestNewFrontMatter_OmitsUnknownFields(t *testing.T) {
+	// 檔案模式的網址不一定是 PTT 文章網址，標題也可能沒有分類
+	fm := newFrontMatter(types.MarkdownInfo{Title: "沒有分類", ArticleURL: "https://example.com/post/1"}, nil)
+	if !fm.Date.IsZero() || fm.Tags != nil {
+		t.Fatalf("無法推導時應省略 date 與 tags，實際 %+v", fm)
+	}
+
+	var b strings.Builder
+	if err := writeFrontMatter(&b, types.MarkdownInfo{Title: "沒有分類"}, nil); err != nil {
+		t.Fatal(err)
+	}
+	for _, key := range []string{"date:", "tags:", "author:", "source:", "images:"} {
+		if got := b.String(); strings.Contains(got, key) {
+			t.Errorf("不應輸出 %s\n%s", key, got)
+		}
+	}
+}
+
+func TestNewFrontMatter_ArticleDateAndAuthor(t *testing.T) {
+	posted := time.Date(2026, 1, 5, 2, 0, 0, 0, time.UTC) // 台灣時間 10:00
+	info := types.MarkdownInfo{
+		Title:      "[正妹] 測試",
+		ArticleURL: "https://www.ptt.cc/bbs/Beauty/M.1234567890.A.ABC.html",
+		Author:     "tester",
+		Date:       posted,
+		ImageURLs:  []string{"https://i.imgur.com/a.jpg"},
+	}
+	fm := newFrontMatter(info, []string{"portrait/a.jpg"})
+	// 文章頁的「時間」優先於網址推導的時間，並以台灣時間輸出
+	if !fm.Date.Equal(posted) || fm.Date.Location() != constants.PttLocation {
+		t.Errorf("date = %v, want %v（UTC+8）", fm.Date, posted)
+	}
+	if fm.Author != "tester" || fm.Source != info.ArticleURL {
+		t.Errorf("author/source = %q/%q", fm.Author, fm.Source)
+	}
+	if !reflect.DeepEqual(fm.Images, []string{"portrait/a.jpg"}) {
+		t.Errorf("images = %v, want 分類後的路徑", fm.Images)
+	}
+
+	info.Archive = "images.tar"
+	if fm := newFrontMatter(info, []string{"a.jpg"}); fm.Images != nil {
+		t.Errorf("打包模式不應列出圖片路徑: %v", fm.Images)
+	}
+}
+
+func TestTitleCategory(t *testing.T) {
+	tests := []struct {
+		title string
+		want  string
+	}{
+		{"[正妹] 測試", "正妹"},
+		{"Re: [問卦] 測試", "問卦"},
+		{"Fw: Re: [ 公告 ] 測試", "公告"},
+		{"沒有分類", ""},
+		{"[未閉合 測試", ""},
+		{"", ""},
+	}
+	for _, tt := range tests {
+		if got := titleCategory(tt.title); got != tt.want {
+			t.Errorf("titleCategory(%q) = %q, want %q", tt.title, got, tt.want)
+		}
+	}
+}
+
+func TestArticleTime(t *testing.T) {
+	got, ok := articleTime("https://www.ptt.cc/bbs/Beauty/M.1234567890.A.ABC.html")
+	if !ok || got.Unix() != 1234567890 {
+		t.Fatalf("articleTime = %v, %v", got, ok)
+	}
+	if _, off := got.Zone(); off != 8*60*60 {
+		t.Errorf("時區偏移 = %d，應為 UTC+8", off)
+	}
+	for _, u := range []string{"https://example.com/a.html", "https://www.ptt.cc/bbs/Beauty/M.abc.A.ABC.html", ""} {
+		if _, ok := articleTime(u); ok {
+			t.Errorf("articleTime(%q) 不應成功", u)
+		}
+	}
+}
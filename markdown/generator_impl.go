@@ -1,4 +1,4 @@
-// Package markdown 為每篇文章產生帶圖片連結的 Markdown 檔案。
+// Package markdown 為每篇文章產生帶圖片連結的輸出檔：README.md（預設）、index.html 圖片牆、manifest.json 與 pushes.json。
 package markdown
 
 import (
@@ -6,6 +6,7 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"text/template"
 
 	"github.com/twtrubiks/ptt-spider-go/constants"
 	"github.com/twtrubiks/ptt-spider-go/errors"
@@ -15,49 +16,150 @@ import (
 )
 
 // GeneratorImpl 實現 MarkdownGenerator 介面
-type GeneratorImpl struct{}
+type GeneratorImpl struct {
+	formats      []Format // 輸出格式，未指定時只輸出 Markdown
+	numberImages bool     // 每張圖片前加上「### 圖 N」小標題
+	manifest     bool     // 另外輸出 manifest.json
+	inlinePushes bool     // 在 README.md／index.html 加上「推文」段落
+	pushesFile   bool     // 另外輸出 pushes.json
+	frontMatter  bool     // 在 README.md 開頭輸出 YAML front matter
+
+	template *template.Template // 自訂 README.md 模板，nil 時使用內建格式
+	linkBase string             // 圖片與文章連結的基底，為空時使用 ./ 相對連結
+}
+
+// GeneratorOption 定義 GeneratorImpl 的可選配置函式
+type GeneratorOption func(*GeneratorImpl)
+
+// WithNumberedImages 啟用後在每張圖片前加上「### 圖 N」小標題（N 從 1 開始），方便引用
+func WithNumberedImages(enabled bool) GeneratorOption {
+	return func(g *GeneratorImpl) { g.numberImages = enabled }
+}
+
+// WithManifest 啟用後在 README.md 旁另外輸出 manifest.json（文章資訊與圖片檔名、原始 URL），供程式讀取
+func WithManifest(enabled bool) GeneratorOption {
+	return func(g *GeneratorImpl) { g.manifest = enabled }
+}
 
 // NewGenerator 建立新的 Markdown 生成器實例
-func NewGenerator() interfaces.MarkdownGenerator {
-	return &GeneratorImpl{}
+func NewGenerator(opts ...GeneratorOption) interfaces.MarkdownGenerator {
+	g := &GeneratorImpl{}
+	for _, opt := range opts {
+		opt(g)
+	}
+	return g
 }
 
-// Generate 實現 MarkdownGenerator 介面的 Generate 方法
+// Generate 實現 MarkdownGenerator 介面的 Generate 方法，依設定的格式在 SaveDir 寫入各輸出檔
 func (g *GeneratorImpl) Generate(info types.MarkdownInfo) error {
 	// 確保儲存目錄存在
 	if err := os.MkdirAll(info.SaveDir, constants.DirPermission); err != nil {
 		return errors.NewFileError(fmt.Sprintf("建立目錄失敗 %s", info.SaveDir), err)
 	}
 
-	// 設定 Markdown 檔案路徑
-	mdFileName := "README.md"
-	mdFilePath := filepath.Join(info.SaveDir, mdFileName)
+	imageFiles := imageFilesOf(info)
+	for _, r := range g.renderers() {
+		data, err := r.render(info, imageFiles)
+		if err != nil {
+			return errors.NewFileError(fmt.Sprintf("產生 %s 內容失敗", r.name()), err)
+		}
+		if err := os.WriteFile(filepath.Join(info.SaveDir, r.fileName()), data, constants.FilePermission); err != nil {
+			return errors.NewFileError(fmt.Sprintf("寫入 %s 檔案失敗", r.name()), err)
+		}
+	}
+	return nil
+}
+
+// imageFilesOf 回傳各圖片相對於 SaveDir 的路徑。crawler 已提供 ImageFiles（如圖片分類後的位置）時直接使用；
+// 否則檔名推導（含碰撞序號後綴）與 crawler 下載存檔共用同一邏輯，確保各格式的連結不失效
+func imageFilesOf(info types.MarkdownInfo) []string {
+	if len(info.ImageFiles) == len(info.ImageURLs) && len(info.ImageFiles) > 0 {
+		return info.ImageFiles
+	}
+	return fileutil.ImageFileNames(info.ImageURLs)
+}
+
+// MarkdownFileName 是文章目錄中 Markdown 輸出檔的檔名
+const MarkdownFileName = "README.md"
 
+// markdownRenderer 產生 README.md
+type markdownRenderer struct {
+	numberImages bool   // 每張圖片前加上「### 圖 N」小標題
+	inlinePushes bool   // 圖片列表之後加上「推文」段落
+	frontMatter  bool   // 開頭加上 YAML front matter
+	linkBase     string // 圖片連結的基底，為空時使用 ./檔名
+}
+
+func (markdownRenderer) name() string     { return "Markdown" }
+func (markdownRenderer) fileName() string { return MarkdownFileName }
+
+func (r markdownRenderer) render(info types.MarkdownInfo, imageFiles []string) ([]byte, error) {
 	// 建立一個 strings.Builder 來高效地建立 Markdown 內容
 	var builder strings.Builder
 
+	if r.frontMatter {
+		fmImages := imageFiles
+		if r.linkBase != "" {
+			fmImages = imageLinks(r.linkBase, info, imageFiles)
+		}
+		if err := writeFrontMatter(&builder, info, fmImages); err != nil {
+			return nil, err
+		}
+	}
+
 	// 寫入標題
 	fmt.Fprintf(&builder, "# %s\n\n", info.Title)
 
 	// 寫入文章資訊
 	fmt.Fprintf(&builder, "- **文章網址**: [%s](%s)\n", info.ArticleURL, info.ArticleURL)
-	fmt.Fprintf(&builder, "- **推文數量**: %d\n\n", info.PushCount)
+	fmt.Fprintf(&builder, "- **推文數量**: %d\n", info.PushCount)
+	// 推噓統計來自文章頁，未解析到任何推文時不輸出
+	if p := info.Pushes; p.Total() > 0 {
+		fmt.Fprintf(&builder, "- **推噓統計**: 推 %d / 噓 %d / → %d\n", p.PushCount, p.BooCount, p.NeutralCount)
+	}
+	if len(info.ImageURLs) > 0 {
+		fmt.Fprintf(&builder, "- **圖床統計**: %s\n", formatHosts(countHosts(info.ImageURLs)))
+	}
+	if info.Archive != "" {
+		fmt.Fprintf(&builder, "- **圖片封存**: [%s](%s)（`tar -xf %s` 解開後即可檢視下方圖片，或開啟 %s）\n",
+			info.Archive, fileLink(r.linkBase, info, info.Archive), info.Archive, HTMLFileName)
+	}
+	builder.WriteString("\n")
+
+	if info.Body != "" {
+		writeMarkdownBody(&builder, info.Body)
+	}
 
 	// 寫入圖片標題
 	builder.WriteString("## 圖片列表\n\n")
 
-	// 寫入圖片連結，檔名推導（含碰撞序號後綴）與 crawler 下載存檔
-	// 共用同一邏輯，確保連結不失效
-	for _, imgFileName := range fileutil.ImageFileNames(info.ImageURLs) {
+	for i, imgFileName := range imageFiles {
+		// 編號依 ImageURLs 順序，與下載存檔的檔名一一對應
+		if r.numberImages {
+			fmt.Fprintf(&builder, "### 圖 %d\n\n", i+1)
+		}
 		// Markdown 格式：![替代文字](圖片路徑)
-		fmt.Fprintf(&builder, "![%s](./%s)\n", imgFileName, imgFileName)
+		fmt.Fprintf(&builder, "![%s](%s)\n", imgFileName, fileLink(r.linkBase, info, imgFileName))
+		if r.numberImages {
+			builder.WriteString("\n")
+		}
 	}
-
-	// 將組合好的內容寫入檔案
-	err := os.WriteFile(mdFilePath, []byte(builder.String()), constants.FilePermission)
-	if err != nil {
-		return errors.NewFileError("寫入 Markdown 檔案失敗", err)
+	if r.inlinePushes {
+		writeMarkdownPushes(&builder, info.Comments)
 	}
+	return []byte(builder.String()), nil
+}
 
-	return nil
+// writeMarkdownBody 以引用區塊寫入「內文」段落，空行寫成單獨的「>」以維持同一個引用區塊
+func writeMarkdownBody(b *strings.Builder, body string) {
+	b.WriteString("## 內文\n\n")
+	for line := range strings.Lines(body) {
+		line = strings.TrimRight(line, "\n")
+		if line == "" {
+			b.WriteString(">\n")
+			continue
+		}
+		fmt.Fprintf(b, "> %s\n", line)
+	}
+	b.WriteString("\n")
 }
@@ -3,6 +3,7 @@ package markdown
 import (
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
 	"testing"
 
@@ -175,6 +176,60 @@ func TestGeneratorImpl_FileNameCollision(t *testing.T) {
 	}
 }
 
+// TestGeneratorImpl_ImageFiles 驗證 crawler 提供 ImageFiles（如圖片分類後的位置）時引用該路徑，
+// 數量與 ImageURLs 不一致時退回由 URL 推導檔名
+func TestGeneratorImpl_ImageFiles(t *testing.T) {
+	info := types.MarkdownInfo{
+		Title:      "分類測試",
+		ArticleURL: "https://www.ptt.cc/bbs/Beauty/M.1234567890.A.ABC.html",
+		ImageURLs:  []string{"https://i.imgur.com/a.jpg", "https://i.imgur.com/b.png"},
+		ImageFiles: []string{"landscape/a.jpg", "b.png"},
+		SaveDir:    t.TempDir(),
+	}
+	if err := NewGenerator().Generate(info); err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+	content := readGeneratedContent(t, filepath.Join(info.SaveDir, "README.md"))
+	if want := "![landscape/a.jpg](./landscape/a.jpg)\n![b.png](./b.png)\n"; !strings.HasSuffix(content, want) {
+		t.Errorf("圖片列表 = %q, want suffix %q", content, want)
+	}
+
+	info.ImageFiles = info.ImageFiles[:1]
+	if got := imageFilesOf(info); !slices.Equal(got, []string{"a.jpg", "b.png"}) {
+		t.Errorf("imageFilesOf() = %v, want 由 URL 推導的檔名", got)
+	}
+}
+
+func TestGeneratorImpl_NumberedImages(t *testing.T) {
+	info := types.MarkdownInfo{
+		Title:      "編號測試",
+		ArticleURL: "https://www.ptt.cc/bbs/Beauty/M.1234567890.A.ABC.html",
+		ImageURLs:  []string{"https://i.imgur.com/a.jpg", "https://i.imgur.com/b.png"},
+	}
+
+	tests := []struct {
+		name    string
+		enabled bool
+		want    string
+	}{
+		{"預設不編號", false, "## 圖片列表\n\n![a.jpg](./a.jpg)\n![b.png](./b.png)\n"},
+		{"啟用後每張圖前加上小標題", true, "## 圖片列表\n\n### 圖 1\n\n![a.jpg](./a.jpg)\n\n### 圖 2\n\n![b.png](./b.png)\n\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			info.SaveDir = t.TempDir()
+			if err := NewGenerator(WithNumberedImages(tt.enabled)).Generate(info); err != nil {
+				t.Fatalf("Generate failed: %v", err)
+			}
+			content := readGeneratedContent(t, filepath.Join(info.SaveDir, "README.md"))
+			if !strings.HasSuffix(content, tt.want) {
+				t.Errorf("圖片列表 = %q, want suffix %q", content, tt.want)
+			}
+		})
+	}
+}
+
 func TestNewGenerator(t *testing.T) {
 	generator := NewGenerator()
 	if generator == nil {
@@ -0,0 +1,52 @@
+package markdown
+
+import (
+	"cmp"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// UnknownHost 是無法從 URL 解析出 host 的圖片所歸入的分組
+const UnknownHost = "(unknown)"
+
+// ManifestHost 是單一圖床在文章中的圖片數
+type ManifestHost struct {
+	Host   string `json:"host"`   // 圖片 URL 的 host（小寫），無法解析時為 UnknownHost
+	Images int    `json:"images"` // 該 host 的圖片數
+}
+
+// countHosts 依圖片 URL 的 host 分組計數，依圖片數遞減排序，數量相同時依 host 排序。
+// host 比對不分大小寫；URL 無法解析或沒有 host（如相對路徑）時歸入 UnknownHost。
+func countHosts(imageURLs []string) []ManifestHost {
+	if len(imageURLs) == 0 {
+		return nil
+	}
+	counts := make(map[string]int)
+	for _, imgURL := range imageURLs {
+		host := UnknownHost
+		if u, err := url.Parse(imgURL); err == nil && u.Hostname() != "" {
+			host = strings.ToLower(u.Hostname())
+		}
+		counts[host]++
+	}
+
+	hosts := make([]ManifestHost, 0, len(counts))
+	for host, n := range counts {
+		hosts = append(hosts, ManifestHost{Host: host, Images: n})
+	}
+	slices.SortFunc(hosts, func(a, b ManifestHost) int {
+		return cmp.Or(cmp.Compare(b.Images, a.Images), cmp.Compare(a.Host, b.Host))
+	})
+	return hosts
+}
+
+// formatHosts 將圖床統計寫成「i.imgur.com 3 張、example.com 1 張」
+func formatHosts(hosts []ManifestHost) string {
+	parts := make([]string, len(hosts))
+	for i, h := range hosts {
+		parts[i] = h.Host + " " + strconv.Itoa(h.Images) + " 張"
+	}
+	return strings.Join(parts, "、")
+}
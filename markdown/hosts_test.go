@@ -0,0 +1,58 @@
+package markdown
+
+import (
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/twtrubiks/ptt-spider-go/types"
+)
+
+func TestCountHosts(t *testing.T) {
+	got := countHosts([]string{
+		"https://i.imgur.com/a.jpg",
+		"https://example.com/b.png",
+		"https://I.IMGUR.com/c.jpg", // host 不分大小寫
+		"https://pbs.twimg.com/d.jpg",
+		"https://i.imgur.com:443/e.jpg", // 不含連接埠
+		"://bad-url",                    // 無法解析
+		"relative/f.jpg",                // 沒有 host
+	})
+	want := []ManifestHost{
+		{Host: "i.imgur.com", Images: 3},
+		{Host: UnknownHost, Images: 2},
+		{Host: "example.com", Images: 1},
+		{Host: "pbs.twimg.com", Images: 1},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("countHosts() = %+v, want %+v", got, want)
+	}
+
+	if got := countHosts(nil); got != nil {
+		t.Errorf("countHosts(nil) = %+v, want nil", got)
+	}
+}
+
+func TestGenerateHostStats(t *testing.T) {
+	info := types.MarkdownInfo{
+		Title:     "[正妹] 圖床",
+		ImageURLs: []string{"https://i.imgur.com/a.jpg", "https://example.com/b.jpg", "https://i.imgur.com/c.jpg"},
+		SaveDir:   t.TempDir(),
+	}
+	if err := NewGenerator().Generate(info); err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+	md := readGeneratedContent(t, filepath.Join(info.SaveDir, MarkdownFileName))
+	checkContentContains(t, md, "- **圖床統計**: i.imgur.com 2 張、example.com 1 張\n",
+		"README.md should list image counts per host")
+
+	// 沒有圖片時不輸出圖床統計
+	info.ImageURLs = nil
+	if err := NewGenerator().Generate(info); err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+	if md := readGeneratedContent(t, filepath.Join(info.SaveDir, MarkdownFileName)); strings.Contains(md, "圖床統計") {
+		t.Error("README.md should not contain host stats without images")
+	}
+}
@@ -0,0 +1,124 @@
+package markdown
+
+import (
+	"bytes"
+	"fmt"
+	"html/template"
+	"path/filepath"
+
+	"github.com/twtrubiks/ptt-spider-go/types"
+)
+
+// HTMLFileName 是 HTML 圖片牆的檔名，可直接以瀏覽器開啟
+const HTMLFileName = "index.html"
+
+// htmlTemplate 以 CSS grid 排列圖片縮圖，欄數隨視窗寬度自動調整；點擊縮圖開啟原圖
+var htmlTemplate = template.Must(template.New("index").Parse(`<!DOCTYPE html>
+<html lang="zh-Hant">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>{{.Title}}</title>
+<style>
+body { margin: 0 auto; max-width: 1200px; padding: 16px; font-family: sans-serif; }
+.gallery { display: grid; grid-template-columns: repeat(auto-fill, minmax(200px, 1fr)); gap: 12px; }
+.gallery figure { margin: 0; }
+.gallery img { width: 100%; aspect-ratio: 1; object-fit: cover; border-radius: 4px; }
+.gallery figcaption { text-align: center; font-size: 0.9em; }
+.pushes { list-style: none; padding: 0; }
+.pushes li { padding: 2px 0; border-bottom: 1px solid #eee; }
+.pushes .time { color: #888; font-size: 0.85em; }
+</style>
+</head>
+<body>
+<h1>{{.Title}}</h1>
+<ul>
+<li><strong>文章網址</strong>: <a href="{{.ArticleURL}}">{{.ArticleURL}}</a></li>
+<li><strong>推文數量</strong>: {{.PushCount}}</li>
+{{- with .Pushes}}{{if .Total}}
+<li><strong>推噓統計</strong>: 推 {{.PushCount}} / 噓 {{.BooCount}} / → {{.NeutralCount}}</li>
+{{- end}}{{end}}
+</ul>
+<h2>圖片列表</h2>
+<div class="gallery">
+{{- range .Images}}
+<figure>
+{{- if .Data}}
+<a href="{{.Data}}"><img src="{{.Data}}" alt="{{.File}}" loading="lazy"></a>
+{{- else}}
+<a href="{{.Link}}"><img src="{{.Link}}" alt="{{.File}}" loading="lazy"></a>
+{{- end}}
+{{- if $.NumberImages}}
+<figcaption>圖 {{.Index}}</figcaption>
+{{- end}}
+</figure>
+{{- end}}
+</div>
+{{- if .Comments}}
+<h2>推文</h2>
+<ul class="pushes">
+{{- range .Comments}}
+<li>{{.Tag}} <strong>{{.Author}}</strong>: {{.Content}}{{if .Time}} <span class="time">{{.Time}}</span>{{end}}</li>
+{{- end}}
+</ul>
+{{- end}}
+</body>
+</html>
+`))
+
+// htmlImage 是模板中的單張圖片
+type htmlImage struct {
+	Index int          // 編號（從 1 開始）
+	File  string       // 文章目錄中的檔名
+	Link  string       // 引用 File 的連結（依 output.linkBase，預設為 ./檔名）
+	Data  template.URL // 圖片位於封存檔時內嵌的 data URI，為空時連結 File
+}
+
+// htmlRenderer 產生 index.html 圖片牆
+type htmlRenderer struct {
+	numberImages bool   // 每張縮圖下方標示「圖 N」
+	inlinePushes bool   // 圖片牆之後加上「推文」列表
+	linkBase     string // 圖片連結的基底，為空時使用 ./檔名
+}
+
+func (htmlRenderer) name() string     { return "HTML" }
+func (htmlRenderer) fileName() string { return HTMLFileName }
+
+func (r htmlRenderer) render(info types.MarkdownInfo, imageFiles []string) ([]byte, error) {
+	// 打包模式下圖片不是個別檔案，改為內嵌封存檔中的圖片，不必解開即可檢視
+	var packed map[string][]byte
+	if info.Archive != "" {
+		var err error
+		if packed, err = readArchive(filepath.Join(info.SaveDir, info.Archive)); err != nil {
+			return nil, fmt.Errorf("讀取圖片封存檔失敗: %w", err)
+		}
+	}
+
+	images := make([]htmlImage, len(imageFiles))
+	for i, f := range imageFiles {
+		images[i] = htmlImage{Index: i + 1, File: f, Link: fileLink(r.linkBase, info, f)}
+		if data, ok := packed[f]; ok {
+			images[i].Data = dataURI(data)
+		}
+	}
+
+	var comments []types.Push
+	if r.inlinePushes {
+		comments = info.Comments
+	}
+
+	var buf bytes.Buffer
+	err := htmlTemplate.Execute(&buf, struct {
+		Title        string
+		ArticleURL   string
+		PushCount    int
+		Pushes       types.PushStats
+		NumberImages bool
+		Images       []htmlImage
+		Comments     []types.Push // 未啟用 inlinePushes 時為 nil
+	}{info.Title, info.ArticleURL, info.PushCount, info.Pushes, r.numberImages, images, comments})
+	if err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
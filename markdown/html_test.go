@@ -0,0 +1,129 @@
+package markdown
+
+import (
+	"archive/tar"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/twtrubiks/ptt-spider-go/types"
+)
+
+func TestGeneratorImpl_HTML(t *testing.T) {
+	info := types.MarkdownInfo{
+		Title:      "[正妹] <測試> & 圖片牆",
+		ArticleURL: "https://www.ptt.cc/bbs/Beauty/M.1234567890.A.ABC.html",
+		PushCount:  42,
+		ImageURLs:  []string{"https://i.imgur.com/a.jpg", "https://example.com/x/a.jpg"},
+		SaveDir:    t.TempDir(),
+	}
+	if err := NewGenerator(WithFormats(FormatHTML), WithNumberedImages(true)).Generate(info); err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+
+	if _, err := os.Stat(filepath.Join(info.SaveDir, "README.md")); !os.IsNotExist(err) {
+		t.Errorf("只輸出 HTML 時不應產生 README.md, err = %v", err)
+	}
+	content := readGeneratedContent(t, filepath.Join(info.SaveDir, HTMLFileName))
+	for _, want := range []string{
+		`<meta name="viewport"`,
+		"grid-template-columns: repeat(auto-fill",
+		// 標題經過 HTML 跳脫
+		"<h1>[正妹] &lt;測試&gt; &amp; 圖片牆</h1>",
+		`<a href="https://www.ptt.cc/bbs/Beauty/M.1234567890.A.ABC.html">`,
+		"<strong>推文數量</strong>: 42",
+		// 檔名與下載存檔相同（含碰撞序號後綴），點擊縮圖開啟原圖
+		`<a href="./a.jpg"><img src="./a.jpg" alt="a.jpg" loading="lazy"></a>`,
+		`<a href="./a_2.jpg"><img src="./a_2.jpg" alt="a_2.jpg" loading="lazy"></a>`,
+		"<figcaption>圖 2</figcaption>",
+	} {
+		if !strings.Contains(content, want) {
+			t.Errorf("index.html 缺少 %q\n%s", want, content)
+		}
+	}
+}
+
+// TestGeneratorImpl_Archive 驗證打包模式下 index.html 內嵌封存檔中的圖片，README.md 標示封存檔
+func TestGeneratorImpl_Archive(t *testing.T) {
+	info := types.MarkdownInfo{
+		Title:     "打包",
+		ImageURLs: []string{"https://i.imgur.com/a.png", "https://i.imgur.com/missing.jpg"},
+		Archive:   ImageArchiveFileName,
+		SaveDir:   t.TempDir(),
+	}
+	file, err := os.Create(filepath.Join(info.SaveDir, ImageArchiveFileName))
+	if err != nil {
+		t.Fatal(err)
+	}
+	tw := tar.NewWriter(file)
+	png := "\x89PNG\r\n\x1a\n"
+	if err := tw.WriteHeader(&tar.Header{Name: "a.png", Mode: 0o644, Size: int64(len(png))}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := tw.Write([]byte(png)); err != nil {
+		t.Fatal(err)
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := file.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := NewGenerator(WithFormats(FormatMarkdown, FormatHTML)).Generate(info); err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+
+	content := readGeneratedContent(t, filepath.Join(info.SaveDir, HTMLFileName))
+	for _, want := range []string{
+		`<img src="data:image/png;base64,iVBORw0KGgo=" alt="a.png" loading="lazy">`,
+		// 封存檔中沒有的圖片（下載失敗）沿用檔名連結
+		`<img src="./missing.jpg" alt="missing.jpg" loading="lazy">`,
+	} {
+		if !strings.Contains(content, want) {
+			t.Errorf("index.html 缺少 %q\n%s", want, content)
+		}
+	}
+	readme := readGeneratedContent(t, filepath.Join(info.SaveDir, MarkdownFileName))
+	if !strings.Contains(readme, "- **圖片封存**: [images.tar](./images.tar)") {
+		t.Errorf("README.md 缺少封存檔連結\n%s", readme)
+	}
+
+	// 封存檔不存在時無法產生可檢視的 index.html
+	info.SaveDir = t.TempDir()
+	if err := NewGenerator(WithFormats(FormatHTML)).Generate(info); err == nil {
+		t.Error("封存檔不存在時 Generate 應回傳錯誤")
+	}
+}
+
+func TestGeneratorImpl_Formats(t *testing.T) {
+	tests := []struct {
+		name      string
+		opts      []GeneratorOption
+		wantFiles []string
+		noFiles   []string
+	}{
+		{"預設只輸出 Markdown", nil, []string{"README.md"}, []string{HTMLFileName, ManifestFileName}},
+		{"同時輸出 Markdown 與 HTML", []GeneratorOption{WithFormats(FormatMarkdown, FormatHTML)}, []string{"README.md", HTMLFileName}, []string{ManifestFileName}},
+		{"HTML 搭配 manifest", []GeneratorOption{WithFormats(FormatHTML), WithManifest(true)}, []string{HTMLFileName, ManifestFileName}, []string{"README.md"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			info := types.MarkdownInfo{Title: "格式", ImageURLs: []string{"https://i.imgur.com/a.jpg"}, SaveDir: t.TempDir()}
+			if err := NewGenerator(tt.opts...).Generate(info); err != nil {
+				t.Fatalf("Generate failed: %v", err)
+			}
+			for _, name := range tt.wantFiles {
+				if _, err := os.Stat(filepath.Join(info.SaveDir, name)); err != nil {
+					t.Errorf("應產生 %s: %v", name, err)
+				}
+			}
+			for _, name := range tt.noFiles {
+				if _, err := os.Stat(filepath.Join(info.SaveDir, name)); !os.IsNotExist(err) {
+					t.Errorf("不應產生 %s, err = %v", name, err)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,172 @@
+package markdown
+
+import (
+	"bytes"
+	"fmt"
+	"html/template"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/twtrubiks/ptt-spider-go/constants"
+	"github.com/twtrubiks/ptt-spider-go/errors"
+	"github.com/twtrubiks/ptt-spider-go/types"
+)
+
+// IndexMarkdownFileName 是看板目錄中文章總覽的 Markdown 檔名
+const IndexMarkdownFileName = "index.md"
+
+// indexEntry 是總覽中的一篇文章，路徑皆為相對於看板目錄、已跳脫的連結
+type indexEntry struct {
+	Title     string
+	PushCount int
+	Images    int
+	Link      string // 文章輸出檔（README.md 或 index.html）
+	Thumbnail string // 第一張圖片，沒有圖片時為空字串
+}
+
+// GenerateIndex 將 articles 增量合併進 dir 既有的總覽（index.json，舊版只有 index.md 時由其轉換），
+// 再依設定的格式重新產生 index.md 及/或 index.html：每篇文章列出標題、推文數、文章目錄連結與第一張圖片縮圖，
+// 依推文數由高到低排列。同一篇文章以本次資料為準，目錄已刪除的既有文章不再列出。
+func (g *GeneratorImpl) GenerateIndex(dir string, articles []types.MarkdownInfo) error {
+	if dir != "" {
+		if err := os.MkdirAll(dir, constants.DirPermission); err != nil {
+			return errors.NewFileError(fmt.Sprintf("建立目錄失敗 %s", dir), err)
+		}
+	}
+
+	existing, err := loadIndexData(dir)
+	if err != nil {
+		return err
+	}
+	merged := mergeIndexArticles(dir, existing, newIndexArticles(articles))
+	prefix := boardLinkPrefix(g.linkBase, articles)
+
+	for _, f := range g.outputFormats() {
+		var (
+			name     string
+			fileName string
+			data     []byte
+		)
+		switch f {
+		case FormatMarkdown:
+			name, fileName = "Markdown 總覽", IndexMarkdownFileName
+			data = renderMarkdownIndex(dir, indexEntries(merged, prefix, markdownRenderer{}.fileName()))
+		case FormatHTML:
+			name, fileName = "HTML 總覽", HTMLFileName
+			data, err = renderHTMLIndex(dir, indexEntries(merged, prefix, HTMLFileName))
+		default:
+			continue
+		}
+		if err != nil {
+			return errors.NewFileError(fmt.Sprintf("產生 %s 內容失敗", name), err)
+		}
+		if err := os.WriteFile(filepath.Join(dir, fileName), data, constants.FilePermission); err != nil {
+			return errors.NewFileError(fmt.Sprintf("寫入 %s 檔案失敗", name), err)
+		}
+	}
+	return writeIndexData(dir, merged)
+}
+
+// indexEntries 將總覽資料轉為總覽項目，prefix 為文章目錄連結的前綴（預設 ./），link 為文章目錄中要連結的輸出檔
+func indexEntries(articles []IndexArticle, prefix, link string) []indexEntry {
+	entries := make([]indexEntry, len(articles))
+	for i, a := range articles {
+		entries[i] = indexEntry{
+			Title:     a.Title,
+			PushCount: a.PushCount,
+			Images:    a.Images,
+			Link:      prefixedLink(prefix, a.Dir, link),
+		}
+		if a.Thumbnail != "" {
+			entries[i].Thumbnail = prefixedLink(prefix, a.Dir, a.Thumbnail)
+		}
+	}
+	return entries
+}
+
+// relativeLink 組出 ./folder/file 的相對連結，各段以 URL 跳脫（目錄名常含空白與中括號）；
+// file 可含以 / 分隔的子目錄（如分類後的 landscape/a.jpg）
+func relativeLink(folder, file string) string {
+	return prefixedLink("./", folder, file)
+}
+
+// prefixedLink 組出 prefix + folder/file 的連結，各段以 URL 跳脫
+func prefixedLink(prefix, folder, file string) string {
+	return prefix + url.PathEscape(folder) + "/" + escapePath(file)
+}
+
+// indexTitle 是總覽的標題，以看板目錄名稱命名
+func indexTitle(dir string) string {
+	if dir == "" {
+		return "文章總覽"
+	}
+	return filepath.Base(dir) + " 文章總覽"
+}
+
+// markdownTableEscaper 跳脫會破壞 Markdown 表格的字元
+var markdownTableEscaper = strings.NewReplacer("|", `\|`, "\n", " ")
+
+// renderMarkdownIndex 以表格列出所有文章，縮圖以 <img> 指定寬度
+func renderMarkdownIndex(dir string, entries []indexEntry) []byte {
+	var builder strings.Builder
+	fmt.Fprintf(&builder, "# %s\n\n", indexTitle(dir))
+	fmt.Fprintf(&builder, "共 %d 篇文章\n\n", len(entries))
+	builder.WriteString("| 縮圖 | 標題 | 推文數 | 圖片數 |\n")
+	builder.WriteString("| --- | --- | --- | --- |\n")
+	for _, e := range entries {
+		thumbnail := ""
+		if e.Thumbnail != "" {
+			thumbnail = fmt.Sprintf(`<img src="%s" width="120">`, e.Thumbnail)
+		}
+		fmt.Fprintf(&builder, "| %s | [%s](%s) | %d | %d |\n",
+			thumbnail, markdownTableEscaper.Replace(e.Title), e.Link, e.PushCount, e.Images)
+	}
+	return []byte(builder.String())
+}
+
+// htmlIndexTemplate 以 CSS grid 排列每篇文章的第一張圖片與標題，點擊開啟該文章的圖片牆
+var htmlIndexTemplate = template.Must(template.New("board").Parse(`<!DOCTYPE html>
+<html lang="zh-Hant">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>{{.Title}}</title>
+<style>
+body { margin: 0 auto; max-width: 1200px; padding: 16px; font-family: sans-serif; }
+.articles { display: grid; grid-template-columns: repeat(auto-fill, minmax(200px, 1fr)); gap: 12px; }
+.articles a { color: inherit; text-decoration: none; }
+.articles img, .articles .empty { width: 100%; aspect-ratio: 1; object-fit: cover; border-radius: 4px; background: #eee; }
+.articles figcaption { font-size: 0.9em; }
+</style>
+</head>
+<body>
+<h1>{{.Title}}</h1>
+<p>共 {{len .Articles}} 篇文章</p>
+<div class="articles">
+{{- range .Articles}}
+<figure>
+<a href="{{.Link}}">
+{{- if .Thumbnail}}<img src="{{.Thumbnail}}" alt="{{.Title}}" loading="lazy">{{else}}<div class="empty"></div>{{end -}}
+<figcaption>{{.Title}}（推文 {{.PushCount}}，{{.Images}} 張圖片）</figcaption>
+</a>
+</figure>
+{{- end}}
+</div>
+</body>
+</html>
+`))
+
+// renderHTMLIndex 產生看板的 HTML 總覽
+func renderHTMLIndex(dir string, entries []indexEntry) ([]byte, error) {
+	var buf bytes.Buffer
+	err := htmlIndexTemplate.Execute(&buf, struct {
+		Title    string
+		Articles []indexEntry
+	}{indexTitle(dir), entries})
+	if err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
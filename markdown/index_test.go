@@ -0,0 +1,204 @@
+package markdown
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/twtrubiks/ptt-spider-go/types"
+)
+
+func indexArticles(dir string) []types.MarkdownInfo {
+	return []types.MarkdownInfo{
+		{Title: "[正妹] 低推文", PushCount: 3, ImageURLs: []string{"https://i.imgur.com/low.jpg"}, SaveDir: filepath.Join(dir, "[正妹] 低推文_3")},
+		{Title: "[正妹] A|B", PushCount: 99, ImageURLs: []string{"https://i.imgur.com/a.jpg", "https://i.imgur.com/b.jpg"}, SaveDir: filepath.Join(dir, "[正妹] A|B_99")},
+		{Title: "[公告] 沒有圖", PushCount: 10, SaveDir: filepath.Join(dir, "[公告] 沒有圖_10")},
+	}
+}
+
+func TestGeneratorImpl_GenerateIndexMarkdown(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "Beauty")
+	if err := NewGenerator().GenerateIndex(dir, indexArticles(dir)); err != nil {
+		t.Fatalf("GenerateIndex failed: %v", err)
+	}
+
+	content := readGeneratedContent(t, filepath.Join(dir, IndexMarkdownFileName))
+	want := "# Beauty 文章總覽\n\n" +
+		"共 3 篇文章\n\n" +
+		"| 縮圖 | 標題 | 推文數 | 圖片數 |\n" +
+		"| --- | --- | --- | --- |\n" +
+		// 依推文數由高到低排列，連結各段經 URL 跳脫，標題中的 | 需跳脫
+		`| <img src="./%5B%E6%AD%A3%E5%A6%B9%5D%20A%7CB_99/a.jpg" width="120"> | [[正妹] A\|B](./%5B%E6%AD%A3%E5%A6%B9%5D%20A%7CB_99/README.md) | 99 | 2 |` + "\n" +
+		`|  | [[公告] 沒有圖](./%5B%E5%85%AC%E5%91%8A%5D%20%E6%B2%92%E6%9C%89%E5%9C%96_10/README.md) | 10 | 0 |` + "\n" +
+		`| <img src="./%5B%E6%AD%A3%E5%A6%B9%5D%20%E4%BD%8E%E6%8E%A8%E6%96%87_3/low.jpg" width="120"> | [[正妹] 低推文](./%5B%E6%AD%A3%E5%A6%B9%5D%20%E4%BD%8E%E6%8E%A8%E6%96%87_3/README.md) | 3 | 1 |` + "\n"
+	if content != want {
+		t.Errorf("index.md =\n%s\nwant\n%s", content, want)
+	}
+	if _, err := os.Stat(filepath.Join(dir, HTMLFileName)); !os.IsNotExist(err) {
+		t.Errorf("只輸出 Markdown 時不應產生 index.html, err = %v", err)
+	}
+}
+
+func TestIndexEntries_ClassifiedThumbnail(t *testing.T) {
+	articles := []types.MarkdownInfo{{
+		Title:      "分類",
+		ImageURLs:  []string{"https://i.imgur.com/a.jpg"},
+		ImageFiles: []string{"landscape/a b.jpg"},
+		SaveDir:    filepath.Join("Beauty", "分類_1"),
+	}}
+	got := indexEntries(newIndexArticles(articles), "./", MarkdownFileName)[0].Thumbnail
+	// 子目錄的 / 保留，各段分別跳脫
+	if want := "./%E5%88%86%E9%A1%9E_1/landscape/a%20b.jpg"; got != want {
+		t.Errorf("Thumbnail = %q, want %q", got, want)
+	}
+}
+
+func TestIndexEntries_ArchivedNoThumbnail(t *testing.T) {
+	articles := []types.MarkdownInfo{{
+		Title:     "打包",
+		ImageURLs: []string{"https://i.imgur.com/a.jpg"},
+		Archive:   ImageArchiveFileName,
+		SaveDir:   filepath.Join("Beauty", "打包_1"),
+	}}
+	e := indexEntries(newIndexArticles(articles), "./", MarkdownFileName)[0]
+	if e.Thumbnail != "" || e.Images != 1 {
+		t.Errorf("entry = %+v, want 無縮圖、圖片數 1（圖片位於封存檔內）", e)
+	}
+}
+
+func TestGeneratorImpl_GenerateIndexHTML(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "Beauty")
+	if err := NewGenerator(WithFormats(FormatMarkdown, FormatHTML)).GenerateIndex(dir, indexArticles(dir)); err != nil {
+		t.Fatalf("GenerateIndex failed: %v", err)
+	}
+
+	if _, err := os.Stat(filepath.Join(dir, IndexMarkdownFileName)); err != nil {
+		t.Errorf("both 時應同時產生 index.md: %v", err)
+	}
+	content := readGeneratedContent(t, filepath.Join(dir, HTMLFileName))
+	for _, want := range []string{
+		"<h1>Beauty 文章總覽</h1>",
+		"共 3 篇文章",
+		// HTML 總覽連結到各文章的 index.html 圖片牆
+		`<a href="./%5B%E6%AD%A3%E5%A6%B9%5D%20A%7CB_99/index.html">`,
+		`<img src="./%5B%E6%AD%A3%E5%A6%B9%5D%20A%7CB_99/a.jpg" alt="[正妹] A|B" loading="lazy">`,
+		`<div class="empty"></div>`,
+		"[正妹] 低推文（推文 3，1 張圖片）",
+	} {
+		if !strings.Contains(content, want) {
+			t.Errorf("index.html 缺少 %q\n%s", want, content)
+		}
+	}
+	if strings.Index(content, "A|B") > strings.Index(content, "低推文") {
+		t.Error("文章應依推文數由高到低排列")
+	}
+}
+
+func TestGeneratorImpl_GenerateIndexMerge(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "Beauty")
+	g := NewGenerator()
+	first := []types.MarkdownInfo{
+		{Title: "舊文章", ArticleURL: "https://www.ptt.cc/bbs/Beauty/M.1.A.AAA.html", PushCount: 5, SaveDir: filepath.Join(dir, "舊文章_5")},
+		{Title: "會更新", ArticleURL: "https://www.ptt.cc/bbs/Beauty/M.2.A.BBB.html", PushCount: 1, SaveDir: filepath.Join(dir, "會更新_1")},
+		{Title: "已刪除", ArticleURL: "https://www.ptt.cc/bbs/Beauty/M.3.A.CCC.html", PushCount: 9, SaveDir: filepath.Join(dir, "已刪除_9")},
+	}
+	if err := g.GenerateIndex(dir, first); err != nil {
+		t.Fatalf("GenerateIndex failed: %v", err)
+	}
+	// 文章目錄由 Generate 建立；已刪除_9 模擬使用者在兩次執行之間刪除的目錄
+	for _, name := range []string{"舊文章_5", "會更新_1"} {
+		if err := os.MkdirAll(filepath.Join(dir, name), 0o755); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	second := []types.MarkdownInfo{
+		// 同一篇文章推文數增加，目錄名稱隨之改變
+		{Title: "會更新", ArticleURL: "https://www.ptt.cc/bbs/Beauty/M.2.A.BBB.html", PushCount: 20, SaveDir: filepath.Join(dir, "會更新_20")},
+		{Title: "新文章", ArticleURL: "https://www.ptt.cc/bbs/Beauty/M.4.A.DDD.html", PushCount: 3, SaveDir: filepath.Join(dir, "新文章_3")},
+	}
+	if err := g.GenerateIndex(dir, second); err != nil {
+		t.Fatalf("GenerateIndex failed: %v", err)
+	}
+
+	data, err := os.ReadFile(filepath.Join(dir, IndexDataFileName))
+	if err != nil {
+		t.Fatalf("讀取 index.json 失敗: %v", err)
+	}
+	var idx IndexData
+	if err := json.Unmarshal(data, &idx); err != nil {
+		t.Fatalf("解析 index.json 失敗: %v", err)
+	}
+	if idx.Version != IndexDataVersion {
+		t.Errorf("version = %d, want %d", idx.Version, IndexDataVersion)
+	}
+	var dirs []string
+	for _, a := range idx.Articles {
+		dirs = append(dirs, a.Dir)
+	}
+	if want := []string{"會更新_20", "舊文章_5", "新文章_3"}; !slices.Equal(dirs, want) {
+		t.Errorf("合併後的文章 = %v, want %v", dirs, want)
+	}
+	if content := readGeneratedContent(t, filepath.Join(dir, IndexMarkdownFileName)); !strings.Contains(content, "共 3 篇文章") {
+		t.Errorf("index.md 應列出合併後的 3 篇文章:\n%s", content)
+	}
+}
+
+func TestGeneratorImpl_GenerateIndexLegacyMarkdown(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "Beauty")
+	// 先前版本只輸出 index.md
+	old := indexArticles(dir)
+	if err := NewGenerator().GenerateIndex(dir, old); err != nil {
+		t.Fatalf("GenerateIndex failed: %v", err)
+	}
+	if err := os.Remove(filepath.Join(dir, IndexDataFileName)); err != nil {
+		t.Fatal(err)
+	}
+	for _, a := range old {
+		if err := os.MkdirAll(a.SaveDir, 0o755); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	got, err := loadIndexData(dir)
+	if err != nil {
+		t.Fatalf("loadIndexData failed: %v", err)
+	}
+	want := []IndexArticle{
+		{Title: "[正妹] A|B", PushCount: 99, Images: 2, Dir: "[正妹] A|B_99", Thumbnail: "a.jpg"},
+		{Title: "[公告] 沒有圖", PushCount: 10, Images: 0, Dir: "[公告] 沒有圖_10"},
+		{Title: "[正妹] 低推文", PushCount: 3, Images: 1, Dir: "[正妹] 低推文_3", Thumbnail: "low.jpg"},
+	}
+	if !slices.Equal(got, want) {
+		t.Errorf("由 index.md 轉換 = %+v\nwant %+v", got, want)
+	}
+
+	// 重爬同一目錄的文章時以本次資料取代舊列，不重複列出
+	again := []types.MarkdownInfo{{Title: "[公告] 沒有圖", ArticleURL: "https://www.ptt.cc/bbs/Beauty/M.5.A.EEE.html", PushCount: 10, SaveDir: filepath.Join(dir, "[公告] 沒有圖_10")}}
+	if err := NewGenerator().GenerateIndex(dir, again); err != nil {
+		t.Fatalf("GenerateIndex failed: %v", err)
+	}
+	if content := readGeneratedContent(t, filepath.Join(dir, IndexMarkdownFileName)); !strings.Contains(content, "共 3 篇文章") {
+		t.Errorf("index.md 應列出 3 篇文章:\n%s", content)
+	}
+}
+
+func TestGeneratorImpl_GenerateIndexNewerVersion(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, IndexDataFileName)
+	newer := `{"version": 99, "articles": []}`
+	if err := os.WriteFile(path, []byte(newer), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	err := NewGenerator().GenerateIndex(dir, indexArticles(dir))
+	if err == nil || !strings.Contains(err.Error(), "版本 99") {
+		t.Fatalf("較新版本的 index.json 應回傳錯誤，err = %v", err)
+	}
+	// 不覆寫看不懂的檔案
+	if content := readGeneratedContent(t, path); content != newer {
+		t.Errorf("index.json 不應被覆寫: %s", content)
+	}
+}
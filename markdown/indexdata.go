@@ -0,0 +1,194 @@
+package markdown
+
+import (
+	"cmp"
+	"encoding/json"
+	stderrors "errors"
+	"fmt"
+	"io/fs"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/twtrubiks/ptt-spider-go/constants"
+	"github.com/twtrubiks/ptt-spider-go/errors"
+	"github.com/twtrubiks/ptt-spider-go/types"
+)
+
+// IndexDataFileName 是看板目錄中總覽資料的檔名，記錄歷次執行累積的文章，供下次執行增量合併
+const IndexDataFileName = "index.json"
+
+// IndexDataVersion 是目前寫入的 index.json 格式版本。
+// 新增欄位時沿用同一版本（舊檔缺少的欄位為零值）；欄位意義改變時才遞增版本並在 loadIndexData 轉換舊格式。
+const IndexDataVersion = 1
+
+// IndexData 是 index.json 的內容
+type IndexData struct {
+	Version  int            `json:"version"`  // 格式版本
+	Articles []IndexArticle `json:"articles"` // 依推文數由高到低排列的文章
+}
+
+// IndexArticle 是總覽中一篇文章的資料，路徑皆為未跳脫的原始名稱
+type IndexArticle struct {
+	Title     string `json:"title"`               // 文章標題
+	URL       string `json:"url,omitempty"`       // 原始文章 URL，由舊版 index.md 轉換而來時為空
+	PushCount int    `json:"pushCount"`           // 推文數
+	Images    int    `json:"images"`              // 圖片數
+	Dir       string `json:"dir"`                 // 文章目錄名稱（相對於看板目錄）
+	Thumbnail string `json:"thumbnail,omitempty"` // 第一張圖片相對於文章目錄的路徑，沒有可引用的縮圖時為空
+}
+
+// key 是合併時判斷同一篇文章的依據：優先使用文章 URL，舊版資料沒有 URL 時使用目錄名稱
+func (a IndexArticle) key() string {
+	if a.URL != "" {
+		return a.URL
+	}
+	return "dir:" + a.Dir
+}
+
+// newIndexArticles 將本次產生的文章轉為總覽資料
+func newIndexArticles(articles []types.MarkdownInfo) []IndexArticle {
+	records := make([]IndexArticle, len(articles))
+	for i, a := range articles {
+		records[i] = IndexArticle{
+			Title:     a.Title,
+			URL:       a.ArticleURL,
+			PushCount: a.PushCount,
+			Images:    len(a.ImageURLs),
+			Dir:       filepath.Base(a.SaveDir),
+		}
+		// 打包模式的圖片位於封存檔內，沒有可引用的縮圖
+		if len(a.ImageURLs) > 0 && a.Archive == "" {
+			// 路徑與文章輸出檔引用的相同（含碰撞序號後綴與分類子目錄）
+			records[i].Thumbnail = imageFilesOf(a)[0]
+		}
+	}
+	return records
+}
+
+// mergeIndexArticles 將本次的文章合併進既有的總覽：同一篇文章（URL 相同，或目錄相同）以本次資料為準，
+// 既有文章的目錄已不存在於 dir 時移除，結果依推文數由高到低、同推文數依目錄名稱排列
+func mergeIndexArticles(dir string, existing, current []IndexArticle) []IndexArticle {
+	replaced := make(map[string]bool, len(current)*2)
+	for _, a := range current {
+		replaced[a.key()] = true
+		replaced["dir:"+a.Dir] = true
+	}
+
+	merged := slices.Clone(current)
+	for _, a := range existing {
+		if replaced[a.key()] || replaced["dir:"+a.Dir] {
+			continue
+		}
+		// 使用者刪除的文章目錄不再列入總覽
+		if _, err := os.Stat(filepath.Join(dir, a.Dir)); err != nil {
+			continue
+		}
+		replaced[a.key()] = true
+		merged = append(merged, a)
+	}
+
+	slices.SortStableFunc(merged, func(a, b IndexArticle) int {
+		return cmp.Or(b.PushCount-a.PushCount, strings.Compare(a.Dir, b.Dir))
+	})
+	return merged
+}
+
+// loadIndexData 讀取 dir 中既有的總覽資料：優先讀取 index.json；
+// 沒有 index.json 時（先前版本只輸出 index.md）由 index.md 的表格轉換，兩者皆不存在時回傳空清單
+func loadIndexData(dir string) ([]IndexArticle, error) {
+	path := filepath.Join(dir, IndexDataFileName)
+	data, err := os.ReadFile(path)
+	if stderrors.Is(err, fs.ErrNotExist) {
+		return loadLegacyMarkdownIndex(dir)
+	}
+	if err != nil {
+		return nil, errors.NewFileError("讀取既有總覽失敗", err)
+	}
+
+	var idx IndexData
+	if err := json.Unmarshal(data, &idx); err != nil {
+		return nil, errors.NewFileError(fmt.Sprintf("解析既有總覽失敗: %s", path), err)
+	}
+	if idx.Version > IndexDataVersion {
+		// 不認得較新版本的欄位，覆寫會遺失資料
+		return nil, errors.NewFileError(fmt.Sprintf("既有總覽 %s 的格式版本 %d 較新（支援至 %d），請更新程式", path, idx.Version, IndexDataVersion), nil)
+	}
+	return idx.Articles, nil
+}
+
+// legacyIndexRow 符合舊版 index.md 的表格列：| 縮圖 | [標題](連結) | 推文數 | 圖片數 |
+var legacyIndexRow = regexp.MustCompile(`^\| (?:<img src="([^"]*)" width="120">)? \| \[(.*)\]\((\./[^)]*)\) \| (-?\d+) \| (\d+) \|$`)
+
+// loadLegacyMarkdownIndex 由 index.md 的表格還原總覽資料（舊版沒有文章 URL，合併時以目錄名稱判斷重複），
+// 無法辨識的列直接略過
+func loadLegacyMarkdownIndex(dir string) ([]IndexArticle, error) {
+	data, err := os.ReadFile(filepath.Join(dir, IndexMarkdownFileName))
+	if stderrors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.NewFileError("讀取既有總覽失敗", err)
+	}
+
+	var records []IndexArticle
+	for line := range strings.Lines(string(data)) {
+		m := legacyIndexRow.FindStringSubmatch(strings.TrimRight(line, "\r\n"))
+		if m == nil {
+			continue
+		}
+		folder, _, ok := splitRelativeLink(m[3])
+		if !ok {
+			continue
+		}
+		pushCount, _ := strconv.Atoi(m[4])
+		images, _ := strconv.Atoi(m[5])
+		record := IndexArticle{
+			Title:     strings.ReplaceAll(m[2], `\|`, "|"),
+			PushCount: pushCount,
+			Images:    images,
+			Dir:       folder,
+		}
+		if thumbFolder, file, ok := splitRelativeLink(m[1]); ok && thumbFolder == folder {
+			record.Thumbnail = file
+		}
+		records = append(records, record)
+	}
+	return records, nil
+}
+
+// splitRelativeLink 是 relativeLink 的反向操作：將 ./folder/file 拆回未跳脫的目錄與檔案路徑
+func splitRelativeLink(link string) (folder, file string, ok bool) {
+	rest, found := strings.CutPrefix(link, "./")
+	if !found {
+		return "", "", false
+	}
+	segments := strings.Split(rest, "/")
+	if len(segments) < 2 {
+		return "", "", false
+	}
+	for i, s := range segments {
+		unescaped, err := url.PathUnescape(s)
+		if err != nil {
+			return "", "", false
+		}
+		segments[i] = unescaped
+	}
+	return segments[0], strings.Join(segments[1:], "/"), true
+}
+
+// writeIndexData 將總覽資料以目前的格式版本寫入 dir 的 index.json
+func writeIndexData(dir string, articles []IndexArticle) error {
+	data, err := json.MarshalIndent(IndexData{Version: IndexDataVersion, Articles: articles}, "", "  ")
+	if err != nil {
+		return errors.NewFileError("產生總覽資料失敗", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, IndexDataFileName), append(data, '\n'), constants.FilePermission); err != nil {
+		return errors.NewFileError("寫入總覽資料失敗", err)
+	}
+	return nil
+}
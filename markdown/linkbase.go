@@ -0,0 +1,75 @@
+package markdown
+
+import (
+	"net/url"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/twtrubiks/ptt-spider-go/types"
+)
+
+// WithLinkBase 設定輸出檔中圖片與文章連結的基底（output.linkBase）。
+// 為空時使用相對於文章目錄的 ./檔名（預設）；設定後改為 基底/看板/文章目錄/檔名，
+// 例如 "/" 產生以站台根目錄為起點的 /Beauty/標題_10/a.jpg，讓看板總覽與各篇文章共用同一個靜態根目錄
+func WithLinkBase(base string) GeneratorOption {
+	return func(g *GeneratorImpl) { g.linkBase = base }
+}
+
+// articleDir 回傳文章目錄相對於輸出根目錄、以 / 分隔的路徑；未提供 SiteDir 時以 SaveDir 代替
+func articleDir(info types.MarkdownInfo) string {
+	dir := info.SiteDir
+	if dir == "" {
+		dir = info.SaveDir
+	}
+	return path.Clean(filepath.ToSlash(dir))
+}
+
+// fileLink 回傳文章輸出檔引用文章目錄中 file 的連結：未設定 base 時為 ./file，
+// 否則為 base/文章目錄/file，各段以 URL 跳脫（目錄名常含空白與中括號）
+func fileLink(base string, info types.MarkdownInfo, file string) string {
+	if base == "" {
+		return "./" + file
+	}
+	return joinLink(base, articleDir(info)+"/"+file)
+}
+
+// imageLinks 回傳與 imageFiles 一一對應的圖片連結
+func imageLinks(base string, info types.MarkdownInfo, imageFiles []string) []string {
+	links := make([]string, len(imageFiles))
+	for i, f := range imageFiles {
+		links[i] = fileLink(base, info, f)
+	}
+	return links
+}
+
+// boardLinkPrefix 回傳看板總覽中文章連結的前綴：未設定 base 時為 ./，
+// 否則為 base/看板目錄/（看板目錄取自本次文章的 SiteDir，各篇文章皆位於同一看板目錄）
+func boardLinkPrefix(base string, articles []types.MarkdownInfo) string {
+	if base == "" || len(articles) == 0 {
+		return "./"
+	}
+	board := path.Dir(articleDir(articles[0]))
+	if board == "." {
+		return joinLink(base, "") // 未指定看板時文章直接位於輸出根目錄
+	}
+	return joinLink(base, board) + "/"
+}
+
+// joinLink 將 base 與以 / 分隔的相對路徑組成連結，路徑各段以 URL 跳脫
+func joinLink(base, p string) string {
+	base = strings.TrimRight(base, "/") + "/"
+	if p == "" {
+		return base
+	}
+	return base + escapePath(p)
+}
+
+// escapePath 以 URL 跳脫以 / 分隔的路徑中的每一段
+func escapePath(p string) string {
+	segments := strings.Split(p, "/")
+	for i, s := range segments {
+		segments[i] = url.PathEscape(s)
+	}
+	return strings.Join(segments, "/")
+}
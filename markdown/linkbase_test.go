@@ -0,0 +1,69 @@
+package markdown
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/twtrubiks/ptt-spider-go/types"
+)
+
+func TestFileLink(t *testing.T) {
+	info := types.MarkdownInfo{SaveDir: filepath.Join("out", "Beauty", "[正妹] 測試_10"), SiteDir: "Beauty/[正妹] 測試_10"}
+	tests := []struct {
+		name string
+		base string
+		info types.MarkdownInfo
+		file string
+		want string
+	}{
+		{"預設相對於文章目錄", "", info, "a.jpg", "./a.jpg"},
+		{"站台根目錄", "/", info, "a.jpg", "/Beauty/%5B%E6%AD%A3%E5%A6%B9%5D%20%E6%B8%AC%E8%A9%A6_10/a.jpg"},
+		{"完整網址且結尾有斜線", "https://cdn.example.com/gallery/", info, "landscape/a b.jpg",
+			"https://cdn.example.com/gallery/Beauty/%5B%E6%AD%A3%E5%A6%B9%5D%20%E6%B8%AC%E8%A9%A6_10/landscape/a%20b.jpg"},
+		{"沒有 SiteDir 時以 SaveDir 代替", "/site", types.MarkdownInfo{SaveDir: filepath.Join("Beauty", "t_1")}, "a.jpg", "/site/Beauty/t_1/a.jpg"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := fileLink(tt.base, tt.info, tt.file); got != tt.want {
+				t.Errorf("fileLink() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGeneratorImpl_LinkBase(t *testing.T) {
+	root := t.TempDir()
+	info := types.MarkdownInfo{
+		Title:      "測試",
+		ImageURLs:  []string{"https://i.imgur.com/a.jpg"},
+		SaveDir:    filepath.Join(root, "Beauty", "測試_1"),
+		SiteDir:    "Beauty/測試_1",
+		ArticleURL: "https://www.ptt.cc/bbs/Beauty/M.1234567890.A.ABC.html",
+	}
+	gen := NewGenerator(WithLinkBase("/"), WithFormats(FormatMarkdown, FormatHTML), WithFrontMatter(true))
+	if err := gen.Generate(info); err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+
+	link := "/Beauty/%E6%B8%AC%E8%A9%A6_1/a.jpg"
+	readme := readGeneratedContent(t, filepath.Join(info.SaveDir, MarkdownFileName))
+	if !strings.Contains(readme, "![a.jpg]("+link+")") {
+		t.Errorf("README.md 的圖片連結應為 %s:\n%s", link, readme)
+	}
+	if fm, _ := readFrontMatter(t, info.SaveDir); fm["images"].([]any)[0] != link {
+		t.Errorf("front matter images = %v, want [%s]", fm["images"], link)
+	}
+	if page := readGeneratedContent(t, filepath.Join(info.SaveDir, HTMLFileName)); !strings.Contains(page, `src="`+link+`"`) {
+		t.Errorf("index.html 的圖片連結應為 %s:\n%s", link, page)
+	}
+
+	// 看板總覽同樣以站台根目錄為起點
+	if err := gen.GenerateIndex(filepath.Join(root, "Beauty"), []types.MarkdownInfo{info}); err != nil {
+		t.Fatalf("GenerateIndex failed: %v", err)
+	}
+	index := readGeneratedContent(t, filepath.Join(root, "Beauty", IndexMarkdownFileName))
+	if !strings.Contains(index, `<img src="`+link+`"`) || !strings.Contains(index, "(/Beauty/%E6%B8%AC%E8%A9%A6_1/README.md)") {
+		t.Errorf("index.md 的連結應以 / 為起點:\n%s", index)
+	}
+}
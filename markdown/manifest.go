@@ -0,0 +1,72 @@
+package markdown
+
+import (
+	"encoding/json"
+
+	"github.com/twtrubiks/ptt-spider-go/types"
+)
+
+// ManifestFileName 是每篇文章目錄中結構化資訊檔的檔名
+const ManifestFileName = "manifest.json"
+
+// Manifest 是 manifest.json 的內容，供程式讀取文章與圖片資訊
+type Manifest struct {
+	Title      string          `json:"title"`             // 文章標題
+	ArticleURL string          `json:"article_url"`       // 原始文章 URL
+	Author     string          `json:"author"`            // 作者帳號（未知時為空字串）
+	PushCount  int             `json:"push_count"`        // 推文數
+	Pushes     ManifestPushes  `json:"pushes,omitzero"`   // 文章頁的推／噓／→ 統計，未解析到推文時省略
+	Hosts      []ManifestHost  `json:"hosts,omitempty"`   // 依圖床（URL host）分組的圖片數，沒有圖片時省略
+	Images     []ManifestImage `json:"images"`            // 圖片清單，順序與 README.md 相同
+	Archive    string          `json:"archive,omitempty"` // 圖片封存檔（pack: tar 時），images 的 file 為封存檔內的檔名
+}
+
+// ManifestPushes 是文章頁推文依類型的數量
+type ManifestPushes struct {
+	Push    int `json:"push"`    // 推
+	Boo     int `json:"boo"`     // 噓
+	Neutral int `json:"neutral"` // →
+}
+
+// ManifestImage 是單張圖片的本地檔名與原始 URL
+type ManifestImage struct {
+	File string `json:"file"` // 文章目錄中的檔名
+	URL  string `json:"url"`  // 原始圖片 URL
+}
+
+// newManifest 由 MarkdownInfo 與對應的本地檔名建立 Manifest
+func newManifest(info types.MarkdownInfo, imageFiles []string) Manifest {
+	images := make([]ManifestImage, len(info.ImageURLs))
+	for i, imgURL := range info.ImageURLs {
+		images[i] = ManifestImage{File: imageFiles[i], URL: imgURL}
+	}
+	return Manifest{
+		Title:      info.Title,
+		ArticleURL: info.ArticleURL,
+		Author:     info.Author,
+		PushCount:  info.PushCount,
+		Pushes: ManifestPushes{
+			Push:    info.Pushes.PushCount,
+			Boo:     info.Pushes.BooCount,
+			Neutral: info.Pushes.NeutralCount,
+		},
+		Hosts:   countHosts(info.ImageURLs),
+		Images:  images,
+		Archive: info.Archive,
+	}
+}
+
+// manifestRenderer 產生 manifest.json
+type manifestRenderer struct{}
+
+func (manifestRenderer) name() string     { return "manifest" }
+func (manifestRenderer) fileName() string { return ManifestFileName }
+
+// render 將文章資訊序列化為以換行結尾的縮排 JSON
+func (manifestRenderer) render(info types.MarkdownInfo, imageFiles []string) ([]byte, error) {
+	data, err := json.MarshalIndent(newManifest(info, imageFiles), "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(data, '\n'), nil
+}
@@ -0,0 +1,95 @@
+package markdown
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/twtrubiks/ptt-spider-go/types"
+)
+
+func TestGeneratorImpl_Manifest(t *testing.T) {
+	info := types.MarkdownInfo{
+		Title:      "[正妹] 測試",
+		ArticleURL: "https://www.ptt.cc/bbs/Beauty/M.1234567890.A.ABC.html",
+		Author:     "alice",
+		PushCount:  42,
+		ImageURLs:  []string{"https://i.imgur.com/a.jpg", "https://example.com/x/a.jpg"},
+		SaveDir:    t.TempDir(),
+	}
+	if err := NewGenerator(WithManifest(true)).Generate(info); err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+
+	data, err := os.ReadFile(filepath.Join(info.SaveDir, ManifestFileName))
+	if err != nil {
+		t.Fatalf("應產生 %s: %v", ManifestFileName, err)
+	}
+	var got Manifest
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatalf("manifest 不是合法 JSON: %v", err)
+	}
+	want := Manifest{
+		Title:      info.Title,
+		ArticleURL: info.ArticleURL,
+		Author:     "alice",
+		PushCount:  42,
+		// 數量相同時依 host 排序
+		Hosts: []ManifestHost{{Host: "example.com", Images: 1}, {Host: "i.imgur.com", Images: 1}},
+		// 檔名與 README.md、下載存檔相同（含碰撞序號後綴）
+		Images: []ManifestImage{
+			{File: "a.jpg", URL: "https://i.imgur.com/a.jpg"},
+			{File: "a_2.jpg", URL: "https://example.com/x/a.jpg"},
+		},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("manifest = %+v, want %+v", got, want)
+	}
+	if data[len(data)-1] != '\n' || data[1] != '\n' {
+		t.Errorf("manifest 應為縮排 JSON 並以換行結尾: %q", data)
+	}
+}
+
+func TestGeneratorImpl_ManifestPushes(t *testing.T) {
+	info := types.MarkdownInfo{
+		Title:   "推噓",
+		Pushes:  types.PushStats{PushCount: 3, BooCount: 1, NeutralCount: 2},
+		SaveDir: t.TempDir(),
+	}
+	data, err := manifestRenderer{}.render(info, nil)
+	if err != nil {
+		t.Fatalf("render failed: %v", err)
+	}
+	var got Manifest
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatalf("manifest 不是合法 JSON: %v", err)
+	}
+	if want := (ManifestPushes{Push: 3, Boo: 1, Neutral: 2}); got.Pushes != want {
+		t.Errorf("pushes = %+v, want %+v", got.Pushes, want)
+	}
+
+	// 沒有推文統計時省略 pushes 欄位
+	data, err = manifestRenderer{}.render(types.MarkdownInfo{Title: "無推文"}, nil)
+	if err != nil {
+		t.Fatalf("render failed: %v", err)
+	}
+	var raw map[string]any
+	if err := json.Unmarshal(data, &raw); err != nil {
+		t.Fatalf("manifest 不是合法 JSON: %v", err)
+	}
+	if _, ok := raw["pushes"]; ok {
+		t.Errorf("沒有推文時不應輸出 pushes: %s", data)
+	}
+}
+
+func TestGeneratorImpl_ManifestDisabled(t *testing.T) {
+	info := types.MarkdownInfo{Title: "預設", SaveDir: t.TempDir()}
+	if err := NewGenerator().Generate(info); err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(info.SaveDir, ManifestFileName)); !os.IsNotExist(err) {
+		t.Errorf("未啟用時不應產生 manifest, err = %v", err)
+	}
+}
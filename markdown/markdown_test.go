@@ -313,6 +313,7 @@ func TestGenerateContentFormat(t *testing.T) {
 		"",
 		"- **文章網址**: [https://www.ptt.cc/bbs/Beauty/M.1234567890.A.ABC.html](https://www.ptt.cc/bbs/Beauty/M.1234567890.A.ABC.html)",
 		"- **推文數量**: 42",
+		"- **圖床統計**: i.imgur.com 2 張、example.com 1 張",
 		"",
 		"## 圖片列表",
 		"",
@@ -331,3 +332,49 @@ func TestGenerateContentFormat(t *testing.T) {
 		}
 	}
 }
+
+func TestGeneratePushStats(t *testing.T) {
+	info := types.MarkdownInfo{
+		Title:     "[正妹] 推噓統計",
+		PushCount: 2,
+		Pushes:    types.PushStats{PushCount: 3, BooCount: 1, NeutralCount: 2},
+		ImageURLs: []string{"https://i.imgur.com/a.jpg"},
+		SaveDir:   t.TempDir(),
+	}
+	if err := NewGenerator(WithFormats(FormatMarkdown, FormatHTML)).Generate(info); err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+
+	md := readGeneratedContent(t, filepath.Join(info.SaveDir, MarkdownFileName))
+	checkContentContains(t, md, "- **推文數量**: 2\n- **推噓統計**: 推 3 / 噓 1 / → 2\n- **圖床統計**: i.imgur.com 1 張\n\n## 圖片列表",
+		"README.md should contain push stats after push count")
+
+	html := readGeneratedContent(t, filepath.Join(info.SaveDir, HTMLFileName))
+	checkContentContains(t, html, "<li><strong>推噓統計</strong>: 推 3 / 噓 1 / → 2</li>",
+		"index.html should contain push stats")
+}
+
+func TestGenerateBody(t *testing.T) {
+	info := types.MarkdownInfo{
+		Title:     "[正妹] 內文",
+		Body:      "第一行\n\n  縮排的第二行",
+		ImageURLs: []string{"https://i.imgur.com/a.jpg"},
+		SaveDir:   t.TempDir(),
+	}
+	if err := NewGenerator().Generate(info); err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+
+	md := readGeneratedContent(t, filepath.Join(info.SaveDir, MarkdownFileName))
+	checkContentContains(t, md, "## 內文\n\n> 第一行\n>\n>   縮排的第二行\n\n## 圖片列表",
+		"README.md should quote the body before the image list")
+
+	// 沒有內文時不輸出段落
+	info.Body = ""
+	if err := NewGenerator().Generate(info); err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+	if md := readGeneratedContent(t, filepath.Join(info.SaveDir, MarkdownFileName)); strings.Contains(md, "## 內文") {
+		t.Error("README.md should not contain the body section when the body is empty")
+	}
+}
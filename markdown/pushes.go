@@ -0,0 +1,63 @@
+package markdown
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/twtrubiks/ptt-spider-go/types"
+)
+
+// PushesFileName 是每篇文章目錄中推文內容檔的檔名
+const PushesFileName = "pushes.json"
+
+// PushesFile 是 pushes.json 的內容
+type PushesFile struct {
+	Title      string       `json:"title"`      // 文章標題
+	ArticleURL string       `json:"articleUrl"` // 原始文章 URL
+	Pushes     []types.Push `json:"pushes"`     // 推文，順序與文章頁相同
+}
+
+// WithInlinePushes 啟用後在 README.md／index.html 的圖片列表之後加上「推文」段落
+func WithInlinePushes(enabled bool) GeneratorOption {
+	return func(g *GeneratorImpl) { g.inlinePushes = enabled }
+}
+
+// WithPushesFile 啟用後另外輸出 pushes.json（文章的所有推文）
+func WithPushesFile(enabled bool) GeneratorOption {
+	return func(g *GeneratorImpl) { g.pushesFile = enabled }
+}
+
+// writeMarkdownPushes 在 Markdown 內容最後加上「推文」段落，沒有推文時不輸出
+func writeMarkdownPushes(builder *strings.Builder, pushes []types.Push) {
+	if len(pushes) == 0 {
+		return
+	}
+	builder.WriteString("\n## 推文\n\n")
+	for _, p := range pushes {
+		fmt.Fprintf(builder, "- %s **%s**: %s", p.Tag, p.Author, p.Content)
+		if p.Time != "" {
+			fmt.Fprintf(builder, " `%s`", p.Time)
+		}
+		builder.WriteString("\n")
+	}
+}
+
+// pushesRenderer 產生 pushes.json
+type pushesRenderer struct{}
+
+func (pushesRenderer) name() string     { return "推文" }
+func (pushesRenderer) fileName() string { return PushesFileName }
+
+// render 將推文序列化為以換行結尾的縮排 JSON，沒有推文時輸出空陣列
+func (pushesRenderer) render(info types.MarkdownInfo, _ []string) ([]byte, error) {
+	pushes := info.Comments
+	if pushes == nil {
+		pushes = []types.Push{}
+	}
+	data, err := json.MarshalIndent(PushesFile{Title: info.Title, ArticleURL: info.ArticleURL, Pushes: pushes}, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(data, '\n'), nil
+}
@@ -0,0 +1,108 @@
+package markdown
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/twtrubiks/ptt-spider-go/types"
+)
+
+var testPushes = []types.Push{
+	{Tag: "推", Author: "alice", Content: "正", Time: "01/05 10:00"},
+	{Tag: "噓", Author: "bob", Content: "<b>普</b>", Time: "01/05 10:01"},
+	{Tag: "→", Author: "carol", Content: "路過"},
+}
+
+func TestGeneratorImpl_InlinePushes(t *testing.T) {
+	info := types.MarkdownInfo{
+		Title:     "推文",
+		ImageURLs: []string{"https://i.imgur.com/a.jpg"},
+		Comments:  testPushes,
+		SaveDir:   t.TempDir(),
+	}
+	if err := NewGenerator(WithFormats(FormatMarkdown, FormatHTML), WithInlinePushes(true)).Generate(info); err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+
+	md := readGeneratedContent(t, filepath.Join(info.SaveDir, MarkdownFileName))
+	wantMD := "![a.jpg](./a.jpg)\n\n## 推文\n\n" +
+		"- 推 **alice**: 正 `01/05 10:00`\n" +
+		"- 噓 **bob**: <b>普</b> `01/05 10:01`\n" +
+		"- → **carol**: 路過\n"
+	if !strings.HasSuffix(md, wantMD) {
+		t.Errorf("README.md 結尾 = %q, want %q", md, wantMD)
+	}
+
+	html := readGeneratedContent(t, filepath.Join(info.SaveDir, HTMLFileName))
+	for _, want := range []string{
+		"<h2>推文</h2>",
+		`<li>推 <strong>alice</strong>: 正 <span class="time">01/05 10:00</span></li>`,
+		// 推文內容經過 HTML 跳脫
+		"<li>噓 <strong>bob</strong>: &lt;b&gt;普&lt;/b&gt;",
+		"<li>→ <strong>carol</strong>: 路過</li>",
+	} {
+		if !strings.Contains(html, want) {
+			t.Errorf("index.html 缺少 %q", want)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(info.SaveDir, PushesFileName)); !os.IsNotExist(err) {
+		t.Errorf("未啟用 pushesFile 時不應產生 %s, err = %v", PushesFileName, err)
+	}
+}
+
+func TestGeneratorImpl_InlinePushesDisabled(t *testing.T) {
+	info := types.MarkdownInfo{Title: "推文", Comments: testPushes, SaveDir: t.TempDir()}
+	if err := NewGenerator(WithFormats(FormatMarkdown, FormatHTML)).Generate(info); err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+	for _, name := range []string{MarkdownFileName, HTMLFileName} {
+		if content := readGeneratedContent(t, filepath.Join(info.SaveDir, name)); strings.Contains(content, "<h2>推文</h2>") ||
+			strings.Contains(content, "## 推文") {
+			t.Errorf("未啟用 inlinePushes 時 %s 不應包含推文段落", name)
+		}
+	}
+}
+
+func TestGeneratorImpl_PushesFile(t *testing.T) {
+	tests := []struct {
+		name     string
+		comments []types.Push
+		want     []types.Push
+	}{
+		{"有推文", testPushes, testPushes},
+		{"沒有推文時輸出空陣列", nil, []types.Push{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			info := types.MarkdownInfo{
+				Title:      "推文",
+				ArticleURL: "https://www.ptt.cc/bbs/Beauty/M.1.A.html",
+				Comments:   tt.comments,
+				SaveDir:    t.TempDir(),
+			}
+			if err := NewGenerator(WithPushesFile(true)).Generate(info); err != nil {
+				t.Fatalf("Generate failed: %v", err)
+			}
+
+			data, err := os.ReadFile(filepath.Join(info.SaveDir, PushesFileName))
+			if err != nil {
+				t.Fatalf("應產生 %s: %v", PushesFileName, err)
+			}
+			var got PushesFile
+			if err := json.Unmarshal(data, &got); err != nil {
+				t.Fatalf("%s 不是合法 JSON: %v", PushesFileName, err)
+			}
+			want := PushesFile{Title: info.Title, ArticleURL: info.ArticleURL, Pushes: tt.want}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("%s = %+v, want %+v", PushesFileName, got, want)
+			}
+			if md := readGeneratedContent(t, filepath.Join(info.SaveDir, MarkdownFileName)); strings.Contains(md, "## 推文") {
+				t.Error("只啟用 pushesFile 時 README.md 不應包含推文段落")
+			}
+		})
+	}
+}
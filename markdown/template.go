@@ -0,0 +1,95 @@
+package markdown
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"text/template"
+	"time"
+
+	"github.com/twtrubiks/ptt-spider-go/constants"
+	"github.com/twtrubiks/ptt-spider-go/errors"
+	"github.com/twtrubiks/ptt-spider-go/types"
+)
+
+// TemplateData 是自訂 README.md 模板（output.templatePath）可使用的資料：
+// MarkdownInfo 的所有欄位（ImageFiles 已填入與 ImageURLs 一一對應的本地路徑），另加上由網址與標題推導的欄位
+type TemplateData struct {
+	types.MarkdownInfo
+	Date       time.Time // 發文時間（文章頁的「時間」欄位，沒有時由文章網址推導，UTC+8），無法取得時為零值
+	Category   string    // 標題開頭方括號內的分類（如「正妹」），沒有分類時為空字串
+	ImageLinks []string  // 與 ImageFiles 一一對應的圖片連結（依 output.linkBase，預設為 ./檔名）
+}
+
+// templateFuncs 是模板中可使用的輔助函式
+var templateFuncs = template.FuncMap{
+	"inc":   func(i int) int { return i + 1 },                  // 圖片編號：{{inc $i}} 由 1 開始
+	"quote": func(s string) string { return strconv.Quote(s) }, // 雙引號字串，front matter 中標題含冒號或引號時使用
+}
+
+// LoadTemplate 讀取並解析 README.md 的 text/template 模板，並以範例文章試產生一次，
+// 讓語法錯誤與不存在的欄位在啟動時就回報，而不是每篇文章各失敗一次
+func LoadTemplate(path string) (*template.Template, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, errors.NewFileError(fmt.Sprintf("讀取 Markdown 模板失敗: %s", path), err)
+	}
+	tmpl, err := template.New(filepath.Base(path)).Funcs(templateFuncs).Parse(string(data))
+	if err != nil {
+		return nil, errors.NewParseError(fmt.Sprintf("解析 Markdown 模板失敗: %s", path), err)
+	}
+	if err := tmpl.Execute(io.Discard, newTemplateData(sampleArticle, imageFilesOf(sampleArticle), "")); err != nil {
+		return nil, errors.NewParseError(fmt.Sprintf("Markdown 模板無法套用: %s", path), err)
+	}
+	return tmpl, nil
+}
+
+// sampleArticle 是 LoadTemplate 試產生模板時使用的範例文章，各欄位皆有值
+var sampleArticle = types.MarkdownInfo{
+	Title:      "[正妹] 範例文章",
+	ArticleURL: "https://www.ptt.cc/bbs/Beauty/M.1700000000.A.123.html",
+	Author:     "example",
+	Date:       time.Date(2023, 11, 15, 6, 13, 20, 0, constants.PttLocation),
+	PushCount:  10,
+	Pushes:     types.PushStats{PushCount: 1},
+	ImageURLs:  []string{"https://i.imgur.com/example.jpg"},
+	Comments:   []types.Push{{Tag: "推", Author: "someone", Content: "範例推文", Time: "01/01 12:00"}},
+	Body:       "範例內文",
+	SaveDir:    "Beauty/example",
+}
+
+// WithTemplate 以自訂模板取代內建的 README.md 格式，nil 時使用內建格式。
+// 使用模板時 numberImages、frontMatter 與內嵌推文設定不影響 README.md，由模板自行決定內容
+func WithTemplate(tmpl *template.Template) GeneratorOption {
+	return func(g *GeneratorImpl) { g.template = tmpl }
+}
+
+// newTemplateData 組出模板資料
+func newTemplateData(info types.MarkdownInfo, imageFiles []string, linkBase string) TemplateData {
+	info.ImageFiles = imageFiles
+	d := TemplateData{MarkdownInfo: info, Category: titleCategory(info.Title), ImageLinks: imageLinks(linkBase, info, imageFiles)}
+	if t, ok := articleDate(info); ok {
+		d.Date = t
+	}
+	return d
+}
+
+// templateRenderer 以自訂模板產生 README.md
+type templateRenderer struct {
+	tmpl     *template.Template
+	linkBase string // 圖片連結（.ImageLinks）的基底
+}
+
+func (templateRenderer) name() string     { return "Markdown" }
+func (templateRenderer) fileName() string { return MarkdownFileName }
+
+func (r templateRenderer) render(info types.MarkdownInfo, imageFiles []string) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := r.tmpl.Execute(&buf, newTemplateData(info, imageFiles, r.linkBase)); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,110 @@
+package markdown
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/twtrubiks/ptt-spider-go/types"
+)
+
+// writeTemplate 將模板內容寫入暫存檔並回傳路徑
+func writeTemplate(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "article.tmpl")
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatalf("寫入模板失敗: %v", err)
+	}
+	return path
+}
+
+func TestGeneratorImpl_Template(t *testing.T) {
+	path := writeTemplate(t, `---
+title: {{quote .Title}}
+date: {{.Date.Format "2006-01-02"}}
+category: {{.Category}}
+---
+
+## {{.Title}}（{{.Author}}，{{.PushCount}} 推）
+
+{{range $i, $f := .ImageFiles}}{{inc $i}}. ![]({{$f}}) <{{index $.ImageURLs $i}}>
+{{end}}`)
+	tmpl, err := LoadTemplate(path)
+	if err != nil {
+		t.Fatalf("LoadTemplate failed: %v", err)
+	}
+
+	info := types.MarkdownInfo{
+		Title:      `[正妹] 標題: "引號"`,
+		ArticleURL: "https://www.ptt.cc/bbs/Beauty/M.1234567890.A.ABC.html",
+		Author:     "tester",
+		PushCount:  42,
+		ImageURLs:  []string{"https://i.imgur.com/a.jpg", "https://i.imgur.com/b.png"},
+		SaveDir:    t.TempDir(),
+	}
+	// 同時啟用內建格式的選項，使用模板時不應影響 README.md
+	if err := NewGenerator(WithTemplate(tmpl), WithNumberedImages(true), WithFrontMatter(true)).Generate(info); err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+
+	data, err := os.ReadFile(filepath.Join(info.SaveDir, MarkdownFileName))
+	if err != nil {
+		t.Fatalf("讀取 README.md 失敗: %v", err)
+	}
+	want := `---
+title: "[正妹] 標題: \"引號\""
+date: 2009-02-14
+category: 正妹
+---
+
+## [正妹] 標題: "引號"（tester，42 推）
+
+1. ![](a.jpg) <https://i.imgur.com/a.jpg>
+2. ![](b.png) <https://i.imgur.com/b.png>
+`
+	if string(data) != want {
+		t.Errorf("README.md =\n%s\nwant\n%s", data, want)
+	}
+}
+
+func TestGeneratorImpl_TemplateOnlyReplacesMarkdown(t *testing.T) {
+	tmpl, err := LoadTemplate(writeTemplate(t, "# {{.Title}}\n"))
+	if err != nil {
+		t.Fatalf("LoadTemplate failed: %v", err)
+	}
+	info := types.MarkdownInfo{Title: "測試", ImageURLs: []string{"https://i.imgur.com/a.jpg"}, SaveDir: t.TempDir()}
+	if err := NewGenerator(WithTemplate(tmpl), WithFormats(FormatMarkdown, FormatHTML)).Generate(info); err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+	if data, _ := os.ReadFile(filepath.Join(info.SaveDir, MarkdownFileName)); string(data) != "# 測試\n" {
+		t.Errorf("README.md = %q, want %q", data, "# 測試\n")
+	}
+	if _, err := os.Stat(filepath.Join(info.SaveDir, HTMLFileName)); err != nil {
+		t.Errorf("index.html 應照常以內建格式產生: %v", err)
+	}
+}
+
+func TestLoadTemplate_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		path    func(t *testing.T) string
+		wantMsg string
+	}{
+		{"檔案不存在", func(t *testing.T) string { return filepath.Join(t.TempDir(), "missing.tmpl") }, "讀取 Markdown 模板失敗"},
+		{"語法錯誤", func(t *testing.T) string { return writeTemplate(t, "# {{.Title") }, "解析 Markdown 模板失敗"},
+		{"未知的函式", func(t *testing.T) string { return writeTemplate(t, "{{upper .Title}}") }, "解析 Markdown 模板失敗"},
+		{"不存在的欄位", func(t *testing.T) string { return writeTemplate(t, "{{.Titel}}") }, "Markdown 模板無法套用"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := LoadTemplate(tt.path(t))
+			if err == nil {
+				t.Fatal("預期回傳錯誤")
+			}
+			if !strings.Contains(err.Error(), tt.wantMsg) {
+				t.Errorf("錯誤訊息 %q 應包含 %q", err.Error(), tt.wantMsg)
+			}
+		})
+	}
+}
@@ -0,0 +1,200 @@
+// Package metrics 提供 interfaces.MetricsCollector 的記憶體內實作，
+// 統計請求數、錯誤類型、狀態碼、下載量與請求延遲。
+package metrics
+
+import (
+	"math/rand/v2"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/twtrubiks/ptt-spider-go/interfaces"
+	"github.com/twtrubiks/ptt-spider-go/types"
+)
+
+// 錯誤類型，RecordRequest 會自動分類 network 與 http_status，其餘由呼叫方記錄
+const (
+	ErrorNetwork      = "network"       // 請求未取得回應（連線失敗、逾時等）
+	ErrorHTTPStatus   = "http_status"   // 回應狀態碼 >= 400
+	ErrorParse        = "parse"         // 頁面解析失敗
+	ErrorInvalidImage = "invalid_image" // 回應內容不是圖片
+	ErrorOversize     = "oversize"      // 圖片超過大小上限
+	ErrorWrite        = "write"         // 建立或寫入檔案失敗
+)
+
+// LatencyBuckets 是延遲直方圖的區間上限，沿用 Prometheus 預設的 DefBuckets
+var LatencyBuckets = []time.Duration{
+	5 * time.Millisecond, 10 * time.Millisecond, 25 * time.Millisecond, 50 * time.Millisecond,
+	100 * time.Millisecond, 250 * time.Millisecond, 500 * time.Millisecond,
+	time.Second, 2500 * time.Millisecond, 5 * time.Second, 10 * time.Second,
+}
+
+// latencySampleSize 是估算延遲百分位所保留的樣本數上限。
+// 請求數未超過上限時百分位為精確值，超過後以蓄水池抽樣（reservoir sampling）估算，
+// 長時間執行時記憶體與 Snapshot 的排序成本維持固定。
+const latencySampleSize = 1024
+
+// Collector 以 mutex 保護的記憶體內指標收集器，可供多個 worker 並行呼叫。
+// 延遲直方圖與總和逐筆累加，不保留每筆請求的延遲。
+type Collector struct {
+	mu               sync.Mutex
+	totalRequests    int64
+	totalErrors      int64
+	errorsByType     map[string]int64
+	statusCodes      map[int]int64
+	imagesDownloaded int64
+	bytesWritten     int64
+	imagesPrechecked int64
+	bytesExpected    int64
+	downloadFailures map[string]int64
+	articlesParsed   int64
+	latencySum       time.Duration
+	latencyCounts    []int64         // 各直方圖區間（非累積）的請求數，超過最大上限者只計入 totalRequests
+	latencySamples   []time.Duration // 延遲的蓄水池樣本，供估算百分位
+}
+
+// 確保 Collector 實作 MetricsCollector 介面
+var _ interfaces.MetricsCollector = (*Collector)(nil)
+
+// NewCollector 建立空的指標收集器
+func NewCollector() *Collector {
+	return &Collector{
+		errorsByType:     make(map[string]int64),
+		statusCodes:      make(map[int]int64),
+		downloadFailures: make(map[string]int64),
+		latencyCounts:    make([]int64, len(LatencyBuckets)),
+	}
+}
+
+// RecordRequest 記錄一次請求；err 不為 nil 計為 network 錯誤，狀態碼 >= 400 計為 http_status 錯誤
+func (c *Collector) RecordRequest(statusCode int, duration time.Duration, err error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.totalRequests++
+	c.recordLatencyLocked(duration)
+	switch {
+	case err != nil:
+		c.recordErrorLocked(ErrorNetwork)
+	case statusCode >= 400:
+		c.statusCodes[statusCode]++
+		c.recordErrorLocked(ErrorHTTPStatus)
+	default:
+		c.statusCodes[statusCode]++
+	}
+}
+
+// recordLatencyLocked 將延遲計入直方圖與總和，並以蓄水池抽樣更新百分位樣本；呼叫方需持有鎖
+func (c *Collector) recordLatencyLocked(d time.Duration) {
+	c.latencySum += d
+	// 第一個上限 >= d 的區間
+	if i, _ := slices.BinarySearch(LatencyBuckets, d); i < len(c.latencyCounts) {
+		c.latencyCounts[i]++
+	}
+	if len(c.latencySamples) < latencySampleSize {
+		c.latencySamples = append(c.latencySamples, d)
+		return
+	}
+	if j := rand.Int64N(c.totalRequests); j < latencySampleSize {
+		c.latencySamples[j] = d
+	}
+}
+
+// RecordError 記錄一次指定類型的錯誤
+func (c *Collector) RecordError(kind string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.recordErrorLocked(kind)
+}
+
+func (c *Collector) recordErrorLocked(kind string) {
+	c.totalErrors++
+	c.errorsByType[kind]++
+}
+
+// RecordImageDownloaded 記錄一張成功下載的圖片
+func (c *Collector) RecordImageDownloaded(bytes int64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.imagesDownloaded++
+	c.bytesWritten += bytes
+}
+
+// RecordImagePrechecked 記錄一張經 HEAD 預檢取得大小、將排入下載的圖片
+func (c *Collector) RecordImagePrechecked(bytes int64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.imagesPrechecked++
+	c.bytesExpected += bytes
+}
+
+// RecordDownloadFailure 記錄一張下載失敗的圖片與失敗原因分類。
+// 與 ErrorsByType 分開統計：一張圖片只計一次，且 404 等狀態碼會依原因細分
+func (c *Collector) RecordDownloadFailure(reason string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.downloadFailures[reason]++
+}
+
+// RecordArticleParsed 記錄一篇成功解析的文章
+func (c *Collector) RecordArticleParsed() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.articlesParsed++
+}
+
+// Snapshot 回傳目前指標的複本，map 與延遲統計不會與收集器共用。
+// 百分位由最多 latencySampleSize 筆樣本計算，成本不隨請求數增加
+func (c *Collector) Snapshot() types.MetricsSnapshot {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	snap := types.MetricsSnapshot{
+		TotalRequests:    c.totalRequests,
+		TotalErrors:      c.totalErrors,
+		ErrorsByType:     make(map[string]int64, len(c.errorsByType)),
+		StatusCodes:      make(map[int]int64, len(c.statusCodes)),
+		ImagesDownloaded: c.imagesDownloaded,
+		BytesWritten:     c.bytesWritten,
+		ImagesPrechecked: c.imagesPrechecked,
+		BytesExpected:    c.bytesExpected,
+		DownloadFailures: make(map[string]int64, len(c.downloadFailures)),
+		ArticlesParsed:   c.articlesParsed,
+	}
+	for k, v := range c.errorsByType {
+		snap.ErrorsByType[k] = v
+	}
+	for k, v := range c.statusCodes {
+		snap.StatusCodes[k] = v
+	}
+	for k, v := range c.downloadFailures {
+		snap.DownloadFailures[k] = v
+	}
+
+	snap.LatencyBuckets = make([]types.LatencyBucket, len(LatencyBuckets))
+	var cumulative int64
+	for i, bound := range LatencyBuckets {
+		cumulative += c.latencyCounts[i]
+		snap.LatencyBuckets[i] = types.LatencyBucket{UpperBound: bound, Count: cumulative}
+	}
+
+	if c.totalRequests > 0 {
+		snap.LatencySum = c.latencySum
+		snap.AvgLatency = c.latencySum / time.Duration(c.totalRequests)
+		sorted := slices.Clone(c.latencySamples)
+		slices.Sort(sorted)
+		snap.P50Latency = percentile(sorted, 50)
+		snap.P95Latency = percentile(sorted, 95)
+		snap.P99Latency = percentile(sorted, 99)
+	}
+	return snap
+}
+
+// percentile 以 nearest-rank 法取已排序資料的第 p 百分位
+func percentile(sorted []time.Duration, p int) time.Duration {
+	rank := (p*len(sorted) + 99) / 100 // ceil(p/100 * n)
+	if rank < 1 {
+		rank = 1
+	}
+	return sorted[rank-1]
+}
@@ -0,0 +1,170 @@
+package metrics
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestCollector_Counts(t *testing.T) {
+	c := NewCollector()
+	c.RecordRequest(200, 10*time.Millisecond, nil)
+	c.RecordRequest(200, 20*time.Millisecond, nil)
+	c.RecordRequest(404, 30*time.Millisecond, nil)
+	c.RecordRequest(0, 40*time.Millisecond, errors.New("connection reset"))
+	c.RecordError(ErrorParse)
+	c.RecordImageDownloaded(1024)
+	c.RecordImageDownloaded(2048)
+	c.RecordImagePrechecked(4096)
+	c.RecordDownloadFailure("not_found")
+	c.RecordDownloadFailure("not_found")
+	c.RecordDownloadFailure("timeout")
+	c.RecordArticleParsed()
+
+	snap := c.Snapshot()
+	if snap.TotalRequests != 4 {
+		t.Errorf("TotalRequests = %d, want 4", snap.TotalRequests)
+	}
+	if snap.TotalErrors != 3 {
+		t.Errorf("TotalErrors = %d, want 3", snap.TotalErrors)
+	}
+	wantErrors := map[string]int64{ErrorNetwork: 1, ErrorHTTPStatus: 1, ErrorParse: 1}
+	for kind, want := range wantErrors {
+		if snap.ErrorsByType[kind] != want {
+			t.Errorf("ErrorsByType[%s] = %d, want %d", kind, snap.ErrorsByType[kind], want)
+		}
+	}
+	if snap.StatusCodes[200] != 2 || snap.StatusCodes[404] != 1 || len(snap.StatusCodes) != 2 {
+		t.Errorf("StatusCodes = %v, want map[200:2 404:1]", snap.StatusCodes)
+	}
+	if snap.ImagesDownloaded != 2 || snap.BytesWritten != 3072 {
+		t.Errorf("下載統計 = %d 張 / %d bytes, want 2 / 3072", snap.ImagesDownloaded, snap.BytesWritten)
+	}
+	if snap.ImagesPrechecked != 1 || snap.BytesExpected != 4096 {
+		t.Errorf("預檢統計 = %d 張 / %d bytes, want 1 / 4096", snap.ImagesPrechecked, snap.BytesExpected)
+	}
+	if snap.ArticlesParsed != 1 {
+		t.Errorf("ArticlesParsed = %d, want 1", snap.ArticlesParsed)
+	}
+	// 下載失敗依原因分開統計，不計入 TotalErrors
+	if snap.DownloadFailures["not_found"] != 2 || snap.DownloadFailures["timeout"] != 1 || len(snap.DownloadFailures) != 2 {
+		t.Errorf("DownloadFailures = %v, want map[not_found:2 timeout:1]", snap.DownloadFailures)
+	}
+	if snap.AvgLatency != 25*time.Millisecond {
+		t.Errorf("AvgLatency = %v, want 25ms", snap.AvgLatency)
+	}
+}
+
+func TestCollector_Percentiles(t *testing.T) {
+	c := NewCollector()
+	// 逆序寫入 1ms..100ms，驗證快照會先排序
+	for i := 100; i >= 1; i-- {
+		c.RecordRequest(200, time.Duration(i)*time.Millisecond, nil)
+	}
+
+	snap := c.Snapshot()
+	tests := []struct {
+		name string
+		got  time.Duration
+		want time.Duration
+	}{
+		{"P50", snap.P50Latency, 50 * time.Millisecond},
+		{"P95", snap.P95Latency, 95 * time.Millisecond},
+		{"P99", snap.P99Latency, 99 * time.Millisecond},
+	}
+	for _, tt := range tests {
+		if tt.got != tt.want {
+			t.Errorf("%s = %v, want %v", tt.name, tt.got, tt.want)
+		}
+	}
+}
+
+func TestCollector_EmptySnapshot(t *testing.T) {
+	snap := NewCollector().Snapshot()
+	if snap.TotalRequests != 0 || snap.AvgLatency != 0 || snap.P99Latency != 0 {
+		t.Errorf("空收集器的快照應為零值，got %+v", snap)
+	}
+}
+
+func TestCollector_SnapshotIsCopy(t *testing.T) {
+	c := NewCollector()
+	c.RecordError(ErrorWrite)
+	snap := c.Snapshot()
+	snap.ErrorsByType[ErrorWrite] = 100
+
+	if got := c.Snapshot().ErrorsByType[ErrorWrite]; got != 1 {
+		t.Errorf("修改快照不應影響收集器，got %d", got)
+	}
+}
+
+func TestCollector_Concurrent(t *testing.T) {
+	c := NewCollector()
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			c.RecordRequest(200, time.Millisecond, nil)
+			c.RecordImageDownloaded(10)
+			c.RecordArticleParsed()
+		}()
+	}
+	wg.Wait()
+
+	snap := c.Snapshot()
+	if snap.TotalRequests != 50 || snap.ImagesDownloaded != 50 || snap.BytesWritten != 500 || snap.ArticlesParsed != 50 {
+		t.Errorf("並行記錄後計數錯誤: %+v", snap)
+	}
+}
+
+func TestCollector_LatencyBuckets(t *testing.T) {
+	c := NewCollector()
+	for _, d := range []time.Duration{3 * time.Millisecond, 5 * time.Millisecond, 40 * time.Millisecond, 2 * time.Second, 30 * time.Second} {
+		c.RecordRequest(200, d, nil)
+	}
+
+	snap := c.Snapshot()
+	if len(snap.LatencyBuckets) != len(LatencyBuckets) {
+		t.Fatalf("len(LatencyBuckets) = %d, want %d", len(snap.LatencyBuckets), len(LatencyBuckets))
+	}
+	// 區間計數為累計值，且上限等於邊界的樣本會計入該區間
+	want := map[time.Duration]int64{
+		5 * time.Millisecond:    2,
+		25 * time.Millisecond:   2,
+		50 * time.Millisecond:   3,
+		time.Second:             3,
+		2500 * time.Millisecond: 4,
+		10 * time.Second:        4,
+	}
+	for _, b := range snap.LatencyBuckets {
+		if w, ok := want[b.UpperBound]; ok && b.Count != w {
+			t.Errorf("bucket le=%v count = %d, want %d", b.UpperBound, b.Count, w)
+		}
+	}
+	if snap.LatencySum != 2048*time.Millisecond+30*time.Second {
+		t.Errorf("LatencySum = %v, want 32.048s", snap.LatencySum)
+	}
+}
+
+func TestCollector_LatencyMemoryBounded(t *testing.T) {
+	c := NewCollector()
+	const n = 10 * latencySampleSize
+	for i := range n {
+		c.RecordRequest(200, time.Duration(i%2+1)*time.Millisecond, nil)
+	}
+
+	if len(c.latencySamples) != latencySampleSize {
+		t.Errorf("延遲樣本數 = %d, want 上限 %d", len(c.latencySamples), latencySampleSize)
+	}
+	snap := c.Snapshot()
+	if got := snap.LatencyBuckets[0].Count; got != n {
+		t.Errorf("直方圖應計入全部請求，le=5ms count = %d, want %d", got, n)
+	}
+	if want := time.Duration(n/2*1+n/2*2) * time.Millisecond; snap.LatencySum != want {
+		t.Errorf("LatencySum = %v, want %v", snap.LatencySum, want)
+	}
+	if snap.P99Latency != 2*time.Millisecond {
+		t.Errorf("P99Latency = %v, want 2ms", snap.P99Latency)
+	}
+}
@@ -0,0 +1,122 @@
+package metrics
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/twtrubiks/ptt-spider-go/interfaces"
+	"github.com/twtrubiks/ptt-spider-go/types"
+)
+
+// metricPrefix 是所有匯出指標名稱的前綴
+const metricPrefix = "ptt_spider_"
+
+// shutdownTimeout 是 context 取消後等待進行中請求完成的上限
+const shutdownTimeout = 5 * time.Second
+
+// WritePrometheus 將指標快照以 Prometheus text exposition format（0.0.4）寫出
+func WritePrometheus(w io.Writer, snap types.MetricsSnapshot) error {
+	bw := bufio.NewWriter(w)
+
+	writeCounter(bw, "requests_total", "對外 HTTP 請求總數（含失敗）", snap.TotalRequests)
+	writeCounter(bw, "articles_parsed_total", "成功解析的文章數", snap.ArticlesParsed)
+	writeCounter(bw, "images_downloaded_total", "成功下載的圖片數", snap.ImagesDownloaded)
+	writeCounter(bw, "bytes_written_total", "寫入磁碟的圖片位元組數", snap.BytesWritten)
+	writeCounter(bw, "images_prechecked_total", "HEAD 預檢取得大小、排入下載的圖片數", snap.ImagesPrechecked)
+	writeCounter(bw, "bytes_expected_total", "HEAD 預檢取得的預計下載位元組數", snap.BytesExpected)
+
+	writeHeader(bw, "responses_total", "counter", "依 HTTP 狀態碼分類的回應數")
+	codes := make([]int, 0, len(snap.StatusCodes))
+	for code := range snap.StatusCodes {
+		codes = append(codes, code)
+	}
+	slices.Sort(codes)
+	for _, code := range codes {
+		fmt.Fprintf(bw, "%sresponses_total{code=\"%d\"} %d\n", metricPrefix, code, snap.StatusCodes[code])
+	}
+
+	writeHeader(bw, "errors_total", "counter", "依類型分類的錯誤數（parse 為解析錯誤）")
+	kinds := make([]string, 0, len(snap.ErrorsByType))
+	for kind := range snap.ErrorsByType {
+		kinds = append(kinds, kind)
+	}
+	slices.Sort(kinds)
+	for _, kind := range kinds {
+		fmt.Fprintf(bw, "%serrors_total{type=%q} %d\n", metricPrefix, kind, snap.ErrorsByType[kind])
+	}
+
+	writeHeader(bw, "download_failures_total", "counter", "依失敗原因分類的圖片下載失敗數")
+	reasons := make([]string, 0, len(snap.DownloadFailures))
+	for reason := range snap.DownloadFailures {
+		reasons = append(reasons, reason)
+	}
+	slices.Sort(reasons)
+	for _, reason := range reasons {
+		fmt.Fprintf(bw, "%sdownload_failures_total{reason=%q} %d\n", metricPrefix, reason, snap.DownloadFailures[reason])
+	}
+
+	writeHeader(bw, "request_duration_seconds", "histogram", "對外 HTTP 請求耗時（秒）")
+	for _, b := range snap.LatencyBuckets {
+		fmt.Fprintf(bw, "%srequest_duration_seconds_bucket{le=%q} %d\n",
+			metricPrefix, strconv.FormatFloat(b.UpperBound.Seconds(), 'g', -1, 64), b.Count)
+	}
+	fmt.Fprintf(bw, "%srequest_duration_seconds_bucket{le=\"+Inf\"} %d\n", metricPrefix, snap.TotalRequests)
+	fmt.Fprintf(bw, "%srequest_duration_seconds_sum %s\n",
+		metricPrefix, strconv.FormatFloat(snap.LatencySum.Seconds(), 'g', -1, 64))
+	fmt.Fprintf(bw, "%srequest_duration_seconds_count %d\n", metricPrefix, snap.TotalRequests)
+
+	return bw.Flush()
+}
+
+func writeHeader(w io.Writer, name, kind, help string) {
+	fmt.Fprintf(w, "# HELP %s%s %s\n# TYPE %s%s %s\n", metricPrefix, name, help, metricPrefix, name, kind)
+}
+
+func writeCounter(w io.Writer, name, help string, value int64) {
+	writeHeader(w, name, "counter", help)
+	fmt.Fprintf(w, "%s%s %d\n", metricPrefix, name, value)
+}
+
+// Handler 回傳以 Prometheus 格式輸出收集器目前指標的 http.Handler
+func Handler(c interfaces.MetricsCollector) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+		_ = WritePrometheus(w, c.Snapshot())
+	})
+}
+
+// Serve 在 ln 上提供 /metrics，阻塞直到 ctx 取消後完成關閉。
+// 由呼叫端先建立 listener，讓位址被占用等錯誤能在爬蟲啟動前回報；
+// ctx 取消後正常關閉時回傳 nil。
+func Serve(ctx context.Context, ln net.Listener, c interfaces.MetricsCollector) error {
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", Handler(c))
+	srv := &http.Server{Handler: mux, ReadHeaderTimeout: 10 * time.Second}
+
+	errCh := make(chan error, 1)
+	go func() { errCh <- srv.Serve(ln) }()
+
+	select {
+	case err := <-errCh:
+		return err
+	case <-ctx.Done():
+	}
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+	if err := srv.Shutdown(shutdownCtx); err != nil {
+		return fmt.Errorf("關閉 metrics 伺服器失敗: %w", err)
+	}
+	if err := <-errCh; err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,126 @@
+package metrics
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/twtrubiks/ptt-spider-go/types"
+)
+
+func TestWritePrometheus(t *testing.T) {
+	snap := types.MetricsSnapshot{
+		TotalRequests:    3,
+		ErrorsByType:     map[string]int64{ErrorParse: 2, ErrorNetwork: 1},
+		StatusCodes:      map[int]int64{404: 1, 200: 2},
+		ImagesDownloaded: 5,
+		BytesWritten:     4096,
+		ImagesPrechecked: 4,
+		BytesExpected:    8192,
+		ArticlesParsed:   7,
+		DownloadFailures: map[string]int64{"rate_limited": 3},
+		LatencySum:       1500 * time.Millisecond,
+		LatencyBuckets: []types.LatencyBucket{
+			{UpperBound: 100 * time.Millisecond, Count: 1},
+			{UpperBound: 2500 * time.Millisecond, Count: 3},
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := WritePrometheus(&buf, snap); err != nil {
+		t.Fatalf("WritePrometheus() error = %v", err)
+	}
+	out := buf.String()
+
+	wantLines := []string{
+		"# TYPE ptt_spider_requests_total counter",
+		"ptt_spider_requests_total 3",
+		"ptt_spider_images_downloaded_total 5",
+		"ptt_spider_bytes_written_total 4096",
+		"ptt_spider_images_prechecked_total 4",
+		"ptt_spider_bytes_expected_total 8192",
+		"ptt_spider_articles_parsed_total 7",
+		`ptt_spider_responses_total{code="200"} 2`,
+		`ptt_spider_errors_total{type="parse"} 2`,
+		"# TYPE ptt_spider_download_failures_total counter",
+		`ptt_spider_download_failures_total{reason="rate_limited"} 3`,
+		"# TYPE ptt_spider_request_duration_seconds histogram",
+		`ptt_spider_request_duration_seconds_bucket{le="0.1"} 1`,
+		`ptt_spider_request_duration_seconds_bucket{le="2.5"} 3`,
+		`ptt_spider_request_duration_seconds_bucket{le="+Inf"} 3`,
+		"ptt_spider_request_duration_seconds_sum 1.5",
+		"ptt_spider_request_duration_seconds_count 3",
+	}
+	for _, want := range wantLines {
+		if !strings.Contains(out, want+"\n") {
+			t.Errorf("輸出缺少 %q\n完整輸出:\n%s", want, out)
+		}
+	}
+
+	// 標籤依值排序，輸出才會穩定
+	if strings.Index(out, `code="200"`) > strings.Index(out, `code="404"`) {
+		t.Error("狀態碼標籤應依數值排序")
+	}
+	if strings.Index(out, `type="network"`) > strings.Index(out, `type="parse"`) {
+		t.Error("錯誤類型標籤應依字母排序")
+	}
+}
+
+func TestHandler(t *testing.T) {
+	c := NewCollector()
+	c.RecordRequest(200, 10*time.Millisecond, nil)
+	c.RecordImageDownloaded(100)
+
+	rec := httptest.NewRecorder()
+	Handler(c).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+
+	if ct := rec.Header().Get("Content-Type"); !strings.HasPrefix(ct, "text/plain; version=0.0.4") {
+		t.Errorf("Content-Type = %q, want Prometheus text format", ct)
+	}
+	body := rec.Body.String()
+	for _, want := range []string{"ptt_spider_images_downloaded_total 1\n", `ptt_spider_request_duration_seconds_bucket{le="0.01"} 1`} {
+		if !strings.Contains(body, want) {
+			t.Errorf("回應缺少 %q", want)
+		}
+	}
+}
+
+func TestServe_ShutdownOnCancel(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	errCh := make(chan error, 1)
+	go func() { errCh <- Serve(ctx, ln, NewCollector()) }()
+
+	resp, err := http.Get("http://" + ln.Addr().String() + "/metrics")
+	if err != nil {
+		t.Fatalf("GET /metrics: %v", err)
+	}
+	body, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK || !strings.Contains(string(body), "ptt_spider_requests_total 0") {
+		t.Errorf("GET /metrics = %d %q", resp.StatusCode, body)
+	}
+
+	cancel()
+	select {
+	case err := <-errCh:
+		if err != nil {
+			t.Errorf("Serve() error = %v, want nil", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("ctx 取消後伺服器未關閉")
+	}
+
+	if _, err := net.DialTimeout("tcp", ln.Addr().String(), time.Second); err == nil {
+		t.Error("伺服器關閉後仍可連線")
+	}
+}
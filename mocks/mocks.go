@@ -2,9 +2,11 @@
 package mocks
 
 import (
+	"context"
 	"io"
 	"net/http"
 	"strings"
+	"time"
 
 	"github.com/twtrubiks/ptt-spider-go/types"
 )
@@ -28,7 +30,8 @@ func (m *MockHTTPClient) Do(req *http.Request) (*http.Response, error) {
 // MockParser 模擬解析器
 type MockParser struct {
 	ParseArticlesFunc       func(body io.Reader) ([]types.ArticleInfo, error)
-	ParseArticleContentFunc func(body io.Reader) (string, []string, error)
+	ParseArticleContentFunc func(body io.Reader) (types.ArticleContent, error)
+	ParseArticlePushesFunc  func(body io.Reader) ([]types.Push, error)
 	ParseMaxPageFunc        func(body io.Reader) (int, error)
 }
 
@@ -41,11 +44,23 @@ func (m *MockParser) ParseArticles(body io.Reader) ([]types.ArticleInfo, error)
 }
 
 // ParseArticleContent 呼叫 ParseArticleContentFunc（若已設定），否則回傳固定的測試資料
-func (m *MockParser) ParseArticleContent(body io.Reader) (string, []string, error) {
+func (m *MockParser) ParseArticleContent(body io.Reader) (types.ArticleContent, error) {
 	if m.ParseArticleContentFunc != nil {
 		return m.ParseArticleContentFunc(body)
 	}
-	return "Mock Title", []string{"http://example.com/image.jpg"}, nil
+	return types.ArticleContent{
+		Title:     "Mock Title",
+		Author:    "mockuser",
+		ImageURLs: []string{"http://example.com/image.jpg"},
+	}, nil
+}
+
+// ParseArticlePushes 呼叫 ParseArticlePushesFunc（若已設定），否則回傳空列表
+func (m *MockParser) ParseArticlePushes(body io.Reader) ([]types.Push, error) {
+	if m.ParseArticlePushesFunc != nil {
+		return m.ParseArticlePushesFunc(body)
+	}
+	return nil, nil
 }
 
 // ParseMaxPage 呼叫 ParseMaxPageFunc（若已設定），否則回傳 100
@@ -58,7 +73,8 @@ func (m *MockParser) ParseMaxPage(body io.Reader) (int, error) {
 
 // MockMarkdownGenerator 模擬 Markdown 生成器
 type MockMarkdownGenerator struct {
-	GenerateFunc func(info types.MarkdownInfo) error
+	GenerateFunc      func(info types.MarkdownInfo) error
+	GenerateIndexFunc func(dir string, articles []types.MarkdownInfo) error
 }
 
 // Generate 呼叫 GenerateFunc（若已設定），否則回傳 nil
@@ -69,14 +85,30 @@ func (m *MockMarkdownGenerator) Generate(info types.MarkdownInfo) error {
 	return nil
 }
 
+// GenerateIndex 呼叫 GenerateIndexFunc（若已設定），否則回傳 nil
+func (m *MockMarkdownGenerator) GenerateIndex(dir string, articles []types.MarkdownInfo) error {
+	if m.GenerateIndexFunc != nil {
+		return m.GenerateIndexFunc(dir, articles)
+	}
+	return nil
+}
+
 // MockLogger 模擬 Logger，可捕捉各等級的日誌呼叫
 type MockLogger struct {
+	DebugFunc   func(format string, args ...any)
 	InfoFunc    func(format string, args ...any)
 	SuccessFunc func(format string, args ...any)
 	ErrorFunc   func(format string, args ...any)
 	WarnFunc    func(format string, args ...any)
 }
 
+// Debug 呼叫 DebugFunc（若已設定）
+func (m *MockLogger) Debug(format string, args ...any) {
+	if m.DebugFunc != nil {
+		m.DebugFunc(format, args...)
+	}
+}
+
 // Info 呼叫 InfoFunc（若已設定）
 func (m *MockLogger) Info(format string, args ...any) {
 	if m.InfoFunc != nil {
@@ -105,6 +137,28 @@ func (m *MockLogger) Warn(format string, args ...any) {
 	}
 }
 
+// MockRateLimiter 模擬速率限制器
+type MockRateLimiter struct {
+	AllowFunc func() bool
+	WaitFunc  func(ctx context.Context) error
+}
+
+// Allow 呼叫 AllowFunc（若已設定），否則回傳 true
+func (m *MockRateLimiter) Allow() bool {
+	if m.AllowFunc != nil {
+		return m.AllowFunc()
+	}
+	return true
+}
+
+// Wait 呼叫 WaitFunc（若已設定），否則立即回傳 nil
+func (m *MockRateLimiter) Wait(ctx context.Context) error {
+	if m.WaitFunc != nil {
+		return m.WaitFunc(ctx)
+	}
+	return nil
+}
+
 // NewMockHTTPClient 建立新的模擬 HTTP 客戶端
 func NewMockHTTPClient() *MockHTTPClient {
 	return &MockHTTPClient{}
@@ -119,3 +173,64 @@ func NewMockParser() *MockParser {
 func NewMockMarkdownGenerator() *MockMarkdownGenerator {
 	return &MockMarkdownGenerator{}
 }
+
+// MockMetricsCollector 模擬指標收集器
+type MockMetricsCollector struct {
+	RecordRequestFunc         func(statusCode int, duration time.Duration, err error)
+	RecordErrorFunc           func(kind string)
+	RecordImageDownloadedFunc func(bytes int64)
+	RecordImagePrecheckedFunc func(bytes int64)
+	RecordDownloadFailureFunc func(reason string)
+	RecordArticleParsedFunc   func()
+	SnapshotFunc              func() types.MetricsSnapshot
+}
+
+// RecordRequest 呼叫 RecordRequestFunc（若已設定）
+func (m *MockMetricsCollector) RecordRequest(statusCode int, duration time.Duration, err error) {
+	if m.RecordRequestFunc != nil {
+		m.RecordRequestFunc(statusCode, duration, err)
+	}
+}
+
+// RecordError 呼叫 RecordErrorFunc（若已設定）
+func (m *MockMetricsCollector) RecordError(kind string) {
+	if m.RecordErrorFunc != nil {
+		m.RecordErrorFunc(kind)
+	}
+}
+
+// RecordImageDownloaded 呼叫 RecordImageDownloadedFunc（若已設定）
+func (m *MockMetricsCollector) RecordImageDownloaded(bytes int64) {
+	if m.RecordImageDownloadedFunc != nil {
+		m.RecordImageDownloadedFunc(bytes)
+	}
+}
+
+// RecordImagePrechecked 呼叫 RecordImagePrecheckedFunc（若已設定）
+func (m *MockMetricsCollector) RecordImagePrechecked(bytes int64) {
+	if m.RecordImagePrecheckedFunc != nil {
+		m.RecordImagePrecheckedFunc(bytes)
+	}
+}
+
+// RecordDownloadFailure 呼叫 RecordDownloadFailureFunc（若已設定）
+func (m *MockMetricsCollector) RecordDownloadFailure(reason string) {
+	if m.RecordDownloadFailureFunc != nil {
+		m.RecordDownloadFailureFunc(reason)
+	}
+}
+
+// RecordArticleParsed 呼叫 RecordArticleParsedFunc（若已設定）
+func (m *MockMetricsCollector) RecordArticleParsed() {
+	if m.RecordArticleParsedFunc != nil {
+		m.RecordArticleParsedFunc()
+	}
+}
+
+// Snapshot 呼叫 SnapshotFunc（若已設定），否則回傳零值快照
+func (m *MockMetricsCollector) Snapshot() types.MetricsSnapshot {
+	if m.SnapshotFunc != nil {
+		return m.SnapshotFunc()
+	}
+	return types.MetricsSnapshot{}
+}
@@ -1,11 +1,13 @@
 package mocks
 
 import (
+	"context"
 	"fmt"
 	"io"
 	"net/http"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/twtrubiks/ptt-spider-go/types"
 )
@@ -111,10 +113,14 @@ func testCustomParseArticles(t *testing.T, parser *MockParser) {
 }
 
 func testDefaultParseArticleContent(t *testing.T, parser *MockParser) {
-	title, images, err := parser.ParseArticleContent(strings.NewReader(""))
+	content, err := parser.ParseArticleContent(strings.NewReader(""))
 	if err != nil {
 		t.Errorf("Expected no error, got %v", err)
 	}
+	title, images := content.Title, content.ImageURLs
+	if content.Author != "mockuser" {
+		t.Errorf("Expected 'mockuser', got '%s'", content.Author)
+	}
 	if title != "Mock Title" {
 		t.Errorf("Expected 'Mock Title', got '%s'", title)
 	}
@@ -124,14 +130,18 @@ func testDefaultParseArticleContent(t *testing.T, parser *MockParser) {
 }
 
 func testCustomParseArticleContent(t *testing.T, parser *MockParser) {
-	parser.ParseArticleContentFunc = func(_ io.Reader) (string, []string, error) {
-		return "Custom Title", []string{"http://custom.com/image1.jpg", "http://custom.com/image2.jpg"}, nil
+	parser.ParseArticleContentFunc = func(_ io.Reader) (types.ArticleContent, error) {
+		return types.ArticleContent{
+			Title:     "Custom Title",
+			ImageURLs: []string{"http://custom.com/image1.jpg", "http://custom.com/image2.jpg"},
+		}, nil
 	}
 
-	title, images, err := parser.ParseArticleContent(strings.NewReader(""))
+	content, err := parser.ParseArticleContent(strings.NewReader(""))
 	if err != nil {
 		t.Errorf("Expected no error, got %v", err)
 	}
+	title, images := content.Title, content.ImageURLs
 	if title != "Custom Title" {
 		t.Errorf("Expected 'Custom Title', got '%s'", title)
 	}
@@ -207,3 +217,45 @@ func TestMockMarkdownGenerator(t *testing.T) {
 		t.Errorf("Expected 'generation failed', got '%s'", err.Error())
 	}
 }
+
+func TestMockRateLimiter(t *testing.T) {
+	limiter := &MockRateLimiter{}
+	if !limiter.Allow() {
+		t.Error("預設 Allow 應回傳 true")
+	}
+	if err := limiter.Wait(context.Background()); err != nil {
+		t.Errorf("預設 Wait 應回傳 nil，got %v", err)
+	}
+
+	limiter.AllowFunc = func() bool { return false }
+	limiter.WaitFunc = func(_ context.Context) error { return context.Canceled }
+	if limiter.Allow() {
+		t.Error("自訂 AllowFunc 應回傳 false")
+	}
+	if err := limiter.Wait(context.Background()); err != context.Canceled {
+		t.Errorf("自訂 WaitFunc 應回傳 context.Canceled，got %v", err)
+	}
+}
+
+func TestMockMetricsCollector(t *testing.T) {
+	collector := &MockMetricsCollector{}
+	// 未設定函式時不應 panic，Snapshot 回傳零值
+	collector.RecordRequest(200, time.Millisecond, nil)
+	collector.RecordError("parse")
+	collector.RecordImageDownloaded(10)
+	collector.RecordArticleParsed()
+	if snap := collector.Snapshot(); snap.TotalRequests != 0 {
+		t.Errorf("預設 Snapshot 應為零值，got %+v", snap)
+	}
+
+	var requests int
+	collector.RecordRequestFunc = func(_ int, _ time.Duration, _ error) { requests++ }
+	collector.SnapshotFunc = func() types.MetricsSnapshot { return types.MetricsSnapshot{TotalRequests: 7} }
+	collector.RecordRequest(200, time.Millisecond, nil)
+	if requests != 1 {
+		t.Errorf("RecordRequestFunc 應被呼叫 1 次，got %d", requests)
+	}
+	if snap := collector.Snapshot(); snap.TotalRequests != 7 {
+		t.Errorf("自訂 SnapshotFunc 應回傳 TotalRequests=7，got %d", snap.TotalRequests)
+	}
+}
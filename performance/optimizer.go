@@ -1,11 +1,12 @@
 // Package performance 提供效能監控工具，
-// 包括記憶體狀態監控和統計資訊。
+// 包括記憶體狀態監控、統計資訊，以及記憶體超過門檻時觸發 GC。
 package performance
 
 import (
 	"context"
 	"fmt"
 	"runtime"
+	"strings"
 	"sync"
 	"time"
 )
@@ -16,19 +17,27 @@ type Logger interface {
 	Info(format string, args ...any)
 }
 
-// Optimizer 效能監控器
+// Optimizer 效能監控器：每隔 monitorInterval 輸出記憶體統計，
+// 堆積記憶體超過 memoryThreshold 時觸發 GC
 type Optimizer struct {
+	memoryThreshold uint64 // 觸發 GC 的堆積記憶體門檻（bytes），0 表示不主動 GC
 	monitorInterval time.Duration
 	logger          Logger
+	gc              func() // 觸發 GC 的函式，預設為 runtime.GC（測試可替換）
 	stopChan        chan struct{}
 	stopOnce        sync.Once
+
+	pipelineMu sync.Mutex
+	pipeline   func() PipelineStats // 管線佇列統計的來源（SetPipelineStats），nil 時不輸出
 }
 
-// NewOptimizer 建立新的效能監控器
-func NewOptimizer(monitorInterval time.Duration, logger Logger) *Optimizer {
+// NewOptimizer 建立新的效能監控器，memoryThresholdMB 為觸發 GC 的堆積記憶體門檻（MiB），0 表示不主動 GC
+func NewOptimizer(memoryThresholdMB int, monitorInterval time.Duration, logger Logger) *Optimizer {
 	return &Optimizer{
+		memoryThreshold: uint64(max(memoryThresholdMB, 0)) * 1024 * 1024,
 		monitorInterval: monitorInterval,
 		logger:          logger,
+		gc:              runtime.GC,
 		stopChan:        make(chan struct{}),
 	}
 }
@@ -47,7 +56,12 @@ func (o *Optimizer) Start(ctx context.Context) {
 				return
 			case <-ticker.C:
 				stats := o.GetMemoryStats()
-				o.logger.Info("記憶體監控: %s", stats.String())
+				if pipeline := o.GetPipelineStats(); len(pipeline) > 0 {
+					o.logger.Info("記憶體監控: %s, %s", stats.String(), pipeline.String())
+				} else {
+					o.logger.Info("記憶體監控: %s", stats.String())
+				}
+				o.checkMemory(stats)
 			}
 		}
 	}()
@@ -60,6 +74,35 @@ func (o *Optimizer) Stop() {
 	})
 }
 
+// checkMemory 在堆積記憶體超過門檻時觸發 GC，回傳是否已觸發
+func (o *Optimizer) checkMemory(stats MemoryStats) bool {
+	if o.memoryThreshold == 0 || stats.HeapAlloc <= o.memoryThreshold {
+		return false
+	}
+	o.logger.Info("堆積記憶體 %s 超過門檻 %s，觸發 GC", FormatBytes(stats.HeapAlloc), FormatBytes(o.memoryThreshold))
+	o.gc()
+	return true
+}
+
+// SetPipelineStats 設定管線佇列統計的來源，設定後定期監控會一併輸出各佇列的填充程度。
+// 佇列通常在監控啟動後才建立，因此可在 Start 之後呼叫；nil 表示不輸出
+func (o *Optimizer) SetPipelineStats(f func() PipelineStats) {
+	o.pipelineMu.Lock()
+	defer o.pipelineMu.Unlock()
+	o.pipeline = f
+}
+
+// GetPipelineStats 獲取管線佇列統計，未設定來源時為 nil
+func (o *Optimizer) GetPipelineStats() PipelineStats {
+	o.pipelineMu.Lock()
+	f := o.pipeline
+	o.pipelineMu.Unlock()
+	if f == nil {
+		return nil
+	}
+	return f()
+}
+
 // GetMemoryStats 獲取記憶體統計資訊
 func (o *Optimizer) GetMemoryStats() MemoryStats {
 	var m runtime.MemStats
@@ -97,15 +140,43 @@ type MemoryStats struct {
 func (ms MemoryStats) String() string {
 	return fmt.Sprintf(
 		"Memory: Alloc=%s, Sys=%s, NumGC=%d, Goroutines=%d",
-		formatBytes(ms.Alloc),
-		formatBytes(ms.Sys),
+		FormatBytes(ms.Alloc),
+		FormatBytes(ms.Sys),
 		ms.NumGC,
 		ms.NumGoroutine,
 	)
 }
 
-// formatBytes 格式化 bytes 為可讀格式
-func formatBytes(bytes uint64) string {
+// QueueStats 管線中一個佇列（channel）目前積壓的數量與緩衝容量
+type QueueStats struct {
+	Name string // 佇列名稱（如「下載」）
+	Len  int    // 目前積壓的數量
+	Cap  int    // 緩衝容量
+}
+
+// Fill 返回佇列的填充比例（0~1），無緩衝的佇列為 0
+func (q QueueStats) Fill() float64 {
+	if q.Cap <= 0 {
+		return 0
+	}
+	return float64(q.Len) / float64(q.Cap)
+}
+
+// PipelineStats 管線各佇列的填充程度，依管線順序排列。
+// 某個佇列長期接近滿載表示其下游是瓶頸（背壓），接近空則表示上游供應不足
+type PipelineStats []QueueStats
+
+// String 返回管線統計的字串表示，如 "Queues: 文章=5/100 (5%), 下載=1000/1000 (100%)"
+func (ps PipelineStats) String() string {
+	parts := make([]string, len(ps))
+	for i, q := range ps {
+		parts[i] = fmt.Sprintf("%s=%d/%d (%.0f%%)", q.Name, q.Len, q.Cap, q.Fill()*100)
+	}
+	return "Queues: " + strings.Join(parts, ", ")
+}
+
+// FormatBytes 格式化 bytes 為可讀格式（如 1.5 MiB）
+func FormatBytes(bytes uint64) string {
 	const unit = 1024
 	if bytes < unit {
 		return fmt.Sprintf("%d B", bytes)
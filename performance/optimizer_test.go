@@ -3,6 +3,9 @@ package performance
 import (
 	"context"
 	"fmt"
+	"runtime"
+	"strings"
+	"sync"
 	"testing"
 	"time"
 )
@@ -13,17 +16,111 @@ type testLogger struct{}
 func (testLogger) Info(string, ...any) {}
 
 func TestNewOptimizer(t *testing.T) {
-	opt := NewOptimizer(5*time.Second, testLogger{})
+	opt := NewOptimizer(100, 5*time.Second, testLogger{})
 	if opt == nil {
 		t.Fatal("NewOptimizer returned nil")
 	}
 	if opt.monitorInterval != 5*time.Second {
 		t.Errorf("monitorInterval = %v, want %v", opt.monitorInterval, 5*time.Second)
 	}
+	if opt.memoryThreshold != 100*1024*1024 {
+		t.Errorf("memoryThreshold = %d, want %d", opt.memoryThreshold, 100*1024*1024)
+	}
+}
+
+func TestOptimizer_CheckMemory(t *testing.T) {
+	const mib = 1024 * 1024
+	tests := []struct {
+		name      string
+		threshold int
+		heap      uint64
+		wantGC    bool
+	}{
+		{"低於門檻不觸發", 100, 50 * mib, false},
+		{"等於門檻不觸發", 100, 100 * mib, false},
+		{"超過門檻觸發", 100, 150 * mib, true},
+		{"門檻為 0 不主動 GC", 0, 150 * mib, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opt := NewOptimizer(tt.threshold, time.Second, testLogger{})
+			calls := 0
+			opt.gc = func() { calls++ }
+
+			if got := opt.checkMemory(MemoryStats{HeapAlloc: tt.heap}); got != tt.wantGC {
+				t.Errorf("checkMemory() = %v, want %v", got, tt.wantGC)
+			}
+			if want := map[bool]int{false: 0, true: 1}[tt.wantGC]; calls != want {
+				t.Errorf("gc 呼叫 %d 次, want %d", calls, want)
+			}
+		})
+	}
+}
+
+// tickLogger 在每次定期監控輸出時送出通知，供測試等待已知次數的 tick
+type tickLogger struct{ ticks chan<- struct{} }
+
+func (l tickLogger) Info(format string, _ ...any) {
+	if strings.HasPrefix(format, "記憶體監控") {
+		select {
+		case l.ticks <- struct{}{}:
+		default:
+		}
+	}
+}
+
+func TestOptimizer_MonitorRespectsThreshold(t *testing.T) {
+	// 持有 8 MiB 的配置，確保堆積記憶體超過 1 MiB 門檻而遠低於 1 TiB 門檻
+	ballast := make([]byte, 8*1024*1024)
+	defer runtime.KeepAlive(ballast)
+
+	const waitTicks = 3
+	tests := []struct {
+		name      string
+		threshold int
+		wantGC    bool
+	}{
+		{"超過門檻時觸發 GC", 1, true},
+		{"未超過門檻時不觸發 GC", 1024 * 1024, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ticks := make(chan struct{}, waitTicks)
+			gcCalls := make(chan struct{}, waitTicks+1)
+			opt := NewOptimizer(tt.threshold, 5*time.Millisecond, tickLogger{ticks: ticks})
+			opt.gc = func() {
+				select {
+				case gcCalls <- struct{}{}:
+				default:
+				}
+			}
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			opt.Start(ctx)
+			// 同一個 tick 先輸出監控再檢查門檻，收到第 n 次輸出時前 n-1 次的檢查必定已完成
+			for i := range waitTicks {
+				select {
+				case <-ticks:
+				case <-time.After(5 * time.Second):
+					t.Fatalf("等待第 %d 次監控逾時", i+1)
+				}
+			}
+			opt.Stop()
+
+			got := len(gcCalls)
+			if tt.wantGC && got < waitTicks-1 {
+				t.Errorf("gc 呼叫 %d 次, want 至少 %d 次", got, waitTicks-1)
+			}
+			if !tt.wantGC && got != 0 {
+				t.Errorf("gc 呼叫 %d 次, want 0", got)
+			}
+		})
+	}
 }
 
 func TestOptimizer_StartAndStop(_ *testing.T) {
-	opt := NewOptimizer(50*time.Millisecond, testLogger{})
+	opt := NewOptimizer(100, 50*time.Millisecond, testLogger{})
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
@@ -37,7 +134,7 @@ func TestOptimizer_StartAndStop(_ *testing.T) {
 }
 
 func TestOptimizer_StopMultipleCalls(_ *testing.T) {
-	opt := NewOptimizer(time.Second, testLogger{})
+	opt := NewOptimizer(100, time.Second, testLogger{})
 	ctx := context.Background()
 	opt.Start(ctx)
 
@@ -48,7 +145,7 @@ func TestOptimizer_StopMultipleCalls(_ *testing.T) {
 }
 
 func TestOptimizer_StartContextCancel(_ *testing.T) {
-	opt := NewOptimizer(50*time.Millisecond, testLogger{})
+	opt := NewOptimizer(100, 50*time.Millisecond, testLogger{})
 	ctx, cancel := context.WithCancel(context.Background())
 
 	opt.Start(ctx)
@@ -60,7 +157,7 @@ func TestOptimizer_StartContextCancel(_ *testing.T) {
 }
 
 func TestOptimizer_MonitorLogsMemory(t *testing.T) {
-	opt := NewOptimizer(30*time.Millisecond, testLogger{})
+	opt := NewOptimizer(100, 30*time.Millisecond, testLogger{})
 
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
@@ -79,7 +176,7 @@ func TestOptimizer_MonitorLogsMemory(t *testing.T) {
 }
 
 func TestGetMemoryStats(t *testing.T) {
-	opt := NewOptimizer(time.Second, testLogger{})
+	opt := NewOptimizer(100, time.Second, testLogger{})
 	stats := opt.GetMemoryStats()
 
 	if stats.Alloc == 0 {
@@ -107,6 +204,67 @@ func TestMemoryStats_String(t *testing.T) {
 	}
 }
 
+func TestPipelineStats_String(t *testing.T) {
+	stats := PipelineStats{
+		{Name: "文章", Len: 5, Cap: 100},
+		{Name: "下載", Len: 1000, Cap: 1000},
+		{Name: "Markdown", Len: 0, Cap: 0},
+	}
+	want := "Queues: 文章=5/100 (5%), 下載=1000/1000 (100%), Markdown=0/0 (0%)"
+	if got := stats.String(); got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+}
+
+// recordingLogger 記錄格式化後的訊息
+type recordingLogger struct {
+	mu    sync.Mutex
+	lines []string
+}
+
+func (l *recordingLogger) Info(format string, args ...any) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.lines = append(l.lines, fmt.Sprintf(format, args...))
+}
+
+func (l *recordingLogger) last() string {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if len(l.lines) == 0 {
+		return ""
+	}
+	return l.lines[len(l.lines)-1]
+}
+
+func TestOptimizer_MonitorLogsPipelineStats(t *testing.T) {
+	logger := &recordingLogger{}
+	opt := NewOptimizer(100, 5*time.Millisecond, logger)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	opt.Start(ctx)
+	defer opt.Stop()
+	// 監控啟動後才設定來源，與爬蟲在建立佇列後設定的順序相同
+	queue := make(chan int, 4)
+	queue <- 1
+	opt.SetPipelineStats(func() PipelineStats {
+		return PipelineStats{{Name: "下載", Len: len(queue), Cap: cap(queue)}}
+	})
+
+	want := ", Queues: 下載=1/4 (25%)"
+	deadline := time.Now().Add(2 * time.Second)
+	for !strings.HasSuffix(logger.last(), want) {
+		if time.Now().After(deadline) {
+			t.Fatalf("記憶體監控應一併輸出佇列填充程度，last = %q", logger.last())
+		}
+		time.Sleep(time.Millisecond)
+	}
+	if got := opt.GetPipelineStats(); len(got) != 1 || got[0].Fill() != 0.25 {
+		t.Errorf("GetPipelineStats() = %v", got)
+	}
+}
+
 func TestFormatBytes(t *testing.T) {
 	tests := []struct {
 		input    uint64
@@ -121,9 +279,9 @@ func TestFormatBytes(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(fmt.Sprintf("%d", tt.input), func(t *testing.T) {
-			result := formatBytes(tt.input)
+			result := FormatBytes(tt.input)
 			if result != tt.expected {
-				t.Errorf("formatBytes(%d) = %q, want %q", tt.input, result, tt.expected)
+				t.Errorf("FormatBytes(%d) = %q, want %q", tt.input, result, tt.expected)
 			}
 		})
 	}
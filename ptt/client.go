@@ -3,24 +3,85 @@ package ptt
 
 import (
 	"fmt"
+	"hash/fnv"
+	"math/rand/v2"
 	"net/http"
 	"net/http/cookiejar"
 	"net/url"
+	"strings"
+	"sync/atomic"
 
 	"github.com/twtrubiks/ptt-spider-go/config"
 	"github.com/twtrubiks/ptt-spider-go/constants"
 )
 
-// customTransport 是一個自訂的 http.RoundTripper，用於在請求中加入 User-Agent
+// customTransport 是一個自訂的 http.RoundTripper，用於在請求中加入 User-Agent 與自訂標頭。
+// userAgents 為空時固定使用 constants.DefaultUserAgent；有多個時每個請求輪替或隨機挑選一個
 type customTransport struct {
-	transport http.RoundTripper
+	transport  http.RoundTripper
+	userAgents []string
+	random     bool          // true 時隨機挑選，否則依序輪替
+	next       atomic.Uint64 // 輪替模式下一個要使用的索引
+	headers    http.Header   // 每個請求額外加入的標頭
+
+	// 指定 seed 時以 seed 與請求 URL 衍生亂數（0 時使用全域亂數），
+	// 同一 URL 挑到的 User-Agent 固定，不受 worker 排程與請求順序影響
+	seed uint64
 }
 
-// RoundTrip 攔截請求，加入 User-Agent 標頭，然後繼續發送請求。
+// newCustomTransport 建立以 userAgents 輪替 User-Agent 的 transport，rotation 為 config.UARotationRandom 時隨機挑選；
+// headers 為每個請求額外加入的標頭
+func newCustomTransport(transport http.RoundTripper, userAgents []string, rotation string, headers map[string]string) *customTransport {
+	h := make(http.Header, len(headers))
+	for name, value := range headers {
+		h.Set(name, value)
+	}
+	return &customTransport{
+		transport:  transport,
+		userAgents: userAgents,
+		random:     rotation == config.UARotationRandom,
+		headers:    h,
+	}
+}
+
+// userAgent 回傳請求 key（URL）使用的 User-Agent，可供多個 goroutine 並行呼叫
+func (t *customTransport) userAgent(key string) string {
+	switch n := len(t.userAgents); {
+	case n == 0:
+		return constants.DefaultUserAgent
+	case n == 1:
+		return t.userAgents[0]
+	case t.random:
+		return t.userAgents[t.intN(key, n)]
+	default:
+		return t.userAgents[(t.next.Add(1)-1)%uint64(n)]
+	}
+}
+
+// intN 回傳 [0, n) 的隨機整數，指定 seed 時以 seed 與 key 衍生，重跑時可重現
+func (t *customTransport) intN(key string, n int) int {
+	if t.seed == 0 {
+		return rand.IntN(n)
+	}
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(key))
+	return rand.New(rand.NewPCG(t.seed, h.Sum64())).IntN(n)
+}
+
+// RoundTrip 攔截請求，加入自訂標頭與 User-Agent，然後繼續發送請求。
+// 自訂標頭不覆寫請求本身已設定的同名標頭（如下載圖片時的 Accept）；
+// 自訂標頭含 User-Agent 時優先於 userAgents 清單。
 // 依照 http.RoundTripper 契約，不修改原始 request，而是 clone 後再設定 header。
 func (t *customTransport) RoundTrip(req *http.Request) (*http.Response, error) {
 	clone := req.Clone(req.Context())
-	clone.Header.Set("User-Agent", constants.DefaultUserAgent)
+	for name, values := range t.headers {
+		if _, ok := clone.Header[name]; !ok {
+			clone.Header[name] = values
+		}
+	}
+	if clone.Header.Get("User-Agent") == "" {
+		clone.Header.Set("User-Agent", t.userAgent(clone.URL.String()))
+	}
 	transport := t.transport
 	if transport == nil {
 		transport = http.DefaultTransport
@@ -28,8 +89,8 @@ func (t *customTransport) RoundTrip(req *http.Request) (*http.Response, error) {
 	return transport.RoundTrip(clone)
 }
 
-// configureCookies 為客戶端配置 over18 cookie
-func configureCookies(client *http.Client) error {
+// configureCookies 為客戶端配置 over18 cookie 與 extra 指定的額外 cookie
+func configureCookies(client *http.Client, extra []config.CookieConfig) error {
 	jar, err := cookiejar.New(nil)
 	if err != nil {
 		return fmt.Errorf("建立 cookie jar 失敗: %w", err)
@@ -47,10 +108,38 @@ func configureCookies(client *http.Client) error {
 		{Name: constants.Over18CookieName, Value: constants.Over18CookieValue, Path: "/"},
 	})
 
+	for _, c := range extra {
+		if err := setCookie(jar, c); err != nil {
+			return err
+		}
+	}
+
 	client.Jar = jar
 	return nil
 }
 
+// setCookie 將設定的 cookie 加入 jar：未指定 domain 時只送往 PTT（www.ptt.cc），
+// 未指定 path 時適用整個網站
+func setCookie(jar http.CookieJar, c config.CookieConfig) error {
+	if c.Name == "" {
+		return fmt.Errorf("cookie 缺少名稱（domain: %q）", c.Domain)
+	}
+	target := constants.PttBaseURL + "/"
+	if c.Domain != "" {
+		target = "https://" + strings.TrimPrefix(c.Domain, ".") + "/"
+	}
+	u, err := url.Parse(target)
+	if err != nil {
+		return fmt.Errorf("cookie %s 的 domain %q 無效: %w", c.Name, c.Domain, err)
+	}
+	path := c.Path
+	if path == "" {
+		path = "/"
+	}
+	jar.SetCookies(u, []*http.Cookie{{Name: c.Name, Value: c.Value, Domain: c.Domain, Path: path}})
+	return nil
+}
+
 // NewClient 建立一個新的 http 客戶端，並設定 over18 cookie
 func NewClient() (*http.Client, error) {
 	return newClientWithOptions(nil)
@@ -61,13 +150,41 @@ func NewClientWithConfig(cfg *config.Config) (*http.Client, error) {
 	return newClientWithOptions(cfg)
 }
 
+// proxyFunc 依 http.proxy 設定回傳 Transport.Proxy：
+// 未設定時沿用環境變數（HTTP_PROXY/HTTPS_PROXY/NO_PROXY），
+// 設定時所有請求都經由該代理（支援 http、https、socks5、socks5h）。
+// 格式錯誤時回傳錯誤而非退回直連，避免使用者以為流量經過代理（如 Tor）。
+func proxyFunc(proxy string) (func(*http.Request) (*url.URL, error), error) {
+	if proxy == "" {
+		return http.ProxyFromEnvironment, nil
+	}
+	u, err := url.Parse(proxy)
+	if err != nil {
+		return nil, fmt.Errorf("無效的代理位址 %q: %w", proxy, err)
+	}
+	switch u.Scheme {
+	case "http", "https", "socks5", "socks5h":
+	default:
+		return nil, fmt.Errorf("不支援的代理協定 %q（可用: http、https、socks5、socks5h）", u.Scheme)
+	}
+	if u.Host == "" {
+		return nil, fmt.Errorf("無效的代理位址 %q: 缺少主機", u.Redacted())
+	}
+	return http.ProxyURL(u), nil
+}
+
 // newClientWithOptions 統一的客戶端建立邏輯
 func newClientWithOptions(cfg *config.Config) (*http.Client, error) {
 	var transport http.RoundTripper
 
 	if cfg != nil {
 		// 建立優化的 HTTP Transport
+		proxy, err := proxyFunc(cfg.Crawler.HTTP.Proxy)
+		if err != nil {
+			return nil, err
+		}
 		httpTransport := &http.Transport{
+			Proxy:                 proxy,
 			MaxIdleConns:          cfg.Crawler.HTTP.MaxIdleConns,
 			MaxIdleConnsPerHost:   cfg.Crawler.HTTP.MaxIdleConnsPerHost,
 			IdleConnTimeout:       cfg.GetIdleConnTimeout(),
@@ -75,7 +192,10 @@ func newClientWithOptions(cfg *config.Config) (*http.Client, error) {
 			ExpectContinueTimeout: cfg.GetExpectContinueTimeout(),
 			DisableKeepAlives:     false, // 啟用 Keep-Alive
 		}
-		transport = &customTransport{transport: httpTransport}
+		ct := newCustomTransport(httpTransport,
+			cfg.Crawler.HTTP.UserAgents, cfg.Crawler.HTTP.UserAgentRotation, cfg.Crawler.HTTP.Headers)
+		ct.seed = uint64(cfg.Crawler.Seed)
+		transport = ct
 	} else {
 		transport = &customTransport{}
 	}
@@ -90,7 +210,11 @@ func newClientWithOptions(cfg *config.Config) (*http.Client, error) {
 	}
 
 	// 配置 cookies
-	if err := configureCookies(client); err != nil {
+	var cookies []config.CookieConfig
+	if cfg != nil {
+		cookies = cfg.Crawler.HTTP.Cookies
+	}
+	if err := configureCookies(client, cookies); err != nil {
 		return nil, fmt.Errorf("配置 cookie 失敗: %w", err)
 	}
 
@@ -0,0 +1,102 @@
+package ptt
+
+import (
+	"net/url"
+	"path"
+	"regexp"
+	"strings"
+)
+
+// DefaultImageExtensions 回傳預設視為圖片連結的副檔名
+func DefaultImageExtensions() []string {
+	return []string{".jpg", ".jpeg", ".png", ".gif", ".webp", ".bmp", ".avif"}
+}
+
+// newExtSet 將副檔名清單正規化為小寫、含前導點的集合，忽略空白項目；
+// 清單為空時回傳 nil，讓解析器沿用預設值
+func newExtSet(exts []string) map[string]struct{} {
+	var set map[string]struct{}
+	for _, ext := range exts {
+		ext = strings.ToLower(strings.TrimSpace(ext))
+		if ext == "" {
+			continue
+		}
+		if !strings.HasPrefix(ext, ".") {
+			ext = "." + ext
+		}
+		if set == nil {
+			set = make(map[string]struct{}, len(exts))
+		}
+		set[ext] = struct{}{}
+	}
+	return set
+}
+
+// isImageURL 回報連結的副檔名是否在 exts 中。
+// 以 URL path 判斷（忽略 query string 與 fragment），副檔名不分大小寫。
+func isImageURL(href string, exts map[string]struct{}) bool {
+	p := href
+	if u, err := url.Parse(href); err == nil {
+		p = u.Path
+	}
+	_, ok := exts[strings.ToLower(path.Ext(p))]
+	return ok
+}
+
+// upgradeToHTTPS 將文章中的連結統一為 https：http://、https://（scheme 不分大小寫）與協定相對的 //host/...
+// 只替換 scheme 部分，其餘保持原樣（不重新編碼 path 與 query）。
+// 其他 scheme（如 ftp:、javascript:、data:）、沒有 host 的連結（含只有 "http://"）或無法解析時回傳 false，應略過該連結。
+func upgradeToHTTPS(href string) (string, bool) {
+	href = strings.TrimSpace(href)
+	u, err := url.Parse(href)
+	if err != nil || u.Host == "" {
+		return "", false
+	}
+	switch {
+	case u.Scheme == "" && strings.HasPrefix(href, "//"):
+		return "https:" + href, true
+	case strings.EqualFold(u.Scheme, "http"), strings.EqualFold(u.Scheme, "https"):
+		return "https:" + href[len(u.Scheme)+1:], true
+	default:
+		return "", false
+	}
+}
+
+// imgurHosts 是 imgur 單圖頁面與圖片檔所在的 host
+var imgurHosts = map[string]struct{}{
+	"imgur.com":     {},
+	"www.imgur.com": {},
+	"m.imgur.com":   {},
+	"i.imgur.com":   {},
+}
+
+// imgurImageID 比對 imgur 單圖連結的 path：圖片 ID 為 5 碼（舊）或 7 碼英數字，如 /AbC123x
+var imgurImageID = regexp.MustCompile(`^/([A-Za-z0-9]{5}|[A-Za-z0-9]{7})$`)
+
+// imgurPages 是長度與圖片 ID 相同的 imgur 站內頁面，不視為單圖
+var imgurPages = map[string]struct{}{
+	"/about":   {},
+	"/rules":   {},
+	"/explore": {},
+	"/privacy": {},
+	"/emerald": {},
+}
+
+// isImgurSingleImage 回報連結是否確定為沒有副檔名的 imgur 單圖連結（如 https://imgur.com/AbC123x）。
+// 相簿（/a/）、gallery、標籤或使用者頁面、帶副檔名的影片（.gifv、.mp4）與帶 query string 的連結皆不符合。
+func isImgurSingleImage(href string) bool {
+	if !strings.Contains(href, "://") && !strings.HasPrefix(href, "//") {
+		href = "https://" + href // 沒有 scheme 的連結（如 imgur.com/AbC123x）
+	}
+	u, err := url.Parse(href)
+	if err != nil || u.RawQuery != "" || u.Fragment != "" {
+		return false
+	}
+	if _, ok := imgurHosts[strings.ToLower(u.Hostname())]; !ok {
+		return false
+	}
+	if _, ok := imgurPages[strings.ToLower(u.Path)]; ok {
+		return false
+	}
+	return imgurImageID.MatchString(u.Path)
+}
@@ -0,0 +1,121 @@
+package ptt
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+// signatureSeparator 是 PTT 文章簽名檔前的分隔線
+const signatureSeparator = "--"
+
+// WithSkipQuotedLinks 略過引用區塊（以 ":" 開頭的行，回文時引用的原文）中的連結，
+// 避免重複下載原文的圖片
+func WithSkipQuotedLinks(skip bool) ParserOption {
+	return func(p *ParserImpl) { p.skipQuotes = skip }
+}
+
+// WithSkipSignatureLinks 略過簽名檔（「※ 發信站」之前最後一個「--」之後）與站台資訊中的連結，
+// 推文中的連結不受影響；找不到「※ 發信站」時不視為有簽名檔
+func WithSkipSignatureLinks(skip bool) ParserOption {
+	return func(p *ParserImpl) { p.skipSignature = skip }
+}
+
+// WithIgnoreLinks 略過文章中符合任一正規表示式的連結（比對文章中的原始連結），
+// 無法編譯的樣式直接忽略（配置檔載入時已驗證並記錄警告）
+func WithIgnoreLinks(patterns []string) ParserOption {
+	return func(p *ParserImpl) {
+		p.ignoreLinks = nil
+		for _, pattern := range patterns {
+			if re, err := regexp.Compile(pattern); err == nil {
+				p.ignoreLinks = append(p.ignoreLinks, re)
+			}
+		}
+	}
+}
+
+// ignoredLink 回報連結是否符合 WithIgnoreLinks 的任一樣式
+func (p *ParserImpl) ignoredLink(href string) bool {
+	for _, re := range p.ignoreLinks {
+		if re.MatchString(href) {
+			return true
+		}
+	}
+	return false
+}
+
+// excludedLinks 依文字行的位置找出內文區塊中應略過的連結：quotes 為 true 時略過引用行中的連結，
+// signature 為 true 時略過簽名檔與站台資訊中的連結。
+// metadata 行、推文與嵌入預覽不屬於內文，不在判斷範圍內（推文中的連結照常保留）。
+// 判斷方式與 cleanBody 切除簽名檔相同：以「※ 發信站」行之前最後一個「--」行為簽名檔起點。
+func excludedLinks(doc *goquery.Selection, sel Selectors, quotes, signature bool) map[*html.Node]bool {
+	main := findFirst(doc, sel.MainContent).First()
+	if main.Length() == 0 {
+		return nil
+	}
+	skipped := make(map[*html.Node]bool)
+	nonBody := findFirst(main, sel.PushEntry).Union(findFirst(main, sel.RichContent)).Union(findFirst(main, sel.MetaLine))
+	for _, n := range nonBody.Nodes {
+		skipped[n] = true
+	}
+
+	type link struct {
+		node   *html.Node
+		quoted bool
+	}
+	var (
+		links    []link
+		line     strings.Builder // 目前這一行到目前節點為止的文字
+		sigStart = -1            // 最後一個「--」行之後第一個連結的索引
+		footerAt = -1            // 「※ 發信站」行之後第一個連結的索引
+	)
+	var walk func(n *html.Node)
+	walk = func(n *html.Node) {
+		switch n.Type {
+		case html.TextNode:
+			for i, part := range strings.Split(n.Data, "\n") {
+				if i > 0 {
+					if footerAt < 0 && strings.TrimSpace(line.String()) == signatureSeparator {
+						sigStart = len(links)
+					}
+					line.Reset()
+				}
+				line.WriteString(part)
+				if footerAt < 0 && strings.HasPrefix(strings.TrimSpace(line.String()), footerPrefix) {
+					footerAt = len(links)
+				}
+			}
+		case html.ElementNode:
+			if skipped[n] {
+				return
+			}
+			if n.DataAtom == atom.A {
+				links = append(links, link{node: n, quoted: strings.HasPrefix(strings.TrimSpace(line.String()), ":")})
+			}
+			for c := n.FirstChild; c != nil; c = c.NextSibling {
+				walk(c)
+			}
+		}
+	}
+	for _, n := range main.Nodes {
+		walk(n)
+	}
+
+	sigFrom := len(links) // 沒有站台資訊時不視為有簽名檔
+	if footerAt >= 0 {
+		sigFrom = footerAt
+		if sigStart >= 0 {
+			sigFrom = sigStart
+		}
+	}
+	excluded := make(map[*html.Node]bool)
+	for i, l := range links {
+		if (quotes && l.quoted) || (signature && i >= sigFrom) {
+			excluded[l.node] = true
+		}
+	}
+	return excluded
+}
@@ -1,23 +1,66 @@
 package ptt
 
 import (
+	"fmt"
 	"io"
+	"net/url"
+	"path"
+	"regexp"
+	"slices"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/PuerkitoBio/goquery"
 	"github.com/twtrubiks/ptt-spider-go/constants"
 	"github.com/twtrubiks/ptt-spider-go/errors"
 	"github.com/twtrubiks/ptt-spider-go/interfaces"
 	"github.com/twtrubiks/ptt-spider-go/types"
+	"golang.org/x/net/html"
 )
 
 // ParserImpl 實現 Parser 介面
-type ParserImpl struct{}
+type ParserImpl struct {
+	selectors Selectors           // 覆寫的 selector；零值欄位使用 DefaultSelectors
+	imageExts map[string]struct{} // 視為圖片的副檔名（小寫含點）；nil 時使用 DefaultImageExtensions
+	strict    bool                // imgur 嚴格模式：只對確定是單圖的連結補 .jpg
+
+	skipQuotes    bool             // 略過引用區塊中的連結
+	skipSignature bool             // 略過簽名檔與站台資訊中的連結
+	ignoreLinks   []*regexp.Regexp // 符合任一樣式的連結一律略過
+}
+
+// ParserOption 定義 ParserImpl 的可選配置函式
+type ParserOption func(*ParserImpl)
+
+// WithSelectors 覆寫解析時使用的 selector 候選清單，未指定的欄位沿用預設值
+func WithSelectors(s Selectors) ParserOption {
+	return func(p *ParserImpl) { p.selectors = s }
+}
+
+// WithImageExtensions 設定視為圖片連結的副檔名（如 ".webp"，不分大小寫），空清單時沿用預設值
+func WithImageExtensions(exts []string) ParserOption {
+	return func(p *ParserImpl) { p.imageExts = newExtSet(exts) }
+}
+
+// WithStrictImgur 設定 imgur 嚴格模式：沒有副檔名的 imgur 連結只在確定是單圖時補上 .jpg，
+// gallery、影片與無法判斷的連結不再猜測為圖片（gallery 仍會交給相簿展開）
+func WithStrictImgur(strict bool) ParserOption {
+	return func(p *ParserImpl) { p.strict = strict }
+}
 
 // NewParser 建立新的解析器實例
-func NewParser() interfaces.Parser {
-	return &ParserImpl{}
+func NewParser(opts ...ParserOption) interfaces.Parser {
+	p := &ParserImpl{}
+	for _, opt := range opts {
+		opt(p)
+	}
+	return p
+}
+
+// sel 回傳實際使用的 selector 組
+func (p *ParserImpl) sel() Selectors {
+	return DefaultSelectors().merge(p.selectors)
 }
 
 // ParseArticles 實現 Parser 介面的 ParseArticles 方法
@@ -27,9 +70,10 @@ func (p *ParserImpl) ParseArticles(r io.Reader) ([]types.ArticleInfo, error) {
 		return nil, errors.NewParseError("建立 goquery 文檔失敗", err)
 	}
 
+	sel := p.sel()
 	var articles []types.ArticleInfo
-	doc.Find(".r-ent").Each(func(_ int, s *goquery.Selection) {
-		titleNode := s.Find(".title a")
+	findFirst(doc.Selection, sel.ArticleEntry).Each(func(_ int, s *goquery.Selection) {
+		titleNode := findFirst(s, sel.ArticleTitle).First()
 		if titleNode.Length() == 0 {
 			return // 處理被刪除的文章
 		}
@@ -42,8 +86,10 @@ func (p *ParserImpl) ParseArticles(r io.Reader) ([]types.ArticleInfo, error) {
 			return
 		}
 
-		author := strings.TrimSpace(s.Find(".meta .author").Text())
-		pushRateStr := strings.TrimSpace(s.Find(".nrec span").Text())
+		author := strings.TrimSpace(findFirst(s, sel.ArticleAuthor).First().Text())
+		pushRateNode := findFirst(s, sel.ArticlePushRate).First()
+		pushRateStr := strings.TrimSpace(pushRateNode.Text())
+		date := strings.TrimSpace(findFirst(s, sel.ArticleDate).First().Text())
 
 		pushRate := 0
 		switch {
@@ -60,80 +106,260 @@ func (p *ParserImpl) ParseArticles(r io.Reader) ([]types.ArticleInfo, error) {
 		}
 
 		articles = append(articles, types.ArticleInfo{
-			Title:    title,
-			URL:      constants.PttBaseURL + url,
-			Author:   author,
-			PushRate: pushRate,
+			Title:     title,
+			URL:       constants.PttBaseURL + url,
+			Author:    author,
+			PushRate:  pushRate,
+			HeatLevel: parseHeatLevel(pushRateNode, pushRate),
+			Date:      date,
 		})
 	})
 
 	return articles, nil
 }
 
+// heatClasses 是 PTT 推文數顏色 class 對應的熱度等級
+var heatClasses = map[string]types.HeatLevel{
+	"f1": types.HeatHigh,
+	"f3": types.HeatMedium,
+	"f2": types.HeatLow,
+}
+
+// parseHeatLevel 由推文數節點的 `.hl fN` class 判斷熱度等級。
+// 備用 selector 選到外層容器（如 .nrec）時改看其中的 .hl 元素；噓文（推文數為負）不計熱度。
+func parseHeatLevel(node *goquery.Selection, pushRate int) types.HeatLevel {
+	if pushRate < 0 {
+		return types.HeatNone
+	}
+	if !node.HasClass("hl") {
+		node = node.Find(".hl").First()
+	}
+	class, _ := node.Attr("class")
+	for _, c := range strings.Fields(class) {
+		if level, ok := heatClasses[c]; ok {
+			return level
+		}
+	}
+	return types.HeatNone
+}
+
 // ParseArticleContent 實現 Parser 介面的 ParseArticleContent 方法
-func (p *ParserImpl) ParseArticleContent(r io.Reader) (string, []string, error) {
+func (p *ParserImpl) ParseArticleContent(r io.Reader) (types.ArticleContent, error) {
 	doc, err := goquery.NewDocumentFromReader(r)
 	if err != nil {
-		return "", nil, errors.NewParseError("建立 goquery 文檔失敗", err)
+		return types.ArticleContent{}, errors.NewParseError("建立 goquery 文檔失敗", err)
 	}
 
-	// 提取文章標題
-	title := ""
-	doc.Find(".article-meta-tag").EachWithBreak(func(_ int, s *goquery.Selection) bool {
-		if strings.TrimSpace(s.Text()) == "標題" {
-			title = strings.TrimSpace(s.Next().Text())
-			return false // 找到後就停止遍歷
+	sel := p.sel()
+
+	// 提取文章標題、作者與發文時間
+	var content types.ArticleContent
+	findFirst(doc.Selection, sel.MetaTag).EachWithBreak(func(_ int, s *goquery.Selection) bool {
+		value := strings.TrimSpace(s.Next().Text())
+		switch strings.TrimSpace(s.Text()) {
+		case "標題":
+			content.Title = value
+		case "作者":
+			content.Author = parseAuthorID(value)
+		case "時間":
+			content.Date, _ = parseArticleTime(value)
 		}
-		return true
+		return content.Title == "" || content.Author == "" || content.Date.IsZero() // 都找到後就停止遍歷
 	})
 
 	// 提取圖片 URL
-	var imgURLs []string
-	doc.Find("a").Each(func(_ int, s *goquery.Selection) {
+	exts := p.imageExts
+	if exts == nil {
+		exts = newExtSet(DefaultImageExtensions())
+	}
+	var excluded map[*html.Node]bool
+	if p.skipQuotes || p.skipSignature {
+		excluded = excludedLinks(doc.Selection, sel, p.skipQuotes, p.skipSignature)
+	}
+	findFirst(doc.Selection, sel.ContentLink).Each(func(_ int, s *goquery.Selection) {
 		href, exists := s.Attr("href")
-		if !exists {
+		if !exists || excluded[s.Get(0)] || p.ignoredLink(href) {
 			return
 		}
 
-		// 簡單的圖片 URL 過濾邏輯
-		if strings.HasSuffix(href, ".jpg") || strings.HasSuffix(href, ".jpeg") || strings.HasSuffix(href, ".png") || strings.HasSuffix(href, ".gif") {
-			if strings.HasPrefix(href, "//") {
-				href = "https:" + href
-			} else if strings.HasPrefix(href, "http://") { // 新增：將 http 轉換為 https
-				href = "https://" + href[7:]
+		if isImageURL(href, exts) {
+			if imgURL, ok := upgradeToHTTPS(href); ok {
+				content.ImageURLs = append(content.ImageURLs, imgURL)
+			}
+		} else if strings.Contains(href, "imgur.com/") {
+			if u, err := url.Parse(href); err == nil && u.Scheme == "" && u.Host == "" {
+				href = "https://" + href // 沒有 scheme 的連結（如 imgur.com/AbC123x）
+			}
+			var ok bool
+			if href, ok = upgradeToHTTPS(href); !ok {
+				return
+			}
+			isAlbum := strings.Contains(href, "imgur.com/a/")
+			if isAlbum || strings.Contains(href, "imgur.com/gallery/") {
+				content.AlbumURLs = append(content.AlbumURLs, href)
+			}
+			if !isAlbum && (!p.strict || isImgurSingleImage(href)) {
+				// 處理沒有副檔名的 imgur 連結（gallery 未展開時沿用此方式猜測單張圖片，嚴格模式下不猜測）
+				content.ImageURLs = append(content.ImageURLs, href+".jpg")
+			}
+		}
+	})
+
+	content.Pushes = countPushes(findFirst(doc.Selection, sel.PushTag))
+	content.Body = parseBody(doc.Selection, sel)
+	return content, nil
+}
+
+// footerPrefix 是 PTT 文章結尾站台資訊行的開頭，其後為推文與編輯紀錄
+const footerPrefix = "※ 發信站"
+
+// parseBody 取出內文區塊的文字：去除 metadata 行、推文與嵌入預覽後，
+// 再由 cleanBody 切除簽名檔與站台資訊。找不到內文區塊時回傳空字串；
+// 沒有 metadata 行（如舊文章或被修改過格式）時直接保留全部文字。
+func parseBody(doc *goquery.Selection, sel Selectors) string {
+	main := findFirst(doc, sel.MainContent).First()
+	if main.Length() == 0 {
+		return ""
+	}
+	main = main.Clone() // 不修改原文件，圖片與推文的解析仍需完整的 DOM
+	findFirst(main, sel.MetaLine).Remove()
+	findFirst(main, sel.PushEntry).Remove()
+	findFirst(main, sel.RichContent).Remove()
+	return cleanBody(main.Text())
+}
+
+// cleanBody 在「※ 發信站」行截斷，並去除其前最後一個「--」分隔線之後的簽名檔，
+// 最後移除各行尾端空白與首尾空行（行首空白保留給排版與 ASCII 圖）
+func cleanBody(text string) string {
+	lines := strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimRight(line, " \t\u3000")
+	}
+	footer := slices.IndexFunc(lines, func(line string) bool {
+		return strings.HasPrefix(strings.TrimSpace(line), footerPrefix)
+	})
+	if footer >= 0 {
+		lines = lines[:footer]
+		for i := len(lines) - 1; i >= 0; i-- {
+			if lines[i] == "--" {
+				lines = lines[:i]
+				break
 			}
-			imgURLs = append(imgURLs, href)
-		} else if strings.Contains(href, "imgur.com/") && !strings.Contains(href, "imgur.com/a/") {
-			// 處理沒有副檔名的 imgur 連結
-			imgURLs = append(imgURLs, href+".jpg")
 		}
+	}
+	return strings.Trim(strings.Join(lines, "\n"), "\n")
+}
+
+// countPushes 依推文標籤的文字（「推」「噓」「→」）分類計數，無法辨識的標籤略過
+func countPushes(tags *goquery.Selection) types.PushStats {
+	var stats types.PushStats
+	tags.Each(func(_ int, s *goquery.Selection) {
+		switch strings.TrimSpace(s.Text()) {
+		case "推":
+			stats.PushCount++
+		case "噓":
+			stats.BooCount++
+		case "→":
+			stats.NeutralCount++
+		}
+	})
+	return stats
+}
+
+// ParseArticlePushes 解析文章頁的推文。
+// 每則推文的類型標籤、推文者、內容與時間分別位於 .push 區塊內的子 span；
+// 沒有可辨識類型標籤的區塊（如「檔案過大！部分文章無法顯示」的警告框）會被略過。
+func (p *ParserImpl) ParseArticlePushes(r io.Reader) ([]types.Push, error) {
+	doc, err := goquery.NewDocumentFromReader(r)
+	if err != nil {
+		return nil, errors.NewParseError("建立 goquery 文檔失敗", err)
+	}
+
+	sel := p.sel()
+	var pushes []types.Push
+	findFirst(doc.Selection, sel.PushEntry).Each(func(_ int, s *goquery.Selection) {
+		tag := strings.TrimSpace(findFirst(s, sel.PushTag).First().Text())
+		switch tag {
+		case "推", "噓", "→":
+		default:
+			return
+		}
+		// 內容的開頭是推文者帳號後的「: 」分隔
+		content := strings.TrimSpace(findFirst(s, sel.PushContent).First().Text())
+		content = strings.TrimSpace(strings.TrimPrefix(content, ":"))
+		pushes = append(pushes, types.Push{
+			Tag:     tag,
+			Author:  strings.TrimSpace(findFirst(s, sel.PushAuthor).First().Text()),
+			Content: content,
+			Time:    strings.TrimSpace(findFirst(s, sel.PushTime).First().Text()),
+		})
 	})
+	return pushes, nil
+}
 
-	return title, imgURLs, nil
+// parseAuthorID 從「作者」欄位（如 "testuser (測試使用者)"）取出帳號
+func parseAuthorID(value string) string {
+	if id, _, found := strings.Cut(value, " "); found {
+		return id
+	}
+	return value
 }
 
-// ParseMaxPage 從看板首頁 HTML 解析最大頁數
+// articleTimeLayout 是文章頁「時間」欄位的格式，如 "Mon Jan  5 10:00:00 2026"（日期以空白補齊）
+const articleTimeLayout = "Mon Jan _2 15:04:05 2006"
+
+// parseArticleTime 解析文章頁「時間」欄位，格式不符（如轉錄文章被改寫的欄位）時回傳 false
+func parseArticleTime(value string) (time.Time, bool) {
+	t, err := time.ParseInLocation(articleTimeLayout, value, constants.PttLocation)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return t, true
+}
+
+// ParseMaxPage 從看板首頁 HTML 解析最大頁數（「上頁」連結的頁碼 + 1），看板只有一頁時回傳 1
 func (p *ParserImpl) ParseMaxPage(body io.Reader) (int, error) {
 	doc, err := goquery.NewDocumentFromReader(body)
 	if err != nil {
 		return 0, errors.NewParseError("解析 HTML 失敗", err)
 	}
 
-	prevPageURL, exists := doc.Find(".btn-group-paging a:contains('‹ 上頁')").Attr("href")
+	sel := p.sel()
+	prev := findFirst(doc.Selection, sel.PrevPageLink)
+	prevPageURL, exists := prev.Attr("href")
 	if !exists {
-		return 0, errors.NewParseError("無法找到上一頁按鈕", nil)
+		// 新開或文章很少的看板只有一頁：「上頁」按鈕停用（沒有 href）或不存在，但分頁按鈕列仍在。
+		// 連分頁按鈕列都找不到時，頁面多半不是看板列表（如錯誤頁或改版），視為解析失敗。
+		if prev.Length() > 0 || findFirst(doc.Selection, sel.PagingGroup).Length() > 0 {
+			return 1, nil
+		}
+		return 0, errors.NewParseError("無法找到上一頁按鈕或分頁按鈕列", nil)
 	}
 
-	// 從 /bbs/Beauty/index2345.html 中提取 2345
-	parts := strings.Split(strings.TrimSuffix(prevPageURL, ".html"), "index")
-	if len(parts) < 2 {
-		return 0, errors.NewParseError("無法解析頁碼", nil)
+	maxPage, err := parseIndexPage(prevPageURL)
+	if err != nil {
+		return 0, err
 	}
+	return maxPage + 1, nil
+}
 
-	maxPage, err := strconv.Atoi(parts[1])
+// indexPagePattern 比對看板列表頁路徑的最後一段，如 index2345.html
+var indexPagePattern = regexp.MustCompile(`^index(\d+)\.html$`)
+
+// parseIndexPage 從列表頁連結（如 /bbs/Beauty/index2345.html，可含 host、query 或 fragment）取出頁碼 2345。
+// 只比對路徑的最後一段，看板名稱中含有 index 等字樣時不受影響。
+func parseIndexPage(href string) (int, error) {
+	u, err := url.Parse(href)
+	if err != nil {
+		return 0, errors.NewParseError(fmt.Sprintf("無法解析上一頁連結 %q", href), err)
+	}
+	m := indexPagePattern.FindStringSubmatch(path.Base(u.Path))
+	if m == nil {
+		return 0, errors.NewParseError(fmt.Sprintf("無法從上一頁連結 %q 解析頁碼", href), nil)
+	}
+	page, err := strconv.Atoi(m[1])
 	if err != nil {
 		return 0, errors.NewParseError("頁碼轉換失敗", err)
 	}
-
-	return maxPage + 1, nil
+	return page, nil
 }
@@ -3,6 +3,8 @@ package ptt
 import (
 	"strings"
 	"testing"
+
+	"github.com/twtrubiks/ptt-spider-go/types"
 )
 
 func TestParserImpl_ParseArticles(t *testing.T) {
@@ -16,6 +18,7 @@ func TestParserImpl_ParseArticles(t *testing.T) {
 			</div>
 			<div class="meta">
 				<div class="author">testuser</div>
+				<div class="date"> 1/05</div>
 			</div>
 			<div class="nrec">
 				<span class="hl f1">爆</span>
@@ -53,6 +56,9 @@ func TestParserImpl_ParseArticles(t *testing.T) {
 	if articles[0].Author != "testuser" {
 		t.Errorf("Expected author 'testuser', got %s", articles[0].Author)
 	}
+	if articles[0].Date != "1/05" {
+		t.Errorf("Expected date '1/05', got %q", articles[0].Date)
+	}
 
 	// Check second article
 	if articles[1].Title != "[正妹] 另一個標題" {
@@ -61,6 +67,9 @@ func TestParserImpl_ParseArticles(t *testing.T) {
 	if articles[1].PushRate != 99 {
 		t.Errorf("Expected push rate 99, got %d", articles[1].PushRate)
 	}
+	if articles[1].Date != "" {
+		t.Errorf("Expected empty date, got %q", articles[1].Date)
+	}
 
 	// Test with empty HTML (goquery doesn't error on invalid HTML, just returns empty results)
 	articles, err = parser.ParseArticles(strings.NewReader(""))
@@ -72,6 +81,43 @@ func TestParserImpl_ParseArticles(t *testing.T) {
 	}
 }
 
+func TestParserImpl_ParseArticles_HeatLevel(t *testing.T) {
+	entry := func(id, nrec string) string {
+		return `<div class="r-ent"><div class="title"><a href="/bbs/Beauty/M.` + id + `.A.ABC.html">[正妹] ` + id + `</a></div>` +
+			`<div class="nrec">` + nrec + `</div><div class="meta"><div class="author">user</div></div></div>`
+	}
+	html := entry("1", `<span class="hl f1">爆</span>`) +
+		entry("2", `<span class="hl f3">42</span>`) +
+		entry("3", `<span class="hl f2">5</span>`) +
+		entry("4", ``) +
+		entry("5", `<span class="hl f1">X3</span>`) +
+		entry("6", `<span class="hl f7">8</span>`)
+
+	articles, err := NewParser().ParseArticles(strings.NewReader(html))
+	if err != nil {
+		t.Fatalf("ParseArticles failed: %v", err)
+	}
+	want := []types.HeatLevel{types.HeatHigh, types.HeatMedium, types.HeatLow, types.HeatNone, types.HeatNone, types.HeatNone}
+	if len(articles) != len(want) {
+		t.Fatalf("Expected %d articles, got %d", len(want), len(articles))
+	}
+	for i, a := range articles {
+		if a.HeatLevel != want[i] {
+			t.Errorf("%s: HeatLevel = %v, want %v", a.Title, a.HeatLevel, want[i])
+		}
+	}
+
+	// 備用 selector 選到 .nrec 容器時改看其中的 .hl 元素
+	parser := NewParser(WithSelectors(Selectors{ArticlePushRate: []string{".nrec"}}))
+	articles, err = parser.ParseArticles(strings.NewReader(entry("7", `<span class="hl f3">12</span>`)))
+	if err != nil {
+		t.Fatalf("ParseArticles failed: %v", err)
+	}
+	if len(articles) != 1 || articles[0].PushRate != 12 || articles[0].HeatLevel != types.HeatMedium {
+		t.Errorf("fallback selector: got %+v, want PushRate 12 HeatLevel medium", articles)
+	}
+}
+
 func TestParserImpl_ParseArticleContent(t *testing.T) {
 	parser := NewParser()
 
@@ -88,10 +134,11 @@ func TestParserImpl_ParseArticleContent(t *testing.T) {
 		</div>
 	`
 
-	title, images, err := parser.ParseArticleContent(strings.NewReader(htmlContent))
+	content, err := parser.ParseArticleContent(strings.NewReader(htmlContent))
 	if err != nil {
 		t.Fatalf("ParseArticleContent failed: %v", err)
 	}
+	title, images := content.Title, content.ImageURLs
 
 	if title != "[正妹] 測試文章標題" {
 		t.Errorf("Expected title '[正妹] 測試文章標題', got '%s'", title)
@@ -122,10 +169,11 @@ func TestParserImpl_ParseArticleContent(t *testing.T) {
 	}
 
 	// Test with empty HTML
-	title, images, err = parser.ParseArticleContent(strings.NewReader(""))
+	content, err = parser.ParseArticleContent(strings.NewReader(""))
 	if err != nil {
 		t.Errorf("ParseArticleContent should not error on empty HTML: %v", err)
 	}
+	title, images = content.Title, content.ImageURLs
 	if title != "" {
 		t.Errorf("Expected empty title for empty HTML, got '%s'", title)
 	}
@@ -134,6 +182,214 @@ func TestParserImpl_ParseArticleContent(t *testing.T) {
 	}
 }
 
+func TestParserImpl_ParseArticleContent_AlbumURLs(t *testing.T) {
+	html := `
+		<a href="https://imgur.com/a/AbC12">相簿</a>
+		<a href="https://imgur.com/gallery/XyZ9">gallery</a>
+		<a href="https://i.imgur.com/single.jpg">單張</a>
+	`
+	content, err := NewParser().ParseArticleContent(strings.NewReader(html))
+	if err != nil {
+		t.Fatalf("ParseArticleContent() error = %v", err)
+	}
+
+	wantAlbums := "https://imgur.com/a/AbC12,https://imgur.com/gallery/XyZ9"
+	if got := strings.Join(content.AlbumURLs, ","); got != wantAlbums {
+		t.Errorf("AlbumURLs = %v, want %s", content.AlbumURLs, wantAlbums)
+	}
+	// /a/ 相簿不猜測單張圖片；gallery 維持原本補上 .jpg 的行為，未啟用展開時仍可嘗試下載
+	wantImages := "https://imgur.com/gallery/XyZ9.jpg,https://i.imgur.com/single.jpg"
+	if got := strings.Join(content.ImageURLs, ","); got != wantImages {
+		t.Errorf("ImageURLs = %v, want %s", content.ImageURLs, wantImages)
+	}
+}
+
+func TestParseAuthorID(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"testuser (測試使用者)", "testuser"},
+		{"testuser", "testuser"},
+		{"", ""},
+	}
+	for _, tt := range tests {
+		if got := parseAuthorID(tt.input); got != tt.want {
+			t.Errorf("parseAuthorID(%q) = %q, want %q", tt.input, got, tt.want)
+		}
+	}
+}
+
+func TestUpgradeToHTTPS(t *testing.T) {
+	tests := []struct {
+		href   string
+		want   string
+		wantOK bool
+	}{
+		{"https://i.imgur.com/a.jpg", "https://i.imgur.com/a.jpg", true},
+		{"http://example.com/a.jpg", "https://example.com/a.jpg", true},
+		{"HTTP://example.com/a.jpg", "https://example.com/a.jpg", true},
+		{"Https://example.com/a.jpg", "https://example.com/a.jpg", true},
+		{"//i.imgur.com/a.png", "https://i.imgur.com/a.png", true},
+		{" http://example.com/a.jpg ", "https://example.com/a.jpg", true},
+		// path 與 query 中的 http:// 不受影響，也不重新編碼
+		{"http://example.com/r?u=http://x.com/a.jpg", "https://example.com/r?u=http://x.com/a.jpg", true},
+		{"http://example.com/中文%20圖.jpg", "https://example.com/中文%20圖.jpg", true},
+		{"http://", "", false},
+		{"https:///a.jpg", "", false},
+		{"ftp://example.com/a.jpg", "", false},
+		{"javascript:alert('a.jpg')", "", false},
+		{"data:image/png;base64,AAAA.png", "", false},
+		{"/bbs/Beauty/a.jpg", "", false},
+		{"a.jpg", "", false},
+	}
+	for _, tt := range tests {
+		got, ok := upgradeToHTTPS(tt.href)
+		if got != tt.want || ok != tt.wantOK {
+			t.Errorf("upgradeToHTTPS(%q) = %q, %v, want %q, %v", tt.href, got, ok, tt.want, tt.wantOK)
+		}
+	}
+}
+
+func TestParserImpl_ParseArticleContent_SchemeVariants(t *testing.T) {
+	html := `<div id="main-content">
+		<a href="http://i.imgur.com/a.jpg">http</a>
+		<a href="//i.imgur.com/b.jpg">協定相對</a>
+		<a href="https://i.imgur.com/c.jpg">https</a>
+		<a href="ftp://example.com/d.jpg">ftp</a>
+		<a href="http://">只有 scheme</a>
+		<a href="http://imgur.com/AbC12">http imgur</a>
+		<a href="imgur.com/XyZ98">沒有 scheme 的 imgur</a>
+		<a href="ftp://imgur.com/Qwe34">ftp imgur</a>
+	</div>`
+	content, err := NewParser().ParseArticleContent(strings.NewReader(html))
+	if err != nil {
+		t.Fatalf("ParseArticleContent() error = %v", err)
+	}
+	want := "https://i.imgur.com/a.jpg,https://i.imgur.com/b.jpg,https://i.imgur.com/c.jpg," +
+		"https://imgur.com/AbC12.jpg,https://imgur.com/XyZ98.jpg"
+	if got := strings.Join(content.ImageURLs, ","); got != want {
+		t.Errorf("ImageURLs = %v, want %s", content.ImageURLs, want)
+	}
+}
+
+func TestIsImageURL(t *testing.T) {
+	defaults := newExtSet(DefaultImageExtensions())
+	tests := []struct {
+		href string
+		want bool
+	}{
+		{"https://i.imgur.com/a.jpg", true},
+		{"https://i.imgur.com/a.webp", true},
+		{"https://example.com/a.BMP", true},
+		{"https://example.com/a.avif#top", true},
+		{"https://example.com/a.png?size=large", true},
+		{"https://example.com/a.svg", false},
+		{"https://example.com/jpg", false},
+		{"https://example.com/a.html?file=b.jpg", false},
+	}
+	for _, tt := range tests {
+		if got := isImageURL(tt.href, defaults); got != tt.want {
+			t.Errorf("isImageURL(%q) = %v, want %v", tt.href, got, tt.want)
+		}
+	}
+}
+
+func TestWithImageExtensions(t *testing.T) {
+	html := `
+		<a href="https://example.com/a.jpg">a</a>
+		<a href="https://example.com/b.heic">b</a>
+		<a href="https://example.com/c.webp">c</a>
+	`
+	tests := []struct {
+		name string
+		exts []string
+		want []string
+	}{
+		{"未設定時使用預設清單", nil, []string{"https://example.com/a.jpg", "https://example.com/c.webp"}},
+		{"自訂清單取代預設且可省略前導點", []string{"HEIC", ".jpg", " "}, []string{"https://example.com/a.jpg", "https://example.com/b.heic"}},
+		{"只有空白項目時沿用預設", []string{""}, []string{"https://example.com/a.jpg", "https://example.com/c.webp"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			content, err := NewParser(WithImageExtensions(tt.exts)).ParseArticleContent(strings.NewReader(html))
+			if err != nil {
+				t.Fatalf("ParseArticleContent() error = %v", err)
+			}
+			if strings.Join(content.ImageURLs, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("ImageURLs = %v, want %v", content.ImageURLs, tt.want)
+			}
+		})
+	}
+}
+
+func TestIsImgurSingleImage(t *testing.T) {
+	tests := []struct {
+		href string
+		want bool
+	}{
+		{"https://imgur.com/AbC123x", true},
+		{"http://i.imgur.com/AbC123x", true},
+		{"//m.imgur.com/AbC12", true},
+		{"imgur.com/AbC123x", true},
+		{"https://imgur.com/a/AbC123x", false},
+		{"https://imgur.com/gallery/AbC123x", false},
+		{"https://imgur.com/t/funny", false},
+		{"https://imgur.com/user/someone", false},
+		{"https://i.imgur.com/AbC123x.gifv", false},
+		{"https://i.imgur.com/AbC123x.mp4", false},
+		{"https://imgur.com/AbC123x?tags", false},
+		{"https://imgur.com/upload", false},
+		{"https://imgur.com/about", false},
+		{"https://imgur.com/AbC123xyz", false},
+		{"https://example.com/imgur.com/AbC123x", false},
+	}
+	for _, tt := range tests {
+		if got := isImgurSingleImage(tt.href); got != tt.want {
+			t.Errorf("isImgurSingleImage(%q) = %v, want %v", tt.href, got, tt.want)
+		}
+	}
+}
+
+func TestWithStrictImgur(t *testing.T) {
+	html := `
+		<a href="https://imgur.com/AbC123x">單圖</a>
+		<a href="https://imgur.com/a/Album01">相簿</a>
+		<a href="https://imgur.com/gallery/Gal0001">gallery</a>
+		<a href="https://i.imgur.com/Vid0001.gifv">影片</a>
+		<a href="https://i.imgur.com/Vid0002.mp4">影片</a>
+	`
+	tests := []struct {
+		name   string
+		strict bool
+		want   []string
+	}{
+		{"預設一律補 .jpg", false, []string{
+			"https://imgur.com/AbC123x.jpg",
+			"https://imgur.com/gallery/Gal0001.jpg",
+			"https://i.imgur.com/Vid0001.gifv.jpg",
+			"https://i.imgur.com/Vid0002.mp4.jpg",
+		}},
+		{"嚴格模式只對單圖補 .jpg", true, []string{"https://imgur.com/AbC123x.jpg"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			content, err := NewParser(WithStrictImgur(tt.strict)).ParseArticleContent(strings.NewReader(html))
+			if err != nil {
+				t.Fatalf("ParseArticleContent() error = %v", err)
+			}
+			if strings.Join(content.ImageURLs, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("ImageURLs = %v, want %v", content.ImageURLs, tt.want)
+			}
+			// 相簿與 gallery 仍交給相簿展開
+			wantAlbums := "https://imgur.com/a/Album01,https://imgur.com/gallery/Gal0001"
+			if got := strings.Join(content.AlbumURLs, ","); got != wantAlbums {
+				t.Errorf("AlbumURLs = %v, want %v", content.AlbumURLs, wantAlbums)
+			}
+		})
+	}
+}
+
 func TestParserImpl_ParseMaxPage(t *testing.T) {
 	parser := NewParser()
 
@@ -157,6 +413,16 @@ func TestParserImpl_ParseMaxPage(t *testing.T) {
 		t.Error("Expected error for missing previous page button, got nil")
 	}
 
+	// 看板名稱含 index 時仍取路徑最後一段的頁碼
+	maxPage, err = parser.ParseMaxPage(strings.NewReader(`
+		<div class="btn-group-paging">
+			<a href="/bbs/index_html/index41.html">‹ 上頁</a>
+		</div>
+	`))
+	if err != nil || maxPage != 42 {
+		t.Errorf("ParseMaxPage() = %d, %v, want 42", maxPage, err)
+	}
+
 	// Test invalid page number format
 	invalidHTML := `
 		<div class="btn-group-paging">
@@ -169,6 +435,40 @@ func TestParserImpl_ParseMaxPage(t *testing.T) {
 	}
 }
 
+func TestParseIndexPage(t *testing.T) {
+	tests := []struct {
+		href    string
+		want    int
+		wantErr bool
+	}{
+		{href: "/bbs/Beauty/index2345.html", want: 2345},
+		{href: "/bbs/index/index12.html", want: 12},      // 看板名稱就是 index
+		{href: "/bbs/Index_html/index3.html", want: 3},   // 看板名稱含 index 與 html
+		{href: "/bbs/indexindex/index40.html", want: 40}, // 看板名稱重複 index
+		{href: "/bbs/lhtml/index7.html", want: 7},        // 看板名稱結尾為 .html 的字元集
+		{href: "/bbs/index123/index8.html", want: 8},     // 看板名稱本身像頁碼
+		{href: "https://www.ptt.cc/bbs/Beauty/index99.html", want: 99},
+		{href: "/bbs/Beauty/index5.html?from=1#top", want: 5},
+		{href: "/bbs/Beauty/index.html", wantErr: true},
+		{href: "/bbs/Beauty/index12.htm", wantErr: true},
+		{href: "/bbs/Beauty/index-1.html", wantErr: true},
+		{href: "/bbs/index123/", wantErr: true},
+		{href: "/bbs/Beauty/index99999999999999999999.html", wantErr: true},
+		{href: "%zz", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		got, err := parseIndexPage(tt.href)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("parseIndexPage(%q) error = %v, wantErr %v", tt.href, err, tt.wantErr)
+			continue
+		}
+		if !tt.wantErr && got != tt.want {
+			t.Errorf("parseIndexPage(%q) = %d, want %d", tt.href, got, tt.want)
+		}
+	}
+}
+
 func TestNewParser(t *testing.T) {
 	parser := NewParser()
 	if parser == nil {
@@ -0,0 +1,93 @@
+package ptt
+
+import "github.com/PuerkitoBio/goquery"
+
+// Selectors 集中定義解析 PTT 頁面所用的 CSS selector。
+// 每個欄位都是依序嘗試的候選清單：第一個有匹配結果的 selector 生效，
+// PTT 改版使舊 selector 失效時，會自動改用後面的備用 selector。
+type Selectors struct {
+	ArticleEntry    []string // 列表頁中每篇文章的區塊
+	ArticleTitle    []string // 文章區塊內的標題連結
+	ArticleAuthor   []string // 文章區塊內的作者
+	ArticlePushRate []string // 文章區塊內的推文數
+	ArticleDate     []string // 文章區塊內的發文日期（M/DD）
+	MetaTag         []string // 文章頁的 metadata 標籤（其下一個節點為對應的值）
+	ContentLink     []string // 文章頁中可能指向圖片的連結
+	MainContent     []string // 文章頁的內文區塊（含 metadata 與推文）
+	MetaLine        []string // 內文區塊中的 metadata 行（作者、標題、時間）
+	RichContent     []string // 內文區塊中自動嵌入的圖片／影片預覽，不屬於內文
+	PushTag         []string // 文章頁每則推文的類型標籤（推／噓／→）
+	PushEntry       []string // 文章頁每則推文的區塊
+	PushAuthor      []string // 推文區塊內的推文者帳號
+	PushContent     []string // 推文區塊內的推文內容
+	PushTime        []string // 推文區塊內的推文時間（可能含 IP）
+	PrevPageLink    []string // 看板首頁的「上頁」按鈕
+	PagingGroup     []string // 看板列表頁的分頁按鈕列（最舊／上頁／下頁／最新）
+}
+
+// DefaultSelectors 回傳內建的 selector 組：第一個候選對應目前的 PTT 網頁版，
+// 其後為結構調整時的備用寫法。
+func DefaultSelectors() Selectors {
+	return Selectors{
+		ArticleEntry:    []string{".r-ent", ".r-list-container .article-item", "[data-article-id]"},
+		ArticleTitle:    []string{".title a", "a.title", ".article-title a"},
+		ArticleAuthor:   []string{".meta .author", ".author", ".article-author"},
+		ArticlePushRate: []string{".nrec span", ".nrec", ".push-count"},
+		ArticleDate:     []string{".meta .date", ".date", ".article-date"},
+		MetaTag:         []string{".article-meta-tag", ".article-metaline .tag", ".meta-tag"},
+		ContentLink:     []string{"a"},
+		MainContent:     []string{"#main-content", ".bbs-screen.bbs-content"},
+		MetaLine:        []string{".article-metaline, .article-metaline-right"},
+		RichContent:     []string{".richcontent"},
+		PushTag:         []string{".push .push-tag", ".push-tag"},
+		PushEntry:       []string{".push"},
+		PushAuthor:      []string{".push-userid"},
+		PushContent:     []string{".push-content"},
+		PushTime:        []string{".push-ipdatetime"},
+		PrevPageLink: []string{
+			".btn-group-paging a:contains('‹ 上頁')",
+			".btn-group-paging a:contains('上頁')",
+			"a.btn:contains('上頁')",
+		},
+		PagingGroup: []string{".btn-group-paging", ".action-bar .btn-group"},
+	}
+}
+
+// merge 以 override 中非空的欄位覆寫 s，未指定的欄位沿用 s 的候選
+func (s Selectors) merge(override Selectors) Selectors {
+	pick := func(base, o []string) []string {
+		if len(o) > 0 {
+			return o
+		}
+		return base
+	}
+	return Selectors{
+		ArticleEntry:    pick(s.ArticleEntry, override.ArticleEntry),
+		ArticleTitle:    pick(s.ArticleTitle, override.ArticleTitle),
+		ArticleAuthor:   pick(s.ArticleAuthor, override.ArticleAuthor),
+		ArticlePushRate: pick(s.ArticlePushRate, override.ArticlePushRate),
+		ArticleDate:     pick(s.ArticleDate, override.ArticleDate),
+		MetaTag:         pick(s.MetaTag, override.MetaTag),
+		ContentLink:     pick(s.ContentLink, override.ContentLink),
+		MainContent:     pick(s.MainContent, override.MainContent),
+		MetaLine:        pick(s.MetaLine, override.MetaLine),
+		RichContent:     pick(s.RichContent, override.RichContent),
+		PushTag:         pick(s.PushTag, override.PushTag),
+		PushEntry:       pick(s.PushEntry, override.PushEntry),
+		PushAuthor:      pick(s.PushAuthor, override.PushAuthor),
+		PushContent:     pick(s.PushContent, override.PushContent),
+		PushTime:        pick(s.PushTime, override.PushTime),
+		PrevPageLink:    pick(s.PrevPageLink, override.PrevPageLink),
+		PagingGroup:     pick(s.PagingGroup, override.PagingGroup),
+	}
+}
+
+// findFirst 依序嘗試候選 selector，回傳第一個有匹配的結果；全部失效時回傳空集合
+func findFirst(s *goquery.Selection, candidates []string) *goquery.Selection {
+	for _, c := range candidates {
+		if found := s.Find(c); found.Length() > 0 {
+			return found
+		}
+	}
+	return s.Slice(0, 0) // 空集合，讓呼叫端可以照常呼叫 Text/Attr/Each
+}
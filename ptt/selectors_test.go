@@ -0,0 +1,135 @@
+package ptt
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/twtrubiks/ptt-spider-go/constants"
+)
+
+func TestParseArticles_FallbackSelectors(t *testing.T) {
+	articles, err := NewParser().ParseArticles(strings.NewReader(loadFixture(t, "board_list_v2.html")))
+	if err != nil {
+		t.Fatalf("ParseArticles() error = %v", err)
+	}
+	// 被刪除的文章沒有標題連結，應被略過
+	if len(articles) != 2 {
+		t.Fatalf("expected 2 articles, got %d", len(articles))
+	}
+
+	tests := []struct {
+		title    string
+		url      string
+		author   string
+		pushRate int
+	}{
+		{"[正妹] 改版後的標題", "/bbs/Beauty/M.1234567890.A.ABC.html", "newuser", 100},
+		{"[正妹] 改版後的負評", "/bbs/Beauty/M.1234567891.A.DEF.html", "user2", -3},
+	}
+	for i, want := range tests {
+		got := articles[i]
+		if got.Title != want.title || got.URL != constants.PttBaseURL+want.url ||
+			got.Author != want.author || got.PushRate != want.pushRate {
+			t.Errorf("articles[%d] = %+v, want %+v", i, got, want)
+		}
+	}
+}
+
+func TestParseArticleContent_FallbackSelectors(t *testing.T) {
+	content, err := NewParser().ParseArticleContent(strings.NewReader(loadFixture(t, "article_content_v2.html")))
+	if err != nil {
+		t.Fatalf("ParseArticleContent() error = %v", err)
+	}
+	title, imgURLs := content.Title, content.ImageURLs
+	if content.Author != "newuser" {
+		t.Errorf("author = %q, want %q", content.Author, "newuser")
+	}
+	if title != "[正妹] 改版後的標題" {
+		t.Errorf("title = %q, want %q", title, "[正妹] 改版後的標題")
+	}
+	if len(imgURLs) != 1 || imgURLs[0] != "https://i.imgur.com/new.jpg" {
+		t.Errorf("imgURLs = %v, want [https://i.imgur.com/new.jpg]", imgURLs)
+	}
+}
+
+func TestParseMaxPage_FallbackSelectors(t *testing.T) {
+	maxPage, err := NewParser().ParseMaxPage(strings.NewReader(loadFixture(t, "board_list_v2.html")))
+	if err != nil {
+		t.Fatalf("ParseMaxPage() error = %v", err)
+	}
+	if maxPage != 1235 {
+		t.Errorf("maxPage = %d, want 1235", maxPage)
+	}
+}
+
+func TestParseArticles_CurrentSelectorWins(t *testing.T) {
+	// 兩種結構並存時只採用第一個有匹配的候選，避免同一頁的文章被重複解析
+	html := loadFixture(t, "board_list.html") + loadFixture(t, "board_list_v2.html")
+	articles, err := NewParser().ParseArticles(strings.NewReader(html))
+	if err != nil {
+		t.Fatalf("ParseArticles() error = %v", err)
+	}
+	if len(articles) != 3 {
+		t.Errorf("expected 3 articles from current layout, got %d", len(articles))
+	}
+}
+
+func TestWithSelectors(t *testing.T) {
+	html := `<ul><li class="post"><a class="link" href="/bbs/Beauty/M.1.A.html">[正妹] 自訂</a>
+		<em>42</em><b>custom</b></li></ul>
+		<nav><a id="prev" href="/bbs/Beauty/index99.html">prev</a></nav>`
+
+	p := NewParser(WithSelectors(Selectors{
+		ArticleEntry:    []string{"li.post"},
+		ArticleTitle:    []string{"a.link"},
+		ArticleAuthor:   []string{"b"},
+		ArticlePushRate: []string{"em"},
+		PrevPageLink:    []string{"#prev"},
+	}))
+
+	articles, err := p.ParseArticles(strings.NewReader(html))
+	if err != nil {
+		t.Fatalf("ParseArticles() error = %v", err)
+	}
+	if len(articles) != 1 || articles[0].Author != "custom" || articles[0].PushRate != 42 {
+		t.Fatalf("articles = %+v, want one article by custom with 42 pushes", articles)
+	}
+
+	maxPage, err := p.ParseMaxPage(strings.NewReader(html))
+	if err != nil || maxPage != 100 {
+		t.Errorf("ParseMaxPage() = %d, %v, want 100, nil", maxPage, err)
+	}
+
+	// 未覆寫的欄位（MetaTag）沿用預設值
+	content, err := p.ParseArticleContent(strings.NewReader(loadFixture(t, "article_content.html")))
+	if err != nil || content.Title != "[正妹] 測試標題" {
+		t.Errorf("ParseArticleContent() title = %q, %v, want 預設 selector 解析結果", content.Title, err)
+	}
+}
+
+func TestDefaultSelectors_AllFieldsSet(t *testing.T) {
+	s := DefaultSelectors()
+	fields := map[string][]string{
+		"ArticleEntry":    s.ArticleEntry,
+		"ArticleTitle":    s.ArticleTitle,
+		"ArticleAuthor":   s.ArticleAuthor,
+		"ArticlePushRate": s.ArticlePushRate,
+		"MetaTag":         s.MetaTag,
+		"ContentLink":     s.ContentLink,
+		"MainContent":     s.MainContent,
+		"MetaLine":        s.MetaLine,
+		"RichContent":     s.RichContent,
+		"PushTag":         s.PushTag,
+		"PushEntry":       s.PushEntry,
+		"PushAuthor":      s.PushAuthor,
+		"PushContent":     s.PushContent,
+		"PushTime":        s.PushTime,
+		"PrevPageLink":    s.PrevPageLink,
+		"PagingGroup":     s.PagingGroup,
+	}
+	for name, candidates := range fields {
+		if len(candidates) == 0 {
+			t.Errorf("DefaultSelectors().%s 不應為空", name)
+		}
+	}
+}
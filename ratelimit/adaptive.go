@@ -0,0 +1,105 @@
+package ratelimit
+
+import (
+	"sync"
+	"time"
+)
+
+const (
+	// adaptiveQuietPeriod 是 host 多久沒收到 429 才調降一次延遲
+	adaptiveQuietPeriod = 30 * time.Second
+
+	// adaptiveSteps 將 [min, max] 分為幾段，每次調降（及首次調升）一段
+	adaptiveSteps = 10
+
+	// adaptiveHoldOff 內同一 host 的多個 429 只調升一次，並行中的請求常同時被拒，不應連乘多次
+	adaptiveHoldOff = time.Second
+)
+
+// AdaptiveDelay 依各 host 最近的 429 回應調整請求前的延遲（AIMD）：
+// 收到 429 時延遲加倍（multiplicative increase），持續 adaptiveQuietPeriod 沒有 429 時
+// 每段安靜期調降一段（additive decrease），延遲維持在 [min, max] 之間。可供多個 goroutine 共用。
+type AdaptiveDelay struct {
+	min, max time.Duration
+	step     time.Duration // 每次調降的幅度
+
+	mu    sync.Mutex
+	hosts map[string]*adaptiveHost
+	now   func() time.Time // 可替換的時鐘，供測試使用
+}
+
+// adaptiveHost 是單一 host 的延遲狀態
+type adaptiveHost struct {
+	delay    time.Duration
+	changed  time.Time // 上次調整（調升或調降）的時間，安靜期由此起算
+	increase time.Time // 上次調升的時間
+}
+
+// NewAdaptiveDelay 建立延遲介於 minDelay 與 maxDelay 之間的自適應延遲控制器，
+// 未收到 429 的 host 延遲為 minDelay。maxDelay 小於 minDelay 時視為等於 minDelay。
+func NewAdaptiveDelay(minDelay, maxDelay time.Duration) *AdaptiveDelay {
+	minDelay = max(minDelay, 0)
+	maxDelay = max(maxDelay, minDelay)
+	return &AdaptiveDelay{
+		min:   minDelay,
+		max:   maxDelay,
+		step:  max((maxDelay-minDelay)/adaptiveSteps, time.Millisecond),
+		hosts: make(map[string]*adaptiveHost),
+		now:   time.Now,
+	}
+}
+
+// Delay 回傳目前對 host 發出請求前應等待的延遲，並套用已經過的安靜期調降
+func (a *AdaptiveDelay) Delay(host string) time.Duration {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	h, ok := a.hosts[host]
+	if !ok {
+		return a.min
+	}
+	a.decay(host, h)
+	return h.delay
+}
+
+// Throttled 記錄 host 回應了 429 並調升其延遲，回傳調升後的延遲與是否實際調升
+// （已達上限或仍在 adaptiveHoldOff 內時不調升）
+func (a *AdaptiveDelay) Throttled(host string) (time.Duration, bool) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	now := a.now()
+	h, ok := a.hosts[host]
+	if ok {
+		a.decay(host, h)
+		if now.Sub(h.increase) < adaptiveHoldOff {
+			return h.delay, false
+		}
+	} else {
+		h = &adaptiveHost{delay: a.min}
+	}
+	// decay 回到下限時會移除狀態，調升後需重新放回
+	a.hosts[host] = h
+
+	next := min(max(h.delay*2, a.min+a.step), a.max)
+	if next == h.delay {
+		return h.delay, false
+	}
+	h.delay = next
+	h.changed = now
+	h.increase = now
+	return h.delay, true
+}
+
+// decay 依距上次調整經過的安靜期數調降延遲，回到下限時移除該 host 的狀態。呼叫方需持有鎖
+func (a *AdaptiveDelay) decay(host string, h *adaptiveHost) {
+	periods := a.now().Sub(h.changed) / adaptiveQuietPeriod
+	if periods <= 0 {
+		return
+	}
+	h.delay = max(h.delay-time.Duration(periods)*a.step, a.min)
+	h.changed = h.changed.Add(periods * adaptiveQuietPeriod)
+	if h.delay == a.min {
+		delete(a.hosts, host)
+	}
+}
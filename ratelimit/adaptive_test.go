@@ -0,0 +1,90 @@
+package ratelimit
+
+import (
+	"testing"
+	"time"
+)
+
+func newTestAdaptive(minDelay, maxDelay time.Duration) (*AdaptiveDelay, *fakeClock) {
+	clock := &fakeClock{t: time.Unix(0, 0)}
+	a := NewAdaptiveDelay(minDelay, maxDelay)
+	a.now = clock.now
+	return a, clock
+}
+
+func TestAdaptiveDelay_IncreaseOnThrottle(t *testing.T) {
+	a, clock := newTestAdaptive(100*time.Millisecond, 2*time.Second)
+
+	if got := a.Delay("i.imgur.com"); got != 100*time.Millisecond {
+		t.Fatalf("未收到 429 時應為下限, got %v", got)
+	}
+
+	// 首次調升一段（(max-min)/10 = 190ms），之後每次加倍直到上限
+	want := []time.Duration{290 * time.Millisecond, 580 * time.Millisecond, 1160 * time.Millisecond, 2 * time.Second}
+	for i, w := range want {
+		got, raised := a.Throttled("i.imgur.com")
+		if got != w || !raised {
+			t.Errorf("第 %d 次 429 後延遲 = %v, %v, want %v, true", i+1, got, raised, w)
+		}
+		clock.t = clock.t.Add(adaptiveHoldOff)
+	}
+	if got, raised := a.Throttled("i.imgur.com"); got != 2*time.Second || raised {
+		t.Errorf("已達上限時不應再調升, got %v, %v", got, raised)
+	}
+
+	// 其他 host 不受影響
+	if got := a.Delay("www.ptt.cc"); got != 100*time.Millisecond {
+		t.Errorf("其他 host 應維持下限, got %v", got)
+	}
+}
+
+func TestAdaptiveDelay_HoldOff(t *testing.T) {
+	a, clock := newTestAdaptive(0, time.Second)
+
+	first, _ := a.Throttled("example.com")
+	// 並行請求同時收到的 429 只調升一次
+	if got, raised := a.Throttled("example.com"); got != first || raised {
+		t.Errorf("holdOff 內的 429 不應調升, got %v, %v", got, raised)
+	}
+	clock.t = clock.t.Add(adaptiveHoldOff)
+	if got, raised := a.Throttled("example.com"); got != 2*first || !raised {
+		t.Errorf("holdOff 之後應加倍, got %v, %v, want %v", got, raised, 2*first)
+	}
+}
+
+func TestAdaptiveDelay_DecreaseAfterQuietPeriod(t *testing.T) {
+	a, clock := newTestAdaptive(0, time.Second)
+
+	for range 3 {
+		a.Throttled("example.com")
+		clock.t = clock.t.Add(adaptiveHoldOff)
+	}
+	peak := a.Delay("example.com") // 100ms → 200ms → 400ms
+	if peak != 400*time.Millisecond {
+		t.Fatalf("peak = %v, want 400ms", peak)
+	}
+
+	// 安靜期未滿不調降
+	clock.t = clock.t.Add(adaptiveQuietPeriod - 2*adaptiveHoldOff)
+	if got := a.Delay("example.com"); got != peak {
+		t.Errorf("安靜期未滿時 = %v, want %v", got, peak)
+	}
+
+	// 每段安靜期調降一段（100ms）
+	clock.t = clock.t.Add(adaptiveQuietPeriod)
+	if got := a.Delay("example.com"); got != 300*time.Millisecond {
+		t.Errorf("一段安靜期後 = %v, want 300ms", got)
+	}
+	clock.t = clock.t.Add(10 * adaptiveQuietPeriod)
+	if got := a.Delay("example.com"); got != 0 {
+		t.Errorf("長時間沒有 429 應回到下限, got %v", got)
+	}
+	if len(a.hosts) != 0 {
+		t.Errorf("回到下限後應移除 host 狀態, got %d", len(a.hosts))
+	}
+
+	// 回到下限後再次收到 429 仍能正常調升
+	if got, raised := a.Throttled("example.com"); got != 100*time.Millisecond || !raised {
+		t.Errorf("重新調升 = %v, %v, want 100ms, true", got, raised)
+	}
+}
@@ -0,0 +1,105 @@
+// Package ratelimit 提供 interfaces.RateLimiter 的 token bucket 實作，以及依 429 回應調整延遲的 AdaptiveDelay。
+package ratelimit
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/twtrubiks/ptt-spider-go/interfaces"
+)
+
+// TokenBucket 以固定速率補充額度的 token bucket 限速器，可供多個 goroutine 共用。
+type TokenBucket struct {
+	rate  float64 // 每秒補充的額度
+	burst float64 // 額度上限
+
+	mu     sync.Mutex
+	tokens float64
+	last   time.Time
+	now    func() time.Time // 可替換的時鐘，供測試使用
+}
+
+// 確保 TokenBucket 實作 RateLimiter 介面
+var _ interfaces.RateLimiter = (*TokenBucket)(nil)
+
+// NewTokenBucket 建立每秒 rps 個請求、最多累積 burst 個額度的限速器。
+// burst 小於 1 時視為 1；rps 小於等於 0 時不限速；初始額度為滿。
+func NewTokenBucket(rps float64, burst int) *TokenBucket {
+	if burst < 1 {
+		burst = 1
+	}
+	tb := &TokenBucket{
+		rate:  rps,
+		burst: float64(burst),
+		now:   time.Now,
+	}
+	tb.tokens = tb.burst
+	tb.last = tb.now()
+	return tb
+}
+
+// SetRate 在執行期調整速率與額度上限（語意同 NewTokenBucket），
+// 已累積的額度保留但不超過新的上限，正在 Wait 的呼叫端下次嘗試時即套用新速率
+func (tb *TokenBucket) SetRate(rps float64, burst int) {
+	if burst < 1 {
+		burst = 1
+	}
+	tb.mu.Lock()
+	defer tb.mu.Unlock()
+	tb.refill()
+	tb.rate = rps
+	tb.burst = float64(burst)
+	tb.tokens = min(tb.tokens, tb.burst)
+}
+
+// refill 依經過時間補充額度，呼叫方需持有鎖
+func (tb *TokenBucket) refill() {
+	now := tb.now()
+	elapsed := now.Sub(tb.last).Seconds()
+	tb.last = now
+	tb.tokens += elapsed * tb.rate
+	if tb.tokens > tb.burst {
+		tb.tokens = tb.burst
+	}
+}
+
+// reserve 嘗試取得一個額度，額度不足時回傳需等待的時間
+func (tb *TokenBucket) reserve() time.Duration {
+	tb.mu.Lock()
+	defer tb.mu.Unlock()
+
+	if tb.rate <= 0 {
+		return 0
+	}
+	tb.refill()
+	if tb.tokens >= 1 {
+		tb.tokens--
+		return 0
+	}
+	missing := 1 - tb.tokens
+	return time.Duration(missing / tb.rate * float64(time.Second))
+}
+
+// Allow 在有可用額度時消耗一個額度並回傳 true
+func (tb *TokenBucket) Allow() bool {
+	return tb.reserve() == 0
+}
+
+// Wait 阻塞直到取得一個額度，context 取消時回傳其錯誤
+func (tb *TokenBucket) Wait(ctx context.Context) error {
+	for {
+		wait := tb.reserve()
+		if wait == 0 {
+			return nil
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
@@ -0,0 +1,133 @@
+package ratelimit
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+// fakeClock 是可手動推進的時鐘
+type fakeClock struct{ t time.Time }
+
+func (f *fakeClock) now() time.Time { return f.t }
+
+func newTestBucket(rps float64, burst int) (*TokenBucket, *fakeClock) {
+	clock := &fakeClock{t: time.Unix(0, 0)}
+	tb := NewTokenBucket(rps, burst)
+	tb.now = clock.now
+	tb.last = clock.t
+	return tb, clock
+}
+
+func TestTokenBucket_AllowBurstThenRefill(t *testing.T) {
+	tb, clock := newTestBucket(2, 3)
+
+	for i := 0; i < 3; i++ {
+		if !tb.Allow() {
+			t.Fatalf("第 %d 次請求應在 burst 額度內", i+1)
+		}
+	}
+	if tb.Allow() {
+		t.Fatal("額度用盡後應拒絕")
+	}
+
+	// 每秒 2 個，經過 0.5 秒補回 1 個
+	clock.t = clock.t.Add(500 * time.Millisecond)
+	if !tb.Allow() {
+		t.Error("補充後應允許 1 次")
+	}
+	if tb.Allow() {
+		t.Error("只補充 1 個額度，第 2 次應拒絕")
+	}
+}
+
+func TestTokenBucket_RefillCappedAtBurst(t *testing.T) {
+	tb, clock := newTestBucket(10, 2)
+	tb.Allow()
+	tb.Allow()
+
+	clock.t = clock.t.Add(time.Hour)
+	allowed := 0
+	for tb.Allow() {
+		allowed++
+	}
+	if allowed != 2 {
+		t.Errorf("長時間閒置後額度應上限為 burst=2，實際 %d", allowed)
+	}
+}
+
+func TestTokenBucket_WaitBlocksUntilToken(t *testing.T) {
+	tb := NewTokenBucket(20, 1) // 每 50ms 一個額度
+	ctx := context.Background()
+
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		if err := tb.Wait(ctx); err != nil {
+			t.Fatalf("Wait() error = %v", err)
+		}
+	}
+	// 第 1 次立即取得，之後每次約 50ms
+	if elapsed := time.Since(start); elapsed < 80*time.Millisecond {
+		t.Errorf("3 次請求應至少等待約 100ms，實際 %v", elapsed)
+	}
+}
+
+func TestTokenBucket_WaitContextCancelled(t *testing.T) {
+	tb := NewTokenBucket(0.1, 1) // 每 10 秒一個額度
+	tb.Allow()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	if err := tb.Wait(ctx); err == nil {
+		t.Error("context 取消時 Wait 應回傳錯誤")
+	}
+}
+
+func TestTokenBucket_ZeroRateUnlimited(t *testing.T) {
+	tb, _ := newTestBucket(0, 1)
+	for i := 0; i < 100; i++ {
+		if !tb.Allow() {
+			t.Fatalf("速率為 0 時應不限速，第 %d 次被拒絕", i+1)
+		}
+	}
+}
+
+func TestTokenBucket_SetRate(t *testing.T) {
+	tb, clock := newTestBucket(1, 5)
+	tb.Allow()
+	tb.Allow()
+
+	// 縮小額度上限：剩餘 3 個額度截為 1 個
+	tb.SetRate(10, 1)
+	if !tb.Allow() {
+		t.Fatal("調整後應保留 1 個額度")
+	}
+	if tb.Allow() {
+		t.Fatal("額度上限為 1，第 2 次應拒絕")
+	}
+
+	// 新速率每秒 10 個，經過 0.1 秒補回 1 個
+	clock.t = clock.t.Add(100 * time.Millisecond)
+	if !tb.Allow() {
+		t.Error("應以新速率補充額度")
+	}
+
+	// 改為不限速
+	tb.SetRate(0, 1)
+	for i := 0; i < 10; i++ {
+		if !tb.Allow() {
+			t.Fatalf("改為不限速後第 %d 次被拒絕", i+1)
+		}
+	}
+
+	// 再改回限速時從空額度開始累積
+	tb.SetRate(10, 1)
+	clock.t = clock.t.Add(100 * time.Millisecond)
+	if !tb.Allow() {
+		t.Error("恢復限速後應依經過時間補充額度")
+	}
+	if tb.Allow() {
+		t.Error("恢復限速後額度上限為 1")
+	}
+}
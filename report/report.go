@@ -0,0 +1,241 @@
+// Package report 產生爬取結束後的執行報告：單一自包含的 HTML 檔，
+// 含各看板統計、錯誤分類、下載失敗原因、狀態碼分布、下載量、耗時與最慢的文章。
+// 圖表以內嵌 CSS 長條呈現，不引用任何外部資源，可直接分享或離線開啟。
+package report
+
+import (
+	"bytes"
+	"cmp"
+	"html/template"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/twtrubiks/ptt-spider-go/constants"
+	"github.com/twtrubiks/ptt-spider-go/errors"
+	"github.com/twtrubiks/ptt-spider-go/performance"
+	"github.com/twtrubiks/ptt-spider-go/types"
+)
+
+// FileName 是執行報告的檔名
+const FileName = "report.html"
+
+// SlowestLimit 是報告中列出的最慢文章數
+const SlowestLimit = 10
+
+// BoardStats 是單一看板的統計
+type BoardStats struct {
+	Board    string // 看板名稱（檔案模式未指定看板時為空字串）
+	Articles int    // 解析完成的文章數
+	Images   int    // 成功下載的圖片數
+	Failed   int    // 下載失敗的圖片數
+	Bytes    int64  // 寫入磁碟的位元組數
+}
+
+// ArticleStats 是單篇文章的處理耗時
+type ArticleStats struct {
+	Board    string
+	Title    string
+	URL      string
+	Images   int           // 文章中的圖片數
+	Duration time.Duration // 開始解析文章頁到最後一張圖片下載結束的實際經過時間
+}
+
+// Report 是一次執行的報告內容
+type Report struct {
+	StartedAt   time.Time
+	Duration    time.Duration
+	Interrupted bool                   // 是否因中斷信號提前結束
+	Metrics     *types.MetricsSnapshot // 請求與下載指標，未收集時為 nil
+	Boards      []BoardStats
+	Articles    []ArticleStats // 所有文章，Write 時取最慢的 SlowestLimit 篇
+}
+
+// bar 是長條圖的一列
+type bar struct {
+	Label   string
+	Count   int64
+	Percent float64 // 相對於最大值的寬度百分比
+}
+
+// bars 將計數轉為依數量遞減排列的長條圖資料
+func bars[K cmp.Ordered](counts map[K]int64, label func(K) string) []bar {
+	keys := slices.Sorted(maps.Keys(counts))
+	slices.SortStableFunc(keys, func(a, b K) int { return cmp.Compare(counts[b], counts[a]) })
+
+	var peak int64
+	for _, k := range keys {
+		peak = max(peak, counts[k])
+	}
+	out := make([]bar, 0, len(keys))
+	for _, k := range keys {
+		percent := 0.0
+		if peak > 0 {
+			percent = float64(counts[k]) * 100 / float64(peak)
+		}
+		out = append(out, bar{Label: label(k), Count: counts[k], Percent: percent})
+	}
+	return out
+}
+
+// slowest 回傳耗時最長的 n 篇文章
+func slowest(articles []ArticleStats, n int) []ArticleStats {
+	sorted := slices.Clone(articles)
+	slices.SortStableFunc(sorted, func(a, b ArticleStats) int { return cmp.Compare(b.Duration, a.Duration) })
+	return sorted[:min(n, len(sorted))]
+}
+
+var funcs = template.FuncMap{
+	"bytes":    func(n int64) string { return performance.FormatBytes(uint64(max(n, 0))) },
+	"duration": func(d time.Duration) string { return d.Round(time.Millisecond).String() },
+	"boardName": func(board string) string {
+		if board == "" {
+			return "（未指定看板）"
+		}
+		return board
+	},
+}
+
+var htmlTemplate = template.Must(template.New("report").Funcs(funcs).Parse(`<!DOCTYPE html>
+<html lang="zh-Hant">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>PTT Spider 執行報告</title>
+<style>
+body { margin: 0 auto; max-width: 1000px; padding: 16px; font-family: sans-serif; color: #222; }
+h2 { margin-top: 32px; border-bottom: 1px solid #ddd; padding-bottom: 4px; }
+.cards { display: grid; grid-template-columns: repeat(auto-fill, minmax(180px, 1fr)); gap: 12px; }
+.card { background: #f5f5f5; border-radius: 6px; padding: 12px; }
+.card .value { font-size: 1.6em; font-weight: bold; }
+.card .label { color: #666; font-size: 0.9em; }
+table { width: 100%; border-collapse: collapse; }
+th, td { text-align: left; padding: 6px 8px; border-bottom: 1px solid #eee; }
+td.num, th.num { text-align: right; }
+.bar-row { display: grid; grid-template-columns: 140px 1fr 60px; gap: 8px; align-items: center; margin: 4px 0; }
+.bar { height: 16px; background: #4a90d9; border-radius: 3px; }
+.bar.error { background: #d9534f; }
+.warn { color: #b94a48; }
+</style>
+</head>
+<body>
+<h1>PTT Spider 執行報告</h1>
+<p>開始時間 {{.StartedAt.Format "2006-01-02 15:04:05"}}，總耗時 {{duration .Duration}}
+{{- if .Interrupted}}<span class="warn">（因中斷信號提前結束）</span>{{end}}</p>
+
+<h2>總覽</h2>
+<div class="cards">
+<div class="card"><div class="value">{{.TotalArticles}}</div><div class="label">文章數</div></div>
+<div class="card"><div class="value">{{.TotalImages}}</div><div class="label">下載圖片</div></div>
+<div class="card"><div class="value">{{.TotalFailed}}</div><div class="label">下載失敗</div></div>
+<div class="card"><div class="value">{{bytes .TotalBytes}}</div><div class="label">下載量</div></div>
+{{- with .Metrics}}
+<div class="card"><div class="value">{{.TotalRequests}}</div><div class="label">對外請求</div></div>
+<div class="card"><div class="value">{{.TotalErrors}}</div><div class="label">錯誤</div></div>
+<div class="card"><div class="value">{{duration .P95Latency}}</div><div class="label">請求延遲 P95（平均 {{duration .AvgLatency}}）</div></div>
+{{- end}}
+</div>
+
+<h2>各看板統計</h2>
+{{- if .Boards}}
+<table>
+<tr><th>看板</th><th class="num">文章數</th><th class="num">下載圖片</th><th class="num">失敗</th><th class="num">下載量</th></tr>
+{{- range .Boards}}
+<tr><td>{{boardName .Board}}</td><td class="num">{{.Articles}}</td><td class="num">{{.Images}}</td><td class="num">{{.Failed}}</td><td class="num">{{bytes .Bytes}}</td></tr>
+{{- end}}
+</table>
+{{- else}}
+<p>沒有派發任何下載。</p>
+{{- end}}
+
+<h2>錯誤分類</h2>
+{{- if .ErrorBars}}
+{{- range .ErrorBars}}
+<div class="bar-row"><span>{{.Label}}</span><div class="bar error" style="width: {{printf "%.1f" .Percent}}%"></div><span>{{.Count}}</span></div>
+{{- end}}
+{{- else}}
+<p>沒有錯誤。</p>
+{{- end}}
+
+{{- if .FailureBars}}
+<h2>下載失敗原因</h2>
+{{- range .FailureBars}}
+<div class="bar-row"><span>{{.Label}}</span><div class="bar error" style="width: {{printf "%.1f" .Percent}}%"></div><span>{{.Count}}</span></div>
+{{- end}}
+{{- end}}
+
+{{- if .StatusBars}}
+<h2>HTTP 狀態碼</h2>
+{{- range .StatusBars}}
+<div class="bar-row"><span>{{.Label}}</span><div class="bar" style="width: {{printf "%.1f" .Percent}}%"></div><span>{{.Count}}</span></div>
+{{- end}}
+{{- end}}
+
+<h2>最慢的文章</h2>
+{{- if .Slowest}}
+<table>
+<tr><th>標題</th><th>看板</th><th class="num">圖片數</th><th class="num">耗時</th></tr>
+{{- range .Slowest}}
+<tr><td><a href="{{.URL}}">{{.Title}}</a></td><td>{{boardName .Board}}</td><td class="num">{{.Images}}</td><td class="num">{{duration .Duration}}</td></tr>
+{{- end}}
+</table>
+{{- else}}
+<p>沒有處理任何文章。</p>
+{{- end}}
+</body>
+</html>
+`))
+
+// Render 產生報告的 HTML 內容
+func Render(r Report) ([]byte, error) {
+	data := struct {
+		Report
+		TotalArticles int
+		TotalImages   int
+		TotalFailed   int
+		TotalBytes    int64
+		ErrorBars     []bar
+		FailureBars   []bar
+		StatusBars    []bar
+		Slowest       []ArticleStats
+	}{Report: r, Slowest: slowest(r.Articles, SlowestLimit)}
+
+	for _, b := range r.Boards {
+		data.TotalArticles += b.Articles
+		data.TotalImages += b.Images
+		data.TotalFailed += b.Failed
+		data.TotalBytes += b.Bytes
+	}
+	if r.Metrics != nil {
+		data.ErrorBars = bars(r.Metrics.ErrorsByType, func(k string) string { return k })
+		data.FailureBars = bars(r.Metrics.DownloadFailures, func(k string) string { return errors.FailureReason(k).Label() })
+		data.StatusBars = bars(r.Metrics.StatusCodes, strconv.Itoa)
+	}
+
+	var buf bytes.Buffer
+	if err := htmlTemplate.Execute(&buf, data); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// Write 將報告寫入 dir/report.html，回傳寫入的路徑
+func Write(dir string, r Report) (string, error) {
+	data, err := Render(r)
+	if err != nil {
+		return "", errors.NewFileError("產生執行報告失敗", err)
+	}
+	if dir != "" {
+		if err := os.MkdirAll(dir, constants.DirPermission); err != nil {
+			return "", errors.NewFileError("建立目錄失敗 "+dir, err)
+		}
+	}
+	path := filepath.Join(dir, FileName)
+	if err := os.WriteFile(path, data, constants.FilePermission); err != nil {
+		return "", errors.NewFileError("寫入執行報告失敗", err)
+	}
+	return path, nil
+}
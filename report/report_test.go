@@ -0,0 +1,121 @@
+package report
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/twtrubiks/ptt-spider-go/types"
+)
+
+func TestSlowest(t *testing.T) {
+	var articles []ArticleStats
+	for i := range SlowestLimit + 5 {
+		articles = append(articles, ArticleStats{URL: fmt.Sprint(i), Duration: time.Duration(i%7) * time.Second})
+	}
+
+	got := slowest(articles, SlowestLimit)
+	if len(got) != SlowestLimit {
+		t.Fatalf("len(slowest) = %d, want %d", len(got), SlowestLimit)
+	}
+	if !slices.IsSortedFunc(got, func(a, b ArticleStats) int { return int(b.Duration - a.Duration) }) {
+		t.Errorf("slowest 未依耗時遞減排序: %v", got)
+	}
+	if got[0].Duration != 6*time.Second {
+		t.Errorf("最慢文章耗時 = %v, want 6s", got[0].Duration)
+	}
+	if articles[0].URL != "0" {
+		t.Error("slowest 不應修改傳入的 slice")
+	}
+}
+
+func TestBars(t *testing.T) {
+	got := bars(map[string]int64{"parse": 1, "network": 4, "write": 1}, func(k string) string { return k })
+	want := []bar{
+		{Label: "network", Count: 4, Percent: 100},
+		{Label: "parse", Count: 1, Percent: 25},
+		{Label: "write", Count: 1, Percent: 25},
+	}
+	if !slices.Equal(got, want) {
+		t.Errorf("bars() = %v, want %v", got, want)
+	}
+}
+
+func TestWrite(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "out")
+	r := Report{
+		StartedAt: time.Date(2026, 10, 1, 12, 0, 0, 0, time.Local),
+		Duration:  90 * time.Second,
+		Metrics: &types.MetricsSnapshot{
+			TotalRequests:    12,
+			TotalErrors:      2,
+			ErrorsByType:     map[string]int64{"network": 2},
+			StatusCodes:      map[int]int64{200: 10, 404: 2},
+			DownloadFailures: map[string]int64{"not_found": 2},
+		},
+		Boards: []BoardStats{
+			{Board: "Beauty", Articles: 2, Images: 5, Failed: 1, Bytes: 2048},
+			{Board: "", Articles: 1, Images: 1},
+		},
+		Articles: []ArticleStats{
+			{Board: "Beauty", Title: "<script>alert(1)</script>", URL: "https://www.ptt.cc/bbs/Beauty/M.1.html", Images: 3, Duration: 3 * time.Second},
+		},
+	}
+
+	path, err := Write(dir, r)
+	if err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+	if path != filepath.Join(dir, FileName) {
+		t.Errorf("path = %q, want %q", path, filepath.Join(dir, FileName))
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	html := string(data)
+
+	for _, want := range []string{
+		"2026-10-01 12:00:00",
+		"1m30s",
+		"<td>Beauty</td>",
+		"（未指定看板）",
+		"2.0 KiB",
+		"network",
+		"404",
+		"<h2>下載失敗原因</h2>",
+		"<span>圖片已刪除</span>",
+		"&lt;script&gt;alert(1)&lt;/script&gt;",
+		`href="https://www.ptt.cc/bbs/Beauty/M.1.html"`,
+	} {
+		if !strings.Contains(html, want) {
+			t.Errorf("報告缺少 %q", want)
+		}
+	}
+	if strings.Contains(html, "<script>") {
+		t.Error("文章標題未跳脫")
+	}
+	// 自包含：不引用任何外部資源
+	for _, external := range []string{"<link", "src=", "@import"} {
+		if strings.Contains(html, external) {
+			t.Errorf("報告不應引用外部資源，發現 %q", external)
+		}
+	}
+}
+
+func TestRender_NoMetrics(t *testing.T) {
+	data, err := Render(Report{Interrupted: true})
+	if err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+	html := string(data)
+	for _, want := range []string{"因中斷信號提前結束", "沒有派發任何下載", "沒有錯誤", "沒有處理任何文章"} {
+		if !strings.Contains(html, want) {
+			t.Errorf("報告缺少 %q", want)
+		}
+	}
+}
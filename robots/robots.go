@@ -0,0 +1,210 @@
+// Package robots 提供 robots.txt 的解析與快取查詢，供爬蟲在請求前確認路徑是否允許爬取。
+package robots
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"sync"
+
+	"github.com/twtrubiks/ptt-spider-go/interfaces"
+	"github.com/twtrubiks/ptt-spider-go/internal/ioutil"
+)
+
+// maxRobotsBytes 限制 robots.txt 讀取大小，RFC 9309 要求至少解析 500 KiB
+const maxRobotsBytes = 512 * 1024
+
+// rule 是一條 Allow/Disallow 規則
+type rule struct {
+	allow   bool
+	length  int            // 規則 pattern 長度，最長匹配者優先
+	pattern *regexp.Regexp // 由 pattern 轉換的正規表示式（支援 * 與 $）
+}
+
+// Rules 是套用於本爬蟲（User-agent: *）的 robots.txt 規則集合
+type Rules struct {
+	rules []rule
+}
+
+// AllowAll 回傳不限制任何路徑的規則，用於 robots.txt 不存在或無法取得時
+func AllowAll() *Rules {
+	return &Rules{}
+}
+
+// Parse 解析 robots.txt 內容，只保留 User-agent 為 * 的群組規則。
+func Parse(r io.Reader) *Rules {
+	rules := &Rules{}
+	scanner := bufio.NewScanner(r)
+
+	inGroup := false      // 目前群組是否套用於 *
+	lastWasAgent := false // 連續的 User-agent 行屬於同一群組
+	for scanner.Scan() {
+		line := scanner.Text()
+		if i := strings.Index(line, "#"); i >= 0 {
+			line = line[:i]
+		}
+		key, value, ok := strings.Cut(line, ":")
+		if !ok {
+			continue
+		}
+		key = strings.ToLower(strings.TrimSpace(key))
+		value = strings.TrimSpace(value)
+
+		switch key {
+		case "user-agent":
+			if !lastWasAgent {
+				inGroup = false
+			}
+			if value == "*" {
+				inGroup = true
+			}
+			lastWasAgent = true
+		case "allow", "disallow":
+			lastWasAgent = false
+			// 空的 Disallow 代表允許全部，不需要規則
+			if !inGroup || value == "" {
+				continue
+			}
+			rules.rules = append(rules.rules, rule{
+				allow:   key == "allow",
+				length:  len(value),
+				pattern: compilePattern(value),
+			})
+		default:
+			lastWasAgent = false
+		}
+	}
+	return rules
+}
+
+// compilePattern 將 robots.txt 路徑 pattern 轉為錨定開頭的正規表示式。
+// * 匹配任意字元序列，結尾的 $ 表示必須匹配到路徑結尾。
+func compilePattern(pattern string) *regexp.Regexp {
+	anchorEnd := strings.HasSuffix(pattern, "$")
+	pattern = strings.TrimSuffix(pattern, "$")
+
+	parts := strings.Split(pattern, "*")
+	for i, p := range parts {
+		parts[i] = regexp.QuoteMeta(p)
+	}
+	expr := "^" + strings.Join(parts, ".*")
+	if anchorEnd {
+		expr += "$"
+	}
+	return regexp.MustCompile(expr)
+}
+
+// Allowed 判斷路徑（含 query string）是否允許爬取。
+// 依 RFC 9309，匹配長度最長的規則優先；長度相同時 Allow 優先。
+func (r *Rules) Allowed(path string) bool {
+	if path == "" {
+		path = "/"
+	}
+	best := -1
+	allowed := true
+	for _, ru := range r.rules {
+		if !ru.pattern.MatchString(path) {
+			continue
+		}
+		if ru.length > best || (ru.length == best && ru.allow) {
+			best = ru.length
+			allowed = ru.allow
+		}
+	}
+	return allowed
+}
+
+// hostEntry 是單一 host 的快取項目。mu 讓同一 host 同時只有一個請求在抓取 robots.txt，
+// 只有成功取得或確定不存在（4xx）時才標記 done，暫時性的失敗留待下次查詢重試。
+type hostEntry struct {
+	mu    sync.Mutex
+	done  bool
+	rules *Rules
+}
+
+// Checker 依 host 快取 robots.txt 解析結果，可供多個 goroutine 並行查詢。
+type Checker struct {
+	client interfaces.HTTPClient
+
+	mu    sync.Mutex
+	hosts map[string]*hostEntry
+}
+
+// NewChecker 建立新的 robots.txt 檢查器
+func NewChecker(client interfaces.HTTPClient) *Checker {
+	return &Checker{
+		client: client,
+		hosts:  make(map[string]*hostEntry),
+	}
+}
+
+// Allowed 判斷 URL 是否允許爬取。首次查詢某 host 時會抓取並快取其 robots.txt。
+// robots.txt 不存在（4xx）時視為全部允許；抓取失敗時同樣允許，並回傳錯誤供呼叫方記錄，
+// 失敗結果不快取，下次查詢該 host 時重新抓取。
+func (c *Checker) Allowed(ctx context.Context, rawURL string) (bool, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return true, fmt.Errorf("解析 URL 失敗: %w", err)
+	}
+
+	rules, err := c.rulesFor(ctx, u)
+	path := u.EscapedPath()
+	if u.RawQuery != "" {
+		path += "?" + u.RawQuery
+	}
+	return rules.Allowed(path), err
+}
+
+// rulesFor 回傳 host 的快取規則，尚未快取時抓取 robots.txt
+func (c *Checker) rulesFor(ctx context.Context, u *url.URL) (*Rules, error) {
+	origin := u.Scheme + "://" + u.Host
+
+	c.mu.Lock()
+	entry, ok := c.hosts[origin]
+	if !ok {
+		entry = &hostEntry{}
+		c.hosts[origin] = entry
+	}
+	c.mu.Unlock()
+
+	entry.mu.Lock()
+	defer entry.mu.Unlock()
+	if entry.done {
+		return entry.rules, nil
+	}
+	rules, err := c.fetch(ctx, origin+"/robots.txt")
+	if err != nil {
+		return AllowAll(), err
+	}
+	entry.rules, entry.done = rules, true
+	return rules, nil
+}
+
+// fetch 抓取並解析 robots.txt。4xx 視為不存在而回傳 AllowAll；
+// 請求失敗（含 ctx 取消）或其他非 200 狀態回傳錯誤。
+func (c *Checker) fetch(ctx context.Context, robotsURL string) (*Rules, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", robotsURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("建立 robots.txt 請求失敗: %w", err)
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("抓取 robots.txt 失敗: %w", err)
+	}
+	defer ioutil.CloseWithLog(resp.Body, "robots.txt 回應 Body")
+
+	if resp.StatusCode != http.StatusOK {
+		if resp.StatusCode >= 400 && resp.StatusCode < 500 {
+			return AllowAll(), nil
+		}
+		return nil, fmt.Errorf("抓取 robots.txt 失敗，HTTP 狀態: %d", resp.StatusCode)
+	}
+
+	return Parse(io.LimitReader(resp.Body, maxRobotsBytes)), nil
+}
@@ -0,0 +1,188 @@
+package robots
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/twtrubiks/ptt-spider-go/mocks"
+)
+
+const sampleRobots = `
+# 其他爬蟲的群組不適用
+User-agent: Googlebot
+Disallow: /
+
+User-agent: Bingbot
+User-agent: *
+Disallow: /bbs/*/search
+Disallow: /private/
+Allow: /private/public.html
+Disallow: /*.php$
+Disallow:
+`
+
+func TestRules_Allowed(t *testing.T) {
+	rules := Parse(strings.NewReader(sampleRobots))
+
+	tests := []struct {
+		path string
+		want bool
+	}{
+		{"/bbs/Beauty/index.html", true},
+		{"/bbs/Beauty/search?q=test", false},
+		{"/private/secret.html", false},
+		{"/private/public.html", true}, // 較長的 Allow 規則優先
+		{"/index.php", false},
+		{"/index.php?x=1", true}, // $ 錨定結尾
+		{"", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			if got := rules.Allowed(tt.path); got != tt.want {
+				t.Errorf("Allowed(%q) = %v, want %v", tt.path, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParse_IgnoresOtherAgents(t *testing.T) {
+	rules := Parse(strings.NewReader("User-agent: Googlebot\nDisallow: /\n"))
+	if !rules.Allowed("/bbs/Beauty/index.html") {
+		t.Error("只針對其他 User-agent 的規則不應套用")
+	}
+}
+
+func TestChecker_CachesPerHost(t *testing.T) {
+	var robotsFetches atomic.Int32
+	client := &mocks.MockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			if req.URL.Path == "/robots.txt" {
+				robotsFetches.Add(1)
+			}
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       io.NopCloser(strings.NewReader("User-agent: *\nDisallow: /bbs/*/search\n")),
+			}, nil
+		},
+	}
+	checker := NewChecker(client)
+	ctx := context.Background()
+
+	allowed, err := checker.Allowed(ctx, "https://www.ptt.cc/bbs/Beauty/index.html")
+	if err != nil || !allowed {
+		t.Fatalf("Allowed() = %v, %v, want true, nil", allowed, err)
+	}
+	allowed, _ = checker.Allowed(ctx, "https://www.ptt.cc/bbs/Beauty/search?q=x")
+	if allowed {
+		t.Error("search 路徑應被禁止")
+	}
+
+	if got := robotsFetches.Load(); got != 1 {
+		t.Errorf("同一 host 的 robots.txt 應只抓取 1 次，實際 %d 次", got)
+	}
+}
+
+func TestChecker_MissingRobotsAllowsAll(t *testing.T) {
+	client := &mocks.MockHTTPClient{
+		DoFunc: func(_ *http.Request) (*http.Response, error) {
+			return &http.Response{
+				StatusCode: http.StatusNotFound,
+				Body:       io.NopCloser(strings.NewReader("")),
+			}, nil
+		},
+	}
+
+	allowed, err := NewChecker(client).Allowed(context.Background(), "https://example.com/anything")
+	if err != nil || !allowed {
+		t.Errorf("robots.txt 不存在時應全部允許，got %v, %v", allowed, err)
+	}
+}
+
+func TestChecker_ServerErrorAllowsWithError(t *testing.T) {
+	client := &mocks.MockHTTPClient{
+		DoFunc: func(_ *http.Request) (*http.Response, error) {
+			return &http.Response{
+				StatusCode: http.StatusServiceUnavailable,
+				Body:       io.NopCloser(strings.NewReader("")),
+			}, nil
+		},
+	}
+
+	allowed, err := NewChecker(client).Allowed(context.Background(), "https://example.com/anything")
+	if !allowed {
+		t.Error("robots.txt 抓取失敗時應允許爬取")
+	}
+	if err == nil {
+		t.Error("robots.txt 抓取失敗時應回傳錯誤供呼叫方記錄")
+	}
+}
+
+// TestChecker_RetriesOnlyTransientFailures 驗證暫時性失敗不快取、下次查詢重新抓取，
+// 確定不存在（4xx）則快取，不再重複請求
+func TestChecker_RetriesOnlyTransientFailures(t *testing.T) {
+	tests := []struct {
+		name        string
+		statuses    []int // 依序回應的狀態碼
+		wantFetches int32
+		wantErrs    []bool
+	}{
+		{"5xx 後重試並快取成功結果", []int{http.StatusServiceUnavailable, http.StatusOK, http.StatusOK}, 2, []bool{true, false, false}},
+		{"4xx 視為不存在並快取", []int{http.StatusNotFound, http.StatusOK, http.StatusOK}, 1, []bool{false, false, false}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var fetches atomic.Int32
+			client := &mocks.MockHTTPClient{
+				DoFunc: func(_ *http.Request) (*http.Response, error) {
+					n := fetches.Add(1)
+					return &http.Response{
+						StatusCode: tt.statuses[n-1],
+						Body:       io.NopCloser(strings.NewReader("User-agent: *\nDisallow: /private/\n")),
+					}, nil
+				},
+			}
+			checker := NewChecker(client)
+
+			for i, wantErr := range tt.wantErrs {
+				if _, err := checker.Allowed(context.Background(), "https://example.com/anything"); (err != nil) != wantErr {
+					t.Errorf("第 %d 次查詢 err = %v, wantErr %v", i+1, err, wantErr)
+				}
+			}
+			if got := fetches.Load(); got != tt.wantFetches {
+				t.Errorf("robots.txt 抓取 %d 次, want %d", got, tt.wantFetches)
+			}
+		})
+	}
+}
+
+// TestChecker_CanceledNotCached 驗證 ctx 取消造成的失敗不快取
+func TestChecker_CanceledNotCached(t *testing.T) {
+	client := &mocks.MockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			if err := req.Context().Err(); err != nil {
+				return nil, err
+			}
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       io.NopCloser(strings.NewReader("User-agent: *\nDisallow: /private/\n")),
+			}, nil
+		},
+	}
+	checker := NewChecker(client)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := checker.Allowed(ctx, "https://example.com/private/a"); err == nil {
+		t.Fatal("ctx 取消時應回傳錯誤")
+	}
+	allowed, err := checker.Allowed(context.Background(), "https://example.com/private/a")
+	if err != nil || allowed {
+		t.Errorf("取消後重新查詢應抓取規則並禁止, got %v, %v", allowed, err)
+	}
+}
@@ -135,7 +135,7 @@ func TestIntegrationTypesFlow(t *testing.T) {
 // TestIntegrationContextCancellation tests context cancellation across components
 func TestIntegrationContextCancellation(t *testing.T) {
 	cfg := config.DefaultConfig()
-	cfg.Crawler.Workers = 1
+	cfg.Crawler.Workers = config.FixedWorkers(1)
 	cfg.Crawler.ParserCount = 1
 
 	crawler, err := crawler.NewCrawler("Beauty", 1, 10, "", cfg)
@@ -162,7 +162,7 @@ func TestIntegrationConfigurability(t *testing.T) {
 	// Test custom configuration
 	cfg := &config.Config{
 		Crawler: config.CrawlerConfig{
-			Workers:     3,
+			Workers:     config.FixedWorkers(3),
 			ParserCount: 2,
 			Channels: config.ChannelConfig{
 				ArticleInfo:  50,
@@ -191,7 +191,7 @@ func TestIntegrationConfigurability(t *testing.T) {
 	}
 
 	// Verify config values are correct (config is passed by reference)
-	if cfg.Crawler.Workers != 3 {
+	if cfg.Crawler.Workers != config.FixedWorkers(3) {
 		t.Error("Config should have custom worker count")
 	}
 	if cfg.Crawler.ParserCount != 2 {
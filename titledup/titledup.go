@@ -0,0 +1,320 @@
+// Package titledup 偵測標題相同或相似的文章（轉錄、重發），將其分為重複群組。
+//
+// 標題先經 Normalize 正規化，再以字元 bigram 集合的 Jaccard 相似度比較。
+// 門檻為 1 時只比對正規化後完全相同的標題（雜湊分組，O(n)）；
+// 門檻小於 1 時以 prefix filtering 只比較共用罕見 bigram 的候選對，避免大量文章時兩兩比較的 O(n²)。
+package titledup
+
+import (
+	"encoding/json"
+	"math"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"unicode"
+
+	"github.com/twtrubiks/ptt-spider-go/constants"
+	"github.com/twtrubiks/ptt-spider-go/errors"
+)
+
+// IndexFileName 是看板目錄中重複群組索引檔的檔名
+const IndexFileName = "duplicates.json"
+
+// Article 是參與偵測的一篇文章
+type Article struct {
+	Title string `json:"title"` // 原始標題
+	URL   string `json:"url"`   // 文章 URL
+	Dir   string `json:"dir"`   // 本地存放目錄
+}
+
+// Group 是一組標題重複的文章，依加入順序排列
+type Group struct {
+	Title    string    `json:"title"`    // 群組中第一篇文章正規化後的標題
+	Articles []Article `json:"articles"` // 群組中的文章（至少兩篇）
+}
+
+// Index 是重複群組索引檔的內容
+type Index struct {
+	Threshold float64 `json:"threshold"` // 產生索引時的相似度門檻
+	Groups    []Group `json:"groups"`    // 重複群組，沒有時為空陣列
+}
+
+// Detector 收集文章並偵測標題重複的群組，可供多個 goroutine 並行 Add。
+type Detector struct {
+	threshold float64
+
+	mu       sync.Mutex
+	articles []Article
+	urls     map[string]struct{}
+}
+
+// NewDetector 建立相似度門檻為 threshold（0~1，1 表示正規化後完全相同）的偵測器，
+// 超出範圍時視為 1
+func NewDetector(threshold float64) *Detector {
+	if threshold <= 0 || threshold > 1 {
+		threshold = 1
+	}
+	return &Detector{threshold: threshold, urls: make(map[string]struct{})}
+}
+
+// Threshold 回傳相似度門檻
+func (d *Detector) Threshold() float64 {
+	return d.threshold
+}
+
+// Add 加入一篇文章，同一 URL 重複加入時忽略
+func (d *Detector) Add(a Article) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if _, ok := d.urls[a.URL]; ok {
+		return
+	}
+	d.urls[a.URL] = struct{}{}
+	d.articles = append(d.articles, a)
+}
+
+// Len 回傳已加入的文章數
+func (d *Detector) Len() int {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return len(d.articles)
+}
+
+// Groups 回傳目前所有重複群組，依群組中第一篇文章的加入順序排列
+func (d *Detector) Groups() []Group {
+	d.mu.Lock()
+	articles := slices.Clone(d.articles)
+	d.mu.Unlock()
+
+	// 正規化後相同的標題先以雜湊合併，相似度比較只需處理不重複的標題
+	keys := make([]string, 0, len(articles))
+	keyIndex := make(map[string]int)
+	owner := make([]int, len(articles)) // 文章 → 所屬標題 key 的索引，-1 表示不參與
+	for i, a := range articles {
+		key := Normalize(a.Title)
+		if key == "" {
+			owner[i] = -1
+			continue
+		}
+		k, ok := keyIndex[key]
+		if !ok {
+			k = len(keys)
+			keyIndex[key] = k
+			keys = append(keys, key)
+		}
+		owner[i] = k
+	}
+
+	uf := newUnionFind(len(keys))
+	if d.threshold < 1 {
+		unionSimilar(keys, d.threshold, uf)
+	}
+
+	byRoot := make(map[int]int) // union-find 根 → groups 索引
+	var groups []Group
+	for i, a := range articles {
+		if owner[i] < 0 {
+			continue
+		}
+		root := uf.find(owner[i])
+		g, ok := byRoot[root]
+		if !ok {
+			g = len(groups)
+			byRoot[root] = g
+			groups = append(groups, Group{Title: keys[owner[i]]})
+		}
+		groups[g].Articles = append(groups[g].Articles, a)
+	}
+	return slices.DeleteFunc(groups, func(g Group) bool { return len(g.Articles) < 2 })
+}
+
+// WriteIndex 將重複群組以縮排 JSON 寫入 dir/duplicates.json，回傳群組數
+func (d *Detector) WriteIndex(dir string) (int, error) {
+	groups := d.Groups()
+	if groups == nil {
+		groups = []Group{}
+	}
+	data, err := json.MarshalIndent(Index{Threshold: d.threshold, Groups: groups}, "", "  ")
+	if err != nil {
+		return 0, errors.NewFileError("序列化重複標題索引失敗", err)
+	}
+	if dir != "" {
+		if err := os.MkdirAll(dir, constants.DirPermission); err != nil {
+			return 0, errors.NewFileError("建立目錄失敗 "+dir, err)
+		}
+	}
+	if err := os.WriteFile(filepath.Join(dir, IndexFileName), append(data, '\n'), constants.FilePermission); err != nil {
+		return 0, errors.NewFileError("寫入重複標題索引失敗", err)
+	}
+	return len(groups), nil
+}
+
+// repostPrefixes 是轉錄、回覆文章標題的前綴，比較時移除
+var repostPrefixes = []string{"re:", "fw:", "fwd:", "[轉錄]"}
+
+// Normalize 將標題正規化以便比較：轉為小寫、移除 Re:/Fw:/[轉錄] 前綴，
+// 只保留文字與數字（去除空白、標點與表情符號）
+func Normalize(title string) string {
+	title = strings.ToLower(strings.TrimSpace(title))
+	for trimmed := true; trimmed; {
+		trimmed = false
+		for _, p := range repostPrefixes {
+			if rest, ok := strings.CutPrefix(title, p); ok {
+				title, trimmed = strings.TrimSpace(rest), true
+			}
+		}
+	}
+	return strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return r
+		}
+		return -1
+	}, title)
+}
+
+// bigrams 回傳字串的字元 bigram 集合（去重），只有一個字元時回傳該字元
+func bigrams(s string) []string {
+	runes := []rune(s)
+	if len(runes) == 1 {
+		return []string{s}
+	}
+	set := make(map[string]struct{}, len(runes))
+	out := make([]string, 0, len(runes)-1)
+	for i := 0; i+1 < len(runes); i++ {
+		bg := string(runes[i : i+2])
+		if _, ok := set[bg]; !ok {
+			set[bg] = struct{}{}
+			out = append(out, bg)
+		}
+	}
+	return out
+}
+
+// unionSimilar 以 prefix filtering 找出 Jaccard 相似度達 threshold 的標題對並合併。
+// bigram 依全域出現次數由少到多編號，每個標題的集合依編號排序；兩集合相似度達門檻時，
+// 必定在各自前 |x| - ceil(t·|x|) + 1 個 bigram 中共用至少一個，因此只需索引這段前綴。
+// 標題依集合大小由小到大處理，並略過大小差距已不可能達到門檻的候選。
+func unionSimilar(keys []string, threshold float64, uf *unionFind) {
+	// 先以出現順序為 bigram 編號並計數，再依（次數, 編號）重新編號為排序用的 rank
+	ids := make(map[string]int)
+	var freq []int
+	tokens := make([][]int, len(keys))
+	for i, key := range keys {
+		gs := bigrams(key)
+		tokens[i] = make([]int, len(gs))
+		for k, g := range gs {
+			id, ok := ids[g]
+			if !ok {
+				id = len(freq)
+				ids[g] = id
+				freq = append(freq, 0)
+			}
+			freq[id]++
+			tokens[i][k] = id
+		}
+	}
+	byFreq := make([]int, len(freq))
+	for id := range byFreq {
+		byFreq[id] = id
+	}
+	slices.SortFunc(byFreq, func(a, b int) int {
+		if freq[a] != freq[b] {
+			return freq[a] - freq[b]
+		}
+		return a - b
+	})
+	rank := make([]int, len(freq))
+	for r, id := range byFreq {
+		rank[id] = r
+	}
+	for _, ts := range tokens {
+		for k, id := range ts {
+			ts[k] = rank[id]
+		}
+		slices.Sort(ts)
+	}
+
+	order := make([]int, len(keys))
+	for i := range order {
+		order[i] = i
+	}
+	slices.SortStableFunc(order, func(a, b int) int { return len(tokens[a]) - len(tokens[b]) })
+
+	prefix := make(map[int][]int) // bigram 編號 → 前綴含有該 bigram 的標題（依大小遞增）
+	lastProbe := make([]int, len(keys))
+	for i := range lastProbe {
+		lastProbe[i] = -1
+	}
+	for _, i := range order {
+		ts := tokens[i]
+		// 減去極小值避免浮點誤差（如 0.8*5 算成 4.0000000001）讓門檻多算一個
+		minSize := int(math.Ceil(threshold*float64(len(ts)) - 1e-9))
+		prefixLen := len(ts) - minSize + 1
+		for _, t := range ts[:prefixLen] {
+			list := prefix[t]
+			// 清單依大小遞增，前段過小的候選對之後更大的標題也不可能達到門檻
+			start := 0
+			for start < len(list) && len(tokens[list[start]]) < minSize {
+				start++
+			}
+			list = list[start:]
+			for _, j := range list {
+				if lastProbe[j] == i {
+					continue
+				}
+				lastProbe[j] = i
+				if jaccard(ts, tokens[j]) >= threshold {
+					uf.union(i, j)
+				}
+			}
+			prefix[t] = append(list, i)
+		}
+	}
+}
+
+// jaccard 計算兩個已排序、不重複集合的 Jaccard 相似度
+func jaccard(a, b []int) float64 {
+	common := 0
+	for i, j := 0, 0; i < len(a) && j < len(b); {
+		switch {
+		case a[i] == b[j]:
+			common++
+			i++
+			j++
+		case a[i] < b[j]:
+			i++
+		default:
+			j++
+		}
+	}
+	return float64(common) / float64(len(a)+len(b)-common)
+}
+
+// unionFind 是合併重複群組用的互斥集合
+type unionFind struct {
+	parent []int
+}
+
+func newUnionFind(n int) *unionFind {
+	uf := &unionFind{parent: make([]int, n)}
+	for i := range uf.parent {
+		uf.parent[i] = i
+	}
+	return uf
+}
+
+func (uf *unionFind) find(x int) int {
+	for uf.parent[x] != x {
+		uf.parent[x] = uf.parent[uf.parent[x]]
+		x = uf.parent[x]
+	}
+	return x
+}
+
+func (uf *unionFind) union(a, b int) {
+	if ra, rb := uf.find(a), uf.find(b); ra != rb {
+		uf.parent[rb] = ra
+	}
+}
@@ -0,0 +1,162 @@
+package titledup
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/rand/v2"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestNormalize(t *testing.T) {
+	tests := []struct {
+		title string
+		want  string
+	}{
+		{"[正妹] 今天的 正妹！", "正妹今天的正妹"},
+		{"Re: [正妹] 今天的正妹", "正妹今天的正妹"},
+		{"Fw: Re: [正妹]  今天的正妹", "正妹今天的正妹"},
+		{"[轉錄] [神人] Hello World", "神人helloworld"},
+		{"？！…", ""},
+	}
+	for _, tt := range tests {
+		if got := Normalize(tt.title); got != tt.want {
+			t.Errorf("Normalize(%q) = %q, want %q", tt.title, got, tt.want)
+		}
+	}
+}
+
+// groupURLs 將群組轉為各群組的 URL 清單方便比較
+func groupURLs(groups []Group) [][]string {
+	out := make([][]string, len(groups))
+	for i, g := range groups {
+		for _, a := range g.Articles {
+			out[i] = append(out[i], a.URL)
+		}
+	}
+	return out
+}
+
+func TestDetector_Groups(t *testing.T) {
+	articles := []Article{
+		{Title: "[正妹] 今天的正妹", URL: "a"},
+		{Title: "[帥哥] 路上的帥哥", URL: "b"},
+		{Title: "Fw: [正妹] 今天的正妹", URL: "c"},
+		{Title: "[正妹] 今天的正妹 2", URL: "d"},
+		{Title: "[帥哥] 完全不同的標題", URL: "e"},
+		{Title: "[正妹] 今天的正妹", URL: "a"}, // 同一 URL 重複加入時忽略
+		{Title: "！！！", URL: "f"},        // 正規化後為空字串，不參與比對
+	}
+
+	tests := []struct {
+		name      string
+		threshold float64
+		want      [][]string
+	}{
+		{"完全相同", 1, [][]string{{"a", "c"}}},
+		{"相似度 0.8", 0.8, [][]string{{"a", "c", "d"}}},
+		{"門檻超出範圍視為完全相同", 1.5, [][]string{{"a", "c"}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := NewDetector(tt.threshold)
+			for _, a := range articles {
+				d.Add(a)
+			}
+			if got := groupURLs(d.Groups()); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Groups() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDetector_GroupTitle(t *testing.T) {
+	d := NewDetector(1)
+	d.Add(Article{Title: "Re: [問卦] 有沒有八卦", URL: "a"})
+	d.Add(Article{Title: "[問卦] 有沒有八卦", URL: "b"})
+	groups := d.Groups()
+	if len(groups) != 1 || groups[0].Title != "問卦有沒有八卦" {
+		t.Errorf("Groups() = %+v, want 一組且標題為正規化後的標題", groups)
+	}
+}
+
+// TestDetector_ManyArticles 驗證大量不重複標題時仍能快速完成（prefix filtering 避免兩兩比較）
+func TestDetector_ManyArticles(t *testing.T) {
+	d := NewDetector(0.8)
+	const n = 50000
+	rng := rand.New(rand.NewPCG(1, 2))
+	titles := make([]string, n)
+	for i := range n {
+		// 以常用漢字隨機組成 8~15 字的標題，模擬真實看板的標題分佈
+		runes := make([]rune, 8+rng.IntN(8))
+		for k := range runes {
+			runes[k] = rune(0x4E00 + rng.IntN(3000))
+		}
+		titles[i] = "[正妹] " + string(runes)
+		d.Add(Article{Title: titles[i], URL: fmt.Sprint(i)})
+	}
+	d.Add(Article{Title: "Fw: " + titles[42] + "！", URL: "dup"})
+
+	start := time.Now()
+	groups := d.Groups()
+	if elapsed := time.Since(start); elapsed > 10*time.Second {
+		t.Errorf("Groups() 耗時 %v，文章數多時不應退化為兩兩比較", elapsed)
+	}
+	found := false
+	for _, g := range groups {
+		for _, a := range g.Articles {
+			found = found || a.URL == "dup"
+		}
+	}
+	if !found {
+		t.Errorf("應偵測到轉錄文章與原文重複，groups = %d 組", len(groups))
+	}
+}
+
+func TestDetector_WriteIndex(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "Beauty")
+	d := NewDetector(1)
+	d.Add(Article{Title: "[正妹] 同標題", URL: "a", Dir: "Beauty/[正妹] 同標題_10"})
+	d.Add(Article{Title: "[正妹] 同標題", URL: "b", Dir: "Beauty/[正妹] 同標題_10_2"})
+	d.Add(Article{Title: "[正妹] 不同", URL: "c", Dir: "Beauty/[正妹] 不同_3"})
+
+	n, err := d.WriteIndex(dir)
+	if err != nil {
+		t.Fatalf("WriteIndex() error = %v", err)
+	}
+	if n != 1 {
+		t.Errorf("WriteIndex() = %d 組, want 1", n)
+	}
+
+	data, err := os.ReadFile(filepath.Join(dir, IndexFileName))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var idx Index
+	if err := json.Unmarshal(data, &idx); err != nil {
+		t.Fatalf("索引不是合法 JSON: %v", err)
+	}
+	want := Index{Threshold: 1, Groups: []Group{{
+		Title: "正妹同標題",
+		Articles: []Article{
+			{Title: "[正妹] 同標題", URL: "a", Dir: "Beauty/[正妹] 同標題_10"},
+			{Title: "[正妹] 同標題", URL: "b", Dir: "Beauty/[正妹] 同標題_10_2"},
+		},
+	}}}
+	if !reflect.DeepEqual(idx, want) {
+		t.Errorf("索引 = %+v, want %+v", idx, want)
+	}
+
+	// 沒有重複時寫入空陣列，覆蓋先前執行的結果
+	empty := NewDetector(1)
+	if _, err := empty.WriteIndex(dir); err != nil {
+		t.Fatal(err)
+	}
+	data, _ = os.ReadFile(filepath.Join(dir, IndexFileName))
+	if err := json.Unmarshal(data, &idx); err != nil || idx.Groups == nil || len(idx.Groups) != 0 {
+		t.Errorf("沒有重複時 groups 應為空陣列: %s", data)
+	}
+}
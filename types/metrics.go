@@ -0,0 +1,29 @@
+package types
+
+import "time"
+
+// MetricsSnapshot 是執行期指標在某一時間點的快照
+type MetricsSnapshot struct {
+	TotalRequests    int64            // 對外請求總數（含失敗）
+	TotalErrors      int64            // 錯誤總數
+	ErrorsByType     map[string]int64 // 依錯誤類型分類的次數
+	StatusCodes      map[int]int64    // 依 HTTP 狀態碼分類的回應數
+	ImagesDownloaded int64            // 成功下載的圖片數
+	BytesWritten     int64            // 寫入磁碟的位元組數
+	ImagesPrechecked int64            // HEAD 預檢取得大小、排入下載的圖片數
+	BytesExpected    int64            // HEAD 預檢取得的預計下載位元組數
+	DownloadFailures map[string]int64 // 依失敗原因分類的圖片下載失敗數
+	ArticlesParsed   int64            // 成功解析的文章數
+	AvgLatency       time.Duration    // 請求平均延遲
+	P50Latency       time.Duration    // 請求延遲中位數
+	P95Latency       time.Duration    // 請求延遲第 95 百分位
+	P99Latency       time.Duration    // 請求延遲第 99 百分位
+	LatencySum       time.Duration    // 所有請求延遲的總和
+	LatencyBuckets   []LatencyBucket  // 請求延遲的累積分布（依上限遞增）
+}
+
+// LatencyBucket 是延遲直方圖的一個累積區間：延遲 <= UpperBound 的請求數
+type LatencyBucket struct {
+	UpperBound time.Duration
+	Count      int64
+}
@@ -1,6 +1,8 @@
 // Package types 定義 PTT Spider 的核心資料結構與進度事件類型。
 package types
 
+import "time"
+
 // EventType 定義進度事件的類型
 type EventType int
 
@@ -12,15 +14,17 @@ const (
 	EventDownloadDone                   // 圖片下載完成
 	EventDownloadFail                   // 圖片下載失敗
 	EventCrawlerDone                    // 爬蟲全部完成
+	EventProgress                       // 定期的整體進度與 ETA
 )
 
 // ProgressEvent 表示爬蟲執行過程中的進度事件
 type ProgressEvent struct {
-	Type         EventType // 事件類型
-	WorkerID     int       // Worker 編號（僅下載事件使用）
-	Message      string    // 事件描述訊息
-	ArticleTitle string    // 文章標題（僅文章相關事件使用）
-	ImageCount   int       // 圖片數量（僅 EventArticleParsed 使用）
-	CurrentPage  int       // 當前頁數（僅 EventPageParsed 使用）
-	TotalPages   int       // 總頁數（僅 EventPageParsed 使用）
+	Type         EventType     // 事件類型
+	WorkerID     int           // Worker 編號（僅下載事件使用）
+	Message      string        // 事件描述訊息
+	ArticleTitle string        // 文章標題（僅文章相關事件使用）
+	ImageCount   int           // 圖片數量（僅 EventArticleParsed 使用）
+	CurrentPage  int           // 當前頁數（僅 EventPageParsed 使用）
+	TotalPages   int           // 總頁數（僅 EventPageParsed 使用）
+	ETA          time.Duration // 預估剩餘時間（僅 EventProgress 使用，0 表示尚無法估算或即將完成）
 }
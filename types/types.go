@@ -1,24 +1,103 @@
 package types
 
+import "time"
+
 // ArticleInfo 用於儲存從看板列表頁解析出的基本文章資訊.
 type ArticleInfo struct {
-	Title    string // 文章標題，可能為空（檔案模式時）
-	URL      string // 文章完整 URL
-	Author   string // 作者帳號
-	PushRate int    // 推文數（正數為推，負數為噓）
+	Title     string    `json:"title"`                // 文章標題，可能為空（檔案模式時）
+	URL       string    `json:"url"`                  // 文章完整 URL
+	Author    string    `json:"author"`               // 作者帳號
+	PushRate  int       `json:"push_rate"`            // 推文數（正數為推，負數為噓）
+	HeatLevel HeatLevel `json:"heat_level,omitempty"` // 列表頁推文數的顏色等級（檔案模式為 HeatNone）
+	Board     string    `json:"board"`                // 所屬看板（看板模式由 producer 填入，檔案模式為空）
+	Date      string    `json:"date"`                 // 列表頁的發文日期（如 "1/05"，不含年份；檔案模式為空）
+	Pushes    PushStats `json:"pushes"`               // 文章頁的推／噓／→ 統計（解析文章頁後填入，列表頁為零值）
+	Comments  []Push    `json:"comments,omitempty"`   // 文章頁的推文內容（啟用 savePushes 時解析後填入）
+	Body      string    `json:"body,omitempty"`       // 文章內文（解析文章頁後填入，列表頁為空）
+	PostedAt  time.Time `json:"posted_at,omitzero"`   // 文章頁「時間」欄位的發文時間（解析文章頁後填入，未解析到時為零值）
+}
+
+// HeatLevel 是列表頁推文數的熱度等級，對應 PTT 以 `.hl f1`~`.hl f3` 標示的顏色，
+// 數值越大越熱門，可直接比較大小排序或過濾
+type HeatLevel int
+
+// 推文數的熱度等級
+const (
+	HeatNone   HeatLevel = iota // 沒有顏色標示（無推文、噓文或檔案模式）
+	HeatLow                     // 綠色（f2）：推文數 1~9
+	HeatMedium                  // 黃色（f3）：推文數 10~99
+	HeatHigh                    // 紅色（f1）：爆
+)
+
+// String 回傳熱度等級的名稱，如 "high"
+func (h HeatLevel) String() string {
+	switch h {
+	case HeatLow:
+		return "low"
+	case HeatMedium:
+		return "medium"
+	case HeatHigh:
+		return "high"
+	default:
+		return "none"
+	}
+}
+
+// Push 是文章頁的一則推文
+type Push struct {
+	Tag     string `json:"tag"`     // 推文類型：推、噓或 →
+	Author  string `json:"author"`  // 推文者帳號
+	Content string `json:"content"` // 推文內容（已去除開頭的「: 」）
+	Time    string `json:"time"`    // 推文時間（PTT 原始格式，如 "01/05 10:00"，可能含 IP）
+}
+
+// PushStats 是文章頁推文依類型的實際數量。
+// 列表頁的 PushRate 是 PTT 計算的淨推文數（爆、X1 等為估計值），這裡則是逐則統計。
+type PushStats struct {
+	PushCount    int `json:"push_count"`    // 推
+	BooCount     int `json:"boo_count"`     // 噓
+	NeutralCount int `json:"neutral_count"` // →（箭頭，不計入推噓）
+}
+
+// Total 回傳推文總則數
+func (p PushStats) Total() int {
+	return p.PushCount + p.BooCount + p.NeutralCount
+}
+
+// ArticleContent 用於儲存從文章內容頁解析出的資訊.
+type ArticleContent struct {
+	Title     string    // 文章標題（metadata 的「標題」欄位，可能為空）
+	Author    string    // 作者帳號（metadata 的「作者」欄位去除暱稱，可能為空）
+	Date      time.Time // 發文時間（metadata 的「時間」欄位，UTC+8），無法解析時為零值
+	ImageURLs []string  // 文章中的圖片 URL
+	AlbumURLs []string  // imgur 相簿連結（/a/、/gallery/），啟用 expandImgurAlbums 時展開為圖片
+	Pushes    PushStats // 推文依類型（推／噓／→）的數量
+	Comments  []Push    // 推文內容（啟用 savePushes 時才解析）
+	Body      string    // 內文：#main-content 去除 metadata、推文、簽名檔與「※ 發信站」之後的文字
 }
 
 // DownloadTask 用於儲存單一圖片的下載任務資訊.
 type DownloadTask struct {
-	ImageURL string // 圖片的完整 URL
-	SavePath string // 圖片應儲存的完整本地路徑 (含檔名)
+	ImageURL   string `json:"image_url"`   // 圖片的完整 URL
+	SavePath   string `json:"save_path"`   // 圖片應儲存的完整本地路徑 (含檔名)
+	Board      string `json:"board"`       // 所屬看板，用於依看板分檔的日誌（可能為空）
+	ArticleURL string `json:"article_url"` // 所屬文章的 URL，用於斷點續爬追蹤文章是否完整處理（可能為空）
+	Size       int64  `json:"size"`        // HEAD 預檢取得的圖片大小（bytes），未預檢或無法取得時為 0
 }
 
 // MarkdownInfo 用於儲存產生 Markdown 檔案所需的資訊.
 type MarkdownInfo struct {
-	Title      string   // 文章標題
-	ArticleURL string   // 原始文章 URL
-	PushCount  int      // 推文數
-	ImageURLs  []string // 所有圖片 URL 列表
-	SaveDir    string   // 儲存 Markdown 和圖片的目錄
+	Title      string    `json:"title"`                 // 文章標題
+	ArticleURL string    `json:"article_url"`           // 原始文章 URL
+	Author     string    `json:"author"`                // 作者帳號（未知時為空字串）
+	Date       time.Time `json:"date,omitzero"`         // 發文時間（文章頁的「時間」欄位），未解析到時為零值
+	PushCount  int       `json:"push_count"`            // 推文數
+	Pushes     PushStats `json:"pushes"`                // 文章頁的推／噓／→ 統計（未解析時為零值）
+	ImageURLs  []string  `json:"image_urls"`            // 所有圖片 URL 列表
+	ImageFiles []string  `json:"image_files,omitempty"` // 圖片相對於 SaveDir 的路徑，與 ImageURLs 一一對應；為空時由 URL 推導檔名
+	Archive    string    `json:"archive,omitempty"`     // 圖片封存檔相對於 SaveDir 的路徑（pack: tar 時），圖片位於封存檔內而非個別檔案
+	Comments   []Push    `json:"comments,omitempty"`    // 推文內容（啟用 savePushes 時才有）
+	Body       string    `json:"body,omitempty"`        // 文章內文（未解析到時為空）
+	SaveDir    string    `json:"save_dir"`              // 儲存 Markdown 和圖片的目錄
+	SiteDir    string    `json:"site_dir,omitempty"`    // 文章目錄相對於輸出根目錄、以 / 分隔的路徑（如 Beauty/標題_10），output.linkBase 以此組出連結
 }
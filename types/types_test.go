@@ -1,7 +1,9 @@
 package types
 
 import (
+	"encoding/json"
 	"reflect"
+	"slices"
 	"testing"
 )
 
@@ -422,6 +424,68 @@ func TestTypesSerialization(t *testing.T) {
 	}
 }
 
+// jsonKeys 回傳 v 序列化後的最上層欄位名（已排序）
+func jsonKeys(t *testing.T, v any) []string {
+	t.Helper()
+	data, err := json.Marshal(v)
+	if err != nil {
+		t.Fatalf("json.Marshal() error = %v", err)
+	}
+	var m map[string]any
+	if err := json.Unmarshal(data, &m); err != nil {
+		t.Fatalf("json.Unmarshal() error = %v", err)
+	}
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+	return keys
+}
+
+func TestTypesJSONTags(t *testing.T) {
+	tests := []struct {
+		name string
+		v    any
+		want []string
+	}{
+		{"ArticleInfo", ArticleInfo{}, []string{"author", "board", "date", "push_rate", "pushes", "title", "url"}},
+		{"PushStats", PushStats{}, []string{"boo_count", "neutral_count", "push_count"}},
+		{"DownloadTask", DownloadTask{}, []string{"article_url", "board", "image_url", "save_path", "size"}},
+		{"MarkdownInfo", MarkdownInfo{}, []string{"article_url", "author", "image_urls", "push_count", "pushes", "save_dir", "title"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := jsonKeys(t, tt.v); !slices.Equal(got, tt.want) {
+				t.Errorf("JSON 欄位 = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTypesJSONRoundTrip(t *testing.T) {
+	info := MarkdownInfo{
+		Title:      "[正妹] 序列化測試",
+		ArticleURL: "https://www.ptt.cc/bbs/Beauty/M.1234567890.A.ABC.html",
+		Author:     "testuser",
+		PushCount:  42,
+		Pushes:     PushStats{PushCount: 40, BooCount: 1, NeutralCount: 3},
+		ImageURLs:  []string{"https://i.imgur.com/test.jpg"},
+		SaveDir:    "/path/to/save",
+	}
+	data, err := json.Marshal(info)
+	if err != nil {
+		t.Fatalf("json.Marshal() error = %v", err)
+	}
+	var got MarkdownInfo
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatalf("json.Unmarshal() error = %v", err)
+	}
+	if !reflect.DeepEqual(got, info) {
+		t.Errorf("round trip = %+v, want %+v", got, info)
+	}
+}
+
 func TestTypesZeroValues(t *testing.T) {
 	// Test zero values of each type
 	var articleInfo ArticleInfo
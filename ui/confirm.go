@@ -0,0 +1,34 @@
+package ui
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// IsTerminal 回報 f 是否連接到終端機（互動環境）；管線、重新導向的檔案與 cron 等非互動環境回傳 false
+func IsTerminal(f *os.File) bool {
+	info, err := f.Stat()
+	if err != nil {
+		return false
+	}
+	return info.Mode()&os.ModeCharDevice != 0
+}
+
+// Confirm 在 out 顯示 prompt 並從 in 讀取一行回答，y 或 yes（不分大小寫）視為同意；
+// 其他回答、空行或讀取失敗（如 EOF）一律視為拒絕
+func Confirm(in io.Reader, out io.Writer, prompt string) bool {
+	fmt.Fprintf(out, "%s [y/N]: ", prompt)
+	line, err := bufio.NewReader(in).ReadString('\n')
+	if err != nil && line == "" {
+		return false
+	}
+	switch strings.ToLower(strings.TrimSpace(line)) {
+	case "y", "yes":
+		return true
+	default:
+		return false
+	}
+}
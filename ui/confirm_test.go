@@ -0,0 +1,47 @@
+package ui
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestConfirm(t *testing.T) {
+	tests := []struct {
+		input string
+		want  bool
+	}{
+		{"y\n", true},
+		{"YES\n", true},
+		{" yes \n", true},
+		{"y", true}, // 沒有換行就 EOF
+		{"n\n", false},
+		{"\n", false},
+		{"", false},
+		{"yep\n", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			var out strings.Builder
+			if got := Confirm(strings.NewReader(tt.input), &out, "繼續？"); got != tt.want {
+				t.Errorf("Confirm(%q) = %v, want %v", tt.input, got, tt.want)
+			}
+			if out.String() != "繼續？ [y/N]: " {
+				t.Errorf("提示 = %q", out.String())
+			}
+		})
+	}
+}
+
+func TestIsTerminal_File(t *testing.T) {
+	f, err := os.Create(filepath.Join(t.TempDir(), "stdin"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	if IsTerminal(f) {
+		t.Error("一般檔案不應視為終端機")
+	}
+}
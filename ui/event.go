@@ -0,0 +1,37 @@
+package ui
+
+import (
+	"fmt"
+	"log/slog"
+)
+
+// LevelSuccess 成功訊息的等級，介於 Info 與 Warn 之間。
+// 等級過濾時視為 Info；結構化輸出時記為 INFO 並附加 success=true。
+const LevelSuccess = slog.LevelInfo + 2
+
+// EventLogger 是可選介面，由支援結構化欄位的 Logger（如 SlogLogger）實作。
+// event 為事件名稱（如 download_done），attrs 為附加欄位（如 worker_id、url）。
+type EventLogger interface {
+	Event(level slog.Level, event, msg string, attrs ...slog.Attr)
+}
+
+// LogEvent 輸出一筆事件日誌：logger 實作 EventLogger 時輸出結構化欄位，
+// 否則依等級退回一般的格式化訊息，純文字輸出與未使用事件時相同。
+func LogEvent(logger Logger, level slog.Level, event string, attrs []slog.Attr, format string, args ...any) {
+	if el, ok := logger.(EventLogger); ok {
+		el.Event(level, event, fmt.Sprintf(format, args...), attrs...)
+		return
+	}
+	switch {
+	case level == LevelSuccess:
+		logger.Success(format, args...)
+	case level >= slog.LevelError:
+		logger.Error(format, args...)
+	case level >= slog.LevelWarn:
+		logger.Warn(format, args...)
+	case level >= slog.LevelInfo:
+		logger.Info(format, args...)
+	default:
+		logger.Debug(format, args...)
+	}
+}
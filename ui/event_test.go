@@ -0,0 +1,81 @@
+package ui
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"testing"
+)
+
+func TestLogEvent_FallbackToPlainMethods(t *testing.T) {
+	tests := []struct {
+		name  string
+		level slog.Level
+		want  string
+	}{
+		{"debug", slog.LevelDebug, "debug"},
+		{"info", slog.LevelInfo, "info"},
+		{"success", LevelSuccess, "success"},
+		{"warn", slog.LevelWarn, "warn"},
+		{"error", slog.LevelError, "error"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			inner := &recordingLogger{calls: map[string]int{}}
+			LogEvent(inner, tt.level, "download_done", []slog.Attr{slog.Int("worker_id", 1)}, "msg %d", 1)
+			if inner.calls[tt.want] != 1 || len(inner.calls) != 1 {
+				t.Errorf("等級 %v 應只呼叫 %s，got %v", tt.level, tt.want, inner.calls)
+			}
+		})
+	}
+}
+
+func TestLogEvent_StructuredOutput(t *testing.T) {
+	var buf bytes.Buffer
+	logger := NewSlogLogger(slog.New(NewJSONHandler(&buf, slog.LevelInfo))).With("board", "Beauty")
+
+	LogEvent(logger, LevelSuccess, "download_done",
+		[]slog.Attr{slog.Int("worker_id", 3), slog.String("url", "https://i.imgur.com/a.jpg"), slog.Int64("bytes", 1024)},
+		"工人 #%d 下載完成", 3)
+
+	var rec map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &rec); err != nil {
+		t.Fatalf("輸出不是合法 JSON: %v, %q", err, buf.String())
+	}
+
+	want := map[string]any{
+		"level":     "INFO",
+		"msg":       "工人 #3 下載完成",
+		"event":     "download_done",
+		"board":     "Beauty",
+		"worker_id": float64(3),
+		"url":       "https://i.imgur.com/a.jpg",
+		"bytes":     float64(1024),
+		"success":   true,
+	}
+	for k, v := range want {
+		if rec[k] != v {
+			t.Errorf("欄位 %s = %v, want %v", k, rec[k], v)
+		}
+	}
+	if _, ok := rec["ts"]; !ok {
+		t.Error("應包含 ts 時間欄位")
+	}
+}
+
+func TestLevelLogger_Event(t *testing.T) {
+	var buf bytes.Buffer
+	inner := NewSlogLogger(slog.New(NewJSONHandler(&buf, slog.LevelDebug)))
+	logger := NewLevelLogger(inner, slog.LevelInfo)
+
+	LogEvent(logger, slog.LevelDebug, "download_start", nil, "略過")
+	if buf.Len() != 0 {
+		t.Errorf("低於等級的事件應被過濾，got %q", buf.String())
+	}
+
+	LogEvent(logger, LevelSuccess, "download_done", nil, "完成")
+	if !bytes.Contains(buf.Bytes(), []byte(`"event":"download_done"`)) {
+		t.Errorf("LevelLogger 應保留內層的結構化欄位，got %q", buf.String())
+	}
+}
@@ -0,0 +1,20 @@
+package ui
+
+// JSON 日誌（-log-format json）的欄位名稱。
+// 所有欄位皆為 snake_case，同一概念在各模組使用相同名稱，
+// 讓 ELK、Loki 等日誌系統可以用固定的欄位做查詢與建立索引。
+const (
+	FieldTime        = "ts"           // RFC 3339 UTC 時間（毫秒精度）
+	FieldLevel       = "level"        // 等級：DEBUG、INFO、WARN、ERROR 其中之一
+	FieldMessage     = "msg"          // 人類可讀的訊息
+	FieldEvent       = "event"        // 事件名稱（如 download_done），僅事件日誌有此欄位
+	FieldSuccess     = "success"      // 成功訊息標記（Logger.Success 與 LevelSuccess 事件）
+	FieldBoard       = "board"        // 看板名稱
+	FieldWorkerID    = "worker_id"    // 下載工人編號
+	FieldURL         = "url"          // 請求的 URL
+	FieldPath        = "path"         // 本機檔案路徑
+	FieldBytes       = "bytes"        // 位元組數
+	FieldStatus      = "status"       // HTTP 狀態碼
+	FieldContentType = "content_type" // 回應的 Content-Type
+	FieldError       = "error"        // 錯誤訊息字串
+)
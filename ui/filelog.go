@@ -0,0 +1,185 @@
+package ui
+
+import (
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/twtrubiks/ptt-spider-go/constants"
+)
+
+// BoardRouter 是可選介面，由能依看板分流的 Logger（如 BoardFileLogger）實作。
+// ForBoard 回傳該看板專屬的 Logger；呼叫端未取得看板時應直接使用原 Logger.
+type BoardRouter interface {
+	ForBoard(board string) Logger
+}
+
+// TeeLogger 將每則訊息同時送往多個 Logger（如終端與日誌檔）.
+type TeeLogger struct {
+	loggers []Logger
+}
+
+// NewTeeLogger 建立同時輸出到所有 loggers 的 Logger.
+func NewTeeLogger(loggers ...Logger) *TeeLogger {
+	return &TeeLogger{loggers: loggers}
+}
+
+// Debug 將除錯訊息送往所有 Logger.
+func (t *TeeLogger) Debug(format string, args ...any) {
+	for _, l := range t.loggers {
+		l.Debug(format, args...)
+	}
+}
+
+// Info 將一般資訊訊息送往所有 Logger.
+func (t *TeeLogger) Info(format string, args ...any) {
+	for _, l := range t.loggers {
+		l.Info(format, args...)
+	}
+}
+
+// Success 將成功訊息送往所有 Logger.
+func (t *TeeLogger) Success(format string, args ...any) {
+	for _, l := range t.loggers {
+		l.Success(format, args...)
+	}
+}
+
+// Error 將錯誤訊息送往所有 Logger.
+func (t *TeeLogger) Error(format string, args ...any) {
+	for _, l := range t.loggers {
+		l.Error(format, args...)
+	}
+}
+
+// Warn 將警告訊息送往所有 Logger.
+func (t *TeeLogger) Warn(format string, args ...any) {
+	for _, l := range t.loggers {
+		l.Warn(format, args...)
+	}
+}
+
+// Event 將事件送往所有 Logger，支援 EventLogger 的保留結構化欄位.
+func (t *TeeLogger) Event(level slog.Level, event, msg string, attrs ...slog.Attr) {
+	for _, l := range t.loggers {
+		LogEvent(l, level, event, attrs, "%s", msg)
+	}
+}
+
+// NewFileLogger 建立寫入 w 的 Logger：format 為 json 時輸出 NDJSON（欄位同 NewJSONHandler），
+// 其他值輸出 slog 的 key=value 文字格式，兩者都帶時間戳，適合寫入日誌檔.
+func NewFileLogger(w io.Writer, format string, level slog.Leveler) *SlogLogger {
+	if format == "json" {
+		return NewSlogLogger(slog.New(NewJSONHandler(w, level)))
+	}
+	return NewSlogLogger(slog.New(slog.NewTextHandler(w, &slog.HandlerOptions{Level: level})))
+}
+
+// OpenLogFile 以附加模式開啟（必要時建立）日誌檔與其目錄.
+func OpenLogFile(path string) (*os.File, error) {
+	if err := os.MkdirAll(filepath.Dir(path), constants.DirPermission); err != nil {
+		return nil, fmt.Errorf("建立日誌目錄失敗: %w", err)
+	}
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_APPEND, constants.FilePermission)
+	if err != nil {
+		return nil, fmt.Errorf("開啟日誌檔失敗: %w", err)
+	}
+	return f, nil
+}
+
+// BoardLogPath 由主日誌檔路徑推導看板日誌檔路徑，如 logs/crawl.log → logs/crawl-Beauty.log.
+// 看板名稱中非英數、底線、連字號的字元以底線取代，避免跳出日誌目錄.
+func BoardLogPath(mainPath, board string) string {
+	safe := strings.Map(func(r rune) rune {
+		if r == '_' || r == '-' || (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') {
+			return r
+		}
+		return '_'
+	}, board)
+	ext := filepath.Ext(mainPath)
+	return strings.TrimSuffix(mainPath, ext) + "-" + safe + ext
+}
+
+// BoardFileLogger 在 base Logger 之外，將看板相關的訊息另外寫入各看板的日誌檔。
+// 看板日誌檔在第一次使用時才開啟，最多 maxFiles 個；超過上限的看板只寫入 base 並提示一次。
+// 使用完畢須呼叫 Close 關閉所有檔案.
+type BoardFileLogger struct {
+	Logger // 未指定看板的訊息直接寫入 base
+
+	mainPath string
+	maxFiles int
+	newLog   func(io.Writer) Logger
+
+	mu      sync.Mutex
+	files   []*os.File
+	loggers map[string]Logger
+	full    bool
+}
+
+// NewBoardFileLogger 建立依看板分檔的 Logger：看板日誌檔路徑由 BoardLogPath(mainPath, board) 推導，
+// newLog 決定檔案內的輸出格式（如 NewFileLogger）.
+func NewBoardFileLogger(base Logger, mainPath string, maxFiles int, newLog func(io.Writer) Logger) *BoardFileLogger {
+	return &BoardFileLogger{
+		Logger:   base,
+		mainPath: mainPath,
+		maxFiles: maxFiles,
+		newLog:   newLog,
+		loggers:  make(map[string]Logger),
+	}
+}
+
+// Event 將未指定看板的事件寫入 base，支援 EventLogger 時保留結構化欄位.
+func (b *BoardFileLogger) Event(level slog.Level, event, msg string, attrs ...slog.Attr) {
+	LogEvent(b.Logger, level, event, attrs, "%s", msg)
+}
+
+// ForBoard 回傳同時寫入 base 與該看板日誌檔的 Logger。
+// 開檔失敗或已達檔案數上限時退回 base，並透過 base 記錄原因.
+func (b *BoardFileLogger) ForBoard(board string) Logger {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if l, ok := b.loggers[board]; ok {
+		return l
+	}
+	if len(b.files) >= b.maxFiles {
+		if !b.full {
+			b.full = true
+			b.Logger.Warn("看板日誌檔已達上限 %d 個，其餘看板（如 %s）只寫入主日誌", b.maxFiles, board)
+		}
+		b.loggers[board] = b.Logger
+		return b.Logger
+	}
+
+	path := BoardLogPath(b.mainPath, board)
+	f, err := OpenLogFile(path)
+	if err != nil {
+		b.Logger.Warn("看板 %s 的日誌檔無法使用，只寫入主日誌: %v", board, err)
+		b.loggers[board] = b.Logger
+		return b.Logger
+	}
+	b.files = append(b.files, f)
+	l := NewTeeLogger(b.Logger, b.newLog(f))
+	b.loggers[board] = l
+	return l
+}
+
+// Close 關閉所有已開啟的看板日誌檔，回傳第一個遇到的錯誤.
+func (b *BoardFileLogger) Close() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	var first error
+	for _, f := range b.files {
+		if err := f.Close(); err != nil && first == nil {
+			first = err
+		}
+	}
+	b.files = nil
+	b.loggers = make(map[string]Logger)
+	return first
+}
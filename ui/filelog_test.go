@@ -0,0 +1,145 @@
+package ui
+
+import (
+	"bytes"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestBoardLogPath(t *testing.T) {
+	tests := []struct {
+		mainPath, board, want string
+	}{
+		{"logs/crawl.log", "Beauty", "logs/crawl-Beauty.log"},
+		{"crawl", "Gossiping", "crawl-Gossiping"},
+		{"logs/crawl.log", "../etc", "logs/crawl-___etc.log"},
+		{"logs/crawl.log", "a b/c", "logs/crawl-a_b_c.log"},
+	}
+	for _, tt := range tests {
+		if got := BoardLogPath(tt.mainPath, tt.board); got != tt.want {
+			t.Errorf("BoardLogPath(%q, %q) = %q, want %q", tt.mainPath, tt.board, got, tt.want)
+		}
+	}
+}
+
+func TestTeeLogger(t *testing.T) {
+	var a, b bytes.Buffer
+	logger := NewTeeLogger(NewFileLogger(&a, "json", slog.LevelInfo), NewFileLogger(&b, "text", slog.LevelInfo))
+
+	logger.Info("正在解析文章: %s", "Beauty")
+	logger.Event(slog.LevelWarn, "download_failed", "下載失敗", slog.String(FieldURL, "https://i.imgur.com/a.jpg"))
+
+	for name, out := range map[string]string{"json": a.String(), "text": b.String()} {
+		for _, want := range []string{"正在解析文章: Beauty", "download_failed", "i.imgur.com/a.jpg"} {
+			if !strings.Contains(out, want) {
+				t.Errorf("%s 輸出應包含 %q，got %q", name, want, out)
+			}
+		}
+	}
+}
+
+func readFile(t *testing.T, path string) string {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("讀取 %s 失敗: %v", path, err)
+	}
+	return string(data)
+}
+
+func TestBoardFileLogger_RoutesByBoard(t *testing.T) {
+	dir := t.TempDir()
+	mainPath := filepath.Join(dir, "logs", "crawl.log")
+	f, err := OpenLogFile(mainPath)
+	if err != nil {
+		t.Fatalf("OpenLogFile() error = %v", err)
+	}
+	defer f.Close()
+
+	newLog := func(w io.Writer) Logger { return NewFileLogger(w, "text", slog.LevelInfo) }
+	logger := NewBoardFileLogger(newLog(f), mainPath, 4, newLog)
+
+	logger.Info("開始爬取")
+	logger.ForBoard("Beauty").Info("Beauty 文章")
+	logger.ForBoard("Gossiping").Info("Gossiping 文章")
+	logger.ForBoard("Beauty").Warn("Beauty 警告")
+	if err := logger.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+
+	main := readFile(t, mainPath)
+	for _, want := range []string{"開始爬取", "Beauty 文章", "Gossiping 文章", "Beauty 警告"} {
+		if !strings.Contains(main, want) {
+			t.Errorf("主日誌應包含所有訊息 %q，got %q", want, main)
+		}
+	}
+
+	beauty := readFile(t, filepath.Join(dir, "logs", "crawl-Beauty.log"))
+	if !strings.Contains(beauty, "Beauty 文章") || !strings.Contains(beauty, "Beauty 警告") {
+		t.Errorf("Beauty 日誌缺少看板訊息: %q", beauty)
+	}
+	if strings.Contains(beauty, "Gossiping") || strings.Contains(beauty, "開始爬取") {
+		t.Errorf("Beauty 日誌不應包含其他看板或未指定看板的訊息: %q", beauty)
+	}
+	gossiping := readFile(t, filepath.Join(dir, "logs", "crawl-Gossiping.log"))
+	if !strings.Contains(gossiping, "Gossiping 文章") || strings.Contains(gossiping, "Beauty") {
+		t.Errorf("Gossiping 日誌內容不正確: %q", gossiping)
+	}
+}
+
+func TestBoardFileLogger_MaxFiles(t *testing.T) {
+	dir := t.TempDir()
+	mainPath := filepath.Join(dir, "crawl.log")
+	var base bytes.Buffer
+	newLog := func(w io.Writer) Logger { return NewFileLogger(w, "text", slog.LevelInfo) }
+	logger := NewBoardFileLogger(newLog(&base), mainPath, 2, newLog)
+	defer logger.Close()
+
+	for _, board := range []string{"A", "B", "C", "D"} {
+		logger.ForBoard(board).Info("%s 文章", board)
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 2 {
+		t.Errorf("看板日誌檔應限制為 2 個，got %d", len(entries))
+	}
+	if _, err := os.Stat(BoardLogPath(mainPath, "C")); !os.IsNotExist(err) {
+		t.Errorf("超過上限的看板不應建立日誌檔, err = %v", err)
+	}
+	out := base.String()
+	if strings.Count(out, "看板日誌檔已達上限") != 1 {
+		t.Errorf("達上限時應只提示一次: %q", out)
+	}
+	for _, want := range []string{"C 文章", "D 文章"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("超過上限的看板訊息仍應寫入主日誌 %q", want)
+		}
+	}
+}
+
+func TestBoardFileLogger_CloseClosesFiles(t *testing.T) {
+	dir := t.TempDir()
+	newLog := func(w io.Writer) Logger { return NewFileLogger(w, "text", slog.LevelInfo) }
+	logger := NewBoardFileLogger(NewPlainLogger(), filepath.Join(dir, "crawl.log"), 4, newLog)
+
+	logger.ForBoard("Beauty").Info("第一則")
+	files := append([]*os.File(nil), logger.files...)
+	if err := logger.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+	for _, f := range files {
+		if _, err := f.Write([]byte("x")); err == nil {
+			t.Errorf("Close 後 %s 應已關閉", f.Name())
+		}
+	}
+	if len(logger.files) != 0 {
+		t.Errorf("Close 後不應保留已開啟的檔案，got %d", len(logger.files))
+	}
+}
@@ -0,0 +1,75 @@
+package ui
+
+import (
+	"fmt"
+	"log/slog"
+	"strings"
+)
+
+// ParseLevel 將 debug/info/warn/error 字串（不分大小寫）解析為 slog.Level.
+func ParseLevel(s string) (slog.Level, error) {
+	var level slog.Level
+	if err := level.UnmarshalText([]byte(strings.TrimSpace(s))); err != nil {
+		return slog.LevelInfo, fmt.Errorf("無效的日誌等級 %q（可用值: debug、info、warn、error）", s)
+	}
+	return level, nil
+}
+
+// LevelLogger 包裝任一 Logger，低於最低等級的訊息直接丟棄。
+// Success 視為 Info 等級。
+type LevelLogger struct {
+	inner Logger
+	level slog.Level
+}
+
+// NewLevelLogger 建立依等級過濾的 Logger.
+func NewLevelLogger(inner Logger, level slog.Level) *LevelLogger {
+	return &LevelLogger{inner: inner, level: level}
+}
+
+// Debug 在等級允許時輸出除錯訊息.
+func (l *LevelLogger) Debug(format string, args ...any) {
+	if l.level <= slog.LevelDebug {
+		l.inner.Debug(format, args...)
+	}
+}
+
+// Info 在等級允許時輸出一般資訊訊息.
+func (l *LevelLogger) Info(format string, args ...any) {
+	if l.level <= slog.LevelInfo {
+		l.inner.Info(format, args...)
+	}
+}
+
+// Success 在等級允許時輸出成功訊息.
+func (l *LevelLogger) Success(format string, args ...any) {
+	if l.level <= slog.LevelInfo {
+		l.inner.Success(format, args...)
+	}
+}
+
+// Error 在等級允許時輸出錯誤訊息.
+func (l *LevelLogger) Error(format string, args ...any) {
+	if l.level <= slog.LevelError {
+		l.inner.Error(format, args...)
+	}
+}
+
+// Warn 在等級允許時輸出警告訊息.
+func (l *LevelLogger) Warn(format string, args ...any) {
+	if l.level <= slog.LevelWarn {
+		l.inner.Warn(format, args...)
+	}
+}
+
+// Event 在等級允許時轉交內層 Logger，內層支援 EventLogger 時保留結構化欄位.
+func (l *LevelLogger) Event(level slog.Level, event, msg string, attrs ...slog.Attr) {
+	effective := level
+	if level == LevelSuccess {
+		effective = slog.LevelInfo
+	}
+	if effective < l.level {
+		return
+	}
+	LogEvent(l.inner, level, event, attrs, "%s", msg)
+}
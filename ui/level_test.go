@@ -0,0 +1,76 @@
+package ui
+
+import (
+	"log/slog"
+	"testing"
+)
+
+// recordingLogger 記錄各等級被呼叫的次數
+type recordingLogger struct {
+	calls map[string]int
+}
+
+func (r *recordingLogger) Debug(_ string, _ ...any)   { r.calls["debug"]++ }
+func (r *recordingLogger) Info(_ string, _ ...any)    { r.calls["info"]++ }
+func (r *recordingLogger) Success(_ string, _ ...any) { r.calls["success"]++ }
+func (r *recordingLogger) Error(_ string, _ ...any)   { r.calls["error"]++ }
+func (r *recordingLogger) Warn(_ string, _ ...any)    { r.calls["warn"]++ }
+
+func TestParseLevel(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    slog.Level
+		wantErr bool
+	}{
+		{"debug", slog.LevelDebug, false},
+		{"info", slog.LevelInfo, false},
+		{"WARN", slog.LevelWarn, false},
+		{" error ", slog.LevelError, false},
+		{"verbose", slog.LevelInfo, true},
+		{"", slog.LevelInfo, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := ParseLevel(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseLevel(%q) err = %v, wantErr %v", tt.input, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ParseLevel(%q) = %v, want %v", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLevelLogger_Filters(t *testing.T) {
+	tests := []struct {
+		name  string
+		level slog.Level
+		want  map[string]int
+	}{
+		{"debug 全部輸出", slog.LevelDebug, map[string]int{"debug": 1, "info": 1, "success": 1, "warn": 1, "error": 1}},
+		{"info 略過 debug", slog.LevelInfo, map[string]int{"info": 1, "success": 1, "warn": 1, "error": 1}},
+		{"warn 只剩警告與錯誤", slog.LevelWarn, map[string]int{"warn": 1, "error": 1}},
+		{"error 只剩錯誤", slog.LevelError, map[string]int{"error": 1}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			inner := &recordingLogger{calls: map[string]int{}}
+			var logger Logger = NewLevelLogger(inner, tt.level)
+
+			logger.Debug("d")
+			logger.Info("i")
+			logger.Success("s")
+			logger.Warn("w")
+			logger.Error("e")
+
+			for _, key := range []string{"debug", "info", "success", "warn", "error"} {
+				if inner.calls[key] != tt.want[key] {
+					t.Errorf("%s 呼叫次數 = %d, want %d", key, inner.calls[key], tt.want[key])
+				}
+			}
+		})
+	}
+}
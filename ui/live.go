@@ -55,6 +55,7 @@ type liveModel struct {
 	imagesTotal  int
 	downloadOK   int
 	downloadFail int
+	eta          string // 最近一次 EventProgress 的進度與 ETA 摘要
 
 	// Worker 狀態：workerID → 目前訊息
 	workers     map[int]string
@@ -173,6 +174,9 @@ func (m *liveModel) handleEvent(evt types.ProgressEvent) {
 		m.workers[evt.WorkerID] = workerStatusIdle
 		m.addLog(ts, " ERR", fmt.Sprintf("Worker#%d %s", evt.WorkerID, evt.Message))
 
+	case types.EventProgress:
+		m.eta = evt.Message
+
 	case types.EventCrawlerDone:
 		m.done = true
 		m.doneMsg = evt.Message
@@ -231,7 +235,12 @@ func (m liveModel) View() tea.View {
 	// 文章統計
 	b.WriteString(liveDimStyle.Render(fmt.Sprintf("  文章: %d 篇已解析，共發現 %d 張圖片",
 		m.articlesOK, m.imagesTotal)))
-	b.WriteString("\n\n")
+	b.WriteString("\n")
+	if m.eta != "" && !m.done {
+		b.WriteString(liveDimStyle.Render("  進度: " + m.eta))
+		b.WriteString("\n")
+	}
+	b.WriteString("\n")
 
 	// Worker 狀態
 	if m.maxWorkerID > 0 {
@@ -258,6 +267,9 @@ func (m liveModel) View() tea.View {
 		// 標題(1) + 設定(1) + 空行(1) + 頁面進度(1) + 下載進度(1) + 統計(1) + 空行(1)
 		// + workers + 空行(1) + 日誌標題(1) + 日誌空行(1) + 底部(1) = 11 + workerCount
 		fixedLines := 12 + len(m.workers)
+		if m.eta != "" && !m.done {
+			fixedLines++ // 進度與 ETA
+		}
 
 		maxVisible := len(m.logs)
 		if m.height > 0 {
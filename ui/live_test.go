@@ -4,6 +4,7 @@ import (
 	"context"
 	"strings"
 	"testing"
+	"time"
 	"unicode/utf8"
 
 	tea "charm.land/bubbletea/v2"
@@ -163,6 +164,34 @@ func TestLiveModel_HandleCrawlerDone(t *testing.T) {
 	}
 }
 
+func TestLiveModel_HandleProgressEvent(t *testing.T) {
+	ch := make(chan types.ProgressEvent, 1)
+	_, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	m := newLiveModel(LiveConfig{Board: "test", Pages: 3}, ch, cancel)
+	if strings.Contains(m.View().Content, "預估剩餘") {
+		t.Error("尚未收到 EventProgress 時不應顯示 ETA")
+	}
+
+	m.handleEvent(types.ProgressEvent{
+		Type:    types.EventProgress,
+		Message: "1/3 頁，圖片 2/10，預估剩餘 40s",
+		ETA:     40 * time.Second,
+	})
+	if !strings.Contains(m.View().Content, "預估剩餘 40s") {
+		t.Error("View should contain ETA after EventProgress")
+	}
+	if len(m.logs) != 0 {
+		t.Error("EventProgress 只更新進度列，不應寫入事件 log")
+	}
+
+	m.done = true
+	if strings.Contains(m.View().Content, "預估剩餘") {
+		t.Error("完成後不應再顯示 ETA")
+	}
+}
+
 func TestLiveModel_LogRotation(t *testing.T) {
 	ch := make(chan types.ProgressEvent, 1)
 	_, cancel := context.WithCancel(context.Background())
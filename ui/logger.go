@@ -6,6 +6,7 @@ import "log"
 // Logger 定義日誌輸出介面，支援不同等級的訊息樣式化。
 // 實作此介面可自訂輸出格式（如彩色終端、TUI 等）。
 type Logger interface {
+	Debug(format string, args ...any)
 	Info(format string, args ...any)
 	Success(format string, args ...any)
 	Error(format string, args ...any)
@@ -19,6 +20,9 @@ type NoopLogger struct{}
 // NewNoopLogger 建立靜默 Logger.
 func NewNoopLogger() *NoopLogger { return &NoopLogger{} }
 
+// Debug 靜默丟棄 Debug 訊息.
+func (l *NoopLogger) Debug(_ string, _ ...any) {}
+
 // Info 靜默丟棄 Info 訊息.
 func (l *NoopLogger) Info(_ string, _ ...any) {}
 
@@ -40,6 +44,11 @@ func NewPlainLogger() *PlainLogger {
 	return &PlainLogger{}
 }
 
+// Debug 輸出除錯訊息.
+func (l *PlainLogger) Debug(format string, args ...any) {
+	log.Printf(format, args...)
+}
+
 // Info 輸出一般資訊訊息.
 func (l *PlainLogger) Info(format string, args ...any) {
 	log.Printf(format, args...)
@@ -24,6 +24,7 @@ func TestPlainLogger_Output(t *testing.T) {
 
 	logger := NewPlainLogger()
 
+	logger.Debug("test debug %s", "msg")
 	logger.Info("test info %s", "msg")
 	logger.Success("test success %d", 42)
 	logger.Error("test error")
@@ -35,6 +36,7 @@ func TestPlainLogger_Output(t *testing.T) {
 		name     string
 		expected string
 	}{
+		{"debug message", "test debug msg"},
 		{"info message", "test info msg"},
 		{"success message", "test success 42"},
 		{"error message", "test error"},
@@ -0,0 +1,115 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"time"
+)
+
+// NewJSONHandler 建立輸出 NDJSON 的 slog.Handler，每行一個 JSON 物件，欄位名稱見 fields.go：
+// 時間欄位命名為 ts 並統一為 UTC，level 一律為 DEBUG/INFO/WARN/ERROR
+// （LevelSuccess 等自訂等級歸入較低的標準等級），方便匯入日誌彙整系統.
+func NewJSONHandler(w io.Writer, level slog.Leveler) slog.Handler {
+	return slog.NewJSONHandler(w, &slog.HandlerOptions{
+		Level: level,
+		ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
+			if len(groups) > 0 {
+				return a
+			}
+			switch a.Key {
+			case slog.TimeKey:
+				a.Key = FieldTime
+				if t, ok := a.Value.Any().(time.Time); ok {
+					a.Value = slog.TimeValue(t.UTC())
+				}
+			case slog.LevelKey:
+				if l, ok := a.Value.Any().(slog.Level); ok {
+					a.Value = slog.StringValue(standardLevel(l).String())
+				}
+			}
+			return a
+		},
+	})
+}
+
+// standardLevel 將自訂等級歸入不高於它的標準等級，避免輸出 "INFO+2" 這類日誌系統無法辨識的值
+func standardLevel(l slog.Level) slog.Level {
+	switch {
+	case l >= slog.LevelError:
+		return slog.LevelError
+	case l >= slog.LevelWarn:
+		return slog.LevelWarn
+	case l >= slog.LevelInfo:
+		return slog.LevelInfo
+	default:
+		return slog.LevelDebug
+	}
+}
+
+// SlogLogger 以 log/slog 輸出結構化日誌，等級過濾與輸出格式由 slog.Handler 決定。
+// 適用於需要機器可讀日誌（如搭配 slog.NewJSONHandler）的場景。
+type SlogLogger struct {
+	l *slog.Logger
+}
+
+// NewSlogLogger 建立以 slog 為後端的 Logger，l 為 nil 時使用 slog.Default().
+func NewSlogLogger(l *slog.Logger) *SlogLogger {
+	if l == nil {
+		l = slog.Default()
+	}
+	return &SlogLogger{l: l}
+}
+
+// With 回傳附加固定欄位（如 board）的新 Logger，args 格式同 slog.Logger.With.
+func (l *SlogLogger) With(args ...any) *SlogLogger {
+	return &SlogLogger{l: l.l.With(args...)}
+}
+
+// Debug 輸出 Debug 等級訊息.
+func (l *SlogLogger) Debug(format string, args ...any) {
+	l.log(slog.LevelDebug, format, args)
+}
+
+// Info 輸出 Info 等級訊息.
+func (l *SlogLogger) Info(format string, args ...any) {
+	l.log(slog.LevelInfo, format, args)
+}
+
+// Success 輸出 Info 等級訊息，並附加 success=true 屬性以便與一般資訊區分.
+func (l *SlogLogger) Success(format string, args ...any) {
+	l.log(slog.LevelInfo, format, args, slog.Bool(FieldSuccess, true))
+}
+
+// Error 輸出 Error 等級訊息.
+func (l *SlogLogger) Error(format string, args ...any) {
+	l.log(slog.LevelError, format, args)
+}
+
+// Warn 輸出 Warn 等級訊息.
+func (l *SlogLogger) Warn(format string, args ...any) {
+	l.log(slog.LevelWarn, format, args)
+}
+
+// Event 輸出帶 event 欄位的結構化日誌，LevelSuccess 記為 Info 並附加 success=true.
+func (l *SlogLogger) Event(level slog.Level, event, msg string, attrs ...slog.Attr) {
+	if level == LevelSuccess {
+		level = slog.LevelInfo
+		attrs = append(attrs, slog.Bool(FieldSuccess, true))
+	}
+	ctx := context.Background()
+	if !l.l.Enabled(ctx, level) {
+		return
+	}
+	l.l.LogAttrs(ctx, level, msg, append([]slog.Attr{slog.String(FieldEvent, event)}, attrs...)...)
+}
+
+// log 先檢查等級再格式化訊息，避免被過濾的訊息也付出 Sprintf 成本
+func (l *SlogLogger) log(level slog.Level, format string, args []any, attrs ...slog.Attr) {
+	ctx := context.Background()
+	if !l.l.Enabled(ctx, level) {
+		return
+	}
+	l.l.LogAttrs(ctx, level, fmt.Sprintf(format, args...), attrs...)
+}
@@ -0,0 +1,115 @@
+package ui
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSlogLogger_JSONOutput(t *testing.T) {
+	var buf bytes.Buffer
+	handler := slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelInfo})
+	var logger Logger = NewSlogLogger(slog.New(handler))
+
+	logger.Debug("不應輸出 %d", 1)
+	logger.Info("正在解析文章: %s", "Beauty")
+	logger.Success("下載完成")
+	logger.Warn("警告 %d", 2)
+	logger.Error("錯誤")
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 4 {
+		t.Fatalf("應輸出 4 行（Debug 被過濾），got %d: %q", len(lines), buf.String())
+	}
+
+	want := []struct {
+		level   string
+		msg     string
+		success bool
+	}{
+		{"INFO", "正在解析文章: Beauty", false},
+		{"INFO", "下載完成", true},
+		{"WARN", "警告 2", false},
+		{"ERROR", "錯誤", false},
+	}
+	for i, w := range want {
+		var rec map[string]any
+		if err := json.Unmarshal([]byte(lines[i]), &rec); err != nil {
+			t.Fatalf("第 %d 行不是合法 JSON: %v", i, err)
+		}
+		if rec["level"] != w.level || rec["msg"] != w.msg {
+			t.Errorf("第 %d 行 = %v, want level=%s msg=%s", i, rec, w.level, w.msg)
+		}
+		if _, ok := rec["success"]; ok != w.success {
+			t.Errorf("第 %d 行 success 屬性存在 = %v, want %v", i, ok, w.success)
+		}
+	}
+}
+
+func TestNewSlogLogger_NilUsesDefault(t *testing.T) {
+	if NewSlogLogger(nil).l != slog.Default() {
+		t.Error("傳入 nil 時應使用 slog.Default()")
+	}
+}
+
+// TestNewJSONHandler_Schema 驗證每行 JSON 都有 ts/level/msg，時間為 UTC RFC 3339，
+// level 只會是標準等級名稱，欄位名稱皆為 snake_case
+func TestNewJSONHandler_Schema(t *testing.T) {
+	var buf bytes.Buffer
+	l := slog.New(NewJSONHandler(&buf, slog.Level(-8)))
+	logger := NewSlogLogger(l).With(FieldBoard, "Beauty")
+
+	logger.Info("一般訊息")
+	logger.Success("成功訊息")
+	logger.Event(slog.LevelError, "download_failed", "下載失敗",
+		slog.Int(FieldWorkerID, 3), slog.Any(FieldError, errors.New("connection reset")))
+	l.Log(context.Background(), LevelSuccess, "直接以自訂等級輸出")
+	l.Log(context.Background(), slog.LevelDebug-4, "低於 debug 的等級")
+
+	wantLevels := []string{"INFO", "INFO", "ERROR", "INFO", "DEBUG"}
+	snakeCase := regexp.MustCompile(`^[a-z]+(_[a-z]+)*$`)
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != len(wantLevels) {
+		t.Fatalf("應輸出 %d 行，got %d: %q", len(wantLevels), len(lines), buf.String())
+	}
+	for i, line := range lines {
+		var rec map[string]any
+		if err := json.Unmarshal([]byte(line), &rec); err != nil {
+			t.Fatalf("第 %d 行不是合法 JSON: %v", i, err)
+		}
+		for key := range rec {
+			if !snakeCase.MatchString(key) {
+				t.Errorf("第 %d 行欄位 %q 不是 snake_case", i, key)
+			}
+		}
+		if _, ok := rec[slog.TimeKey]; ok {
+			t.Errorf("第 %d 行不應有 %q 欄位（應改名為 %q）", i, slog.TimeKey, FieldTime)
+		}
+		ts, _ := rec[FieldTime].(string)
+		parsed, err := time.Parse(time.RFC3339Nano, ts)
+		if err != nil || !strings.HasSuffix(ts, "Z") || parsed.IsZero() {
+			t.Errorf("第 %d 行 ts = %q, want UTC RFC 3339 時間 (err=%v)", i, ts, err)
+		}
+		if rec[FieldLevel] != wantLevels[i] {
+			t.Errorf("第 %d 行 level = %v, want %s", i, rec[FieldLevel], wantLevels[i])
+		}
+		if msg, _ := rec[FieldMessage].(string); msg == "" {
+			t.Errorf("第 %d 行缺少 msg", i)
+		}
+		if rec[FieldBoard] != "Beauty" && i < 3 {
+			t.Errorf("第 %d 行 board = %v, want Beauty", i, rec[FieldBoard])
+		}
+	}
+
+	var failed map[string]any
+	_ = json.Unmarshal([]byte(lines[2]), &failed)
+	if failed[FieldError] != "connection reset" || failed[FieldWorkerID] != float64(3) || failed[FieldEvent] != "download_failed" {
+		t.Errorf("事件欄位 = %v, want error 為字串、worker_id=3、event=download_failed", failed)
+	}
+}
@@ -0,0 +1,113 @@
+package ui
+
+import (
+	"fmt"
+	"io"
+	"log/slog"
+	"sync"
+)
+
+// clearLine 將游標移回行首並清除整行（ANSI escape code）
+const clearLine = "\r\033[K"
+
+// StatusBar 在終端機最後一行顯示持續更新的狀態（如 -progress 的進度列）。
+// 日誌須經由 Logger 包裝後輸出：每則訊息先清除狀態列、輸出後再重繪，
+// 狀態列因此固定在所有日誌下方，不會與訊息交錯在同一行。
+// 狀態列不換行，內容超過終端機寬度時會折行而無法完整清除，呼叫端應保持簡短。
+type StatusBar struct {
+	mu   sync.Mutex
+	w    io.Writer
+	line string // 目前顯示的內容，空字串表示未顯示
+}
+
+// NewStatusBar 建立輸出到 w 的狀態列，w 應為日誌所寫入的同一個終端機（os.Stderr）
+func NewStatusBar(w io.Writer) *StatusBar {
+	return &StatusBar{w: w}
+}
+
+// Set 以 line 取代目前的狀態列內容並重繪
+func (b *StatusBar) Set(line string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.clearLocked()
+	b.line = line
+	b.drawLocked()
+}
+
+// Clear 清除狀態列，之後的日誌照常輸出，直到下一次 Set
+func (b *StatusBar) Clear() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.clearLocked()
+	b.line = ""
+}
+
+// Logger 包裝 inner，讓它的每則訊息都不會與狀態列交錯
+func (b *StatusBar) Logger(inner Logger) Logger {
+	return &statusLogger{bar: b, inner: inner}
+}
+
+// around 在清除狀態列後執行 fn（輸出一則日誌），再重繪狀態列
+func (b *StatusBar) around(fn func()) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.clearLocked()
+	fn()
+	b.drawLocked()
+}
+
+func (b *StatusBar) clearLocked() {
+	if b.line != "" {
+		_, _ = fmt.Fprint(b.w, clearLine)
+	}
+}
+
+func (b *StatusBar) drawLocked() {
+	if b.line != "" {
+		_, _ = fmt.Fprint(b.w, b.line)
+	}
+}
+
+// statusLogger 是經由 StatusBar 輸出的 Logger
+type statusLogger struct {
+	bar   *StatusBar
+	inner Logger
+}
+
+// Debug 輸出除錯訊息.
+func (l *statusLogger) Debug(format string, args ...any) {
+	l.bar.around(func() { l.inner.Debug(format, args...) })
+}
+
+// Info 輸出一般資訊訊息.
+func (l *statusLogger) Info(format string, args ...any) {
+	l.bar.around(func() { l.inner.Info(format, args...) })
+}
+
+// Success 輸出成功訊息.
+func (l *statusLogger) Success(format string, args ...any) {
+	l.bar.around(func() { l.inner.Success(format, args...) })
+}
+
+// Error 輸出錯誤訊息.
+func (l *statusLogger) Error(format string, args ...any) {
+	l.bar.around(func() { l.inner.Error(format, args...) })
+}
+
+// Warn 輸出警告訊息.
+func (l *statusLogger) Warn(format string, args ...any) {
+	l.bar.around(func() { l.inner.Warn(format, args...) })
+}
+
+// Event 輸出事件日誌，inner 支援結構化欄位時保留欄位.
+func (l *statusLogger) Event(level slog.Level, event, msg string, attrs ...slog.Attr) {
+	l.bar.around(func() { LogEvent(l.inner, level, event, attrs, "%s", msg) })
+}
+
+// ForBoard 在 inner 支援依看板分檔時，回傳同樣經由狀態列輸出的看板 Logger；否則回傳自己
+func (l *statusLogger) ForBoard(board string) Logger {
+	if r, ok := l.inner.(BoardRouter); ok {
+		return &statusLogger{bar: l.bar, inner: r.ForBoard(board)}
+	}
+	return l
+}
@@ -0,0 +1,64 @@
+package ui
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"testing"
+)
+
+// writerLogger 將訊息逐行寫入與狀態列相同的 writer，模擬輸出到終端機的 Logger
+type writerLogger struct {
+	w *bytes.Buffer
+}
+
+func (l writerLogger) Debug(format string, args ...any)   { fmt.Fprintf(l.w, format+"\n", args...) }
+func (l writerLogger) Info(format string, args ...any)    { fmt.Fprintf(l.w, format+"\n", args...) }
+func (l writerLogger) Success(format string, args ...any) { fmt.Fprintf(l.w, format+"\n", args...) }
+func (l writerLogger) Error(format string, args ...any)   { fmt.Fprintf(l.w, format+"\n", args...) }
+func (l writerLogger) Warn(format string, args ...any)    { fmt.Fprintf(l.w, format+"\n", args...) }
+
+func TestStatusBar_LogsDoNotInterleave(t *testing.T) {
+	var buf bytes.Buffer
+	bar := NewStatusBar(&buf)
+	logger := bar.Logger(writerLogger{&buf})
+
+	logger.Info("尚未顯示狀態列")
+	bar.Set("進度 1")
+	logger.Warn("警告 %d", 1)
+	bar.Set("進度 2")
+	bar.Clear()
+	logger.Info("結束")
+
+	want := "尚未顯示狀態列\n" +
+		"進度 1" +
+		clearLine + "警告 1\n" + "進度 1" + // 訊息前清除狀態列，輸出後重繪
+		clearLine + "進度 2" +
+		clearLine + // Clear 後不再重繪
+		"結束\n"
+	if buf.String() != want {
+		t.Errorf("output = %q, want %q", buf.String(), want)
+	}
+}
+
+func TestStatusBar_ForBoard(t *testing.T) {
+	var buf bytes.Buffer
+	bar := NewStatusBar(&buf)
+
+	// inner 不支援依看板分檔時回傳原 Logger
+	plain := bar.Logger(writerLogger{&buf})
+	if got := plain.(BoardRouter).ForBoard("Beauty"); got != plain {
+		t.Errorf("ForBoard() = %v, want 原 Logger", got)
+	}
+
+	rec := &recordingLogger{calls: map[string]int{}}
+	boards := NewBoardFileLogger(rec, "", 0, func(_ io.Writer) Logger { return NewNoopLogger() })
+	bar.Set("進度")
+	bar.Logger(boards).(BoardRouter).ForBoard("Beauty").Info("看板訊息")
+	if rec.calls["info"] != 1 {
+		t.Errorf("看板 Logger 應寫入原 Logger，calls = %v", rec.calls)
+	}
+	if want := "進度" + clearLine + "進度"; buf.String() != want {
+		t.Errorf("看板 Logger 也應先清除再重繪狀態列，output = %q, want %q", buf.String(), want)
+	}
+}
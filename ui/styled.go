@@ -12,11 +12,13 @@ import (
 var (
 	timeStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("245"))
 
+	debugLabel   = lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("245"))
 	infoLabel    = lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("12"))
 	successLabel = lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("10"))
 	errorLabel   = lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("9"))
 	warnLabel    = lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("11"))
 
+	debugText   = lipgloss.NewStyle().Foreground(lipgloss.Color("245"))
 	infoText    = lipgloss.NewStyle().Foreground(lipgloss.Color("252"))
 	successText = lipgloss.NewStyle().Foreground(lipgloss.Color("10"))
 	errorText   = lipgloss.NewStyle().Foreground(lipgloss.Color("9"))
@@ -35,6 +37,14 @@ func NewStyledLogger() *StyledLogger {
 	return &StyledLogger{}
 }
 
+// Debug 輸出灰色除錯訊息.
+func (l *StyledLogger) Debug(format string, args ...any) {
+	msg := fmt.Sprintf(format, args...)
+	l.mu.Lock()
+	_, _ = lipgloss.Fprintf(os.Stderr, "%s %s  %s\n", timestamp(), debugLabel.Render(" DBG"), debugText.Render(msg))
+	l.mu.Unlock()
+}
+
 // Info 輸出藍色一般資訊訊息.
 func (l *StyledLogger) Info(format string, args ...any) {
 	msg := fmt.Sprintf(format, args...)
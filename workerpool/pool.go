@@ -0,0 +1,253 @@
+// Package workerpool 提供泛型的常駐工人池：工人從佇列領取任務處理，可在執行期增減工人數，
+// 並集中處理 context 取消、停止後拒絕新任務與等待工人結束的流程。
+package workerpool
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/twtrubiks/ptt-spider-go/interfaces"
+)
+
+// ErrStopped 表示工人池已停止，不再接受新任務
+var ErrStopped = errors.New("工人池已停止，不再接受任務")
+
+// Handler 以工人 id（從 1 開始）的身分處理單一任務，回傳 true 表示該工人應結束（如處理中被中斷）
+type Handler[T any] func(ctx context.Context, id int, task T) (stop bool)
+
+// Option 設定工人池的可選行為
+type Option func(*options)
+
+type options struct {
+	idleWarn time.Duration
+	onIdle   func(id int, idle time.Duration, received bool)
+	onStart  func(id int)
+	onExit   func(id int, totalIdle time.Duration, err error)
+}
+
+// WithIdleWarn 在工人等待任務達 d 時呼叫 fn(id, 已等待時間, false)，之後每隔 d 再呼叫一次；
+// 等待達 d 後才領到任務時呼叫 fn(id, 等待時間, true)。d 為 0 時不呼叫。
+func WithIdleWarn(d time.Duration, fn func(id int, idle time.Duration, received bool)) Option {
+	return func(o *options) {
+		o.idleWarn = d
+		o.onIdle = fn
+	}
+}
+
+// WithHooks 設定工人啟動與結束時的回呼。onExit 的 totalIdle 為該工人累計等待任務的時間，
+// err 為結束時的 ctx.Err()：nil 表示正常結束（佇列關閉或工人數縮減）。任一回呼可為 nil。
+func WithHooks(onStart func(id int), onExit func(id int, totalIdle time.Duration, err error)) Option {
+	return func(o *options) {
+		o.onStart = onStart
+		o.onExit = onExit
+	}
+}
+
+// Pool 是 interfaces.WorkerPool 的實作，可供多個 goroutine 並行 Submit。
+type Pool[T any] struct {
+	handler Handler[T]
+	tasks   chan T
+	opts    options
+	wg      sync.WaitGroup
+
+	// Submit 持有讀鎖送出任務，Stop 取得寫鎖後才關閉佇列，避免對已關閉的 channel 送出
+	submitMu sync.RWMutex
+	stopping chan struct{} // Stop 時關閉，讓阻塞中的 Submit 與尚未送出的退場信號返回
+	retire   chan struct{} // 每個值讓一個閒置的工人結束
+
+	mu      sync.Mutex
+	ctx     context.Context
+	size    int  // 目標工人數
+	nextID  int  // 最後一個工人的編號
+	started bool // 已呼叫 Start
+	stopped bool // 已呼叫 Stop，不再增加工人
+}
+
+// 確保 Pool 實作 WorkerPool 介面
+var _ interfaces.WorkerPool[int] = (*Pool[int])(nil)
+
+// New 建立以 queue 為佇列、目標 workers 個工人的工人池，Start 之後才啟動工人。
+// queue 交由工人池管理：呼叫端可直接送入任務（效果同 Submit），但不可自行關閉，改由 Stop 關閉。
+func New[T any](queue chan T, workers int, handler Handler[T], opts ...Option) *Pool[T] {
+	p := &Pool[T]{
+		handler:  handler,
+		tasks:    queue,
+		size:     workers,
+		stopping: make(chan struct{}),
+		retire:   make(chan struct{}),
+	}
+	for _, opt := range opts {
+		opt(&p.opts)
+	}
+	return p
+}
+
+// Start 以 ctx 啟動目標數量的工人，重複呼叫或 Stop 之後呼叫時不作用
+func (p *Pool[T]) Start(ctx context.Context) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.started || p.stopped {
+		return
+	}
+	p.started = true
+	p.ctx = ctx
+	for range p.size {
+		p.spawn()
+	}
+}
+
+// spawn 啟動一個新工人，呼叫方需持有 mu
+func (p *Pool[T]) spawn() {
+	p.nextID++
+	p.wg.Add(1)
+	go p.work(p.ctx, p.nextID)
+}
+
+// Submit 將任務排入佇列，佇列已滿時阻塞直到有空位。
+// ctx 取消時回傳 ctx.Err()；Stop 之後（或阻塞期間被 Stop）回傳 ErrStopped。
+func (p *Pool[T]) Submit(ctx context.Context, task T) error {
+	p.submitMu.RLock()
+	defer p.submitMu.RUnlock()
+
+	select {
+	case <-p.stopping:
+		return ErrStopped
+	default:
+	}
+	select {
+	case <-p.stopping:
+		return ErrStopped
+	case <-ctx.Done():
+		return ctx.Err()
+	case p.tasks <- task:
+		return nil
+	}
+}
+
+// Stop 不再接受新任務並關閉佇列，工人處理完佇列中剩餘的任務後結束（ctx 取消時則提前結束）。
+// 可重複呼叫；之後以 Wait 等待工人結束。
+func (p *Pool[T]) Stop() {
+	p.mu.Lock()
+	if p.stopped {
+		p.mu.Unlock()
+		return
+	}
+	p.stopped = true
+	close(p.stopping)
+	p.mu.Unlock()
+
+	// 等待進行中的 Submit 返回後才關閉佇列
+	p.submitMu.Lock()
+	close(p.tasks)
+	p.submitMu.Unlock()
+}
+
+// Stopping 回傳 Stop 時關閉的 channel，供監看工人池的 goroutine（如依佇列深度調整工人數）結束
+func (p *Pool[T]) Stopping() <-chan struct{} {
+	return p.stopping
+}
+
+// Wait 阻塞直到所有工人結束
+func (p *Pool[T]) Wait() {
+	p.wg.Wait()
+}
+
+// Size 回傳目前的目標工人數
+func (p *Pool[T]) Size() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.size
+}
+
+// Resize 將目標工人數調整為 n，回傳調整前的目標數。
+// 增加時直接啟動新工人（編號接續）；減少時送出退場信號，由閒置的工人在領取下一個任務前結束，
+// 進行中的任務不受影響。Stop 之後或 ctx 已取消時不作用。
+func (p *Pool[T]) Resize(n int) int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	from := p.size
+	if p.stopped || (p.started && p.ctx.Err() != nil) {
+		return from
+	}
+	if !p.started {
+		p.size = max(n, 0)
+		return from
+	}
+	for ; p.size < n; p.size++ {
+		p.spawn()
+	}
+	if k := p.size - n; k > 0 {
+		p.size = n
+		// 在另一個 goroutine 送出，不阻塞呼叫端：工人都在忙時由之後空閒的工人領取
+		ctx := p.ctx
+		go func() {
+			for range k {
+				select {
+				case p.retire <- struct{}{}:
+				case <-ctx.Done():
+					return
+				case <-p.stopping:
+					return
+				}
+			}
+		}()
+	}
+	return from
+}
+
+// work 是單一工人的主迴圈
+func (p *Pool[T]) work(ctx context.Context, id int) {
+	defer p.wg.Done()
+	if p.opts.onStart != nil {
+		p.opts.onStart(id)
+	}
+
+	var totalIdle time.Duration
+	for {
+		task, idle, ok := p.next(ctx, id)
+		totalIdle += idle
+		if !ok || p.handler(ctx, id, task) {
+			if p.opts.onExit != nil {
+				p.opts.onExit(id, totalIdle, ctx.Err())
+			}
+			return
+		}
+	}
+}
+
+// next 從佇列領取下一個任務，回傳本次等待（閒置）的時間。
+// ctx 取消、佇列關閉或收到退場信號時 ok 為 false。
+func (p *Pool[T]) next(ctx context.Context, id int) (task T, idle time.Duration, ok bool) {
+	// 已取消時優先結束，避免 select 隨機選到佇列中剩餘的任務
+	if ctx.Err() != nil {
+		return task, 0, false
+	}
+	start := time.Now()
+
+	var warnC <-chan time.Time
+	if p.opts.idleWarn > 0 && p.opts.onIdle != nil {
+		ticker := time.NewTicker(p.opts.idleWarn)
+		defer ticker.Stop()
+		warnC = ticker.C
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return task, time.Since(start), false
+		case <-p.retire:
+			return task, time.Since(start), false
+		case <-warnC:
+			p.opts.onIdle(id, time.Since(start), false)
+		case task, ok = <-p.tasks:
+			idle = time.Since(start)
+			if ok && warnC != nil && idle >= p.opts.idleWarn {
+				p.opts.onIdle(id, idle, true)
+			}
+			return task, idle, ok
+		}
+	}
+}
@@ -0,0 +1,234 @@
+package workerpool
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// waitFor 在 1 秒內輪詢 cond，逾時則失敗
+func waitFor(t *testing.T, cond func() bool, msg string) {
+	t.Helper()
+	deadline := time.Now().Add(time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatal(msg)
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestPool_Concurrency(t *testing.T) {
+	const workers, tasks = 3, 12
+	var running, peak, done atomic.Int32
+	handler := func(_ context.Context, _ int, _ int) bool {
+		n := running.Add(1)
+		for {
+			p := peak.Load()
+			if n <= p || peak.CompareAndSwap(p, n) {
+				break
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+		running.Add(-1)
+		done.Add(1)
+		return false
+	}
+
+	p := New(make(chan int), workers, handler)
+	p.Start(context.Background())
+	for i := range tasks {
+		if err := p.Submit(context.Background(), i); err != nil {
+			t.Fatalf("Submit(%d) error = %v", i, err)
+		}
+	}
+	p.Stop()
+	p.Wait()
+
+	if got := done.Load(); got != tasks {
+		t.Errorf("完成任務數 = %d, want %d", got, tasks)
+	}
+	if got := peak.Load(); got != workers {
+		t.Errorf("同時執行的任務數上限 = %d, want %d", got, workers)
+	}
+}
+
+func TestPool_StopDrainsQueue(t *testing.T) {
+	gate := make(chan struct{})
+	var done atomic.Int32
+	p := New(make(chan int, 5), 1, func(context.Context, int, int) bool {
+		<-gate
+		done.Add(1)
+		return false
+	})
+	p.Start(context.Background())
+	for i := range 5 {
+		if err := p.Submit(context.Background(), i); err != nil {
+			t.Fatalf("Submit(%d) error = %v", i, err)
+		}
+	}
+
+	// 工人仍卡在第一個任務時停止，佇列中剩餘的任務仍應處理完
+	p.Stop()
+	close(gate)
+	p.Wait()
+	if got := done.Load(); got != 5 {
+		t.Errorf("完成任務數 = %d, want 5", got)
+	}
+}
+
+func TestPool_SubmitAfterStop(t *testing.T) {
+	p := New(make(chan int), 2, func(context.Context, int, int) bool { return false })
+	p.Start(context.Background())
+	p.Stop()
+	p.Stop() // 重複呼叫不應 panic
+	p.Wait()
+
+	if err := p.Submit(context.Background(), 1); !errors.Is(err, ErrStopped) {
+		t.Errorf("Stop 之後 Submit() error = %v, want ErrStopped", err)
+	}
+}
+
+func TestPool_SubmitBlockedUntilStop(t *testing.T) {
+	// 沒有啟動工人，Submit 會阻塞在無緩衝佇列上
+	p := New(make(chan int), 1, func(context.Context, int, int) bool { return false })
+
+	errc := make(chan error, 1)
+	go func() { errc <- p.Submit(context.Background(), 1) }()
+	time.Sleep(20 * time.Millisecond)
+	p.Stop()
+
+	select {
+	case err := <-errc:
+		if !errors.Is(err, ErrStopped) {
+			t.Errorf("阻塞中的 Submit() error = %v, want ErrStopped", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Stop 後阻塞中的 Submit 應返回")
+	}
+}
+
+func TestPool_SubmitContextCanceled(t *testing.T) {
+	p := New(make(chan int), 1, func(context.Context, int, int) bool { return false })
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	if err := p.Submit(ctx, 1); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Submit() error = %v, want context.DeadlineExceeded", err)
+	}
+}
+
+func TestPool_CancelStopsWorkers(t *testing.T) {
+	var mu sync.Mutex
+	var exitErrs []error
+	p := New(make(chan int), 3, func(context.Context, int, int) bool { return false },
+		WithHooks(nil, func(_ int, _ time.Duration, err error) {
+			mu.Lock()
+			defer mu.Unlock()
+			exitErrs = append(exitErrs, err)
+		}))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	p.Start(ctx)
+	cancel()
+	p.Wait()
+
+	if len(exitErrs) != 3 {
+		t.Fatalf("結束的工人數 = %d, want 3", len(exitErrs))
+	}
+	for _, err := range exitErrs {
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("onExit err = %v, want context.Canceled", err)
+		}
+	}
+}
+
+func TestPool_HandlerStop(t *testing.T) {
+	var calls atomic.Int32
+	p := New(make(chan int, 2), 1, func(context.Context, int, int) bool {
+		calls.Add(1)
+		return true
+	})
+	p.Start(context.Background())
+	_ = p.Submit(context.Background(), 1)
+	_ = p.Submit(context.Background(), 2)
+	p.Wait()
+
+	if got := calls.Load(); got != 1 {
+		t.Errorf("handler 回傳 true 後工人應結束, 處理了 %d 個任務", got)
+	}
+	p.Stop()
+}
+
+func TestPool_Resize(t *testing.T) {
+	var started, exited atomic.Int32
+	var maxID atomic.Int32
+	p := New(make(chan int), 2, func(context.Context, int, int) bool { return false },
+		WithHooks(func(id int) {
+			started.Add(1)
+			maxID.Store(max(maxID.Load(), int32(id)))
+		}, func(int, time.Duration, error) { exited.Add(1) }))
+
+	if from := p.Resize(3); from != 2 || p.Size() != 3 {
+		t.Errorf("Start 前 Resize(3) = %d, Size() = %d, want 2, 3", from, p.Size())
+	}
+	p.Start(context.Background())
+	waitFor(t, func() bool { return started.Load() == 3 }, "Start 後應啟動 3 個工人")
+
+	if from := p.Resize(5); from != 3 {
+		t.Errorf("Resize(5) = %d, want 3", from)
+	}
+	waitFor(t, func() bool { return started.Load() == 5 }, "擴充後應共有 5 個工人啟動")
+	if maxID.Load() != 5 {
+		t.Errorf("新工人編號應接續為 4~5, 最大編號 = %d", maxID.Load())
+	}
+
+	p.Resize(1)
+	waitFor(t, func() bool { return exited.Load() == 4 }, "縮減後應有 4 個工人結束")
+
+	p.Stop()
+	if p.Resize(10); started.Load() != 5 {
+		t.Error("Stop 之後不應再啟動新工人")
+	}
+	p.Wait()
+	if got := exited.Load(); got != 5 {
+		t.Errorf("全部結束的工人數 = %d, want 5", got)
+	}
+}
+
+func TestPool_IdleWarn(t *testing.T) {
+	type idleEvent struct {
+		received bool
+		idle     time.Duration
+	}
+	var mu sync.Mutex
+	var events []idleEvent
+	p := New(make(chan int), 1, func(context.Context, int, int) bool { return false },
+		WithIdleWarn(10*time.Millisecond, func(_ int, idle time.Duration, received bool) {
+			mu.Lock()
+			defer mu.Unlock()
+			events = append(events, idleEvent{received, idle})
+		}))
+	p.Start(context.Background())
+
+	time.Sleep(50 * time.Millisecond)
+	if err := p.Submit(context.Background(), 1); err != nil {
+		t.Fatalf("Submit() error = %v", err)
+	}
+	p.Stop()
+	p.Wait()
+
+	mu.Lock()
+	defer mu.Unlock()
+	var warned, received bool
+	for _, e := range events {
+		warned = warned || !e.received
+		received = received || (e.received && e.idle >= 50*time.Millisecond)
+	}
+	if !warned || !received {
+		t.Errorf("idle events = %+v, want 閒置提示與領到任務記錄", events)
+	}
+}